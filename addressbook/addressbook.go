@@ -0,0 +1,309 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package addressbook
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	fileMagic = "odyssey-addressbook-v1"
+	saltLen   = 16
+	keyLen    = 32
+
+	// scrypt parameters recommended for interactive logins
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+var (
+	ErrEmptyLabel       = errors.New("address book label cannot be empty")
+	ErrEmptyEntry       = errors.New("address book entry has no address nor node ID")
+	ErrLabelNotFound    = errors.New("label not found in address book")
+	ErrWrongPassphrase  = errors.New("wrong passphrase or corrupted address book file")
+	ErrInvalidBookFile  = errors.New("invalid address book file")
+	ErrEmptyPassphrase  = errors.New("address book passphrase cannot be empty")
+	ErrNoAddressInEntry = errors.New("address book entry has no address for the requested chain")
+)
+
+// Entry maps a human label to the addresses and node ID of a key or contact
+type Entry struct {
+	// Label is the unique human readable name of the entry, e.g. "alice" or "treasury"
+	Label string `json:"label"`
+
+	// OChainAddress is the O-Chain bech32 address, e.g. O-testnet1...
+	OChainAddress string `json:"oChainAddress,omitempty"`
+
+	// AChainAddress is the A-Chain bech32 address, e.g. A-testnet1...
+	AChainAddress string `json:"aChainAddress,omitempty"`
+
+	// DChainAddress is the D-Chain (EVM) hex address, e.g. 0x...
+	DChainAddress string `json:"dChainAddress,omitempty"`
+
+	// NodeID is the node ID of the entry, if it is a node, e.g. NodeID-...
+	NodeID string `json:"nodeID,omitempty"`
+}
+
+// Validate checks that the label is set and that all the addresses of the entry are well formed
+func (e Entry) Validate() error {
+	if strings.TrimSpace(e.Label) == "" {
+		return ErrEmptyLabel
+	}
+	if e.OChainAddress == "" && e.AChainAddress == "" && e.DChainAddress == "" && e.NodeID == "" {
+		return fmt.Errorf("%w: %s", ErrEmptyEntry, e.Label)
+	}
+	for chain, addr := range map[string]string{"O": e.OChainAddress, "A": e.AChainAddress} {
+		if addr == "" {
+			continue
+		}
+		addrChain, _, _, err := odyssey.ParseAddress(addr)
+		if err != nil {
+			return fmt.Errorf("invalid %s-Chain address %s for %s: %w", chain, addr, e.Label, err)
+		}
+		if addrChain != chain {
+			return fmt.Errorf("invalid %s-Chain address %s for %s: unexpected chain %s", chain, addr, e.Label, addrChain)
+		}
+	}
+	if e.DChainAddress != "" && !common.IsHexAddress(e.DChainAddress) {
+		return fmt.Errorf("invalid D-Chain address %s for %s", e.DChainAddress, e.Label)
+	}
+	if e.NodeID != "" {
+		if _, err := ids.NodeIDFromString(e.NodeID); err != nil {
+			return fmt.Errorf("invalid node ID %s for %s: %w", e.NodeID, e.Label, err)
+		}
+	}
+	return nil
+}
+
+// AddressBook is a registry of labeled addresses and node IDs.
+// The Resolve methods accept either a label of the book or the address itself, and a nil book
+// resolves the addresses only. Wallet.TransferTo, Subnet.SetSubnetControlParamsFromBook and
+// Subnet.SetSubnetAuthKeysFromBook take the transfer destination, control keys and subnet
+// auth keys as labels or addresses with them.
+type AddressBook struct {
+	lock    sync.RWMutex
+	entries map[string]Entry
+}
+
+// New creates an empty address book
+func New() *AddressBook {
+	return &AddressBook{entries: map[string]Entry{}}
+}
+
+// Load reads an address book saved with Save, decrypting it with passphrase
+func Load(path string, passphrase []byte) (*AddressBook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := decrypt(data, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	entries := []Entry{}
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBookFile, err)
+	}
+	book := New()
+	for _, entry := range entries {
+		if err := book.Add(entry); err != nil {
+			return nil, err
+		}
+	}
+	return book, nil
+}
+
+// Save writes the address book to path, encrypted with passphrase.
+// The file is only readable by the current user.
+func (b *AddressBook) Save(path string, passphrase []byte) error {
+	plaintext, err := json.Marshal(b.Entries())
+	if err != nil {
+		return err
+	}
+	data, err := encrypt(plaintext, passphrase)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, constants.WriteReadUserOnlyPerms)
+}
+
+// Add adds entry to the book, replacing any entry with the same label
+func (b *AddressBook) Add(entry Entry) error {
+	entry.Label = strings.TrimSpace(entry.Label)
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.entries[entry.Label] = entry
+	return nil
+}
+
+// Remove removes the entry with the given label, if any
+func (b *AddressBook) Remove(label string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.entries, label)
+}
+
+// Get returns the entry with the given label. A nil book has no entries
+func (b *AddressBook) Get(label string) (Entry, bool) {
+	if b == nil {
+		return Entry{}, false
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	entry, ok := b.entries[label]
+	return entry, ok
+}
+
+// Entries returns all the entries of the book, sorted by label
+func (b *AddressBook) Entries() []Entry {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	entries := make([]Entry, 0, len(b.entries))
+	for _, entry := range b.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Label < entries[j].Label })
+	return entries
+}
+
+// ResolveShortID returns the address ID of labelOrAddress, which is either a label of the book
+// or an O-Chain / A-Chain address. For labels, the O-Chain address is preferred.
+// The result can be used for transfer destinations, subnet control keys and subnet auth keys.
+func (b *AddressBook) ResolveShortID(labelOrAddress string) (ids.ShortID, error) {
+	if entry, ok := b.Get(labelOrAddress); ok {
+		switch {
+		case entry.OChainAddress != "":
+			labelOrAddress = entry.OChainAddress
+		case entry.AChainAddress != "":
+			labelOrAddress = entry.AChainAddress
+		default:
+			return ids.ShortEmpty, fmt.Errorf("%w: %s has no O-Chain or A-Chain address", ErrNoAddressInEntry, labelOrAddress)
+		}
+	}
+	_, _, addrID, err := odyssey.ParseAddress(labelOrAddress)
+	if err != nil {
+		return ids.ShortEmpty, fmt.Errorf("%s is neither a known label nor a valid address: %w", labelOrAddress, err)
+	}
+	return addrID, nil
+}
+
+// ResolveShortIDs resolves each of labelsOrAddresses with ResolveShortID, e.g. to build
+// the owner list of a multisig
+func (b *AddressBook) ResolveShortIDs(labelsOrAddresses []string) ([]ids.ShortID, error) {
+	addrIDs := make([]ids.ShortID, 0, len(labelsOrAddresses))
+	for _, labelOrAddress := range labelsOrAddresses {
+		addrID, err := b.ResolveShortID(labelOrAddress)
+		if err != nil {
+			return nil, err
+		}
+		addrIDs = append(addrIDs, addrID)
+	}
+	return addrIDs, nil
+}
+
+// ResolveNodeID returns the node ID of labelOrNodeID, which is either a label of the book
+// or a node ID
+func (b *AddressBook) ResolveNodeID(labelOrNodeID string) (ids.NodeID, error) {
+	if entry, ok := b.Get(labelOrNodeID); ok {
+		if entry.NodeID == "" {
+			return ids.EmptyNodeID, fmt.Errorf("%w: %s has no node ID", ErrNoAddressInEntry, labelOrNodeID)
+		}
+		labelOrNodeID = entry.NodeID
+	}
+	nodeID, err := ids.NodeIDFromString(labelOrNodeID)
+	if err != nil {
+		return ids.EmptyNodeID, fmt.Errorf("%s is neither a known label nor a valid node ID: %w", labelOrNodeID, err)
+	}
+	return nodeID, nil
+}
+
+// ResolveDChainAddress returns the D-Chain address of labelOrAddress, which is either a label
+// of the book or a hex address
+func (b *AddressBook) ResolveDChainAddress(labelOrAddress string) (common.Address, error) {
+	if entry, ok := b.Get(labelOrAddress); ok {
+		if entry.DChainAddress == "" {
+			return common.Address{}, fmt.Errorf("%w: %s has no D-Chain address", ErrNoAddressInEntry, labelOrAddress)
+		}
+		labelOrAddress = entry.DChainAddress
+	}
+	if !common.IsHexAddress(labelOrAddress) {
+		return common.Address{}, fmt.Errorf("%s is neither a known label nor a valid D-Chain address", labelOrAddress)
+	}
+	return common.HexToAddress(labelOrAddress), nil
+}
+
+// encrypt seals plaintext with AES-256-GCM, using a key derived from passphrase with scrypt.
+// The output is fileMagic | salt | nonce | ciphertext
+func encrypt(plaintext []byte, passphrase []byte) ([]byte, error) {
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	data := append([]byte(fileMagic), salt...)
+	data = append(data, nonce...)
+	return aead.Seal(data, nonce, plaintext, []byte(fileMagic)), nil
+}
+
+func decrypt(data []byte, passphrase []byte) ([]byte, error) {
+	if !strings.HasPrefix(string(data), fileMagic) || len(data) < len(fileMagic)+saltLen {
+		return nil, ErrInvalidBookFile
+	}
+	data = data[len(fileMagic):]
+	aead, err := newAEAD(passphrase, data[:saltLen])
+	if err != nil {
+		return nil, err
+	}
+	data = data[saltLen:]
+	if len(data) < aead.NonceSize() {
+		return nil, ErrInvalidBookFile
+	}
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(fileMagic))
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
+
+func newAEAD(passphrase []byte, salt []byte) (cipher.AEAD, error) {
+	if len(passphrase) == 0 {
+		return nil, ErrEmptyPassphrase
+	}
+	key, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, keyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
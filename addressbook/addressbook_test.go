@@ -0,0 +1,133 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package addressbook
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/formatting/address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAddress(t *testing.T, chain string, addrID ids.ShortID) string {
+	addr, err := address.Format(chain, "testnet", addrID[:])
+	require.NoError(t, err)
+	return addr
+}
+
+func TestEntry_Validate(t *testing.T) {
+	addrID := ids.GenerateTestShortID()
+	tests := []struct {
+		name        string
+		entry       Entry
+		expectError bool
+	}{
+		{
+			name: "valid entry",
+			entry: Entry{
+				Label:         "alice",
+				OChainAddress: testAddress(t, "O", addrID),
+				AChainAddress: testAddress(t, "A", addrID),
+				DChainAddress: "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC",
+				NodeID:        ids.GenerateTestNodeID().String(),
+			},
+		},
+		{name: "empty label", entry: Entry{OChainAddress: testAddress(t, "O", addrID)}, expectError: true},
+		{name: "no address", entry: Entry{Label: "alice"}, expectError: true},
+		{name: "invalid O-Chain address", entry: Entry{Label: "alice", OChainAddress: "O-invalid"}, expectError: true},
+		{name: "A-Chain address as O-Chain address", entry: Entry{Label: "alice", OChainAddress: testAddress(t, "A", addrID)}, expectError: true},
+		{name: "invalid D-Chain address", entry: Entry{Label: "alice", DChainAddress: "0x1234"}, expectError: true},
+		{name: "invalid node ID", entry: Entry{Label: "alice", NodeID: "NodeID-invalid"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.entry.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAddressBook_Resolve(t *testing.T) {
+	aliceID := ids.GenerateTestShortID()
+	bobID := ids.GenerateTestShortID()
+	nodeID := ids.GenerateTestNodeID()
+	book := New()
+	require.NoError(t, book.Add(Entry{Label: "alice", OChainAddress: testAddress(t, "O", aliceID), NodeID: nodeID.String()}))
+	require.NoError(t, book.Add(Entry{Label: " bob ", AChainAddress: testAddress(t, "A", bobID)}))
+	require.NoError(t, book.Add(Entry{Label: "evm", DChainAddress: "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"}))
+
+	addrIDs, err := book.ResolveShortIDs([]string{"alice", "bob", testAddress(t, "O", bobID)})
+	require.NoError(t, err)
+	assert.Equal(t, []ids.ShortID{aliceID, bobID, bobID}, addrIDs)
+
+	_, err = book.ResolveShortID("carol")
+	assert.Error(t, err)
+	_, err = book.ResolveShortID("evm")
+	assert.ErrorIs(t, err, ErrNoAddressInEntry)
+
+	resolvedNodeID, err := book.ResolveNodeID("alice")
+	require.NoError(t, err)
+	assert.Equal(t, nodeID, resolvedNodeID)
+	resolvedNodeID, err = book.ResolveNodeID(nodeID.String())
+	require.NoError(t, err)
+	assert.Equal(t, nodeID, resolvedNodeID)
+	_, err = book.ResolveNodeID("bob")
+	assert.ErrorIs(t, err, ErrNoAddressInEntry)
+
+	evmAddr, err := book.ResolveDChainAddress("evm")
+	require.NoError(t, err)
+	assert.Equal(t, "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC", evmAddr.Hex())
+	_, err = book.ResolveDChainAddress("alice")
+	assert.ErrorIs(t, err, ErrNoAddressInEntry)
+
+	book.Remove("alice")
+	_, ok := book.Get("alice")
+	assert.False(t, ok)
+	assert.Len(t, book.Entries(), 2)
+
+	// a nil book only resolves addresses
+	var noBook *AddressBook
+	addrID, err := noBook.ResolveShortID(testAddress(t, "O", aliceID))
+	require.NoError(t, err)
+	assert.Equal(t, aliceID, addrID)
+	_, err = noBook.ResolveShortID("alice")
+	assert.ErrorContains(t, err, "neither a known label nor a valid address")
+}
+
+func TestAddressBook_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "addressbook")
+	book := New()
+	require.NoError(t, book.Add(Entry{Label: "bob", OChainAddress: testAddress(t, "O", ids.GenerateTestShortID())}))
+	require.NoError(t, book.Add(Entry{Label: "alice", NodeID: ids.GenerateTestNodeID().String()}))
+	require.NoError(t, book.Save(path, []byte("secret")))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "alice")
+
+	loaded, err := Load(path, []byte("secret"))
+	require.NoError(t, err)
+	assert.Equal(t, book.Entries(), loaded.Entries())
+	assert.Equal(t, "alice", loaded.Entries()[0].Label)
+
+	_, err = Load(path, []byte("wrong"))
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+	assert.ErrorIs(t, book.Save(path, nil), ErrEmptyPassphrase)
+
+	require.NoError(t, os.WriteFile(path, []byte("not an address book"), 0o600))
+	_, err = Load(path, []byte("secret"))
+	assert.ErrorIs(t, err, ErrInvalidBookFile)
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package amounts
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DioneProtocol/odysseygo/utils/math"
+	"github.com/DioneProtocol/odysseygo/utils/units"
+)
+
+// The units of DIONE. Amounts of other units are converted with Amount.Mul, e.g.
+// DIONE.Mul(2000) for 2000 DIONE
+const (
+	NanoDIONE  = Amount(units.NanoDione)
+	MicroDIONE = Amount(units.MicroDione)
+	MilliDIONE = Amount(units.MilliDione)
+	DIONE      = Amount(units.Dione)
+)
+
+var (
+	ErrInvalidAmount = errors.New("invalid amount")
+	ErrUnknownUnit   = errors.New("unknown DIONE unit")
+	ErrTooPrecise    = errors.New("amount is more precise than 1 nDIONE")
+	ErrOverflow      = errors.New("amount overflows")
+	ErrUnderflow     = errors.New("amount underflows")
+)
+
+// unitSymbols are the symbols of the units parsed by Parse and printed by Format
+var unitSymbols = []struct {
+	symbol string
+	unit   Amount
+}{
+	{"nDIONE", NanoDIONE},
+	{"uDIONE", MicroDIONE},
+	{"μDIONE", MicroDIONE},
+	{"mDIONE", MilliDIONE},
+	{"DIONE", DIONE},
+}
+
+// Amount is an amount of DIONE, in nDIONE. The stake amounts, balances and fees of the
+// SDK and odysseygo APIs are uint64 amounts of nDIONE, converted with uint64(a) and Amount(n)
+type Amount uint64
+
+// Parse parses a human amount of DIONE such as "2.5 DIONE", "2500mDIONE" or "1 nDIONE".
+// The unit is required, as a bare number is as likely to be meant in DIONE as in nDIONE
+func Parse(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	for _, u := range unitSymbols {
+		if number, ok := strings.CutSuffix(s, u.symbol); ok {
+			amount, err := parseDecimal(strings.TrimSpace(number), u.unit)
+			if err != nil {
+				return 0, fmt.Errorf("%w %q: %w", ErrInvalidAmount, s, err)
+			}
+			return amount, nil
+		}
+	}
+	return 0, fmt.Errorf("%w %q: %w, expected one of nDIONE, uDIONE, mDIONE or DIONE", ErrInvalidAmount, s, ErrUnknownUnit)
+}
+
+// MustParse is Parse panicking on invalid amounts, e.g. for constants
+func MustParse(s string) Amount {
+	amount, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return amount
+}
+
+// parseDecimal parses number, a decimal number of unit
+func parseDecimal(number string, unit Amount) (Amount, error) {
+	whole, fraction, hasFraction := strings.Cut(number, ".")
+	if !isDigits(whole+fraction) || hasFraction && fraction == "" {
+		return 0, fmt.Errorf("%q is not a positive decimal number", number)
+	}
+	amount := Amount(0)
+	if whole != "" {
+		n, err := strconv.ParseUint(whole, 10, 64)
+		if err != nil {
+			return 0, ErrOverflow
+		}
+		if amount, err = unit.Mul(n); err != nil {
+			return 0, err
+		}
+	}
+	// the fraction is an amount of nDIONE once padded to the digits of unit
+	fraction = strings.TrimRight(fraction, "0")
+	digits := unitDigits(unit)
+	if len(fraction) > digits {
+		return 0, ErrTooPrecise
+	}
+	if fraction == "" {
+		return amount, nil
+	}
+	n, err := strconv.ParseUint(fraction+strings.Repeat("0", digits-len(fraction)), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return amount.Add(Amount(n))
+}
+
+// isDigits returns true if s is made of decimal digits only, and is not empty
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// Add returns a + b, failing with ErrOverflow if it does not fit in an Amount
+func (a Amount) Add(b Amount) (Amount, error) {
+	sum, err := math.Add64(uint64(a), uint64(b))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s + %s", ErrOverflow, a, b)
+	}
+	return Amount(sum), nil
+}
+
+// Sub returns a - b, failing with ErrUnderflow if b is greater than a
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if b > a {
+		return 0, fmt.Errorf("%w: %s - %s", ErrUnderflow, a, b)
+	}
+	return a - b, nil
+}
+
+// Mul returns a * n, failing with ErrOverflow if it does not fit in an Amount
+func (a Amount) Mul(n uint64) (Amount, error) {
+	product, err := math.Mul64(uint64(a), n)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s * %d", ErrOverflow, a, n)
+	}
+	return Amount(product), nil
+}
+
+// Sum returns the sum of amounts, failing with ErrOverflow if it does not fit in an Amount
+func Sum(amounts ...Amount) (Amount, error) {
+	sum := Amount(0)
+	for _, amount := range amounts {
+		var err error
+		if sum, err = sum.Add(amount); err != nil {
+			return 0, err
+		}
+	}
+	return sum, nil
+}
+
+// String formats a in DIONE, e.g. 2.5 DIONE
+func (a Amount) String() string {
+	return a.Format(DIONE)
+}
+
+// Format formats a in unit, one of NanoDIONE, MicroDIONE, MilliDIONE or DIONE, without
+// trailing zeros, e.g. 2500 mDIONE. Other units format a in nDIONE
+func (a Amount) Format(unit Amount) string {
+	symbol := ""
+	for _, u := range unitSymbols {
+		if u.unit == unit {
+			symbol = u.symbol
+			break
+		}
+	}
+	if symbol == "" {
+		unit, symbol = NanoDIONE, "nDIONE"
+	}
+	number := strconv.FormatUint(uint64(a/unit), 10)
+	if remainder := a % unit; remainder != 0 {
+		fraction := fmt.Sprintf("%0*d", unitDigits(unit), uint64(remainder))
+		number += "." + strings.TrimRight(fraction, "0")
+	}
+	return number + " " + symbol
+}
+
+// unitDigits returns the number of decimal digits of the fraction of an amount of unit, a
+// power of 10
+func unitDigits(unit Amount) int {
+	return len(strconv.FormatUint(uint64(unit), 10)) - 1
+}
+
+// MarshalText formats a as String does, so that amounts read well in config files
+func (a Amount) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText parses text as Parse does
+func (a *Amount) UnmarshalText(text []byte) error {
+	amount, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package amounts
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Amount
+		wantErr  error
+	}{
+		{input: "2.5 DIONE", expected: 2_500_000_000},
+		{input: "2000DIONE", expected: 2000 * DIONE},
+		{input: " .5 DIONE ", expected: 500 * MilliDIONE},
+		{input: "1.000 DIONE", expected: DIONE},
+		{input: "0.000000001 DIONE", expected: NanoDIONE},
+		{input: "2500 mDIONE", expected: 2_500_000_000},
+		{input: "1.5 uDIONE", expected: 1500},
+		{input: "1 μDIONE", expected: MicroDIONE},
+		{input: "42 nDIONE", expected: 42},
+		{input: "2.5", wantErr: ErrUnknownUnit},
+		{input: "2.5 dione", wantErr: ErrUnknownUnit},
+		{input: "0.0000000001 DIONE", wantErr: ErrTooPrecise},
+		{input: "1.5 nDIONE", wantErr: ErrTooPrecise},
+		{input: "20000000000 DIONE", wantErr: ErrOverflow},
+		{input: "99999999999999999999 nDIONE", wantErr: ErrOverflow},
+		{input: "-1 DIONE", wantErr: ErrInvalidAmount},
+		{input: "1. DIONE", wantErr: ErrInvalidAmount},
+		{input: "1.2.3 DIONE", wantErr: ErrInvalidAmount},
+		{input: " DIONE", wantErr: ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			amount, err := Parse(tt.input)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, amount)
+		})
+	}
+	assert.Panics(t, func() { MustParse("2.5") })
+}
+
+func TestAmount_Format(t *testing.T) {
+	assert.Equal(t, "2.5 DIONE", Amount(2_500_000_000).String())
+	assert.Equal(t, "0 DIONE", Amount(0).String())
+	assert.Equal(t, "0.000000001 DIONE", NanoDIONE.String())
+	assert.Equal(t, "2500 mDIONE", Amount(2_500_000_000).Format(MilliDIONE))
+	assert.Equal(t, "1.5 uDIONE", Amount(1500).Format(MicroDIONE))
+	assert.Equal(t, "1500 nDIONE", Amount(1500).Format(Amount(7)))
+	for _, amount := range []Amount{1, 999, DIONE, 123_456_789_012, math.MaxUint64} {
+		parsed, err := Parse(amount.String())
+		require.NoError(t, err)
+		assert.Equal(t, amount, parsed)
+	}
+}
+
+func TestAmount_Arithmetic(t *testing.T) {
+	sum, err := DIONE.Add(500 * MilliDIONE)
+	require.NoError(t, err)
+	assert.Equal(t, MustParse("1.5 DIONE"), sum)
+	_, err = Amount(math.MaxUint64).Add(1)
+	require.ErrorIs(t, err, ErrOverflow)
+
+	diff, err := DIONE.Sub(MilliDIONE)
+	require.NoError(t, err)
+	assert.Equal(t, 999*MilliDIONE, diff)
+	_, err = MilliDIONE.Sub(DIONE)
+	require.ErrorIs(t, err, ErrUnderflow)
+
+	product, err := DIONE.Mul(2000)
+	require.NoError(t, err)
+	assert.Equal(t, MustParse("2000 DIONE"), product)
+	_, err = DIONE.Mul(math.MaxUint64)
+	require.ErrorIs(t, err, ErrOverflow)
+
+	total, err := Sum(DIONE, DIONE, MilliDIONE)
+	require.NoError(t, err)
+	assert.Equal(t, "2.001 DIONE", total.String())
+	_, err = Sum(math.MaxUint64, 1)
+	require.ErrorIs(t, err, ErrOverflow)
+}
+
+func TestAmount_JSON(t *testing.T) {
+	type config struct {
+		Stake Amount `json:"stake"`
+	}
+	bytes, err := json.Marshal(config{Stake: 2000 * DIONE})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"stake": "2000 DIONE"}`, string(bytes))
+
+	var c config
+	require.NoError(t, json.Unmarshal([]byte(`{"stake": "2.5 DIONE"}`), &c))
+	assert.Equal(t, Amount(2_500_000_000), c.Stake)
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"stake": "2.5"}`), &c), ErrUnknownUnit)
+}
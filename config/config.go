@@ -0,0 +1,249 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigFileEnvVar overrides the location of the SDK config file
+	ConfigFileEnvVar = "ODYSSEY_SDK_CONFIG"
+	// NetworkEnvVar overrides the default network of the SDK config file
+	NetworkEnvVar = "ODYSSEY_SDK_NETWORK"
+	// KeyPathEnvVar overrides the default key path of the SDK config file
+	KeyPathEnvVar = "ODYSSEY_SDK_KEY_PATH"
+	// SSHUserEnvVar overrides the default SSH user of the SDK config file
+	SSHUserEnvVar = "ODYSSEY_SDK_SSH_USER"
+
+	configDir      = ".odyssey-sdk"
+	configFile     = "config.yaml"
+	knownHostsFile = "known_hosts"
+
+	testnetAPIEndpoint = "https://testnode.dioneprotocol.com"
+	mainnetAPIEndpoint = "https://node.dioneprotocol.com"
+	devnetAPIEndpoint  = "http://127.0.0.1:9650"
+)
+
+var (
+	loadOnce sync.Once
+	loaded   *Config
+	errLoad  error
+)
+
+// Config holds the SDK defaults. Values are resolved in order from the built-in defaults,
+// the config file (~/.odyssey-sdk/config.yaml, or ODYSSEY_SDK_CONFIG) and environment variables,
+// each level overriding the previous one.
+type Config struct {
+	// Network is the default network kind: testnet, mainnet or devnet
+	Network string `yaml:"network"`
+
+	// Endpoints maps network kinds to their API endpoint
+	Endpoints map[string]string `yaml:"endpoints"`
+
+	// KeyPath is the default path of the stored key used by keychains
+	KeyPath string `yaml:"keyPath"`
+
+	// SSH holds the defaults used to connect to nodes
+	SSH SSHConfig `yaml:"ssh"`
+
+	// Monitoring holds the defaults of monitoring nodes
+	Monitoring MonitoringConfig `yaml:"monitoring"`
+}
+
+// SSHConfig holds the defaults used to connect to nodes over SSH
+type SSHConfig struct {
+	// User is the SSH user, ubuntu by default
+	User string `yaml:"user"`
+
+	// PrivateKeyPath is the SSH private key. When empty, the SSH agent is used
+	PrivateKeyPath string `yaml:"privateKeyPath"`
+
+	// KnownHostsPath is the known_hosts file recording the host keys of the nodes,
+	// ~/.odyssey-sdk/known_hosts by default
+	KnownHostsPath string `yaml:"knownHostsPath"`
+
+	// HostKeyPolicy is how host keys are verified: tofu (default), strict or insecure
+	HostKeyPolicy string `yaml:"hostKeyPolicy"`
+}
+
+// MonitoringConfig holds the ports exposed by monitoring nodes
+type MonitoringConfig struct {
+	GrafanaPort    uint `yaml:"grafanaPort"`
+	PrometheusPort uint `yaml:"prometheusPort"`
+	LokiPort       uint `yaml:"lokiPort"`
+}
+
+// Default returns the built-in defaults
+func Default() *Config {
+	return &Config{
+		Network: "testnet",
+		Endpoints: map[string]string{
+			"testnet": testnetAPIEndpoint,
+			"mainnet": mainnetAPIEndpoint,
+			"devnet":  devnetAPIEndpoint,
+		},
+		SSH: SSHConfig{
+			User: constants.RemoteHostUser,
+		},
+		Monitoring: MonitoringConfig{
+			GrafanaPort:    constants.OdysseygoGrafanaPort,
+			PrometheusPort: constants.OdysseygoMonitoringPort,
+			LokiPort:       constants.OdysseygoLokiPort,
+		},
+	}
+}
+
+// Path returns the location of the SDK config file
+func Path() (string, error) {
+	if path := os.Getenv(ConfigFileEnvVar); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, configDir, configFile), nil
+}
+
+// Load reads the SDK config file, if it exists, on top of the built-in defaults,
+// and applies the environment variable overrides
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFile(path)
+}
+
+// LoadFile reads the config file at path, if it exists, on top of the built-in defaults,
+// and applies the environment variable overrides
+func LoadFile(path string) (*Config, error) {
+	c := Default()
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		fileConfig := Config{}
+		if err := yaml.Unmarshal(data, &fileConfig); err != nil {
+			return nil, fmt.Errorf("invalid SDK config file %s: %w", path, err)
+		}
+		c.merge(fileConfig)
+	}
+	c.merge(Config{
+		Network: os.Getenv(NetworkEnvVar),
+		KeyPath: os.Getenv(KeyPathEnvVar),
+		SSH:     SSHConfig{User: os.Getenv(SSHUserEnvVar)},
+	})
+	if c.KeyPath != "" {
+		c.KeyPath = utils.ExpandHome(c.KeyPath)
+	}
+	if c.SSH.PrivateKeyPath != "" {
+		c.SSH.PrivateKeyPath = utils.ExpandHome(c.SSH.PrivateKeyPath)
+	}
+	if c.SSH.KnownHostsPath != "" {
+		c.SSH.KnownHostsPath = utils.ExpandHome(c.SSH.KnownHostsPath)
+	}
+	return c, nil
+}
+
+// Get returns the SDK config, loaded once from the config file.
+// If the config file is invalid, the built-in defaults are used,
+// and the error is returned by GetError.
+func Get() *Config {
+	loadOnce.Do(func() {
+		loaded, errLoad = Load()
+		if errLoad != nil {
+			loaded = Default()
+		}
+	})
+	return loaded
+}
+
+// GetError returns the error found loading the SDK config in Get, if any
+func GetError() error {
+	Get()
+	return errLoad
+}
+
+// KnownHostsPath returns the location of the known_hosts file of the nodes
+func (c *Config) KnownHostsPath() (string, error) {
+	if c.SSH.KnownHostsPath != "" {
+		return c.SSH.KnownHostsPath, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, configDir, knownHostsFile), nil
+}
+
+// WithNodeDefaults returns the remote config of a node with its unset monitoring ports set
+// from c, and its other unset fields set to their built-in defaults
+func (c *Config) WithNodeDefaults(nodeConfig constants.Config) constants.Config {
+	if nodeConfig.GrafanaPort == 0 {
+		nodeConfig.GrafanaPort = c.Monitoring.GrafanaPort
+	}
+	if nodeConfig.PrometheusPort == 0 {
+		nodeConfig.PrometheusPort = c.Monitoring.PrometheusPort
+	}
+	if nodeConfig.LokiPort == 0 {
+		nodeConfig.LokiPort = c.Monitoring.LokiPort
+	}
+	return nodeConfig.WithDefaults()
+}
+
+// Endpoint returns the API endpoint of the given network kind, or of the default network
+// if networkKind is empty
+func (c *Config) Endpoint(networkKind string) string {
+	if networkKind == "" {
+		networkKind = c.Network
+	}
+	return c.Endpoints[strings.ToLower(networkKind)]
+}
+
+// merge overrides the fields of c with the non-empty fields of other
+func (c *Config) merge(other Config) {
+	if other.Network != "" {
+		c.Network = strings.ToLower(other.Network)
+	}
+	for network, endpoint := range other.Endpoints {
+		c.Endpoints[strings.ToLower(network)] = endpoint
+	}
+	if other.KeyPath != "" {
+		c.KeyPath = other.KeyPath
+	}
+	if other.SSH.User != "" {
+		c.SSH.User = other.SSH.User
+	}
+	if other.SSH.PrivateKeyPath != "" {
+		c.SSH.PrivateKeyPath = other.SSH.PrivateKeyPath
+	}
+	if other.SSH.KnownHostsPath != "" {
+		c.SSH.KnownHostsPath = other.SSH.KnownHostsPath
+	}
+	if other.SSH.HostKeyPolicy != "" {
+		c.SSH.HostKeyPolicy = strings.ToLower(other.SSH.HostKeyPolicy)
+	}
+	if other.Monitoring.GrafanaPort != 0 {
+		c.Monitoring.GrafanaPort = other.Monitoring.GrafanaPort
+	}
+	if other.Monitoring.PrometheusPort != 0 {
+		c.Monitoring.PrometheusPort = other.Monitoring.PrometheusPort
+	}
+	if other.Monitoring.LokiPort != 0 {
+		c.Monitoring.LokiPort = other.Monitoring.LokiPort
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFile_Defaults(t *testing.T) {
+	c, err := LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, Default(), c)
+	assert.Equal(t, testnetAPIEndpoint, c.Endpoint(""))
+	assert.Equal(t, mainnetAPIEndpoint, c.Endpoint("Mainnet"))
+}
+
+func TestLoadFile_Overrides(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+network: Mainnet
+endpoints:
+  mainnet: https://my-node.example.com
+keyPath: ~/keys/main.pk
+ssh:
+  privateKeyPath: /keys/ssh.pem
+  hostKeyPolicy: Strict
+monitoring:
+  grafanaPort: 3001
+`), 0o600))
+
+	c, err := LoadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "mainnet", c.Network)
+	assert.Equal(t, "https://my-node.example.com", c.Endpoint(""))
+	assert.Equal(t, testnetAPIEndpoint, c.Endpoint("testnet"))
+	assert.Equal(t, filepath.Join(home, "keys/main.pk"), c.KeyPath)
+	assert.Equal(t, "ubuntu", c.SSH.User)
+	assert.Equal(t, "/keys/ssh.pem", c.SSH.PrivateKeyPath)
+	assert.Equal(t, "strict", c.SSH.HostKeyPolicy)
+	assert.Equal(t, uint(3001), c.Monitoring.GrafanaPort)
+	assert.Equal(t, uint(9090), c.Monitoring.PrometheusPort)
+
+	t.Setenv(NetworkEnvVar, "devnet")
+	t.Setenv(KeyPathEnvVar, "/env/key.pk")
+	t.Setenv(SSHUserEnvVar, "admin")
+	c, err = LoadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, devnetAPIEndpoint, c.Endpoint(""))
+	assert.Equal(t, "/env/key.pk", c.KeyPath)
+	assert.Equal(t, "admin", c.SSH.User)
+}
+
+func TestLoadFile_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("network: [testnet"), 0o600))
+	_, err := LoadFile(path)
+	assert.Error(t, err)
+}
+
+func TestPath(t *testing.T) {
+	t.Setenv(ConfigFileEnvVar, "/etc/odyssey/sdk.yaml")
+	path, err := Path()
+	require.NoError(t, err)
+	assert.Equal(t, "/etc/odyssey/sdk.yaml", path)
+
+	t.Setenv(ConfigFileEnvVar, "")
+	path, err = Path()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(configDir, configFile), filepath.Join(filepath.Base(filepath.Dir(path)), filepath.Base(path)))
+}
+
+func TestKnownHostsPath(t *testing.T) {
+	path, err := Default().KnownHostsPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(configDir, knownHostsFile), filepath.Join(filepath.Base(filepath.Dir(path)), filepath.Base(path)))
+
+	c := Default()
+	c.SSH.KnownHostsPath = "/keys/known_hosts"
+	path, err = c.KnownHostsPath()
+	require.NoError(t, err)
+	assert.Equal(t, "/keys/known_hosts", path)
+}
+
+func TestWithNodeDefaults(t *testing.T) {
+	c := Default()
+	c.Monitoring.GrafanaPort = 3001
+	nodeConfig := c.WithNodeDefaults(constants.Config{LokiPort: 3101})
+	assert.Equal(t, uint(3001), nodeConfig.GrafanaPort)
+	assert.Equal(t, uint(3101), nodeConfig.LokiPort, "ports set on the node are kept")
+	assert.Equal(t, uint(constants.OdysseygoMonitoringPort), nodeConfig.PrometheusPort)
+	assert.Equal(t, uint(constants.OdysseygoAPIPort), nodeConfig.OdysseygoAPIPort)
+}
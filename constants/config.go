@@ -0,0 +1,121 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package constants
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+const (
+	// DefaultOdysseyGoDir is the default remote odysseygo directory
+	DefaultOdysseyGoDir = "/home/ubuntu/.odysseygo"
+
+	// DefaultServicesDir is the default remote directory of the compose file and of the
+	// files of its services
+	DefaultServicesDir = "/home/ubuntu/.odyssey-cli/" + ServicesDir
+
+	// ComposeFileName is the name of the compose file in the services directory
+	ComposeFileName = "docker-compose.yml"
+)
+
+// Config holds the ports and remote folders the nodes are provisioned with, for deployments
+// not using the standard ones. A zero field stands for its default, see DefaultConfig.
+//
+// odysseygo, the AWM relayer and the RPC gateway run on the host network, so they listen on
+// the configured ports themselves. Prometheus, Grafana, Loki and node-exporter keep listening
+// on their standard ports inside their containers, the configured ones being published on the
+// hosts. The compose service names are not configurable, as the templates, dashboards and
+// Prometheus jobs refer to them
+type Config struct {
+	OdysseygoAPIPort      uint
+	OdysseygoP2PPort      uint
+	GrafanaPort           uint
+	LokiPort              uint
+	PrometheusPort        uint
+	MachineMetricsPort    uint
+	LoadTestPort          uint
+	AWMRelayerMetricsPort uint
+	RPCGatewayHTTPPort    uint
+	RPCGatewayHTTPSPort   uint
+
+	// OdysseyGoDir is the remote odysseygo directory, holding its configs, database, logs,
+	// plugins and staking keys
+	OdysseyGoDir string
+
+	// ServicesDir is the remote directory of the compose file and of the files of its
+	// services, e.g. the Prometheus and promtail configs
+	ServicesDir string
+}
+
+// DefaultConfig returns the standard ports and remote folders
+func DefaultConfig() Config {
+	return Config{
+		OdysseygoAPIPort:      OdysseygoAPIPort,
+		OdysseygoP2PPort:      OdysseygoP2PPort,
+		GrafanaPort:           OdysseygoGrafanaPort,
+		LokiPort:              OdysseygoLokiPort,
+		PrometheusPort:        OdysseygoMonitoringPort,
+		MachineMetricsPort:    OdysseygoMachineMetricsPort,
+		LoadTestPort:          OdysseygoLoadTestPort,
+		AWMRelayerMetricsPort: AWMRelayerMetricsPort,
+		RPCGatewayHTTPPort:    RPCGatewayHTTPPort,
+		RPCGatewayHTTPSPort:   RPCGatewayHTTPSPort,
+		OdysseyGoDir:          DefaultOdysseyGoDir,
+		ServicesDir:           DefaultServicesDir,
+	}
+}
+
+// WithDefaults returns c with its zero fields set to their defaults
+func (c Config) WithDefaults() Config {
+	defaults := DefaultConfig()
+	setDefault(&c.OdysseygoAPIPort, defaults.OdysseygoAPIPort)
+	setDefault(&c.OdysseygoP2PPort, defaults.OdysseygoP2PPort)
+	setDefault(&c.GrafanaPort, defaults.GrafanaPort)
+	setDefault(&c.LokiPort, defaults.LokiPort)
+	setDefault(&c.PrometheusPort, defaults.PrometheusPort)
+	setDefault(&c.MachineMetricsPort, defaults.MachineMetricsPort)
+	setDefault(&c.LoadTestPort, defaults.LoadTestPort)
+	setDefault(&c.AWMRelayerMetricsPort, defaults.AWMRelayerMetricsPort)
+	setDefault(&c.RPCGatewayHTTPPort, defaults.RPCGatewayHTTPPort)
+	setDefault(&c.RPCGatewayHTTPSPort, defaults.RPCGatewayHTTPSPort)
+	setDefault(&c.OdysseyGoDir, defaults.OdysseyGoDir)
+	setDefault(&c.ServicesDir, defaults.ServicesDir)
+	return c
+}
+
+// WithHome returns c with its unset remote folders in the home directory home, for hosts
+// whose user is not RemoteHostUser
+func (c Config) WithHome(home string) Config {
+	setDefault(&c.OdysseyGoDir, filepath.Join(home, ".odysseygo"))
+	setDefault(&c.ServicesDir, filepath.Join(home, ".odyssey-cli", ServicesDir))
+	return c
+}
+
+func setDefault[T comparable](value *T, defaultValue T) {
+	var zero T
+	if *value == zero {
+		*value = defaultValue
+	}
+}
+
+// LocalAPIEndpoint returns the odysseygo API endpoint on the node itself
+func (c Config) LocalAPIEndpoint() string {
+	return fmt.Sprintf("http://127.0.0.1:%d", c.WithDefaults().OdysseygoAPIPort)
+}
+
+// OdysseyGoPath returns the remote path of elems in the odysseygo directory
+func (c Config) OdysseyGoPath(elems ...string) string {
+	return filepath.Join(append([]string{c.WithDefaults().OdysseyGoDir}, elems...)...)
+}
+
+// ComposeFile returns the remote path of the compose file
+func (c Config) ComposeFile() string {
+	return filepath.Join(c.WithDefaults().ServicesDir, ComposeFileName)
+}
+
+// ServicePath returns the remote path of dirs in the directory of the compose service
+// serviceName
+func (c Config) ServicePath(serviceName string, dirs ...string) string {
+	return filepath.Join(append([]string{c.WithDefaults().ServicesDir, serviceName}, dirs...)...)
+}
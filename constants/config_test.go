@@ -0,0 +1,31 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package constants
+
+import "testing"
+
+func TestConfig(t *testing.T) {
+	config := Config{GrafanaPort: 3001, OdysseyGoDir: "/data/odysseygo"}.WithDefaults()
+	if config.GrafanaPort != 3001 || config.OdysseyGoDir != "/data/odysseygo" {
+		t.Errorf("WithDefaults overrode the set fields: %+v", config)
+	}
+	if config.OdysseygoAPIPort != OdysseygoAPIPort || config.ServicesDir != DefaultServicesDir {
+		t.Errorf("WithDefaults did not set the zero fields: %+v", config)
+	}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"LocalAPIEndpoint", Config{OdysseygoAPIPort: 9660}.LocalAPIEndpoint(), "http://127.0.0.1:9660"},
+		{"OdysseyGoPath", config.OdysseyGoPath("configs", "node.json"), "/data/odysseygo/configs/node.json"},
+		{"ComposeFile", Config{}.ComposeFile(), "/home/ubuntu/.odyssey-cli/services/docker-compose.yml"},
+		{"ServicePath", Config{ServicesDir: "/srv"}.ServicePath(ServiceGrafana, "data"), "/srv/grafana/data"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s = %q, expected %q", tt.name, tt.got, tt.expected)
+		}
+	}
+}
@@ -18,6 +18,9 @@ const (
 	OdysseygoMonitoringPort     = 9090
 	OdysseygoMachineMetricsPort = 9100
 	OdysseygoLoadTestPort       = 8082
+	AWMRelayerMetricsPort       = 9090
+	RPCGatewayHTTPPort          = 80
+	RPCGatewayHTTPSPort         = 443
 
 	// http
 	APIRequestTimeout      = 30 * time.Second
@@ -35,11 +38,14 @@ const (
 	ServicesDir   = "services"
 	DashboardsDir = "dashboards"
 	// services
-	ServiceOdysseygo  = "odysseygo"
-	ServicePromtail   = "promtail"
-	ServiceGrafana    = "grafana"
-	ServicePrometheus = "prometheus"
-	ServiceLoki       = "loki"
+	ServiceOdysseygo    = "odysseygo"
+	ServicePromtail     = "promtail"
+	ServiceGrafana      = "grafana"
+	ServicePrometheus   = "prometheus"
+	ServiceLoki         = "loki"
+	ServiceNodeExporter = "node-exporter"
+	ServiceAWMRelayer   = "awm-relayer"
+	ServiceRPCGateway   = "rpc-gateway"
 
 	// misc
 	DefaultPerms755        = 0o755
@@ -58,6 +64,18 @@ const (
 	StakerKeyFileName  = "staker.key"
 	BLSKeyFileName     = "signer.key"
 
+	// awm relayer
+	AWMRelayerDockerImage    = "dionetech/awm-relayer"
+	DefaultAWMRelayerVersion = "latest"
+	AWMRelayerConfigFileName = "awm-relayer-config.json"
+
+	// rpc gateway
+	RPCGatewayDockerImage = "nginx:1.27-alpine"
+	// image obtaining and renewing the ACME certificates of the rpc gateway
+	CertbotDockerImage = "certbot/certbot:v2.11.0"
+	// requests per second allowed to each client IP by default
+	DefaultRPCGatewayRateLimit = 20
+
 	// github
 	DioneProtocolOrg = "DioneProtocol"
 
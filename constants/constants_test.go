@@ -49,6 +49,7 @@ func TestConstants(t *testing.T) {
 		{"ServiceGrafana", ServiceGrafana, "grafana"},
 		{"ServicePrometheus", ServicePrometheus, "prometheus"},
 		{"ServiceLoki", ServiceLoki, "loki"},
+		{"ServiceNodeExporter", ServiceNodeExporter, "node-exporter"},
 
 		// Permission constants
 		{"DefaultPerms755", DefaultPerms755, 0o755},
@@ -6,6 +6,8 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"strings"
+	"time"
 
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
@@ -24,6 +26,47 @@ const (
 	repeatsOnFailure            = 3
 )
 
+// retryPolicy retries the failed RPC calls, each attempt timing out after
+// APIRequestLargeTimeout
+var retryPolicy = utils.RetryPolicy{
+	MaxAttempts:     repeatsOnFailure,
+	AttemptTimeout:  constants.APIRequestLargeTimeout,
+	InitialInterval: time.Second,
+	Jitter:          0.5,
+}
+
+// retry calls fn until it succeeds or retryPolicy is exhausted, prefixing its error with errMsg
+func retry[T any](errMsg string, fn func(context.Context) (T, error)) (T, error) {
+	return retryWithPolicy(context.Background(), retryPolicy, errMsg, fn)
+}
+
+func retryWithPolicy[T any](ctx context.Context, policy utils.RetryPolicy, errMsg string, fn func(context.Context) (T, error)) (T, error) {
+	result, err := utils.Retry(ctx, policy, fn)
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", errMsg, err)
+	}
+	return result, nil
+}
+
+// isTxRejectedError tells if err reports a tx rejected by the node, which sending it again
+// does not change
+func isTxRejectedError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, rejected := range []string{
+		"already known",
+		"nonce too low",
+		"insufficient funds",
+		"intrinsic gas too low",
+		"exceeds block gas limit",
+		"replacement transaction underpriced",
+	} {
+		if strings.Contains(msg, rejected) {
+			return true
+		}
+	}
+	return false
+}
+
 func ContractAlreadyDeployed(
 	client ethclient.Client,
 	contractAddress string,
@@ -40,11 +83,9 @@ func GetContractBytecode(
 	contractAddressStr string,
 ) ([]byte, error) {
 	contractAddress := common.HexToAddress(contractAddressStr)
-	return utils.Retry(
-		func(ctx context.Context) ([]byte, error) { return client.CodeAt(ctx, contractAddress, nil) },
-		constants.APIRequestLargeTimeout,
-		repeatsOnFailure,
+	return retry(
 		fmt.Sprintf("failure obtaining code for %s on %#v", contractAddressStr, client),
+		func(ctx context.Context) ([]byte, error) { return client.CodeAt(ctx, contractAddress, nil) },
 	)
 }
 
@@ -53,11 +94,9 @@ func GetAddressBalance(
 	addressStr string,
 ) (*big.Int, error) {
 	address := common.HexToAddress(addressStr)
-	return utils.Retry(
-		func(ctx context.Context) (*big.Int, error) { return client.BalanceAt(ctx, address, nil) },
-		constants.APIRequestLargeTimeout,
-		repeatsOnFailure,
+	return retry(
 		fmt.Sprintf("failure obtaining balance for %s on %#v", addressStr, client),
+		func(ctx context.Context) (*big.Int, error) { return client.BalanceAt(ctx, address, nil) },
 	)
 }
 
@@ -88,33 +127,27 @@ func NonceAt(
 	addressStr string,
 ) (uint64, error) {
 	address := common.HexToAddress(addressStr)
-	return utils.Retry(
-		func(ctx context.Context) (uint64, error) { return client.NonceAt(ctx, address, nil) },
-		constants.APIRequestLargeTimeout,
-		repeatsOnFailure,
+	return retry(
 		fmt.Sprintf("failure obtaining nonce for %s on %#v", addressStr, client),
+		func(ctx context.Context) (uint64, error) { return client.NonceAt(ctx, address, nil) },
 	)
 }
 
 func SuggestGasTipCap(
 	client ethclient.Client,
 ) (*big.Int, error) {
-	return utils.Retry(
-		func(ctx context.Context) (*big.Int, error) { return client.SuggestGasTipCap(ctx) },
-		constants.APIRequestLargeTimeout,
-		repeatsOnFailure,
+	return retry(
 		fmt.Sprintf("failure obtaining gas tip cap on %#v", client),
+		func(ctx context.Context) (*big.Int, error) { return client.SuggestGasTipCap(ctx) },
 	)
 }
 
 func EstimateBaseFee(
 	client ethclient.Client,
 ) (*big.Int, error) {
-	return utils.Retry(
-		func(ctx context.Context) (*big.Int, error) { return client.EstimateBaseFee(ctx) },
-		constants.APIRequestLargeTimeout,
-		repeatsOnFailure,
+	return retry(
 		fmt.Sprintf("failure estimating base fee on %#v", client),
+		func(ctx context.Context) (*big.Int, error) { return client.EstimateBaseFee(ctx) },
 	)
 }
 
@@ -211,30 +244,28 @@ func SendTransaction(
 	client ethclient.Client,
 	tx *types.Transaction,
 ) error {
-	_, err := utils.Retry(
-		func(ctx context.Context) (interface{}, error) { return nil, client.SendTransaction(ctx, tx) },
-		constants.APIRequestLargeTimeout,
-		repeatsOnFailure,
+	policy := retryPolicy
+	policy.Retryable = func(err error) bool { return !isTxRejectedError(err) }
+	_, err := retryWithPolicy(
+		context.Background(),
+		policy,
 		fmt.Sprintf("failure sending transaction %#v to %#v", tx, client),
+		func(ctx context.Context) (interface{}, error) { return nil, client.SendTransaction(ctx, tx) },
 	)
 	return err
 }
 
 func GetClient(rpcURL string) (ethclient.Client, error) {
-	return utils.Retry(
-		func(ctx context.Context) (ethclient.Client, error) { return ethclient.DialContext(ctx, rpcURL) },
-		constants.APIRequestLargeTimeout,
-		repeatsOnFailure,
+	return retry(
 		fmt.Sprintf("failure connecting to %s", rpcURL),
+		func(ctx context.Context) (ethclient.Client, error) { return ethclient.DialContext(ctx, rpcURL) },
 	)
 }
 
 func GetChainID(client ethclient.Client) (*big.Int, error) {
-	return utils.Retry(
-		func(ctx context.Context) (*big.Int, error) { return client.ChainID(ctx) },
-		constants.APIRequestLargeTimeout,
-		repeatsOnFailure,
+	return retry(
 		fmt.Sprintf("failure getting chain id from client %#v", client),
+		func(ctx context.Context) (*big.Int, error) { return client.ChainID(ctx) },
 	)
 }
 
@@ -257,11 +288,9 @@ func WaitForTransaction(
 	client ethclient.Client,
 	tx *types.Transaction,
 ) (*types.Receipt, bool, error) {
-	receipt, err := utils.Retry(
-		func(ctx context.Context) (*types.Receipt, error) { return bind.WaitMined(ctx, client, tx) },
-		constants.APIRequestLargeTimeout,
-		repeatsOnFailure,
+	receipt, err := retry(
 		fmt.Sprintf("failure waiting for tx %#v on client %#v", tx, client),
+		func(ctx context.Context) (*types.Receipt, error) { return bind.WaitMined(ctx, client, tx) },
 	)
 	var success bool
 	if receipt != nil {
@@ -287,11 +316,9 @@ func GetEventFromLogs[T any](logs []*types.Log, parser func(log types.Log) (T, e
 }
 
 func GetRPCClient(rpcURL string) (*rpc.Client, error) {
-	return utils.Retry(
-		func(ctx context.Context) (*rpc.Client, error) { return rpc.DialContext(ctx, rpcURL) },
-		constants.APIRequestLargeTimeout,
-		repeatsOnFailure,
+	return retry(
 		fmt.Sprintf("failure connecting to %s", rpcURL),
+		func(ctx context.Context) (*rpc.Client, error) { return rpc.DialContext(ctx, rpcURL) },
 	)
 }
 
@@ -300,7 +327,8 @@ func DebugTraceTransaction(
 	txID string,
 ) (map[string]interface{}, error) {
 	var trace map[string]interface{}
-	_, err := utils.Retry(
+	_, err := retry(
+		fmt.Sprintf("failure tracing tx %s for client %#v", txID, client),
 		func(ctx context.Context) (interface{}, error) {
 			return nil, client.CallContext(
 				ctx,
@@ -310,9 +338,6 @@ func DebugTraceTransaction(
 				map[string]string{"tracer": "callTracer"},
 			)
 		},
-		constants.APIRequestLargeTimeout,
-		repeatsOnFailure,
-		fmt.Sprintf("failure tracing tx %s for client %#v", txID, client),
 	)
 	return trace, err
 }
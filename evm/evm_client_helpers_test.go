@@ -4,9 +4,11 @@ package evm
 
 import (
 	"encoding/hex"
+	"errors"
 	"math/big"
 	"testing"
 
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
 	"github.com/DioneProtocol/subnet-evm/core/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
@@ -110,6 +112,26 @@ func TestSendTransaction_SuccessImmediate(t *testing.T) {
 	require.NoError(t, SendTransaction(mock, nil))
 }
 
+func TestSendTransaction_Retries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mock := NewMockClient(ctrl)
+
+	// transient failures are retried
+	gomock.InOrder(
+		mock.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return(errors.New("connection reset by peer")),
+		mock.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return(nil),
+	)
+	require.NoError(t, SendTransaction(mock, nil))
+
+	// rejected txs are not
+	rejected := errors.New("nonce too low")
+	mock.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return(rejected).Times(1)
+	err := SendTransaction(mock, nil)
+	require.ErrorIs(t, err, rejected)
+	require.NotErrorIs(t, err, utils.ErrRetriesExhausted)
+}
+
 func TestWaitForTransaction_SuccessAndFailure(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,368 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/ethclient"
+	"github.com/DioneProtocol/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// DefaultPageLimit is the number of items of a page when the query has no limit
+	DefaultPageLimit = 100
+
+	// DefaultMaxBlockRange is the number of blocks read by a single eth_getLogs call, and
+	// scanned by a single page of transactions
+	DefaultMaxBlockRange = 2048
+)
+
+var ErrInvalidQuery = errors.New("invalid indexer query")
+
+// TransferEventSignature is the topic of the Transfer events of ERC-20 and ERC-721 tokens
+var TransferEventSignature = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// Query selects the items of the blocks FromBlock to ToBlock, both included, at most Limit
+// at a time
+type Query struct {
+	FromBlock uint64
+
+	// FromIndex skips the txs or logs of FromBlock before this index, to resume a page
+	FromIndex uint
+
+	// ToBlock is the last block of the query, the latest block if 0
+	ToBlock uint64
+
+	// Limit is the maximum number of items of a page, DefaultPageLimit if not positive
+	Limit int
+}
+
+// Page holds the items of a query. Next is the query returning the following items, nil if
+// the query is complete
+type Page[T any] struct {
+	Items []T
+	Next  *Query
+}
+
+// IndexedTx is a tx with its position in the chain
+type IndexedTx struct {
+	BlockNumber uint64
+	Index       uint
+
+	// Time is the timestamp of the block of the tx
+	Time uint64
+
+	From common.Address
+	Tx   *types.Transaction
+}
+
+// TokenTransfer is the Transfer event of an ERC-20 or ERC-721 token
+type TokenTransfer struct {
+	Token common.Address
+	From  common.Address
+	To    common.Address
+
+	// Value is the amount of an ERC-20 transfer, nil for ERC-721 transfers
+	Value *big.Int
+
+	// TokenID is the token of an ERC-721 transfer, nil for ERC-20 transfers
+	TokenID *big.Int
+
+	TxHash      common.Hash
+	BlockNumber uint64
+	LogIndex    uint
+}
+
+// TokenTransferFilter selects token transfers. The zero value selects all the transfers of all
+// tokens
+type TokenTransferFilter struct {
+	// Tokens are the token contracts whose transfers are selected, all if empty
+	Tokens []common.Address
+
+	// Address selects the transfers from or to it, if set
+	Address *common.Address
+}
+
+// IndexerOp configures an Indexer
+type IndexerOp struct {
+	retryPolicy   utils.RetryPolicy
+	maxBlockRange uint64
+}
+
+// IndexerOption configures NewIndexer
+type IndexerOption func(*IndexerOp)
+
+// WithIndexerRetryPolicy retries the failed RPC calls of the indexer with policy instead of the
+// default policy of the package
+func WithIndexerRetryPolicy(policy utils.RetryPolicy) IndexerOption {
+	return func(op *IndexerOp) {
+		op.retryPolicy = policy
+	}
+}
+
+// WithMaxBlockRange changes DefaultMaxBlockRange, e.g. to stay under the eth_getLogs block
+// range limit of the RPC
+func WithMaxBlockRange(maxBlockRange uint64) IndexerOption {
+	return func(op *IndexerOp) {
+		op.maxBlockRange = maxBlockRange
+	}
+}
+
+// Indexer queries the blocks, txs and token transfers of a Subnet-EVM chain from its RPC,
+// without a separate indexing stack. Txs by address are found by scanning the blocks, so
+// their queries should cover the blocks of interest only
+type Indexer struct {
+	client ethclient.Client
+	op     IndexerOp
+}
+
+// NewIndexer returns an indexer of the chain of client
+func NewIndexer(client ethclient.Client, opts ...IndexerOption) *Indexer {
+	op := IndexerOp{
+		retryPolicy:   retryPolicy,
+		maxBlockRange: DefaultMaxBlockRange,
+	}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	if op.maxBlockRange == 0 {
+		op.maxBlockRange = DefaultMaxBlockRange
+	}
+	return &Indexer{client: client, op: op}
+}
+
+// resolve sets the latest block and the default limit of q, and checks its range
+func (i *Indexer) resolve(ctx context.Context, q Query) (Query, error) {
+	if q.Limit <= 0 {
+		q.Limit = DefaultPageLimit
+	}
+	if q.ToBlock == 0 {
+		latest, err := retryWithPolicy(
+			ctx,
+			i.op.retryPolicy,
+			"failure obtaining latest block number",
+			func(ctx context.Context) (uint64, error) { return i.client.BlockNumber(ctx) },
+		)
+		if err != nil {
+			return q, err
+		}
+		q.ToBlock = latest
+	}
+	if q.FromBlock > q.ToBlock {
+		return q, fmt.Errorf("%w: from block %d is after to block %d", ErrInvalidQuery, q.FromBlock, q.ToBlock)
+	}
+	return q, nil
+}
+
+// next returns the query of q resuming at index of block
+func (q Query) next(block uint64, index uint) *Query {
+	q.FromBlock = block
+	q.FromIndex = index
+	return &q
+}
+
+func (i *Indexer) blockByNumber(ctx context.Context, number uint64) (*types.Block, error) {
+	return retryWithPolicy(
+		ctx,
+		i.op.retryPolicy,
+		fmt.Sprintf("failure obtaining block %d", number),
+		func(ctx context.Context) (*types.Block, error) {
+			return i.client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+		},
+	)
+}
+
+// Blocks returns the blocks of q
+func (i *Indexer) Blocks(ctx context.Context, q Query) (Page[*types.Block], error) {
+	q, err := i.resolve(ctx, q)
+	if err != nil {
+		return Page[*types.Block]{}, err
+	}
+	page := Page[*types.Block]{}
+	for number := q.FromBlock; number <= q.ToBlock; number++ {
+		if len(page.Items) == q.Limit {
+			page.Next = q.next(number, 0)
+			return page, nil
+		}
+		block, err := i.blockByNumber(ctx, number)
+		if err != nil {
+			return page, err
+		}
+		page.Items = append(page.Items, block)
+	}
+	return page, nil
+}
+
+// TransactionsByAddress returns the txs of q sent from or to address. A page scans at most
+// the max block range of the indexer, so it may have fewer items than the limit of q, or none,
+// while Next is set
+func (i *Indexer) TransactionsByAddress(ctx context.Context, address common.Address, q Query) (Page[IndexedTx], error) {
+	q, err := i.resolve(ctx, q)
+	if err != nil {
+		return Page[IndexedTx]{}, err
+	}
+	chainID, err := retryWithPolicy(
+		ctx,
+		i.op.retryPolicy,
+		"failure obtaining chain id",
+		func(ctx context.Context) (*big.Int, error) { return i.client.ChainID(ctx) },
+	)
+	if err != nil {
+		return Page[IndexedTx]{}, err
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	page := Page[IndexedTx]{}
+	for number := q.FromBlock; number <= q.ToBlock; number++ {
+		if number-q.FromBlock == i.op.maxBlockRange {
+			page.Next = q.next(number, 0)
+			return page, nil
+		}
+		block, err := i.blockByNumber(ctx, number)
+		if err != nil {
+			return page, err
+		}
+		for index, tx := range block.Transactions() {
+			if number == q.FromBlock && uint(index) < q.FromIndex {
+				continue
+			}
+			from, err := types.Sender(signer, tx)
+			if err != nil {
+				return page, fmt.Errorf("failure recovering sender of tx %s: %w", tx.Hash(), err)
+			}
+			if from != address && (tx.To() == nil || *tx.To() != address) {
+				continue
+			}
+			if len(page.Items) == q.Limit {
+				page.Next = q.next(number, uint(index))
+				return page, nil
+			}
+			page.Items = append(page.Items, IndexedTx{
+				BlockNumber: number,
+				Index:       uint(index),
+				Time:        block.Time(),
+				From:        from,
+				Tx:          tx,
+			})
+		}
+	}
+	return page, nil
+}
+
+// TokenTransfers returns the ERC-20 and ERC-721 transfers of q selected by filter, in chain
+// order. Transfer events not following these standards are skipped
+func (i *Indexer) TokenTransfers(ctx context.Context, filter TokenTransferFilter, q Query) (Page[TokenTransfer], error) {
+	q, err := i.resolve(ctx, q)
+	if err != nil {
+		return Page[TokenTransfer]{}, err
+	}
+	page := Page[TokenTransfer]{}
+	for start := q.FromBlock; start <= q.ToBlock; {
+		end := min(start+i.op.maxBlockRange-1, q.ToBlock)
+		logs, err := i.transferLogs(ctx, filter, start, end)
+		if err != nil {
+			return page, err
+		}
+		for _, log := range logs {
+			if log.BlockNumber == q.FromBlock && log.Index < q.FromIndex {
+				continue
+			}
+			transfer, ok := parseTokenTransfer(log)
+			if !ok {
+				continue
+			}
+			if len(page.Items) == q.Limit {
+				page.Next = q.next(log.BlockNumber, log.Index)
+				return page, nil
+			}
+			page.Items = append(page.Items, transfer)
+		}
+		if end == q.ToBlock {
+			break
+		}
+		start = end + 1
+	}
+	return page, nil
+}
+
+// transferLogs returns the Transfer logs of the blocks start to end selected by filter, in
+// chain order
+func (i *Indexer) transferLogs(ctx context.Context, filter TokenTransferFilter, start, end uint64) ([]types.Log, error) {
+	topics := [][][]common.Hash{{{TransferEventSignature}}}
+	if filter.Address != nil {
+		// the topics of a filter are ANDed, so the transfers from and to the address are
+		// queried separately
+		addressTopic := common.BytesToHash(filter.Address.Bytes())
+		topics = [][][]common.Hash{
+			{{TransferEventSignature}, {addressTopic}},
+			{{TransferEventSignature}, nil, {addressTopic}},
+		}
+	}
+	seen := map[[2]uint64]bool{}
+	logs := []types.Log{}
+	for _, topic := range topics {
+		query := interfaces.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: filter.Tokens,
+			Topics:    topic,
+		}
+		found, err := retryWithPolicy(
+			ctx,
+			i.op.retryPolicy,
+			fmt.Sprintf("failure obtaining transfer logs of blocks %d to %d", start, end),
+			func(ctx context.Context) ([]types.Log, error) { return i.client.FilterLogs(ctx, query) },
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, log := range found {
+			// transfers from the address to itself match both queries
+			position := [2]uint64{log.BlockNumber, uint64(log.Index)}
+			if !seen[position] && !log.Removed {
+				seen[position] = true
+				logs = append(logs, log)
+			}
+		}
+	}
+	sort.Slice(logs, func(a, b int) bool {
+		if logs[a].BlockNumber != logs[b].BlockNumber {
+			return logs[a].BlockNumber < logs[b].BlockNumber
+		}
+		return logs[a].Index < logs[b].Index
+	})
+	return logs, nil
+}
+
+// parseTokenTransfer decodes an ERC-20 Transfer log, with the amount in its data, or an ERC-721
+// one, with the token ID as third indexed topic
+func parseTokenTransfer(log types.Log) (TokenTransfer, bool) {
+	if len(log.Topics) < 3 || log.Topics[0] != TransferEventSignature {
+		return TokenTransfer{}, false
+	}
+	transfer := TokenTransfer{
+		Token:       log.Address,
+		From:        common.BytesToAddress(log.Topics[1].Bytes()),
+		To:          common.BytesToAddress(log.Topics[2].Bytes()),
+		TxHash:      log.TxHash,
+		BlockNumber: log.BlockNumber,
+		LogIndex:    log.Index,
+	}
+	switch {
+	case len(log.Topics) == 3 && len(log.Data) == common.HashLength:
+		transfer.Value = new(big.Int).SetBytes(log.Data)
+	case len(log.Topics) == 4 && len(log.Data) == 0:
+		transfer.TokenID = log.Topics[3].Big()
+	default:
+		return TokenTransfer{}, false
+	}
+	return transfer, true
+}
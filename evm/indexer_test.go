@@ -0,0 +1,218 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package evm
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testIndexerRetryPolicy = utils.RetryPolicy{MaxAttempts: 2, InitialInterval: time.Millisecond}
+
+// testChain serves blocks of txs from the keys of the test to the mock client
+type testChain struct {
+	chainID *big.Int
+	blocks  []*types.Block
+}
+
+func newTestChain(t *testing.T, mock *MockClient, txsPerBlock [][]*types.Transaction) *testChain {
+	chain := &testChain{chainID: big.NewInt(99999)}
+	for number, txs := range txsPerBlock {
+		header := &types.Header{Number: big.NewInt(int64(number)), Time: uint64(1000 + number)}
+		chain.blocks = append(chain.blocks, types.NewBlockWithHeader(header).WithBody(txs, nil))
+	}
+	mock.EXPECT().ChainID(gomock.Any()).Return(chain.chainID, nil).AnyTimes()
+	mock.EXPECT().BlockNumber(gomock.Any()).Return(uint64(len(chain.blocks)-1), nil).AnyTimes()
+	mock.EXPECT().BlockByNumber(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, number *big.Int) (*types.Block, error) {
+			return chain.blocks[number.Uint64()], nil
+		},
+	).AnyTimes()
+	return chain
+}
+
+func signTestTx(t *testing.T, chainID *big.Int, nonce uint64, to common.Address) *types.Transaction {
+	key, err := crypto.HexToECDSA("56289e99c94b6912bfc12adc093c9b51124f0dc54ac7a766b2bc5ccf558d8027")
+	require.NoError(t, err)
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.LegacyTx{
+		Nonce:    nonce,
+		To:       &to,
+		Value:    big.NewInt(1),
+		Gas:      NativeTransferGas,
+		GasPrice: big.NewInt(1),
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestIndexer_Blocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := NewMockClient(ctrl)
+	newTestChain(t, mock, make([][]*types.Transaction, 5))
+	indexer := NewIndexer(mock, WithIndexerRetryPolicy(testIndexerRetryPolicy))
+
+	page, err := indexer.Blocks(context.Background(), Query{FromBlock: 1, Limit: 3})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 3)
+	assert.Equal(t, uint64(1), page.Items[0].NumberU64())
+	assert.Equal(t, &Query{FromBlock: 4, ToBlock: 4, Limit: 3}, page.Next)
+
+	page, err = indexer.Blocks(context.Background(), *page.Next)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, uint64(4), page.Items[0].NumberU64())
+	assert.Nil(t, page.Next)
+
+	_, err = indexer.Blocks(context.Background(), Query{FromBlock: 3, ToBlock: 2})
+	require.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestIndexer_TransactionsByAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := NewMockClient(ctrl)
+	chainID := big.NewInt(99999)
+	alice := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	bob := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	newTestChain(t, mock, [][]*types.Transaction{
+		{},
+		{signTestTx(t, chainID, 0, alice), signTestTx(t, chainID, 1, bob), signTestTx(t, chainID, 2, alice)},
+		{},
+		{signTestTx(t, chainID, 3, alice)},
+	})
+	indexer := NewIndexer(mock, WithIndexerRetryPolicy(testIndexerRetryPolicy), WithMaxBlockRange(3))
+
+	// the page is cut by its limit in the middle of block 1, before the next tx of alice
+	page, err := indexer.TransactionsByAddress(context.Background(), alice, Query{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, IndexedTx{BlockNumber: 1, Index: 0, Time: 1001, From: page.Items[0].From, Tx: page.Items[0].Tx}, page.Items[0])
+	assert.Equal(t, uint64(0), page.Items[0].Tx.Nonce())
+	assert.Equal(t, &Query{FromBlock: 1, FromIndex: 2, ToBlock: 3, Limit: 1}, page.Next)
+
+	page, err = indexer.TransactionsByAddress(context.Background(), alice, Query{FromBlock: 1, FromIndex: 2, ToBlock: 3, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, uint64(2), page.Items[0].Tx.Nonce())
+	assert.Equal(t, uint64(3), page.Items[1].Tx.Nonce())
+	assert.Nil(t, page.Next)
+
+	// the sender matches too, the page being cut by the max block range
+	sender := page.Items[0].From
+	page, err = indexer.TransactionsByAddress(context.Background(), sender, Query{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 3)
+	assert.Equal(t, &Query{FromBlock: 3, ToBlock: 3, Limit: 10}, page.Next)
+	page, err = indexer.TransactionsByAddress(context.Background(), sender, *page.Next)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Nil(t, page.Next)
+}
+
+func transferLog(token common.Address, from, to common.Address, block uint64, index uint, value *big.Int, tokenID *big.Int) types.Log {
+	log := types.Log{
+		Address:     token,
+		Topics:      []common.Hash{TransferEventSignature, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		BlockNumber: block,
+		Index:       index,
+	}
+	if tokenID != nil {
+		log.Topics = append(log.Topics, common.BigToHash(tokenID))
+	} else {
+		log.Data = common.BigToHash(value).Bytes()
+	}
+	return log
+}
+
+func TestIndexer_TokenTransfers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := NewMockClient(ctrl)
+	token := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	alice := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	bob := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	fromAlice := []types.Log{
+		transferLog(token, alice, bob, 2, 0, big.NewInt(10), nil),
+		transferLog(token, alice, alice, 5, 1, big.NewInt(30), nil),
+	}
+	toAlice := []types.Log{
+		transferLog(token, bob, alice, 3, 4, nil, big.NewInt(7)),
+		transferLog(token, alice, alice, 5, 1, big.NewInt(30), nil),
+	}
+	mock.EXPECT().BlockNumber(gomock.Any()).Return(uint64(5), nil).AnyTimes()
+	mock.EXPECT().FilterLogs(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, query interfaces.FilterQuery) ([]types.Log, error) {
+			assert.Equal(t, []common.Address{token}, query.Addresses)
+			logs := fromAlice
+			if query.Topics[1] == nil {
+				logs = toAlice
+			}
+			selected := []types.Log{}
+			for _, log := range logs {
+				if log.BlockNumber >= query.FromBlock.Uint64() && log.BlockNumber <= query.ToBlock.Uint64() {
+					selected = append(selected, log)
+				}
+			}
+			return selected, nil
+		},
+	).AnyTimes()
+	indexer := NewIndexer(mock, WithIndexerRetryPolicy(testIndexerRetryPolicy), WithMaxBlockRange(4))
+	filter := TokenTransferFilter{Tokens: []common.Address{token}, Address: &alice}
+
+	page, err := indexer.TokenTransfers(context.Background(), filter, Query{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, TokenTransfer{Token: token, From: alice, To: bob, Value: big.NewInt(10), BlockNumber: 2}, page.Items[0])
+	assert.Equal(t, TokenTransfer{Token: token, From: bob, To: alice, TokenID: big.NewInt(7), BlockNumber: 3, LogIndex: 4}, page.Items[1])
+	assert.Equal(t, &Query{FromBlock: 5, FromIndex: 1, ToBlock: 5, Limit: 2}, page.Next)
+
+	// the self transfer is returned once
+	page, err = indexer.TokenTransfers(context.Background(), filter, *page.Next)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, big.NewInt(30), page.Items[0].Value)
+	assert.Nil(t, page.Next)
+}
+
+func TestIndexer_Retries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := NewMockClient(ctrl)
+	gomock.InOrder(
+		mock.EXPECT().BlockNumber(gomock.Any()).Return(uint64(0), errors.New("connection reset by peer")),
+		mock.EXPECT().BlockNumber(gomock.Any()).Return(uint64(0), nil),
+	)
+	mock.EXPECT().BlockByNumber(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection reset by peer")).Times(2)
+	indexer := NewIndexer(mock, WithIndexerRetryPolicy(testIndexerRetryPolicy))
+
+	_, err := indexer.Blocks(context.Background(), Query{})
+	require.ErrorIs(t, err, utils.ErrRetriesExhausted)
+	require.ErrorContains(t, err, "failure obtaining block 0")
+}
+
+func TestParseTokenTransfer(t *testing.T) {
+	token := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	log := transferLog(token, common.Address{1}, common.Address{2}, 1, 0, big.NewInt(5), nil)
+	transfer, ok := parseTokenTransfer(log)
+	require.True(t, ok)
+	assert.Equal(t, big.NewInt(5), transfer.Value)
+	assert.Nil(t, transfer.TokenID)
+
+	// ERC-20 transfers carry a 32 bytes amount
+	log.Data = log.Data[:4]
+	_, ok = parseTokenTransfer(log)
+	assert.False(t, ok)
+
+	log.Topics = log.Topics[:2]
+	_, ok = parseTokenTransfer(log)
+	assert.False(t, ok)
+}
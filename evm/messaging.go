@@ -0,0 +1,409 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/subnet-evm/accounts/abi"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/ethclient"
+	"github.com/DioneProtocol/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// DefaultMessagingTimeout is how long VerifyMessaging waits for the delivery of its message
+	DefaultMessagingTimeout = 2 * time.Minute
+
+	// DefaultMessagingPollInterval is how often VerifyMessaging looks for the delivery of its
+	// message on the destination chain
+	DefaultMessagingPollInterval = 2 * time.Second
+
+	// DefaultMessageRequiredGasLimit is the gas limit of the execution of the test message on
+	// the destination chain
+	DefaultMessageRequiredGasLimit = 100_000
+)
+
+var (
+	// ErrTeleporterNotDeployed is returned when a chain has no TeleporterMessenger at the
+	// address of its endpoint
+	ErrTeleporterNotDeployed = errors.New("teleporter messenger is not deployed")
+
+	// ErrMessageNotDelivered is returned when the test message is not received by the
+	// destination chain in time, e.g. because no relayer relays between the chains
+	ErrMessageNotDelivered = errors.New("cross-chain message was not delivered")
+
+	// ErrMessageExecutionFailed is returned when the test message is received but its
+	// receiver contract fails to execute it
+	ErrMessageExecutionFailed = errors.New("cross-chain message execution failed")
+)
+
+// teleporterMessengerABI holds the sendCrossChainMessage method of the TeleporterMessenger
+const teleporterMessengerABI = `[{
+	"type": "function",
+	"name": "sendCrossChainMessage",
+	"stateMutability": "nonpayable",
+	"inputs": [{
+		"name": "messageInput",
+		"type": "tuple",
+		"components": [
+			{"name": "destinationBlockchainID", "type": "bytes32"},
+			{"name": "destinationAddress", "type": "address"},
+			{"name": "feeInfo", "type": "tuple", "components": [
+				{"name": "feeTokenAddress", "type": "address"},
+				{"name": "amount", "type": "uint256"}
+			]},
+			{"name": "requiredGasLimit", "type": "uint256"},
+			{"name": "allowedRelayerAddresses", "type": "address[]"},
+			{"name": "message", "type": "bytes"}
+		]
+	}],
+	"outputs": [{"name": "", "type": "bytes32"}]
+}]`
+
+// teleporterMessageTuple is the ABI type of the TeleporterMessage struct in the events of the
+// TeleporterMessenger
+const teleporterMessageTuple = "(uint256,address,bytes32,address,uint256,address[],(uint256,address)[],bytes)"
+
+// Topics of the events of the TeleporterMessenger, all of them indexed by message ID
+var (
+	SendCrossChainMessageEventSignature = crypto.Keccak256Hash([]byte(
+		"SendCrossChainMessage(bytes32,bytes32," + teleporterMessageTuple + ",(address,uint256))",
+	))
+	ReceiveCrossChainMessageEventSignature = crypto.Keccak256Hash([]byte(
+		"ReceiveCrossChainMessage(bytes32,bytes32,address,address," + teleporterMessageTuple + ")",
+	))
+	MessageExecutedEventSignature        = crypto.Keccak256Hash([]byte("MessageExecuted(bytes32,bytes32)"))
+	MessageExecutionFailedEventSignature = crypto.Keccak256Hash([]byte(
+		"MessageExecutionFailed(bytes32,bytes32," + teleporterMessageTuple + ")",
+	))
+)
+
+type teleporterFeeInfo struct {
+	FeeTokenAddress common.Address
+	Amount          *big.Int
+}
+
+type teleporterMessageInput struct {
+	DestinationBlockchainID [32]byte
+	DestinationAddress      common.Address
+	FeeInfo                 teleporterFeeInfo
+	RequiredGasLimit        *big.Int
+	AllowedRelayerAddresses []common.Address
+	Message                 []byte
+}
+
+// MessagingEndpoint is a Subnet-EVM chain running a TeleporterMessenger
+type MessagingEndpoint struct {
+	Client       ethclient.Client
+	BlockchainID ids.ID
+
+	// TeleporterMessengerAddress is the address of the TeleporterMessenger of the chain
+	TeleporterMessengerAddress common.Address
+}
+
+// MessagingReport holds the outcome of a cross-chain message sent by VerifyMessaging
+type MessagingReport struct {
+	SourceBlockchainID      ids.ID
+	DestinationBlockchainID ids.ID
+	MessageID               common.Hash
+
+	SendTxHash common.Hash
+	SendBlock  uint64
+
+	// SentAt is the timestamp of the block of the send tx
+	SentAt time.Time
+
+	// Delivered is true if the destination chain received the message
+	Delivered      bool
+	DeliveryTxHash common.Hash
+	DeliveryBlock  uint64
+
+	// DeliveredAt is the timestamp of the block of the delivery tx
+	DeliveredAt time.Time
+
+	// Relayer is the address that delivered the message
+	Relayer common.Address
+
+	// Latency is the time the relayer took to deliver the message, from the timestamps of the
+	// blocks of the send and delivery txs, so at a second precision
+	Latency time.Duration
+
+	// Executed and ExecutionFailed tell if the destination address of the message executed
+	// it. Messages sent to an address without code, the sender by default, always fail to
+	// execute once delivered
+	Executed        bool
+	ExecutionFailed bool
+}
+
+// MessagingOp configures VerifyMessaging
+type MessagingOp struct {
+	timeout          time.Duration
+	pollInterval     time.Duration
+	message          []byte
+	receiver         *common.Address
+	requiredGasLimit uint64
+	allowedRelayers  []common.Address
+}
+
+// MessagingOption configures VerifyMessaging
+type MessagingOption func(*MessagingOp)
+
+// WithMessagingTimeout changes DefaultMessagingTimeout
+func WithMessagingTimeout(timeout time.Duration) MessagingOption {
+	return func(op *MessagingOp) {
+		op.timeout = timeout
+	}
+}
+
+// WithMessagingPollInterval changes DefaultMessagingPollInterval
+func WithMessagingPollInterval(interval time.Duration) MessagingOption {
+	return func(op *MessagingOp) {
+		op.pollInterval = interval
+	}
+}
+
+// WithTestMessage sets the payload of the test message
+func WithTestMessage(message []byte) MessagingOption {
+	return func(op *MessagingOp) {
+		op.message = message
+	}
+}
+
+// WithMessageReceiver sends the test message to a receiver contract on the destination
+// chain, failing with ErrMessageExecutionFailed if it does not execute it, and with
+// requiredGasLimit as gas limit of its execution if not zero
+func WithMessageReceiver(receiver common.Address, requiredGasLimit uint64) MessagingOption {
+	return func(op *MessagingOp) {
+		op.receiver = &receiver
+		if requiredGasLimit != 0 {
+			op.requiredGasLimit = requiredGasLimit
+		}
+	}
+}
+
+// WithAllowedRelayers only lets the relayers of addresses deliver the test message, e.g. to
+// check a given relayer
+func WithAllowedRelayers(addresses ...common.Address) MessagingOption {
+	return func(op *MessagingOp) {
+		op.allowedRelayers = addresses
+	}
+}
+
+// VerifyMessaging sends a test message from source to destination with the TeleporterMessenger
+// of the chains, paying the send tx with privateKey, and waits for a relayer to deliver it.
+// It is the last step of a cross-subnet deployment, proving that the messaging path works.
+// The report is returned along with ErrMessageNotDelivered or ErrMessageExecutionFailed once
+// the message was sent
+func VerifyMessaging(
+	ctx context.Context,
+	source MessagingEndpoint,
+	destination MessagingEndpoint,
+	privateKey string,
+	opts ...MessagingOption,
+) (*MessagingReport, error) {
+	op := MessagingOp{
+		timeout:          DefaultMessagingTimeout,
+		pollInterval:     DefaultMessagingPollInterval,
+		message:          []byte("odyssey-sdk messaging check"),
+		requiredGasLimit: DefaultMessageRequiredGasLimit,
+	}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	key, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	receiver := sender
+	if op.receiver != nil {
+		receiver = *op.receiver
+	}
+	for _, endpoint := range []MessagingEndpoint{source, destination} {
+		if err := checkTeleporterDeployed(ctx, endpoint); err != nil {
+			return nil, err
+		}
+	}
+	// the delivery is looked for from the current block of the destination chain
+	fromBlock, err := retryWithPolicy(
+		ctx,
+		retryPolicy,
+		fmt.Sprintf("failure obtaining block number of blockchain %s", destination.BlockchainID),
+		func(ctx context.Context) (uint64, error) { return destination.Client.BlockNumber(ctx) },
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedABI, err := parseTeleporterMessengerABI()
+	if err != nil {
+		return nil, err
+	}
+	data, err := parsedABI.Pack("sendCrossChainMessage", teleporterMessageInput{
+		DestinationBlockchainID: destination.BlockchainID,
+		DestinationAddress:      receiver,
+		FeeInfo:                 teleporterFeeInfo{Amount: big.NewInt(0)},
+		RequiredGasLimit:        new(big.Int).SetUint64(op.requiredGasLimit),
+		AllowedRelayerAddresses: op.allowedRelayers,
+		Message:                 op.message,
+	})
+	if err != nil {
+		return nil, err
+	}
+	tx, err := newCallTx(source.Client, sender, source.TeleporterMessengerAddress, data)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := SignAndIssueTx(source.Client, privateKey, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failure sending message from blockchain %s: %w", source.BlockchainID, err)
+	}
+	report := &MessagingReport{
+		SourceBlockchainID:      source.BlockchainID,
+		DestinationBlockchainID: destination.BlockchainID,
+		SendTxHash:              receipt.TxHash,
+		SendBlock:               receipt.BlockNumber.Uint64(),
+	}
+	for _, log := range receipt.Logs {
+		if log.Address == source.TeleporterMessengerAddress &&
+			len(log.Topics) == 3 &&
+			log.Topics[0] == SendCrossChainMessageEventSignature {
+			report.MessageID = log.Topics[1]
+		}
+	}
+	if report.MessageID == (common.Hash{}) {
+		return nil, fmt.Errorf("send tx %s emitted no SendCrossChainMessage event", receipt.TxHash)
+	}
+	if report.SentAt, err = blockTime(ctx, source, report.SendBlock); err != nil {
+		return nil, err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, op.timeout)
+	defer cancel()
+	ticker := time.NewTicker(op.pollInterval)
+	defer ticker.Stop()
+	for {
+		delivered, err := findDelivery(waitCtx, destination, fromBlock, report)
+		if err != nil && waitCtx.Err() == nil {
+			return report, err
+		}
+		if delivered {
+			break
+		}
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return report, ctx.Err()
+			}
+			return report, fmt.Errorf(
+				"%w: message %s from blockchain %s to %s not received after %s",
+				ErrMessageNotDelivered, report.MessageID, source.BlockchainID, destination.BlockchainID, op.timeout,
+			)
+		case <-ticker.C:
+		}
+	}
+	if report.DeliveredAt, err = blockTime(ctx, destination, report.DeliveryBlock); err != nil {
+		return report, err
+	}
+	report.Latency = report.DeliveredAt.Sub(report.SentAt)
+	if report.ExecutionFailed && op.receiver != nil {
+		return report, fmt.Errorf("%w: message %s to %s", ErrMessageExecutionFailed, report.MessageID, receiver)
+	}
+	return report, nil
+}
+
+func parseTeleporterMessengerABI() (abi.ABI, error) {
+	return abi.JSON(strings.NewReader(teleporterMessengerABI))
+}
+
+// checkTeleporterDeployed checks that the chain of endpoint has code at the address of its
+// TeleporterMessenger
+func checkTeleporterDeployed(ctx context.Context, endpoint MessagingEndpoint) error {
+	code, err := retryWithPolicy(
+		ctx,
+		retryPolicy,
+		fmt.Sprintf("failure obtaining code of %s on blockchain %s", endpoint.TeleporterMessengerAddress, endpoint.BlockchainID),
+		func(ctx context.Context) ([]byte, error) {
+			return endpoint.Client.CodeAt(ctx, endpoint.TeleporterMessengerAddress, nil)
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("%w on blockchain %s at %s", ErrTeleporterNotDeployed, endpoint.BlockchainID, endpoint.TeleporterMessengerAddress)
+	}
+	return nil
+}
+
+// findDelivery looks for the events of the message of report on the destination chain from
+// fromBlock, filling report with them. It returns true once the message was received
+func findDelivery(
+	ctx context.Context,
+	destination MessagingEndpoint,
+	fromBlock uint64,
+	report *MessagingReport,
+) (bool, error) {
+	query := interfaces.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		Addresses: []common.Address{destination.TeleporterMessengerAddress},
+		Topics: [][]common.Hash{
+			{ReceiveCrossChainMessageEventSignature, MessageExecutedEventSignature, MessageExecutionFailedEventSignature},
+			{report.MessageID},
+		},
+	}
+	logs, err := retryWithPolicy(
+		ctx,
+		retryPolicy,
+		fmt.Sprintf("failure obtaining logs of message %s on blockchain %s", report.MessageID, destination.BlockchainID),
+		func(ctx context.Context) ([]types.Log, error) { return destination.Client.FilterLogs(ctx, query) },
+	)
+	if err != nil {
+		return false, err
+	}
+	for _, log := range logs {
+		if log.Removed || len(log.Topics) < 2 {
+			continue
+		}
+		switch log.Topics[0] {
+		case ReceiveCrossChainMessageEventSignature:
+			if len(log.Topics) != 4 {
+				continue
+			}
+			report.Delivered = true
+			report.DeliveryTxHash = log.TxHash
+			report.DeliveryBlock = log.BlockNumber
+			report.Relayer = common.BytesToAddress(log.Topics[3].Bytes())
+		case MessageExecutedEventSignature:
+			report.Executed = true
+		case MessageExecutionFailedEventSignature:
+			report.ExecutionFailed = true
+		}
+	}
+	return report.Delivered, nil
+}
+
+// blockTime returns the timestamp of the block number of the chain of endpoint
+func blockTime(ctx context.Context, endpoint MessagingEndpoint, number uint64) (time.Time, error) {
+	header, err := retryWithPolicy(
+		ctx,
+		retryPolicy,
+		fmt.Sprintf("failure obtaining block %d of blockchain %s", number, endpoint.BlockchainID),
+		func(ctx context.Context) (*types.Header, error) {
+			return endpoint.Client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+		},
+	)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(header.Time), 0), nil
+}
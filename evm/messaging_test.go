@@ -0,0 +1,190 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testMessagingKey = "56289e99c94b6912bfc12adc093c9b51124f0dc54ac7a766b2bc5ccf558d8027"
+
+var testMessengerAddress = common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf")
+
+// testMessagingPath is a source and a destination chain whose mock clients serve the send tx
+// of a test message, and its delivery once delivery is set
+type testMessagingPath struct {
+	source      MessagingEndpoint
+	destination MessagingEndpoint
+	messageID   common.Hash
+	sentData    []byte
+
+	// delivery holds the logs of the destination chain after the send tx, served after
+	// pendingPolls polls
+	delivery     []types.Log
+	pendingPolls int
+}
+
+func newTestMessagingPath(t *testing.T) *testMessagingPath {
+	ctrl := gomock.NewController(t)
+	sourceMock := NewMockClient(ctrl)
+	destinationMock := NewMockClient(ctrl)
+	path := &testMessagingPath{
+		source:      MessagingEndpoint{Client: sourceMock, BlockchainID: ids.GenerateTestID(), TeleporterMessengerAddress: testMessengerAddress},
+		destination: MessagingEndpoint{Client: destinationMock, BlockchainID: ids.GenerateTestID(), TeleporterMessengerAddress: testMessengerAddress},
+		messageID:   common.HexToHash("0x1234"),
+	}
+
+	sourceMock.EXPECT().CodeAt(gomock.Any(), testMessengerAddress, gomock.Nil()).Return([]byte{0x60}, nil).AnyTimes()
+	sourceMock.EXPECT().ChainID(gomock.Any()).Return(big.NewInt(99999), nil).AnyTimes()
+	sourceMock.EXPECT().EstimateBaseFee(gomock.Any()).Return(big.NewInt(25), nil).AnyTimes()
+	sourceMock.EXPECT().SuggestGasTipCap(gomock.Any()).Return(big.NewInt(1), nil).AnyTimes()
+	sourceMock.EXPECT().NonceAt(gomock.Any(), gomock.Any(), gomock.Nil()).Return(uint64(0), nil).AnyTimes()
+	sourceMock.EXPECT().EstimateGas(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, msg interfaces.CallMsg) (uint64, error) {
+			path.sentData = msg.Data
+			return 200_000, nil
+		},
+	).AnyTimes()
+	sourceMock.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	sourceMock.EXPECT().TransactionReceipt(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+			return &types.Receipt{
+				Status:      types.ReceiptStatusSuccessful,
+				TxHash:      txHash,
+				BlockNumber: big.NewInt(10),
+				Logs: []*types.Log{{
+					Address: testMessengerAddress,
+					Topics: []common.Hash{
+						SendCrossChainMessageEventSignature,
+						path.messageID,
+						common.Hash(path.destination.BlockchainID),
+					},
+				}},
+			}, nil
+		},
+	).AnyTimes()
+	sourceMock.EXPECT().HeaderByNumber(gomock.Any(), big.NewInt(10)).Return(&types.Header{Time: 1000}, nil).AnyTimes()
+
+	destinationMock.EXPECT().CodeAt(gomock.Any(), testMessengerAddress, gomock.Nil()).Return([]byte{0x60}, nil).AnyTimes()
+	destinationMock.EXPECT().BlockNumber(gomock.Any()).Return(uint64(50), nil).AnyTimes()
+	destinationMock.EXPECT().FilterLogs(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, query interfaces.FilterQuery) ([]types.Log, error) {
+			require.Equal(t, big.NewInt(50), query.FromBlock)
+			require.Equal(t, []common.Hash{path.messageID}, query.Topics[1])
+			if path.pendingPolls > 0 {
+				path.pendingPolls--
+				return nil, nil
+			}
+			return path.delivery, nil
+		},
+	).AnyTimes()
+	destinationMock.EXPECT().HeaderByNumber(gomock.Any(), big.NewInt(52)).Return(&types.Header{Time: 1003}, nil).AnyTimes()
+	return path
+}
+
+// deliver makes relayer deliver the message in block 52 of the destination chain, its
+// execution emitting executionEvent
+func (p *testMessagingPath) deliver(relayer common.Address, executionEvent common.Hash) {
+	p.delivery = []types.Log{
+		{
+			Address:     testMessengerAddress,
+			Topics:      []common.Hash{ReceiveCrossChainMessageEventSignature, p.messageID, common.Hash(p.source.BlockchainID), common.BytesToHash(relayer.Bytes())},
+			TxHash:      common.HexToHash("0xde11"),
+			BlockNumber: 52,
+		},
+		{
+			Address:     testMessengerAddress,
+			Topics:      []common.Hash{executionEvent, p.messageID, common.Hash(p.source.BlockchainID)},
+			TxHash:      common.HexToHash("0xde11"),
+			BlockNumber: 52,
+		},
+	}
+}
+
+func TestVerifyMessaging(t *testing.T) {
+	ctx := context.Background()
+	relayer := common.HexToAddress("0x0a")
+	pollInterval := WithMessagingPollInterval(time.Millisecond)
+
+	t.Run("delivered", func(t *testing.T) {
+		path := newTestMessagingPath(t)
+		path.deliver(relayer, MessageExecutionFailedEventSignature)
+		report, err := VerifyMessaging(ctx, path.source, path.destination, testMessagingKey, pollInterval, WithTestMessage([]byte("ping")))
+		require.NoError(t, err)
+		assert.True(t, report.Delivered)
+		assert.Equal(t, path.messageID, report.MessageID)
+		assert.Equal(t, uint64(10), report.SendBlock)
+		assert.Equal(t, uint64(52), report.DeliveryBlock)
+		assert.Equal(t, common.HexToHash("0xde11"), report.DeliveryTxHash)
+		assert.Equal(t, relayer, report.Relayer)
+		assert.Equal(t, 3*time.Second, report.Latency)
+		// the sender has no code to execute the message
+		assert.True(t, report.ExecutionFailed)
+
+		parsedABI, err := parseTeleporterMessengerABI()
+		require.NoError(t, err)
+		args, err := parsedABI.Methods["sendCrossChainMessage"].Inputs.Unpack(path.sentData[4:])
+		require.NoError(t, err)
+		input := args[0].(struct {
+			DestinationBlockchainID [32]byte       `json:"destinationBlockchainID"`
+			DestinationAddress      common.Address `json:"destinationAddress"`
+			FeeInfo                 struct {
+				FeeTokenAddress common.Address `json:"feeTokenAddress"`
+				Amount          *big.Int       `json:"amount"`
+			} `json:"feeInfo"`
+			RequiredGasLimit        *big.Int         `json:"requiredGasLimit"`
+			AllowedRelayerAddresses []common.Address `json:"allowedRelayerAddresses"`
+			Message                 []byte           `json:"message"`
+		})
+		assert.Equal(t, [32]byte(path.destination.BlockchainID), input.DestinationBlockchainID)
+		assert.Equal(t, []byte("ping"), input.Message)
+		assert.Equal(t, big.NewInt(DefaultMessageRequiredGasLimit), input.RequiredGasLimit)
+	})
+
+	t.Run("not delivered", func(t *testing.T) {
+		path := newTestMessagingPath(t)
+		report, err := VerifyMessaging(ctx, path.source, path.destination, testMessagingKey, pollInterval, WithMessagingTimeout(20*time.Millisecond))
+		require.ErrorIs(t, err, ErrMessageNotDelivered)
+		require.NotNil(t, report)
+		assert.False(t, report.Delivered)
+		assert.Equal(t, path.messageID, report.MessageID)
+	})
+
+	t.Run("delivered later", func(t *testing.T) {
+		path := newTestMessagingPath(t)
+		path.deliver(relayer, MessageExecutedEventSignature)
+		path.pendingPolls = 3
+		report, err := VerifyMessaging(ctx, path.source, path.destination, testMessagingKey, pollInterval)
+		require.NoError(t, err)
+		assert.True(t, report.Delivered)
+		assert.True(t, report.Executed)
+		assert.Zero(t, path.pendingPolls)
+	})
+
+	t.Run("receiver execution failed", func(t *testing.T) {
+		path := newTestMessagingPath(t)
+		path.deliver(relayer, MessageExecutionFailedEventSignature)
+		report, err := VerifyMessaging(ctx, path.source, path.destination, testMessagingKey, pollInterval, WithMessageReceiver(common.HexToAddress("0x0b"), 0))
+		require.ErrorIs(t, err, ErrMessageExecutionFailed)
+		assert.True(t, report.Delivered)
+	})
+
+	t.Run("teleporter not deployed", func(t *testing.T) {
+		path := newTestMessagingPath(t)
+		path.destination.TeleporterMessengerAddress = common.HexToAddress("0x0c")
+		path.destination.Client.(*MockClient).EXPECT().CodeAt(gomock.Any(), common.HexToAddress("0x0c"), gomock.Nil()).Return(nil, nil)
+		_, err := VerifyMessaging(ctx, path.source, path.destination, testMessagingKey, pollInterval)
+		require.ErrorIs(t, err, ErrTeleporterNotDeployed)
+	})
+}
@@ -0,0 +1,232 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DioneProtocol/subnet-evm/commontype"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/ethclient"
+	"github.com/DioneProtocol/subnet-evm/interfaces"
+	"github.com/DioneProtocol/subnet-evm/precompile/allowlist"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/feemanager"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrPrecompileNotEnabled is returned when the chain does not run the precompile, which was
+	// not set in its genesis nor activated by an upgrade
+	ErrPrecompileNotEnabled = errors.New("precompile is not enabled on the chain")
+
+	// ErrPrecompileNotAllowed is returned when the sender of a precompile admin tx has no role
+	// in the allow list of the precompile, the tx being reverted otherwise
+	ErrPrecompileNotAllowed = errors.New("address is not allowed to call the precompile")
+)
+
+// AllowListRole returns the role of address in the allow list of the precompile at
+// precompileAddress, e.g. feemanager.ContractAddress, as currently set on the chain: the
+// admin, manager and enabled lists of its genesis or upgrade config, updated by the admins
+func AllowListRole(
+	client ethclient.Client,
+	precompileAddress common.Address,
+	address common.Address,
+) (allowlist.Role, error) {
+	output, err := callPrecompile(client, precompileAddress, allowlist.PackReadAllowList(address))
+	if err != nil {
+		return allowlist.NoRole, err
+	}
+	if len(output) != common.HashLength {
+		return allowlist.NoRole, fmt.Errorf("%w: %s", ErrPrecompileNotEnabled, precompileAddress)
+	}
+	return allowlist.Role(common.BytesToHash(output)), nil
+}
+
+// GetFeeConfig returns the fee config set by the FeeManager precompile
+func GetFeeConfig(client ethclient.Client) (commontype.FeeConfig, error) {
+	output, err := callPrecompile(client, feemanager.ContractAddress, feemanager.PackGetFeeConfigInput())
+	if err != nil {
+		return commontype.FeeConfig{}, err
+	}
+	if len(output) == 0 {
+		return commontype.FeeConfig{}, fmt.Errorf("%w: fee manager", ErrPrecompileNotEnabled)
+	}
+	return feemanager.UnpackFeeConfigInput(output)
+}
+
+// GetRewardAddress returns the address the RewardManager precompile sends the fees to, and
+// whether the block producers may set their own fee recipient instead. The fees are burnt
+// when the address is the blackhole address
+func GetRewardAddress(client ethclient.Client) (common.Address, bool, error) {
+	rewardAddress := common.Address{}
+	if err := callRewardManager(client, "currentRewardAddress", &rewardAddress); err != nil {
+		return common.Address{}, false, err
+	}
+	allowed := false
+	if err := callRewardManager(client, "areFeeRecipientsAllowed", &allowed); err != nil {
+		return common.Address{}, false, err
+	}
+	return rewardAddress, allowed, nil
+}
+
+// NewSetFeeConfigTx returns the unsigned tx from sender setting the fee config of the chain
+// with the FeeManager precompile
+func NewSetFeeConfigTx(
+	client ethclient.Client,
+	sender common.Address,
+	feeConfig commontype.FeeConfig,
+) (*types.Transaction, error) {
+	if err := feeConfig.Verify(); err != nil {
+		return nil, err
+	}
+	data, err := feemanager.PackSetFeeConfig(feeConfig)
+	if err != nil {
+		return nil, err
+	}
+	return newPrecompileTx(client, sender, feemanager.ContractAddress, data)
+}
+
+// NewSetRewardAddressTx returns the unsigned tx from sender making the RewardManager
+// precompile send the fees to rewardAddress
+func NewSetRewardAddressTx(
+	client ethclient.Client,
+	sender common.Address,
+	rewardAddress common.Address,
+) (*types.Transaction, error) {
+	data, err := rewardmanager.PackSetRewardAddress(rewardAddress)
+	if err != nil {
+		return nil, err
+	}
+	return newPrecompileTx(client, sender, rewardmanager.ContractAddress, data)
+}
+
+// NewAllowFeeRecipientsTx returns the unsigned tx from sender letting the block producers set
+// their own fee recipient with the RewardManager precompile
+func NewAllowFeeRecipientsTx(client ethclient.Client, sender common.Address) (*types.Transaction, error) {
+	data, err := rewardmanager.PackAllowFeeRecipients()
+	if err != nil {
+		return nil, err
+	}
+	return newPrecompileTx(client, sender, rewardmanager.ContractAddress, data)
+}
+
+// NewDisableRewardsTx returns the unsigned tx from sender making the RewardManager precompile
+// burn the fees
+func NewDisableRewardsTx(client ethclient.Client, sender common.Address) (*types.Transaction, error) {
+	data, err := rewardmanager.PackDisableRewards()
+	if err != nil {
+		return nil, err
+	}
+	return newPrecompileTx(client, sender, rewardmanager.ContractAddress, data)
+}
+
+// SignAndIssueTx signs tx with privateKey, sends it and waits for its receipt, failing with
+// ErrFailedReceiptStatus if the tx was reverted
+func SignAndIssueTx(
+	client ethclient.Client,
+	privateKey string,
+	tx *types.Transaction,
+) (*types.Receipt, error) {
+	key, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(tx.ChainId()), key)
+	if err != nil {
+		return nil, err
+	}
+	if err := SendTransaction(client, signedTx); err != nil {
+		return nil, err
+	}
+	receipt, success, err := WaitForTransaction(client, signedTx)
+	if err != nil {
+		return receipt, err
+	} else if !success {
+		return receipt, ErrFailedReceiptStatus
+	}
+	return receipt, nil
+}
+
+// newPrecompileTx returns the unsigned tx from sender calling the precompile at
+// precompileAddress with data, once checked that sender has a role in its allow list
+func newPrecompileTx(
+	client ethclient.Client,
+	sender common.Address,
+	precompileAddress common.Address,
+	data []byte,
+) (*types.Transaction, error) {
+	role, err := AllowListRole(client, precompileAddress, sender)
+	if err != nil {
+		return nil, err
+	}
+	if !role.IsEnabled() {
+		return nil, fmt.Errorf("%w: %s has role %s on precompile %s", ErrPrecompileNotAllowed, sender, role, precompileAddress)
+	}
+	return newCallTx(client, sender, precompileAddress, data)
+}
+
+// newCallTx returns the unsigned tx from sender calling the contract at to with data, its gas
+// estimated by the chain
+func newCallTx(
+	client ethclient.Client,
+	sender common.Address,
+	to common.Address,
+	data []byte,
+) (*types.Transaction, error) {
+	chainID, err := GetChainID(client)
+	if err != nil {
+		return nil, err
+	}
+	gasFeeCap, gasTipCap, nonce, err := CalculateTxParams(client, sender.Hex())
+	if err != nil {
+		return nil, err
+	}
+	gas, err := retry(
+		fmt.Sprintf("failure estimating gas of contract %s call on %#v", to, client),
+		func(ctx context.Context) (uint64, error) {
+			return client.EstimateGas(ctx, interfaces.CallMsg{From: sender, To: &to, Data: data})
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		To:        &to,
+		Gas:       gas,
+		GasFeeCap: gasFeeCap,
+		GasTipCap: gasTipCap,
+		Data:      data,
+	}), nil
+}
+
+func callPrecompile(client ethclient.Client, precompileAddress common.Address, data []byte) ([]byte, error) {
+	return retry(
+		fmt.Sprintf("failure calling precompile %s on %#v", precompileAddress, client),
+		func(ctx context.Context) ([]byte, error) {
+			return client.CallContract(ctx, interfaces.CallMsg{To: &precompileAddress, Data: data}, nil)
+		},
+	)
+}
+
+// callRewardManager calls the view method of the RewardManager precompile, unpacking its
+// single output into out
+func callRewardManager(client ethclient.Client, method string, out interface{}) error {
+	data, err := rewardmanager.RewardManagerABI.Pack(method)
+	if err != nil {
+		return err
+	}
+	output, err := callPrecompile(client, rewardmanager.ContractAddress, data)
+	if err != nil {
+		return err
+	}
+	if len(output) == 0 {
+		return fmt.Errorf("%w: reward manager", ErrPrecompileNotEnabled)
+	}
+	return rewardmanager.RewardManagerABI.UnpackIntoInterface(out, method, output)
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package evm
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/DioneProtocol/subnet-evm/commontype"
+	"github.com/DioneProtocol/subnet-evm/interfaces"
+	"github.com/DioneProtocol/subnet-evm/precompile/allowlist"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/feemanager"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testFeeConfig = commontype.FeeConfig{
+	GasLimit:                 big.NewInt(8_000_000),
+	TargetBlockRate:          2,
+	MinBaseFee:               big.NewInt(25_000_000_000),
+	TargetGas:                big.NewInt(15_000_000),
+	BaseFeeChangeDenominator: big.NewInt(36),
+	MinBlockGasCost:          big.NewInt(0),
+	MaxBlockGasCost:          big.NewInt(1_000_000),
+	BlockGasCostStep:         big.NewInt(200_000),
+}
+
+// expectPrecompileCall makes the mock client answer the calls of data to precompileAddress
+// with output
+func expectPrecompileCall(mock *MockClient, precompileAddress common.Address, data []byte, output []byte) {
+	mock.EXPECT().CallContract(gomock.Any(), gomock.Cond(func(x any) bool {
+		msg := x.(interfaces.CallMsg)
+		return *msg.To == precompileAddress && bytes.Equal(msg.Data, data)
+	}), gomock.Nil()).Return(output, nil).AnyTimes()
+}
+
+func TestAllowListRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := NewMockClient(ctrl)
+	admin := common.HexToAddress("0x01")
+	expectPrecompileCall(mock, feemanager.ContractAddress, allowlist.PackReadAllowList(admin), common.Hash(allowlist.AdminRole).Bytes())
+	expectPrecompileCall(mock, rewardmanager.ContractAddress, allowlist.PackReadAllowList(admin), nil)
+
+	role, err := AllowListRole(mock, feemanager.ContractAddress, admin)
+	require.NoError(t, err)
+	assert.Equal(t, allowlist.AdminRole, role)
+
+	// calls to precompiles not enabled return nothing
+	_, err = AllowListRole(mock, rewardmanager.ContractAddress, admin)
+	require.ErrorIs(t, err, ErrPrecompileNotEnabled)
+}
+
+func TestNewPrecompileTxs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := NewMockClient(ctrl)
+	enabled := common.HexToAddress("0x01")
+	stranger := common.HexToAddress("0x02")
+	rewardAddress := common.HexToAddress("0x03")
+	for _, precompileAddress := range []common.Address{feemanager.ContractAddress, rewardmanager.ContractAddress} {
+		expectPrecompileCall(mock, precompileAddress, allowlist.PackReadAllowList(enabled), common.Hash(allowlist.EnabledRole).Bytes())
+		expectPrecompileCall(mock, precompileAddress, allowlist.PackReadAllowList(stranger), common.Hash(allowlist.NoRole).Bytes())
+	}
+	mock.EXPECT().ChainID(gomock.Any()).Return(big.NewInt(99999), nil).AnyTimes()
+	mock.EXPECT().EstimateBaseFee(gomock.Any()).Return(big.NewInt(25), nil).AnyTimes()
+	mock.EXPECT().SuggestGasTipCap(gomock.Any()).Return(big.NewInt(1), nil).AnyTimes()
+	mock.EXPECT().NonceAt(gomock.Any(), enabled, gomock.Nil()).Return(uint64(7), nil).AnyTimes()
+	mock.EXPECT().EstimateGas(gomock.Any(), gomock.Any()).Return(uint64(50_000), nil).AnyTimes()
+
+	tx, err := NewSetFeeConfigTx(mock, enabled, testFeeConfig)
+	require.NoError(t, err)
+	assert.Equal(t, feemanager.ContractAddress, *tx.To())
+	assert.Equal(t, uint64(7), tx.Nonce())
+	assert.Equal(t, uint64(50_000), tx.Gas())
+	assert.Equal(t, big.NewInt(99999), tx.ChainId())
+	feeConfig, err := feemanager.UnpackFeeConfigInput(tx.Data()[4:])
+	require.NoError(t, err)
+	assert.True(t, testFeeConfig.Equal(&feeConfig))
+
+	tx, err = NewSetRewardAddressTx(mock, enabled, rewardAddress)
+	require.NoError(t, err)
+	assert.Equal(t, rewardmanager.ContractAddress, *tx.To())
+	unpacked, err := rewardmanager.UnpackSetRewardAddressInput(tx.Data()[4:])
+	require.NoError(t, err)
+	assert.Equal(t, rewardAddress, unpacked)
+
+	_, err = NewAllowFeeRecipientsTx(mock, enabled)
+	require.NoError(t, err)
+	_, err = NewDisableRewardsTx(mock, stranger)
+	require.ErrorIs(t, err, ErrPrecompileNotAllowed)
+	require.ErrorContains(t, err, "NoRole")
+
+	invalid := testFeeConfig
+	invalid.GasLimit = big.NewInt(0)
+	_, err = NewSetFeeConfigTx(mock, enabled, invalid)
+	require.Error(t, err)
+}
+
+func TestGetPrecompileState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := NewMockClient(ctrl)
+	rewardAddress := common.HexToAddress("0x03")
+	feeConfigOutput, err := feemanager.PackFeeConfig(testFeeConfig)
+	require.NoError(t, err)
+	expectPrecompileCall(mock, feemanager.ContractAddress, feemanager.PackGetFeeConfigInput(), feeConfigOutput)
+	currentRewardAddress, err := rewardmanager.PackCurrentRewardAddress()
+	require.NoError(t, err)
+	currentRewardAddressOutput, err := rewardmanager.PackCurrentRewardAddressOutput(rewardAddress)
+	require.NoError(t, err)
+	expectPrecompileCall(mock, rewardmanager.ContractAddress, currentRewardAddress, currentRewardAddressOutput)
+	areFeeRecipientsAllowed, err := rewardmanager.PackAreFeeRecipientsAllowed()
+	require.NoError(t, err)
+	areFeeRecipientsAllowedOutput, err := rewardmanager.PackAreFeeRecipientsAllowedOutput(false)
+	require.NoError(t, err)
+	expectPrecompileCall(mock, rewardmanager.ContractAddress, areFeeRecipientsAllowed, areFeeRecipientsAllowedOutput)
+
+	feeConfig, err := GetFeeConfig(mock)
+	require.NoError(t, err)
+	assert.True(t, testFeeConfig.Equal(&feeConfig))
+
+	address, allowed, err := GetRewardAddress(mock)
+	require.NoError(t, err)
+	assert.Equal(t, rewardAddress, address)
+	assert.False(t, allowed)
+}
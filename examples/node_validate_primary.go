@@ -15,7 +15,6 @@ import (
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
 	"github.com/DioneProtocol/odysseygo/ids"
 	"github.com/DioneProtocol/odysseygo/utils/units"
-	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
 	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
 
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node"
@@ -64,7 +63,6 @@ func ValidatePrimaryNetwork() {
 		&primary.WalletConfig{
 			URI:              network.Endpoint,
 			DIONEKeychain:    keychain.Keychain,
-			EthKeychain:      secp256k1fx.NewKeychain(),
 			OChainTxsToFetch: nil,
 		},
 	)
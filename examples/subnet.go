@@ -17,7 +17,6 @@ import (
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/subnet"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/vm"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
-	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
 	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
 	"github.com/DioneProtocol/subnet-evm/core"
 	"github.com/DioneProtocol/subnet-evm/params"
@@ -77,7 +76,6 @@ func DeploySubnet() {
 		&primary.WalletConfig{
 			URI:              network.Endpoint,
 			DIONEKeychain:    keychain.Keychain,
-			EthKeychain:      secp256k1fx.NewKeychain(),
 			OChainTxsToFetch: nil,
 		},
 	)
@@ -135,7 +133,6 @@ func DeploySubnetWithLedger() {
 		&primary.WalletConfig{
 			URI:              network.Endpoint,
 			DIONEKeychain:    keychainA.Keychain,
-			EthKeychain:      secp256k1fx.NewKeychain(),
 			OChainTxsToFetch: nil,
 		},
 	)
@@ -178,7 +175,6 @@ func DeploySubnetWithLedger() {
 		&primary.WalletConfig{
 			URI:              network.Endpoint,
 			DIONEKeychain:    keychainB.Keychain,
-			EthKeychain:      secp256k1fx.NewKeychain(),
 			OChainTxsToFetch: set.Of(subnetID),
 		},
 	)
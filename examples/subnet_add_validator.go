@@ -17,7 +17,6 @@ import (
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
 	"github.com/DioneProtocol/odysseygo/ids"
 	"github.com/DioneProtocol/odysseygo/utils/set"
-	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
 	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
 )
 
@@ -82,7 +81,6 @@ func AddSubnetValidator() {
 		&primary.WalletConfig{
 			URI:              network.Endpoint,
 			DIONEKeychain:    keychain.Keychain,
-			EthKeychain:      secp256k1fx.NewKeychain(),
 			OChainTxsToFetch: set.Of(subnetID),
 		},
 	)
@@ -14,7 +14,6 @@ import (
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
 	"github.com/DioneProtocol/odysseygo/ids"
 	"github.com/DioneProtocol/odysseygo/utils/set"
-	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
 	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
 )
 
@@ -63,7 +62,6 @@ func DeploySubnetMultiSig() {
 		&primary.WalletConfig{
 			URI:              network.Endpoint,
 			DIONEKeychain:    keychainA.Keychain,
-			EthKeychain:      secp256k1fx.NewKeychain(),
 			OChainTxsToFetch: nil,
 		},
 	)
@@ -78,7 +76,7 @@ func DeploySubnetMultiSig() {
 	newSubnet.SetSubnetAuthKeys(subnetAuthKeys)
 	deployChainTx, err := newSubnet.CreateBlockchainTx(walletA)
 	if err != nil {
-		fmt.Errorf("error signing tx walletA: %w", err)
+		panic(fmt.Errorf("error signing tx walletA: %w", err))
 	}
 
 	// we need to include subnetID in OChainTxsToFetch when creating second wallet
@@ -89,14 +87,13 @@ func DeploySubnetMultiSig() {
 		&primary.WalletConfig{
 			URI:              network.Endpoint,
 			DIONEKeychain:    keychainB.Keychain,
-			EthKeychain:      secp256k1fx.NewKeychain(),
 			OChainTxsToFetch: set.Of(subnetID),
 		},
 	)
 
 	// sign with second wallet so that we have 2/2 threshold reached
 	if err := walletB.O().Signer().Sign(context.Background(), deployChainTx.OChainTx); err != nil {
-		fmt.Errorf("error signing tx walletB: %w", err)
+		panic(fmt.Errorf("error signing tx walletB: %w", err))
 	}
 
 	// since we have two signatures, we can now commit the transaction on chain
@@ -3,9 +3,11 @@
 package install
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/releases"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
 )
 
@@ -18,6 +20,22 @@ const (
 	CustomRelease
 )
 
+// InstallOp holds the options of InstallGithubRelease
+type InstallOp struct {
+	strictChecksum bool
+}
+
+// InstallOption configures InstallGithubRelease
+type InstallOption func(*InstallOp)
+
+// WithStrictChecksum fails the installation of release assets without a published checksum,
+// which are otherwise installed unverified
+func WithStrictChecksum() InstallOption {
+	return func(op *InstallOp) {
+		op.strictChecksum = true
+	}
+}
+
 // installs archive from given [url] into [outputDir], unless
 // [relativeBinPath] exists in [outputDir] and is executable.
 // verifies [relativeBinPath] exists in [outputDir] after installation,
@@ -36,6 +54,18 @@ func InstallBinary(
 	if err != nil {
 		return "", err
 	}
+	return extractBinary(bs, archiveKind, outputDir, relativeBinPath)
+}
+
+// extracts archive [bs] into [outputDir], and verifies [relativeBinPath]
+// exists in [outputDir] and is executable
+func extractBinary(
+	bs []byte,
+	archiveKind ArchiveKind,
+	outputDir string,
+	relativeBinPath string,
+) (string, error) {
+	expectedBinPath := filepath.Join(outputDir, relativeBinPath)
 	if err := ExtractArchive(archiveKind, bs, outputDir); err != nil {
 		return "", err
 	}
@@ -65,6 +95,10 @@ func InstallBinaryVersion(
 	)
 }
 
+// installs the asset [getAssetName] of a release of [org]/[repo] into a
+// version subdir of [baseDir], verifying it against the checksum published
+// with the release if any, see releases.Client.DownloadAsset.
+// see InstallBinary from installation checks
 func InstallGithubRelease(
 	org string,
 	repo string,
@@ -75,19 +109,50 @@ func InstallGithubRelease(
 	archiveKind ArchiveKind,
 	baseDir string,
 	relativeBinPath string,
+	opts ...InstallOption,
+) (string, error) {
+	return installGithubRelease(
+		context.Background(),
+		releases.NewClient(org, repo, releases.WithAuthToken(authToken)),
+		releaseKind,
+		customVersion,
+		getAssetName,
+		archiveKind,
+		baseDir,
+		relativeBinPath,
+		opts...,
+	)
+}
+
+// installs the release asset downloaded with [client]
+// see InstallGithubRelease
+func installGithubRelease(
+	ctx context.Context,
+	client *releases.Client,
+	releaseKind ReleaseKind,
+	customVersion string,
+	getAssetName func(string) (string, error),
+	archiveKind ArchiveKind,
+	baseDir string,
+	relativeBinPath string,
+	opts ...InstallOption,
 ) (string, error) {
+	op := InstallOp{}
+	for _, opt := range opts {
+		opt(&op)
+	}
 	var (
 		version string
 		err     error
 	)
 	switch releaseKind {
 	case LatestRelease:
-		version, err = utils.GetLatestGithubReleaseVersion(org, repo, authToken)
+		version, err = client.LatestVersion(ctx)
 		if err != nil {
 			return "", err
 		}
 	case LatestPreRelease:
-		version, err = utils.GetLatestGithubPreReleaseVersion(org, repo, authToken)
+		version, err = client.LatestVersion(ctx, releases.WithPreReleases())
 		if err != nil {
 			return "", err
 		}
@@ -100,17 +165,18 @@ func InstallGithubRelease(
 	if err != nil {
 		return "", err
 	}
-	url := utils.GetGithubReleaseAssetURL(
-		org,
-		repo,
-		version,
-		asset,
-	)
-	return InstallBinaryVersion(
-		url,
-		archiveKind,
-		baseDir,
-		relativeBinPath,
-		version,
-	)
+	outputDir := filepath.Join(baseDir, version)
+	expectedBinPath := filepath.Join(outputDir, relativeBinPath)
+	if utils.IsExecutable(expectedBinPath) {
+		return expectedBinPath, nil
+	}
+	downloadOpts := []releases.DownloadOption{}
+	if !op.strictChecksum {
+		downloadOpts = append(downloadOpts, releases.WithUnverifiedFallback())
+	}
+	bs, err := client.DownloadAsset(ctx, version, asset, downloadOpts...)
+	if err != nil {
+		return "", err
+	}
+	return extractBinary(bs, archiveKind, outputDir, relativeBinPath)
 }
@@ -4,10 +4,21 @@
 package install
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/releases"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -254,6 +265,76 @@ func TestInstallGithubRelease(t *testing.T) {
 	}
 }
 
+func TestInstallGithubRelease_Checksum(t *testing.T) {
+	var archive bytes.Buffer
+	gzWriter := gzip.NewWriter(&archive)
+	tarWriter := tar.NewWriter(gzWriter)
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "bin/tool", Mode: 0o755, Size: 4}))
+	_, err := tarWriter.Write([]byte("tool"))
+	require.NoError(t, err)
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzWriter.Close())
+	sum := sha256.Sum256(archive.Bytes())
+
+	// newServer publishes checksum with the release, no checksum if empty
+	newServer := func(checksum string) *httptest.Server {
+		var server *httptest.Server
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/repos/testorg/testrepo/releases":
+				assets := []releases.Asset{{Name: "tool.tar.gz", URL: server.URL + "/tool.tar.gz"}}
+				if checksum != "" {
+					assets = append(assets, releases.Asset{Name: "tool.tar.gz.sha256", URL: server.URL + "/tool.tar.gz.sha256"})
+				}
+				require.NoError(t, json.NewEncoder(w).Encode([]releases.Release{{
+					Version: "v1.0.0",
+					Assets:  assets,
+				}}))
+			case "/tool.tar.gz":
+				_, _ = w.Write(archive.Bytes())
+			case "/tool.tar.gz.sha256":
+				_, _ = w.Write([]byte(checksum + "  tool.tar.gz\n"))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	getAssetName := func(string) (string, error) {
+		return "tool.tar.gz", nil
+	}
+
+	t.Run("valid checksum", func(t *testing.T) {
+		client := releases.NewClient("testorg", "testrepo", releases.WithBaseURL(newServer(hex.EncodeToString(sum[:])).URL))
+		baseDir := t.TempDir()
+		binPath, err := installGithubRelease(context.Background(), client, LatestRelease, "", getAssetName, TarGz, baseDir, "bin/tool")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(baseDir, "v1.0.0", "bin/tool"), binPath)
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		client := releases.NewClient("testorg", "testrepo", releases.WithBaseURL(newServer(strings.Repeat("0", 64)).URL))
+		baseDir := t.TempDir()
+		_, err := installGithubRelease(context.Background(), client, CustomRelease, "v1.0.0", getAssetName, TarGz, baseDir, "bin/tool")
+		require.ErrorIs(t, err, releases.ErrChecksumMismatch)
+		assert.NoFileExists(t, filepath.Join(baseDir, "v1.0.0", "bin/tool"))
+	})
+
+	t.Run("no checksum", func(t *testing.T) {
+		client := releases.NewClient("testorg", "testrepo", releases.WithBaseURL(newServer("").URL))
+		baseDir := t.TempDir()
+		_, err := installGithubRelease(context.Background(), client, CustomRelease, "v1.0.0", getAssetName, TarGz, baseDir, "bin/tool", WithStrictChecksum())
+		require.ErrorIs(t, err, releases.ErrNoChecksum)
+		assert.NoFileExists(t, filepath.Join(baseDir, "v1.0.0", "bin/tool"))
+
+		// the asset is installed unverified unless the checksum is strictly checked
+		binPath, err := installGithubRelease(context.Background(), client, CustomRelease, "v1.0.0", getAssetName, TarGz, baseDir, "bin/tool")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(baseDir, "v1.0.0", "bin/tool"), binPath)
+	})
+}
+
 func TestReleaseKindString(t *testing.T) {
 	tests := []struct {
 		kind     ReleaseKind
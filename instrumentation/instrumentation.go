@@ -0,0 +1,152 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package instrumentation lets applications embedding the SDK observe its wallet, node and
+// multisig operations. Hooks registered with Register receive the requests made by the SDK,
+// the txs it issues and the SSH commands it runs. NewPrometheusHooks and NewTracingHooks
+// provide built-in Prometheus metrics and OpenTelemetry spans
+package instrumentation
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+)
+
+// Components of the SDK emitting requests
+const (
+	ComponentWallet   = "wallet"
+	ComponentNode     = "node"
+	ComponentMultisig = "multisig"
+)
+
+// Request is an operation of the SDK
+type Request struct {
+	// Component is the part of the SDK running the operation, e.g. ComponentWallet
+	Component string
+
+	// Operation names the operation, e.g. IssueTx or Commit
+	Operation string
+
+	// Target is what the operation acts on, e.g. the API endpoint or the node ID, if any
+	Target string
+}
+
+// Tx is a tx issued by the SDK
+type Tx struct {
+	ID ids.ID
+
+	// Kind is the type of the unsigned tx, e.g. CreateSubnetTx
+	Kind string
+
+	// Chain is the alias of the chain the tx was issued to, e.g. O
+	Chain string
+
+	// Endpoint is the API endpoint the tx was issued to
+	Endpoint string
+}
+
+// SSHCommand is a command run on a node over SSH
+type SSHCommand struct {
+	NodeID   string
+	Host     string
+	Duration time.Duration
+	Err      error
+}
+
+// Hooks receive the operations of the SDK. Nil hooks are skipped. Hooks are called
+// synchronously, possibly from multiple goroutines, so they should return quickly
+type Hooks struct {
+	// OnRequestStart is called when a request starts. The context it returns, e.g. holding
+	// a span, is passed to the other hooks of the request. A nil context keeps ctx
+	OnRequestStart func(ctx context.Context, req Request) context.Context
+
+	// OnRequestEnd is called when a request ends, err being its error if it failed
+	OnRequestEnd func(ctx context.Context, req Request, duration time.Duration, err error)
+
+	// OnTxIssued is called when a tx is accepted for issuance by an API endpoint
+	OnTxIssued func(ctx context.Context, tx Tx)
+
+	// OnSSHCommand is called when an SSH command completes
+	OnSSHCommand func(ctx context.Context, cmd SSHCommand)
+}
+
+var (
+	lock       sync.RWMutex
+	registered []*Hooks
+)
+
+// Register adds hooks to the hooks receiving the operations of the SDK, returning the
+// function unregistering them
+func Register(hooks Hooks) (unregister func()) {
+	h := &hooks
+	lock.Lock()
+	defer lock.Unlock()
+	registered = append(slices.Clip(registered), h)
+	return func() {
+		lock.Lock()
+		defer lock.Unlock()
+		registered = slices.DeleteFunc(slices.Clone(registered), func(r *Hooks) bool { return r == h })
+	}
+}
+
+// Enabled tells if hooks are registered, letting the SDK skip the instrumentation otherwise
+func Enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return len(registered) > 0
+}
+
+func current() []*Hooks {
+	lock.RLock()
+	defer lock.RUnlock()
+	return registered
+}
+
+// StartRequest reports the start of a request to the registered hooks, returning the context
+// of the request and the function reporting its end
+func StartRequest(ctx context.Context, component, operation, target string) (context.Context, func(error)) {
+	hooks := current()
+	if len(hooks) == 0 {
+		return ctx, func(error) {}
+	}
+	req := Request{Component: component, Operation: operation, Target: target}
+	start := time.Now()
+	for _, h := range hooks {
+		if h.OnRequestStart != nil {
+			if reqCtx := h.OnRequestStart(ctx, req); reqCtx != nil {
+				ctx = reqCtx
+			}
+		}
+	}
+	return ctx, func(err error) {
+		duration := time.Since(start)
+		// in reverse order, so that nested spans end first
+		for i := len(hooks) - 1; i >= 0; i-- {
+			if hooks[i].OnRequestEnd != nil {
+				hooks[i].OnRequestEnd(ctx, req, duration, err)
+			}
+		}
+	}
+}
+
+// RecordTxIssued reports an issued tx to the registered hooks
+func RecordTxIssued(ctx context.Context, tx Tx) {
+	for _, h := range current() {
+		if h.OnTxIssued != nil {
+			h.OnTxIssued(ctx, tx)
+		}
+	}
+}
+
+// RecordSSHCommand reports a completed SSH command to the registered hooks
+func RecordSSHCommand(ctx context.Context, cmd SSHCommand) {
+	for _, h := range current() {
+		if h.OnSSHCommand != nil {
+			h.OnSSHCommand(ctx, cmd)
+		}
+	}
+}
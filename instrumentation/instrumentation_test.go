@@ -0,0 +1,78 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey string
+
+func TestRegister(t *testing.T) {
+	assert.False(t, Enabled())
+	events := []string{}
+	unregisterFirst := Register(Hooks{
+		OnRequestStart: func(ctx context.Context, req Request) context.Context {
+			events = append(events, "start first "+req.Operation)
+			return context.WithValue(ctx, ctxKey("first"), true)
+		},
+		OnRequestEnd: func(ctx context.Context, req Request, _ time.Duration, err error) {
+			assert.Equal(t, true, ctx.Value(ctxKey("first")))
+			events = append(events, "end first "+err.Error())
+		},
+	})
+	unregisterSecond := Register(Hooks{
+		OnRequestStart: func(ctx context.Context, req Request) context.Context {
+			// the context of the previous hooks is chained
+			if ctx.Value(ctxKey("first")) != nil {
+				events = append(events, "start second chained "+req.Operation)
+			} else {
+				events = append(events, "start second "+req.Operation)
+			}
+			return nil
+		},
+		OnRequestEnd: func(_ context.Context, _ Request, _ time.Duration, err error) {
+			events = append(events, "end second "+err.Error())
+		},
+		OnTxIssued: func(_ context.Context, tx Tx) {
+			events = append(events, "tx "+tx.Kind)
+		},
+		OnSSHCommand: func(_ context.Context, cmd SSHCommand) {
+			events = append(events, "ssh "+cmd.NodeID)
+		},
+	})
+	require.True(t, Enabled())
+
+	ctx, end := StartRequest(context.Background(), ComponentWallet, "IssueTx", "")
+	RecordTxIssued(ctx, Tx{ID: ids.GenerateTestID(), Kind: "BaseTx"})
+	end(errors.New("failed"))
+	RecordSSHCommand(context.Background(), SSHCommand{NodeID: "node-1"})
+	assert.Equal(t, []string{
+		"start first IssueTx",
+		"start second chained IssueTx",
+		"tx BaseTx",
+		"end second failed",
+		"end first failed",
+		"ssh node-1",
+	}, events)
+
+	unregisterFirst()
+	unregisterFirst()
+	events = []string{}
+	_, end = StartRequest(context.Background(), ComponentNode, "Setup Node", "node-1")
+	end(errors.New("failed"))
+	assert.Equal(t, []string{"start second Setup Node", "end second failed"}, events)
+
+	unregisterSecond()
+	assert.False(t, Enabled())
+	_, end = StartRequest(context.Background(), ComponentNode, "Setup Node", "node-1")
+	end(nil)
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package instrumentation
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metricsNamespace prefixes the names of the metrics of NewPrometheusHooks
+const metricsNamespace = "odyssey_sdk"
+
+// requestStatus is the status label of a request or SSH command
+func requestStatus(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
+// NewPrometheusHooks registers the metrics of the SDK operations with registerer, returning
+// the hooks updating them:
+//   - odyssey_sdk_requests_total and odyssey_sdk_request_duration_seconds, by component,
+//     operation and status
+//   - odyssey_sdk_txs_issued_total, by chain and tx kind
+//   - odyssey_sdk_ssh_commands_total and odyssey_sdk_ssh_command_duration_seconds, by status
+func NewPrometheusHooks(registerer prometheus.Registerer) (Hooks, error) {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "requests_total",
+		Help:      "Number of SDK requests",
+	}, []string{"component", "operation", "status"})
+	requestDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "request_duration_seconds",
+		Help:      "Duration of SDK requests",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 4, 8),
+	}, []string{"component", "operation", "status"})
+	txs := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "txs_issued_total",
+		Help:      "Number of txs issued by the SDK",
+	}, []string{"chain", "kind"})
+	sshCommands := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "ssh_commands_total",
+		Help:      "Number of SSH commands run by the SDK",
+	}, []string{"status"})
+	sshDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "ssh_command_duration_seconds",
+		Help:      "Duration of SSH commands run by the SDK",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 4, 8),
+	}, []string{"status"})
+	for _, collector := range []prometheus.Collector{requests, requestDuration, txs, sshCommands, sshDuration} {
+		if err := registerer.Register(collector); err != nil {
+			return Hooks{}, err
+		}
+	}
+	return Hooks{
+		OnRequestEnd: func(_ context.Context, req Request, duration time.Duration, err error) {
+			status := requestStatus(err)
+			requests.WithLabelValues(req.Component, req.Operation, status).Inc()
+			requestDuration.WithLabelValues(req.Component, req.Operation, status).Observe(duration.Seconds())
+		},
+		OnTxIssued: func(_ context.Context, tx Tx) {
+			txs.WithLabelValues(tx.Chain, tx.Kind).Inc()
+		},
+		OnSSHCommand: func(_ context.Context, cmd SSHCommand) {
+			status := requestStatus(cmd.Err)
+			sshCommands.WithLabelValues(status).Inc()
+			sshDuration.WithLabelValues(status).Observe(cmd.Duration.Seconds())
+		},
+	}, nil
+}
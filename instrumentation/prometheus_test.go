@@ -0,0 +1,61 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gatherCounters returns the value of the counters of registry by metric name and labels
+func gatherCounters(t *testing.T, registry *prometheus.Registry) map[string]float64 {
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	counters := map[string]float64{}
+	for _, family := range families {
+		if family.GetType() != dto.MetricType_COUNTER {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			name := family.GetName()
+			for _, label := range metric.GetLabel() {
+				name += "," + label.GetName() + "=" + label.GetValue()
+			}
+			counters[name] = metric.GetCounter().GetValue()
+		}
+	}
+	return counters
+}
+
+func TestNewPrometheusHooks(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	hooks, err := NewPrometheusHooks(registry)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	req := Request{Component: ComponentMultisig, Operation: "Commit"}
+	hooks.OnRequestEnd(ctx, req, time.Second, nil)
+	hooks.OnRequestEnd(ctx, req, time.Second, errors.New("failed"))
+	hooks.OnRequestEnd(ctx, req, time.Second, nil)
+	hooks.OnTxIssued(ctx, Tx{Chain: "O", Kind: "CreateSubnetTx"})
+	hooks.OnSSHCommand(ctx, SSHCommand{Duration: time.Millisecond})
+
+	assert.Equal(t, map[string]float64{
+		"odyssey_sdk_requests_total,component=multisig,operation=Commit,status=success": 2,
+		"odyssey_sdk_requests_total,component=multisig,operation=Commit,status=error":   1,
+		"odyssey_sdk_txs_issued_total,chain=O,kind=CreateSubnetTx":                      1,
+		"odyssey_sdk_ssh_commands_total,status=success":                                 1,
+	}, gatherCounters(t, registry))
+
+	// the metrics are already registered
+	_, err = NewPrometheusHooks(registry)
+	assert.Error(t, err)
+}
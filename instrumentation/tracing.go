@@ -0,0 +1,95 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package instrumentation
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the spans of NewTracingHooks
+const tracerName = "github.com/DioneProtocol/odyssey-tooling-sdk-go"
+
+// spanKey holds the span of a request in its context, per tracing hooks so that several of
+// them can be registered
+type spanKey struct {
+	hooks *tracingHooks
+}
+
+type tracingHooks struct {
+	tracer trace.Tracer
+}
+
+// NewTracingHooks returns the hooks tracing the SDK operations with tracer, or with the tracer
+// of the global OpenTelemetry provider if nil:
+//   - each request is a span named <component>.<operation>, a child of the span of its context
+//   - issued txs are events of the span of their request
+//   - SSH commands are spans named ssh.command
+func NewTracingHooks(tracer trace.Tracer) Hooks {
+	if tracer == nil {
+		tracer = otel.Tracer(tracerName)
+	}
+	h := &tracingHooks{tracer: tracer}
+	return Hooks{
+		OnRequestStart: h.onRequestStart,
+		OnRequestEnd:   h.onRequestEnd,
+		OnTxIssued:     h.onTxIssued,
+		OnSSHCommand:   h.onSSHCommand,
+	}
+}
+
+func (h *tracingHooks) onRequestStart(ctx context.Context, req Request) context.Context {
+	attrs := []attribute.KeyValue{
+		attribute.String("odyssey.component", req.Component),
+		attribute.String("odyssey.operation", req.Operation),
+	}
+	if req.Target != "" {
+		attrs = append(attrs, attribute.String("odyssey.target", req.Target))
+	}
+	ctx, span := h.tracer.Start(ctx, req.Component+"."+req.Operation, trace.WithAttributes(attrs...))
+	return context.WithValue(ctx, spanKey{hooks: h}, span)
+}
+
+func (h *tracingHooks) onRequestEnd(ctx context.Context, _ Request, _ time.Duration, err error) {
+	span, ok := ctx.Value(spanKey{hooks: h}).(trace.Span)
+	if !ok {
+		return
+	}
+	endSpan(span, err)
+}
+
+func (*tracingHooks) onTxIssued(ctx context.Context, tx Tx) {
+	trace.SpanFromContext(ctx).AddEvent("tx issued", trace.WithAttributes(
+		attribute.String("odyssey.tx.id", tx.ID.String()),
+		attribute.String("odyssey.tx.kind", tx.Kind),
+		attribute.String("odyssey.tx.chain", tx.Chain),
+		attribute.String("odyssey.tx.endpoint", tx.Endpoint),
+	))
+}
+
+func (h *tracingHooks) onSSHCommand(ctx context.Context, cmd SSHCommand) {
+	end := time.Now()
+	_, span := h.tracer.Start(ctx, "ssh.command",
+		trace.WithTimestamp(end.Add(-cmd.Duration)),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("odyssey.node.id", cmd.NodeID),
+			attribute.String("odyssey.node.host", cmd.Host),
+		),
+	)
+	endSpan(span, cmd.Err, trace.WithTimestamp(end))
+}
+
+func endSpan(span trace.Span, err error, opts ...trace.SpanEndOption) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End(opts...)
+}
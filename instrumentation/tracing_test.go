@@ -0,0 +1,43 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestNewTracingHooks(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(Register(NewTracingHooks(provider.Tracer("test"))))
+
+	ctx, end := StartRequest(context.Background(), ComponentWallet, "IssueTx", "http://127.0.0.1:9650")
+	RecordTxIssued(ctx, Tx{ID: ids.GenerateTestID(), Kind: "BaseTx", Chain: "O"})
+	RecordSSHCommand(ctx, SSHCommand{NodeID: "node-1", Duration: time.Second, Err: errors.New("exit status 1")})
+	end(nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	ssh, request := spans[0], spans[1]
+
+	assert.Equal(t, "ssh.command", ssh.Name())
+	assert.Equal(t, codes.Error, ssh.Status().Code)
+	assert.Equal(t, time.Second, ssh.EndTime().Sub(ssh.StartTime()))
+	assert.Equal(t, request.SpanContext().SpanID(), ssh.Parent().SpanID())
+
+	assert.Equal(t, "wallet.IssueTx", request.Name())
+	assert.Equal(t, codes.Unset, request.Status().Code)
+	require.Len(t, request.Events(), 1)
+	assert.Equal(t, "tx issued", request.Events()[0].Name)
+}
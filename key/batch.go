@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Dione Limited.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+)
+
+var ErrInvalidBatchCount = errors.New("number of keys to generate must be positive")
+
+// BatchKeyFileName is the name of the file of the key at index of a batch of GenerateBatch,
+// e.g. key-0007.pk
+func BatchKeyFileName(index int) string {
+	return fmt.Sprintf("key-%04d.pk", index)
+}
+
+// GenerateBatch creates n soft keys saved in dir as BatchKeyFileName(0) to
+// BatchKeyFileName(n-1), creating dir if needed. Keys already saved in dir are loaded
+// instead, so that test fixtures keep their keys, and their funds, across runs
+func GenerateBatch(n int, dir string) ([]*SoftKey, error) {
+	if n <= 0 {
+		return nil, ErrInvalidBatchCount
+	}
+	if err := os.MkdirAll(dir, constants.DefaultPerms755); err != nil {
+		return nil, err
+	}
+	keys := make([]*SoftKey, 0, n)
+	for i := 0; i < n; i++ {
+		keyPath := filepath.Join(dir, BatchKeyFileName(i))
+		k, err := LoadSoftOrCreate(keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate key %s: %w", keyPath, err)
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2025 Dione Limited.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateBatch(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "keys")
+	keys, err := GenerateBatch(3, dir)
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+	assert.NotEqual(t, keys[0].PrivKeyHex(), keys[1].PrivKeyHex())
+	for i, k := range keys {
+		loaded, err := LoadSoft(filepath.Join(dir, BatchKeyFileName(i)))
+		require.NoError(t, err)
+		assert.Equal(t, k.PrivKeyHex(), loaded.PrivKeyHex())
+	}
+
+	// existing keys are kept
+	more, err := GenerateBatch(5, dir)
+	require.NoError(t, err)
+	require.Len(t, more, 5)
+	for i, k := range keys {
+		assert.Equal(t, k.PrivKeyHex(), more[i].PrivKeyHex())
+	}
+
+	_, err = GenerateBatch(0, dir)
+	assert.ErrorIs(t, err, ErrInvalidBatchCount)
+}
+
+func TestBatchKeyFileName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "key-0000.pk", BatchKeyFileName(0))
+	assert.Equal(t, "key-0042.pk", BatchKeyFileName(42))
+}
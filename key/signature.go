@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Dione Limited.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+	"github.com/ethereum/go-ethereum/common"
+
+	eth_crypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// signedMessagePrefix is prepended to personal messages before hashing, so that a signed message
+// can never be mistaken for a signed transaction. The first byte is the length of the text
+const signedMessagePrefix = "\x18Odyssey Signed Message:\n"
+
+var (
+	ErrInvalidSignature  = errors.New("signature does not match the public key or address")
+	ErrInvalidSigner     = errors.New("invalid public key or address")
+	ErrInvalidMessageLen = errors.New("message is too long to be signed")
+)
+
+// MessageHash returns the hash signed by SignMessage for msg: the SHA-256 of the personal
+// message prefix, the big endian uint32 length of msg and msg
+func MessageHash(msg []byte) ([]byte, error) {
+	if uint64(len(msg)) > uint64(^uint32(0)) {
+		return nil, ErrInvalidMessageLen
+	}
+	var msgLen [4]byte
+	binary.BigEndian.PutUint32(msgLen[:], uint32(len(msg)))
+	hash := sha256.New()
+	hash.Write([]byte(signedMessagePrefix))
+	hash.Write(msgLen[:])
+	hash.Write(msg)
+	return hash.Sum(nil), nil
+}
+
+// SignMessage signs msg as a personal message, returning a 65 bytes recoverable signature.
+// Use VerifyMessage to check it against the public key or any address of m
+func (m *SoftKey) SignMessage(msg []byte) ([]byte, error) {
+	msgHash, err := MessageHash(msg)
+	if err != nil {
+		return nil, err
+	}
+	return m.privKey.SignHash(msgHash)
+}
+
+// VerifyMessage checks that sig is the personal message signature of msg by
+// pubkeyOrAddress, as produced by SignMessage
+func VerifyMessage(pubkeyOrAddress string, msg []byte, sig []byte) error {
+	msgHash, err := MessageHash(msg)
+	if err != nil {
+		return err
+	}
+	return VerifySignature(pubkeyOrAddress, msgHash, sig)
+}
+
+// VerifySignature checks that sig is a recoverable signature of msgHash by pubkeyOrAddress,
+// which is either:
+//   - a hex encoded compressed public key
+//   - an O-Chain or A-Chain bech32 address, e.g. O-testnet1...
+//   - a CB58 encoded short ID
+//   - a D-Chain hex address, e.g. 0x...
+//
+// Returns ErrInvalidSignature if the signature was made by another key
+func VerifySignature(pubkeyOrAddress string, msgHash []byte, sig []byte) error {
+	factory := secp256k1.Factory{}
+	pubKey, err := factory.RecoverHashPublicKey(msgHash, sig)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	pubkeyOrAddress = strings.TrimSpace(pubkeyOrAddress)
+	var matches bool
+	switch {
+	case common.IsHexAddress(pubkeyOrAddress):
+		matches = eth_crypto.PubkeyToAddress(*pubKey.ToECDSA()) == common.HexToAddress(pubkeyOrAddress)
+	case isHexPublicKey(pubkeyOrAddress):
+		pubKeyBytes, _ := hex.DecodeString(strings.TrimPrefix(pubkeyOrAddress, "0x"))
+		signer, err := factory.ToPublicKey(pubKeyBytes)
+		if err != nil {
+			return fmt.Errorf("%w %s: %w", ErrInvalidSigner, pubkeyOrAddress, err)
+		}
+		matches = signer.Address() == pubKey.Address()
+	default:
+		signer, err := parseShortID(pubkeyOrAddress)
+		if err != nil {
+			return fmt.Errorf("%w %s: %w", ErrInvalidSigner, pubkeyOrAddress, err)
+		}
+		matches = signer == pubKey.Address()
+	}
+	if !matches {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func isHexPublicKey(s string) bool {
+	s = strings.TrimPrefix(s, "0x")
+	if len(s) != secp256k1.PublicKeyLen*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// parseShortID parses a bech32 chain address or a CB58 short ID
+func parseShortID(s string) (ids.ShortID, error) {
+	if strings.Contains(s, "-") {
+		_, _, shortID, err := odyssey.ParseAddress(s)
+		return shortID, err
+	}
+	return ids.ShortFromString(s)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Dione Limited.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftKeySignMessage(t *testing.T) {
+	t.Parallel()
+
+	m, err := LoadEwoq()
+	require.NoError(t, err)
+	msg := []byte("I control this validator owner address")
+	sig, err := m.SignMessage(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, 65)
+
+	oAddr, err := m.O("custom")
+	require.NoError(t, err)
+	aAddr, err := m.A("custom")
+	require.NoError(t, err)
+	pubKey := hex.EncodeToString(m.PrivKey().PublicKey().Bytes())
+
+	for _, signer := range []string{oAddr, aAddr, m.D(), pubKey, "0x" + pubKey, m.Addresses()[0].String()} {
+		assert.NoError(t, VerifyMessage(signer, msg, sig), signer)
+	}
+
+	other, err := NewSoft()
+	require.NoError(t, err)
+	otherAddr, err := other.O("custom")
+	require.NoError(t, err)
+	assert.ErrorIs(t, VerifyMessage(otherAddr, msg, sig), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifyMessage(other.D(), msg, sig), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifyMessage(oAddr, []byte("another message"), sig), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifyMessage(oAddr, msg, sig[:64]), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifyMessage("not an address", msg, sig), ErrInvalidSigner)
+}
+
+func TestVerifySignature_RawHash(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft()
+	require.NoError(t, err)
+	msgHash, err := MessageHash([]byte("hello"))
+	require.NoError(t, err)
+	sig, err := m.PrivKey().SignHash(msgHash)
+	require.NoError(t, err)
+	require.NoError(t, VerifySignature(m.D(), msgHash, sig))
+
+	// the personal message prefix makes the signed hash differ from the plain message hash
+	plainSig, err := m.PrivKey().Sign([]byte("hello"))
+	require.NoError(t, err)
+	assert.ErrorIs(t, VerifyMessage(m.D(), []byte("hello"), plainSig), ErrInvalidSignature)
+}
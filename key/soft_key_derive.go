@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Dione Limited.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+
+	eth_crypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// deriveDomain separates the child keys of a soft key from any other use of its private key
+const deriveDomain = "odyssey-soft-key-derive-v1"
+
+var ErrInvalidDeriveCount = errors.New("number of keys to derive must be positive")
+
+// Derive deterministically derives the child key at index from the private key of m.
+// The same key and index always produce the same child key, and children of different
+// indexes are unrelated to each other and to m for anyone not knowing the private key of m.
+//
+// Child keys are meant for test accounts, such as the funded keys of load tests or
+// allocation tables, and are not compatible with BIP32 wallets
+func (m *SoftKey) Derive(index uint32) (*SoftKey, error) {
+	mac := hmac.New(sha256.New, m.privKeyRaw)
+	// a candidate is out of the curve order with negligible probability, try the next one then
+	for attempt := uint32(0); ; attempt++ {
+		mac.Reset()
+		mac.Write([]byte(deriveDomain))
+		var counters [8]byte
+		binary.BigEndian.PutUint32(counters[:4], index)
+		binary.BigEndian.PutUint32(counters[4:], attempt)
+		mac.Write(counters[:])
+		childRaw := mac.Sum(nil)
+		if _, err := eth_crypto.ToECDSA(childRaw); err != nil {
+			continue
+		}
+		factory := secp256k1.Factory{}
+		childPrivKey, err := factory.ToPrivateKey(childRaw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive key %d: %w", index, err)
+		}
+		return NewSoft(WithPrivateKey(childPrivKey))
+	}
+}
+
+// DeriveN derives the n first child keys of m, that is the keys at indexes 0 to n-1.
+// Their O-Chain, A-Chain and D-Chain addresses are given by the O, A and D methods
+func (m *SoftKey) DeriveN(n int) ([]*SoftKey, error) {
+	if n <= 0 {
+		return nil, ErrInvalidDeriveCount
+	}
+	children := make([]*SoftKey, 0, n)
+	for i := 0; i < n; i++ {
+		child, err := m.Derive(uint32(i))
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+	return children, nil
+}
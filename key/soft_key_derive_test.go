@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Dione Limited.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftKeyDeriveN(t *testing.T) {
+	t.Parallel()
+
+	ewoq, err := LoadEwoq()
+	require.NoError(t, err)
+
+	children, err := ewoq.DeriveN(5)
+	require.NoError(t, err)
+	require.Len(t, children, 5)
+
+	again, err := ewoq.DeriveN(3)
+	require.NoError(t, err)
+	seen := map[string]struct{}{ewoq.PrivKeyHex(): {}}
+	for i, child := range children {
+		if i < len(again) {
+			assert.Equal(t, child.PrivKeyHex(), again[i].PrivKeyHex())
+		}
+		_, duplicated := seen[child.PrivKeyHex()]
+		assert.False(t, duplicated)
+		seen[child.PrivKeyHex()] = struct{}{}
+
+		oAddr, err := child.O("custom")
+		require.NoError(t, err)
+		assert.Contains(t, oAddr, "O-custom1")
+		assert.Contains(t, child.D(), "0x")
+	}
+
+	// pinned so that derived test accounts never change across releases
+	assert.Equal(t, "2511d330ec5dd47d58ae474f3799a2bfec29ba44d1d9d3bef0905d3e056b29f0", children[0].PrivKeyHex())
+
+	third, err := ewoq.Derive(2)
+	require.NoError(t, err)
+	assert.Equal(t, children[2].PrivKeyCB58(), third.PrivKeyCB58())
+
+	other, err := NewSoft()
+	require.NoError(t, err)
+	otherChild, err := other.Derive(0)
+	require.NoError(t, err)
+	assert.NotEqual(t, children[0].PrivKeyHex(), otherChild.PrivKeyHex())
+}
+
+func TestSoftKeyDeriveN_InvalidCount(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft()
+	require.NoError(t, err)
+	for _, n := range []int{0, -1} {
+		_, err := m.DeriveN(n)
+		assert.ErrorIs(t, err, ErrInvalidDeriveCount)
+	}
+}
@@ -3,16 +3,32 @@
 package keychain
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 
+	sdkconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/config"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/key"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/ledger"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
 	"github.com/DioneProtocol/odysseygo/utils/crypto/keychain"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/ethereum/go-ethereum/common"
 	"golang.org/x/exp/maps"
 )
 
+// ErrNoEthKeychain is returned by D for keychains whose keys cannot sign D-Chain EVM inputs,
+// such as Ledger keychains
+var ErrNoEthKeychain = errors.New("keychain has no EVM keys")
+
+// EthKeychain signs the D-Chain EVM inputs. It is the EthKeychain of primary.WalletConfig
+type EthKeychain interface {
+	GetEth(addr common.Address) (keychain.Signer, bool)
+	EthAddresses() set.Set[common.Address]
+}
+
 type Keychain struct {
 	keychain.Keychain
 	network odyssey.Network
@@ -38,6 +54,10 @@ type LedgerParams struct {
 	// For example if Ledger's index 0 and index 1 each contains 0.1 DIONE and RequiredFunds is
 	// 0.2 DIONE, LedgerIndices will have value of [0,1]
 	RequiredFunds uint64
+
+	// Signing configures the confirmation timeouts, retries and progress of the signatures
+	// requested to the Ledger
+	Signing ledger.SigningOptions
 }
 
 // Ledger is part of the output of NewKeyChain if a new keychain is to be created using Ledger
@@ -51,7 +71,8 @@ type Ledger struct {
 
 // NewKeychain generates a new key pair from either a stored key path or Ledger.
 // For stored keys, NewKeychain will generate a new key pair in the provided keyPath if no .pk
-// file currently exists in the provided path.
+// file currently exists in the provided path. If keyPath is empty, the key path of the SDK
+// config file is used.
 func NewKeychain(
 	network odyssey.Network,
 	keyPath string,
@@ -65,6 +86,7 @@ func NewKeychain(
 		if err != nil {
 			return nil, err
 		}
+		dev.SetSigningOptions(ledgerInfo.Signing)
 		kc := Keychain{
 			Ledger: &Ledger{
 				LedgerDevice: dev,
@@ -86,6 +108,9 @@ func NewKeychain(
 		}
 		return &kc, nil
 	}
+	if keyPath == "" {
+		keyPath = sdkconfig.Get().KeyPath
+	}
 	sf, err := key.LoadSoftOrCreate(keyPath)
 	if err != nil {
 		return nil, err
@@ -105,6 +130,17 @@ func NewKeychainFromExisting(existingKeychain keychain.Keychain, network odyssey
 	}
 }
 
+// Network returns the network whose HRP is used to format the keychain addresses
+func (kc *Keychain) Network() odyssey.Network {
+	return kc.network
+}
+
+// SetNetwork changes the network whose HRP is used to format the keychain addresses,
+// e.g. to a custom network created with odyssey.NewCustomNetwork
+func (kc *Keychain) SetNetwork(network odyssey.Network) {
+	kc.network = network
+}
+
 // O returns string formatted O-Chain addresses in the keychain
 func (kc *Keychain) O() ([]string, error) {
 	return utils.O(kc.network.HRP(), kc.Addresses().List())
@@ -115,6 +151,30 @@ func (kc *Keychain) A() ([]string, error) {
 	return utils.A(kc.network.HRP(), kc.Addresses().List())
 }
 
+// D returns the 0x formatted EVM addresses of the keychain keys on the D-Chain
+func (kc *Keychain) D() ([]string, error) {
+	ethKeychain, ok := kc.Keychain.(EthKeychain)
+	if !ok {
+		return nil, ErrNoEthKeychain
+	}
+	addrs := []string{}
+	for addr := range ethKeychain.EthAddresses() {
+		addrs = append(addrs, addr.Hex())
+	}
+	sort.Strings(addrs)
+	return addrs, nil
+}
+
+// EthKeychain returns the keychain signing the D-Chain EVM inputs with the same secp256k1 keys
+// as the keychain, to be set as the EthKeychain of a wallet config. It is empty for keychains
+// with no EVM keys, such as Ledger keychains
+func (kc *Keychain) EthKeychain() EthKeychain {
+	if ethKeychain, ok := kc.Keychain.(EthKeychain); ok {
+		return ethKeychain
+	}
+	return secp256k1fx.NewKeychain()
+}
+
 func (kc *Keychain) LedgerEnabled() bool {
 	return kc.Ledger != nil && kc.Ledger.LedgerDevice != nil
 }
@@ -123,7 +183,7 @@ func (kc *Keychain) AddLedgerIndices(indices []uint32) error {
 	if kc.LedgerEnabled() {
 		kc.Ledger.LedgerIndices = utils.Unique(append(kc.Ledger.LedgerIndices, indices...))
 		utils.Uint32Sort(kc.Ledger.LedgerIndices)
-		newKc, err := keychain.NewLedgerKeychainFromIndices(kc.Ledger.LedgerDevice, kc.Ledger.LedgerIndices)
+		newKc, err := ledger.NewKeychain(kc.Ledger.LedgerDevice, kc.Ledger.LedgerIndices)
 		if err != nil {
 			return err
 		}
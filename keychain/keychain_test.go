@@ -0,0 +1,50 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package keychain
+
+import (
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/keychain"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addressesKeychain holds addresses but no EVM keys, as Ledger keychains do
+type addressesKeychain struct {
+	set.Set[ids.ShortID]
+}
+
+func (addressesKeychain) Get(ids.ShortID) (keychain.Signer, bool) {
+	return nil, false
+}
+
+func (kc addressesKeychain) Addresses() set.Set[ids.ShortID] {
+	return kc.Set
+}
+
+func TestKeychain_D(t *testing.T) {
+	factory := secp256k1.Factory{}
+	key, err := factory.NewPrivateKey()
+	require.NoError(t, err)
+	kc := NewKeychainFromExisting(secp256k1fx.NewKeychain(key), odyssey.TestnetNetwork())
+
+	addrs, err := kc.D()
+	require.NoError(t, err)
+	ethAddr := crypto.PubkeyToAddress(*key.PublicKey().ToECDSA())
+	assert.Equal(t, []string{ethAddr.Hex()}, addrs)
+	ethKeychain := kc.EthKeychain()
+	_, ok := ethKeychain.GetEth(ethAddr)
+	assert.True(t, ok)
+
+	kc = NewKeychainFromExisting(addressesKeychain{set.Of(key.Address())}, odyssey.TestnetNetwork())
+	_, err = kc.D()
+	require.ErrorIs(t, err, ErrNoEthKeychain)
+	assert.Equal(t, 0, kc.EthKeychain().EthAddresses().Len())
+}
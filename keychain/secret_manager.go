@@ -0,0 +1,247 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package keychain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/key"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+)
+
+var (
+	ErrInvalidSecretRef    = errors.New("invalid secret reference")
+	ErrSecretNotFound      = errors.New("secret not found")
+	ErrSecretManager       = errors.New("secret manager request failed")
+	ErrMissingCredentials  = errors.New("missing secret manager credentials")
+	ErrUnsupportedProvider = errors.New("unsupported secret manager")
+)
+
+// SecretProvider is a secret manager holding soft keys
+type SecretProvider string
+
+const (
+	// AWSSecretsManager refs are aws-sm://<region>/<secret name>
+	AWSSecretsManager SecretProvider = "aws-sm"
+
+	// GCPSecretManager refs are gcp-sm://<project>/<secret name>, the latest version of the
+	// secret being read
+	GCPSecretManager SecretProvider = "gcp-sm"
+
+	// Vault refs are vault://<kv v2 mount>/<secret path>[#field], the key being stored in the
+	// private_key field of the secret if no field is given
+	Vault SecretProvider = "vault"
+)
+
+// defaultVaultField is the field of Vault secrets holding the key
+const defaultVaultField = "private_key"
+
+// secretManagerHTTPClient sends the requests to the secret managers
+var secretManagerHTTPClient = &http.Client{Timeout: constants.APIRequestTimeout}
+
+// SecretRef locates a soft key in a secret manager, see ParseSecretRef
+type SecretRef struct {
+	Provider SecretProvider
+
+	// Location is the AWS region, the GCP project or the Vault mount of the secret
+	Location string
+
+	// Name is the name of the secret, or its path for Vault
+	Name string
+
+	// Field is the field of the Vault secret holding the key
+	Field string
+}
+
+// ParseSecretRef parses refs like aws-sm://us-east-1/deployer-key,
+// gcp-sm://my-project/deployer-key or vault://secret/odyssey/deployer-key
+func ParseSecretRef(ref string) (SecretRef, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return SecretRef{}, fmt.Errorf("%w %q: %w", ErrInvalidSecretRef, ref, err)
+	}
+	secretRef := SecretRef{
+		Provider: SecretProvider(u.Scheme),
+		Location: u.Host,
+		Name:     strings.Trim(u.Path, "/"),
+		Field:    u.Fragment,
+	}
+	switch secretRef.Provider {
+	case AWSSecretsManager, GCPSecretManager:
+		if secretRef.Field != "" {
+			return SecretRef{}, fmt.Errorf("%w %q: fields are only supported by %s", ErrInvalidSecretRef, ref, Vault)
+		}
+	case Vault:
+		if secretRef.Field == "" {
+			secretRef.Field = defaultVaultField
+		}
+	default:
+		return SecretRef{}, fmt.Errorf("%w %q in %q", ErrUnsupportedProvider, u.Scheme, ref)
+	}
+	if secretRef.Location == "" || secretRef.Name == "" {
+		return SecretRef{}, fmt.Errorf("%w %q: expected %s://<location>/<name>", ErrInvalidSecretRef, ref, u.Scheme)
+	}
+	return secretRef, nil
+}
+
+// String returns the ref parsed by ParseSecretRef
+func (r SecretRef) String() string {
+	ref := fmt.Sprintf("%s://%s/%s", r.Provider, r.Location, r.Name)
+	if r.Provider == Vault && r.Field != defaultVaultField {
+		ref += "#" + r.Field
+	}
+	return ref
+}
+
+// SecretManager reads and writes the secrets of a secret manager
+type SecretManager interface {
+	// GetSecret returns the value of the secret ref, failing with ErrSecretNotFound if
+	// it does not exist
+	GetSecret(ctx context.Context, ref SecretRef) ([]byte, error)
+
+	// PutSecret creates the secret ref or adds value as its new version
+	PutSecret(ctx context.Context, ref SecretRef, value []byte) error
+}
+
+// SecretManagerOp configures the secret manager operations
+type SecretManagerOp struct {
+	manager         SecretManager
+	cachePath       string
+	createIfMissing bool
+}
+
+// SecretManagerOption configures FromSecretManager and ExportToSecretManager
+type SecretManagerOption func(*SecretManagerOp)
+
+// WithSecretManager uses manager instead of the client of the ref provider, e.g. to use
+// credentials other than the default ones
+func WithSecretManager(manager SecretManager) SecretManagerOption {
+	return func(op *SecretManagerOp) {
+		op.manager = manager
+	}
+}
+
+// WithLocalCache caches the key in the .pk file keyPath: the key is read from it if it
+// exists, and written to it otherwise. Keys are never written to disk without it
+func WithLocalCache(keyPath string) SecretManagerOption {
+	return func(op *SecretManagerOp) {
+		op.cachePath = keyPath
+	}
+}
+
+// WithCreateIfMissing makes FromSecretManager store a new key in the secret if it does not
+// exist, as NewKeychain does for .pk files
+func WithCreateIfMissing() SecretManagerOption {
+	return func(op *SecretManagerOp) {
+		op.createIfMissing = true
+	}
+}
+
+func newSecretManagerOp(opts []SecretManagerOption) SecretManagerOp {
+	op := SecretManagerOp{}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	return op
+}
+
+// secretManagerFor returns the client of the ref provider, with the credentials of the
+// environment
+func secretManagerFor(ref SecretRef) (SecretManager, error) {
+	switch ref.Provider {
+	case AWSSecretsManager:
+		return NewAWSSecretsManager(), nil
+	case GCPSecretManager:
+		return NewGCPSecretManager(), nil
+	case Vault:
+		return NewVaultSecretManager("", ""), nil
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedProvider, ref.Provider)
+	}
+}
+
+// LoadSoftFromSecretManager loads the soft key stored in the secret ref
+func LoadSoftFromSecretManager(ctx context.Context, ref string, opts ...SecretManagerOption) (*key.SoftKey, error) {
+	op := newSecretManagerOp(opts)
+	if op.cachePath != "" && utils.FileExists(op.cachePath) {
+		return key.LoadSoft(op.cachePath)
+	}
+	secretRef, err := ParseSecretRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	manager := op.manager
+	if manager == nil {
+		if manager, err = secretManagerFor(secretRef); err != nil {
+			return nil, err
+		}
+	}
+	var sk *key.SoftKey
+	value, err := manager.GetSecret(ctx, secretRef)
+	switch {
+	case errors.Is(err, ErrSecretNotFound) && op.createIfMissing:
+		if sk, err = key.NewSoft(); err != nil {
+			return nil, err
+		}
+		if err := manager.PutSecret(ctx, secretRef, []byte(sk.PrivKeyHex())); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	default:
+		if sk, err = key.LoadSoftFromBytes([]byte(strings.TrimSpace(string(value)))); err != nil {
+			return nil, fmt.Errorf("invalid key in secret %s: %w", secretRef, err)
+		}
+	}
+	if op.cachePath != "" {
+		if err := os.MkdirAll(filepath.Dir(op.cachePath), constants.DefaultPerms755); err != nil {
+			return nil, err
+		}
+		if err := sk.Save(op.cachePath); err != nil {
+			return nil, err
+		}
+	}
+	return sk, nil
+}
+
+// FromSecretManager creates a keychain of network from the soft key stored in the secret
+// ref, e.g. aws-sm://us-east-1/deployer-key. See ParseSecretRef for the refs of each secret
+// manager, and NewAWSSecretsManager, NewGCPSecretManager and NewVaultSecretManager for their
+// credentials. Unlike NewKeychain, the key is not written to disk unless WithLocalCache is
+// given, so that automated pipelines can sign without .pk files
+func FromSecretManager(ctx context.Context, network odyssey.Network, ref string, opts ...SecretManagerOption) (*Keychain, error) {
+	sk, err := LoadSoftFromSecretManager(ctx, ref, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Keychain{
+		Keychain: sk.KeyChain(),
+		network:  network,
+	}, nil
+}
+
+// ExportToSecretManager stores sk in the secret ref, e.g. to move a .pk file to a secret
+// manager
+func ExportToSecretManager(ctx context.Context, sk *key.SoftKey, ref string, opts ...SecretManagerOption) error {
+	op := newSecretManagerOp(opts)
+	secretRef, err := ParseSecretRef(ref)
+	if err != nil {
+		return err
+	}
+	manager := op.manager
+	if manager == nil {
+		if manager, err = secretManagerFor(secretRef); err != nil {
+			return err
+		}
+	}
+	return manager.PutSecret(ctx, secretRef, []byte(sk.PrivKeyHex()))
+}
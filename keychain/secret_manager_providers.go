@@ -0,0 +1,351 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package keychain
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// sendSecretRequest sends a JSON request to a secret manager and decodes its JSON reply into
+// reply. Replies with status notFoundStatus fail with ErrSecretNotFound
+func sendSecretRequest(req *http.Request, notFoundStatus int, reply interface{}) error {
+	resp, err := secretManagerHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSecretManager, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSecretManager, err)
+	}
+	if resp.StatusCode == notFoundStatus {
+		return ErrSecretNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: %s %s: %s: %s", ErrSecretManager, req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	if reply == nil || len(body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, reply)
+}
+
+// AWSCredentials sign the requests to AWS
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+type awsSecretsManager struct {
+	credentials AWSCredentials
+	// endpoint overrides the regional endpoint of the service
+	endpoint string
+	now      func() time.Time
+}
+
+// NewAWSSecretsManager returns a client of AWS Secrets Manager authenticated with the
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables.
+// Shared config profiles and instance roles are not supported, use
+// NewAWSSecretsManagerWithCredentials with credentials obtained from them
+func NewAWSSecretsManager() SecretManager {
+	return NewAWSSecretsManagerWithCredentials(AWSCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	})
+}
+
+// NewAWSSecretsManagerWithCredentials returns a client of AWS Secrets Manager authenticated
+// with credentials
+func NewAWSSecretsManagerWithCredentials(credentials AWSCredentials) SecretManager {
+	return &awsSecretsManager{credentials: credentials, now: time.Now}
+}
+
+func (m *awsSecretsManager) GetSecret(ctx context.Context, ref SecretRef) ([]byte, error) {
+	reply := struct {
+		SecretString string
+		SecretBinary []byte
+	}{}
+	if err := m.call(ctx, ref.Location, "GetSecretValue", map[string]string{"SecretId": ref.Name}, &reply); err != nil {
+		return nil, err
+	}
+	if reply.SecretString != "" {
+		return []byte(reply.SecretString), nil
+	}
+	return reply.SecretBinary, nil
+}
+
+func (m *awsSecretsManager) PutSecret(ctx context.Context, ref SecretRef, value []byte) error {
+	err := m.call(ctx, ref.Location, "PutSecretValue", map[string]string{"SecretId": ref.Name, "SecretString": string(value)}, nil)
+	if !errors.Is(err, ErrSecretNotFound) {
+		return err
+	}
+	return m.call(ctx, ref.Location, "CreateSecret", map[string]string{"Name": ref.Name, "SecretString": string(value)}, nil)
+}
+
+// call calls the action of the Secrets Manager API of region
+func (m *awsSecretsManager) call(ctx context.Context, region string, action string, args interface{}, reply interface{}) error {
+	if m.credentials.AccessKeyID == "" || m.credentials.SecretAccessKey == "" {
+		return fmt.Errorf("%w: AWS access key", ErrMissingCredentials)
+	}
+	body, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	endpoint := m.endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://secretsmanager.%s.amazonaws.com", region)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager."+action)
+	signAWSRequest(req, body, m.credentials, region, "secretsmanager", m.now())
+	// missing secrets are reported as a bad request of type ResourceNotFoundException
+	err = sendSecretRequest(req, 0, reply)
+	if err != nil && strings.Contains(err.Error(), "ResourceNotFoundException") {
+		return ErrSecretNotFound
+	}
+	return err
+}
+
+// signAWSRequest signs req with the AWS signature version 4 of its headers and body
+func signAWSRequest(req *http.Request, body []byte, credentials AWSCredentials, region string, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
+	}
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	canonicalHeaders := ""
+	for _, name := range names {
+		canonicalHeaders += name + ":" + headers[name] + "\n"
+	}
+	signedHeaders := strings.Join(names, ";")
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	bodyHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders,
+		signedHeaders,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+	signingKey := []byte("AWS4" + credentials.SecretAccessKey)
+	for _, part := range []string{date, region, service, "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		credentials.AccessKeyID,
+		scope,
+		signedHeaders,
+		hex.EncodeToString(hmacSHA256(signingKey, stringToSign)),
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// gcpMetadataTokenURL returns the access token of the service account of GCP instances
+const gcpMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+type gcpSecretManager struct {
+	token func(ctx context.Context) (string, error)
+	// endpoint is the base URL of the service
+	endpoint string
+}
+
+// NewGCPSecretManager returns a client of GCP Secret Manager authenticated with the access
+// token of the GOOGLE_OAUTH_ACCESS_TOKEN environment variable, e.g. set to the output of
+// gcloud auth print-access-token, or else with the service account of the GCP instance
+func NewGCPSecretManager() SecretManager {
+	return NewGCPSecretManagerWithToken(gcpDefaultToken)
+}
+
+// NewGCPSecretManagerWithToken returns a client of GCP Secret Manager authenticated with the
+// OAuth2 access tokens returned by token
+func NewGCPSecretManagerWithToken(token func(ctx context.Context) (string, error)) SecretManager {
+	return &gcpSecretManager{token: token, endpoint: "https://secretmanager.googleapis.com"}
+}
+
+func gcpDefaultToken(ctx context.Context) (string, error) {
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpMetadataTokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	reply := struct {
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := sendSecretRequest(req, 0, &reply); err != nil {
+		return "", fmt.Errorf("%w: GCP access token: %w", ErrMissingCredentials, err)
+	}
+	return reply.AccessToken, nil
+}
+
+func (m *gcpSecretManager) GetSecret(ctx context.Context, ref SecretRef) ([]byte, error) {
+	reply := struct {
+		Payload struct {
+			Data []byte `json:"data"`
+		} `json:"payload"`
+	}{}
+	if err := m.call(ctx, http.MethodGet, m.secretPath(ref)+"/versions/latest:access", nil, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Payload.Data, nil
+}
+
+func (m *gcpSecretManager) PutSecret(ctx context.Context, ref SecretRef, value []byte) error {
+	version := map[string]interface{}{
+		"payload": map[string]string{"data": base64.StdEncoding.EncodeToString(value)},
+	}
+	err := m.call(ctx, http.MethodPost, m.secretPath(ref)+":addVersion", version, nil)
+	if !errors.Is(err, ErrSecretNotFound) {
+		return err
+	}
+	secret := map[string]interface{}{"replication": map[string]interface{}{"automatic": map[string]interface{}{}}}
+	path := fmt.Sprintf("/v1/projects/%s/secrets?secretId=%s", url.PathEscape(ref.Location), url.QueryEscape(ref.Name))
+	if err := m.call(ctx, http.MethodPost, path, secret, nil); err != nil {
+		return err
+	}
+	return m.call(ctx, http.MethodPost, m.secretPath(ref)+":addVersion", version, nil)
+}
+
+func (*gcpSecretManager) secretPath(ref SecretRef) string {
+	return fmt.Sprintf("/v1/projects/%s/secrets/%s", url.PathEscape(ref.Location), url.PathEscape(ref.Name))
+}
+
+func (m *gcpSecretManager) call(ctx context.Context, method string, path string, args interface{}, reply interface{}) error {
+	token, err := m.token(ctx)
+	if err != nil {
+		return err
+	}
+	var body io.Reader
+	if args != nil {
+		encoded, err := json.Marshal(args)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, m.endpoint+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	return sendSecretRequest(req, http.StatusNotFound, reply)
+}
+
+type vaultSecretManager struct {
+	address string
+	token   string
+}
+
+// NewVaultSecretManager returns a client of the HashiCorp Vault at address, authenticated
+// with token. The VAULT_ADDR and VAULT_TOKEN environment variables are used if empty. Secrets
+// are read from and written to KV version 2 secrets engines
+func NewVaultSecretManager(address string, token string) SecretManager {
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	if address == "" {
+		address = "https://127.0.0.1:8200"
+	}
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	return &vaultSecretManager{address: strings.TrimSuffix(address, "/"), token: token}
+}
+
+func (m *vaultSecretManager) GetSecret(ctx context.Context, ref SecretRef) ([]byte, error) {
+	reply := struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}{}
+	if err := m.call(ctx, http.MethodGet, ref, nil, &reply); err != nil {
+		return nil, err
+	}
+	value, ok := reply.Data.Data[ref.Field]
+	if !ok {
+		return nil, fmt.Errorf("%w: no field %s in secret %s", ErrInvalidSecretRef, ref.Field, ref)
+	}
+	return []byte(value), nil
+}
+
+// PutSecret writes a new version of the secret with value as its only field, the other
+// fields of the secret being dropped
+func (m *vaultSecretManager) PutSecret(ctx context.Context, ref SecretRef, value []byte) error {
+	return m.call(ctx, http.MethodPost, ref, map[string]interface{}{
+		"data": map[string]string{ref.Field: string(value)},
+	}, nil)
+}
+
+func (m *vaultSecretManager) call(ctx context.Context, method string, ref SecretRef, args interface{}, reply interface{}) error {
+	if m.token == "" {
+		return fmt.Errorf("%w: Vault token", ErrMissingCredentials)
+	}
+	var body io.Reader
+	if args != nil {
+		encoded, err := json.Marshal(args)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+	path := fmt.Sprintf("%s/v1/%s/data/%s", m.address, ref.Location, ref.Name)
+	req, err := http.NewRequestWithContext(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", m.token)
+	req.Header.Set("Content-Type", "application/json")
+	return sendSecretRequest(req, http.StatusNotFound, reply)
+}
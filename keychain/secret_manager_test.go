@@ -0,0 +1,284 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package keychain
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/key"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySecretManager stores the secrets in memory
+type memorySecretManager struct {
+	lock    sync.Mutex
+	secrets map[string][]byte
+}
+
+func (m *memorySecretManager) GetSecret(_ context.Context, ref SecretRef) ([]byte, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	value, ok := m.secrets[ref.String()]
+	if !ok {
+		return nil, ErrSecretNotFound
+	}
+	return value, nil
+}
+
+func (m *memorySecretManager) PutSecret(_ context.Context, ref SecretRef, value []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.secrets[ref.String()] = value
+	return nil
+}
+
+func TestParseSecretRef(t *testing.T) {
+	tests := []struct {
+		ref         string
+		expected    SecretRef
+		expectedErr error
+	}{
+		{ref: "aws-sm://us-east-1/deployer-key", expected: SecretRef{Provider: AWSSecretsManager, Location: "us-east-1", Name: "deployer-key"}},
+		{ref: "gcp-sm://my-project/deployer-key", expected: SecretRef{Provider: GCPSecretManager, Location: "my-project", Name: "deployer-key"}},
+		{ref: "vault://secret/odyssey/deployer", expected: SecretRef{Provider: Vault, Location: "secret", Name: "odyssey/deployer", Field: "private_key"}},
+		{ref: "vault://secret/odyssey/deployer#key", expected: SecretRef{Provider: Vault, Location: "secret", Name: "odyssey/deployer", Field: "key"}},
+		{ref: "aws-sm://us-east-1/deployer-key#key", expectedErr: ErrInvalidSecretRef},
+		{ref: "aws-sm://us-east-1", expectedErr: ErrInvalidSecretRef},
+		{ref: "azure-kv://vault/key", expectedErr: ErrUnsupportedProvider},
+		{ref: "/home/user/key.pk", expectedErr: ErrUnsupportedProvider},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			ref, err := ParseSecretRef(tt.ref)
+			require.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				assert.Equal(t, tt.expected, ref)
+				assert.Equal(t, tt.ref, ref.String())
+			}
+		})
+	}
+}
+
+func TestFromSecretManager(t *testing.T) {
+	manager := &memorySecretManager{secrets: map[string][]byte{}}
+	sk, err := key.NewSoft()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = FromSecretManager(ctx, odyssey.TestnetNetwork(), "vault://secret/deployer", WithSecretManager(manager))
+	require.ErrorIs(t, err, ErrSecretNotFound)
+
+	require.NoError(t, ExportToSecretManager(ctx, sk, "vault://secret/deployer", WithSecretManager(manager)))
+	kc, err := FromSecretManager(ctx, odyssey.TestnetNetwork(), "vault://secret/deployer", WithSecretManager(manager))
+	require.NoError(t, err)
+	assert.Equal(t, sk.KeyChain().Addresses(), kc.Addresses())
+
+	// the CB58 encoding of keys is accepted as well
+	manager.secrets["vault://secret/encoded"] = []byte(sk.PrivKeyCB58() + "\n")
+	encoded, err := LoadSoftFromSecretManager(ctx, "vault://secret/encoded", WithSecretManager(manager))
+	require.NoError(t, err)
+	assert.Equal(t, sk.PrivKeyHex(), encoded.PrivKeyHex())
+
+	// a new key is stored if missing, and written to disk only if cached
+	dir := t.TempDir()
+	created, err := LoadSoftFromSecretManager(ctx, "vault://secret/new", WithSecretManager(manager), WithCreateIfMissing())
+	require.NoError(t, err)
+	assert.Equal(t, created.PrivKeyHex(), string(manager.secrets["vault://secret/new"]))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	cachePath := filepath.Join(dir, "keys", "deployer.pk")
+	cached, err := LoadSoftFromSecretManager(ctx, "vault://secret/new", WithSecretManager(manager), WithLocalCache(cachePath))
+	require.NoError(t, err)
+	assert.Equal(t, created.PrivKeyHex(), cached.PrivKeyHex())
+	delete(manager.secrets, "vault://secret/new")
+	cached, err = LoadSoftFromSecretManager(ctx, "vault://secret/new", WithSecretManager(manager), WithLocalCache(cachePath))
+	require.NoError(t, err)
+	assert.Equal(t, created.PrivKeyHex(), cached.PrivKeyHex())
+
+	manager.secrets["vault://secret/invalid"] = []byte("not a key")
+	_, err = LoadSoftFromSecretManager(ctx, "vault://secret/invalid", WithSecretManager(manager))
+	require.ErrorContains(t, err, "invalid key in secret vault://secret/invalid")
+}
+
+func TestSignAWSRequest(t *testing.T) {
+	// get-vanilla of the AWS signature version 4 test suite
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	require.NoError(t, err)
+	signAWSRequest(req, nil, AWSCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}, "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+	assert.Equal(t,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+			"SignedHeaders=host;x-amz-date, "+
+			"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"),
+	)
+}
+
+func TestAWSSecretsManager(t *testing.T) {
+	secrets := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		args := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&args))
+		notFound := func() {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"not found"}`))
+		}
+		switch r.Header.Get("X-Amz-Target") {
+		case "secretsmanager.GetSecretValue":
+			value, ok := secrets[args["SecretId"]]
+			if !ok {
+				notFound()
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"SecretString": value})
+		case "secretsmanager.PutSecretValue":
+			if _, ok := secrets[args["SecretId"]]; !ok {
+				notFound()
+				return
+			}
+			secrets[args["SecretId"]] = args["SecretString"]
+		case "secretsmanager.CreateSecret":
+			secrets[args["Name"]] = args["SecretString"]
+		}
+	}))
+	t.Cleanup(server.Close)
+	ref := SecretRef{Provider: AWSSecretsManager, Location: "us-east-1", Name: "deployer"}
+	ctx := context.Background()
+
+	_, err := NewAWSSecretsManagerWithCredentials(AWSCredentials{}).GetSecret(ctx, ref)
+	require.ErrorIs(t, err, ErrMissingCredentials)
+
+	manager := NewAWSSecretsManagerWithCredentials(AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret"})
+	manager.(*awsSecretsManager).endpoint = server.URL
+	_, err = manager.GetSecret(ctx, ref)
+	require.ErrorIs(t, err, ErrSecretNotFound)
+	require.NoError(t, manager.PutSecret(ctx, ref, []byte("v1")))
+	require.NoError(t, manager.PutSecret(ctx, ref, []byte("v2")))
+	value, err := manager.GetSecret(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(value))
+}
+
+func TestGCPSecretManager(t *testing.T) {
+	secrets := map[string][]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		const prefix = "/v1/projects/my-project/secrets"
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == prefix:
+			secrets[r.URL.Query().Get("secretId")] = []string{}
+		case strings.HasSuffix(r.URL.Path, ":addVersion"):
+			name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, prefix+"/"), ":addVersion")
+			if _, ok := secrets[name]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			version := struct {
+				Payload struct {
+					Data []byte `json:"data"`
+				} `json:"payload"`
+			}{}
+			require.NoError(t, json.Unmarshal(body, &version))
+			secrets[name] = append(secrets[name], string(version.Payload.Data))
+		case strings.HasSuffix(r.URL.Path, "/versions/latest:access"):
+			name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, prefix+"/"), "/versions/latest:access")
+			if len(secrets[name]) == 0 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"payload": map[string][]byte{"data": []byte(secrets[name][len(secrets[name])-1])},
+			})
+		}
+	}))
+	t.Cleanup(server.Close)
+	ref := SecretRef{Provider: GCPSecretManager, Location: "my-project", Name: "deployer"}
+	ctx := context.Background()
+
+	manager := NewGCPSecretManagerWithToken(func(context.Context) (string, error) { return "token", nil })
+	manager.(*gcpSecretManager).endpoint = server.URL
+	_, err := manager.GetSecret(ctx, ref)
+	require.ErrorIs(t, err, ErrSecretNotFound)
+	require.NoError(t, manager.PutSecret(ctx, ref, []byte("v1")))
+	require.NoError(t, manager.PutSecret(ctx, ref, []byte("v2")))
+	value, err := manager.GetSecret(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(value))
+	assert.Equal(t, []string{"v1", "v2"}, secrets["deployer"])
+
+	manager = NewGCPSecretManagerWithToken(func(context.Context) (string, error) { return "expired", nil })
+	manager.(*gcpSecretManager).endpoint = server.URL
+	_, err = manager.GetSecret(ctx, ref)
+	require.ErrorIs(t, err, ErrSecretManager)
+}
+
+func TestVaultSecretManager(t *testing.T) {
+	secrets := map[string]map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := secrets[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"data": data}})
+		case http.MethodPost:
+			args := struct {
+				Data map[string]string `json:"data"`
+			}{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&args))
+			secrets[r.URL.Path] = args.Data
+		}
+	}))
+	t.Cleanup(server.Close)
+	ref, err := ParseSecretRef("vault://secret/odyssey/deployer")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = NewVaultSecretManager(server.URL, "").GetSecret(ctx, ref)
+	if os.Getenv("VAULT_TOKEN") == "" {
+		require.ErrorIs(t, err, ErrMissingCredentials)
+	}
+
+	manager := NewVaultSecretManager(server.URL+"/", "token")
+	_, err = manager.GetSecret(ctx, ref)
+	require.ErrorIs(t, err, ErrSecretNotFound)
+	require.NoError(t, manager.PutSecret(ctx, ref, []byte("v1")))
+	assert.Equal(t, map[string]string{"private_key": "v1"}, secrets["/v1/secret/data/odyssey/deployer"])
+	value, err := manager.GetSecret(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(value))
+
+	ref.Field = "other"
+	_, err = manager.GetSecret(ctx, ref)
+	require.ErrorIs(t, err, ErrInvalidSecretRef)
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package keychain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+)
+
+var (
+	ErrNoControlKeys        = errors.New("subnet has no control keys")
+	ErrInvalidThreshold     = errors.New("invalid subnet threshold")
+	ErrDuplicatedControlKey = errors.New("duplicated subnet control key")
+)
+
+// SubnetAuth tells how the keychain can authorize the changes of a subnet
+type SubnetAuth struct {
+	// Threshold is the number of signatures required by the subnet
+	Threshold uint32
+
+	// LocalKeys are the control keys of the subnet in the keychain, in control keys order
+	LocalKeys []ids.ShortID
+
+	// AuthKeys are the Threshold control keys expected to sign, to pass to
+	// Wallet.SetSubnetAuthMultisig or Subnet.SetSubnetAuthKeys. Local keys are preferred,
+	// completed with the first other control keys when the keychain cannot reach Threshold
+	AuthKeys []ids.ShortID
+
+	// RemoteKeys are the AuthKeys not in the keychain, whose owners have to sign the
+	// transaction after it is built, e.g. through multisig.Multisig
+	RemoteKeys []ids.ShortID
+}
+
+// CanSign tells if the keychain alone can reach the subnet threshold
+func (a SubnetAuth) CanSign() bool {
+	return len(a.RemoteKeys) == 0
+}
+
+// Warning describes why the keychain alone cannot authorize the subnet changes, or is
+// empty when it can
+func (a SubnetAuth) Warning() string {
+	if a.CanSign() {
+		return ""
+	}
+	return fmt.Sprintf(
+		"keychain holds %d of the %d signatures required by the subnet: %d more signatures are needed from %v",
+		len(a.LocalKeys), a.Threshold, len(a.RemoteKeys), a.RemoteKeys,
+	)
+}
+
+// SubnetAuth computes the auth keys to use for a subnet with controlKeys and threshold,
+// picking the control keys of the keychain first
+func (kc *Keychain) SubnetAuth(controlKeys []ids.ShortID, threshold uint32) (SubnetAuth, error) {
+	return NewSubnetAuth(kc.Addresses(), controlKeys, threshold)
+}
+
+// NewSubnetAuth computes the auth keys to use for a subnet with controlKeys and threshold,
+// picking the control keys in localKeys first
+func NewSubnetAuth(localKeys set.Set[ids.ShortID], controlKeys []ids.ShortID, threshold uint32) (SubnetAuth, error) {
+	if len(controlKeys) == 0 {
+		return SubnetAuth{}, ErrNoControlKeys
+	}
+	if threshold == 0 || int(threshold) > len(controlKeys) {
+		return SubnetAuth{}, fmt.Errorf("%w %d for %d control keys", ErrInvalidThreshold, threshold, len(controlKeys))
+	}
+	seen := set.NewSet[ids.ShortID](len(controlKeys))
+	for _, controlKey := range controlKeys {
+		if seen.Contains(controlKey) {
+			return SubnetAuth{}, fmt.Errorf("%w %s", ErrDuplicatedControlKey, controlKey)
+		}
+		seen.Add(controlKey)
+	}
+	auth := SubnetAuth{
+		Threshold:  threshold,
+		LocalKeys:  []ids.ShortID{},
+		AuthKeys:   []ids.ShortID{},
+		RemoteKeys: []ids.ShortID{},
+	}
+	for _, controlKey := range controlKeys {
+		if localKeys.Contains(controlKey) {
+			auth.LocalKeys = append(auth.LocalKeys, controlKey)
+		}
+	}
+	// the signatures of a subnet transaction must follow the control keys order
+	signers := set.Of(auth.LocalKeys[:min(len(auth.LocalKeys), int(threshold))]...)
+	for _, controlKey := range controlKeys {
+		if signers.Len() == int(threshold) {
+			break
+		}
+		if !localKeys.Contains(controlKey) {
+			signers.Add(controlKey)
+		}
+	}
+	for _, controlKey := range controlKeys {
+		if !signers.Contains(controlKey) {
+			continue
+		}
+		auth.AuthKeys = append(auth.AuthKeys, controlKey)
+		if !localKeys.Contains(controlKey) {
+			auth.RemoteKeys = append(auth.RemoteKeys, controlKey)
+		}
+	}
+	return auth, nil
+}
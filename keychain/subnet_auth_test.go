@@ -0,0 +1,75 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package keychain
+
+import (
+	"testing"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSubnetAuth(t *testing.T) {
+	keyA, keyB, keyC, keyD := ids.GenerateTestShortID(), ids.GenerateTestShortID(), ids.GenerateTestShortID(), ids.GenerateTestShortID()
+	controlKeys := []ids.ShortID{keyA, keyB, keyC, keyD}
+	tests := []struct {
+		name       string
+		localKeys  set.Set[ids.ShortID]
+		threshold  uint32
+		authKeys   []ids.ShortID
+		remoteKeys []ids.ShortID
+	}{
+		{
+			name:       "local keys reach threshold",
+			localKeys:  set.Of(keyB, keyD),
+			threshold:  2,
+			authKeys:   []ids.ShortID{keyB, keyD},
+			remoteKeys: []ids.ShortID{},
+		},
+		{
+			name:       "more local keys than threshold",
+			localKeys:  set.Of(keyA, keyC, keyD),
+			threshold:  2,
+			authKeys:   []ids.ShortID{keyA, keyC},
+			remoteKeys: []ids.ShortID{},
+		},
+		{
+			name:       "local keys below threshold",
+			localKeys:  set.Of(keyC),
+			threshold:  3,
+			authKeys:   []ids.ShortID{keyA, keyB, keyC},
+			remoteKeys: []ids.ShortID{keyA, keyB},
+		},
+		{
+			name:       "no local keys",
+			localKeys:  set.Of(ids.GenerateTestShortID()),
+			threshold:  1,
+			authKeys:   []ids.ShortID{keyA},
+			remoteKeys: []ids.ShortID{keyA},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth, err := NewSubnetAuth(tt.localKeys, controlKeys, tt.threshold)
+			require.NoError(t, err)
+			assert.Equal(t, tt.authKeys, auth.AuthKeys)
+			assert.Equal(t, tt.remoteKeys, auth.RemoteKeys)
+			assert.Equal(t, len(tt.remoteKeys) == 0, auth.CanSign())
+			assert.Equal(t, auth.CanSign(), auth.Warning() == "")
+		})
+	}
+}
+
+func TestNewSubnetAuth_Errors(t *testing.T) {
+	keyA := ids.GenerateTestShortID()
+	_, err := NewSubnetAuth(set.Of(keyA), nil, 1)
+	require.ErrorIs(t, err, ErrNoControlKeys)
+	_, err = NewSubnetAuth(set.Of(keyA), []ids.ShortID{keyA}, 0)
+	require.ErrorIs(t, err, ErrInvalidThreshold)
+	_, err = NewSubnetAuth(set.Of(keyA), []ids.ShortID{keyA}, 2)
+	require.ErrorIs(t, err, ErrInvalidThreshold)
+	_, err = NewSubnetAuth(set.Of(keyA), []ids.ShortID{keyA, keyA}, 1)
+	require.ErrorIs(t, err, ErrDuplicatedControlKey)
+}
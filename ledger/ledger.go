@@ -4,6 +4,7 @@ package ledger
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
@@ -21,6 +22,12 @@ const (
 
 type LedgerDevice struct {
 	keychain.Ledger
+
+	// deviceMu serializes the signature requests to the device
+	deviceMu sync.Mutex
+
+	signingMu      sync.Mutex
+	signingOptions SigningOptions
 }
 
 func New() (*LedgerDevice, error) {
@@ -28,10 +35,9 @@ func New() (*LedgerDevice, error) {
 	if err != nil {
 		return nil, err
 	}
-	dev := LedgerDevice{
+	return &LedgerDevice{
 		Ledger: avagoDev,
-	}
-	return &dev, nil
+	}, nil
 }
 
 func (dev *LedgerDevice) O(network odyssey.Network, indices []uint32) ([]string, error) {
@@ -42,6 +48,16 @@ func (dev *LedgerDevice) O(network odyssey.Network, indices []uint32) ([]string,
 	return utils.O(network.HRP(), addresses)
 }
 
+// ShowAddress displays the O-Chain address at index on the Ledger screen, formatted with the
+// HRP of network, and returns it
+func (dev *LedgerDevice) ShowAddress(network odyssey.Network, index uint32) (string, error) {
+	addr, err := dev.Address(network.HRP(), index)
+	if err != nil {
+		return "", err
+	}
+	return address.Format("O", network.HRP(), addr[:])
+}
+
 func (dev *LedgerDevice) FindAddresses(addresses []string, maxIndex uint32) (map[string]uint32, error) {
 	addressesIDs, err := address.ParseToIDs(addresses)
 	if err != nil {
@@ -0,0 +1,258 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package ledger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/progress"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/keychain"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+)
+
+// defaultMaxBatchSize keeps the signing paths of a batch well below the buffer limit of the
+// Ledger app, over which it falls back to blind hash signing
+const defaultMaxBatchSize = 16
+
+var (
+	ErrConfirmationTimeout = errors.New("timed out waiting for the Ledger confirmation")
+	ErrNoLedgerIndices     = errors.New("no ledger indices provided")
+	ErrMissingSignature    = errors.New("ledger returned no signature for index")
+)
+
+// SigningOptions configures how the signatures of a LedgerDevice are requested to the user
+type SigningOptions struct {
+	// ConfirmationTimeout is the time the user has to confirm a prompt on the device. As a
+	// prompt cannot be cancelled, the user is reminded and given another ConfirmationTimeout
+	// for each retry left. Zero waits for the user without limit
+	ConfirmationTimeout time.Duration
+
+	// Retries is the number of times a signature is requested again after the device
+	// failed, e.g. the user rejected the prompt, or after a ConfirmationTimeout
+	Retries int
+
+	// MaxBatchSize is the maximum number of addresses signing in a single prompt, 16 if zero
+	MaxBatchSize int
+
+	// Progress receives a StageSign event for each prompt and each signed batch
+	Progress progress.Reporter
+}
+
+// SetSigningOptions sets the options of the signatures requested through the keychains
+// of NewKeychain
+func (dev *LedgerDevice) SetSigningOptions(opts SigningOptions) {
+	dev.signingMu.Lock()
+	defer dev.signingMu.Unlock()
+	dev.signingOptions = opts
+}
+
+func (dev *LedgerDevice) getSigningOptions() SigningOptions {
+	dev.signingMu.Lock()
+	defer dev.signingMu.Unlock()
+	opts := dev.signingOptions
+	if opts.MaxBatchSize <= 0 {
+		opts.MaxBatchSize = defaultMaxBatchSize
+	}
+	return opts
+}
+
+// NewKeychain returns a keychain of the addresses at indices of dev whose signatures of a
+// tx are queued: the first signature requested signs for all the addresses the wallet got
+// signers of, in a single device session, following the SigningOptions of dev
+func NewKeychain(dev *LedgerDevice, indices []uint32) (keychain.Keychain, error) {
+	if len(indices) == 0 {
+		return nil, ErrNoLedgerIndices
+	}
+	addrs, err := dev.Addresses(indices)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) != len(indices) {
+		return nil, fmt.Errorf("expected %d ledger addresses, got %d", len(indices), len(addrs))
+	}
+	kc := &queuedKeychain{
+		queue:     &signingQueue{dev: dev, pending: set.Set[uint32]{}},
+		addrs:     set.Of(addrs...),
+		addrToIdx: map[ids.ShortID]uint32{},
+	}
+	for i, addr := range addrs {
+		kc.addrToIdx[addr] = indices[i]
+	}
+	return kc, nil
+}
+
+type queuedKeychain struct {
+	queue     *signingQueue
+	addrs     set.Set[ids.ShortID]
+	addrToIdx map[ids.ShortID]uint32
+}
+
+func (kc *queuedKeychain) Addresses() set.Set[ids.ShortID] {
+	return kc.addrs
+}
+
+// Get queues the index of addr, as the wallet signers get the signers of all the inputs
+// of a tx before signing it
+func (kc *queuedKeychain) Get(addr ids.ShortID) (keychain.Signer, bool) {
+	idx, ok := kc.addrToIdx[addr]
+	if !ok {
+		return nil, false
+	}
+	kc.queue.add(idx)
+	return &queuedSigner{queue: kc.queue, idx: idx, addr: addr}, true
+}
+
+type queuedSigner struct {
+	queue *signingQueue
+	idx   uint32
+	addr  ids.ShortID
+}
+
+func (s *queuedSigner) SignHash(hash []byte) ([]byte, error) {
+	return s.queue.sign(hash, true, s.idx)
+}
+
+func (s *queuedSigner) Sign(unsignedBytes []byte) ([]byte, error) {
+	return s.queue.sign(unsignedBytes, false, s.idx)
+}
+
+func (s *queuedSigner) Address() ids.ShortID {
+	return s.addr
+}
+
+// signingQueue holds the indices waiting for a signature and the signatures of the last
+// signed payload
+type signingQueue struct {
+	dev *LedgerDevice
+
+	lock       sync.Mutex
+	pending    set.Set[uint32]
+	payload    []byte
+	signHash   bool
+	signatures map[uint32][]byte
+}
+
+func (q *signingQueue) add(idx uint32) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.pending.Add(idx)
+}
+
+// sign returns the signature of payload by idx, signing it with all the pending indices
+// if it is not signed yet
+func (q *signingQueue) sign(payload []byte, signHash bool, idx uint32) ([]byte, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.signHash != signHash || !bytes.Equal(q.payload, payload) {
+		q.payload = bytes.Clone(payload)
+		q.signHash = signHash
+		q.signatures = map[uint32][]byte{}
+	}
+	if sig, ok := q.signatures[idx]; ok {
+		return sig, nil
+	}
+	q.pending.Add(idx)
+	indices := q.pending.List()
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	opts := q.dev.getSigningOptions()
+	total := len(q.signatures) + len(indices)
+	for start := 0; start < len(indices); start += opts.MaxBatchSize {
+		batch := indices[start:min(start+opts.MaxBatchSize, len(indices))]
+		sigs, err := q.dev.signWithRetries(opts, payload, signHash, batch)
+		if err != nil {
+			return nil, err
+		}
+		for i, batchIdx := range batch {
+			q.signatures[batchIdx] = sigs[i]
+			q.pending.Remove(batchIdx)
+		}
+		opts.Progress.Report(progress.Event{
+			Stage:   progress.StageSign,
+			Percent: 100 * float64(len(q.signatures)) / float64(total),
+			Message: fmt.Sprintf("signed %d/%d ledger addresses", len(q.signatures), total),
+		})
+	}
+	sig, ok := q.signatures[idx]
+	if !ok {
+		return nil, fmt.Errorf("%w %d", ErrMissingSignature, idx)
+	}
+	return sig, nil
+}
+
+type signResult struct {
+	sigs [][]byte
+	err  error
+}
+
+// signWithRetries prompts the user to sign payload with indices, prompting again after a
+// failure and reminding the user after a timeout, up to opts.Retries times
+func (dev *LedgerDevice) signWithRetries(opts SigningOptions, payload []byte, signHash bool, indices []uint32) ([][]byte, error) {
+	prompt := func(attempt int) <-chan signResult {
+		message := fmt.Sprintf("confirm the signature of ledger indices %v on the device", indices)
+		if attempt > 0 {
+			message = fmt.Sprintf("%s (retry %d/%d)", message, attempt, opts.Retries)
+		}
+		opts.Progress.Report(progress.Event{Stage: progress.StageSign, Percent: progress.UnknownPercent, Message: message})
+		return dev.startSigning(payload, signHash, indices)
+	}
+	result := prompt(0)
+	for attempt := 0; ; attempt++ {
+		var timeout <-chan time.Time
+		var timer *time.Timer
+		if opts.ConfirmationTimeout > 0 {
+			timer = time.NewTimer(opts.ConfirmationTimeout)
+			timeout = timer.C
+		}
+		select {
+		case res := <-result:
+			if timer != nil {
+				timer.Stop()
+			}
+			if res.err == nil {
+				if len(res.sigs) != len(indices) {
+					return nil, fmt.Errorf("expected %d ledger signatures, got %d", len(indices), len(res.sigs))
+				}
+				return res.sigs, nil
+			}
+			if attempt >= opts.Retries {
+				return nil, res.err
+			}
+			result = prompt(attempt + 1)
+		case <-timeout:
+			if attempt >= opts.Retries {
+				return nil, fmt.Errorf("%w after %s", ErrConfirmationTimeout, opts.ConfirmationTimeout)
+			}
+			// the prompt is still displayed on the device, remind the user of it
+			opts.Progress.Report(progress.Event{
+				Stage:   progress.StageSign,
+				Percent: progress.UnknownPercent,
+				Message: fmt.Sprintf("still waiting for the confirmation of ledger indices %v on the device (retry %d/%d)", indices, attempt+1, opts.Retries),
+			})
+		}
+	}
+}
+
+// startSigning requests the signature of payload by indices to the device. Requests are
+// serialized, so that a request abandoned after a timeout keeps the device until the user
+// acts on its prompt
+func (dev *LedgerDevice) startSigning(payload []byte, signHash bool, indices []uint32) <-chan signResult {
+	result := make(chan signResult, 1)
+	go func() {
+		dev.deviceMu.Lock()
+		defer dev.deviceMu.Unlock()
+		var res signResult
+		if signHash {
+			res.sigs, res.err = dev.SignHash(payload, indices)
+		} else {
+			res.sigs, res.err = dev.Sign(payload, indices)
+		}
+		result <- res
+	}()
+	return result
+}
@@ -0,0 +1,170 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/progress"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errRejected = errors.New("rejected by the user")
+
+// fakeLedger signs with the index as signature, failing or blocking on the first calls
+type fakeLedger struct {
+	lock     sync.Mutex
+	calls    [][]uint32
+	failures int
+	delay    time.Duration
+}
+
+func (*fakeLedger) Version() (*version.Semantic, error) {
+	return &version.Semantic{}, nil
+}
+
+func (*fakeLedger) Address(string, uint32) (ids.ShortID, error) {
+	return ids.ShortEmpty, nil
+}
+
+func (*fakeLedger) Addresses(indices []uint32) ([]ids.ShortID, error) {
+	addrs := make([]ids.ShortID, len(indices))
+	for i, index := range indices {
+		addrs[i] = ids.ShortID{byte(index + 1)}
+	}
+	return addrs, nil
+}
+
+func (l *fakeLedger) SignHash(hash []byte, indices []uint32) ([][]byte, error) {
+	return l.Sign(hash, indices)
+}
+
+func (l *fakeLedger) Sign(_ []byte, indices []uint32) ([][]byte, error) {
+	l.lock.Lock()
+	l.calls = append(l.calls, indices)
+	delay := l.delay
+	l.delay = 0
+	failed := l.failures > 0
+	l.failures--
+	l.lock.Unlock()
+	time.Sleep(delay)
+	if failed {
+		return nil, errRejected
+	}
+	sigs := make([][]byte, len(indices))
+	for i, index := range indices {
+		sigs[i] = []byte(fmt.Sprint(index))
+	}
+	return sigs, nil
+}
+
+func (*fakeLedger) Disconnect() error {
+	return nil
+}
+
+func TestNewKeychain_SignsQueuedIndicesInOneSession(t *testing.T) {
+	fake := &fakeLedger{}
+	dev := &LedgerDevice{Ledger: fake}
+	events := []progress.Event{}
+	dev.SetSigningOptions(SigningOptions{Progress: func(e progress.Event) { events = append(events, e) }})
+	kc, err := NewKeychain(dev, []uint32{0, 3, 5})
+	require.NoError(t, err)
+	assert.Equal(t, 3, kc.Addresses().Len())
+
+	// the wallet gets the signers of all the inputs, then signs
+	signers := []interface {
+		Sign([]byte) ([]byte, error)
+	}{}
+	for _, index := range []uint32{5, 0} {
+		signer, ok := kc.Get(ids.ShortID{byte(index + 1)})
+		require.True(t, ok)
+		signers = append(signers, signer)
+	}
+	for i, index := range []uint32{5, 0} {
+		sig, err := signers[i].Sign([]byte("tx"))
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprint(index), string(sig))
+	}
+	assert.Equal(t, [][]uint32{{0, 5}}, fake.calls)
+	require.Len(t, events, 2)
+	assert.Equal(t, float64(100), events[1].Percent)
+
+	// another tx is signed in a new session
+	signer, _ := kc.Get(ids.ShortID{4})
+	_, err = signer.Sign([]byte("other tx"))
+	require.NoError(t, err)
+	assert.Equal(t, [][]uint32{{0, 5}, {3}}, fake.calls)
+
+	_, ok := kc.Get(ids.ShortID{10})
+	assert.False(t, ok)
+}
+
+func TestNewKeychain_Batches(t *testing.T) {
+	fake := &fakeLedger{}
+	dev := &LedgerDevice{Ledger: fake}
+	dev.SetSigningOptions(SigningOptions{MaxBatchSize: 2})
+	kc, err := NewKeychain(dev, []uint32{0, 1, 2})
+	require.NoError(t, err)
+	for _, addr := range kc.Addresses().List() {
+		_, _ = kc.Get(addr)
+	}
+	signer, _ := kc.Get(ids.ShortID{1})
+	_, err = signer.Sign([]byte("tx"))
+	require.NoError(t, err)
+	assert.Equal(t, [][]uint32{{0, 1}, {2}}, fake.calls)
+
+	_, err = NewKeychain(dev, nil)
+	require.ErrorIs(t, err, ErrNoLedgerIndices)
+}
+
+func TestSignWithRetries(t *testing.T) {
+	tests := []struct {
+		name          string
+		failures      int
+		delay         time.Duration
+		opts          SigningOptions
+		expectedErr   error
+		expectedCalls int
+	}{
+		{name: "signed", expectedCalls: 1},
+		{name: "rejected", failures: 1, expectedErr: errRejected, expectedCalls: 1},
+		{name: "rejected then signed", failures: 2, opts: SigningOptions{Retries: 2}, expectedCalls: 3},
+		{
+			name:          "timed out",
+			delay:         200 * time.Millisecond,
+			opts:          SigningOptions{ConfirmationTimeout: 10 * time.Millisecond},
+			expectedErr:   ErrConfirmationTimeout,
+			expectedCalls: 1,
+		},
+		{
+			name:          "confirmed after a reminder",
+			delay:         30 * time.Millisecond,
+			opts:          SigningOptions{ConfirmationTimeout: 20 * time.Millisecond, Retries: 3},
+			expectedCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeLedger{failures: tt.failures, delay: tt.delay}
+			dev := &LedgerDevice{Ledger: fake}
+			sigs, err := dev.signWithRetries(tt.opts, []byte("tx"), false, []uint32{7})
+			// wait for the abandoned requests
+			dev.deviceMu.Lock()
+			defer dev.deviceMu.Unlock()
+			assert.Len(t, fake.calls, tt.expectedCalls)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, [][]byte{[]byte("7")}, sigs)
+		})
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package multisig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/instrumentation"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/rpc"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/status"
+)
+
+const (
+	defaultCommitAttempts      = 5
+	defaultCommitBackoff       = time.Second
+	maxCommitBackoff           = 30 * time.Second
+	defaultCommitPollFrequency = time.Second
+)
+
+var (
+	ErrNoCommitEndpoint = errors.New("no API endpoint to commit the tx to")
+	// ErrTxDropped is returned when the network drops a committed tx, e.g. because it is invalid
+	ErrTxDropped = errors.New("tx was dropped")
+)
+
+// CommitResult is the outcome of Multisig.Commit
+type CommitResult struct {
+	// TxID is the ID of the committed tx
+	TxID ids.ID
+
+	// Status is the last known status of the tx. It is status.Committed unless the commit
+	// did not wait for acceptance
+	Status status.Status
+
+	// AlreadyIssued is true if the tx was already known to the network when committing,
+	// e.g. because a previous commit crashed after issuing it
+	AlreadyIssued bool
+
+	// Endpoint is the API endpoint that accepted the tx
+	Endpoint string
+}
+
+// CommitOp holds the options of Multisig.Commit
+type CommitOp struct {
+	endpoints     []string
+	attempts      int
+	backoff       time.Duration
+	noWait        bool
+	pollFrequency time.Duration
+}
+
+// CommitOption configures Multisig.Commit
+type CommitOption func(*CommitOp)
+
+// WithCommitEndpoints adds fallback API endpoints, tried in order after the network endpoint
+// when it fails with a transient error
+func WithCommitEndpoints(endpoints ...string) CommitOption {
+	return func(op *CommitOp) {
+		op.endpoints = append(op.endpoints, endpoints...)
+	}
+}
+
+// WithCommitRetries sets the number of rounds over all the endpoints and the initial backoff
+// between them, doubled after each round. Defaults to 5 attempts with a 1s backoff
+func WithCommitRetries(attempts int, backoff time.Duration) CommitOption {
+	return func(op *CommitOp) {
+		op.attempts = attempts
+		op.backoff = backoff
+	}
+}
+
+// WithoutAcceptanceWait makes Commit return once the tx is issued, without waiting for it
+// to be committed
+func WithoutAcceptanceWait() CommitOption {
+	return func(op *CommitOp) {
+		op.noWait = true
+	}
+}
+
+// oChainClient is the subset of omegavm.Client used to commit txs
+type oChainClient interface {
+	IssueTx(ctx context.Context, tx []byte, options ...rpc.Option) (ids.ID, error)
+	GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (*omegavm.GetTxStatusResponse, error)
+	AwaitTxDecided(ctx context.Context, txID ids.ID, freq time.Duration, options ...rpc.Option) (*omegavm.GetTxStatusResponse, error)
+}
+
+var newOChainClient = func(endpoint string) oChainClient {
+	return odyssey.SharedClientFactory().OChain(endpoint)
+}
+
+// Commit issues the fully signed tx to the O-Chain of network and waits for it to be committed.
+//
+// Commit is idempotent: a tx already processing or committed, e.g. because a previous signing
+// ceremony crashed after issuing it, is reported as success with AlreadyIssued set.
+// Transient network failures make Commit try the next endpoint, and retry all of them with an
+// exponential backoff until ctx is done or the attempts are exhausted
+func (ms *Multisig) Commit(ctx context.Context, network odyssey.Network, opts ...CommitOption) (result *CommitResult, err error) {
+	ctx, end := instrumentation.StartRequest(ctx, instrumentation.ComponentMultisig, "Commit", network.Endpoint)
+	defer func() { end(err) }()
+	if ms.Undefined() {
+		return nil, ErrUndefinedTx
+	}
+	op := &CommitOp{
+		attempts:      defaultCommitAttempts,
+		backoff:       defaultCommitBackoff,
+		pollFrequency: defaultCommitPollFrequency,
+	}
+	if network.Endpoint != "" {
+		op.endpoints = []string{network.Endpoint}
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+	if len(op.endpoints) == 0 {
+		return nil, ErrNoCommitEndpoint
+	}
+	isReady, err := ms.IsReadyToCommit()
+	if err != nil {
+		return nil, err
+	}
+	if !isReady {
+		return nil, ErrThresholdNotMet
+	}
+	return ms.commit(ctx, op)
+}
+
+func (ms *Multisig) commit(ctx context.Context, op *CommitOp) (*CommitResult, error) {
+	tx, err := ms.GetWrappedOChainTx()
+	if err != nil {
+		return nil, err
+	}
+	txID := tx.ID()
+	backoff := op.backoff
+	var lastErr error
+	for attempt := 0; attempt < max(op.attempts, 1); attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("commit of tx %s interrupted: %w (last error: %w)", txID, ctx.Err(), lastErr)
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, maxCommitBackoff)
+		}
+		for _, endpoint := range op.endpoints {
+			result, err := commitTo(ctx, newOChainClient(endpoint), tx.Bytes(), txID, op)
+			if err == nil {
+				result.Endpoint = endpoint
+				instrumentation.RecordTxIssued(ctx, instrumentation.Tx{
+					ID:       txID,
+					Kind:     strings.TrimPrefix(fmt.Sprintf("%T", tx.Unsigned), "*txs."),
+					Chain:    "O",
+					Endpoint: endpoint,
+				})
+				return result, nil
+			}
+			if ctx.Err() != nil || !isTransientCommitError(err) {
+				return nil, fmt.Errorf("commit of tx %s to %s failed: %w", txID, endpoint, err)
+			}
+			lastErr = fmt.Errorf("%s: %w", endpoint, err)
+		}
+	}
+	return nil, fmt.Errorf("commit of tx %s failed after %d attempts: %w", txID, op.attempts, lastErr)
+}
+
+// commitTo issues txBytes with client unless the tx is already known, then awaits its decision
+func commitTo(ctx context.Context, client oChainClient, txBytes []byte, txID ids.ID, op *CommitOp) (*CommitResult, error) {
+	result := &CommitResult{TxID: txID}
+	txStatus, err := client.GetTxStatus(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+	switch txStatus.Status {
+	case status.Committed, status.Processing:
+		result.AlreadyIssued = true
+	default:
+		if _, err := client.IssueTx(ctx, txBytes); err != nil {
+			if !isTxKnownError(err) {
+				// the tx may have been accepted meanwhile through another endpoint
+				txStatus, statusErr := client.GetTxStatus(ctx, txID)
+				if statusErr != nil || (txStatus.Status != status.Committed && txStatus.Status != status.Processing) {
+					return nil, err
+				}
+			}
+			result.AlreadyIssued = true
+		}
+	}
+	if op.noWait {
+		result.Status = status.Processing
+		if txStatus.Status == status.Committed {
+			result.Status = status.Committed
+		}
+		return result, nil
+	}
+	txStatus, err = client.AwaitTxDecided(ctx, txID, op.pollFrequency)
+	if err != nil {
+		return nil, err
+	}
+	result.Status = txStatus.Status
+	if txStatus.Status != status.Committed {
+		return nil, fmt.Errorf("%w: %s %s", ErrTxDropped, txStatus.Status, txStatus.Reason)
+	}
+	return result, nil
+}
+
+// isTxKnownError tells if err reports that the issued tx is already known to the node
+func isTxKnownError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, known := range []string{"duplicate tx", "already known", "already accepted", "already issued"} {
+		if strings.Contains(msg, known) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTransientCommitError tells if err is a network failure worth retrying
+func isTransientCommitError(err error) bool {
+	if errors.Is(err, ErrTxDropped) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, transient := range []string{
+		"connection refused",
+		"connection reset",
+		"timeout",
+		"status code: 502",
+		"status code: 503",
+		"status code: 504",
+		"not bootstrapped",
+		"chain not synced",
+		"mempool is full",
+	} {
+		if strings.Contains(msg, transient) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package multisig
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/instrumentation"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/rpc"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/status"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOChainClient answers with the status of its tx, issuing it with issueErr
+type fakeOChainClient struct {
+	status    status.Status
+	issueErr  error
+	statusErr error
+	issued    int
+	// acceptOnIssueErr marks the tx committed even when IssueTx fails
+	acceptOnIssueErr bool
+	// dropOnIssue makes the network drop the issued tx
+	dropOnIssue bool
+}
+
+func (c *fakeOChainClient) IssueTx(context.Context, []byte, ...rpc.Option) (ids.ID, error) {
+	c.issued++
+	if c.issueErr != nil {
+		if c.acceptOnIssueErr {
+			c.status = status.Committed
+		}
+		return ids.Empty, c.issueErr
+	}
+	c.status = status.Committed
+	if c.dropOnIssue {
+		c.status = status.Dropped
+	}
+	return ids.Empty, nil
+}
+
+func (c *fakeOChainClient) GetTxStatus(context.Context, ids.ID, ...rpc.Option) (*omegavm.GetTxStatusResponse, error) {
+	if c.statusErr != nil {
+		return nil, c.statusErr
+	}
+	return &omegavm.GetTxStatusResponse{Status: c.status}, nil
+}
+
+func (c *fakeOChainClient) AwaitTxDecided(ctx context.Context, txID ids.ID, _ time.Duration, _ ...rpc.Option) (*omegavm.GetTxStatusResponse, error) {
+	return c.GetTxStatus(ctx, txID)
+}
+
+func newTestCommitMultisig(t *testing.T) *Multisig {
+	tx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{Owner: &secp256k1fx.OutputOwners{}}}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return New(tx)
+}
+
+func withFakeClients(t *testing.T, clients map[string]*fakeOChainClient) {
+	original := newOChainClient
+	t.Cleanup(func() { newOChainClient = original })
+	newOChainClient = func(endpoint string) oChainClient {
+		return clients[endpoint]
+	}
+}
+
+func TestMultisigCommit(t *testing.T) {
+	errRefused := errors.New("dial tcp: connection refused")
+	tests := []struct {
+		name             string
+		primary          *fakeOChainClient
+		fallback         *fakeOChainClient
+		options          []CommitOption
+		expectedErr      error
+		expectedEndpoint string
+		alreadyIssued    bool
+		expectedStatus   status.Status
+	}{
+		{
+			name:             "issued and committed",
+			primary:          &fakeOChainClient{status: status.Unknown},
+			expectedEndpoint: "http://primary",
+			expectedStatus:   status.Committed,
+		},
+		{
+			name:             "already committed",
+			primary:          &fakeOChainClient{status: status.Committed},
+			expectedEndpoint: "http://primary",
+			alreadyIssued:    true,
+			expectedStatus:   status.Committed,
+		},
+		{
+			name:             "duplicate tx on issue",
+			primary:          &fakeOChainClient{status: status.Unknown, issueErr: errors.New("duplicate tx"), acceptOnIssueErr: true},
+			expectedEndpoint: "http://primary",
+			alreadyIssued:    true,
+			expectedStatus:   status.Committed,
+		},
+		{
+			name:             "accepted meanwhile",
+			primary:          &fakeOChainClient{status: status.Unknown, issueErr: errors.New("failed to get UTXO"), acceptOnIssueErr: true},
+			expectedEndpoint: "http://primary",
+			alreadyIssued:    true,
+			expectedStatus:   status.Committed,
+		},
+		{
+			name:             "fallback endpoint",
+			primary:          &fakeOChainClient{statusErr: errRefused},
+			fallback:         &fakeOChainClient{status: status.Unknown},
+			options:          []CommitOption{WithCommitEndpoints("http://fallback")},
+			expectedEndpoint: "http://fallback",
+			expectedStatus:   status.Committed,
+		},
+		{
+			name:           "no acceptance wait",
+			primary:        &fakeOChainClient{status: status.Unknown, issueErr: errors.New("tx already known")},
+			options:        []CommitOption{WithoutAcceptanceWait()},
+			alreadyIssued:  true,
+			expectedStatus: status.Processing,
+		},
+		{
+			name:        "transient errors exhaust attempts",
+			primary:     &fakeOChainClient{statusErr: errRefused},
+			options:     []CommitOption{WithCommitRetries(2, time.Millisecond)},
+			expectedErr: errRefused,
+		},
+		{
+			name:        "permanent issue error",
+			primary:     &fakeOChainClient{status: status.Unknown, issueErr: errors.New("insufficient funds")},
+			expectedErr: errors.New("insufficient funds"),
+		},
+		{
+			name:        "dropped",
+			primary:     &fakeOChainClient{status: status.Unknown, dropOnIssue: true},
+			expectedErr: ErrTxDropped,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withFakeClients(t, map[string]*fakeOChainClient{"http://primary": tt.primary, "http://fallback": tt.fallback})
+			ms := newTestCommitMultisig(t)
+			network := odyssey.NewNetwork(odyssey.Devnet, 1337, "http://primary")
+
+			result, err := ms.Commit(context.Background(), network, tt.options...)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				if errors.Is(tt.expectedErr, ErrTxDropped) || errors.Is(tt.expectedErr, errRefused) {
+					assert.ErrorIs(t, err, tt.expectedErr)
+				} else {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, ms.OChainTx.ID(), result.TxID)
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			assert.Equal(t, tt.alreadyIssued, result.AlreadyIssued)
+			if tt.expectedEndpoint != "" {
+				assert.Equal(t, tt.expectedEndpoint, result.Endpoint)
+			}
+		})
+	}
+}
+
+func TestMultisigCommit_Errors(t *testing.T) {
+	_, err := (&Multisig{}).Commit(context.Background(), odyssey.TestnetNetwork())
+	assert.ErrorIs(t, err, ErrUndefinedTx)
+
+	ms := newTestCommitMultisig(t)
+	_, err = ms.Commit(context.Background(), odyssey.Network{})
+	assert.ErrorIs(t, err, ErrNoCommitEndpoint)
+
+	withFakeClients(t, map[string]*fakeOChainClient{"http://primary": {statusErr: errors.New("connection refused")}})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = ms.Commit(ctx, odyssey.NewNetwork(odyssey.Devnet, 1337, "http://primary"))
+	assert.Error(t, err)
+}
+
+func TestMultisigCommit_Instrumentation(t *testing.T) {
+	withFakeClients(t, map[string]*fakeOChainClient{"http://primary": {status: status.Unknown}})
+	requests := []instrumentation.Request{}
+	issued := []instrumentation.Tx{}
+	t.Cleanup(instrumentation.Register(instrumentation.Hooks{
+		OnRequestEnd: func(_ context.Context, req instrumentation.Request, _ time.Duration, err error) {
+			assert.NoError(t, err)
+			requests = append(requests, req)
+		},
+		OnTxIssued: func(_ context.Context, tx instrumentation.Tx) {
+			issued = append(issued, tx)
+		},
+	}))
+
+	ms := newTestCommitMultisig(t)
+	_, err := ms.Commit(context.Background(), odyssey.NewNetwork(odyssey.Devnet, 1337, "http://primary"))
+	require.NoError(t, err)
+	assert.Equal(t, []instrumentation.Request{{
+		Component: instrumentation.ComponentMultisig,
+		Operation: "Commit",
+		Target:    "http://primary",
+	}}, requests)
+	assert.Equal(t, []instrumentation.Tx{{
+		ID:       ms.OChainTx.ID(),
+		Kind:     "CreateSubnetTx",
+		Chain:    "O",
+		Endpoint: "http://primary",
+	}}, issued)
+}
+
+func TestIsTransientCommitError(t *testing.T) {
+	assert.True(t, isTransientCommitError(errors.New("received status code: 503")))
+	assert.True(t, isTransientCommitError(context.DeadlineExceeded))
+	assert.False(t, isTransientCommitError(errors.New("insufficient funds")))
+	assert.False(t, isTransientCommitError(ErrTxDropped))
+}
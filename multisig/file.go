@@ -0,0 +1,109 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package multisig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/DioneProtocol/odysseygo/utils/formatting"
+)
+
+// Multisig tx files are made of a header line with FileMagic and the file version, e.g.
+// "odyssey-multisig-tx v1", followed by the payload of that version. Files without header are
+// the legacy raw hex tx files, read as LegacyFileVersion
+const (
+	FileMagic = "odyssey-multisig-tx"
+	// FileVersion is the version of the files written by ToFile. Version 1 payload is the hex
+	// encoded signed tx
+	FileVersion = 1
+	// LegacyFileVersion is the version of the raw hex tx files written before versioning
+	LegacyFileVersion = 0
+)
+
+var (
+	ErrInvalidTxFile          = errors.New("invalid multisig tx file")
+	ErrUnsupportedFileVersion = errors.New("unsupported multisig tx file version")
+)
+
+// encodeTxFile returns the content of a multisig tx file of the current version for txBytes
+func encodeTxFile(txBytes []byte) ([]byte, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't encode signed tx: %w", err)
+	}
+	return []byte(fmt.Sprintf("%s v%d\n%s\n", FileMagic, FileVersion, txStr)), nil
+}
+
+// decodeTxFile returns the version and the tx bytes of the multisig tx file content
+func decodeTxFile(content []byte) (int, []byte, error) {
+	version, payload, err := parseTxFileHeader(content)
+	if err != nil {
+		return 0, nil, err
+	}
+	switch version {
+	case LegacyFileVersion, FileVersion:
+		txBytes, err := formatting.Decode(formatting.Hex, strings.TrimSpace(string(payload)))
+		if err != nil {
+			return 0, nil, fmt.Errorf("couldn't decode signed tx: %w", err)
+		}
+		return version, txBytes, nil
+	default:
+		return 0, nil, fmt.Errorf("%w %d: the latest supported version is %d", ErrUnsupportedFileVersion, version, FileVersion)
+	}
+}
+
+// parseTxFileHeader returns the version and the payload of the multisig tx file content
+func parseTxFileHeader(content []byte) (int, []byte, error) {
+	if !bytes.HasPrefix(content, []byte(FileMagic)) {
+		return LegacyFileVersion, content, nil
+	}
+	header, payload, _ := bytes.Cut(content, []byte("\n"))
+	fields := strings.Fields(string(header))
+	if len(fields) != 2 || fields[0] != FileMagic || !strings.HasPrefix(fields[1], "v") {
+		return 0, nil, fmt.Errorf("%w: malformed header %q", ErrInvalidTxFile, header)
+	}
+	version, err := strconv.Atoi(strings.TrimPrefix(fields[1], "v"))
+	if err != nil || version <= LegacyFileVersion {
+		return 0, nil, fmt.Errorf("%w: malformed version in header %q", ErrInvalidTxFile, header)
+	}
+	return version, payload, nil
+}
+
+// TxFileVersion returns the version of the multisig tx file at txPath
+func TxFileVersion(txPath string) (int, error) {
+	content, err := os.ReadFile(txPath)
+	if err != nil {
+		return 0, err
+	}
+	version, _, err := parseTxFileHeader(content)
+	return version, err
+}
+
+// MigrateTxFile rewrites the multisig tx file at txPath in the current file version, returning
+// false if it already was in that version
+func MigrateTxFile(txPath string) (bool, error) {
+	content, err := os.ReadFile(txPath)
+	if err != nil {
+		return false, err
+	}
+	version, txBytes, err := decodeTxFile(content)
+	if err != nil {
+		return false, err
+	}
+	if version == FileVersion {
+		return false, nil
+	}
+	ms := &Multisig{}
+	if err := ms.FromBytes(txBytes); err != nil {
+		return false, err
+	}
+	if err := ms.ToFile(txPath); err != nil {
+		return false, err
+	}
+	return true, nil
+}
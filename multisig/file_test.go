@@ -0,0 +1,100 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package multisig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DioneProtocol/odysseygo/utils/formatting"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFileTestMultisig(t *testing.T) *Multisig {
+	tx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{Owner: &secp256k1fx.OutputOwners{}}}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return New(tx)
+}
+
+func TestTxFile_RoundTrip(t *testing.T) {
+	ms := newFileTestMultisig(t)
+	txPath := filepath.Join(t.TempDir(), "tx.txt")
+	require.NoError(t, ms.ToFile(txPath))
+
+	content, err := os.ReadFile(txPath)
+	require.NoError(t, err)
+	assert.Regexp(t, `^odyssey-multisig-tx v1\n0x[0-9a-f]+\n$`, string(content))
+
+	version, err := TxFileVersion(txPath)
+	require.NoError(t, err)
+	assert.Equal(t, FileVersion, version)
+
+	loaded := New(nil)
+	require.NoError(t, loaded.FromFile(txPath))
+	assert.Equal(t, ms.OChainTx.ID(), loaded.OChainTx.ID())
+}
+
+func TestTxFile_Legacy(t *testing.T) {
+	ms := newFileTestMultisig(t)
+	txBytes, err := ms.ToBytes()
+	require.NoError(t, err)
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	require.NoError(t, err)
+	txPath := filepath.Join(t.TempDir(), "tx.txt")
+	require.NoError(t, os.WriteFile(txPath, []byte(txStr), 0o600))
+
+	version, err := TxFileVersion(txPath)
+	require.NoError(t, err)
+	assert.Equal(t, LegacyFileVersion, version)
+
+	loaded := New(nil)
+	require.NoError(t, loaded.FromFile(txPath))
+	assert.Equal(t, ms.OChainTx.ID(), loaded.OChainTx.ID())
+
+	migrated, err := MigrateTxFile(txPath)
+	require.NoError(t, err)
+	assert.True(t, migrated)
+	version, err = TxFileVersion(txPath)
+	require.NoError(t, err)
+	assert.Equal(t, FileVersion, version)
+	require.NoError(t, loaded.FromFile(txPath))
+	assert.Equal(t, ms.OChainTx.ID(), loaded.OChainTx.ID())
+
+	migrated, err = MigrateTxFile(txPath)
+	require.NoError(t, err)
+	assert.False(t, migrated)
+}
+
+func TestDecodeTxFile_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectedErr error
+	}{
+		{
+			name:        "future version",
+			content:     "odyssey-multisig-tx v2\n{}\n",
+			expectedErr: ErrUnsupportedFileVersion,
+		},
+		{
+			name:        "missing version",
+			content:     "odyssey-multisig-tx\n0x00\n",
+			expectedErr: ErrInvalidTxFile,
+		},
+		{
+			name:        "malformed version",
+			content:     "odyssey-multisig-tx vX\n0x00\n",
+			expectedErr: ErrInvalidTxFile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := decodeTxFile([]byte(tt.content))
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
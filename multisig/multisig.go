@@ -4,22 +4,42 @@ package multisig
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
 	"github.com/DioneProtocol/odysseygo/ids"
 	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
-	"github.com/DioneProtocol/odysseygo/utils/formatting"
 	"github.com/DioneProtocol/odysseygo/vms/components/verify"
-	"github.com/DioneProtocol/odysseygo/vms/omegavm"
 	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
 	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
 )
 
 type TxKind int64
 
-var ErrUndefinedTx = fmt.Errorf("tx is undefined")
+var (
+	ErrUndefinedTx = errors.New("tx is undefined")
+	// ErrThresholdNotMet is returned when committing a tx that does not have enough signatures yet
+	ErrThresholdNotMet = errors.New("tx is not fully signed so can't be committed")
+	// ErrNetworkMismatch is returned when the network set in a Multisig is not the one of its tx
+	ErrNetworkMismatch = errors.New("tx does not belong to the multisig network")
+)
+
+// ErrUnsupportedTxType is returned when an operation does not support the kind of the
+// unsigned tx wrapped by a Multisig
+type ErrUnsupportedTxType struct {
+	// Kind is the go type of the unsigned tx, e.g. *txs.BaseTx
+	Kind string
+}
+
+func (e *ErrUnsupportedTxType) Error() string {
+	return fmt.Sprintf("unexpected unsigned tx type %s", e.Kind)
+}
+
+func newErrUnsupportedTxType(unsignedTx txs.UnsignedTx) error {
+	return &ErrUnsupportedTxType{Kind: fmt.Sprintf("%T", unsignedTx)}
+}
 
 const (
 	Undefined TxKind = iota
@@ -32,11 +52,36 @@ const (
 )
 
 type Multisig struct {
-	OChainTx    *txs.Tx
+	OChainTx *txs.Tx
+
+	// OwnersCache caches the subnet owners of the tx. The shared cache is used when nil
+	OwnersCache *OwnersCache
+
+	// Network is the network of the tx, required for custom networks. When undefined,
+	// it is derived from the network ID of the tx
+	Network odyssey.Network
+
+	// fixed subnet owners, bypassing the owners cache
 	controlKeys []ids.ShortID
 	threshold   uint32
 }
 
+// ReadyOp holds the options of IsReadyToCommit
+type ReadyOp struct {
+	refreshOwners bool
+}
+
+// ReadyOption configures IsReadyToCommit
+type ReadyOption func(*ReadyOp)
+
+// WithOwnersRefresh makes IsReadyToCommit fetch the subnet owners from the network instead of
+// using cached ones, so that recent ownership transfers are taken into account
+func WithOwnersRefresh() ReadyOption {
+	return func(op *ReadyOp) {
+		op.refreshOwners = true
+	}
+}
+
 func New(OChainTx *txs.Tx) *Multisig {
 	ms := Multisig{
 		OChainTx: OChainTx,
@@ -66,6 +111,7 @@ func (ms *Multisig) ToBytes() ([]byte, error) {
 	return txBytes, nil
 }
 
+// ToFile writes the tx into txPath, in the versioned multisig tx file format
 func (ms *Multisig) ToFile(txPath string) error {
 	if ms.Undefined() {
 		return ErrUndefinedTx
@@ -74,16 +120,16 @@ func (ms *Multisig) ToFile(txPath string) error {
 	if err != nil {
 		return err
 	}
-	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	content, err := encodeTxFile(txBytes)
 	if err != nil {
-		return fmt.Errorf("couldn't encode signed tx: %w", err)
+		return err
 	}
 	f, err := os.Create(txPath)
 	if err != nil {
 		return fmt.Errorf("couldn't create file to write tx to: %w", err)
 	}
 	defer f.Close()
-	_, err = f.WriteString(txStr)
+	_, err = f.Write(content)
 	if err != nil {
 		return fmt.Errorf("couldn't write tx into file: %w", err)
 	}
@@ -102,28 +148,39 @@ func (ms *Multisig) FromBytes(txBytes []byte) error {
 	return nil
 }
 
+// FromFile reads the tx from txPath, accepting both the versioned multisig tx files and the
+// legacy raw hex ones
 func (ms *Multisig) FromFile(txPath string) error {
-	txEncodedBytes, err := os.ReadFile(txPath)
+	content, err := os.ReadFile(txPath)
 	if err != nil {
 		return err
 	}
-	txBytes, err := formatting.Decode(formatting.Hex, string(txEncodedBytes))
+	_, txBytes, err := decodeTxFile(content)
 	if err != nil {
-		return fmt.Errorf("couldn't decode signed tx: %w", err)
+		return err
 	}
 	return ms.FromBytes(txBytes)
 }
 
-func (ms *Multisig) IsReadyToCommit() (bool, error) {
+func (ms *Multisig) IsReadyToCommit(opts ...ReadyOption) (bool, error) {
 	if ms.Undefined() {
 		return false, ErrUndefinedTx
 	}
+	op := &ReadyOp{}
+	for _, opt := range opts {
+		opt(op)
+	}
 	unsignedTx := ms.OChainTx.Unsigned
 	switch unsignedTx.(type) {
 	case *txs.CreateSubnetTx:
 		return true, nil
 	default:
 	}
+	if op.refreshOwners {
+		if _, _, err := ms.RefreshSubnetOwners(); err != nil {
+			return false, err
+		}
+	}
 	_, remainingSigners, err := ms.GetRemainingAuthSigners()
 	if err != nil {
 		return false, err
@@ -213,7 +270,7 @@ func (ms *Multisig) GetAuthSigners() ([]ids.ShortID, error) {
 	// case *txs.TransferSubnetOwnershipTx:
 	// 	subnetAuth = unsignedTx.SubnetAuth
 	default:
-		return nil, fmt.Errorf("unexpected unsigned tx type %T", unsignedTx)
+		return nil, newErrUnsupportedTxType(unsignedTx)
 	}
 	subnetInput, ok := subnetAuth.(*secp256k1fx.Input)
 	if !ok {
@@ -252,7 +309,7 @@ func (ms *Multisig) GetTxKind() (TxKind, error) {
 	// case *txs.TransferSubnetOwnershipTx:
 	// 	return OChainTransferSubnetOwnershipTx, nil
 	default:
-		return Undefined, fmt.Errorf("unexpected unsigned tx type %T", unsignedTx)
+		return Undefined, newErrUnsupportedTxType(unsignedTx)
 	}
 }
 
@@ -277,7 +334,7 @@ func (ms *Multisig) GetNetworkID() (uint32, error) {
 	// case *txs.TransferSubnetOwnershipTx:
 	// 	networkID = unsignedTx.NetworkID
 	default:
-		return 0, fmt.Errorf("unexpected unsigned tx type %T", unsignedTx)
+		return 0, newErrUnsupportedTxType(unsignedTx)
 	}
 	return networkID, nil
 }
@@ -291,6 +348,12 @@ func (ms *Multisig) GetNetwork() (odyssey.Network, error) {
 	if err != nil {
 		return odyssey.UndefinedNetwork, err
 	}
+	if ms.Network.Kind != odyssey.Undefined {
+		if ms.Network.ID != networkID {
+			return odyssey.UndefinedNetwork, fmt.Errorf("%w: tx network ID is %d, multisig network ID is %d", ErrNetworkMismatch, networkID, ms.Network.ID)
+		}
+		return ms.Network, nil
+	}
 	network := odyssey.NetworkFromNetworkID(networkID)
 	if network.Kind == odyssey.Undefined {
 		return odyssey.UndefinedNetwork, fmt.Errorf("undefined network model for tx")
@@ -318,7 +381,7 @@ func (ms *Multisig) GetBlockchainID() (ids.ID, error) {
 	// case *txs.TransferSubnetOwnershipTx:
 	// 	blockchainID = unsignedTx.BlockchainID
 	default:
-		return ids.Empty, fmt.Errorf("unexpected unsigned tx type %T", unsignedTx)
+		return ids.Empty, newErrUnsupportedTxType(unsignedTx)
 	}
 	return blockchainID, nil
 }
@@ -344,37 +407,62 @@ func (ms *Multisig) GetSubnetID() (ids.ID, error) {
 	// case *txs.TransferSubnetOwnershipTx:
 	// 	subnetID = unsignedTx.Subnet
 	default:
-		return ids.Empty, fmt.Errorf("unexpected unsigned tx type %T", unsignedTx)
+		return ids.Empty, newErrUnsupportedTxType(unsignedTx)
 	}
 	return subnetID, nil
 }
 
+// GetSubnetOwners gets the control keys and threshold of the subnet of the tx, using the
+// owners cache of the multisig
 func (ms *Multisig) GetSubnetOwners() ([]ids.ShortID, uint32, error) {
+	return ms.getSubnetOwners(false)
+}
+
+// SetSubnetOwners fixes the subnet owners of the tx, bypassing the owners cache, e.g. to work
+// on a tx offline when its owners are known
+func (ms *Multisig) SetSubnetOwners(controlKeys []ids.ShortID, threshold uint32) {
+	ms.controlKeys = copyControlKeys(controlKeys)
+	ms.threshold = threshold
+}
+
+// RefreshSubnetOwners is GetSubnetOwners fetching the owners from the network and updating
+// the owners cache
+func (ms *Multisig) RefreshSubnetOwners() ([]ids.ShortID, uint32, error) {
+	return ms.getSubnetOwners(true)
+}
+
+func (ms *Multisig) getSubnetOwners(refresh bool) ([]ids.ShortID, uint32, error) {
 	if ms.Undefined() {
 		return nil, 0, ErrUndefinedTx
 	}
-	if ms.controlKeys == nil {
-		subnetID, err := ms.GetSubnetID()
-		if err != nil {
-			return nil, 0, err
-		}
+	if ms.controlKeys != nil {
+		return ms.controlKeys, ms.threshold, nil
+	}
+	subnetID, err := ms.GetSubnetID()
+	if err != nil {
+		return nil, 0, err
+	}
+	network, err := ms.GetNetwork()
+	if err != nil {
+		return nil, 0, err
+	}
+	if refresh {
+		return ms.ownersCache().Refresh(network, subnetID)
+	}
+	return ms.ownersCache().Get(network, subnetID)
+}
 
-		network, err := ms.GetNetwork()
-		if err != nil {
-			return nil, 0, err
-		}
-		controlKeys, threshold, err := GetOwners(network, subnetID)
-		if err != nil {
-			return nil, 0, err
-		}
-		ms.controlKeys = controlKeys
-		ms.threshold = threshold
+func (ms *Multisig) ownersCache() *OwnersCache {
+	if ms.OwnersCache != nil {
+		return ms.OwnersCache
 	}
-	return ms.controlKeys, ms.threshold, nil
+	return sharedOwnersCache
 }
 
+// GetOwners fetches the control keys and threshold of subnetID from the O-Chain of network.
+// Use SharedOwnersCache().Get to avoid querying the network on every call
 func GetOwners(network odyssey.Network, subnetID ids.ID) ([]ids.ShortID, uint32, error) {
-	pClient := omegavm.NewClient(network.Endpoint)
+	pClient := odyssey.SharedClientFactory().OChain(network.Endpoint)
 	ctx := context.Background()
 	subnets, err := pClient.GetSubnets(ctx, []ids.ID{subnetID})
 	if err != nil {
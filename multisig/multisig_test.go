@@ -3,11 +3,13 @@
 package multisig
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odysseytest"
 	"github.com/DioneProtocol/odysseygo/ids"
 	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
 	"github.com/DioneProtocol/odysseygo/vms/components/verify"
@@ -282,7 +284,7 @@ func TestMultisigIntegration(t *testing.T) {
 	t.Run("Integration with local node", func(t *testing.T) {
 		// This test would require a running local node
 		// For now, we'll test the network detection
-		network := odyssey.TestnetNetwork()
+		network := odyssey.LocalNetwork("")
 		assert.Equal(t, "http://127.0.0.1:9650", network.Endpoint)
 		assert.Equal(t, odyssey.Testnet, network.Kind)
 	})
@@ -614,6 +616,23 @@ func TestMultisigGetNetworkComprehensive(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, odyssey.UndefinedNetwork, network)
 	})
+
+	t.Run("GetNetwork with custom network", func(t *testing.T) {
+		ms := New(&txs.Tx{Unsigned: &txs.AddSubnetValidatorTx{}})
+		_, err := ms.GetNetwork()
+		assert.Error(t, err)
+
+		custom, err := odyssey.NewCustomNetwork(0, "http://127.0.0.1:9650", "private")
+		require.NoError(t, err)
+		ms.Network = custom
+		network, err := ms.GetNetwork()
+		require.NoError(t, err)
+		assert.Equal(t, "private", network.HRP())
+
+		ms.Network.ID = 1337
+		_, err = ms.GetNetwork()
+		assert.ErrorIs(t, err, ErrNetworkMismatch)
+	})
 }
 
 // TestMultisigGetSubnetOwnersComprehensive tests GetSubnetOwners with caching
@@ -652,6 +671,21 @@ func TestMultisigGetOwnersComprehensive(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "subnet tx")
 	})
+
+	t.Run("GetOwners from the O-Chain", func(t *testing.T) {
+		srv := odysseytest.NewServer()
+		defer srv.Close()
+		subnetID, err := srv.AddSubnet(odysseytest.Owners(2, ids.ShortID{2}, ids.ShortID{1}))
+		require.NoError(t, err)
+
+		controlKeys, threshold, err := GetOwners(srv.Network(), subnetID)
+		require.NoError(t, err)
+		assert.Equal(t, []ids.ShortID{{1}, {2}}, controlKeys)
+		assert.Equal(t, uint32(2), threshold)
+
+		_, _, err = GetOwners(srv.Network(), ids.GenerateTestID())
+		require.ErrorContains(t, err, "not found")
+	})
 }
 
 // Helper function to check if error message contains any of the expected strings
@@ -1283,6 +1317,8 @@ func TestGetOwnersProduction(t *testing.T) {
 
 		for _, tc := range testCases {
 			t.Run(tc.name, func(t *testing.T) {
+				odyssey.LocalNodeEnvEnabled = true
+				defer func() { odyssey.LocalNodeEnvEnabled = false }()
 				os.Setenv("LOCAL_NODE", tc.localNode)
 				defer os.Unsetenv("LOCAL_NODE")
 
@@ -1792,3 +1828,21 @@ func TestConcurrentAccessSafety(t *testing.T) {
 		}
 	})
 }
+
+func TestMultisigTypedErrors(t *testing.T) {
+	t.Parallel()
+
+	ms := New(&txs.Tx{Unsigned: &txs.CreateSubnetTx{}})
+	_, err := ms.GetTxKind()
+	var unsupportedTxType *ErrUnsupportedTxType
+	require.True(t, errors.As(err, &unsupportedTxType))
+	assert.Equal(t, "*txs.CreateSubnetTx", unsupportedTxType.Kind)
+	assert.Equal(t, "unexpected unsigned tx type *txs.CreateSubnetTx", err.Error())
+
+	_, err = ms.GetSubnetID()
+	assert.True(t, errors.As(err, &unsupportedTxType))
+
+	_, err = New(nil).GetTxKind()
+	assert.ErrorIs(t, err, ErrUndefinedTx)
+	assert.Equal(t, "tx is not fully signed so can't be committed", ErrThresholdNotMet.Error())
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package multisig
+
+import (
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+)
+
+// DefaultOwnersCacheTTL is how long the shared owners cache keeps subnet owners
+const DefaultOwnersCacheTTL = time.Minute
+
+type ownersFetcher func(network odyssey.Network, subnetID ids.ID) ([]ids.ShortID, uint32, error)
+
+type ownersCacheKey struct {
+	networkID uint32
+	endpoint  string
+	subnetID  ids.ID
+}
+
+type ownersCacheEntry struct {
+	controlKeys []ids.ShortID
+	threshold   uint32
+	fetchedAt   time.Time
+}
+
+// OwnersCache caches the control keys and threshold of subnets fetched with GetOwners,
+// keyed by network and subnet ID. It is safe for concurrent use.
+type OwnersCache struct {
+	lock    sync.Mutex
+	ttl     time.Duration
+	entries map[ownersCacheKey]ownersCacheEntry
+	fetch   ownersFetcher
+	now     func() time.Time
+}
+
+var sharedOwnersCache = NewOwnersCache(DefaultOwnersCacheTTL)
+
+// SharedOwnersCache returns the owners cache used by Multisig when it has no OwnersCache set
+func SharedOwnersCache() *OwnersCache {
+	return sharedOwnersCache
+}
+
+// NewOwnersCache creates an owners cache keeping entries for ttl.
+// A ttl of zero or less disables caching
+func NewOwnersCache(ttl time.Duration) *OwnersCache {
+	return &OwnersCache{
+		ttl:     ttl,
+		entries: map[ownersCacheKey]ownersCacheEntry{},
+		fetch:   GetOwners,
+		now:     time.Now,
+	}
+}
+
+func newOwnersCacheKey(network odyssey.Network, subnetID ids.ID) ownersCacheKey {
+	return ownersCacheKey{
+		networkID: network.ID,
+		endpoint:  network.Endpoint,
+		subnetID:  subnetID,
+	}
+}
+
+// TTL returns how long entries are kept
+func (c *OwnersCache) TTL() time.Duration {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.ttl
+}
+
+// SetTTL changes how long entries are kept, applying to the entries already cached
+func (c *OwnersCache) SetTTL(ttl time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.ttl = ttl
+}
+
+// Get returns the owners of subnetID on network, fetching them if they are not cached
+// or expired
+func (c *OwnersCache) Get(network odyssey.Network, subnetID ids.ID) ([]ids.ShortID, uint32, error) {
+	c.lock.Lock()
+	entry, ok := c.entries[newOwnersCacheKey(network, subnetID)]
+	expired := c.now().Sub(entry.fetchedAt) >= c.ttl
+	c.lock.Unlock()
+	if ok && !expired {
+		return copyControlKeys(entry.controlKeys), entry.threshold, nil
+	}
+	return c.Refresh(network, subnetID)
+}
+
+// Refresh fetches the owners of subnetID on network, replacing any cached entry
+func (c *OwnersCache) Refresh(network odyssey.Network, subnetID ids.ID) ([]ids.ShortID, uint32, error) {
+	controlKeys, threshold, err := c.fetch(network, subnetID)
+	if err != nil {
+		return nil, 0, err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.ttl > 0 {
+		c.entries[newOwnersCacheKey(network, subnetID)] = ownersCacheEntry{
+			controlKeys: copyControlKeys(controlKeys),
+			threshold:   threshold,
+			fetchedAt:   c.now(),
+		}
+	}
+	return controlKeys, threshold, nil
+}
+
+// Invalidate removes the cached owners of subnetID on network
+func (c *OwnersCache) Invalidate(network odyssey.Network, subnetID ids.ID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.entries, newOwnersCacheKey(network, subnetID))
+}
+
+// InvalidateAll removes all cached owners
+func (c *OwnersCache) InvalidateAll() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries = map[ownersCacheKey]ownersCacheEntry{}
+}
+
+func copyControlKeys(controlKeys []ids.ShortID) []ids.ShortID {
+	if controlKeys == nil {
+		return nil
+	}
+	return append([]ids.ShortID{}, controlKeys...)
+}
@@ -0,0 +1,237 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package multisig
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/components/verify"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOwners serves subnet owners that can be changed to simulate ownership transfers
+type fakeOwners struct {
+	lock        sync.Mutex
+	calls       int
+	controlKeys []ids.ShortID
+	threshold   uint32
+	err         error
+}
+
+func (f *fakeOwners) fetch(odyssey.Network, ids.ID) ([]ids.ShortID, uint32, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.calls++
+	if f.err != nil {
+		return nil, 0, f.err
+	}
+	return append([]ids.ShortID{}, f.controlKeys...), f.threshold, nil
+}
+
+func (f *fakeOwners) set(controlKeys []ids.ShortID, threshold uint32) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.controlKeys = controlKeys
+	f.threshold = threshold
+}
+
+func newTestOwnersCache(ttl time.Duration, owners *fakeOwners) (*OwnersCache, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	cache := NewOwnersCache(ttl)
+	cache.fetch = owners.fetch
+	cache.now = func() time.Time { return now }
+	return cache, &now
+}
+
+func TestOwnersCache_Get(t *testing.T) {
+	keyA := ids.ShortID{1}
+	keyB := ids.ShortID{2}
+	network := odyssey.TestnetNetwork()
+	subnetID := ids.ID{1}
+	owners := &fakeOwners{controlKeys: []ids.ShortID{keyA}, threshold: 1}
+	cache, now := newTestOwnersCache(time.Minute, owners)
+
+	controlKeys, threshold, err := cache.Get(network, subnetID)
+	require.NoError(t, err)
+	assert.Equal(t, []ids.ShortID{keyA}, controlKeys)
+	assert.Equal(t, uint32(1), threshold)
+	assert.Equal(t, 1, owners.calls)
+
+	// ownership transfer is not seen until the entry expires
+	owners.set([]ids.ShortID{keyB}, 1)
+	controlKeys, _, err = cache.Get(network, subnetID)
+	require.NoError(t, err)
+	assert.Equal(t, []ids.ShortID{keyA}, controlKeys)
+	assert.Equal(t, 1, owners.calls)
+
+	*now = now.Add(time.Minute)
+	controlKeys, _, err = cache.Get(network, subnetID)
+	require.NoError(t, err)
+	assert.Equal(t, []ids.ShortID{keyB}, controlKeys)
+	assert.Equal(t, 2, owners.calls)
+
+	// callers can't modify the cached entry
+	controlKeys[0] = keyA
+	controlKeys, _, err = cache.Get(network, subnetID)
+	require.NoError(t, err)
+	assert.Equal(t, []ids.ShortID{keyB}, controlKeys)
+}
+
+func TestOwnersCache_Keys(t *testing.T) {
+	owners := &fakeOwners{controlKeys: []ids.ShortID{{1}}, threshold: 1}
+	cache, _ := newTestOwnersCache(time.Minute, owners)
+	testnet := odyssey.TestnetNetwork()
+	mainnet := odyssey.MainnetNetwork()
+	localTestnet := odyssey.Network{Kind: testnet.Kind, ID: testnet.ID, Endpoint: "http://127.0.0.1:9650"}
+
+	for _, network := range []odyssey.Network{testnet, mainnet, localTestnet} {
+		for _, subnetID := range []ids.ID{{1}, {2}} {
+			_, _, err := cache.Get(network, subnetID)
+			require.NoError(t, err)
+		}
+	}
+	assert.Equal(t, 6, owners.calls)
+	for _, network := range []odyssey.Network{testnet, mainnet, localTestnet} {
+		_, _, err := cache.Get(network, ids.ID{1})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 6, owners.calls)
+}
+
+func TestOwnersCache_InvalidateAndRefresh(t *testing.T) {
+	network := odyssey.TestnetNetwork()
+	owners := &fakeOwners{controlKeys: []ids.ShortID{{1}}, threshold: 1}
+	cache, _ := newTestOwnersCache(time.Hour, owners)
+
+	_, _, err := cache.Get(network, ids.ID{1})
+	require.NoError(t, err)
+	_, _, err = cache.Get(network, ids.ID{2})
+	require.NoError(t, err)
+	assert.Equal(t, 2, owners.calls)
+
+	cache.Invalidate(network, ids.ID{1})
+	_, _, err = cache.Get(network, ids.ID{1})
+	require.NoError(t, err)
+	_, _, err = cache.Get(network, ids.ID{2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, owners.calls)
+
+	_, _, err = cache.Refresh(network, ids.ID{2})
+	require.NoError(t, err)
+	assert.Equal(t, 4, owners.calls)
+
+	cache.InvalidateAll()
+	_, _, err = cache.Get(network, ids.ID{1})
+	require.NoError(t, err)
+	_, _, err = cache.Get(network, ids.ID{2})
+	require.NoError(t, err)
+	assert.Equal(t, 6, owners.calls)
+}
+
+func TestOwnersCache_TTL(t *testing.T) {
+	network := odyssey.TestnetNetwork()
+	owners := &fakeOwners{controlKeys: []ids.ShortID{{1}}, threshold: 1}
+
+	t.Run("disabled", func(t *testing.T) {
+		cache, _ := newTestOwnersCache(0, owners)
+		owners.calls = 0
+		for i := 0; i < 3; i++ {
+			_, _, err := cache.Get(network, ids.ID{1})
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 3, owners.calls)
+	})
+
+	t.Run("set ttl applies to cached entries", func(t *testing.T) {
+		cache, now := newTestOwnersCache(time.Hour, owners)
+		owners.calls = 0
+		_, _, err := cache.Get(network, ids.ID{1})
+		require.NoError(t, err)
+		*now = now.Add(10 * time.Second)
+		cache.SetTTL(5 * time.Second)
+		assert.Equal(t, 5*time.Second, cache.TTL())
+		_, _, err = cache.Get(network, ids.ID{1})
+		require.NoError(t, err)
+		assert.Equal(t, 2, owners.calls)
+	})
+}
+
+func TestOwnersCache_FetchError(t *testing.T) {
+	network := odyssey.TestnetNetwork()
+	fetchErr := errors.New("subnet tx query error")
+	owners := &fakeOwners{err: fetchErr}
+	cache, _ := newTestOwnersCache(time.Minute, owners)
+
+	_, _, err := cache.Get(network, ids.ID{1})
+	assert.ErrorIs(t, err, fetchErr)
+	// errors are not cached
+	owners.err = nil
+	owners.set([]ids.ShortID{{1}}, 1)
+	controlKeys, _, err := cache.Get(network, ids.ID{1})
+	require.NoError(t, err)
+	assert.Len(t, controlKeys, 1)
+	assert.Equal(t, 2, owners.calls)
+}
+
+func TestSharedOwnersCache(t *testing.T) {
+	assert.Same(t, sharedOwnersCache, SharedOwnersCache())
+	assert.Equal(t, DefaultOwnersCacheTTL, SharedOwnersCache().TTL())
+	assert.Same(t, sharedOwnersCache, New(&txs.Tx{}).ownersCache())
+}
+
+func TestIsReadyToCommit_WithOwnersRefresh(t *testing.T) {
+	keyA := ids.ShortID{1}
+	keyB := ids.ShortID{2}
+	owners := &fakeOwners{controlKeys: []ids.ShortID{keyA}, threshold: 1}
+	cache, _ := newTestOwnersCache(time.Hour, owners)
+
+	filledSig := [secp256k1.SignatureLen]byte{1}
+	tx := &txs.Tx{
+		Unsigned: &txs.AddSubnetValidatorTx{
+			BaseTx:     txs.BaseTx{BaseTx: dione.BaseTx{NetworkID: odyssey.TestnetNetwork().ID}},
+			SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
+		},
+		Creds: []verify.Verifiable{
+			&secp256k1fx.Credential{Sigs: [][secp256k1.SignatureLen]byte{filledSig}},
+			&secp256k1fx.Credential{Sigs: [][secp256k1.SignatureLen]byte{{}}},
+		},
+	}
+	ms := New(tx)
+	ms.OwnersCache = cache
+
+	ready, err := ms.IsReadyToCommit()
+	require.NoError(t, err)
+	assert.False(t, ready)
+	_, remaining, err := ms.GetRemainingAuthSigners()
+	require.NoError(t, err)
+	assert.Equal(t, []ids.ShortID{keyA}, remaining)
+	assert.Equal(t, 1, owners.calls)
+
+	// the subnet ownership is transferred while the signer process is running
+	owners.set([]ids.ShortID{keyB}, 1)
+	_, remaining, err = ms.GetRemainingAuthSigners()
+	require.NoError(t, err)
+	assert.Equal(t, []ids.ShortID{keyA}, remaining)
+
+	ready, err = ms.IsReadyToCommit(WithOwnersRefresh())
+	require.NoError(t, err)
+	assert.False(t, ready)
+	assert.Equal(t, 2, owners.calls)
+	_, remaining, err = ms.GetRemainingAuthSigners()
+	require.NoError(t, err)
+	assert.Equal(t, []ids.ShortID{keyB}, remaining)
+
+	owners.err = errors.New("network down")
+	_, err = ms.IsReadyToCommit(WithOwnersRefresh())
+	assert.ErrorIs(t, err, owners.err)
+}
@@ -0,0 +1,394 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package multisig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+	"github.com/DioneProtocol/odysseygo/utils/hashing"
+	"github.com/DioneProtocol/odysseygo/utils/rpc"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/components/verify"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/status"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+)
+
+// utxosPageSize is the number of UTXOs fetched per O-Chain API call
+const utxosPageSize = 1024
+
+var (
+	// ErrTxExpired is returned when the start time of a tx already passed on the O-Chain
+	ErrTxExpired = errors.New("tx start time passed")
+	// ErrInputsSpent is returned when UTXOs consumed by a tx are not available anymore
+	ErrInputsSpent = errors.New("tx inputs were spent")
+	// ErrSubnetOwnersChanged is returned when the subnet auth of a tx does not match the
+	// current owners of its subnet, e.g. after an ownership transfer
+	ErrSubnetOwnersChanged = errors.New("subnet owners changed since the tx was built")
+)
+
+// stalenessClient is the subset of omegavm.Client used to check the staleness of txs
+type stalenessClient interface {
+	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
+	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
+	GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (*omegavm.GetTxStatusResponse, error)
+	GetUTXOs(ctx context.Context, addrs []ids.ShortID, limit uint32, startAddress ids.ShortID, startUTXOID ids.ID, options ...rpc.Option) ([][]byte, ids.ShortID, ids.ID, error)
+}
+
+var newStalenessClient = func(endpoint string) stalenessClient {
+	return odyssey.SharedClientFactory().OChain(endpoint)
+}
+
+// StalenessReport tells whether a partially signed tx can still be committed, see
+// Multisig.Staleness
+type StalenessReport struct {
+	// TxID is the ID of the tx
+	TxID ids.ID
+
+	// Committed is true if the tx is already committed, e.g. by another participant. Its
+	// inputs are then spent by the tx itself, so no other check is done
+	Committed bool
+
+	// Expired is true if StartTime, the start time of the validation period of the tx,
+	// is not after ChainTime, the O-Chain time when checked. Only validator txs have a
+	// start time
+	Expired   bool
+	StartTime time.Time
+	ChainTime time.Time
+
+	// SpentInputs are the UTXOs consumed by the tx that are not available anymore
+	SpentInputs []ids.ID
+
+	// UncheckedInputs are the UTXOs consumed by the tx whose availability could not be
+	// checked, their owners being unknown, see WithInputOwners
+	UncheckedInputs []ids.ID
+
+	// OwnersChanged is true if the subnet auth of the tx does not match the current owners
+	// of its subnet, as explained by OwnersIssue
+	OwnersChanged bool
+	OwnersIssue   string
+}
+
+// Stale tells if the tx can no longer be committed
+func (r *StalenessReport) Stale() bool {
+	return r.Expired || len(r.SpentInputs) > 0 || r.OwnersChanged
+}
+
+// Dead tells if the tx is not pending anymore, because it is either committed or stale
+func (r *StalenessReport) Dead() bool {
+	return r.Committed || r.Stale()
+}
+
+// Err returns the reasons the tx is stale, joined, or nil if it is not
+func (r *StalenessReport) Err() error {
+	errs := []error{}
+	if r.Expired {
+		errs = append(errs, fmt.Errorf("%w: starts at %s, O-Chain time is %s", ErrTxExpired, r.StartTime.UTC(), r.ChainTime.UTC()))
+	}
+	if len(r.SpentInputs) > 0 {
+		errs = append(errs, fmt.Errorf("%w: %v", ErrInputsSpent, r.SpentInputs))
+	}
+	if r.OwnersChanged {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrSubnetOwnersChanged, r.OwnersIssue))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("tx %s is stale: %w", r.TxID, errors.Join(errs...))
+}
+
+// StalenessOp holds the options of Multisig.Staleness and Multisig.Validate
+type StalenessOp struct {
+	inputOwners []ids.ShortID
+}
+
+// StalenessOption configures Multisig.Staleness and Multisig.Validate
+type StalenessOption func(*StalenessOp)
+
+// WithInputOwners adds addresses owning the inputs of the tx, e.g. those of the wallet
+// that built it, to look for the inputs whose owners are not known otherwise
+func WithInputOwners(addrs ...ids.ShortID) StalenessOption {
+	return func(op *StalenessOp) {
+		op.inputOwners = append(op.inputOwners, addrs...)
+	}
+}
+
+// Validate checks that the partially signed tx can still be committed on network: its
+// start time did not pass, its inputs are available and its subnet auth matches the
+// current owners of its subnet. It returns the errors of StalenessReport.Err, or the error
+// preventing the checks
+func (ms *Multisig) Validate(ctx context.Context, network odyssey.Network, opts ...StalenessOption) error {
+	report, err := ms.Staleness(ctx, network, opts...)
+	if err != nil {
+		return err
+	}
+	return report.Err()
+}
+
+// Staleness checks whether the partially signed tx can still be committed on network:
+//   - a validator tx must start after the current O-Chain time
+//   - its inputs must still be available. Their owners are looked up from the txs that
+//     produced them, the change outputs of the tx and WithInputOwners
+//   - its subnet auth must be valid for the current subnet owners, which are fetched from
+//     network and refresh the owners cache: the signers must be control keys, at least
+//     threshold of them, and the signatures already added must be theirs. Owners set with
+//     SetSubnetOwners must match them
+//
+// A committed tx is reported as such without further checks
+func (ms *Multisig) Staleness(ctx context.Context, network odyssey.Network, opts ...StalenessOption) (*StalenessReport, error) {
+	if ms.Undefined() {
+		return nil, ErrUndefinedTx
+	}
+	op := &StalenessOp{}
+	for _, opt := range opts {
+		opt(op)
+	}
+	tx := ms.OChainTx
+	if networkID, err := ms.GetNetworkID(); err == nil && network.ID != 0 && networkID != network.ID {
+		return nil, fmt.Errorf("%w: tx network ID is %d, network ID is %d", ErrNetworkMismatch, networkID, network.ID)
+	}
+	client := newStalenessClient(network.Endpoint)
+	report := &StalenessReport{TxID: tx.ID()}
+	txStatus, err := client.GetTxStatus(ctx, report.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the status of tx %s: %w", report.TxID, err)
+	}
+	if txStatus.Status == status.Committed {
+		report.Committed = true
+		return report, nil
+	}
+	if staker, ok := tx.Unsigned.(interface{ StartTime() time.Time }); ok {
+		chainTime, err := client.GetTimestamp(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the O-Chain time: %w", err)
+		}
+		report.StartTime = staker.StartTime()
+		report.ChainTime = chainTime
+		report.Expired = !report.StartTime.After(chainTime)
+	}
+	if err := checkInputs(ctx, client, tx, op.inputOwners, report); err != nil {
+		return nil, err
+	}
+	if err := ms.checkSubnetAuth(network, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// checkInputs reports the inputs of tx that are spent, or whose owners are unknown
+func checkInputs(ctx context.Context, client stalenessClient, tx *txs.Tx, inputOwners []ids.ShortID, report *StalenessReport) error {
+	inputIDs := tx.Unsigned.InputIDs()
+	if inputIDs.Len() == 0 {
+		return nil
+	}
+	// owners of the inputs, by input
+	owners := map[ids.ID]set.Set[ids.ShortID]{}
+	addrs := set.Of(inputOwners...)
+	for _, utxo := range tx.UTXOs() {
+		addrs.Add(outputAddresses(utxo.Out)...)
+	}
+	producers := map[ids.ID]*txs.Tx{}
+	for _, in := range inputUTXOs(tx.Unsigned) {
+		producer, ok := producers[in.TxID]
+		if !ok {
+			// the producing tx may not be an O-Chain tx, e.g. for genesis UTXOs
+			if txBytes, err := client.GetTx(ctx, in.TxID); err == nil {
+				producer, _ = txs.Parse(txs.Codec, txBytes)
+			}
+			producers[in.TxID] = producer
+		}
+		if producer == nil {
+			continue
+		}
+		for _, utxo := range producer.UTXOs() {
+			if utxo.InputID() == in.InputID() {
+				inOwners := set.Of(outputAddresses(utxo.Out)...)
+				owners[in.InputID()] = inOwners
+				addrs.Union(inOwners)
+			}
+		}
+	}
+	available := set.Set[ids.ID]{}
+	if addrs.Len() > 0 {
+		var err error
+		if available, err = fetchUTXOIDs(ctx, client, addrs.List()); err != nil {
+			return err
+		}
+	}
+	for _, inputID := range sortedIDs(inputIDs) {
+		switch {
+		case available.Contains(inputID):
+		case owners[inputID].Len() > 0:
+			report.SpentInputs = append(report.SpentInputs, inputID)
+		default:
+			report.UncheckedInputs = append(report.UncheckedInputs, inputID)
+		}
+	}
+	return nil
+}
+
+// fetchUTXOIDs returns the IDs of the O-Chain UTXOs owned by addrs
+func fetchUTXOIDs(ctx context.Context, client stalenessClient, addrs []ids.ShortID) (set.Set[ids.ID], error) {
+	utxoIDs := set.Set[ids.ID]{}
+	startAddr, startUTXO := ids.ShortEmpty, ids.Empty
+	for {
+		utxosBytes, endAddr, endUTXO, err := client.GetUTXOs(ctx, addrs, utxosPageSize, startAddr, startUTXO)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the O-Chain UTXOs: %w", err)
+		}
+		for _, utxoBytes := range utxosBytes {
+			utxo := &dione.UTXO{}
+			if _, err := txs.Codec.Unmarshal(utxoBytes, utxo); err != nil {
+				return nil, fmt.Errorf("failed to parse O-Chain UTXO: %w", err)
+			}
+			utxoIDs.Add(utxo.InputID())
+		}
+		if len(utxosBytes) < utxosPageSize {
+			return utxoIDs, nil
+		}
+		startAddr, startUTXO = endAddr, endUTXO
+	}
+}
+
+// checkSubnetAuth reports whether the subnet auth of the tx does not match the current
+// owners of its subnet, always fetched from network, nor the owners set with
+// SetSubnetOwners if any. Txs without subnet auth are not checked
+func (ms *Multisig) checkSubnetAuth(network odyssey.Network, report *StalenessReport) error {
+	subnetInput := ms.subnetAuthInput()
+	if subnetInput == nil {
+		return nil
+	}
+	subnetID, err := ms.GetSubnetID()
+	if err != nil {
+		return err
+	}
+	controlKeys, threshold, err := ms.ownersCache().Refresh(network, subnetID)
+	if err != nil {
+		return err
+	}
+	// the signer indices of the tx refer to the control keys it was built with
+	if ms.controlKeys != nil && (ms.threshold != threshold || !slices.Equal(ms.controlKeys, controlKeys)) {
+		report.OwnersChanged = true
+		report.OwnersIssue = fmt.Sprintf("the tx was built for threshold %d of %d control keys, the subnet has threshold %d of %d control keys", ms.threshold, len(ms.controlKeys), threshold, len(controlKeys))
+		return nil
+	}
+	if uint32(len(subnetInput.SigIndices)) < threshold {
+		report.OwnersChanged = true
+		report.OwnersIssue = fmt.Sprintf("the tx has %d signers, the subnet threshold is %d", len(subnetInput.SigIndices), threshold)
+		return nil
+	}
+	cred, ok := ms.subnetAuthCredential()
+	unsignedHash := hashing.ComputeHash256(ms.OChainTx.Unsigned.Bytes())
+	emptySig := [secp256k1.SignatureLen]byte{}
+	factory := secp256k1.Factory{}
+	for i, sigIndex := range subnetInput.SigIndices {
+		if sigIndex >= uint32(len(controlKeys)) {
+			report.OwnersChanged = true
+			report.OwnersIssue = fmt.Sprintf("signer index %d exceeds the %d control keys of the subnet", sigIndex, len(controlKeys))
+			return nil
+		}
+		if !ok || i >= len(cred.Sigs) || cred.Sigs[i] == emptySig {
+			continue
+		}
+		publicKey, err := factory.RecoverHashPublicKey(unsignedHash, cred.Sigs[i][:])
+		if err != nil || publicKey.Address() != controlKeys[sigIndex] {
+			report.OwnersChanged = true
+			report.OwnersIssue = fmt.Sprintf("signature %d is not from control key %s of the subnet", i, controlKeys[sigIndex])
+			return nil
+		}
+	}
+	return nil
+}
+
+// subnetAuthInput returns the subnet auth of the tx, nil if it has none
+func (ms *Multisig) subnetAuthInput() *secp256k1fx.Input {
+	var subnetAuth verify.Verifiable
+	switch unsignedTx := ms.OChainTx.Unsigned.(type) {
+	case *txs.RemoveSubnetValidatorTx:
+		subnetAuth = unsignedTx.SubnetAuth
+	case *txs.AddSubnetValidatorTx:
+		subnetAuth = unsignedTx.SubnetAuth
+	case *txs.CreateChainTx:
+		subnetAuth = unsignedTx.SubnetAuth
+	case *txs.TransformSubnetTx:
+		subnetAuth = unsignedTx.SubnetAuth
+	}
+	subnetInput, _ := subnetAuth.(*secp256k1fx.Input)
+	return subnetInput
+}
+
+// subnetAuthCredential returns the subnet auth credential of the tx, its last one
+func (ms *Multisig) subnetAuthCredential() (*secp256k1fx.Credential, bool) {
+	creds := ms.OChainTx.Creds
+	if len(creds) == 0 {
+		return nil, false
+	}
+	cred, ok := creds[len(creds)-1].(*secp256k1fx.Credential)
+	return cred, ok
+}
+
+// inputUTXOs returns the O-Chain UTXOs consumed by unsignedTx, all O-Chain txs consuming
+// UTXOs embedding a BaseTx. The UTXOs imported by an ImportTx are left out
+func inputUTXOs(unsignedTx txs.UnsignedTx) []*dione.UTXOID {
+	withInputs, ok := unsignedTx.(interface{ InputUTXOs() []*dione.UTXOID })
+	if !ok {
+		return nil
+	}
+	return withInputs.InputUTXOs()
+}
+
+// sortedIDs returns the IDs of idSet in ascending order
+func sortedIDs(idSet set.Set[ids.ID]) []ids.ID {
+	sorted := idSet.List()
+	utils.Sort(sorted)
+	return sorted
+}
+
+// outputAddresses returns the addresses owning out, if a secp256k1fx output
+func outputAddresses(out interface{}) []ids.ShortID {
+	owned, ok := out.(interface{ Addresses() [][]byte })
+	if !ok {
+		return nil
+	}
+	addrs := []ids.ShortID{}
+	for _, addr := range owned.Addresses() {
+		if shortID, err := ids.ToShortID(addr); err == nil {
+			addrs = append(addrs, shortID)
+		}
+	}
+	return addrs
+}
+
+// PruneStale checks the pending partially signed txs on network, e.g. those a signing
+// coordinator collects signatures for, and splits them into the live ones and the reports
+// of the dead ones, committed or stale, to stop collecting signatures for. The txs that
+// could not be checked are kept live, and the errors of their checks returned joined
+func PruneStale(ctx context.Context, network odyssey.Network, pending []*Multisig, opts ...StalenessOption) ([]*Multisig, []*StalenessReport, error) {
+	live := []*Multisig{}
+	dead := []*StalenessReport{}
+	errs := []error{}
+	for _, ms := range pending {
+		report, err := ms.Staleness(ctx, network, opts...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to check tx %s: %w", ms, err))
+			live = append(live, ms)
+			continue
+		}
+		if report.Dead() {
+			dead = append(dead, report)
+			continue
+		}
+		live = append(live, ms)
+	}
+	return live, dead, errors.Join(errs...)
+}
@@ -0,0 +1,350 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package multisig
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+	"github.com/DioneProtocol/odysseygo/utils/hashing"
+	"github.com/DioneProtocol/odysseygo/utils/rpc"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/components/verify"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/status"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStalenessClient serves an O-Chain with the known txs and the UTXOs of its fields
+type fakeStalenessClient struct {
+	chainTime time.Time
+	txs       map[ids.ID]*txs.Tx
+	committed set.Set[ids.ID]
+	utxos     []*dione.UTXO
+	err       error
+}
+
+func (c *fakeStalenessClient) GetTimestamp(context.Context, ...rpc.Option) (time.Time, error) {
+	return c.chainTime, c.err
+}
+
+func (c *fakeStalenessClient) GetTx(_ context.Context, txID ids.ID, _ ...rpc.Option) ([]byte, error) {
+	tx, ok := c.txs[txID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return tx.Bytes(), nil
+}
+
+func (c *fakeStalenessClient) GetTxStatus(_ context.Context, txID ids.ID, _ ...rpc.Option) (*omegavm.GetTxStatusResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.committed.Contains(txID) {
+		return &omegavm.GetTxStatusResponse{Status: status.Committed}, nil
+	}
+	return &omegavm.GetTxStatusResponse{Status: status.Unknown}, nil
+}
+
+func (c *fakeStalenessClient) GetUTXOs(_ context.Context, addrs []ids.ShortID, _ uint32, _ ids.ShortID, _ ids.ID, _ ...rpc.Option) ([][]byte, ids.ShortID, ids.ID, error) {
+	utxosBytes := [][]byte{}
+	for _, utxo := range c.utxos {
+		owners := set.Of(outputAddresses(utxo.Out)...)
+		if owners.Overlaps(set.Of(addrs...)) {
+			utxoBytes, err := txs.Codec.Marshal(txs.Version, utxo)
+			if err != nil {
+				return nil, ids.ShortEmpty, ids.Empty, err
+			}
+			utxosBytes = append(utxosBytes, utxoBytes)
+		}
+	}
+	return utxosBytes, ids.ShortEmpty, ids.Empty, nil
+}
+
+func withFakeStalenessClient(t *testing.T, client *fakeStalenessClient) {
+	original := newStalenessClient
+	t.Cleanup(func() { newStalenessClient = original })
+	newStalenessClient = func(string) stalenessClient {
+		return client
+	}
+}
+
+// stalenessFixture is an AddSubnetValidatorTx funded by a UTXO of funder, whose subnet is
+// owned by 2 of controlKeys, signed by the first control key
+type stalenessFixture struct {
+	ms          *Multisig
+	client      *fakeStalenessClient
+	owners      *fakeOwners
+	funder      *secp256k1.PrivateKey
+	controlKeys []*secp256k1.PrivateKey
+	startTime   time.Time
+}
+
+func newStalenessFixture(t *testing.T) *stalenessFixture {
+	factory := secp256k1.Factory{}
+	newKey := func() *secp256k1.PrivateKey {
+		key, err := factory.NewPrivateKey()
+		require.NoError(t, err)
+		return key
+	}
+	f := &stalenessFixture{
+		funder:      newKey(),
+		controlKeys: []*secp256k1.PrivateKey{newKey(), newKey(), newKey()},
+		startTime:   time.Unix(1_700_000_000, 0),
+	}
+	assetID := ids.GenerateTestID()
+	producer := &txs.Tx{Unsigned: &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: dione.BaseTx{
+			NetworkID:    constants.TestnetID,
+			BlockchainID: constants.OmegaChainID,
+			Outs: []*dione.TransferableOutput{{
+				Asset: dione.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          1_000_000,
+					OutputOwners: secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{f.funder.Address()}},
+				},
+			}},
+		}},
+		Owner: &secp256k1fx.OutputOwners{},
+	}}
+	require.NoError(t, producer.Initialize(txs.Codec))
+	fundingUTXO := producer.UTXOs()[0]
+
+	tx := &txs.Tx{Unsigned: &txs.AddSubnetValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: dione.BaseTx{
+			NetworkID:    constants.TestnetID,
+			BlockchainID: constants.OmegaChainID,
+			Ins: []*dione.TransferableInput{{
+				UTXOID: fundingUTXO.UTXOID,
+				Asset:  fundingUTXO.Asset,
+				In:     &secp256k1fx.TransferInput{Amt: 1_000_000, Input: secp256k1fx.Input{SigIndices: []uint32{0}}},
+			}},
+		}},
+		SubnetValidator: txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: ids.GenerateTestNodeID(),
+				Start:  uint64(f.startTime.Unix()),
+				End:    uint64(f.startTime.Add(24 * time.Hour).Unix()),
+				Wght:   20,
+			},
+			Subnet: ids.GenerateTestID(),
+		},
+		SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{0, 2}},
+	}}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	unsignedHash := hashing.ComputeHash256(tx.Unsigned.Bytes())
+	fundingSig, err := f.funder.SignHash(unsignedHash)
+	require.NoError(t, err)
+	authSig, err := f.controlKeys[0].SignHash(unsignedHash)
+	require.NoError(t, err)
+	fundingCred := &secp256k1fx.Credential{Sigs: make([][secp256k1.SignatureLen]byte, 1)}
+	copy(fundingCred.Sigs[0][:], fundingSig)
+	authCred := &secp256k1fx.Credential{Sigs: make([][secp256k1.SignatureLen]byte, 2)}
+	copy(authCred.Sigs[0][:], authSig)
+	tx.Creds = []verify.Verifiable{fundingCred, authCred}
+	require.NoError(t, tx.Initialize(txs.Codec))
+
+	f.client = &fakeStalenessClient{
+		chainTime: f.startTime.Add(-time.Hour),
+		txs:       map[ids.ID]*txs.Tx{producer.ID(): producer},
+		committed: set.Set[ids.ID]{},
+		utxos:     []*dione.UTXO{fundingUTXO},
+	}
+	withFakeStalenessClient(t, f.client)
+	f.owners = &fakeOwners{}
+	f.owners.set(f.controlKeyAddrs(), 2)
+	cache, _ := newTestOwnersCache(time.Minute, f.owners)
+	f.ms = New(tx)
+	f.ms.OwnersCache = cache
+	return f
+}
+
+func (f *stalenessFixture) controlKeyAddrs() []ids.ShortID {
+	addrs := []ids.ShortID{}
+	for _, key := range f.controlKeys {
+		addrs = append(addrs, key.Address())
+	}
+	return addrs
+}
+
+func TestMultisigStaleness(t *testing.T) {
+	network := odyssey.TestnetNetwork()
+	ctx := context.Background()
+
+	t.Run("pending", func(t *testing.T) {
+		f := newStalenessFixture(t)
+		report, err := f.ms.Staleness(ctx, network)
+		require.NoError(t, err)
+		assert.False(t, report.Dead())
+		assert.Empty(t, report.SpentInputs)
+		assert.Empty(t, report.UncheckedInputs)
+		assert.Equal(t, f.startTime, report.StartTime)
+		// the owners are refreshed from the network on each check
+		assert.Equal(t, 1, f.owners.calls)
+		require.NoError(t, f.ms.Validate(ctx, network))
+		assert.Equal(t, 2, f.owners.calls)
+	})
+
+	t.Run("committed", func(t *testing.T) {
+		f := newStalenessFixture(t)
+		f.client.committed.Add(f.ms.OChainTx.ID())
+		f.client.utxos = nil
+		report, err := f.ms.Staleness(ctx, network)
+		require.NoError(t, err)
+		assert.True(t, report.Committed)
+		assert.False(t, report.Stale())
+		assert.True(t, report.Dead())
+		assert.NoError(t, f.ms.Validate(ctx, network))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		f := newStalenessFixture(t)
+		f.client.chainTime = f.startTime
+		err := f.ms.Validate(ctx, network)
+		require.ErrorIs(t, err, ErrTxExpired)
+		assert.NotErrorIs(t, err, ErrInputsSpent)
+	})
+
+	t.Run("input spent", func(t *testing.T) {
+		f := newStalenessFixture(t)
+		inputID := f.client.utxos[0].InputID()
+		f.client.utxos = nil
+		report, err := f.ms.Staleness(ctx, network)
+		require.NoError(t, err)
+		assert.Equal(t, []ids.ID{inputID}, report.SpentInputs)
+		require.ErrorIs(t, report.Err(), ErrInputsSpent)
+	})
+
+	t.Run("unknown input owners", func(t *testing.T) {
+		f := newStalenessFixture(t)
+		f.client.txs = map[ids.ID]*txs.Tx{}
+		report, err := f.ms.Staleness(ctx, network)
+		require.NoError(t, err)
+		assert.Len(t, report.UncheckedInputs, 1)
+		assert.False(t, report.Stale())
+
+		report, err = f.ms.Staleness(ctx, network, WithInputOwners(f.funder.Address()))
+		require.NoError(t, err)
+		assert.Empty(t, report.UncheckedInputs)
+		assert.Empty(t, report.SpentInputs)
+	})
+
+	t.Run("inputs of any tx type", func(t *testing.T) {
+		f := newStalenessFixture(t)
+		fundingUTXO := f.client.utxos[0]
+		base := txs.BaseTx{BaseTx: dione.BaseTx{
+			NetworkID:    constants.TestnetID,
+			BlockchainID: constants.OmegaChainID,
+			Ins: []*dione.TransferableInput{{
+				UTXOID: fundingUTXO.UTXOID,
+				Asset:  fundingUTXO.Asset,
+				In:     &secp256k1fx.TransferInput{Amt: 1_000_000, Input: secp256k1fx.Input{SigIndices: []uint32{0}}},
+			}},
+		}}
+		unsignedTxs := []txs.UnsignedTx{
+			&txs.ExportTx{BaseTx: base, DestinationChain: ids.GenerateTestID()},
+			&txs.AddDelegatorTx{BaseTx: base, DelegationRewardsOwner: &secp256k1fx.OutputOwners{}},
+		}
+		f.client.utxos = nil
+		for _, unsignedTx := range unsignedTxs {
+			tx := &txs.Tx{Unsigned: unsignedTx}
+			require.NoError(t, tx.Initialize(txs.Codec))
+			report, err := New(tx).Staleness(ctx, network)
+			require.NoError(t, err)
+			assert.Equal(t, []ids.ID{fundingUTXO.InputID()}, report.SpentInputs)
+		}
+	})
+
+	t.Run("owners set offline", func(t *testing.T) {
+		f := newStalenessFixture(t)
+		f.ms.SetSubnetOwners(f.controlKeyAddrs(), 2)
+		require.NoError(t, f.ms.Validate(ctx, network))
+		// the owners are fetched from the network even when set
+		assert.Equal(t, 1, f.owners.calls)
+
+		addrs := f.controlKeyAddrs()
+		f.owners.set([]ids.ShortID{addrs[0], addrs[2], addrs[1]}, 2)
+		err := f.ms.Validate(ctx, network)
+		require.ErrorIs(t, err, ErrSubnetOwnersChanged)
+		assert.ErrorContains(t, err, "the tx was built for threshold 2 of 3 control keys")
+	})
+
+	t.Run("owners transferred", func(t *testing.T) {
+		f := newStalenessFixture(t)
+		addrs := f.controlKeyAddrs()
+		addrs[0] = ids.GenerateTestShortID()
+		f.owners.set(addrs, 2)
+		err := f.ms.Validate(ctx, network)
+		require.ErrorIs(t, err, ErrSubnetOwnersChanged)
+		assert.ErrorContains(t, err, "signature 0 is not from control key")
+	})
+
+	t.Run("threshold raised", func(t *testing.T) {
+		f := newStalenessFixture(t)
+		f.owners.set(f.controlKeyAddrs(), 3)
+		require.ErrorIs(t, f.ms.Validate(ctx, network), ErrSubnetOwnersChanged)
+	})
+
+	t.Run("control keys removed", func(t *testing.T) {
+		f := newStalenessFixture(t)
+		f.owners.set(f.controlKeyAddrs()[:2], 2)
+		err := f.ms.Validate(ctx, network)
+		require.ErrorIs(t, err, ErrSubnetOwnersChanged)
+		assert.ErrorContains(t, err, "signer index 2 exceeds")
+	})
+
+	t.Run("network mismatch", func(t *testing.T) {
+		f := newStalenessFixture(t)
+		_, err := f.ms.Staleness(ctx, odyssey.MainnetNetwork())
+		require.ErrorIs(t, err, ErrNetworkMismatch)
+	})
+
+	t.Run("undefined", func(t *testing.T) {
+		require.ErrorIs(t, New(nil).Validate(ctx, network), ErrUndefinedTx)
+	})
+}
+
+func TestPruneStale(t *testing.T) {
+	network := odyssey.TestnetNetwork()
+	f := newStalenessFixture(t)
+	committed := New(f.ms.OChainTx)
+	committed.OwnersCache = f.ms.OwnersCache
+
+	expired := newStalenessFixture(t)
+	expired.client.chainTime = expired.startTime.Add(time.Hour)
+	// the fixtures share the fake client of the last one
+	for txID, tx := range f.client.txs {
+		expired.client.txs[txID] = tx
+	}
+	expired.client.utxos = append(expired.client.utxos, f.client.utxos...)
+
+	live, dead, err := PruneStale(context.Background(), network, []*Multisig{f.ms, expired.ms})
+	require.NoError(t, err)
+	require.Len(t, dead, 2)
+	assert.Empty(t, live)
+	assert.ErrorIs(t, dead[1].Err(), ErrTxExpired)
+
+	expired.client.chainTime = expired.startTime.Add(-time.Hour)
+	expired.client.committed.Add(committed.OChainTx.ID())
+	live, dead, err = PruneStale(context.Background(), network, []*Multisig{committed, expired.ms})
+	require.NoError(t, err)
+	assert.Equal(t, []*Multisig{expired.ms}, live)
+	require.Len(t, dead, 1)
+	assert.True(t, dead[0].Committed)
+
+	expired.client.err = errors.New("connection refused")
+	live, dead, err = PruneStale(context.Background(), network, []*Multisig{expired.ms})
+	require.ErrorContains(t, err, "connection refused")
+	assert.Equal(t, []*Multisig{expired.ms}, live)
+	assert.Empty(t, dead)
+}
@@ -12,7 +12,6 @@ import (
 	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
 
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
-	"github.com/DioneProtocol/odyssey-tooling-sdk-go/subnet"
 
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
@@ -36,24 +35,24 @@ func (h *Node) ValidatePrimaryNetwork(
 	wallet wallet.Wallet,
 ) (ids.ID, error) {
 	if validatorParams.NodeID == ids.EmptyNodeID {
-		return ids.Empty, subnet.ErrEmptyValidatorNodeID
+		return ids.Empty, validator.ErrEmptyNodeID
 	}
 
 	if validatorParams.Duration == 0 {
-		return ids.Empty, subnet.ErrEmptyValidatorDuration
+		return ids.Empty, validator.ErrEmptyDuration
 	}
 
-	minValStake, err := network.GetMinStakingAmount()
+	networkParams, err := odyssey.GetNetworkParams(network)
 	if err != nil {
 		return ids.Empty, err
 	}
 
-	if validatorParams.StakeAmount < minValStake {
-		return ids.Empty, fmt.Errorf("invalid weight, must be greater than or equal to %d: %d", minValStake, validatorParams.StakeAmount)
+	if validatorParams.DelegationFee == 0 {
+		validatorParams.DelegationFee = networkParams.MinDelegationFee
 	}
 
-	if validatorParams.DelegationFee == 0 {
-		validatorParams.DelegationFee = network.GenesisParams().MinDelegationFee
+	if err := validatorParams.Validate(networkParams); err != nil {
+		return ids.Empty, err
 	}
 
 	if err = h.GetBLSKeyFromRemoteHost(); err != nil {
@@ -75,11 +74,19 @@ func (h *Node) ValidatePrimaryNetwork(
 		return ids.Empty, err
 	}
 
+	startTime := time.Now()
+	var start uint64
+	if !validatorParams.StartTime.IsZero() {
+		startTime = validatorParams.StartTime
+		start = uint64(startTime.Unix())
+	}
+
 	unsignedTx, err := wallet.O().Builder().NewAddPermissionlessValidatorTx(
 		&txs.SubnetValidator{
 			Validator: txs.Validator{
 				NodeID: nodeID,
-				End:    uint64(time.Now().Add(validatorParams.Duration).Unix()),
+				Start:  start,
+				End:    uint64(startTime.Add(validatorParams.Duration).Unix()),
 				Wght:   validatorParams.StakeAmount,
 			},
 			Subnet: ids.Empty,
@@ -119,7 +126,7 @@ func (h *Node) ValidatePrimaryNetwork(
 
 // GetBLSKeyFromRemoteHost gets BLS information from remote host and sets the BlsSecretKey value in Node object
 func (h *Node) GetBLSKeyFromRemoteHost() error {
-	blsKeyBytes, err := h.ReadFileBytes(remoteconfig.GetRemoteBLSKeyFile(), constants.SSHFileOpsTimeout)
+	blsKeyBytes, err := h.ReadFileBytes(remoteconfig.GetRemoteBLSKeyFile(h.config()), constants.SSHFileOpsTimeout)
 	if err != nil {
 		return err
 	}
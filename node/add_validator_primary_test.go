@@ -67,7 +67,7 @@ func TestValidatePrimaryNetwork_ValidationErrors(t *testing.T) {
 				StakeAmount: 100, // Too low
 			},
 			expectError:   true,
-			errorContains: "invalid weight",
+			errorContains: "stake amount out of range",
 		},
 		{
 			name: "Valid parameters",
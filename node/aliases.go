@@ -0,0 +1,221 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+	"github.com/DioneProtocol/odysseygo/ids"
+)
+
+var (
+	ErrInvalidAlias = errors.New("invalid alias")
+	ErrEmptyVMID    = errors.New("VM ID is not provided")
+
+	aliasRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+)
+
+// ChainAlias is a friendly name of a VM and, optionally, of a blockchain running it, so that
+// RPC URLs can use the name instead of the blockchain ID, e.g. /ext/bc/<name>/rpc
+type ChainAlias struct {
+	// Name is the alias, e.g. the chain name
+	Name string
+
+	// VMID is the VM aliased as Name
+	VMID ids.ID
+
+	// BlockchainID is the blockchain aliased as Name. No blockchain is aliased when empty
+	BlockchainID ids.ID
+}
+
+// Validate checks that the alias can be set on odysseygo
+func (a ChainAlias) Validate() error {
+	if a.VMID == ids.Empty {
+		return ErrEmptyVMID
+	}
+	if !aliasRegex.MatchString(a.Name) {
+		return fmt.Errorf("%w %q: must be letters, digits, _, . or -", ErrInvalidAlias, a.Name)
+	}
+	// odysseygo would not know whether the name is an alias or an ID
+	if _, err := ids.FromString(a.Name); err == nil {
+		return fmt.Errorf("%w %q: aliases cannot be IDs", ErrInvalidAlias, a.Name)
+	}
+	return nil
+}
+
+// ConfigureAliases writes aliases into the VM and chain aliases files of odysseygo. An alias
+// replaces the previous aliases of its VM and blockchain, and is removed from any other VM
+// or blockchain, so that renamed chains keep a single alias.
+//
+// Chain aliases are set at runtime through the admin API when it is enabled. Otherwise, and
+// whenever VM aliases change, odysseygo is restarted. Returns whether odysseygo was restarted
+func (h *Node) ConfigureAliases(aliases []ChainAlias) (bool, error) {
+	for _, alias := range aliases {
+		if err := alias.Validate(); err != nil {
+			return false, err
+		}
+	}
+	vmAliasesChanged, chainAliasesChanged, err := h.uploadAliases(aliases)
+	if err != nil {
+		return false, err
+	}
+	if !h.aliasesNeedRestart(aliases, vmAliasesChanged, chainAliasesChanged) {
+		return false, nil
+	}
+	// odysseygo only reads its aliases files on startup
+	if err := h.RunSSHRestartOdysseygo(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// aliasesNeedRestart tells if odysseygo has to be restarted to apply changed aliases, setting
+// the chain aliases at runtime when only them changed
+func (h *Node) aliasesNeedRestart(aliases []ChainAlias, vmAliasesChanged bool, chainAliasesChanged bool) bool {
+	switch {
+	case vmAliasesChanged:
+		return true
+	case chainAliasesChanged:
+		return h.aliasChains(aliases) != nil
+	}
+	return false
+}
+
+// uploadAliases updates the remote VM and chain aliases files with aliases, returning
+// whether each of them changed
+func (h *Node) uploadAliases(aliases []ChainAlias) (bool, bool, error) {
+	if len(aliases) == 0 {
+		return false, false, nil
+	}
+	vmAliases := map[ids.ID]string{}
+	chainAliases := map[ids.ID]string{}
+	for _, alias := range aliases {
+		vmAliases[alias.VMID] = alias.Name
+		if alias.BlockchainID != ids.Empty {
+			chainAliases[alias.BlockchainID] = alias.Name
+		}
+	}
+	vmAliasesChanged, err := h.uploadAliasesFile(remoteconfig.GetRemoteOdysseyVMAliasesFile(h.config()), vmAliases)
+	if err != nil {
+		return false, false, err
+	}
+	chainAliasesChanged, err := h.uploadAliasesFile(remoteconfig.GetRemoteOdysseyChainAliasesFile(h.config()), chainAliases)
+	if err != nil {
+		return false, false, err
+	}
+	return vmAliasesChanged, chainAliasesChanged, nil
+}
+
+// uploadAliasesFile sets aliases into the remote aliases file, returning whether it changed
+func (h *Node) uploadAliasesFile(remoteFile string, aliases map[ids.ID]string) (bool, error) {
+	if len(aliases) == 0 {
+		return false, nil
+	}
+	content := map[string][]string{}
+	exists, err := h.FileExists(remoteFile)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		data, err := h.ReadFileBytes(remoteFile, constants.SSHFileOpsTimeout)
+		if err != nil {
+			return false, err
+		}
+		if err := json.Unmarshal(data, &content); err != nil {
+			return false, fmt.Errorf("invalid aliases file %s: %w", remoteFile, err)
+		}
+	}
+	changed := false
+	for id, name := range aliases {
+		changed = setAlias(content, id.String(), name) || changed
+	}
+	if !changed {
+		return false, nil
+	}
+	data, err := json.MarshalIndent(content, "", "\t")
+	if err != nil {
+		return false, err
+	}
+	if err := h.MkdirAll(filepath.Dir(remoteFile), constants.SSHFileOpsTimeout); err != nil {
+		return false, err
+	}
+	return h.UploadBytesIfChanged(data, remoteFile, constants.SSHFileOpsTimeout, true)
+}
+
+// setAlias makes name the only alias of id in aliases, removing it from any other ID.
+// Returns whether aliases changed
+func setAlias(aliases map[string][]string, id string, name string) bool {
+	changed := false
+	for otherID, names := range aliases {
+		if otherID == id || !slices.Contains(names, name) {
+			continue
+		}
+		names = slices.DeleteFunc(slices.Clone(names), func(n string) bool { return n == name })
+		if len(names) == 0 {
+			delete(aliases, otherID)
+		} else {
+			aliases[otherID] = names
+		}
+		changed = true
+	}
+	if !slices.Equal(aliases[id], []string{name}) {
+		aliases[id] = []string{name}
+		changed = true
+	}
+	return changed
+}
+
+// aliasChains sets the chain aliases through the admin API of odysseygo. Aliases previously
+// set at runtime are kept until odysseygo restarts
+func (h *Node) aliasChains(aliases []ChainAlias) error {
+	for _, alias := range aliases {
+		if alias.BlockchainID == ids.Empty {
+			continue
+		}
+		request, err := json.Marshal(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  "admin.aliasChain",
+			"params": map[string]string{
+				"chain": alias.BlockchainID.String(),
+				"alias": alias.Name,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		response, err := h.Post("/ext/admin", string(request))
+		if err != nil {
+			return err
+		}
+		if err := parseJSONRPCError(response); err != nil {
+			return fmt.Errorf("failed to alias chain %s as %s: %w", alias.BlockchainID, alias.Name, err)
+		}
+	}
+	return nil
+}
+
+// parseJSONRPCError returns the error of a JSON RPC response, if any
+func parseJSONRPCError(response []byte) error {
+	reply := struct {
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+	if err := json.Unmarshal(response, &reply); err != nil {
+		return fmt.Errorf("unexpected JSON RPC response %q: %w", response, err)
+	}
+	if reply.Error != nil {
+		return fmt.Errorf("JSON RPC error %d: %s", reply.Error.Code, reply.Error.Message)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"testing"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainAlias_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		alias       ChainAlias
+		expectedErr error
+	}{
+		{name: "valid", alias: ChainAlias{Name: "my-chain_1.0", VMID: ids.ID{1}}},
+		{name: "no VM ID", alias: ChainAlias{Name: "mychain"}, expectedErr: ErrEmptyVMID},
+		{name: "empty name", alias: ChainAlias{VMID: ids.ID{1}}, expectedErr: ErrInvalidAlias},
+		{name: "path in name", alias: ChainAlias{Name: "my/chain", VMID: ids.ID{1}}, expectedErr: ErrInvalidAlias},
+		{name: "ID as name", alias: ChainAlias{Name: ids.ID{3}.String(), VMID: ids.ID{1}}, expectedErr: ErrInvalidAlias},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.alias.Validate(), tt.expectedErr)
+		})
+	}
+}
+
+func TestSetAlias(t *testing.T) {
+	tests := []struct {
+		name            string
+		aliases         map[string][]string
+		expectedAliases map[string][]string
+		expectedChanged bool
+	}{
+		{
+			name:            "new alias",
+			aliases:         map[string][]string{},
+			expectedAliases: map[string][]string{"vm": {"mychain"}},
+			expectedChanged: true,
+		},
+		{
+			name:            "unchanged",
+			aliases:         map[string][]string{"vm": {"mychain"}},
+			expectedAliases: map[string][]string{"vm": {"mychain"}},
+		},
+		{
+			name:            "renamed",
+			aliases:         map[string][]string{"vm": {"oldchain"}},
+			expectedAliases: map[string][]string{"vm": {"mychain"}},
+			expectedChanged: true,
+		},
+		{
+			name:            "moved from another VM",
+			aliases:         map[string][]string{"old-vm": {"mychain", "other"}, "stale-vm": {"mychain"}},
+			expectedAliases: map[string][]string{"old-vm": {"other"}, "vm": {"mychain"}},
+			expectedChanged: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedChanged, setAlias(tt.aliases, "vm", "mychain"))
+			assert.Equal(t, tt.expectedAliases, tt.aliases)
+		})
+	}
+}
+
+func TestParseJSONRPCError(t *testing.T) {
+	assert.NoError(t, parseJSONRPCError([]byte(`{"jsonrpc":"2.0","result":{},"id":1}`)))
+	assert.ErrorContains(t, parseJSONRPCError([]byte(`{"jsonrpc":"2.0","error":{"code":-32000,"message":"alias already exists"},"id":1}`)), "alias already exists")
+	// admin API disabled
+	assert.Error(t, parseJSONRPCError([]byte("404 page not found")))
+}
+
+func TestConfigureAliases_Validation(t *testing.T) {
+	h := &Node{}
+	_, err := h.ConfigureAliases([]ChainAlias{{Name: "mychain"}})
+	assert.ErrorIs(t, err, ErrEmptyVMID)
+	restarted, err := h.ConfigureAliases(nil)
+	assert.NoError(t, err)
+	assert.False(t, restarted)
+}
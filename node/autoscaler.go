@@ -0,0 +1,424 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+)
+
+const (
+	// odysseyGoAPICallsMetric counts the API calls served by odysseygo
+	odysseyGoAPICallsMetric = "odyssey_api_calls"
+	// odysseyGoCPUMetric is the CPU time, in seconds, used by odysseygo and its VM processes
+	odysseyGoCPUMetric = "odyssey_system_resources_num_cpu_cycles"
+)
+
+var (
+	ErrNoScalingPolicy = errors.New("autoscaler has no scaling policy")
+	ErrNoNodeProvider  = errors.New("autoscaler has no node provider")
+)
+
+// LoadMetrics is the load of an API node, measured between two scrapes of its odysseygo
+// metrics
+type LoadMetrics struct {
+	NodeID string
+
+	// RPCRequestsPerSecond is the rate of the API calls served by the node
+	RPCRequestsPerSecond float64
+
+	// CPUCores is the number of CPU cores used by odysseygo and its VMs, e.g. 1.5
+	CPUCores float64
+}
+
+// ScaleDecision is the scaling of the API nodes decided by a ScalingPolicy
+type ScaleDecision struct {
+	// Add is the number of API nodes to create
+	Add int
+
+	// Remove are the API nodes to destroy
+	Remove []*Node
+
+	// Reason explains the decision, e.g. for logs
+	Reason string
+}
+
+// NoChange tells if the decision keeps the API nodes as they are
+func (d ScaleDecision) NoChange() bool {
+	return d.Add == 0 && len(d.Remove) == 0
+}
+
+// ScalingPolicy decides the scaling of the API nodes from their load. load only holds the
+// nodes measured so far, which is none on the first evaluation of an Autoscaler
+type ScalingPolicy interface {
+	Decide(nodes []*Node, load []LoadMetrics) ScaleDecision
+}
+
+// ScalingPolicyFunc is a ScalingPolicy defined by a function
+type ScalingPolicyFunc func(nodes []*Node, load []LoadMetrics) ScaleDecision
+
+func (f ScalingPolicyFunc) Decide(nodes []*Node, load []LoadMetrics) ScaleDecision {
+	return f(nodes, load)
+}
+
+// ThresholdPolicy scales the API nodes on the average load of a node. Zero thresholds are
+// not checked
+type ThresholdPolicy struct {
+	// MinNodes and MaxNodes bound the number of API nodes. MaxNodes is not checked if zero
+	MinNodes int
+	MaxNodes int
+
+	// ScaleUpRPS and ScaleUpCPUCores are the average load of a node above which Step nodes
+	// are added
+	ScaleUpRPS      float64
+	ScaleUpCPUCores float64
+
+	// ScaleDownRPS and ScaleDownCPUCores are the average load of a node below which the least
+	// loaded node is removed. The load must be below all the thresholds set
+	ScaleDownRPS      float64
+	ScaleDownCPUCores float64
+
+	// Step is the number of nodes added at once, 1 if zero
+	Step int
+}
+
+func (p ThresholdPolicy) Decide(nodes []*Node, load []LoadMetrics) ScaleDecision {
+	switch {
+	case len(nodes) < p.MinNodes:
+		return ScaleDecision{Add: p.MinNodes - len(nodes), Reason: fmt.Sprintf("%d nodes, below the minimum of %d", len(nodes), p.MinNodes)}
+	case p.MaxNodes > 0 && len(nodes) > p.MaxNodes:
+		return ScaleDecision{
+			Remove: leastLoadedNodes(nodes, load, len(nodes)-p.MaxNodes),
+			Reason: fmt.Sprintf("%d nodes, above the maximum of %d", len(nodes), p.MaxNodes),
+		}
+	case len(load) == 0:
+		return ScaleDecision{Reason: "no load measured yet"}
+	}
+	var rps, cpu float64
+	for _, l := range load {
+		rps += l.RPCRequestsPerSecond
+		cpu += l.CPUCores
+	}
+	rps /= float64(len(load))
+	cpu /= float64(len(load))
+	average := fmt.Sprintf("average load of %.1f requests/s and %.2f CPU cores", rps, cpu)
+
+	if (p.ScaleUpRPS > 0 && rps > p.ScaleUpRPS) || (p.ScaleUpCPUCores > 0 && cpu > p.ScaleUpCPUCores) {
+		add := max(p.Step, 1)
+		if p.MaxNodes > 0 {
+			add = min(add, p.MaxNodes-len(nodes))
+		}
+		if add <= 0 {
+			return ScaleDecision{Reason: fmt.Sprintf("%s, already at the maximum of %d nodes", average, p.MaxNodes)}
+		}
+		return ScaleDecision{Add: add, Reason: average + ", above the scale up thresholds"}
+	}
+	scaleDown := p.ScaleDownRPS > 0 || p.ScaleDownCPUCores > 0
+	if p.ScaleDownRPS > 0 && rps >= p.ScaleDownRPS {
+		scaleDown = false
+	}
+	if p.ScaleDownCPUCores > 0 && cpu >= p.ScaleDownCPUCores {
+		scaleDown = false
+	}
+	if scaleDown && len(nodes) > max(p.MinNodes, 1) {
+		return ScaleDecision{Remove: leastLoadedNodes(nodes, load, 1), Reason: average + ", below the scale down thresholds"}
+	}
+	return ScaleDecision{Reason: average}
+}
+
+// leastLoadedNodes returns the count nodes serving the fewest requests, the nodes not
+// measured yet first
+func leastLoadedNodes(nodes []*Node, load []LoadMetrics, count int) []*Node {
+	rps := map[string]float64{}
+	for _, l := range load {
+		rps[l.NodeID] = l.RPCRequestsPerSecond
+	}
+	sorted := append([]*Node{}, nodes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, measuredI := rps[sorted[i].NodeID]
+		rj, measuredJ := rps[sorted[j].NodeID]
+		if measuredI != measuredJ {
+			return !measuredI
+		}
+		return ri < rj
+	})
+	return sorted[:min(count, len(sorted))]
+}
+
+// NodeProvider creates and destroys the API nodes of an Autoscaler
+type NodeProvider interface {
+	// CreateNodes returns count new API nodes, provisioned and running odysseygo
+	CreateNodes(ctx context.Context, count int) ([]Node, error)
+
+	// DestroyNode destroys node
+	DestroyNode(ctx context.Context, node *Node) error
+}
+
+type nodeParamsProvider struct {
+	nodeParams NodeParams
+}
+
+// NewNodeParamsProvider returns a NodeProvider creating nodes with CreateNodes and
+// nodeParams, and destroying them with Node.Destroy. Both fail with ErrCloudRemoved since
+// the cloud functionality was removed: implement NodeProvider with the tool creating the
+// instances instead, e.g. Terraform with the user-data of CloudInitUserData
+func NewNodeParamsProvider(nodeParams NodeParams) NodeProvider {
+	return &nodeParamsProvider{nodeParams: nodeParams}
+}
+
+func (p *nodeParamsProvider) CreateNodes(ctx context.Context, count int) ([]Node, error) {
+	nodeParams := p.nodeParams
+	nodeParams.Count = count
+	return CreateNodes(ctx, &nodeParams)
+}
+
+func (p *nodeParamsProvider) DestroyNode(ctx context.Context, node *Node) error {
+	return node.Destroy(ctx)
+}
+
+// AutoscalerOp holds the options of NewAutoscaler
+type AutoscalerOp struct {
+	monitoringNode *Node
+}
+
+// AutoscalerOption configures NewAutoscaler
+type AutoscalerOption func(*AutoscalerOp)
+
+// WithAutoscalerMonitoring makes the autoscaler add the nodes it creates to the monitoring
+// node, and remove the nodes it destroys
+func WithAutoscalerMonitoring(monitoringNode *Node) AutoscalerOption {
+	return func(op *AutoscalerOp) {
+		op.monitoringNode = monitoringNode
+	}
+}
+
+// loadSample is a scrape of the metrics of an API node
+type loadSample struct {
+	at         time.Time
+	apiCalls   float64
+	cpuSeconds float64
+}
+
+// Autoscaler scales a fleet of API nodes on their load, as decided by its ScalingPolicy.
+// Each evaluation scrapes the odysseygo metrics of the nodes, the load of a node being
+// measured from its second scrape on. It is safe for concurrent use
+type Autoscaler struct {
+	policy         ScalingPolicy
+	provider       NodeProvider
+	monitoringNode *Node
+
+	lock    sync.Mutex
+	nodes   []*Node
+	samples map[string]loadSample
+	now     func() time.Time
+}
+
+// scrapeLoadMetrics returns the odysseygo metrics of node used to measure its load, in the
+// Prometheus text format
+var scrapeLoadMetrics = func(node *Node) ([]byte, error) {
+	output, err := node.Command(nil, constants.SSHPOSTTimeout, fmt.Sprintf(
+		"curl -sf http://127.0.0.1:%d/ext/metrics | grep -E '^(%s|%s)[ {]'",
+		node.config().OdysseygoAPIPort,
+		odysseyGoAPICallsMetric,
+		odysseyGoCPUMetric,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape the metrics of node %s: %w: %s", node.NodeID, err, string(output))
+	}
+	return output, nil
+}
+
+// NewAutoscaler creates an autoscaler of the API nodes, creating and destroying nodes with
+// provider as decided by policy
+func NewAutoscaler(nodes []*Node, policy ScalingPolicy, provider NodeProvider, opts ...AutoscalerOption) (*Autoscaler, error) {
+	if policy == nil {
+		return nil, ErrNoScalingPolicy
+	}
+	if provider == nil {
+		return nil, ErrNoNodeProvider
+	}
+	op := &AutoscalerOp{}
+	for _, opt := range opts {
+		opt(op)
+	}
+	return &Autoscaler{
+		policy:         policy,
+		provider:       provider,
+		monitoringNode: op.monitoringNode,
+		nodes:          append([]*Node{}, nodes...),
+		samples:        map[string]loadSample{},
+		now:            time.Now,
+	}, nil
+}
+
+// Nodes returns the API nodes of the autoscaler
+func (a *Autoscaler) Nodes() []*Node {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return append([]*Node{}, a.nodes...)
+}
+
+// Load scrapes the metrics of the API nodes and returns the load of the nodes scraped
+// before. Nodes failing to be scraped are skipped, their errors joined
+func (a *Autoscaler) Load(ctx context.Context) ([]LoadMetrics, error) {
+	nodes := a.Nodes()
+	results := RunOnNodes(nodes, 0, func(node *Node) (interface{}, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return scrapeLoadMetrics(node)
+	})
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	load := []LoadMetrics{}
+	var errs []error
+	for _, result := range results.GetResults() {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+			continue
+		}
+		sample, err := parseLoadSample(result.Value.([]byte))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("node %s: %w", result.NodeID, err))
+			continue
+		}
+		sample.at = a.now()
+		previous, ok := a.samples[result.NodeID]
+		a.samples[result.NodeID] = sample
+		elapsed := sample.at.Sub(previous.at).Seconds()
+		// counters going down tell that odysseygo restarted
+		if !ok || elapsed <= 0 || sample.apiCalls < previous.apiCalls || sample.cpuSeconds < previous.cpuSeconds {
+			continue
+		}
+		load = append(load, LoadMetrics{
+			NodeID:               result.NodeID,
+			RPCRequestsPerSecond: (sample.apiCalls - previous.apiCalls) / elapsed,
+			CPUCores:             (sample.cpuSeconds - previous.cpuSeconds) / elapsed,
+		})
+	}
+	sort.Slice(load, func(i, j int) bool { return load[i].NodeID < load[j].NodeID })
+	return load, errors.Join(errs...)
+}
+
+// parseLoadSample sums the API calls and CPU time of the odysseygo metrics
+func parseLoadSample(metrics []byte) (loadSample, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(strings.NewReader(string(metrics)))
+	if err != nil {
+		return loadSample{}, fmt.Errorf("failed to parse odysseygo metrics: %w", err)
+	}
+	sample := loadSample{}
+	for name, sum := range map[string]*float64{
+		odysseyGoAPICallsMetric: &sample.apiCalls,
+		odysseyGoCPUMetric:      &sample.cpuSeconds,
+	} {
+		family, ok := families[name]
+		if !ok {
+			return loadSample{}, fmt.Errorf("odysseygo metric %s not found", name)
+		}
+		for _, metric := range family.Metric {
+			switch {
+			case metric.Counter != nil:
+				*sum += metric.Counter.GetValue()
+			case metric.Gauge != nil:
+				*sum += metric.Gauge.GetValue()
+			case metric.Untyped != nil:
+				*sum += metric.Untyped.GetValue()
+			}
+		}
+	}
+	return sample, nil
+}
+
+// Evaluate measures the load of the API nodes and returns the decision of the policy,
+// without applying it. The policy decides on the nodes measured, the scrape errors of the
+// others being returned with the decision
+func (a *Autoscaler) Evaluate(ctx context.Context) (ScaleDecision, error) {
+	load, err := a.Load(ctx)
+	if ctx.Err() != nil {
+		return ScaleDecision{}, ctx.Err()
+	}
+	return a.policy.Decide(a.Nodes(), load), err
+}
+
+// Apply creates and destroys the API nodes of decision. Created nodes are added to the
+// monitoring node, and destroyed ones removed from it
+func (a *Autoscaler) Apply(ctx context.Context, decision ScaleDecision) error {
+	var errs []error
+	if decision.Add > 0 {
+		created, err := a.provider.CreateNodes(ctx, decision.Add)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to create %d API nodes: %w", decision.Add, err))
+		}
+		a.lock.Lock()
+		for i := range created {
+			a.nodes = append(a.nodes, &created[i])
+		}
+		a.lock.Unlock()
+		if len(created) > 0 && a.monitoringNode != nil {
+			if err := a.monitoringNode.AddMonitoringTargets(ctx, created); err != nil {
+				errs = append(errs, fmt.Errorf("failed to add the created API nodes to monitoring: %w", err))
+			}
+		}
+	}
+	destroyed := []Node{}
+	for _, node := range decision.Remove {
+		if err := a.provider.DestroyNode(ctx, node); err != nil {
+			errs = append(errs, fmt.Errorf("failed to destroy API node %s: %w", node.NodeID, err))
+			continue
+		}
+		destroyed = append(destroyed, *node)
+		a.lock.Lock()
+		for i, n := range a.nodes {
+			if n.NodeID == node.NodeID {
+				a.nodes = append(a.nodes[:i], a.nodes[i+1:]...)
+				break
+			}
+		}
+		delete(a.samples, node.NodeID)
+		a.lock.Unlock()
+	}
+	if len(destroyed) > 0 && a.monitoringNode != nil {
+		if err := a.monitoringNode.RemoveMonitoringTargets(ctx, destroyed); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove the destroyed API nodes from monitoring: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Step evaluates the load of the API nodes and applies the decision of the policy
+func (a *Autoscaler) Step(ctx context.Context) (ScaleDecision, error) {
+	decision, err := a.Evaluate(ctx)
+	if ctx.Err() != nil || decision.NoChange() {
+		return decision, err
+	}
+	return decision, errors.Join(err, a.Apply(ctx, decision))
+}
+
+// Run steps the autoscaler every interval until ctx is done, passing each decision and
+// error to onStep if not nil
+func (a *Autoscaler) Run(ctx context.Context, interval time.Duration, onStep func(ScaleDecision, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		decision, err := a.Step(ctx)
+		if onStep != nil {
+			onStep(decision, err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
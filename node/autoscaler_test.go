@@ -0,0 +1,189 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAPINodes(count int) []*Node {
+	nodes := make([]*Node, 0, count)
+	for i := 0; i < count; i++ {
+		nodes = append(nodes, &Node{NodeID: fmt.Sprintf("api-%d", i), IP: fmt.Sprintf("10.0.0.%d", i+1), Roles: []SupportedRole{API}})
+	}
+	return nodes
+}
+
+func testLoad(nodes []*Node, rps ...float64) []LoadMetrics {
+	load := []LoadMetrics{}
+	for i, r := range rps {
+		load = append(load, LoadMetrics{NodeID: nodes[i].NodeID, RPCRequestsPerSecond: r, CPUCores: r / 100})
+	}
+	return load
+}
+
+func TestThresholdPolicy_Decide(t *testing.T) {
+	policy := ThresholdPolicy{
+		MinNodes:          2,
+		MaxNodes:          4,
+		ScaleUpRPS:        100,
+		ScaleUpCPUCores:   3,
+		ScaleDownRPS:      20,
+		ScaleDownCPUCores: 0.5,
+		Step:              2,
+	}
+	nodes := testAPINodes(5)
+	tests := []struct {
+		name       string
+		nodes      []*Node
+		load       []LoadMetrics
+		wantAdd    int
+		wantRemove []string
+	}{
+		{name: "below min", nodes: nodes[:1], wantAdd: 1},
+		{name: "above max", nodes: nodes, load: testLoad(nodes, 50, 10, 50, 50, 50), wantRemove: []string{"api-1"}},
+		{name: "not measured", nodes: nodes[:3]},
+		{name: "scale up", nodes: nodes[:2], load: testLoad(nodes, 150, 120), wantAdd: 2},
+		{name: "scale up capped by max", nodes: nodes[:3], load: testLoad(nodes, 150, 120, 110), wantAdd: 1},
+		{name: "at max", nodes: nodes[:4], load: testLoad(nodes, 150, 120, 110, 130)},
+		{name: "cpu scale up", nodes: nodes[:2], load: []LoadMetrics{{NodeID: "api-0", CPUCores: 3.5}, {NodeID: "api-1", CPUCores: 3.1}}, wantAdd: 2},
+		{name: "steady", nodes: nodes[:3], load: testLoad(nodes, 50, 60, 70)},
+		{name: "scale down", nodes: nodes[:3], load: testLoad(nodes, 10, 5, 15), wantRemove: []string{"api-1"}},
+		{name: "scale down needs all thresholds", nodes: nodes[:3], load: []LoadMetrics{{NodeID: "api-0", RPCRequestsPerSecond: 1, CPUCores: 1}}},
+		{name: "scale down removes unmeasured first", nodes: nodes[:3], load: testLoad(nodes, 10, 5), wantRemove: []string{"api-2"}},
+		{name: "at min", nodes: nodes[:2], load: testLoad(nodes, 1, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := policy.Decide(tt.nodes, tt.load)
+			assert.Equal(t, tt.wantAdd, decision.Add)
+			removed := []string{}
+			for _, node := range decision.Remove {
+				removed = append(removed, node.NodeID)
+			}
+			if tt.wantRemove == nil {
+				tt.wantRemove = []string{}
+			}
+			assert.Equal(t, tt.wantRemove, removed)
+			assert.NotEmpty(t, decision.Reason)
+		})
+	}
+}
+
+func TestParseLoadSample(t *testing.T) {
+	sample, err := parseLoadSample([]byte(`odyssey_api_calls{base="/ext/bc/O"} 100
+odyssey_api_calls{base="/ext/bc/D/rpc"} 50
+odyssey_system_resources_num_cpu_cycles{processID="42"} 12.5
+odyssey_system_resources_num_cpu_cycles{processID="43"} 2.5
+`))
+	require.NoError(t, err)
+	assert.Equal(t, 150.0, sample.apiCalls)
+	assert.Equal(t, 15.0, sample.cpuSeconds)
+
+	_, err = parseLoadSample([]byte("odyssey_api_calls 1\n"))
+	assert.ErrorContains(t, err, odysseyGoCPUMetric)
+}
+
+// fakeNodeProvider records the nodes created and destroyed by an autoscaler
+type fakeNodeProvider struct {
+	created    int
+	destroyed  []string
+	destroyErr error
+}
+
+func (p *fakeNodeProvider) CreateNodes(_ context.Context, count int) ([]Node, error) {
+	nodes := []Node{}
+	for i := 0; i < count; i++ {
+		p.created++
+		nodes = append(nodes, Node{NodeID: fmt.Sprintf("new-%d", p.created), Roles: []SupportedRole{API}})
+	}
+	return nodes, nil
+}
+
+func (p *fakeNodeProvider) DestroyNode(_ context.Context, node *Node) error {
+	if p.destroyErr != nil {
+		return p.destroyErr
+	}
+	p.destroyed = append(p.destroyed, node.NodeID)
+	return nil
+}
+
+func TestAutoscaler_Step(t *testing.T) {
+	nodes := testAPINodes(2)
+	calls := map[string]float64{"api-0": 0, "api-1": 0}
+	originalScrape := scrapeLoadMetrics
+	scrapeLoadMetrics = func(node *Node) ([]byte, error) {
+		if node.NodeID == "api-1" && calls["api-1"] < 0 {
+			return nil, errors.New("unreachable")
+		}
+		return []byte(fmt.Sprintf("odyssey_api_calls %f\nodyssey_system_resources_num_cpu_cycles %f\n", calls[node.NodeID], calls[node.NodeID]/100)), nil
+	}
+	t.Cleanup(func() { scrapeLoadMetrics = originalScrape })
+
+	provider := &fakeNodeProvider{}
+	autoscaler, err := NewAutoscaler(nodes, ThresholdPolicy{MinNodes: 1, ScaleUpRPS: 100, ScaleDownRPS: 10}, provider)
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	autoscaler.now = func() time.Time { return now }
+
+	// the first scrape measures no load
+	decision, err := autoscaler.Step(context.Background())
+	require.NoError(t, err)
+	assert.True(t, decision.NoChange())
+
+	// 150 requests/s on average over 10s
+	now = now.Add(10 * time.Second)
+	calls["api-0"], calls["api-1"] = 2000, 1000
+	load, err := autoscaler.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []LoadMetrics{
+		{NodeID: "api-0", RPCRequestsPerSecond: 200, CPUCores: 2},
+		{NodeID: "api-1", RPCRequestsPerSecond: 100, CPUCores: 1},
+	}, load)
+	now = now.Add(10 * time.Second)
+	calls["api-0"], calls["api-1"] = 4000, 2000
+	decision, err = autoscaler.Step(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, decision.Add)
+	assert.Len(t, autoscaler.Nodes(), 3)
+
+	// unreachable nodes are reported, the others measured. The unreachable node goes first
+	// when scaling down, as it serves no measured requests
+	now = now.Add(10 * time.Second)
+	calls["api-0"], calls["api-1"] = 4010, -1
+	decision, err = autoscaler.Step(context.Background())
+	assert.ErrorContains(t, err, "unreachable")
+	require.Len(t, decision.Remove, 1)
+	assert.Equal(t, []string{"api-1"}, provider.destroyed)
+	assert.Len(t, autoscaler.Nodes(), 2)
+
+	_, err = NewAutoscaler(nodes, nil, provider)
+	assert.ErrorIs(t, err, ErrNoScalingPolicy)
+	_, err = NewAutoscaler(nodes, ThresholdPolicy{}, nil)
+	assert.ErrorIs(t, err, ErrNoNodeProvider)
+}
+
+func TestAutoscaler_ApplyDestroyFailure(t *testing.T) {
+	nodes := testAPINodes(2)
+	provider := &fakeNodeProvider{destroyErr: errors.New("boom")}
+	autoscaler, err := NewAutoscaler(nodes, ThresholdPolicy{}, provider)
+	require.NoError(t, err)
+	err = autoscaler.Apply(context.Background(), ScaleDecision{Remove: nodes[:1]})
+	assert.ErrorContains(t, err, "boom")
+	assert.Len(t, autoscaler.Nodes(), 2)
+}
+
+func TestNodeParamsProvider(t *testing.T) {
+	provider := NewNodeParamsProvider(NodeParams{Roles: []SupportedRole{API}})
+	_, err := provider.CreateNodes(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrCloudRemoved)
+	assert.ErrorIs(t, provider.DestroyNode(context.Background(), &Node{}), ErrCloudRemoved)
+}
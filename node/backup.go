@@ -0,0 +1,306 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/progress"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+)
+
+const (
+	backupFileSuffix        = "-odysseygo-db.tar.gz"
+	backupTimestampFormat   = "20060102T150405Z"
+	backupProgressLogBytes  = 512 * 1024 * 1024
+	remoteStagingDirPattern = "db-restore-XXXXXX"
+)
+
+// BackupDatabase stops odysseygo, archives its database directory and streams the archive to
+// dest, restarting odysseygo afterwards. dest is either a local directory, in which case a
+// timestamped archive is created inside it, or a local .tar.gz file path.
+// Returns the path of the created archive.
+//
+// Cloud storage destinations (S3, GCS) are not supported, as cloud functionality has been
+// removed from this SDK.
+func (h *Node) BackupDatabase(ctx context.Context, dest string) (string, error) {
+	if err := checkLocalBackupPath(dest); err != nil {
+		return "", err
+	}
+	archivePath := dest
+	if utils.DirectoryExists(dest) {
+		archivePath = filepath.Join(dest, backupFileName(h.NodeID, time.Now()))
+	}
+	if err := os.MkdirAll(filepath.Dir(archivePath), constants.DefaultPerms755); err != nil {
+		return "", err
+	}
+	err := h.withOdysseyGoStopped(func() error {
+		archive, err := os.Create(archivePath)
+		if err != nil {
+			return err
+		}
+		defer archive.Close()
+		dbDir := remoteconfig.GetRemoteOdysseyDBDir(h.config())
+		h.Logger.Infof("Backing up %s:%s to %s", h.NodeID, dbDir, archivePath)
+		return h.streamCommand(ctx,
+			fmt.Sprintf("sudo tar -C %s -czf - %s", filepath.Dir(dbDir), filepath.Base(dbDir)),
+			nil,
+			h.progressWriter(archive, "Backed up"),
+		)
+	})
+	if err != nil {
+		_ = os.Remove(archivePath)
+		return "", fmt.Errorf("failed to backup database of node %s: %w", h.NodeID, err)
+	}
+	return archivePath, nil
+}
+
+// RestoreDatabase stops odysseygo, replaces its database directory with the content of the
+// archive at src created by BackupDatabase, and restarts odysseygo.
+// The archive is fully extracted on the node before the current database is replaced.
+func (h *Node) RestoreDatabase(ctx context.Context, src string) error {
+	if err := checkLocalBackupPath(src); err != nil {
+		return err
+	}
+	if !utils.FileExists(src) {
+		return fmt.Errorf("backup archive %s does not exist", src)
+	}
+	dbDir := remoteconfig.GetRemoteOdysseyDBDir(h.config())
+	dbBase := filepath.Base(dbDir)
+	script := strings.Join([]string{
+		"set -e",
+		fmt.Sprintf("STAGING=$(sudo mktemp -d -p %s %s)", filepath.Dir(dbDir), remoteStagingDirPattern),
+		"trap 'sudo rm -rf $STAGING' EXIT",
+		"sudo tar -C $STAGING -xzf -",
+		fmt.Sprintf("test -d $STAGING/%s", dbBase),
+		fmt.Sprintf("sudo mkdir -p %s", dbDir),
+		fmt.Sprintf("sudo find %s -mindepth 1 -delete", dbDir),
+		fmt.Sprintf("sudo cp -a $STAGING/%s/. %s/", dbBase, dbDir),
+		fmt.Sprintf("sudo chown -R %s:%s %s", h.sshUser(), h.sshUser(), dbDir),
+	}, "\n")
+	err := h.withOdysseyGoStopped(func() error {
+		archive, err := os.Open(src)
+		if err != nil {
+			return err
+		}
+		defer archive.Close()
+		info, err := archive.Stat()
+		if err != nil {
+			return err
+		}
+		h.Logger.Infof("Restoring %s to %s:%s", src, h.NodeID, dbDir)
+		return h.streamCommand(ctx, script, h.progressReader(archive, info.Size(), "Restored"), io.Discard)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore database of node %s: %w", h.NodeID, err)
+	}
+	return nil
+}
+
+// withOdysseyGoStopped runs f with the odysseygo service stopped, if the node runs it
+func (h *Node) withOdysseyGoStopped(f func() error) error {
+	odysseyGoInstalled, err := h.hasOdysseyGo()
+	if err != nil {
+		return err
+	}
+	if !odysseyGoInstalled {
+		return f()
+	}
+	if err := h.RunSSHStopOdysseygo(); err != nil {
+		return err
+	}
+	fErr := f()
+	if err := h.RunSSHStartOdysseygo(); err != nil {
+		if fErr != nil {
+			return fmt.Errorf("%w (failed to restart odysseygo: %s)", fErr, err)
+		}
+		return err
+	}
+	return fErr
+}
+
+// streamCommand runs script on the node with the given stdin and stdout
+func (h *Node) streamCommand(ctx context.Context, script string, stdin io.Reader, stdout io.Writer) error {
+	script, stdin, err := h.prepareSudo(script, stdin)
+	if err != nil {
+		return err
+	}
+	if !h.Connected() {
+		if err := h.Connect(0); err != nil {
+			return err
+		}
+	}
+	var stderr bytes.Buffer
+	if err := h.connection.Run(ctx, nil, script, stdin, stdout, &stderr); err != nil {
+		return fmt.Errorf("%w: %s", sudoError(err, stderr.Bytes()), stderr.String())
+	}
+	return nil
+}
+
+// progressCounter logs and reports the amount of bytes transferred every backupProgressLogBytes.
+// size is the amount of bytes to transfer, or 0 if unknown
+type progressCounter struct {
+	node   *Node
+	action string
+	size   int64
+	total  int64
+	logged int64
+}
+
+func (p *progressCounter) add(n int) {
+	p.total += int64(n)
+	if p.total-p.logged >= backupProgressLogBytes {
+		p.logged = p.total
+		p.node.Logger.Infof("%s %dMB for node %s", p.action, p.total/(1024*1024), p.node.NodeID)
+		percent := float64(progress.UnknownPercent)
+		if p.size > 0 {
+			percent = min(float64(p.total)*100/float64(p.size), 100)
+		}
+		p.node.Progress.Report(progress.Event{
+			Stage:   progress.StageTransfer,
+			Node:    p.node.NodeID,
+			Percent: percent,
+			Message: fmt.Sprintf("%s %dMB", p.action, p.total/(1024*1024)),
+		})
+	}
+}
+
+type progressWriter struct {
+	io.Writer
+	*progressCounter
+}
+
+func (w progressWriter) Write(b []byte) (int, error) {
+	n, err := w.Writer.Write(b)
+	w.add(n)
+	return n, err
+}
+
+type progressReader struct {
+	io.Reader
+	*progressCounter
+}
+
+func (r progressReader) Read(b []byte) (int, error) {
+	n, err := r.Reader.Read(b)
+	r.add(n)
+	return n, err
+}
+
+func (h *Node) progressWriter(w io.Writer, action string) io.Writer {
+	return progressWriter{Writer: w, progressCounter: &progressCounter{node: h, action: action}}
+}
+
+func (h *Node) progressReader(r io.Reader, size int64, action string) io.Reader {
+	return progressReader{Reader: r, progressCounter: &progressCounter{node: h, action: action, size: size}}
+}
+
+func checkLocalBackupPath(path string) error {
+	if path == "" {
+		return fmt.Errorf("backup path cannot be empty")
+	}
+	for scheme, cloud := range map[string]string{"s3://": "aws", "gs://": "gcp"} {
+		if strings.HasPrefix(path, scheme) {
+			return fmt.Errorf("unsupported backup location %s, use a local path instead: %w", path, &ErrUnsupportedCloud{Cloud: cloud})
+		}
+	}
+	return nil
+}
+
+func backupFileName(nodeID string, t time.Time) string {
+	return fmt.Sprintf("%s-%s%s", nodeID, t.UTC().Format(backupTimestampFormat), backupFileSuffix)
+}
+
+// BackupScheduler periodically backs up the database of a fleet of nodes into
+// a local directory, keeping one subdirectory per node
+type BackupScheduler struct {
+	// Nodes to back up
+	Nodes []*Node
+
+	// Interval between two backup rounds
+	Interval time.Duration
+
+	// DestDir is the local directory where backups are stored
+	DestDir string
+
+	// Retain is the number of backups kept per node. Zero keeps all backups
+	Retain int
+
+	// OnRound is called after each backup round with the created archive path,
+	// or the error, for each node
+	OnRound func(results *NodeResults)
+}
+
+// Run backs up all the nodes right away and then every Interval, until ctx is done.
+func (s *BackupScheduler) Run(ctx context.Context) error {
+	if s.Interval <= 0 {
+		return fmt.Errorf("backup interval must be positive")
+	}
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		results := s.RunOnce(ctx)
+		if s.OnRound != nil {
+			s.OnRound(results)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce backs up all the nodes concurrently and prunes old backups
+func (s *BackupScheduler) RunOnce(ctx context.Context) *NodeResults {
+	return RunOnNodes(s.Nodes, 0, func(node *Node) (interface{}, error) {
+		nodeDir := filepath.Join(s.DestDir, node.NodeID)
+		if err := os.MkdirAll(nodeDir, constants.DefaultPerms755); err != nil {
+			return nil, err
+		}
+		archivePath, err := node.BackupDatabase(ctx, nodeDir)
+		if err != nil {
+			return nil, err
+		}
+		return archivePath, pruneBackups(nodeDir, s.Retain)
+	})
+}
+
+// pruneBackups removes the oldest backups in dir so that at most retain are kept
+func pruneBackups(dir string, retain int) error {
+	if retain <= 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	backups := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), backupFileSuffix) {
+			backups = append(backups, entry.Name())
+		}
+	}
+	if len(backups) <= retain {
+		return nil
+	}
+	// timestamps in backup file names sort chronologically
+	sort.Strings(backups)
+	for _, backup := range backups[:len(backups)-retain] {
+		if err := os.Remove(filepath.Join(dir, backup)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/progress"
+)
+
+func TestBackupFileName(t *testing.T) {
+	ts := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	assert.Equal(t, "NodeID-abc-20250304T050607Z-odysseygo-db.tar.gz", backupFileName("NodeID-abc", ts))
+}
+
+func TestCheckLocalBackupPath(t *testing.T) {
+	assert.NoError(t, checkLocalBackupPath("/backups"))
+	assert.Error(t, checkLocalBackupPath(""))
+	assert.Error(t, checkLocalBackupPath("s3://bucket/backups"))
+	assert.Error(t, checkLocalBackupPath("gs://bucket/backups"))
+}
+
+func TestPruneBackups(t *testing.T) {
+	dir := t.TempDir()
+	for _, day := range []int{1, 2, 3, 4} {
+		name := backupFileName("node", time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("backup"), 0o600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("keep"), 0o600))
+
+	require.NoError(t, pruneBackups(dir, 0))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 5)
+
+	require.NoError(t, pruneBackups(dir, 2))
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{
+		backupFileName("node", time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)),
+		backupFileName("node", time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC)),
+		"notes.txt",
+	}, names)
+}
+
+func TestProgressReaderWriter(t *testing.T) {
+	node := &Node{NodeID: "test-node"}
+	data := bytes.Repeat([]byte("a"), 1024)
+
+	var out bytes.Buffer
+	w := node.progressWriter(&out, "Backed up")
+	n, err := w.Write(data)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.Equal(t, int64(len(data)), w.(progressWriter).total)
+
+	r := node.progressReader(bytes.NewReader(data), int64(len(data)), "Restored")
+	read, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, data, read)
+	assert.Equal(t, int64(len(data)), r.(progressReader).total)
+}
+
+func TestProgressCounter_Reports(t *testing.T) {
+	events := []progress.Event{}
+	node := &Node{NodeID: "test-node", Progress: func(e progress.Event) { events = append(events, e) }}
+
+	restore := &progressCounter{node: node, action: "Restored", size: 4 * backupProgressLogBytes}
+	restore.add(backupProgressLogBytes - 1)
+	assert.Empty(t, events)
+	restore.add(1)
+	restore.add(backupProgressLogBytes)
+	require.Len(t, events, 2)
+	assert.Equal(t, progress.StageTransfer, events[0].Stage)
+	assert.Equal(t, "test-node", events[0].Node)
+	assert.Equal(t, float64(25), events[0].Percent)
+	assert.Equal(t, float64(50), events[1].Percent)
+
+	backup := &progressCounter{node: node, action: "Backed up"}
+	backup.add(backupProgressLogBytes)
+	require.Len(t, events, 3)
+	assert.Equal(t, float64(progress.UnknownPercent), events[2].Percent)
+	assert.Equal(t, "Backed up 512MB", events[2].Message)
+}
+
+func TestNode_BackupRestoreDatabase_Errors(t *testing.T) {
+	ctx := context.Background()
+	node := Node{NodeID: "test-node"}
+
+	_, err := node.BackupDatabase(ctx, "s3://bucket")
+	assert.Error(t, err)
+
+	err = node.RestoreDatabase(ctx, filepath.Join(t.TempDir(), "missing.tar.gz"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not exist")
+}
+
+func TestBackupScheduler(t *testing.T) {
+	scheduler := &BackupScheduler{DestDir: t.TempDir()}
+	assert.Error(t, scheduler.Run(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rounds := 0
+	scheduler = &BackupScheduler{
+		Interval: time.Hour,
+		DestDir:  t.TempDir(),
+		OnRound: func(results *NodeResults) {
+			rounds++
+			assert.Equal(t, 0, results.Len())
+		},
+	}
+	assert.ErrorIs(t, scheduler.Run(ctx), context.Canceled)
+	assert.Equal(t, 1, rounds)
+}
@@ -0,0 +1,249 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odysseygo/api/info"
+	"github.com/prometheus/common/expfmt"
+)
+
+// ErrNotBootstrapped is returned when a node has not finished bootstrapping its chains in time
+var ErrNotBootstrapped = errors.New("odysseygo is not bootstrapped")
+
+// PrimaryNetworkChains are the aliases of the primary network chains bootstrapped by odysseygo
+var PrimaryNetworkChains = []string{"O", "A", "D"}
+
+// bootstrapCheckInterval is how often WaitForBootstrap checks the bootstrap status of a node
+var bootstrapCheckInterval = 5 * time.Second
+
+// BootstrapProgress is the progress of a chain bootstrapping, as reported by the odysseygo
+// metrics
+type BootstrapProgress struct {
+	// BlocksFetched and BlocksAccepted are the blocks fetched from the peers and the blocks
+	// executed since the node started bootstrapping the chain
+	BlocksFetched  uint64
+	BlocksAccepted uint64
+
+	// FetchETA is the estimated time until all the blocks are fetched, zero if unknown
+	FetchETA time.Duration
+}
+
+// BlocksRemaining returns the blocks fetched that remain to be executed
+func (p BootstrapProgress) BlocksRemaining() uint64 {
+	if p.BlocksAccepted > p.BlocksFetched {
+		return 0
+	}
+	return p.BlocksFetched - p.BlocksAccepted
+}
+
+// ChainBootstrapStatus is the bootstrap status of a chain on a node
+type ChainBootstrapStatus struct {
+	// Chain is the alias or the ID of the chain
+	Chain string
+
+	// Bootstrapped tells if the node has finished bootstrapping the chain
+	Bootstrapped bool
+
+	// Progress is nil if the odysseygo metrics don't report the progress of the chain
+	Progress *BootstrapProgress
+}
+
+// BootstrapStatus is the bootstrap status of the chains of a node
+type BootstrapStatus struct {
+	Chains []ChainBootstrapStatus
+}
+
+// Bootstrapped tells if the node has finished bootstrapping all the chains
+func (s BootstrapStatus) Bootstrapped() bool {
+	for _, chain := range s.Chains {
+		if !chain.Bootstrapped {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the chains not bootstrapped yet with their progress
+func (s BootstrapStatus) String() string {
+	pending := []string{}
+	for _, chain := range s.Chains {
+		if chain.Bootstrapped {
+			continue
+		}
+		if chain.Progress == nil {
+			pending = append(pending, chain.Chain)
+			continue
+		}
+		pending = append(pending, fmt.Sprintf("%s (%d blocks remaining, fetch ETA %s)",
+			chain.Chain, chain.Progress.BlocksRemaining(), chain.Progress.FetchETA))
+	}
+	if len(pending) == 0 {
+		return "bootstrapped"
+	}
+	return "bootstrapping " + strings.Join(pending, ", ")
+}
+
+// isChainBootstrapped asks the odysseygo info API of node if chain is bootstrapped
+var isChainBootstrapped = func(node *Node, chain string) (bool, error) {
+	request, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "info.isBootstrapped",
+		"params":  info.IsBootstrappedArgs{Chain: chain},
+	})
+	if err != nil {
+		return false, err
+	}
+	resp, err := node.Post("", string(request))
+	if err != nil {
+		return false, err
+	}
+	reply := struct {
+		Result *info.IsBootstrappedResponse `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+	if err := json.Unmarshal(resp, &reply); err != nil {
+		return false, err
+	}
+	if reply.Error != nil {
+		return false, fmt.Errorf("failed to check if chain %s is bootstrapped on node %s: %s", chain, node.NodeID, reply.Error.Message)
+	}
+	if reply.Result == nil {
+		return false, fmt.Errorf("unable to parse bootstrap status of chain %s on node %s", chain, node.NodeID)
+	}
+	return reply.Result.IsBootstrapped, nil
+}
+
+// scrapeBootstrapMetrics returns the bootstrap metrics of node, in the Prometheus text format
+var scrapeBootstrapMetrics = func(node *Node) ([]byte, error) {
+	output, err := node.Command(nil, constants.SSHPOSTTimeout, fmt.Sprintf(
+		"curl -sf http://127.0.0.1:%d/ext/metrics | grep -E '^%s_[^ {]+_bs_(fetched|accepted|eta_fetching_complete)[ {]'",
+		node.config().OdysseygoAPIPort,
+		odysseyGoMetricsNamespace,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape the metrics of node %s: %w: %s", node.NodeID, err, string(output))
+	}
+	return output, nil
+}
+
+// odysseyGoMetricsNamespace prefixes the metrics of the odysseygo chains, e.g. odyssey_O_bs_fetched
+const odysseyGoMetricsNamespace = "odyssey"
+
+// parseBootstrapProgress returns the bootstrap progress of each chain found in metrics
+func parseBootstrapProgress(metrics []byte) (map[string]*BootstrapProgress, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(strings.NewReader(string(metrics)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse odysseygo metrics: %w", err)
+	}
+	progress := map[string]*BootstrapProgress{}
+	for name, family := range families {
+		chain, metric, ok := strings.Cut(strings.TrimPrefix(name, odysseyGoMetricsNamespace+"_"), "_bs_")
+		if !ok || len(family.Metric) == 0 {
+			continue
+		}
+		value := 0.0
+		switch m := family.Metric[0]; {
+		case m.Counter != nil:
+			value = m.Counter.GetValue()
+		case m.Gauge != nil:
+			value = m.Gauge.GetValue()
+		case m.Untyped != nil:
+			value = m.Untyped.GetValue()
+		}
+		if progress[chain] == nil {
+			progress[chain] = &BootstrapProgress{}
+		}
+		switch metric {
+		case "fetched":
+			progress[chain].BlocksFetched = uint64(value)
+		case "accepted":
+			progress[chain].BlocksAccepted = uint64(value)
+		case "eta_fetching_complete":
+			progress[chain].FetchETA = time.Duration(value)
+		}
+	}
+	return progress, nil
+}
+
+// BootstrapStatus returns the bootstrap status of chains on the node, the primary network
+// chains if none is given. The progress of the chains is derived from the odysseygo metrics
+// when available, it is left nil otherwise
+func (h *Node) BootstrapStatus(ctx context.Context, chains ...string) (BootstrapStatus, error) {
+	if len(chains) == 0 {
+		chains = PrimaryNetworkChains
+	}
+	status := BootstrapStatus{}
+	for _, chain := range chains {
+		if err := ctx.Err(); err != nil {
+			return BootstrapStatus{}, err
+		}
+		bootstrapped, err := isChainBootstrapped(h, chain)
+		if err != nil {
+			return BootstrapStatus{}, err
+		}
+		status.Chains = append(status.Chains, ChainBootstrapStatus{Chain: chain, Bootstrapped: bootstrapped})
+	}
+	if status.Bootstrapped() {
+		return status, nil
+	}
+	metrics, err := scrapeBootstrapMetrics(h)
+	if err != nil {
+		h.Logger.Warnf("cannot get the bootstrap progress of node %s: %s", h.NodeID, err)
+		return status, nil
+	}
+	progress, err := parseBootstrapProgress(metrics)
+	if err != nil {
+		h.Logger.Warnf("cannot get the bootstrap progress of node %s: %s", h.NodeID, err)
+		return status, nil
+	}
+	for i := range status.Chains {
+		if !status.Chains[i].Bootstrapped {
+			status.Chains[i].Progress = progress[status.Chains[i].Chain]
+		}
+	}
+	return status, nil
+}
+
+// WaitForBootstrap waits up to timeout for the node to finish bootstrapping chains, the
+// primary network chains if none is given, and returns its last bootstrap status. Errors
+// checking the status, e.g. while odysseygo restarts, are retried until timeout
+func (h *Node) WaitForBootstrap(ctx context.Context, timeout time.Duration, chains ...string) (BootstrapStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(bootstrapCheckInterval)
+	defer ticker.Stop()
+	last := BootstrapStatus{}
+	var lastErr error
+	for {
+		status, err := h.BootstrapStatus(ctx, chains...)
+		switch {
+		case err == nil && status.Bootstrapped():
+			return status, nil
+		case err == nil:
+			last, lastErr = status, nil
+		case ctx.Err() == nil:
+			lastErr = err
+		}
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return last, fmt.Errorf("%w on node %s after %s: %w", ErrNotBootstrapped, h.NodeID, timeout, lastErr)
+			}
+			return last, fmt.Errorf("%w on node %s after %s: %s", ErrNotBootstrapped, h.NodeID, timeout, last)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBootstrap replaces the bootstrap status and metrics of nodes with the chains bootstrapped
+// on each node, until the test ends
+type fakeBootstrap struct {
+	lock         sync.Mutex
+	bootstrapped map[string]map[string]bool
+	metrics      string
+	checkErr     error
+}
+
+func newFakeBootstrap(t *testing.T) *fakeBootstrap {
+	fake := &fakeBootstrap{bootstrapped: map[string]map[string]bool{}}
+	originalCheck, originalScrape, originalInterval := isChainBootstrapped, scrapeBootstrapMetrics, bootstrapCheckInterval
+	isChainBootstrapped = func(node *Node, chain string) (bool, error) {
+		fake.lock.Lock()
+		defer fake.lock.Unlock()
+		return fake.bootstrapped[node.NodeID][chain], fake.checkErr
+	}
+	scrapeBootstrapMetrics = func(*Node) ([]byte, error) {
+		if fake.metrics == "" {
+			return nil, errors.New("metrics unavailable")
+		}
+		return []byte(fake.metrics), nil
+	}
+	bootstrapCheckInterval = time.Millisecond
+	t.Cleanup(func() {
+		isChainBootstrapped, scrapeBootstrapMetrics, bootstrapCheckInterval = originalCheck, originalScrape, originalInterval
+	})
+	return fake
+}
+
+func (f *fakeBootstrap) set(nodeID string, chains ...string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.bootstrapped[nodeID] = map[string]bool{}
+	for _, chain := range chains {
+		f.bootstrapped[nodeID][chain] = true
+	}
+}
+
+func TestNode_BootstrapStatus(t *testing.T) {
+	fake := newFakeBootstrap(t)
+	fake.set("node-1", "O", "A")
+	node := &Node{NodeID: "node-1"}
+
+	// the progress is unknown without metrics
+	status, err := node.BootstrapStatus(context.Background())
+	require.NoError(t, err)
+	assert.False(t, status.Bootstrapped())
+	assert.Equal(t, []ChainBootstrapStatus{
+		{Chain: "O", Bootstrapped: true},
+		{Chain: "A", Bootstrapped: true},
+		{Chain: "D"},
+	}, status.Chains)
+
+	fake.metrics = "odyssey_D_bs_fetched 1500\n" +
+		"odyssey_D_bs_accepted 1000\n" +
+		"odyssey_D_bs_eta_fetching_complete 3e+10\n" +
+		"odyssey_O_bs_fetched 10\n"
+	status, err = node.BootstrapStatus(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, status.Chains[0].Progress)
+	assert.Equal(t, &BootstrapProgress{BlocksFetched: 1500, BlocksAccepted: 1000, FetchETA: 30 * time.Second}, status.Chains[2].Progress)
+	assert.Equal(t, uint64(500), status.Chains[2].Progress.BlocksRemaining())
+	assert.Equal(t, "bootstrapping D (500 blocks remaining, fetch ETA 30s)", status.String())
+
+	// only the given chains are checked, e.g. the blockchains of a subnet
+	status, err = node.BootstrapStatus(context.Background(), "O")
+	require.NoError(t, err)
+	assert.True(t, status.Bootstrapped())
+	assert.Equal(t, "bootstrapped", status.String())
+
+	fake.checkErr = errors.New("connection refused")
+	_, err = node.BootstrapStatus(context.Background())
+	require.ErrorIs(t, err, fake.checkErr)
+}
+
+func TestWaitForNodesBootstrap(t *testing.T) {
+	fake := newFakeBootstrap(t)
+	fake.set("node-1", "O", "A", "D")
+	fake.set("node-2", "O")
+	nodes := []*Node{{NodeID: "node-1"}, {NodeID: "node-2"}, {NodeID: "node-3"}}
+
+	// node-2 finishes bootstrapping while waited for
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		fake.set("node-2", "O", "A", "D")
+	}()
+	results := WaitForNodesBootstrap(context.Background(), nodes, 200*time.Millisecond, 0)
+	succeeded := []string{}
+	for _, result := range results.Succeeded() {
+		succeeded = append(succeeded, result.NodeID)
+		assert.True(t, result.Value.(BootstrapStatus).Bootstrapped())
+	}
+	assert.ElementsMatch(t, []string{"node-1", "node-2"}, succeeded)
+	failed := results.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "node-3", failed[0].NodeID)
+	require.ErrorIs(t, failed[0].Err, ErrNotBootstrapped)
+	assert.ErrorContains(t, failed[0].Err, "bootstrapping O, A, D")
+
+	// status errors are retried until timeout
+	fake.checkErr = errors.New("connection refused")
+	_, err := nodes[0].WaitForBootstrap(context.Background(), 20*time.Millisecond)
+	require.ErrorIs(t, err, ErrNotBootstrapped)
+	require.ErrorIs(t, err, fake.checkErr)
+}
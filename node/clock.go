@@ -0,0 +1,208 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+)
+
+// DefaultClockDriftTolerance is the clock drift tolerated by CheckClockDrifts when no
+// tolerance is given
+const DefaultClockDriftTolerance = 500 * time.Millisecond
+
+// clockScript prints, one per line: the time in unix nanoseconds, whether systemd considers
+// the clock synchronized and the system time line of chrony tracking. Missing values are
+// printed as empty lines
+const clockScript = `date +%s%N; (timedatectl show -p NTPSynchronized --value 2>/dev/null || echo); ` +
+	`(chronyc tracking 2>/dev/null | grep '^System time' || echo)`
+
+var (
+	ErrInvalidNTPServer = errors.New("invalid NTP server")
+
+	// ntpServerRegex matches host names and IPv4 addresses
+	ntpServerRegex = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9.-]*[A-Za-z0-9])?$`)
+
+	// chronySystemTimeRegex matches the system time line of chronyc tracking, e.g.
+	// System time     : 0.000012345 seconds fast of NTP time
+	chronySystemTimeRegex = regexp.MustCompile(`^System time\s*:\s*([0-9.]+) seconds (fast|slow) of NTP time`)
+)
+
+// ClockDrift is the clock state of a node
+type ClockDrift struct {
+	NodeID string
+
+	// RemoteTime is the time read on the node
+	RemoteTime time.Time
+
+	// Offset is RemoteTime minus the local time, estimated at the middle of the SSH round trip
+	Offset time.Duration
+
+	// Uncertainty is half the SSH round trip, the maximum error of Offset
+	Uncertainty time.Duration
+
+	// NTPSynchronized tells if systemd considers the node clock synchronized
+	NTPSynchronized bool
+
+	// NTPOffset is the offset of the node clock to NTP time reported by chrony,
+	// or nil if chrony is not running on the node
+	NTPOffset *time.Duration
+}
+
+// Drift returns the best estimate of how far the node clock is from the correct time:
+// the chrony offset if known, otherwise the offset to the local clock beyond its uncertainty
+func (d ClockDrift) Drift() time.Duration {
+	if d.NTPOffset != nil {
+		return absDuration(*d.NTPOffset)
+	}
+	return max(absDuration(d.Offset)-d.Uncertainty, 0)
+}
+
+// Within tells if the node clock is synchronized and drifts no more than tolerance
+func (d ClockDrift) Within(tolerance time.Duration) bool {
+	return d.NTPSynchronized && d.Drift() <= tolerance
+}
+
+// CheckClockDrift compares the clock of the node against the local clock and, when chrony
+// runs on the node, against NTP time
+func (h *Node) CheckClockDrift(ctx context.Context) (ClockDrift, error) {
+	if err := ctx.Err(); err != nil {
+		return ClockDrift{}, err
+	}
+	timeout := constants.SSHPOSTTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = min(timeout, time.Until(deadline))
+	}
+	before := time.Now()
+	output, err := h.Command(nil, timeout, clockScript)
+	after := time.Now()
+	if err != nil {
+		return ClockDrift{}, fmt.Errorf("%w: %s", err, string(output))
+	}
+	drift, err := parseClockOutput(string(output))
+	if err != nil {
+		return ClockDrift{}, err
+	}
+	roundTrip := after.Sub(before)
+	drift.NodeID = h.NodeID
+	drift.Uncertainty = roundTrip / 2
+	drift.Offset = drift.RemoteTime.Sub(before.Add(drift.Uncertainty))
+	return drift, nil
+}
+
+// RunSSHSetupTimeSync installs chrony on the node and enables it, adding ntpServers to its
+// sources. The distribution default sources are kept, and used alone if ntpServers is empty
+func (h *Node) RunSSHSetupTimeSync(ntpServers []string) error {
+	for _, server := range ntpServers {
+		if !ntpServerRegex.MatchString(server) {
+			return fmt.Errorf("%w %q", ErrInvalidNTPServer, server)
+		}
+	}
+	platform, err := h.DetectPlatform()
+	if err != nil {
+		return err
+	}
+	if err := platform.Supported(); err != nil {
+		return err
+	}
+	return h.RunOverSSH(
+		"Setup Time Sync",
+		constants.SSHLongRunningScriptTimeout,
+		"shell/setupTimeSync.sh",
+		scriptInputs{
+			PackageManager: string(platform.PackageManager),
+			NTPServers:     ntpServers,
+		},
+	)
+}
+
+// ClockDriftReport is the clock state of a set of nodes
+type ClockDriftReport struct {
+	Tolerance time.Duration
+
+	// Drifts holds the clock state of the nodes that could be checked, sorted by node ID
+	Drifts []ClockDrift
+
+	// Errors holds the nodes whose clock could not be checked
+	Errors map[string]error
+}
+
+// Flagged returns the nodes whose clock is not synchronized or drifts beyond the tolerance
+func (r ClockDriftReport) Flagged() []ClockDrift {
+	flagged := []ClockDrift{}
+	for _, drift := range r.Drifts {
+		if !drift.Within(r.Tolerance) {
+			flagged = append(flagged, drift)
+		}
+	}
+	return flagged
+}
+
+// CheckClockDrifts checks the clock of each node concurrently, flagging nodes drifting
+// beyond tolerance, or DefaultClockDriftTolerance if tolerance is not positive
+func CheckClockDrifts(ctx context.Context, nodes []*Node, tolerance time.Duration) ClockDriftReport {
+	if tolerance <= 0 {
+		tolerance = DefaultClockDriftTolerance
+	}
+	results := RunOnNodes(nodes, 0, func(node *Node) (interface{}, error) {
+		return node.CheckClockDrift(ctx)
+	})
+	report := ClockDriftReport{
+		Tolerance: tolerance,
+		Drifts:    []ClockDrift{},
+		Errors:    results.GetErrorHostMap(),
+	}
+	for _, result := range results.GetResults() {
+		if result.Err == nil {
+			report.Drifts = append(report.Drifts, result.Value.(ClockDrift))
+		}
+	}
+	sort.Slice(report.Drifts, func(i, j int) bool {
+		return report.Drifts[i].NodeID < report.Drifts[j].NodeID
+	})
+	return report
+}
+
+// parseClockOutput parses the output of clockScript
+func parseClockOutput(output string) (ClockDrift, error) {
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if len(lines) != 3 {
+		return ClockDrift{}, fmt.Errorf("unexpected clock output: %q", output)
+	}
+	nanos, err := strconv.ParseInt(strings.TrimSpace(lines[0]), 10, 64)
+	if err != nil {
+		return ClockDrift{}, fmt.Errorf("unexpected remote time %q: %w", lines[0], err)
+	}
+	drift := ClockDrift{
+		RemoteTime:      time.Unix(0, nanos),
+		NTPSynchronized: strings.TrimSpace(lines[1]) == "yes",
+	}
+	if matches := chronySystemTimeRegex.FindStringSubmatch(strings.TrimSpace(lines[2])); matches != nil {
+		seconds, err := strconv.ParseFloat(matches[1], 64)
+		if err != nil {
+			return ClockDrift{}, fmt.Errorf("unexpected chrony system time %q: %w", lines[2], err)
+		}
+		ntpOffset := time.Duration(seconds * float64(time.Second))
+		if matches[2] == "slow" {
+			ntpOffset = -ntpOffset
+		}
+		drift.NTPOffset = &ntpOffset
+	}
+	return drift, nil
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
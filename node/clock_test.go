@@ -0,0 +1,122 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClockOutput(t *testing.T) {
+	drift, err := parseClockOutput("1700000000123456789\nyes\nSystem time     : 0.250000000 seconds slow of NTP time\n")
+	require.NoError(t, err)
+	assert.Equal(t, time.Unix(1700000000, 123456789), drift.RemoteTime)
+	assert.True(t, drift.NTPSynchronized)
+	require.NotNil(t, drift.NTPOffset)
+	assert.Equal(t, -250*time.Millisecond, *drift.NTPOffset)
+
+	// no systemd nor chrony
+	drift, err = parseClockOutput("1700000000123456789\n\n\n")
+	require.NoError(t, err)
+	assert.False(t, drift.NTPSynchronized)
+	assert.Nil(t, drift.NTPOffset)
+
+	_, err = parseClockOutput("not a time\nyes\n\n")
+	assert.Error(t, err)
+	_, err = parseClockOutput("1700000000123456789\n")
+	assert.Error(t, err)
+}
+
+func TestClockDrift_Within(t *testing.T) {
+	ntpOffset := 800 * time.Millisecond
+	tests := []struct {
+		name          string
+		drift         ClockDrift
+		expectedDrift time.Duration
+		expectedOk    bool
+	}{
+		{
+			name:          "offset within the round trip uncertainty",
+			drift:         ClockDrift{NTPSynchronized: true, Offset: -300 * time.Millisecond, Uncertainty: 400 * time.Millisecond},
+			expectedDrift: 0,
+			expectedOk:    true,
+		},
+		{
+			name:          "offset beyond the round trip uncertainty",
+			drift:         ClockDrift{NTPSynchronized: true, Offset: 2 * time.Second, Uncertainty: time.Second},
+			expectedDrift: time.Second,
+		},
+		{
+			name:          "chrony offset prevails",
+			drift:         ClockDrift{NTPSynchronized: true, NTPOffset: &ntpOffset},
+			expectedDrift: ntpOffset,
+		},
+		{
+			name:          "not synchronized",
+			drift:         ClockDrift{},
+			expectedDrift: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedDrift, tt.drift.Drift())
+			assert.Equal(t, tt.expectedOk, tt.drift.Within(DefaultClockDriftTolerance))
+		})
+	}
+}
+
+func TestClockDriftReport_Flagged(t *testing.T) {
+	report := ClockDriftReport{
+		Tolerance: time.Second,
+		Drifts: []ClockDrift{
+			{NodeID: "node-1", NTPSynchronized: true, Offset: 100 * time.Millisecond},
+			{NodeID: "node-2", NTPSynchronized: true, Offset: 3 * time.Second},
+			{NodeID: "node-3", Offset: 100 * time.Millisecond},
+		},
+	}
+	flagged := report.Flagged()
+	require.Len(t, flagged, 2)
+	assert.Equal(t, "node-2", flagged[0].NodeID)
+	assert.Equal(t, "node-3", flagged[1].NodeID)
+}
+
+func TestCheckClockDrift_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := (&Node{}).CheckClockDrift(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	report := CheckClockDrifts(ctx, []*Node{{NodeID: "node-1"}}, 0)
+	assert.Equal(t, DefaultClockDriftTolerance, report.Tolerance)
+	assert.Empty(t, report.Drifts)
+	assert.ErrorIs(t, report.Errors["node-1"], context.Canceled)
+}
+
+func TestRunSSHSetupTimeSync_InvalidServer(t *testing.T) {
+	assert.ErrorIs(t, (&Node{}).RunSSHSetupTimeSync([]string{"pool.ntp.org; rm -rf /"}), ErrInvalidNTPServer)
+}
+
+func TestTimeSyncScript(t *testing.T) {
+	shellScript, err := script.ReadFile("shell/setupTimeSync.sh")
+	require.NoError(t, err)
+	tmpl, err := template.New("timesync").Parse(string(shellScript))
+	require.NoError(t, err)
+	for packageManager, service := range map[string]string{"apt": "chrony", "dnf": "chronyd"} {
+		var rendered bytes.Buffer
+		require.NoError(t, tmpl.Execute(&rendered, scriptInputs{
+			PackageManager: packageManager,
+			NTPServers:     []string{"time.example.com", "10.0.0.1"},
+		}))
+		output := rendered.String()
+		assert.Contains(t, output, "SERVICE="+service)
+		assert.Contains(t, output, `echo "server time.example.com iburst"`)
+		assert.Contains(t, output, `echo "server 10.0.0.1 iburst"`)
+	}
+}
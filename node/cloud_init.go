@@ -0,0 +1,172 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	sdkconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/config"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/monitoring"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrCloudInitUnsupportedRole is returned by CloudInitUserData for roles that can only be
+// provisioned over SSH
+var ErrCloudInitUnsupportedRole = errors.New("role cannot be provisioned with cloud-init")
+
+// cloudInitScriptDir is where the provisioning scripts are written on the instance
+const cloudInitScriptDir = "/var/lib/odyssey-cli/cloud-init"
+
+type cloudConfig struct {
+	WriteFiles []cloudConfigFile `yaml:"write_files"`
+	RunCmd     []string          `yaml:"runcmd"`
+}
+
+type cloudConfigFile struct {
+	Path        string `yaml:"path"`
+	Content     string `yaml:"content"`
+	Permissions string `yaml:"permissions"`
+}
+
+// CloudInitUserData renders the provisioning of a Validator or API node into a cloud-init
+// user-data document, to pass to the instance at creation instead of calling ProvisionNodes.
+// The instance then installs docker, the odysseygo configs and compose services by itself, so
+// no inbound SSH is needed to provision it. SSH is still used by the day-2 operations of
+// Node, e.g. UpgradeOdysseyGo.
+//
+// The user-data targets Ubuntu instances with an ubuntu user, and nodeID labels the logs
+// shipped by promtail
+func CloudInitUserData(nodeID string, nodeParams *NodeParams) ([]byte, error) {
+	if err := CheckRoles(nodeParams.Roles); err != nil {
+		return nil, err
+	}
+	if len(nodeParams.Roles) == 0 {
+		return nil, fmt.Errorf("%w: no role", ErrCloudInitUnsupportedRole)
+	}
+	for _, role := range nodeParams.Roles {
+		if role != Validator && role != API {
+			return nil, fmt.Errorf("%w: %s", ErrCloudInitUnsupportedRole, role.String())
+		}
+	}
+	if nodeParams.RuntimeMode == SystemdRuntime {
+		return nil, fmt.Errorf("%w: cloud-init only provisions the %s runtime", ErrUnsupportedRuntimeMode, DockerRuntime)
+	}
+	for _, server := range nodeParams.NTPServers {
+		if !ntpServerRegex.MatchString(server) {
+			return nil, fmt.Errorf("%w %q", ErrInvalidNTPServer, server)
+		}
+	}
+	nodeParams, err := resolveOdysseyGoVersion(nodeParams)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVMCompatibility(Node{NodeID: nodeID}, nodeParams); err != nil {
+		return nil, err
+	}
+	nodeConfig := sdkconfig.Get().WithNodeDefaults(nodeParams.Config)
+
+	config := cloudConfig{}
+	// the scripts run as root, in the order of their names
+	scripts := []struct {
+		desc   string
+		path   string
+		inputs scriptInputs
+	}{
+		{"Setup Node", "shell/setupNode.sh", scriptInputs{PackageManager: string(Apt)}},
+		{"Setup Time Sync", "shell/setupTimeSync.sh", scriptInputs{PackageManager: string(Apt), NTPServers: nodeParams.NTPServers}},
+		{"Setup Docker Service", "shell/setupDockerService.sh", scriptInputs{Config: nodeConfig}},
+	}
+	scriptPaths := make([]string, 0, len(scripts))
+	for i, s := range scripts {
+		content, err := renderScript(s.desc, s.path, s.inputs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s script: %w", s.desc, err)
+		}
+		scriptPath := filepath.Join(cloudInitScriptDir, fmt.Sprintf("%02d-%s", i+1, filepath.Base(s.path)))
+		scriptPaths = append(scriptPaths, scriptPath)
+		config.WriteFiles = append(config.WriteFiles, cloudConfigFile{Path: scriptPath, Content: content, Permissions: "0755"})
+	}
+
+	presetConfig, err := nodeParams.Preset.Config()
+	if err != nil {
+		return nil, err
+	}
+	// the public IP is not known before the instance is created, odysseygo resolves it
+	odysseyConf := remoteconfig.PrepareOdysseyConfig("", nodeParams.Network.HRP(), nodeParams.SubnetIDs)
+	odysseyConf.SetPorts(nodeConfig)
+	presetConfig.apply(&odysseyConf)
+	nodeConf, err := remoteconfig.RenderOdysseyNodeConfig(odysseyConf, remoteconfig.WithTemplateOverrides(nodeParams.TemplateOverrides))
+	if err != nil {
+		return nil, err
+	}
+	dChainConf, err := remoteconfig.RenderOdysseyDChainConfig(odysseyConf, remoteconfig.WithTemplateOverrides(nodeParams.TemplateOverrides))
+	if err != nil {
+		return nil, err
+	}
+	promtailConf, err := renderPromtailConfig(nodeID, nodeConfig.LokiPort, nodeParams.TemplateOverrides)
+	if err != nil {
+		return nil, err
+	}
+	composeFile, err := renderComposeFile("templates/odysseygo.docker-compose.yml", "Compose Node", dockerComposeInputs{
+		OdysseygoVersion: nodeParams.OdysseyGoVersion,
+		WithMonitoring:   true,
+		WithOdysseygo:    true,
+		Config:           nodeConfig,
+
+		templateOverrides: nodeParams.TemplateOverrides,
+	})
+	if err != nil {
+		return nil, err
+	}
+	config.WriteFiles = append(config.WriteFiles,
+		cloudConfigFile{Path: remoteconfig.GetRemoteOdysseyNodeConfig(nodeConfig), Content: string(nodeConf), Permissions: "0644"},
+		cloudConfigFile{Path: remoteconfig.GetRemoteOdysseyDChainConfig(nodeConfig), Content: string(dChainConf), Permissions: "0644"},
+		cloudConfigFile{Path: nodeConfig.ServicePath(constants.ServicePromtail, "promtail.yml"), Content: promtailConf, Permissions: "0644"},
+		cloudConfigFile{Path: nodeConfig.ComposeFile(), Content: string(composeFile), Permissions: "0644"},
+	)
+
+	for _, dir := range remoteconfig.RemoteFoldersToCreateOdysseygo(nodeConfig) {
+		config.RunCmd = append(config.RunCmd, "mkdir -p "+dir)
+	}
+	// write_files runs as root before the ubuntu user owns its home and the remote folders,
+	// which may be outside of it
+	config.RunCmd = append(config.RunCmd, fmt.Sprintf("chown -R %[1]s:%[1]s /home/%[1]s %s %s",
+		constants.RemoteHostUser, nodeConfig.OdysseyGoDir, nodeConfig.ServicesDir))
+	for _, scriptPath := range scriptPaths {
+		config.RunCmd = append(config.RunCmd, "bash "+scriptPath)
+	}
+	config.RunCmd = append(config.RunCmd, "systemctl start odyssey-cli-docker")
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte("#cloud-config\n"), out...), nil
+}
+
+// renderPromtailConfig renders the promtail config of a node without a monitoring host yet,
+// pushing its logs to the local lokiPort as provisionOdysseyGoHost does
+func renderPromtailConfig(nodeID string, lokiPort uint, overrides fs.FS) (string, error) {
+	promtailConfig, err := os.CreateTemp("", constants.ServicePromtail)
+	if err != nil {
+		return "", err
+	}
+	_ = promtailConfig.Close()
+	defer os.Remove(promtailConfig.Name())
+	if err := monitoring.WritePromtailConfig(promtailConfig.Name(), "127.0.0.1", strconv.FormatUint(uint64(lokiPort), 10), "127.0.0.1", nodeID, "", monitoring.WithTemplateOverrides(overrides)); err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(promtailConfig.Name())
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCloudInitUserData(t *testing.T) {
+	userData, err := CloudInitUserData("node-1", &NodeParams{
+		Roles:            []SupportedRole{Validator},
+		Network:          odyssey.TestnetNetwork(),
+		SubnetIDs:        []string{"subnet-1"},
+		OdysseyGoVersion: "v1.10.13",
+		NTPServers:       []string{"time.example.com"},
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(userData), "#cloud-config\n"))
+
+	var config cloudConfig
+	require.NoError(t, yaml.Unmarshal(userData, &config))
+	files := map[string]string{}
+	for _, file := range config.WriteFiles {
+		files[file.Path] = file.Content
+	}
+
+	assert.Contains(t, files[remoteconfig.GetRemoteOdysseyNodeConfig(constants.Config{})], "subnet-1")
+	assert.Contains(t, files, remoteconfig.GetRemoteOdysseyDChainConfig(constants.Config{}))
+	assert.Contains(t, files[utils.GetRemoteComposeFile()], "odysseygo:v1.10.13")
+	assert.Contains(t, files[utils.GetRemoteComposeServicePath(constants.ServicePromtail, "promtail.yml")], "node-1")
+	assert.Contains(t, files[cloudInitScriptDir+"/01-setupNode.sh"], "apt-get")
+	assert.Contains(t, files[cloudInitScriptDir+"/02-setupTimeSync.sh"], "time.example.com")
+	assert.Contains(t, files, cloudInitScriptDir+"/03-setupDockerService.sh")
+
+	require.NotEmpty(t, config.RunCmd)
+	assert.Contains(t, config.RunCmd, "bash "+cloudInitScriptDir+"/01-setupNode.sh")
+	assert.Equal(t, "systemctl start odyssey-cli-docker", config.RunCmd[len(config.RunCmd)-1])
+}
+
+func TestCloudInitUserData_Config(t *testing.T) {
+	userData, err := CloudInitUserData("node-1", &NodeParams{
+		Roles:            []SupportedRole{Validator},
+		Network:          odyssey.TestnetNetwork(),
+		OdysseyGoVersion: "v1.10.13",
+		Config:           constants.Config{OdysseyGoDir: "/data/odysseygo", ServicesDir: "/srv/odyssey"},
+	})
+	require.NoError(t, err)
+	var config cloudConfig
+	require.NoError(t, yaml.Unmarshal(userData, &config))
+	assert.Contains(t, config.RunCmd, "mkdir -p /data/odysseygo/configs")
+	assert.Contains(t, config.RunCmd, "chown -R ubuntu:ubuntu /home/ubuntu /data/odysseygo /srv/odyssey")
+}
+
+func TestCloudInitUserData_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      NodeParams
+		expectedErr error
+	}{
+		{
+			name:        "no role",
+			params:      NodeParams{OdysseyGoVersion: "v1.10.13"},
+			expectedErr: ErrCloudInitUnsupportedRole,
+		},
+		{
+			name:        "monitor role",
+			params:      NodeParams{Roles: []SupportedRole{Monitor}, OdysseyGoVersion: "v1.10.13"},
+			expectedErr: ErrCloudInitUnsupportedRole,
+		},
+		{
+			name:        "invalid NTP server",
+			params:      NodeParams{Roles: []SupportedRole{API}, OdysseyGoVersion: "v1.10.13", NTPServers: []string{"bad server;"}},
+			expectedErr: ErrInvalidNTPServer,
+		},
+		{
+			name:        "unsupported preset",
+			params:      NodeParams{Roles: []SupportedRole{API}, OdysseyGoVersion: "v1.10.13", Preset: "archive"},
+			expectedErr: ErrUnsupportedPreset,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CloudInitUserData("node-1", &tt.params)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/instrumentation"
+)
+
+// ErrRemoteCommandFailed is matched by the RemoteCommandError of the commands that ran on the
+// node and exited with a non zero code, as opposed to the commands that could not be run
+var ErrRemoteCommandFailed = errors.New("remote command failed")
+
+// CommandResult is the outcome of a command run on a node by RunCommand
+type CommandResult struct {
+	Stdout []byte
+	Stderr []byte
+
+	// ExitCode is the exit code of the command, -1 if the command did not exit, e.g. when the
+	// SSH connection failed or the command timed out
+	ExitCode int
+
+	Duration time.Duration
+}
+
+// RemoteCommandError is the error of a command that exited with a non zero code
+type RemoteCommandError struct {
+	ExitCode int
+
+	// Err is the error of the SSH client
+	Err error
+}
+
+func (e *RemoteCommandError) Error() string {
+	return fmt.Sprintf("%s with exit code %d", ErrRemoteCommandFailed, e.ExitCode)
+}
+
+func (e *RemoteCommandError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes RemoteCommandError match ErrRemoteCommandFailed
+func (*RemoteCommandError) Is(target error) bool {
+	return target == ErrRemoteCommandFailed
+}
+
+// RunCommand executes a shell command on a remote node like Command, keeping its stdout and
+// stderr apart. When the command exits with a non zero code, the error is a
+// *RemoteCommandError and the result holds the output of the command. Other errors, e.g. SSH
+// failures, come with a result whose ExitCode is -1
+func (h *Node) RunCommand(env []string, timeout time.Duration, script string) (*CommandResult, error) {
+	script, stdin, err := h.prepareSudo(script, nil)
+	if err != nil {
+		return nil, err
+	}
+	result, output, err := h.runCommand(env, timeout, script, stdin)
+	if err != nil && usesSudo(script) {
+		err = sudoError(err, output)
+	}
+	return result, err
+}
+
+// runCommand runs script, returning its result along with its stdout and stderr combined
+func (h *Node) runCommand(env []string, timeout time.Duration, script string, stdin io.Reader) (*CommandResult, []byte, error) {
+	if !h.Connected() {
+		if err := h.Connect(0); err != nil {
+			return nil, nil, err
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var output, stdout, stderr combinedOutput
+	start := time.Now()
+	err := h.connection.Run(ctx, env, script, stdin, io.MultiWriter(&output, &stdout), io.MultiWriter(&output, &stderr))
+	result := &CommandResult{
+		Stdout:   stdout.Bytes(),
+		Stderr:   stderr.Bytes(),
+		Duration: time.Since(start),
+	}
+	instrumentation.RecordSSHCommand(ctx, instrumentation.SSHCommand{
+		NodeID:   h.NodeID,
+		Host:     h.IP,
+		Duration: result.Duration,
+		Err:      err,
+	})
+	if err != nil {
+		result.ExitCode = exitCode(err)
+		if result.ExitCode != -1 {
+			err = &RemoteCommandError{ExitCode: result.ExitCode, Err: err}
+		}
+	}
+	return result, output.Bytes(), err
+}
+
+// exitCode returns the non zero exit code carried by err, the ExitError of an SSH session or
+// of a docker exec, or -1 if err carries none, e.g. when the command was killed by a signal
+func exitCode(err error) int {
+	code := -1
+	var sshExit interface{ ExitStatus() int }
+	var processExit interface{ ExitCode() int }
+	switch {
+	case errors.As(err, &sshExit):
+		code = sshExit.ExitStatus()
+	case errors.As(err, &processExit):
+		code = processExit.ExitCode()
+	}
+	if code <= 0 {
+		return -1
+	}
+	return code
+}
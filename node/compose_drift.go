@@ -0,0 +1,262 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ComposeDrift is the difference between the docker compose file found on a node
+// and the one expected for the node roles
+type ComposeDrift struct {
+	// ServicesAdded are services present on the node but not expected
+	ServicesAdded []string
+
+	// ServicesRemoved are expected services missing on the node
+	ServicesRemoved []string
+
+	// ImageChanges maps each service present on both sides to its image change, if any
+	ImageChanges map[string]ImageChange
+
+	// EnvChanges maps each service present on both sides to its environment changes, if any
+	EnvChanges map[string]EnvChange
+}
+
+// ImageChange is a difference of image (including tag) for a compose service
+type ImageChange struct {
+	Expected string
+	Actual   string
+}
+
+// EnvChange is the set of environment differences for a compose service
+type EnvChange struct {
+	// Added are variables set on the node but not expected
+	Added map[string]string
+
+	// Removed are expected variables missing on the node
+	Removed map[string]string
+
+	// Changed are variables set on both sides with a different value
+	Changed map[string]EnvValueChange
+}
+
+// EnvValueChange is a difference of value for an environment variable
+type EnvValueChange struct {
+	Expected string
+	Actual   string
+}
+
+// HasDrift returns true if the remote compose file differs from the expected one
+func (d *ComposeDrift) HasDrift() bool {
+	return len(d.ServicesAdded) > 0 || len(d.ServicesRemoved) > 0 || len(d.ImageChanges) > 0 || len(d.EnvChanges) > 0
+}
+
+type composeFileContent struct {
+	Services map[string]composeServiceContent `yaml:"services"`
+}
+
+type composeServiceContent struct {
+	Image       string      `yaml:"image,omitempty"`
+	Environment interface{} `yaml:"environment,omitempty"`
+}
+
+// ComposeDrift downloads the remote docker compose file of the node and compares it against
+// the one rendered from the current templates for the node roles, so that manual changes
+// can be detected before running upgrades.
+//
+// odysseyGoVersion is the expected OdysseyGo version for Validator and API nodes.
+// The AWM relayer of Relayer nodes is expected at constants.DefaultAWMRelayerVersion.
+func (h *Node) ComposeDrift(ctx context.Context, odysseyGoVersion string) (*ComposeDrift, error) {
+	expected, err := h.expectedComposeContent(odysseyGoVersion)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	remoteContent, err := h.GetRemoteComposeContent(h.config().ComposeFile(), constants.SSHFileOpsTimeout)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := parseComposeContent([]byte(remoteContent))
+	if err != nil {
+		return nil, err
+	}
+	return diffComposeContent(expected, actual)
+}
+
+// expectedComposeContent renders the compose templates the node roles would be provisioned with
+func (h *Node) expectedComposeContent(odysseyGoVersion string) (composeFileContent, error) {
+	expected := composeFileContent{Services: map[string]composeServiceContent{}}
+	if err := CheckRoles(h.Roles); err != nil {
+		return expected, err
+	}
+	for _, role := range h.Roles {
+		var (
+			composePath string
+			composeVars dockerComposeInputs
+		)
+		switch role {
+		case Validator, API:
+			composePath = "templates/odysseygo.docker-compose.yml"
+			composeVars = dockerComposeInputs{
+				OdysseygoVersion: odysseyGoVersion,
+				WithMonitoring:   true,
+				WithOdysseygo:    true,
+				E2E:              utils.IsE2E(),
+				E2EIP:            utils.E2EConvertIP(h.IP),
+				E2ESuffix:        utils.E2ESuffix(h.IP),
+			}
+		case Loadtest:
+			composePath = "templates/odysseygo.docker-compose.yml"
+			composeVars = dockerComposeInputs{WithMonitoring: true}
+		case Monitor:
+			composePath = "templates/monitoring.docker-compose.yml"
+			composeVars = monitoringComposeInputs(h.MonitoringSecurity)
+		case Relayer:
+			// standalone relayers run the monitoring agents too
+			if !isOdysseyGoNode(*h) {
+				if err := h.addExpectedServices(expected, "templates/odysseygo.docker-compose.yml", dockerComposeInputs{WithMonitoring: true}); err != nil {
+					return expected, err
+				}
+			}
+			composePath = "templates/relayer.docker-compose.yml"
+			composeVars = dockerComposeInputs{AWMRelayerVersion: constants.DefaultAWMRelayerVersion}
+		case RPCGateway:
+			composePath = "templates/rpc-gateway.docker-compose.yml"
+		default:
+			return expected, fmt.Errorf("unsupported role %v", role)
+		}
+		if err := h.addExpectedServices(expected, composePath, composeVars); err != nil {
+			return expected, err
+		}
+	}
+	return expected, nil
+}
+
+// addExpectedServices adds the services of the compose template rendered for h to expected
+func (h *Node) addExpectedServices(expected composeFileContent, composePath string, composeVars dockerComposeInputs) error {
+	composeData, err := renderComposeFile(composePath, "Compose Drift", composeVars.forNode(h))
+	if err != nil {
+		return err
+	}
+	content, err := parseComposeContent(composeData)
+	if err != nil {
+		return err
+	}
+	for name, service := range content.Services {
+		expected.Services[name] = service
+	}
+	return nil
+}
+
+func parseComposeContent(data []byte) (composeFileContent, error) {
+	var content composeFileContent
+	if err := yaml.Unmarshal(data, &content); err != nil {
+		return content, fmt.Errorf("invalid compose file: %w", err)
+	}
+	return content, nil
+}
+
+// diffComposeContent compares the services of two compose files
+func diffComposeContent(expected composeFileContent, actual composeFileContent) (*ComposeDrift, error) {
+	drift := &ComposeDrift{
+		ServicesAdded:   []string{},
+		ServicesRemoved: []string{},
+		ImageChanges:    map[string]ImageChange{},
+		EnvChanges:      map[string]EnvChange{},
+	}
+	for name := range actual.Services {
+		if _, ok := expected.Services[name]; !ok {
+			drift.ServicesAdded = append(drift.ServicesAdded, name)
+		}
+	}
+	for name, expectedService := range expected.Services {
+		actualService, ok := actual.Services[name]
+		if !ok {
+			drift.ServicesRemoved = append(drift.ServicesRemoved, name)
+			continue
+		}
+		if expectedService.Image != actualService.Image {
+			drift.ImageChanges[name] = ImageChange{
+				Expected: expectedService.Image,
+				Actual:   actualService.Image,
+			}
+		}
+		expectedEnv, err := parseComposeEnvironment(expectedService.Environment)
+		if err != nil {
+			return nil, fmt.Errorf("invalid environment for expected service %s: %w", name, err)
+		}
+		actualEnv, err := parseComposeEnvironment(actualService.Environment)
+		if err != nil {
+			return nil, fmt.Errorf("invalid environment for service %s: %w", name, err)
+		}
+		if envChange, changed := diffEnvironment(expectedEnv, actualEnv); changed {
+			drift.EnvChanges[name] = envChange
+		}
+	}
+	sort.Strings(drift.ServicesAdded)
+	sort.Strings(drift.ServicesRemoved)
+	return drift, nil
+}
+
+func diffEnvironment(expected map[string]string, actual map[string]string) (EnvChange, bool) {
+	envChange := EnvChange{
+		Added:   map[string]string{},
+		Removed: map[string]string{},
+		Changed: map[string]EnvValueChange{},
+	}
+	for key, value := range actual {
+		if _, ok := expected[key]; !ok {
+			envChange.Added[key] = value
+		}
+	}
+	for key, expectedValue := range expected {
+		actualValue, ok := actual[key]
+		switch {
+		case !ok:
+			envChange.Removed[key] = expectedValue
+		case actualValue != expectedValue:
+			envChange.Changed[key] = EnvValueChange{Expected: expectedValue, Actual: actualValue}
+		}
+	}
+	changed := len(envChange.Added) > 0 || len(envChange.Removed) > 0 || len(envChange.Changed) > 0
+	return envChange, changed
+}
+
+// parseComposeEnvironment normalizes a compose environment, given either in list
+// (KEY=VALUE) or in map form
+func parseComposeEnvironment(environment interface{}) (map[string]string, error) {
+	env := map[string]string{}
+	switch environment := environment.(type) {
+	case nil:
+	case []interface{}:
+		for _, entry := range environment {
+			s, ok := entry.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected environment entry %v", entry)
+			}
+			key, value, _ := strings.Cut(s, "=")
+			env[key] = value
+		}
+	case map[string]interface{}:
+		for key, value := range environment {
+			if value == nil {
+				env[key] = ""
+			} else {
+				env[key] = fmt.Sprint(value)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unexpected environment format %T", environment)
+	}
+	return env, nil
+}
@@ -0,0 +1,201 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpectedComposeContent(t *testing.T) {
+	tests := []struct {
+		name             string
+		roles            []SupportedRole
+		expectedServices []string
+		expectError      bool
+	}{
+		{
+			name:             "Validator",
+			roles:            []SupportedRole{Validator},
+			expectedServices: []string{constants.ServiceOdysseygo, constants.ServicePromtail, constants.ServiceNodeExporter},
+		},
+		{
+			name:             "Loadtest",
+			roles:            []SupportedRole{Loadtest},
+			expectedServices: []string{constants.ServicePromtail, constants.ServiceNodeExporter},
+		},
+		{
+			name:  "Monitor",
+			roles: []SupportedRole{Monitor},
+			expectedServices: []string{
+				constants.ServicePrometheus,
+				constants.ServiceGrafana,
+				constants.ServiceLoki,
+				constants.ServiceNodeExporter,
+			},
+		},
+		{
+			name:  "Relayer",
+			roles: []SupportedRole{Relayer},
+			expectedServices: []string{
+				constants.ServiceAWMRelayer,
+				constants.ServicePromtail,
+				constants.ServiceNodeExporter,
+			},
+		},
+		{
+			name:  "API with relayer and RPC gateway",
+			roles: []SupportedRole{API, Relayer, RPCGateway},
+			expectedServices: []string{
+				constants.ServiceOdysseygo,
+				constants.ServicePromtail,
+				constants.ServiceNodeExporter,
+				constants.ServiceAWMRelayer,
+				constants.ServiceRPCGateway,
+			},
+		},
+		{
+			name:        "Invalid combination",
+			roles:       []SupportedRole{Validator, API},
+			expectError: true,
+		},
+		{
+			name:        "RPC gateway without API",
+			roles:       []SupportedRole{Validator, RPCGateway},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := Node{NodeID: "test-node", IP: "192.168.1.1", Roles: tt.roles}
+			content, err := node.expectedComposeContent("v1.10.13")
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			services := []string{}
+			for name := range content.Services {
+				services = append(services, name)
+			}
+			assert.ElementsMatch(t, tt.expectedServices, services)
+		})
+	}
+
+	node := Node{NodeID: "test-node", IP: "192.168.1.1", Roles: []SupportedRole{API}}
+	content, err := node.expectedComposeContent("v1.10.13")
+	require.NoError(t, err)
+	assert.Equal(t, "dionetech/odysseygo:v1.10.13", content.Services[constants.ServiceOdysseygo].Image)
+}
+
+func TestDiffComposeContent(t *testing.T) {
+	expected, err := parseComposeContent([]byte(`
+services:
+  odysseygo:
+    image: dionetech/odysseygo:v1.10.13
+  grafana:
+    image: grafana/grafana:10.4.1
+    environment:
+      - GF_SECURITY_ADMIN_PASSWORD=admin
+      - GF_USERS_ALLOW_SIGN_UP=false
+  loki:
+    image: grafana/loki:3.0.0
+`))
+	require.NoError(t, err)
+	actual, err := parseComposeContent([]byte(`
+services:
+  odysseygo:
+    image: dionetech/odysseygo:v1.10.12
+  grafana:
+    image: grafana/grafana:10.4.1
+    environment:
+      GF_SECURITY_ADMIN_PASSWORD: secret
+      GF_INSTALL_PLUGINS: plugin
+  cadvisor:
+    image: gcr.io/cadvisor/cadvisor
+`))
+	require.NoError(t, err)
+
+	drift, err := diffComposeContent(expected, actual)
+	require.NoError(t, err)
+	assert.True(t, drift.HasDrift())
+	assert.Equal(t, []string{"cadvisor"}, drift.ServicesAdded)
+	assert.Equal(t, []string{"loki"}, drift.ServicesRemoved)
+	assert.Equal(t, map[string]ImageChange{
+		"odysseygo": {Expected: "dionetech/odysseygo:v1.10.13", Actual: "dionetech/odysseygo:v1.10.12"},
+	}, drift.ImageChanges)
+	require.Contains(t, drift.EnvChanges, "grafana")
+	envChange := drift.EnvChanges["grafana"]
+	assert.Equal(t, map[string]string{"GF_INSTALL_PLUGINS": "plugin"}, envChange.Added)
+	assert.Equal(t, map[string]string{"GF_USERS_ALLOW_SIGN_UP": "false"}, envChange.Removed)
+	assert.Equal(t, map[string]EnvValueChange{
+		"GF_SECURITY_ADMIN_PASSWORD": {Expected: "admin", Actual: "secret"},
+	}, envChange.Changed)
+}
+
+func TestDiffComposeContent_NoDrift(t *testing.T) {
+	composeData, err := renderComposeFile("templates/monitoring.docker-compose.yml", "test", dockerComposeInputs{})
+	require.NoError(t, err)
+	content, err := parseComposeContent(composeData)
+	require.NoError(t, err)
+
+	drift, err := diffComposeContent(content, content)
+	require.NoError(t, err)
+	assert.False(t, drift.HasDrift())
+}
+
+func TestParseComposeEnvironment(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment interface{}
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name:        "Nil",
+			environment: nil,
+			expected:    map[string]string{},
+		},
+		{
+			name:        "List",
+			environment: []interface{}{"A=1", "B=x=y", "C"},
+			expected:    map[string]string{"A": "1", "B": "x=y", "C": ""},
+		},
+		{
+			name:        "Map",
+			environment: map[string]interface{}{"A": 1, "B": "two", "C": nil},
+			expected:    map[string]string{"A": "1", "B": "two", "C": ""},
+		},
+		{
+			name:        "Invalid",
+			environment: "A=1",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := parseComposeEnvironment(tt.environment)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, env)
+		})
+	}
+}
+
+func TestNode_ComposeDrift_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	node := Node{NodeID: "test-node", IP: "192.168.1.1", Roles: []SupportedRole{Validator}}
+	_, err := node.ComposeDrift(ctx, "v1.10.13")
+	assert.ErrorIs(t, err, context.Canceled)
+}
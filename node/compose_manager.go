@@ -0,0 +1,350 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrComposeNotRendered     = errors.New("no compose template was rendered to the compose file")
+	ErrComposeServiceNotFound = errors.New("compose service not found")
+)
+
+// ComposeManager runs the docker compose operations of a compose file of a node. Use
+// Node.Compose for the compose file the SDK provisions the nodes with, which is run by the
+// systemd unit of the node if any, and NewComposeManager for other compose files.
+//
+// The manager caches the services of the templates it rendered to the compose file, which
+// Drift compares the remote file against
+type ComposeManager struct {
+	node *Node
+	path string
+
+	lock sync.Mutex
+	// rendered are the services of the templates rendered to the compose file, by name
+	rendered map[string]composeServiceContent
+}
+
+// ComposeService is a service of the compose file of a ComposeManager
+type ComposeService struct {
+	Name string
+
+	manager *ComposeManager
+}
+
+// NewComposeManager returns a manager of the compose file at path on h
+func NewComposeManager(h *Node, path string) *ComposeManager {
+	return &ComposeManager{
+		node:     h,
+		path:     path,
+		rendered: map[string]composeServiceContent{},
+	}
+}
+
+// Compose returns the manager of the compose file the SDK provisions h with, in the services
+// directory of its Config. The same manager is returned on each call
+func (h *Node) Compose() *ComposeManager {
+	// copies of h get their own manager, running its operations through them
+	if h.compose == nil || h.compose.node != h || h.compose.path != h.config().ComposeFile() {
+		h.compose = NewComposeManager(h, h.config().ComposeFile())
+	}
+	return h.compose
+}
+
+// composeManager returns the manager of the compose file at path on h
+func (h *Node) composeManager(path string) *ComposeManager {
+	if path == h.config().ComposeFile() {
+		return h.Compose()
+	}
+	return NewComposeManager(h, path)
+}
+
+// Path is the path of the compose file on the node
+func (m *ComposeManager) Path() string {
+	return m.path
+}
+
+// Service returns the handle of the service name. The service is not required to exist
+func (m *ComposeManager) Service(name string) *ComposeService {
+	return &ComposeService{Name: name, manager: m}
+}
+
+// Up creates and starts the services of the compose file
+func (m *ComposeManager) Up(timeout time.Duration) error {
+	return m.run(timeout, "start", "up -d")
+}
+
+// Down stops and removes the services of the compose file
+func (m *ComposeManager) Down(timeout time.Duration) error {
+	return m.run(timeout, "stop", "down")
+}
+
+// Restart restarts the services of the compose file
+func (m *ComposeManager) Restart(timeout time.Duration) error {
+	return m.run(timeout, "restart", "restart")
+}
+
+// run runs the systemctl command of the systemd unit running the compose file of the SDK if
+// the node has systemd, and the docker compose command otherwise
+func (m *ComposeManager) run(timeout time.Duration, systemctlCommand string, composeCommand string) error {
+	h := m.node
+	if m.path == h.config().ComposeFile() && h.HasSystemDAvailable() {
+		if output, err := h.Commandf(nil, timeout, "sudo systemctl %s odyssey-cli-docker", systemctlCommand); err != nil {
+			return fmt.Errorf("%w: %s", err, string(output))
+		}
+		return nil
+	}
+	return m.compose(timeout, composeCommand)
+}
+
+// Validate checks the syntax of the compose file
+func (m *ComposeManager) Validate(timeout time.Duration) error {
+	return m.compose(timeout, "config")
+}
+
+// Services lists the services of the compose file
+func (m *ComposeManager) Services(timeout time.Duration) ([]string, error) {
+	output, err := m.node.dockerCommandf(timeout, "docker compose -f %s config --services", m.path)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// HasService tells whether the compose file has the service name
+func (m *ComposeManager) HasService(name string, timeout time.Duration) (bool, error) {
+	services, err := m.Services(timeout)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(services, name), nil
+}
+
+// Content downloads the compose file
+func (m *ComposeManager) Content(timeout time.Duration) (string, error) {
+	data, err := m.node.ReadFileBytes(m.path, timeout)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Images maps the repository of the images of the services of the compose file to their tag
+func (m *ComposeManager) Images(timeout time.Duration) (map[string]string, error) {
+	images, err := m.images(timeout, "")
+	if err != nil {
+		return nil, err
+	}
+	imageMap := make(map[string]string)
+	for _, image := range images {
+		imageMap[image.Repository] = image.Tag
+	}
+	return imageMap, nil
+}
+
+type composeImage struct {
+	ID         string `json:"ID"`
+	Name       string `json:"ContainerName"`
+	Repository string `json:"Repository"`
+	Tag        string `json:"Tag"`
+	Size       uint   `json:"Size"`
+}
+
+// images lists the images of the containers of service, or of all the services if empty
+func (m *ComposeManager) images(timeout time.Duration, service string) ([]composeImage, error) {
+	output, err := m.node.dockerCommandf(timeout, "docker compose -f %s images --format json %s", m.path, service)
+	if err != nil {
+		return nil, err
+	}
+	var images []composeImage
+	if err := json.Unmarshal(output, &images); err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// Drift compares the compose file against the services of the templates rendered to it by
+// the manager, e.g. when provisioning or upgrading the node, failing with
+// ErrComposeNotRendered if none was. Use Node.ComposeDrift to compare the compose file of the
+// SDK against the templates of the node roles instead
+func (m *ComposeManager) Drift(ctx context.Context) (*ComposeDrift, error) {
+	m.lock.Lock()
+	expected := composeFileContent{Services: make(map[string]composeServiceContent, len(m.rendered))}
+	for name, service := range m.rendered {
+		expected.Services[name] = service
+	}
+	m.lock.Unlock()
+	if len(expected.Services) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrComposeNotRendered, m.path)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	content, err := m.node.ReadFileBytes(m.path, constants.SSHFileOpsTimeout)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := parseComposeContent(content)
+	if err != nil {
+		return nil, err
+	}
+	return diffComposeContent(expected, actual)
+}
+
+// apply renders the compose template at composePath with composeVars, merges it into the
+// compose file and brings the services up, returning whether the compose file changed
+func (m *ComposeManager) apply(
+	composeDesc string,
+	timeout time.Duration,
+	composePath string,
+	composeVars dockerComposeInputs,
+) (bool, error) {
+	h := m.node
+	startTime := time.Now()
+	composeData, err := renderComposeFile(composePath, composeDesc, composeVars.forNode(h))
+	if err != nil {
+		return false, err
+	}
+	if err := h.checkExecutionPolicy(composeDesc, string(composeData)); err != nil {
+		return false, err
+	}
+	content, err := parseComposeContent(composeData)
+	if err != nil {
+		return false, err
+	}
+	h.Logger.Infof("pushComposeFile [%s]%s", h.NodeID, composeDesc)
+	changed, err := m.merge(composeData)
+	if err != nil {
+		return false, err
+	}
+	h.Logger.Infof("ValidateComposeFile [%s]%s", h.NodeID, composeDesc)
+	if err := m.Validate(timeout); err != nil {
+		h.Logger.Errorf("ComposeOverSSH[%s]%s failed to validate: %v", h.NodeID, composeDesc, err)
+		return false, err
+	}
+	m.lock.Lock()
+	for name, service := range content.Services {
+		m.rendered[name] = service
+	}
+	m.lock.Unlock()
+	h.Logger.Infof("StartDockerCompose [%s]%s", h.NodeID, composeDesc)
+	if err := m.Up(timeout); err != nil {
+		return false, err
+	}
+	h.Logger.Infof("ComposeOverSSH[%s]%s took %s", h.NodeID, composeDesc, time.Since(startTime))
+	return changed, nil
+}
+
+// merge merges composeData into the compose file, returning whether it changed
+func (m *ComposeManager) merge(composeData []byte) (bool, error) {
+	tmpFile, err := os.CreateTemp("", "odysseycli-docker-compose-*.yml")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(composeData); err != nil {
+		return false, err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return false, err
+	}
+	return m.node.pushComposeFile(tmpFile.Name(), m.path, true)
+}
+
+func (m *ComposeManager) compose(timeout time.Duration, command string) error {
+	if output, err := m.node.dockerCommandf(timeout, "docker compose -f %s %s", m.path, command); err != nil {
+		return fmt.Errorf("%w: %s", err, string(output))
+	}
+	return nil
+}
+
+// Init creates the containers of the service without starting them
+func (s *ComposeService) Init(timeout time.Duration) error {
+	return s.manager.compose(timeout, "create "+s.Name)
+}
+
+// Start creates the containers of the service if needed and starts them
+func (s *ComposeService) Start(timeout time.Duration) error {
+	if err := s.Init(timeout); err != nil {
+		return err
+	}
+	return s.manager.compose(timeout, "start "+s.Name)
+}
+
+// Stop stops the containers of the service
+func (s *ComposeService) Stop(timeout time.Duration) error {
+	return s.manager.compose(timeout, "stop "+s.Name)
+}
+
+// Restart restarts the containers of the service
+func (s *ComposeService) Restart(timeout time.Duration) error {
+	return s.manager.compose(timeout, "restart "+s.Name)
+}
+
+// Logs returns the last tail lines of the logs of the service, or all of them if tail is 0
+func (s *ComposeService) Logs(tail uint, timeout time.Duration) ([]byte, error) {
+	tailArg := "all"
+	if tail > 0 {
+		tailArg = fmt.Sprint(tail)
+	}
+	output, err := s.manager.node.dockerCommandf(timeout, "docker compose -f %s logs --no-color --no-log-prefix --tail %s %s", s.manager.path, tailArg, s.Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, string(output))
+	}
+	return output, nil
+}
+
+// ImageVersion returns the tag of the image the containers of the service run, or an
+// empty string if the service has no container
+func (s *ComposeService) ImageVersion(timeout time.Duration) (string, error) {
+	images, err := s.manager.images(timeout, s.Name)
+	if err != nil {
+		return "", err
+	}
+	if len(images) == 0 {
+		return "", nil
+	}
+	return images[0].Tag, nil
+}
+
+// Upgrade sets the image of the service in the compose file, e.g. dionetech/odysseygo:v1.10.14,
+// and recreates its containers with it. The service must be in the compose file
+func (s *ComposeService) Upgrade(image string, timeout time.Duration) error {
+	m := s.manager
+	found, err := m.HasService(s.Name, timeout)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%w: %s in %s", ErrComposeServiceNotFound, s.Name, m.path)
+	}
+	override, err := yaml.Marshal(composeFileContent{
+		Services: map[string]composeServiceContent{s.Name: {Image: image}},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := m.merge(override); err != nil {
+		return err
+	}
+	m.lock.Lock()
+	if service, ok := m.rendered[s.Name]; ok {
+		service.Image = image
+		m.rendered[s.Name] = service
+	}
+	m.lock.Unlock()
+	return m.compose(timeout, "up -d "+s.Name)
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/nodemock"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testComposeFile = "/home/ubuntu/relayer/docker-compose.yml"
+
+func TestComposeManager_Service(t *testing.T) {
+	client := nodemock.NewSSHClient()
+	client.OnScript("docker compose -f "+testComposeFile+" create awm-relayer", "", nil).Once()
+	client.OnScript("docker compose -f "+testComposeFile+" start awm-relayer", "", nil).Once()
+	client.OnScript("docker compose -f "+testComposeFile+" stop awm-relayer", "no such service\n", errors.New("exit status 1")).Once()
+	client.OnScript("docker compose -f "+testComposeFile+" logs --no-color --no-log-prefix --tail 2 awm-relayer", "line 1\nline 2\n", nil).Once()
+	client.OnScript("docker compose -f "+testComposeFile+" images --format json awm-relayer",
+		`[{"ContainerName":"awm-relayer","Repository":"dionetech/awm-relayer","Tag":"v1.0.0"}]`, nil).Once()
+	client.OnScript("docker compose -f "+testComposeFile+" config --services", "awm-relayer\npromtail\n", nil).Once()
+	h := &Node{NodeID: "node-1"}
+	h.SetSSHClient(client)
+
+	compose := NewComposeManager(h, testComposeFile)
+	assert.Equal(t, testComposeFile, compose.Path())
+	service := compose.Service(constants.ServiceAWMRelayer)
+	require.NoError(t, service.Start(time.Second))
+	err := service.Stop(time.Second)
+	require.Error(t, err)
+	require.ErrorContains(t, err, "no such service")
+	logs, err := service.Logs(2, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "line 1\nline 2\n", string(logs))
+	version, err := service.ImageVersion(time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", version)
+	found, err := compose.HasService("promtail", time.Second)
+	require.NoError(t, err)
+	assert.True(t, found)
+	client.AssertExpectations(t)
+}
+
+func TestNode_Compose(t *testing.T) {
+	h := &Node{NodeID: "node-1"}
+	compose := h.Compose()
+	assert.Equal(t, utils.GetRemoteComposeFile(), compose.Path())
+	assert.Same(t, compose, h.Compose())
+	assert.Same(t, compose, h.composeManager(utils.GetRemoteComposeFile()))
+	assert.NotSame(t, compose, h.composeManager(testComposeFile))
+
+	// copies of the node do not run the operations through the original
+	copied := *h
+	assert.NotSame(t, compose, copied.Compose())
+}
+
+func TestComposeManager_Drift(t *testing.T) {
+	remoteContent := []byte(`services:
+  awm-relayer:
+    image: dionetech/awm-relayer:v0.9.0
+  promtail:
+    image: grafana/promtail:3.0.0
+`)
+	sum := sha256.Sum256(remoteContent)
+	client := nodemock.NewSSHClient()
+	client.OnScript("base64 '"+testComposeFile+"'", base64.StdEncoding.EncodeToString(remoteContent), nil).Once()
+	client.OnScript("sha256sum '"+testComposeFile+"'", fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), testComposeFile), nil).Once()
+	h := &Node{NodeID: "node-1"}
+	h.SetSSHClient(client)
+	compose := NewComposeManager(h, testComposeFile)
+
+	_, err := compose.Drift(context.Background())
+	require.ErrorIs(t, err, ErrComposeNotRendered)
+
+	compose.rendered[constants.ServiceAWMRelayer] = composeServiceContent{Image: "dionetech/awm-relayer:v1.0.0"}
+	drift, err := compose.Drift(context.Background())
+	require.NoError(t, err)
+	assert.True(t, drift.HasDrift())
+	assert.Equal(t, []string{"promtail"}, drift.ServicesAdded)
+	assert.Equal(t, ImageChange{
+		Expected: "dionetech/awm-relayer:v1.0.0",
+		Actual:   "dionetech/awm-relayer:v0.9.0",
+	}, drift.ImageChanges[constants.ServiceAWMRelayer])
+	client.AssertExpectations(t)
+}
@@ -4,31 +4,71 @@
 package services
 
 import (
+	"bytes"
+	"strconv"
+	"strings"
+	"text/template"
+
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
-	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
 )
 
-func RenderGrafanaLokiDataSourceConfig() ([]byte, error) {
-	return templates.ReadFile("templates/grafana-loki-datasource.yaml")
+// GrafanaDataSourceInputs holds the settings Grafana uses to query Loki and Prometheus
+type GrafanaDataSourceInputs struct {
+	// TLS is true if Loki and Prometheus are served over TLS
+	TLS bool
+
+	// BasicAuthUser and BasicAuthPassword are the Prometheus basic auth credentials, if any
+	BasicAuthUser     string
+	BasicAuthPassword string
+
+	// TenantID is the Loki tenant, if Loki multi-tenancy is enabled
+	TenantID string
+}
+
+var grafanaTemplateFuncs = template.FuncMap{
+	// grafanaQuote quotes a YAML value, escaping the $ that Grafana would expand as env vars
+	"grafanaQuote": func(value string) string {
+		return strconv.Quote(strings.ReplaceAll(value, "$", "$$"))
+	},
+}
+
+func renderGrafanaTemplate(templateName string, inputs GrafanaDataSourceInputs, opts []RenderOption) ([]byte, error) {
+	templateBytes, err := readTemplate(templateName, opts)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New("grafana").Funcs(grafanaTemplateFuncs).Parse(string(templateBytes))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, inputs); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func RenderGrafanaLokiDataSourceConfig(inputs GrafanaDataSourceInputs, opts ...RenderOption) ([]byte, error) {
+	return renderGrafanaTemplate("templates/grafana-loki-datasource.yaml", inputs, opts)
 }
 
-func RenderGrafanaPrometheusDataSourceConfigg() ([]byte, error) {
-	return templates.ReadFile("templates/grafana-prometheus-datasource.yaml")
+func RenderGrafanaPrometheusDataSourceConfigg(inputs GrafanaDataSourceInputs, opts ...RenderOption) ([]byte, error) {
+	return renderGrafanaTemplate("templates/grafana-prometheus-datasource.yaml", inputs, opts)
 }
 
-func RenderGrafanaConfig() ([]byte, error) {
-	return templates.ReadFile("templates/grafana.ini")
+func RenderGrafanaConfig(opts ...RenderOption) ([]byte, error) {
+	return readTemplate("templates/grafana.ini", opts)
 }
 
-func RenderGrafanaDashboardConfig() ([]byte, error) {
-	return templates.ReadFile("templates/grafana-dashboards.yaml")
+func RenderGrafanaDashboardConfig(opts ...RenderOption) ([]byte, error) {
+	return readTemplate("templates/grafana-dashboards.yaml", opts)
 }
 
-func GrafanaFoldersToCreate() []string {
+func GrafanaFoldersToCreate(config constants.Config) []string {
 	return []string{
-		utils.GetRemoteComposeServicePath(constants.ServiceGrafana, "data"),
-		utils.GetRemoteComposeServicePath(constants.ServiceGrafana, "dashboards"),
-		utils.GetRemoteComposeServicePath(constants.ServiceGrafana, "provisioning", "datasources"),
-		utils.GetRemoteComposeServicePath(constants.ServiceGrafana, "provisioning", "dashboards"),
+		config.ServicePath(constants.ServiceGrafana, "data"),
+		config.ServicePath(constants.ServiceGrafana, "dashboards"),
+		config.ServicePath(constants.ServiceGrafana, "provisioning", "datasources"),
+		config.ServicePath(constants.ServiceGrafana, "provisioning", "dashboards"),
 	}
 }
@@ -5,9 +5,8 @@ package services
 
 import (
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
-	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
 )
 
-func LokiFoldersToCreate() []string {
-	return []string{utils.GetRemoteComposeServicePath(constants.ServiceLoki, "data")}
+func LokiFoldersToCreate(config constants.Config) []string {
+	return []string{config.ServicePath(constants.ServiceLoki, "data")}
 }
@@ -5,27 +5,54 @@ package services
 
 import (
 	"embed"
+	"io/fs"
 
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
 )
 
 //go:embed templates/*
 var templates embed.FS
 
+// RenderOp holds the options of the Render functions
+type RenderOp struct {
+	templateOverrides fs.FS
+}
+
+// RenderOption configures the Render functions
+type RenderOption func(*RenderOp)
+
+// WithTemplateOverrides renders from the files of overrides that have the path of an embedded
+// template (e.g. templates/grafana.ini) instead of the embedded one. A nil overrides only
+// uses the embedded templates
+func WithTemplateOverrides(overrides fs.FS) RenderOption {
+	return func(op *RenderOp) {
+		op.templateOverrides = overrides
+	}
+}
+
+func readTemplate(name string, opts []RenderOption) ([]byte, error) {
+	op := RenderOp{}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	return utils.ReadFileWithOverrides(op.templateOverrides, templates, name)
+}
+
 // RemoteFoldersToCreateMonitoring returns a list of folders that need to be created on the remote Monitoring server
-func RemoteFoldersToCreateMonitoring() []string {
+func RemoteFoldersToCreateMonitoring(config constants.Config) []string {
 	return utils.AppendSlices[string](
-		GrafanaFoldersToCreate(),
-		LokiFoldersToCreate(),
-		PrometheusFoldersToCreate(),
-		PromtailFoldersToCreate(),
+		GrafanaFoldersToCreate(config),
+		LokiFoldersToCreate(config),
+		PrometheusFoldersToCreate(config),
+		PromtailFoldersToCreate(config),
 	)
 }
 
 // RemoteFoldersToCreateOdysseygo returns a list of folders that need to be created on the remote Odysseygo server
-func RemoteFoldersToCreateOdysseygo() []string {
+func RemoteFoldersToCreateOdysseygo(config constants.Config) []string {
 	return utils.AppendSlices[string](
-		OdysseyFolderToCreate(),
-		PromtailFoldersToCreate(),
+		OdysseyFolderToCreate(config),
+		PromtailFoldersToCreate(config),
 	)
 }
@@ -5,7 +5,6 @@ package services
 
 import (
 	"bytes"
-	"path/filepath"
 	"strings"
 	"text/template"
 
@@ -26,6 +25,11 @@ type OdysseyConfigInputs struct {
 	BootstrapIDs     string
 	BootstrapIPs     string
 	GenesisPath      string
+
+	// HTTPPort and StakingPort are set for the nodes not using the standard odysseygo ports,
+	// see SetPorts
+	HTTPPort    uint
+	StakingPort uint
 }
 
 func PrepareOdysseyConfig(publicIP string, networkID string, subnetsToTrack []string) OdysseyConfigInputs {
@@ -41,8 +45,21 @@ func PrepareOdysseyConfig(publicIP string, networkID string, subnetsToTrack []st
 	}
 }
 
-func RenderOdysseyTemplate(templateName string, config OdysseyConfigInputs) ([]byte, error) {
-	templateBytes, err := templates.ReadFile(templateName)
+// SetPorts sets the odysseygo API and staking ports of config, if they are not the standard
+// ones
+func (c *OdysseyConfigInputs) SetPorts(config constants.Config) {
+	config = config.WithDefaults()
+	c.HTTPPort, c.StakingPort = 0, 0
+	if config.OdysseygoAPIPort != constants.OdysseygoAPIPort {
+		c.HTTPPort = config.OdysseygoAPIPort
+	}
+	if config.OdysseygoP2PPort != constants.OdysseygoP2PPort {
+		c.StakingPort = config.OdysseygoP2PPort
+	}
+}
+
+func RenderOdysseyTemplate(templateName string, config OdysseyConfigInputs, opts ...RenderOption) ([]byte, error) {
+	templateBytes, err := readTemplate(templateName, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -59,46 +76,71 @@ func RenderOdysseyTemplate(templateName string, config OdysseyConfigInputs) ([]b
 	return buf.Bytes(), nil
 }
 
-func RenderOdysseyNodeConfig(config OdysseyConfigInputs) ([]byte, error) {
-	if output, err := RenderOdysseyTemplate("templates/odyssey-node.tmpl", config); err != nil {
+func RenderOdysseyNodeConfig(config OdysseyConfigInputs, opts ...RenderOption) ([]byte, error) {
+	if output, err := RenderOdysseyTemplate("templates/odyssey-node.tmpl", config, opts...); err != nil {
 		return nil, err
 	} else {
 		return output, nil
 	}
 }
 
-func RenderOdysseyDChainConfig(config OdysseyConfigInputs) ([]byte, error) {
-	if output, err := RenderOdysseyTemplate("templates/odyssey-dchain.tmp", config); err != nil {
+func RenderOdysseyDChainConfig(config OdysseyConfigInputs, opts ...RenderOption) ([]byte, error) {
+	if output, err := RenderOdysseyTemplate("templates/odyssey-dchain.tmpl", config, opts...); err != nil {
 		return nil, err
 	} else {
 		return output, nil
 	}
 }
 
-func GetRemoteBLSKeyFile() string {
-	return filepath.Join("/home/ubuntu/.odysseygo/staking/", constants.BLSKeyFileName)
+func GetRemoteBLSKeyFile(config constants.Config) string {
+	return config.OdysseyGoPath("staking", constants.BLSKeyFileName)
+}
+
+func GetRemoteOdysseyNodeConfig(config constants.Config) string {
+	return config.OdysseyGoPath("configs", "node.json")
+}
+
+func GetRemoteOdysseyDChainConfig(config constants.Config) string {
+	return config.OdysseyGoPath("configs", "chains", "C", "config.json")
+}
+
+func GetRemoteOdysseyGenesis(config constants.Config) string {
+	return config.OdysseyGoPath("configs", "genesis.json")
+}
+
+// GetRemoteOdysseyChainConfigDir returns the directory odysseygo reads the config, upgrade and
+// genesis files of blockchainID from
+func GetRemoteOdysseyChainConfigDir(config constants.Config, blockchainID string) string {
+	return config.OdysseyGoPath("configs", "chains", blockchainID)
+}
+
+// GetRemoteOdysseyVMAliasesFile returns the path of the VM aliases file read by odysseygo
+func GetRemoteOdysseyVMAliasesFile(config constants.Config) string {
+	return config.OdysseyGoPath("configs", "vms", "aliases.json")
 }
 
-func GetRemoteOdysseyNodeConfig() string {
-	return filepath.Join("/home/ubuntu/.odysseygo/configs/", "node.json")
+// GetRemoteOdysseyChainAliasesFile returns the path of the chain aliases file read by odysseygo
+func GetRemoteOdysseyChainAliasesFile(config constants.Config) string {
+	return config.OdysseyGoPath("configs", "chains", "aliases.json")
 }
 
-func GetRemoteOdysseyDChainConfig() string {
-	return filepath.Join("/home/ubuntu/.odysseygo/configs/", "chains", "C", "config.json")
+// GetRemoteOdysseySubnetConfig returns the path of the config file of subnetID read by odysseygo
+func GetRemoteOdysseySubnetConfig(config constants.Config, subnetID string) string {
+	return config.OdysseyGoPath("configs", "subnets", subnetID+".json")
 }
 
-func GetRemoteOdysseyGenesis() string {
-	return filepath.Join("/home/ubuntu/.odysseygo/configs/", "genesis.json")
+func GetRemoteOdysseyDBDir(config constants.Config) string {
+	return config.OdysseyGoPath("db")
 }
 
-func OdysseyFolderToCreate() []string {
+func OdysseyFolderToCreate(config constants.Config) []string {
 	return []string{
-		"/home/ubuntu/.odysseygo/db",
-		"/home/ubuntu/.odysseygo/logs",
-		"/home/ubuntu/.odysseygo/configs",
-		"/home/ubuntu/.odysseygo/configs/subnets/",
-		"/home/ubuntu/.odysseygo/configs/chains/C",
-		"/home/ubuntu/.odysseygo/staking",
-		"/home/ubuntu/.odysseygo/plugins",
+		config.OdysseyGoPath("db"),
+		config.OdysseyGoPath("logs"),
+		config.OdysseyGoPath("configs"),
+		config.OdysseyGoPath("configs", "subnets"),
+		config.OdysseyGoPath("configs", "chains", "C"),
+		config.OdysseyGoPath("staking"),
+		config.OdysseyGoPath("plugins"),
 	}
 }
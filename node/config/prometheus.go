@@ -5,12 +5,12 @@ package services
 
 import (
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
-	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
 )
 
-func PrometheusFoldersToCreate() []string {
+func PrometheusFoldersToCreate(config constants.Config) []string {
 	return []string{
-		utils.GetRemoteComposeServicePath(constants.ServicePrometheus),
-		utils.GetRemoteComposeServicePath(constants.ServicePrometheus, "data"),
+		config.ServicePath(constants.ServicePrometheus),
+		config.ServicePath(constants.ServicePrometheus, "data"),
+		config.ServicePath(constants.ServicePrometheus, "rules"),
 	}
 }
@@ -5,12 +5,11 @@ package services
 
 import (
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
-	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
 )
 
-func PromtailFoldersToCreate() []string {
+func PromtailFoldersToCreate(config constants.Config) []string {
 	return []string{
-		utils.GetRemoteComposeServicePath(constants.ServicePromtail),
-		"/home/ubuntu/.odysseygo/logs",
+		config.ServicePath(constants.ServicePromtail),
+		config.OdysseyGoPath("logs"),
 	}
 }
@@ -0,0 +1,19 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package services
+
+import (
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+)
+
+func AWMRelayerFoldersToCreate(config constants.Config) []string {
+	return []string{
+		config.ServicePath(constants.ServiceAWMRelayer),
+		config.ServicePath(constants.ServiceAWMRelayer, "storage"),
+	}
+}
+
+func GetRemoteAWMRelayerConfig(config constants.Config) string {
+	return config.ServicePath(constants.ServiceAWMRelayer, constants.AWMRelayerConfigFileName)
+}
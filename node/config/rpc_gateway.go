@@ -0,0 +1,180 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package services
+
+import (
+	"bytes"
+	"path"
+	"text/template"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+)
+
+const (
+	// rpcGatewayTLSCertFile and rpcGatewayTLSKeyFile are the paths of the uploaded TLS
+	// certificate and key in the gateway container
+	rpcGatewayTLSCertFile = "/etc/nginx/tls/tls.crt"
+	rpcGatewayTLSKeyFile  = "/etc/nginx/tls/tls.key"
+
+	// rpcGatewayACMEDir is the path of the certificates obtained with ACME in the gateway
+	// container
+	rpcGatewayACMEDir = "/etc/letsencrypt/live"
+)
+
+// RPCGatewayConfigInputs holds the settings of the RPC gateway reverse proxy
+type RPCGatewayConfigInputs struct {
+	// ServerName is the domain the gateway is served on, any domain when empty
+	ServerName string
+
+	// TLS is true if the gateway serves HTTPS, redirecting plain HTTP requests to it
+	TLS bool
+
+	// ACME is true if the certificate of ServerName is obtained with ACME. The gateway then
+	// serves the HTTP-01 challenges, and the certificate obtained if TLS is true
+	ACME bool
+
+	// RateLimit is the number of requests per second allowed to each client IP,
+	// and RateLimitBurst the number of requests above it queued before rejecting them
+	RateLimit      uint
+	RateLimitBurst uint
+
+	// APIKeys restrict the chain RPC endpoints to the clients sending one of the keys, each
+	// key being rate limited instead of the client IPs
+	APIKeys []RPCGatewayAPIKeyInputs
+
+	// AllowedMethods and BlockedMethods filter the JSON-RPC methods of the chain RPC
+	// requests, see RenderRPCGatewayMethodFilter
+	AllowedMethods []string
+	BlockedMethods []string
+
+	// AccessLog is true if the requests are logged as JSON lines into the rpc-gateway
+	// folder of the odysseygo logs, shipped to Loki by promtail
+	AccessLog bool
+
+	// HTTPPort and HTTPSPort are the ports the gateway listens on, and OdysseygoAPIPort the
+	// port of the odysseygo API it proxies. The standard ports are used when zero
+	HTTPPort         uint
+	HTTPSPort        uint
+	OdysseygoAPIPort uint
+}
+
+// RPCGatewayAPIKeyInputs holds an API key of the RPC gateway and the rate limit of its
+// client
+type RPCGatewayAPIKeyInputs struct {
+	Name           string
+	Key            string
+	RateLimit      uint
+	RateLimitBurst uint
+}
+
+// MethodFilter returns true if the JSON-RPC methods of the chain RPC requests are filtered
+func (inputs RPCGatewayConfigInputs) MethodFilter() bool {
+	return len(inputs.AllowedMethods) > 0 || len(inputs.BlockedMethods) > 0
+}
+
+// TLSCertFile returns the path of the TLS certificate in the gateway container
+func (inputs RPCGatewayConfigInputs) TLSCertFile() string {
+	if inputs.ACME {
+		return path.Join(rpcGatewayACMEDir, inputs.ServerName, "fullchain.pem")
+	}
+	return rpcGatewayTLSCertFile
+}
+
+// TLSKeyFile returns the path of the TLS key in the gateway container
+func (inputs RPCGatewayConfigInputs) TLSKeyFile() string {
+	if inputs.ACME {
+		return path.Join(rpcGatewayACMEDir, inputs.ServerName, "privkey.pem")
+	}
+	return rpcGatewayTLSKeyFile
+}
+
+// RenderRPCGatewayConfig renders the nginx server config of the gateway
+func RenderRPCGatewayConfig(inputs RPCGatewayConfigInputs, opts ...RenderOption) ([]byte, error) {
+	if inputs.HTTPPort == 0 {
+		inputs.HTTPPort = constants.RPCGatewayHTTPPort
+	}
+	if inputs.HTTPSPort == 0 {
+		inputs.HTTPSPort = constants.RPCGatewayHTTPSPort
+	}
+	if inputs.OdysseygoAPIPort == 0 {
+		inputs.OdysseygoAPIPort = constants.OdysseygoAPIPort
+	}
+	return renderRPCGatewayTemplate("templates/rpc-gateway.conf", inputs, opts)
+}
+
+// RenderRPCGatewayAPIKeys renders the nginx map of the API keys of the gateway to the names
+// of their clients. It is kept apart from the server config so that the keys are not
+// collected with it, e.g. into support bundles
+func RenderRPCGatewayAPIKeys(inputs RPCGatewayConfigInputs, opts ...RenderOption) ([]byte, error) {
+	return renderRPCGatewayTemplate("templates/rpc-gateway-api-keys.conf", inputs, opts)
+}
+
+// RenderRPCGatewayMethodFilter renders the njs script filtering the JSON-RPC methods of the
+// chain RPC requests. A method is refused if it matches one of BlockedMethods, or if
+// AllowedMethods is not empty and it matches none of them. Patterns ending with * match the
+// methods starting with what precedes it, e.g. debug_*. Batch requests are refused if any
+// of their methods is. The messages of websocket subscriptions are not filtered
+func RenderRPCGatewayMethodFilter(inputs RPCGatewayConfigInputs, opts ...RenderOption) ([]byte, error) {
+	return renderRPCGatewayTemplate("templates/rpc-gateway.js", inputs, opts)
+}
+
+// RenderRPCGatewayMainConfig renders the main nginx config of the gateway, loading the njs
+// module of its method filter
+func RenderRPCGatewayMainConfig(opts ...RenderOption) ([]byte, error) {
+	return renderRPCGatewayTemplate("templates/rpc-gateway-nginx.conf", nil, opts)
+}
+
+func renderRPCGatewayTemplate(name string, inputs any, opts []RenderOption) ([]byte, error) {
+	templateBytes, err := readTemplate(name, opts)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New(path.Base(name)).Parse(string(templateBytes))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, inputs); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func RPCGatewayFoldersToCreate(config constants.Config) []string {
+	return []string{
+		config.ServicePath(constants.ServiceRPCGateway),
+		config.ServicePath(constants.ServiceRPCGateway, "tls"),
+		config.ServicePath(constants.ServiceRPCGateway, "acme"),
+		config.ServicePath(constants.ServiceRPCGateway, "letsencrypt"),
+		GetRemoteRPCGatewayAccessLogDir(config),
+	}
+}
+
+func GetRemoteRPCGatewayConfig(config constants.Config) string {
+	return config.ServicePath(constants.ServiceRPCGateway, "nginx.conf")
+}
+
+func GetRemoteRPCGatewayMainConfig(config constants.Config) string {
+	return config.ServicePath(constants.ServiceRPCGateway, "nginx-main.conf")
+}
+
+func GetRemoteRPCGatewayAPIKeys(config constants.Config) string {
+	return config.ServicePath(constants.ServiceRPCGateway, "api-keys.conf")
+}
+
+func GetRemoteRPCGatewayMethodFilter(config constants.Config) string {
+	return config.ServicePath(constants.ServiceRPCGateway, "rpc.js")
+}
+
+// GetRemoteRPCGatewayACMECertificate returns the remote path of the certificate of
+// serverName obtained with ACME
+func GetRemoteRPCGatewayACMECertificate(config constants.Config, serverName string) string {
+	return config.ServicePath(constants.ServiceRPCGateway, "letsencrypt", "live", serverName, "fullchain.pem")
+}
+
+// GetRemoteRPCGatewayAccessLogDir returns the remote folder of the gateway access logs, in
+// the odysseygo logs folder scraped by promtail
+func GetRemoteRPCGatewayAccessLogDir(config constants.Config) string {
+	return config.OdysseyGoPath("logs", constants.ServiceRPCGateway)
+}
@@ -0,0 +1,49 @@
+// JSON-RPC method filter of the RPC gateway. A method is refused if it matches one of the
+// blocked patterns, or if there are allowed patterns and it matches none of them. Patterns
+// ending with * match the methods starting with what precedes it.
+
+const allowed = [{{ range $i, $method := .AllowedMethods }}{{ if $i }}, {{ end }}{{ printf "%q" $method }}{{ end }}];
+const blocked = [{{ range $i, $method := .BlockedMethods }}{{ if $i }}, {{ end }}{{ printf "%q" $method }}{{ end }}];
+
+function matches(patterns, method) {
+    return patterns.some((pattern) => pattern.endsWith('*')
+        ? method.startsWith(pattern.slice(0, -1))
+        : method === pattern);
+}
+
+function permitted(method) {
+    if (typeof method !== 'string' || matches(blocked, method)) {
+        return false;
+    }
+    return allowed.length === 0 || matches(allowed, method);
+}
+
+function refuse(r, status, id, code, message) {
+    r.headersOut['Content-Type'] = 'application/json';
+    r.return(status, JSON.stringify({ jsonrpc: '2.0', id: id, error: { code: code, message: message } }));
+}
+
+// filter proxies the requests whose methods are all permitted to odysseygo. Requests without
+// a body, e.g. websocket upgrades, are not filtered
+function filter(r) {
+    const body = r.requestText;
+    if (body) {
+        let calls;
+        try {
+            calls = JSON.parse(body);
+        } catch (e) {
+            refuse(r, 400, null, -32700, 'parse error');
+            return;
+        }
+        for (const call of Array.isArray(calls) ? calls : [calls]) {
+            if (call === null || typeof call !== 'object' || !permitted(call.method)) {
+                const id = call !== null && typeof call === 'object' && call.id !== undefined ? call.id : null;
+                refuse(r, 403, id, -32601, 'method not allowed');
+                return;
+            }
+        }
+    }
+    r.internalRedirect('@odysseygo');
+}
+
+export default { filter };
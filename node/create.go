@@ -5,10 +5,16 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/progress"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/releases"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/vm"
 )
 
 // NodeParams is an input for CreateNodes
@@ -29,11 +35,81 @@ type NodeParams struct {
 	SubnetIDs []string
 
 	// SSHPrivateKeyPath is the file path to the private key of the SSH key pair that is used
-	// to gain access to the created nodes
+	// to gain access to the created nodes. Use GenerateSSHKeyPair to create a new key pair
 	SSHPrivateKeyPath string
 
-	// OdysseyGoVersion is the version of Odyssey Go to install in the created node
+	// OdysseyGoVersion is the version of Odyssey Go to install in the created node, or
+	// releases.Latest for the latest stable release of Network
 	OdysseyGoVersion string
+
+	// SubnetEVMVersion is the Subnet-EVM release run by the subnets the nodes track. When set
+	// with OdysseyGoVersion, provisioning refuses releases the nodes cannot run together,
+	// see vm.CheckSubnetEVMCompatibility
+	SubnetEVMVersion string
+
+	// RuntimeMode is how the services of Validator and API nodes are run, DockerRuntime if
+	// empty. See CheckRuntimeMode
+	RuntimeMode RuntimeMode
+
+	// Relayer configures the AWM relayer of nodes with the Relayer role
+	Relayer *RelayerParams
+
+	// RPCGateway configures the public RPC reverse proxy of nodes with the RPCGateway role
+	RPCGateway *RPCGatewayParams
+
+	// ClusterName and Owner are set as the cluster and owner labels of the nodes
+	ClusterName string
+	Owner       string
+
+	// Labels are custom labels of the nodes, in addition to the standard ones.
+	// See ResourceLabels
+	Labels map[string]string
+
+	// NTPServers are added to the time sources of the chrony service installed on the
+	// Validator and API nodes. The distribution default sources are used when empty
+	NTPServers []string
+
+	// Hardening applies a baseline hardening to the nodes before provisioning their roles,
+	// see RunSSHHardenHost. The nodes are not hardened if nil
+	Hardening *HardeningParams
+
+	// Preset configures odysseygo on Validator and API nodes for their use case, e.g.
+	// ArchiveRPCPreset or PrunedValidatorPreset, unless the node has its own Preset
+	Preset NodePreset
+
+	// Config sets the ports and remote folders of the nodes, unless the node has its own
+	// Config. The standard ones are used when zero
+	Config constants.Config
+
+	// ExecutionPolicy restricts the commands of the scripts and compose files rendered for
+	// the nodes, unless the node has its own ExecutionPolicy
+	ExecutionPolicy *ExecutionPolicy
+
+	// TemplateOverrides customizes the compose files and service configs rendered for the
+	// nodes, unless the node has its own TemplateOverrides. See TemplateOverridesDir
+	TemplateOverrides fs.FS
+
+	// Progress receives the provisioning progress of each node, unless the node has its own
+	// Progress reporter
+	Progress progress.Reporter
+}
+
+// ErrCloudRemoved is returned by operations that relied on cloud functionality,
+// which has been removed from this SDK
+var ErrCloudRemoved = errors.New("cloud functionality has been removed from this SDK")
+
+// ErrUnsupportedCloud is returned when an operation targets a cloud provider
+type ErrUnsupportedCloud struct {
+	// Cloud is the requested cloud provider, e.g. aws or gcp
+	Cloud string
+}
+
+func (e *ErrUnsupportedCloud) Error() string {
+	return fmt.Sprintf("unsupported cloud %s: %s", e.Cloud, ErrCloudRemoved)
+}
+
+func (e *ErrUnsupportedCloud) Unwrap() error {
+	return ErrCloudRemoved
 }
 
 // CreateNodes is a placeholder function for node creation.
@@ -43,7 +119,7 @@ func CreateNodes(
 	ctx context.Context,
 	nodeParams *NodeParams,
 ) ([]Node, error) {
-	return nil, fmt.Errorf("cloud functionality has been removed from this SDK. Please use local node setup instead")
+	return nil, fmt.Errorf("%w. Please use local node setup instead", ErrCloudRemoved)
 }
 
 // provisionHost provisions a host with the given roles.
@@ -51,17 +127,53 @@ func provisionHost(node Node, nodeParams *NodeParams) error {
 	if err := CheckRoles(nodeParams.Roles); err != nil {
 		return err
 	}
+	if err := CheckRuntimeMode(nodeParams.RuntimeMode, nodeParams.Roles); err != nil {
+		return err
+	}
+	nodeParams, err := resolveOdysseyGoVersion(nodeParams)
+	if err != nil {
+		return err
+	}
+	if err := checkVMCompatibility(node, nodeParams); err != nil {
+		return err
+	}
+	if node.Progress == nil {
+		node.Progress = nodeParams.Progress
+	}
+	if node.Preset == DefaultPreset {
+		node.Preset = nodeParams.Preset
+	}
+	if node.Config == (constants.Config{}) {
+		node.Config = nodeParams.Config
+	}
+	if node.ExecutionPolicy == nil {
+		node.ExecutionPolicy = nodeParams.ExecutionPolicy
+	}
+	if node.TemplateOverrides == nil {
+		node.TemplateOverrides = nodeParams.TemplateOverrides
+	}
+	steps := len(nodeParams.Roles) + 1
+	if nodeParams.Hardening != nil {
+		steps++
+	}
+	tracker := progress.NewTracker(node.Progress, node.NodeID, steps)
+	tracker.Step(progress.StageConnect, fmt.Sprintf("connecting to %s", node.IP))
 	if err := node.Connect(constants.SSHTCPPort); err != nil {
 		return err
 	}
+	if nodeParams.Hardening != nil {
+		tracker.Step(progress.StageProvision, "hardening host")
+		report, err := node.RunSSHHardenHost(*nodeParams.Hardening)
+		if err != nil {
+			return err
+		}
+		node.Logger.Infof("%s", report)
+	}
 	for _, role := range nodeParams.Roles {
+		tracker.Step(progress.StageProvision, fmt.Sprintf("provisioning %s role", role.String()))
 		switch role {
-		case Validator:
-			if err := provisionOdysseyGoHost(node, nodeParams); err != nil {
-				return err
-			}
-		case API:
-			if err := provisionOdysseyGoHost(node, nodeParams); err != nil {
+		case Validator, API:
+			if err := provisionOdysseyGo(node, nodeParams); err != nil {
 				return err
 			}
 		case Loadtest:
@@ -72,23 +184,68 @@ func provisionHost(node Node, nodeParams *NodeParams) error {
 			if err := provisionMonitoringHost(node); err != nil {
 				return err
 			}
+		case Relayer:
+			if err := provisionRelayerHost(node, nodeParams); err != nil {
+				return err
+			}
+		case RPCGateway:
+			if err := provisionRPCGatewayHost(node, nodeParams); err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("unsupported role %v", role)
 		}
 	}
+	tracker.Done("node provisioned")
 	return nil
 }
 
+// resolveOdysseyGoVersion returns nodeParams with the latest odysseygo release of its network
+// if OdysseyGoVersion is releases.Latest. nodeParams is shared by the provisioned nodes, so it
+// is copied instead of updated
+func resolveOdysseyGoVersion(nodeParams *NodeParams) (*NodeParams, error) {
+	if nodeParams.OdysseyGoVersion != releases.Latest {
+		return nodeParams, nil
+	}
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	version, err := releases.LatestOdysseygoVersion(ctx, nodeParams.Network)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest odysseygo version: %w", err)
+	}
+	resolved := *nodeParams
+	resolved.OdysseyGoVersion = version
+	return &resolved, nil
+}
+
+// checkVMCompatibility fails if nodeParams Subnet-EVM release cannot run on its odysseygo
+// release. Releases missing from the compatibility table are only warned about
+func checkVMCompatibility(node Node, nodeParams *NodeParams) error {
+	if nodeParams.OdysseyGoVersion == "" || nodeParams.SubnetEVMVersion == "" {
+		return nil
+	}
+	err := vm.CheckSubnetEVMCompatibility(nodeParams.OdysseyGoVersion, nodeParams.SubnetEVMVersion)
+	if errors.Is(err, vm.ErrUnknownOdysseyGoVersion) || errors.Is(err, vm.ErrUnknownSubnetEVMVersion) {
+		node.Logger.Warnf("cannot check the compatibility of node %s VM: %s", node.NodeID, err)
+		return nil
+	}
+	return err
+}
+
 func provisionOdysseyGoHost(node Node, nodeParams *NodeParams) error {
 	const withMonitoring = true
 	if err := node.RunSSHSetupNode(); err != nil {
 		return err
 	}
+	// validators drifting from the network time misbehave, e.g. by rejecting valid blocks
+	if err := node.RunSSHSetupTimeSync(nodeParams.NTPServers); err != nil {
+		return err
+	}
 	if err := node.RunSSHSetupDockerService(); err != nil {
 		return err
 	}
 	// provide dummy config for promtail
-	if err := node.RunSSHSetupPromtailConfig("127.0.0.1", constants.OdysseygoLokiPort, node.NodeID, ""); err != nil {
+	if err := node.RunSSHSetupPromtailConfig("127.0.0.1", int(node.config().LokiPort), node.NodeID, ""); err != nil {
 		return err
 	}
 	if err := node.ComposeSSHSetupNode(nodeParams.Network.HRP(), nodeParams.SubnetIDs, nodeParams.OdysseyGoVersion, withMonitoring); err != nil {
@@ -97,13 +254,18 @@ func provisionOdysseyGoHost(node Node, nodeParams *NodeParams) error {
 	if err := node.StartDockerCompose(constants.SSHScriptTimeout); err != nil {
 		return err
 	}
+	node.checkPresetDataVolume()
 	return nil
 }
 
 func provisionLoadTestHost(node Node) error { // stub
-	if err := node.ComposeSSHSetupLoadTest(); err != nil {
+	changed, err := node.composeSSHSetupLoadTest()
+	if err != nil {
 		return err
 	}
+	if !changed {
+		return nil
+	}
 	if err := node.RestartDockerCompose(constants.SSHScriptTimeout); err != nil {
 		return err
 	}
@@ -117,9 +279,13 @@ func provisionMonitoringHost(node Node) error {
 	if err := node.RunSSHSetupMonitoringFolders(); err != nil {
 		return err
 	}
-	if err := node.ComposeSSHSetupMonitoring(); err != nil {
+	changed, err := node.composeSSHSetupMonitoring()
+	if err != nil {
 		return err
 	}
+	if !changed {
+		return nil
+	}
 	if err := node.RestartDockerCompose(constants.SSHScriptTimeout); err != nil {
 		return err
 	}
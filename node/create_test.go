@@ -5,9 +5,12 @@ package node
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/vm"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateNodes_CloudFunctionalityRemoved(t *testing.T) {
@@ -24,3 +27,46 @@ func TestCreateNodes_CloudFunctionalityRemoved(t *testing.T) {
 	assert.Nil(t, nodes)
 	assert.Contains(t, err.Error(), "cloud functionality has been removed")
 }
+
+func TestCloudErrors(t *testing.T) {
+	_, err := CreateNodes(context.Background(), &NodeParams{})
+	assert.ErrorIs(t, err, ErrCloudRemoved)
+	assert.ErrorIs(t, (&Node{}).Destroy(context.Background()), ErrCloudRemoved)
+
+	err = checkLocalBackupPath("s3://bucket/backups")
+	var unsupportedCloud *ErrUnsupportedCloud
+	require.True(t, errors.As(err, &unsupportedCloud))
+	assert.Equal(t, "aws", unsupportedCloud.Cloud)
+	assert.ErrorIs(t, err, ErrCloudRemoved)
+}
+
+func TestNode_Connect_ErrNotConnected(t *testing.T) {
+	node := Node{
+		NodeID: "test-node",
+		IP:     "192.168.1.1",
+		SSHConfig: SSHConfig{
+			User:           "ubuntu",
+			PrivateKeyPath: "/path/to/missing/key",
+		},
+	}
+	err := node.Connect(22)
+	assert.ErrorIs(t, err, ErrNotConnected)
+	assert.Contains(t, err.Error(), "failed to connect to node 192.168.1.1")
+}
+
+func TestCheckVMCompatibility(t *testing.T) {
+	node := Node{NodeID: "node-1"}
+	require.NoError(t, checkVMCompatibility(node, &NodeParams{OdysseyGoVersion: "v1.10.9"}))
+	require.NoError(t, checkVMCompatibility(node, &NodeParams{OdysseyGoVersion: "v1.10.9", SubnetEVMVersion: "v0.5.6"}))
+	// unknown releases are only warned about
+	require.NoError(t, checkVMCompatibility(node, &NodeParams{OdysseyGoVersion: "v1.99.0", SubnetEVMVersion: "v0.5.6"}))
+	err := checkVMCompatibility(node, &NodeParams{OdysseyGoVersion: "v1.10.9", SubnetEVMVersion: "v0.5.0"})
+	require.ErrorIs(t, err, vm.ErrIncompatibleVMVersion)
+}
+
+func TestResolveOdysseyGoVersion_Pinned(t *testing.T) {
+	nodeParams := &NodeParams{OdysseyGoVersion: "v1.10.13"}
+	resolved, err := resolveOdysseyGoVersion(nodeParams)
+	require.NoError(t, err)
+	assert.Same(t, nodeParams, resolved)
+}
@@ -8,8 +8,11 @@ import (
 	"fmt"
 )
 
-// Destroy destroys a node.
-// Cloud functionality has been removed from this SDK.
+// Destroy destroys a node. Nodes created by a DockerProvider are removed with their container.
+// Cloud functionality has been removed from this SDK, so other nodes fail with ErrCloudRemoved.
 func (h *Node) Destroy(ctx context.Context) error {
-	return fmt.Errorf("cloud functionality has been removed from this SDK. Please use local node management instead")
+	if client, ok := h.connection.(*dockerExecClient); ok {
+		return removeContainer(ctx, client.container)
+	}
+	return fmt.Errorf("%w. Please use local node management instead", ErrCloudRemoved)
 }
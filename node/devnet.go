@@ -0,0 +1,139 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+)
+
+// DevnetParams defines a private Odyssey network whose primary network is validated from
+// genesis by a set of nodes
+type DevnetParams struct {
+	// Genesis defines the devnet. The nodes are added to its stakers
+	Genesis odyssey.DevnetGenesisParams
+
+	// RewardAddress receives the validation rewards of the nodes
+	RewardAddress ids.ShortID
+
+	// DelegationFee is the delegation fee of the nodes, in units of 1/10,000 percent
+	DelegationFee uint32
+
+	// StakingKeysDir is the local directory where new staking files are generated for each
+	// node, in a sub directory named after its IP, before being uploaded to it. If empty,
+	// the nodes keep their staking files and NodeID must be set to their Odyssey node ID
+	StakingKeysDir string
+}
+
+// ProvisionDevnet generates the genesis of a devnet validated by nodes, and bootstraps them
+// from it: each node gets the genesis file and a config bootstrapping from the other nodes,
+// and odysseygo is restarted. The nodes must already be provisioned, e.g. with
+// ProvisionNodes. When new staking files are generated, the NodeID of the nodes is set to
+// their new Odyssey node ID.
+//
+// ProvisionDevnet returns the genesis, and the results of the nodes as the fleet operations
+// do. The error is set if the genesis cannot be generated, in which case no node is updated
+func ProvisionDevnet(ctx context.Context, nodes []*Node, params DevnetParams, limit int) ([]byte, *NodeResults, error) {
+	genesisParams := params.Genesis
+	genesisParams.Stakers = append([]odyssey.DevnetStaker{}, genesisParams.Stakers...)
+	nodeIDs := make([]ids.NodeID, 0, len(nodes))
+	for _, node := range nodes {
+		nodeID, err := devnetNodeID(node, params.StakingKeysDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		nodeIDs = append(nodeIDs, nodeID)
+		genesisParams.Stakers = append(genesisParams.Stakers, odyssey.DevnetStaker{
+			NodeID:        nodeID,
+			RewardAddress: params.RewardAddress,
+			DelegationFee: params.DelegationFee,
+		})
+	}
+	genesisBytes, err := odyssey.GenerateDevnetGenesis(genesisParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the nodes are only updated once the genesis is valid
+	for i, node := range nodes {
+		node.NodeID = nodeIDs[i].String()
+	}
+	results := RunOnNodes(nodes, limit, func(node *Node) (interface{}, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if params.StakingKeysDir != "" {
+			if err := node.RunSSHUploadStakingFiles(devnetStakingKeysDir(params.StakingKeysDir, node)); err != nil {
+				return nil, err
+			}
+		}
+		if err := node.RunSSHUploadDevnetConfig(genesisParams.NetworkID, genesisBytes, nodes); err != nil {
+			return nil, err
+		}
+		return nil, node.RunSSHRestartOdysseygo()
+	})
+	return genesisBytes, results, nil
+}
+
+// devnetNodeID returns the Odyssey node ID of node, generating its staking files in
+// stakingKeysDir if set. node is not updated
+func devnetNodeID(node *Node, stakingKeysDir string) (ids.NodeID, error) {
+	if stakingKeysDir == "" {
+		nodeID, err := ids.NodeIDFromString(node.NodeID)
+		if err != nil {
+			return ids.EmptyNodeID, fmt.Errorf("invalid node ID of node %s: %w", node.IP, err)
+		}
+		return nodeID, nil
+	}
+	return GenerateStakingFiles(devnetStakingKeysDir(stakingKeysDir, node))
+}
+
+func devnetStakingKeysDir(stakingKeysDir string, node *Node) string {
+	return filepath.Join(stakingKeysDir, node.IP)
+}
+
+// RunSSHUploadDevnetConfig uploads the genesis of the devnet networkID, and the odysseygo
+// config of the node bootstrapping from the other devnet nodes. Odysseygo must be restarted
+// to apply it
+func (h *Node) RunSSHUploadDevnetConfig(networkID uint32, genesisBytes []byte, devnetNodes []*Node) error {
+	if err := h.MkdirAll(filepath.Dir(remoteconfig.GetRemoteOdysseyGenesis(h.config())), constants.SSHFileOpsTimeout); err != nil {
+		return err
+	}
+	if err := h.UploadBytes(genesisBytes, remoteconfig.GetRemoteOdysseyGenesis(h.config()), constants.SSHFileOpsTimeout); err != nil {
+		return err
+	}
+	nodeConf, err := remoteconfig.RenderOdysseyNodeConfig(devnetNodeConfig(h, networkID, devnetNodes), h.renderOptions()...)
+	if err != nil {
+		return err
+	}
+	return h.UploadBytes(nodeConf, remoteconfig.GetRemoteOdysseyNodeConfig(h.config()), constants.SSHFileOpsTimeout)
+}
+
+// devnetNodeConfig returns the odysseygo config of node in the devnet networkID, bootstrapping
+// from the other devnet nodes
+func devnetNodeConfig(node *Node, networkID uint32, devnetNodes []*Node) remoteconfig.OdysseyConfigInputs {
+	config := remoteconfig.PrepareOdysseyConfig(node.IP, strconv.FormatUint(uint64(networkID), 10), nil)
+	config.SetPorts(node.Config)
+	config.GenesisPath = remoteconfig.GetRemoteOdysseyGenesis(node.config())
+	bootstrapIDs := []string{}
+	bootstrapIPs := []string{}
+	for _, devnetNode := range devnetNodes {
+		if devnetNode.IP == node.IP {
+			continue
+		}
+		bootstrapIDs = append(bootstrapIDs, devnetNode.NodeID)
+		bootstrapIPs = append(bootstrapIPs, net.JoinHostPort(devnetNode.IP, strconv.FormatUint(uint64(devnetNode.config().OdysseygoP2PPort), 10)))
+	}
+	config.BootstrapIDs = strings.Join(bootstrapIDs, ",")
+	config.BootstrapIPs = strings.Join(bootstrapIPs, ",")
+	return config
+}
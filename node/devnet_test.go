@@ -0,0 +1,77 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevnetNodeConfig(t *testing.T) {
+	nodes := []*Node{
+		{NodeID: "NodeID-A", IP: "10.0.0.1"},
+		{NodeID: "NodeID-B", IP: "10.0.0.2"},
+		{NodeID: "NodeID-C", IP: "10.0.0.3"},
+	}
+	config := devnetNodeConfig(nodes[1], 1337, nodes)
+	assert.Equal(t, "1337", config.NetworkID)
+	assert.Equal(t, "10.0.0.2", config.PublicIP)
+	assert.Equal(t, remoteconfig.GetRemoteOdysseyGenesis(constants.Config{}), config.GenesisPath)
+	assert.Equal(t, "NodeID-A,NodeID-C", config.BootstrapIDs)
+	assert.Equal(t, "10.0.0.1:9651,10.0.0.3:9651", config.BootstrapIPs)
+
+	nodeConf, err := remoteconfig.RenderOdysseyNodeConfig(config)
+	require.NoError(t, err)
+	assert.Contains(t, string(nodeConf), `"genesis-file": "/home/ubuntu/.odysseygo/configs/genesis.json"`)
+	assert.Contains(t, string(nodeConf), `"bootstrap-ids": "NodeID-A,NodeID-C"`)
+
+	// a single node devnet bootstraps alone
+	config = devnetNodeConfig(nodes[0], 1337, nodes[:1])
+	assert.Empty(t, config.BootstrapIDs)
+	assert.Empty(t, config.BootstrapIPs)
+}
+
+func TestDevnetNodeID(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+	node := &Node{NodeID: nodeID.String(), IP: "10.0.0.1"}
+	got, err := devnetNodeID(node, "")
+	require.NoError(t, err)
+	assert.Equal(t, nodeID, got)
+
+	_, err = devnetNodeID(&Node{NodeID: "i-0123", IP: "10.0.0.1"}, "")
+	assert.ErrorContains(t, err, "invalid node ID of node 10.0.0.1")
+
+	keysDir := t.TempDir()
+	got, err = devnetNodeID(node, keysDir)
+	require.NoError(t, err)
+	assert.NotEqual(t, nodeID, got)
+	assert.Equal(t, nodeID.String(), node.NodeID)
+	assert.FileExists(t, devnetStakingKeysDir(keysDir, node)+"/staker.crt")
+}
+
+func TestProvisionDevnet_InvalidGenesis(t *testing.T) {
+	nodes := []*Node{{NodeID: ids.GenerateTestNodeID().String(), IP: "10.0.0.1"}}
+	_, results, err := ProvisionDevnet(context.Background(), nodes, DevnetParams{
+		Genesis: odyssey.DevnetGenesisParams{NetworkID: 1337},
+	}, 0)
+	assert.ErrorIs(t, err, odyssey.ErrEmptyRewardAddress)
+	assert.Nil(t, results)
+
+	// the node IDs of new staking files are not set on the nodes
+	nodeID := nodes[0].NodeID
+	_, results, err = ProvisionDevnet(context.Background(), nodes, DevnetParams{
+		Genesis:        odyssey.DevnetGenesisParams{NetworkID: 1337},
+		StakingKeysDir: t.TempDir(),
+	}, 0)
+	assert.ErrorIs(t, err, odyssey.ErrEmptyRewardAddress)
+	assert.Nil(t, results)
+	assert.Equal(t, nodeID, nodes[0].NodeID)
+}
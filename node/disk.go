@@ -0,0 +1,225 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+)
+
+const (
+	bytesInGB = 1 << 30
+	// filesystems reserve part of the block device for metadata, so a grown volume
+	// is considered resized once its filesystem reaches this fraction of the new size
+	minFilesystemSizeRatio  = 0.9
+	tmpDataVolumeMountPoint = "/mnt/odysseygo-data-volume"
+)
+
+// partitionDeviceRegex splits partition devices into disk and partition number,
+// e.g. /dev/xvda1 -> /dev/xvda 1 and /dev/nvme0n1p1 -> /dev/nvme0n1 1
+var partitionDeviceRegex = regexp.MustCompile(`^(/dev/(?:nvme\d+n\d+|mmcblk\d+))p(\d+)$|^(/dev/[a-z]+)(\d+)$`)
+
+// fsTypeRegex matches the filesystem types reported by blkid, e.g. ext4 or xfs
+var fsTypeRegex = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// DiskUsage is the usage of the filesystem holding a directory on a node
+type DiskUsage struct {
+	// Device is the block device of the filesystem, e.g. /dev/nvme0n1p1
+	Device string
+
+	// FSType is the filesystem type, e.g. ext4 or xfs
+	FSType string
+
+	// SizeBytes is the total size of the filesystem
+	SizeBytes uint64
+
+	// AvailableBytes is the free space available to non-root users
+	AvailableBytes uint64
+}
+
+// GetDataVolumeUsage returns the usage of the filesystem holding the odysseygo database
+func (h *Node) GetDataVolumeUsage() (DiskUsage, error) {
+	dbDir := remoteconfig.GetRemoteOdysseyDBDir(h.config())
+	output, err := h.Commandf(nil, constants.SSHScriptTimeout, "findmnt -n -o SOURCE,FSTYPE --target %s", dbDir)
+	if err != nil {
+		return DiskUsage{}, fmt.Errorf("%w: %s", err, string(output))
+	}
+	device, fsType, err := parseFindmntOutput(string(output))
+	if err != nil {
+		return DiskUsage{}, err
+	}
+	output, err = h.Commandf(nil, constants.SSHScriptTimeout, "df -B1 --output=size,avail %s", dbDir)
+	if err != nil {
+		return DiskUsage{}, fmt.Errorf("%w: %s", err, string(output))
+	}
+	size, avail, err := parseDfOutput(string(output))
+	if err != nil {
+		return DiskUsage{}, err
+	}
+	return DiskUsage{
+		Device:         device,
+		FSType:         fsType,
+		SizeBytes:      size,
+		AvailableBytes: avail,
+	}, nil
+}
+
+// ResizeDataVolume grows the partition and filesystem holding the odysseygo database to use
+// all the space of its block device, and verifies that the filesystem is at least newSizeGB.
+//
+// The block device itself must have already been grown through the provider (e.g. by
+// modifying an AWS EBS volume or a GCP persistent disk), as cloud functionality has been
+// removed from this SDK. ext4 and xfs filesystems are supported.
+func (h *Node) ResizeDataVolume(ctx context.Context, newSizeGB uint64) error {
+	usage, err := h.GetDataVolumeUsage()
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if disk, partition, ok := splitPartitionDevice(usage.Device); ok {
+		// growpart exits with 1 when there is nothing to grow
+		if output, err := h.Commandf(nil, constants.SSHScriptTimeout, "sudo growpart %s %s || [ $? -eq 1 ]", disk, partition); err != nil {
+			return fmt.Errorf("failed to grow partition %s: %w: %s", usage.Device, err, string(output))
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var resizeCmd string
+	switch usage.FSType {
+	case "ext2", "ext3", "ext4":
+		resizeCmd = fmt.Sprintf("sudo resize2fs %s", usage.Device)
+	case "xfs":
+		resizeCmd = fmt.Sprintf("sudo xfs_growfs %s", remoteconfig.GetRemoteOdysseyDBDir(h.config()))
+	default:
+		return fmt.Errorf("unsupported filesystem %s on %s", usage.FSType, usage.Device)
+	}
+	if output, err := h.Command(nil, constants.SSHLongRunningScriptTimeout, resizeCmd); err != nil {
+		return fmt.Errorf("failed to resize filesystem on %s: %w: %s", usage.Device, err, string(output))
+	}
+	resized, err := h.GetDataVolumeUsage()
+	if err != nil {
+		return err
+	}
+	if float64(resized.SizeBytes) < float64(newSizeGB*bytesInGB)*minFilesystemSizeRatio {
+		return fmt.Errorf("data volume %s is %dGB after resize, expected %dGB: make sure the block device was grown first",
+			resized.Device, resized.SizeBytes/bytesInGB, newSizeGB)
+	}
+	h.Logger.Infof("Data volume %s on %s resized to %dGB with %dGB available",
+		resized.Device, h.NodeID, resized.SizeBytes/bytesInGB, resized.AvailableBytes/bytesInGB)
+	return nil
+}
+
+// MountDataVolume formats device as ext4 if it holds no filesystem yet and mounts it as the
+// odysseygo database directory, so that the database lives on a separate data volume.
+// Existing database files are copied into the volume, and the mount is persisted in /etc/fstab
+// with the filesystem type of the volume. Nothing is done if device is already mounted there.
+// odysseygo is stopped during the operation and started again afterwards.
+func (h *Node) MountDataVolume(ctx context.Context, device string) error {
+	if device == "" {
+		return fmt.Errorf("data volume device cannot be empty")
+	}
+	dbDir := remoteconfig.GetRemoteOdysseyDBDir(h.config())
+	// findmnt exits with 1 when device is not mounted on dbDir
+	output, err := h.Commandf(nil, constants.SSHScriptTimeout, "findmnt -rn --source %s --mountpoint %s || true", device, dbDir)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, string(output))
+	}
+	if strings.TrimSpace(string(output)) != "" {
+		h.Logger.Infof("Data volume %s already mounted on %s:%s", device, h.NodeID, dbDir)
+		return nil
+	}
+	output, err = h.Commandf(nil, constants.SSHScriptTimeout, "sudo blkid -o value -s TYPE %s || true", device)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, string(output))
+	}
+	fsType := strings.TrimSpace(string(output))
+	switch {
+	case fsType == "":
+		h.Logger.Infof("Formatting data volume %s on %s", device, h.NodeID)
+		if output, err := h.Commandf(nil, constants.SSHLongRunningScriptTimeout, "sudo mkfs.ext4 -q %s", device); err != nil {
+			return fmt.Errorf("failed to format %s: %w: %s", device, err, string(output))
+		}
+		fsType = "ext4"
+	case !fsTypeRegex.MatchString(fsType):
+		return fmt.Errorf("unexpected filesystem type %q on %s", fsType, device)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	script := strings.Join([]string{
+		"set -e",
+		fmt.Sprintf("sudo mkdir -p %s %s", tmpDataVolumeMountPoint, dbDir),
+		fmt.Sprintf("sudo mount %s %s", device, tmpDataVolumeMountPoint),
+		fmt.Sprintf("trap 'sudo umount %s' EXIT", tmpDataVolumeMountPoint),
+		fmt.Sprintf("sudo cp -a %s/. %s/", dbDir, tmpDataVolumeMountPoint),
+		fmt.Sprintf("sudo umount %s", tmpDataVolumeMountPoint),
+		"trap - EXIT",
+		fmt.Sprintf("UUID=$(sudo blkid -o value -s UUID %s)", device),
+		fmt.Sprintf("grep -q \"UUID=$UUID\" /etc/fstab || echo \"UUID=$UUID %s %s defaults,nofail 0 2\" | sudo tee -a /etc/fstab >/dev/null", dbDir, fsType),
+		fmt.Sprintf("sudo mount %s", dbDir),
+		fmt.Sprintf("sudo chown -R %s:%s %s", h.sshUser(), h.sshUser(), dbDir),
+	}, "\n")
+	err = h.withOdysseyGoStopped(func() error {
+		if output, err := h.Command(nil, constants.SSHLongRunningScriptTimeout, script); err != nil {
+			return fmt.Errorf("failed to mount data volume %s: %w: %s", device, err, string(output))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	h.Logger.Infof("Data volume %s mounted on %s:%s", device, h.NodeID, dbDir)
+	return nil
+}
+
+func parseFindmntOutput(output string) (string, string, error) {
+	fields := strings.Fields(output)
+	if len(fields) != 2 {
+		return "", "", fmt.Errorf("unexpected findmnt output: %q", output)
+	}
+	return fields[0], fields[1], nil
+}
+
+func parseDfOutput(output string) (uint64, uint64, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 2 {
+		return 0, 0, fmt.Errorf("unexpected df output: %q", output)
+	}
+	fields := strings.Fields(lines[1])
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected df output: %q", output)
+	}
+	size, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected df size %q: %w", fields[0], err)
+	}
+	avail, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected df available space %q: %w", fields[1], err)
+	}
+	return size, avail, nil
+}
+
+// splitPartitionDevice returns the disk and partition number of a partition device,
+// or false if the device is a whole disk
+func splitPartitionDevice(device string) (string, string, bool) {
+	matches := partitionDeviceRegex.FindStringSubmatch(device)
+	switch {
+	case matches == nil:
+		return "", "", false
+	case matches[1] != "":
+		return matches[1], matches[2], true
+	default:
+		return matches[3], matches[4], true
+	}
+}
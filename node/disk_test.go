@@ -0,0 +1,158 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/nodemock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitPartitionDevice(t *testing.T) {
+	tests := []struct {
+		device            string
+		expectedDisk      string
+		expectedPartition string
+		expectedOk        bool
+	}{
+		{device: "/dev/xvda1", expectedDisk: "/dev/xvda", expectedPartition: "1", expectedOk: true},
+		{device: "/dev/sda15", expectedDisk: "/dev/sda", expectedPartition: "15", expectedOk: true},
+		{device: "/dev/nvme0n1p1", expectedDisk: "/dev/nvme0n1", expectedPartition: "1", expectedOk: true},
+		{device: "/dev/mmcblk0p2", expectedDisk: "/dev/mmcblk0", expectedPartition: "2", expectedOk: true},
+		{device: "/dev/nvme1n1", expectedOk: false},
+		{device: "/dev/xvdb", expectedOk: false},
+		{device: "/dev/mapper/vg-data", expectedOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.device, func(t *testing.T) {
+			disk, partition, ok := splitPartitionDevice(tt.device)
+			assert.Equal(t, tt.expectedOk, ok)
+			assert.Equal(t, tt.expectedDisk, disk)
+			assert.Equal(t, tt.expectedPartition, partition)
+		})
+	}
+}
+
+func TestParseFindmntOutput(t *testing.T) {
+	device, fsType, err := parseFindmntOutput("/dev/nvme0n1p1 ext4\n")
+	require.NoError(t, err)
+	assert.Equal(t, "/dev/nvme0n1p1", device)
+	assert.Equal(t, "ext4", fsType)
+
+	_, _, err = parseFindmntOutput("")
+	assert.Error(t, err)
+}
+
+func TestParseDfOutput(t *testing.T) {
+	size, avail, err := parseDfOutput("     1B-blocks        Avail\n 528309125120 211251175424\n")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(528309125120), size)
+	assert.Equal(t, uint64(211251175424), avail)
+
+	_, _, err = parseDfOutput("1B-blocks Avail\n")
+	assert.Error(t, err)
+
+	_, _, err = parseDfOutput("1B-blocks Avail\nabc 12\n")
+	assert.Error(t, err)
+}
+
+func TestNode_MountDataVolume_EmptyDevice(t *testing.T) {
+	node := Node{NodeID: "test-node"}
+	err := node.MountDataVolume(context.Background(), "")
+	assert.Error(t, err)
+}
+
+func TestNode_MountDataVolume(t *testing.T) {
+	const findmnt = "findmnt -rn --source /dev/nvme1n1 --mountpoint /home/ubuntu/.odysseygo/db || true"
+	for _, tt := range []struct {
+		name   string
+		blkid  string
+		fsType string
+	}{
+		{name: "formatted volume", blkid: "xfs\n", fsType: "xfs"},
+		{name: "blank volume", fsType: "ext4"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client := nodemock.NewSSHClient()
+			client.OnScript(findmnt, "", nil).Once()
+			client.OnScript("sudo blkid -o value -s TYPE /dev/nvme1n1 || true", tt.blkid, nil).Once()
+			if tt.blkid == "" {
+				client.OnScript("sudo mkfs.ext4 -q /dev/nvme1n1", "", nil).Once()
+			}
+			client.OnScript(mock.MatchedBy(func(script string) bool {
+				return strings.HasPrefix(script, "if [ -e ")
+			}), "", nil).Once()
+			var mountScript string
+			client.OnScript(mock.MatchedBy(func(script string) bool {
+				return strings.HasPrefix(script, "set -e")
+			}), "", nil).Run(func(args mock.Arguments) {
+				mountScript = args.String(2)
+			}).Once()
+			h := &Node{NodeID: "node-1"}
+			h.SetSSHClient(client)
+
+			require.NoError(t, h.MountDataVolume(context.Background(), "/dev/nvme1n1"))
+			client.AssertExpectations(t)
+			assert.Contains(t, mountScript, "/home/ubuntu/.odysseygo/db "+tt.fsType+" defaults,nofail 0 2")
+			assert.Contains(t, mountScript, "trap 'sudo umount "+tmpDataVolumeMountPoint+"' EXIT")
+		})
+	}
+
+	t.Run("already mounted", func(t *testing.T) {
+		client := nodemock.NewSSHClient()
+		client.OnScript(findmnt, "/home/ubuntu/.odysseygo/db /dev/nvme1n1 ext4 rw,relatime\n", nil).Once()
+		h := &Node{NodeID: "node-1"}
+		h.SetSSHClient(client)
+		require.NoError(t, h.MountDataVolume(context.Background(), "/dev/nvme1n1"))
+		client.AssertExpectations(t)
+	})
+
+	t.Run("restarts odysseygo on failure", func(t *testing.T) {
+		client := nodemock.NewSSHClient()
+		client.OnScript("findmnt -rn --source /dev/nvme1n1 --mountpoint /home/ec2-user/.odysseygo/db || true", "", nil).Once()
+		client.OnScript("sudo blkid -o value -s TYPE /dev/nvme1n1 || true", "ext4\n", nil).Once()
+		client.OnScript("if [ -e '/etc/systemd/system/odysseygo.service' ]; then echo yes; fi", "yes\n", nil).Once()
+		client.OnScript("sudo systemctl stop odysseygo.service", "", nil).Once()
+		var mountScript string
+		client.OnScript(mock.MatchedBy(func(script string) bool {
+			return strings.HasPrefix(script, "set -e")
+		}), "", errors.New("exit status 32")).Run(func(args mock.Arguments) {
+			mountScript = args.String(2)
+		}).Once()
+		client.OnScript("sudo systemctl start odysseygo.service", "", nil).Once()
+		h := &Node{NodeID: "node-1", RuntimeMode: SystemdRuntime, SSHConfig: SSHConfig{User: "ec2-user"}}
+		h.SetSSHClient(client)
+
+		require.ErrorContains(t, h.MountDataVolume(context.Background(), "/dev/nvme1n1"), "failed to mount data volume")
+		client.AssertExpectations(t)
+		assert.Contains(t, mountScript, "sudo chown -R ec2-user:ec2-user /home/ec2-user/.odysseygo/db")
+	})
+
+	client := nodemock.NewSSHClient()
+	client.OnScript(findmnt, "", nil).Once()
+	client.OnScript("sudo blkid -o value -s TYPE /dev/nvme1n1 || true", "ext4 $(reboot)\n", nil).Once()
+	h := &Node{NodeID: "node-1"}
+	h.SetSSHClient(client)
+	assert.ErrorContains(t, h.MountDataVolume(context.Background(), "/dev/nvme1n1"), "unexpected filesystem type")
+}
+
+func TestNode_ResizeDataVolume_NotConnected(t *testing.T) {
+	node := Node{
+		NodeID: "test-node",
+		IP:     "192.168.1.1",
+		SSHConfig: SSHConfig{
+			User:           "ubuntu",
+			PrivateKeyPath: "/path/to/key",
+		},
+	}
+	err := node.ResizeDataVolume(context.Background(), 500)
+	assert.Error(t, err) // Will fail due to missing key file
+}
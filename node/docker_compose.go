@@ -6,15 +6,15 @@ package node
 import (
 	"bytes"
 	"embed"
-	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
-	"strings"
-	"text/template"
 	"time"
 
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/monitoring"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
 )
 
@@ -25,18 +25,96 @@ type dockerComposeInputs struct {
 	E2E              bool
 	E2EIP            string
 	E2ESuffix        string
+	Platform         string
+
+	// AWMRelayerVersion is the docker image tag of the AWM relayer
+	AWMRelayerVersion string
+
+	// MonitoringBindIP is the host IP the Loki and Prometheus ports are published on
+	MonitoringBindIP string
+	// PrometheusWebConfig enables the Prometheus web config holding its TLS and basic auth settings
+	PrometheusWebConfig bool
+
+	// ExtraEnv and ExtraVolumes are added to the services, by service name, see ComposeExtras
+	ExtraEnv     map[string]map[string]string
+	ExtraVolumes map[string][]string
+
+	// Config sets the published ports and the remote folders mounted by the services, the
+	// defaults when zero
+	Config constants.Config
+
+	// templateOverrides is consulted before the embedded compose templates, see
+	// Node.TemplateOverrides
+	templateOverrides fs.FS
+}
+
+// ComposeExtras customizes the services of the compose files rendered for a node, by
+// service name, e.g. odysseygo or promtail
+type ComposeExtras struct {
+	// Env are the environment variables added to the services
+	Env map[string]map[string]string
+
+	// Volumes are the volumes added to the services, e.g. /data/backups:/backups:rw
+	Volumes map[string][]string
+}
+
+// withExtras returns inputs with the services customized by extras, if not nil
+func (inputs dockerComposeInputs) withExtras(extras *ComposeExtras) dockerComposeInputs {
+	if extras != nil {
+		inputs.ExtraEnv = extras.Env
+		inputs.ExtraVolumes = extras.Volumes
+	}
+	return inputs
+}
+
+// forNode returns inputs with the Config, the TemplateOverrides and the ComposeExtras of h
+func (inputs dockerComposeInputs) forNode(h *Node) dockerComposeInputs {
+	inputs.Config = h.config()
+	inputs.templateOverrides = h.TemplateOverrides
+	return inputs.withExtras(h.ComposeExtras)
 }
 
 //go:embed templates/*.docker-compose.yml
 var composeTemplate embed.FS
 
+// TemplateOverridesDir returns the template overrides of a local directory, to be set as the
+// TemplateOverrides of nodes so that their compose files and service configs can be
+// customized without forking the SDK. Its files replace the embedded ones with the same path:
+//   - templates/odysseygo.docker-compose.yml, templates/monitoring.docker-compose.yml
+//   - configs/prometheus.yml, configs/prometheus-web.yml, configs/loki.yml, configs/promtail.yml
+//   - templates/grafana.ini, templates/grafana-*.yaml, templates/odyssey-node.tmpl
+//
+// Files not found in overrides are read from the embedded templates.
+//
+// The compose and monitoring templates can use the partials/*.tmpl files of overrides and
+// the functions of utils.ParseTemplate. The compose templates include the partials
+// odysseygo-services, monitoring-services, relayer-services and rpc-gateway-services, if
+// any, at the end of their services, e.g. to add a sidecar service
+func TemplateOverridesDir(dir string) (fs.FS, error) {
+	if !utils.DirectoryExists(dir) {
+		return nil, fmt.Errorf("template overrides directory %s does not exist", dir)
+	}
+	return os.DirFS(dir), nil
+}
+
+// renderOptions returns the options rendering the service configs of h
+func (h *Node) renderOptions() []remoteconfig.RenderOption {
+	return []remoteconfig.RenderOption{remoteconfig.WithTemplateOverrides(h.TemplateOverrides)}
+}
+
+// monitoringOptions returns the options generating the monitoring configs of h
+func (h *Node) monitoringOptions() []monitoring.ConfigOption {
+	return []monitoring.ConfigOption{monitoring.WithTemplateOverrides(h.TemplateOverrides)}
+}
+
 func renderComposeFile(composePath string, composeDesc string, templateVars dockerComposeInputs) ([]byte, error) {
-	compose, err := composeTemplate.ReadFile(composePath)
+	compose, err := utils.ReadFileWithOverrides(templateVars.templateOverrides, composeTemplate, composePath)
 	if err != nil {
 		return nil, err
 	}
+	templateVars.Config = templateVars.Config.WithDefaults()
 	var composeBytes bytes.Buffer
-	t, err := template.New(composeDesc).Parse(string(compose))
+	t, err := utils.ParseTemplate(composeDesc, compose, templateVars.templateOverrides)
 	if err != nil {
 		return nil, err
 	}
@@ -46,16 +124,25 @@ func renderComposeFile(composePath string, composeDesc string, templateVars dock
 	return composeBytes.Bytes(), nil
 }
 
+// PushComposeFile uploads a compose file to the node, merging it into the existing remote
+// file if merge is true. The remote file is left untouched if its content would not change,
+// and is otherwise backed up to remoteFile.bak before being overwritten.
 func (h *Node) PushComposeFile(localFile string, remoteFile string, merge bool) error {
+	_, err := h.pushComposeFile(localFile, remoteFile, merge)
+	return err
+}
+
+// pushComposeFile is PushComposeFile that also returns whether the remote file changed
+func (h *Node) pushComposeFile(localFile string, remoteFile string, merge bool) (bool, error) {
 	if !utils.FileExists(localFile) {
-		return fmt.Errorf("file %s does not exist to be uploaded to node: %s", localFile, h.NodeID)
+		return false, fmt.Errorf("file %s does not exist to be uploaded to node: %s", localFile, h.NodeID)
 	}
 	if err := h.MkdirAll(filepath.Dir(remoteFile), constants.SSHFileOpsTimeout); err != nil {
-		return err
+		return false, err
 	}
 	fileExists, err := h.FileExists(remoteFile)
 	if err != nil {
-		return err
+		return false, err
 	}
 	h.Logger.Infof("Pushing compose file %s to %s:%s", localFile, h.NodeID, remoteFile)
 	if fileExists && merge {
@@ -63,7 +150,7 @@ func (h *Node) PushComposeFile(localFile string, remoteFile string, merge bool)
 		h.Logger.Infof("Merging compose files")
 		tmpFile, err := h.CreateTempFile()
 		if err != nil {
-			return err
+			return false, err
 		}
 		defer func() {
 			if err := h.Remove(tmpFile, false); err != nil {
@@ -71,132 +158,89 @@ func (h *Node) PushComposeFile(localFile string, remoteFile string, merge bool)
 			}
 		}()
 		if err := h.Upload(localFile, tmpFile, constants.SSHFileOpsTimeout); err != nil {
-			return err
-		}
-		if err := h.MergeComposeFiles(remoteFile, tmpFile); err != nil {
-			return err
-		}
-	} else {
-		h.Logger.Infof("Uploading compose file for node; %s", h.NodeID)
-		if err := h.Upload(localFile, remoteFile, constants.SSHFileOpsTimeout); err != nil {
-			return err
+			return false, err
 		}
+		return h.mergeComposeFiles(remoteFile, tmpFile)
 	}
-	return nil
+	h.Logger.Infof("Uploading compose file for node; %s", h.NodeID)
+	return h.UploadIfChanged(localFile, remoteFile, constants.SSHFileOpsTimeout, true)
 }
 
-// mergeComposeFiles merges two docker-compose files on a remote node.
+// MergeComposeFiles merges two docker-compose files on a remote node.
 func (h *Node) MergeComposeFiles(currentComposeFile string, newComposeFile string) error {
+	_, err := h.mergeComposeFiles(currentComposeFile, newComposeFile)
+	return err
+}
+
+func (h *Node) mergeComposeFiles(currentComposeFile string, newComposeFile string) (bool, error) {
 	fileExists, err := h.FileExists(currentComposeFile)
 	if err != nil {
-		return err
+		return false, err
 	}
 	if !fileExists {
-		return fmt.Errorf("file %s does not exist", currentComposeFile)
+		return false, fmt.Errorf("file %s does not exist", currentComposeFile)
 	}
 
 	fileExists, err = h.FileExists(newComposeFile)
 	if err != nil {
-		return err
+		return false, err
 	}
 	if !fileExists {
-		return fmt.Errorf("file %s does not exist", newComposeFile)
+		return false, fmt.Errorf("file %s does not exist", newComposeFile)
 	}
 
-	output, err := h.Commandf(nil, constants.SSHScriptTimeout, "docker compose -f %s -f %s config", currentComposeFile, newComposeFile)
+	output, err := h.dockerCommandf(constants.SSHScriptTimeout, "docker compose -f %s -f %s config", currentComposeFile, newComposeFile)
 	if err != nil {
-		return fmt.Errorf("%w: %s", err, string(output))
+		return false, fmt.Errorf("%w: %s", err, string(output))
 	}
 	tmpFile, err := os.CreateTemp("", "avalancecli-docker-compose-*.yml")
 	if err != nil {
-		return err
+		return false, err
 	}
 	defer os.Remove(tmpFile.Name())
 	if _, err := tmpFile.Write(output); err != nil {
-		return err
+		return false, err
 	}
 	h.Logger.Infof("Merged compose files as %s", output)
-	if err := h.PushComposeFile(tmpFile.Name(), currentComposeFile, false); err != nil {
-		return err
-	}
-	return nil
+	return h.pushComposeFile(tmpFile.Name(), currentComposeFile, false)
 }
 
+// StartDockerCompose starts the services of the compose file of the node, see
+// ComposeManager.Up
 func (h *Node) StartDockerCompose(timeout time.Duration) error {
-	// we provide systemd service unit for docker compose if the node has systemd
-	if h.HasSystemDAvailable() {
-		if output, err := h.Command(nil, timeout, "sudo systemctl start odyssey-cli-docker"); err != nil {
-			return fmt.Errorf("%w: %s", err, string(output))
-		}
-	} else {
-		composeFile := utils.GetRemoteComposeFile()
-		output, err := h.Commandf(nil, constants.SSHScriptTimeout, "docker compose -f %s up -d", composeFile)
-		if err != nil {
-			return fmt.Errorf("%w: %s", err, string(output))
-		}
-	}
-	return nil
+	return h.Compose().Up(timeout)
 }
 
+// StopDockerCompose stops the services of the compose file of the node, see ComposeManager.Down
 func (h *Node) StopDockerCompose(timeout time.Duration) error {
-	if h.HasSystemDAvailable() {
-		if output, err := h.Command(nil, timeout, "sudo systemctl stop odyssey-cli-docker"); err != nil {
-			return fmt.Errorf("%w: %s", err, string(output))
-		}
-	} else {
-		composeFile := utils.GetRemoteComposeFile()
-		output, err := h.Commandf(nil, constants.SSHScriptTimeout, "docker compose -f %s down", composeFile)
-		if err != nil {
-			return fmt.Errorf("%w: %s", err, string(output))
-		}
-	}
-	return nil
+	return h.Compose().Down(timeout)
 }
 
+// RestartDockerCompose restarts the services of the compose file of the node, see
+// ComposeManager.Restart
 func (h *Node) RestartDockerCompose(timeout time.Duration) error {
-	if h.HasSystemDAvailable() {
-		if output, err := h.Command(nil, timeout, "sudo systemctl restart odyssey-cli-docker"); err != nil {
-			return fmt.Errorf("%w: %s", err, string(output))
-		}
-	} else {
-		composeFile := utils.GetRemoteComposeFile()
-		output, err := h.Commandf(nil, constants.SSHScriptTimeout, "docker compose -f %s restart", composeFile)
-		if err != nil {
-			return fmt.Errorf("%w: %s", err, string(output))
-		}
-	}
-	return nil
+	return h.Compose().Restart(timeout)
 }
 
+// StartDockerComposeService starts service of composeFile, see ComposeService.Start
 func (h *Node) StartDockerComposeService(composeFile string, service string, timeout time.Duration) error {
-	if err := h.InitDockerComposeService(composeFile, service, timeout); err != nil {
-		return err
-	}
-	if output, err := h.Commandf(nil, timeout, "docker compose -f %s start %s", composeFile, service); err != nil {
-		return fmt.Errorf("%w: %s", err, string(output))
-	}
-	return nil
+	return h.composeManager(composeFile).Service(service).Start(timeout)
 }
 
+// StopDockerComposeService stops service of composeFile, see ComposeService.Stop
 func (h *Node) StopDockerComposeService(composeFile string, service string, timeout time.Duration) error {
-	if output, err := h.Commandf(nil, timeout, "docker compose -f %s stop %s", composeFile, service); err != nil {
-		return fmt.Errorf("%w: %s", err, string(output))
-	}
-	return nil
+	return h.composeManager(composeFile).Service(service).Stop(timeout)
 }
 
+// RestartDockerComposeService restarts service of composeFile, see ComposeService.Restart
 func (h *Node) RestartDockerComposeService(composeFile string, service string, timeout time.Duration) error {
-	if output, err := h.Commandf(nil, timeout, "docker compose -f %s restart %s", composeFile, service); err != nil {
-		return fmt.Errorf("%w: %s", err, string(output))
-	}
-	return nil
+	return h.composeManager(composeFile).Service(service).Restart(timeout)
 }
 
+// InitDockerComposeService creates the containers of service of composeFile, see
+// ComposeService.Init
 func (h *Node) InitDockerComposeService(composeFile string, service string, timeout time.Duration) error {
-	if output, err := h.Commandf(nil, timeout, "docker compose -f %s create %s", composeFile, service); err != nil {
-		return fmt.Errorf("%w: %s", err, string(output))
-	}
-	return nil
+	return h.composeManager(composeFile).Service(service).Init(timeout)
 }
 
 // ComposeOverSSH sets up a docker-compose file on a remote node over SSH.
@@ -206,63 +250,29 @@ func (h *Node) ComposeOverSSH(
 	composePath string,
 	composeVars dockerComposeInputs,
 ) error {
-	remoteComposeFile := utils.GetRemoteComposeFile()
-	startTime := time.Now()
-	tmpFile, err := os.CreateTemp("", "odysseycli-docker-compose-*.yml")
-	if err != nil {
-		return err
-	}
-	defer os.Remove(tmpFile.Name())
-	composeData, err := renderComposeFile(composePath, composeDesc, composeVars)
-	if err != nil {
-		return err
-	}
+	_, err := h.composeOverSSH(composeDesc, timeout, composePath, composeVars)
+	return err
+}
 
-	if _, err := tmpFile.Write(composeData); err != nil {
-		return err
-	}
-	h.Logger.Infof("pushComposeFile [%s]%s", h.NodeID, composeDesc)
-	if err := h.PushComposeFile(tmpFile.Name(), remoteComposeFile, true); err != nil {
-		return err
-	}
-	h.Logger.Infof("ValidateComposeFile [%s]%s", h.NodeID, composeDesc)
-	if err := h.ValidateComposeFile(remoteComposeFile, timeout); err != nil {
-		h.Logger.Errorf("ComposeOverSSH[%s]%s failed to validate: %v", h.NodeID, composeDesc, err)
-		return err
-	}
-	h.Logger.Infof("StartDockerCompose [%s]%s", h.NodeID, composeDesc)
-	if err := h.StartDockerCompose(timeout); err != nil {
-		return err
-	}
-	executionTime := time.Since(startTime)
-	h.Logger.Infof("ComposeOverSSH[%s]%s took %s with err: %v", h.NodeID, composeDesc, executionTime, err)
-	return nil
+// composeOverSSH is ComposeOverSSH that also returns whether the remote compose file changed,
+// so that callers can skip restarting services when it did not
+func (h *Node) composeOverSSH(
+	composeDesc string,
+	timeout time.Duration,
+	composePath string,
+	composeVars dockerComposeInputs,
+) (bool, error) {
+	return h.Compose().apply(composeDesc, timeout, composePath, composeVars)
 }
 
 // ListRemoteComposeServices lists the services in a remote docker-compose file.
 func (h *Node) ListRemoteComposeServices(composeFile string, timeout time.Duration) ([]string, error) {
-	output, err := h.Commandf(nil, timeout, "docker compose -f %s config --services", composeFile)
-	if err != nil {
-		return nil, err
-	}
-	return utils.CleanupStrings(strings.Split(string(output), "\n")), nil
+	return h.composeManager(composeFile).Services(timeout)
 }
 
 // GetRemoteComposeContent gets the content of a remote docker-compose file.
 func (h *Node) GetRemoteComposeContent(composeFile string, timeout time.Duration) (string, error) {
-	tmpFile, err := os.CreateTemp("", "avalancecli-docker-compose-*.yml")
-	if err != nil {
-		return "", err
-	}
-	defer os.Remove(tmpFile.Name())
-	if err := h.Download(composeFile, tmpFile.Name(), timeout); err != nil {
-		return "", err
-	}
-	data, err := os.ReadFile(tmpFile.Name())
-	if err != nil {
-		return "", err
-	}
-	return string(data), nil
+	return h.composeManager(composeFile).Content(timeout)
 }
 
 // ParseRemoteComposeContent extracts a value from a remote docker-compose file.
@@ -276,45 +286,18 @@ func (h *Node) ParseRemoteComposeContent(composeFile string, pattern string, tim
 
 // HasRemoteComposeService checks if a serviceis present in a remote docker-compose file.
 func (h *Node) HasRemoteComposeService(composeFile string, service string, timeout time.Duration) (bool, error) {
-	services, err := h.ListRemoteComposeServices(composeFile, timeout)
-	if err != nil {
-		return false, err
-	}
-	found := false
-	for _, s := range services {
-		if s == service {
-			found = true
-			break
-		}
-	}
-	return found, nil
+	return h.composeManager(composeFile).HasService(service, timeout)
 }
 
+// ListDockerComposeImages maps the repository of the images of the services of composeFile
+// to their tag, see ComposeManager.Images
 func (h *Node) ListDockerComposeImages(composeFile string, timeout time.Duration) (map[string]string, error) {
-	output, err := h.Commandf(nil, timeout, "docker compose -f %s images --format json", composeFile)
-	if err != nil {
-		return nil, err
-	}
-	type dockerImages struct {
-		ID         string `json:"ID"`
-		Name       string `json:"ContainerName"`
-		Repository string `json:"Repository"`
-		Tag        string `json:"Tag"`
-		Size       uint   `json:"Size"`
-	}
-	var images []dockerImages
-	if err := json.Unmarshal(output, &images); err != nil {
-		return nil, err
-	}
-	imageMap := make(map[string]string)
-	for _, image := range images {
-		imageMap[image.Repository] = image.Tag
-	}
-	return imageMap, nil
+	return h.composeManager(composeFile).Images(timeout)
 }
 
+// GetDockerImageVersion returns the tag of image in the compose file of the node
 func (h *Node) GetDockerImageVersion(image string, timeout time.Duration) (string, error) {
-	imageMap, err := h.ListDockerComposeImages(utils.GetRemoteComposeFile(), timeout)
+	imageMap, err := h.Compose().Images(timeout)
 	if err != nil {
 		return "", err
 	}
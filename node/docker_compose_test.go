@@ -7,12 +7,14 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
 )
 
 func TestRenderComposeFile(t *testing.T) {
@@ -94,6 +96,109 @@ func TestRenderComposeFile(t *testing.T) {
 	}
 }
 
+func TestNode_TemplateOverrides(t *testing.T) {
+	h := &Node{TemplateOverrides: fstest.MapFS{
+		"templates/monitoring.docker-compose.yml": {Data: []byte("services:\n  sidecar:\n    image: sidecar:{{ .OdysseygoVersion }}\n")},
+		"templates/grafana.ini":                   {Data: []byte("[custom]\n")},
+	}}
+
+	composeData, err := renderComposeFile("templates/monitoring.docker-compose.yml", "test", dockerComposeInputs{OdysseygoVersion: "v1"}.forNode(h))
+	require.NoError(t, err)
+	assert.Equal(t, "services:\n  sidecar:\n    image: sidecar:v1\n", string(composeData))
+
+	// files missing from the overrides are read from the embedded templates
+	composeData, err = renderComposeFile("templates/odysseygo.docker-compose.yml", "test", dockerComposeInputs{WithOdysseygo: true, OdysseygoVersion: "v1"}.forNode(h))
+	require.NoError(t, err)
+	assert.Contains(t, string(composeData), "dionetech/odysseygo:v1")
+
+	grafanaConfig, err := remoteconfig.RenderGrafanaConfig(h.renderOptions()...)
+	require.NoError(t, err)
+	assert.Equal(t, "[custom]\n", string(grafanaConfig))
+
+	// the overrides of a node do not apply to the others
+	other := &Node{}
+	composeData, err = renderComposeFile("templates/monitoring.docker-compose.yml", "test", dockerComposeInputs{}.forNode(other))
+	require.NoError(t, err)
+	assert.Contains(t, string(composeData), "prometheus")
+	grafanaConfig, err = remoteconfig.RenderGrafanaConfig(other.renderOptions()...)
+	require.NoError(t, err)
+	assert.NotEqual(t, "[custom]\n", string(grafanaConfig))
+}
+
+func TestRenderComposeFile_Extras(t *testing.T) {
+	overrides := fstest.MapFS{
+		"partials/odysseygo-services.tmpl": {Data: []byte("  sidecar:\n    image: sidecar:{{ normalizeVersion .OdysseygoVersion }}\n")},
+	}
+	inputs := dockerComposeInputs{WithOdysseygo: true, WithMonitoring: true, OdysseygoVersion: "1.10.13", templateOverrides: overrides}.withExtras(&ComposeExtras{
+		Env:     map[string]map[string]string{constants.ServiceOdysseygo: {"GOMAXPROCS": "4", "GOGC": "50"}},
+		Volumes: map[string][]string{constants.ServicePromtail: {"/var/log/syslog:/syslog:ro"}},
+	})
+	composeData, err := renderComposeFile("templates/odysseygo.docker-compose.yml", "test", inputs)
+	require.NoError(t, err)
+	content, err := parseComposeContent(composeData)
+	require.NoError(t, err)
+
+	env, err := parseComposeEnvironment(content.Services[constants.ServiceOdysseygo].Environment)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"GOMAXPROCS": "4", "GOGC": "50"}, env)
+	assert.Nil(t, content.Services[constants.ServicePromtail].Environment)
+	assert.Equal(t, "sidecar:v1.10.13", content.Services["sidecar"].Image)
+	assert.Contains(t, string(composeData), "      - /home/ubuntu/.odyssey-cli/services/promtail:/etc/promtail:ro\n      - /var/log/syslog:/syslog:ro\n")
+
+	// the services are rendered as before without extras
+	composeData, err = renderComposeFile("templates/monitoring.docker-compose.yml", "test", dockerComposeInputs{}.withExtras(&ComposeExtras{
+		Env: map[string]map[string]string{constants.ServiceGrafana: {"GF_LOG_LEVEL": "debug"}},
+	}))
+	require.NoError(t, err)
+	content, err = parseComposeContent(composeData)
+	require.NoError(t, err)
+	env, err = parseComposeEnvironment(content.Services[constants.ServiceGrafana].Environment)
+	require.NoError(t, err)
+	assert.Equal(t, "debug", env["GF_LOG_LEVEL"])
+	assert.Equal(t, "false", env["GF_USERS_ALLOW_SIGN_UP"])
+	assert.NotContains(t, string(composeData), "sidecar")
+}
+
+func TestRenderComposeFile_Config(t *testing.T) {
+	config := constants.Config{OdysseygoAPIPort: 9660, GrafanaPort: 3001, OdysseyGoDir: "/data/odysseygo", ServicesDir: "/data/services"}
+	composeData, err := renderComposeFile("templates/odysseygo.docker-compose.yml", "test", dockerComposeInputs{
+		WithOdysseygo:    true,
+		WithMonitoring:   true,
+		OdysseygoVersion: "v1.10.13",
+		Config:           config,
+	})
+	require.NoError(t, err)
+	content, err := parseComposeContent(composeData)
+	require.NoError(t, err)
+	assert.Contains(t, string(composeData), `- "9660:9660"`)
+	assert.Contains(t, string(composeData), `- "9651:9651"`)
+	assert.Contains(t, string(composeData), "- /data/odysseygo:/.odysseygo:rw")
+	assert.Contains(t, string(composeData), "- /data/odysseygo/logs:/logs:ro")
+	assert.Contains(t, string(composeData), "- /data/services/promtail:/etc/promtail:ro")
+	assert.Contains(t, content.Services, constants.ServiceOdysseygo)
+
+	composeData, err = renderComposeFile("templates/monitoring.docker-compose.yml", "test", dockerComposeInputs{Config: config})
+	require.NoError(t, err)
+	assert.Contains(t, string(composeData), `- "3001:3000"`)
+	assert.Contains(t, string(composeData), `- "9090:9090"`)
+	assert.Contains(t, string(composeData), "- /data/services/grafana:/etc/grafana:ro")
+}
+
+func TestTemplateOverridesDir(t *testing.T) {
+	_, err := TemplateOverridesDir(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "templates"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "templates", "odysseygo.docker-compose.yml"), []byte("name: custom\n"), 0o600))
+	overrides, err := TemplateOverridesDir(dir)
+	require.NoError(t, err)
+
+	composeData, err := renderComposeFile("templates/odysseygo.docker-compose.yml", "test", dockerComposeInputs{templateOverrides: overrides})
+	require.NoError(t, err)
+	assert.Equal(t, "name: custom\n", string(composeData))
+}
+
 func TestNode_PushComposeFile(t *testing.T) {
 	// Create a temporary file for testing
 	tempDir := t.TempDir()
@@ -9,21 +9,29 @@ import (
 
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
 	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/monitoring"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
 )
 
 // PrepareOdysseygoConfig creates the config files for the OdysseyGo
 // networkID is the ID of the network to be used
 // trackSubnets is the list of subnets to track
+// the indexer, admin API and D-Chain database are configured by the Preset of the node
 func (h *Node) RunSSHRenderOdysseyNodeConfig(networkID string, trackSubnets []string) error {
 	// Check feature flag for SSH key management
 	if !constants.SSHKeyManagementEnabled {
 		return fmt.Errorf("SSH key management functionality is disabled. Set constants.SSHKeyManagementEnabled = true to enable")
 	}
 
+	presetConfig, err := h.Preset.Config()
+	if err != nil {
+		return err
+	}
 	avagoConf := remoteconfig.PrepareOdysseyConfig(h.IP, networkID, trackSubnets)
+	avagoConf.SetPorts(h.Config)
+	presetConfig.apply(&avagoConf)
 
-	nodeConf, err := remoteconfig.RenderOdysseyNodeConfig(avagoConf)
+	nodeConf, err := remoteconfig.RenderOdysseyNodeConfig(avagoConf, h.renderOptions()...)
 	if err != nil {
 		return err
 	}
@@ -31,7 +39,7 @@ func (h *Node) RunSSHRenderOdysseyNodeConfig(networkID string, trackSubnets []st
 	if nodeConfigFileExists(*h) {
 		// make sure that bootsrap configuration is preserved
 		if genesisFileExists(*h) {
-			avagoConf.GenesisPath = remoteconfig.GetRemoteOdysseyGenesis()
+			avagoConf.GenesisPath = remoteconfig.GetRemoteOdysseyGenesis(h.config())
 		}
 		remoteAvagoConf, err := h.GetOdysseyGoConfigData()
 		if err != nil {
@@ -45,21 +53,21 @@ func (h *Node) RunSSHRenderOdysseyNodeConfig(networkID string, trackSubnets []st
 		avagoConf.BootstrapIPs = bootstrapIPs
 	}
 	// configuration is ready to be uploaded
-	if err := h.UploadBytes(nodeConf, remoteconfig.GetRemoteOdysseyNodeConfig(), constants.SSHFileOpsTimeout); err != nil {
+	if err := h.UploadBytes(nodeConf, remoteconfig.GetRemoteOdysseyNodeConfig(h.config()), constants.SSHFileOpsTimeout); err != nil {
 		return err
 	}
-	dChainConf, err := remoteconfig.RenderOdysseyDChainConfig(avagoConf)
+	dChainConf, err := remoteconfig.RenderOdysseyDChainConfig(avagoConf, h.renderOptions()...)
 	if err != nil {
 		return err
 	}
-	if err := h.UploadBytes(dChainConf, remoteconfig.GetRemoteOdysseyDChainConfig(), constants.SSHFileOpsTimeout); err != nil {
+	if err := h.UploadBytes(dChainConf, remoteconfig.GetRemoteOdysseyDChainConfig(h.config()), constants.SSHFileOpsTimeout); err != nil {
 		return err
 	}
 	return nil
 }
 
-func prepareGrafanaConfig() (string, string, string, string, error) {
-	grafanaDataSource, err := remoteconfig.RenderGrafanaLokiDataSourceConfig()
+func prepareGrafanaConfig(security *monitoring.Security, opts ...remoteconfig.RenderOption) (string, string, string, string, error) {
+	grafanaDataSource, err := remoteconfig.RenderGrafanaLokiDataSourceConfig(grafanaDataSourceInputs(security), opts...)
 	if err != nil {
 		return "", "", "", "", err
 	}
@@ -71,7 +79,7 @@ func prepareGrafanaConfig() (string, string, string, string, error) {
 		return "", "", "", "", err
 	}
 
-	grafanaPromDataSource, err := remoteconfig.RenderGrafanaPrometheusDataSourceConfigg()
+	grafanaPromDataSource, err := remoteconfig.RenderGrafanaPrometheusDataSourceConfigg(grafanaDataSourceInputs(security), opts...)
 	if err != nil {
 		return "", "", "", "", err
 	}
@@ -83,7 +91,7 @@ func prepareGrafanaConfig() (string, string, string, string, error) {
 		return "", "", "", "", err
 	}
 
-	grafanaDashboards, err := remoteconfig.RenderGrafanaDashboardConfig()
+	grafanaDashboards, err := remoteconfig.RenderGrafanaDashboardConfig(opts...)
 	if err != nil {
 		return "", "", "", "", err
 	}
@@ -95,7 +103,7 @@ func prepareGrafanaConfig() (string, string, string, string, error) {
 		return "", "", "", "", err
 	}
 
-	grafanaConfig, err := remoteconfig.RenderGrafanaConfig()
+	grafanaConfig, err := remoteconfig.RenderGrafanaConfig(opts...)
 	if err != nil {
 		return "", "", "", "", err
 	}
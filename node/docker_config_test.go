@@ -116,7 +116,7 @@ func TestNode_RunSSHRenderOdysseyNodeConfig_FeatureFlags(t *testing.T) {
 
 func TestPrepareGrafanaConfig(t *testing.T) {
 	// Test the prepareGrafanaConfig function
-	configFile, dashboardsFile, dataSourceFile, promDataSourceFile, err := prepareGrafanaConfig()
+	configFile, dashboardsFile, dataSourceFile, promDataSourceFile, err := prepareGrafanaConfig(nil)
 
 	// This function creates temporary files, so we expect it to succeed
 	assert.NoError(t, err)
@@ -141,7 +141,7 @@ func TestPrepareGrafanaConfig(t *testing.T) {
 func TestPrepareGrafanaConfig_MultipleCalls(t *testing.T) {
 	// Test multiple calls to prepareGrafanaConfig
 	for i := 0; i < 3; i++ {
-		configFile, dashboardsFile, dataSourceFile, promDataSourceFile, err := prepareGrafanaConfig()
+		configFile, dashboardsFile, dataSourceFile, promDataSourceFile, err := prepareGrafanaConfig(nil)
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, configFile)
@@ -18,7 +18,13 @@ func (h *Node) PullDockerImage(image string) error {
 	}
 
 	h.Logger.Infof("Pulling docker image %s on %s", image, h.NodeID)
-	_, err := h.Commandf(nil, constants.SSHLongRunningScriptTimeout, "docker pull %s", image)
+	if h.platform != nil {
+		// pull the image matching the node architecture, e.g. linux/arm64 on Graviton/Ampere hosts.
+		// If it is not published for it, PrepareDockerImageWithRepo builds it on the node instead
+		_, err := h.dockerCommandf(constants.SSHLongRunningScriptTimeout, "docker pull --platform %s %s", h.platform.DockerPlatform(), image)
+		return err
+	}
+	_, err := h.dockerCommandf(constants.SSHLongRunningScriptTimeout, "docker pull %s", image)
 	return err
 }
 
@@ -29,7 +35,7 @@ func (h *Node) DockerLocalImageExists(image string) (bool, error) {
 		return false, fmt.Errorf("Docker support functionality is disabled. Set constants.DockerSupportEnabled = true to enable")
 	}
 
-	output, err := h.Command(nil, constants.SSHLongRunningScriptTimeout, "docker images --format '{{.Repository}}:{{.Tag}}'")
+	output, err := h.dockerCommandf(constants.SSHLongRunningScriptTimeout, "docker images --format '{{.Repository}}:{{.Tag}}'")
 	if err != nil {
 		return false, err
 	}
@@ -53,7 +59,7 @@ func (h *Node) BuildDockerImage(image string, path string, dockerfile string) er
 		return fmt.Errorf("Docker support functionality is disabled. Set constants.DockerSupportEnabled = true to enable")
 	}
 
-	_, err := h.Commandf(nil, constants.SSHLongRunningScriptTimeout, "cd %s && docker build -q --build-arg GO_VERSION=%s -t %s -f %s .", path, constants.BuildEnvGolangVersion, image, dockerfile)
+	_, err := h.dockerCommandf(constants.SSHLongRunningScriptTimeout, "cd %s && docker build -q --build-arg GO_VERSION=%s -t %s -f %s .", path, constants.BuildEnvGolangVersion, image, dockerfile)
 	return err
 }
 
@@ -0,0 +1,318 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	sdkconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/config"
+	"github.com/pkg/sftp"
+)
+
+// DefaultDockerNodeImage is the image of the containers created by a DockerProvider. It is
+// built from templates/node.Dockerfile by the provider if missing from the docker engine
+const DefaultDockerNodeImage = "odyssey-cli-node:ubuntu-22.04"
+
+//go:embed templates/node.Dockerfile
+var dockerNodeDockerfile []byte
+
+// dockerNodeNamePrefix starts the names of the containers created by a DockerProvider
+const dockerNodeNamePrefix = "odyssey-node"
+
+var (
+	ErrDockerCommand   = errors.New("docker command failed")
+	ErrNotDockerNode   = errors.New("node was not created by a docker provider")
+	ErrNoContainerPort = errors.New("container port is not published")
+)
+
+// runDocker runs the docker CLI with args. It is replaced in tests
+var runDocker = func(ctx context.Context, stdin io.Reader, stdout io.Writer, stderr io.Writer, args ...string) error {
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return cmd.Run()
+}
+
+// dockerOutput runs the docker CLI with args, returning its trimmed stdout
+func dockerOutput(ctx context.Context, args ...string) (string, error) {
+	return dockerOutputWithInput(ctx, nil, args...)
+}
+
+// dockerOutputWithInput runs the docker CLI with args and stdin, returning its trimmed stdout
+func dockerOutputWithInput(ctx context.Context, stdin io.Reader, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	if err := runDocker(ctx, stdin, &stdout, &stderr, args...); err != nil {
+		return "", fmt.Errorf("%w: docker %s: %w: %s", ErrDockerCommand, args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// DockerProviderOp holds the options of NewDockerProvider
+type DockerProviderOp struct {
+	image          string
+	network        string
+	publishedPorts []uint
+}
+
+// DockerProviderOption configures NewDockerProvider
+type DockerProviderOption func(*DockerProviderOp)
+
+// WithDockerImage makes the provider create containers of image instead of
+// DefaultDockerNodeImage. The image is not built by the provider, and must provide what
+// DefaultDockerNodeImage does, its command keeping the container running
+func WithDockerImage(image string) DockerProviderOption {
+	return func(op *DockerProviderOp) {
+		op.image = image
+	}
+}
+
+// WithDockerNetwork attaches the containers to the docker network instead of the default
+// bridge network
+func WithDockerNetwork(network string) DockerProviderOption {
+	return func(op *DockerProviderOp) {
+		op.network = network
+	}
+}
+
+// WithPublishedPorts publishes the container ports on random ports of the loopback interface
+// of the local machine, instead of the odysseygo API and P2P ports of NodeParams.Config. See
+// HostPort
+func WithPublishedPorts(ports ...uint) DockerProviderOption {
+	return func(op *DockerProviderOp) {
+		op.publishedPorts = ports
+	}
+}
+
+// DockerProvider creates nodes as containers of the local docker engine, to run a cluster
+// on a laptop with the same fleet APIs as on remote hosts. The commands and file transfers
+// of its nodes run with docker exec instead of SSH, and the nodes are destroyed with their
+// container by Node.Destroy and DestroyNodes.
+//
+// The nodes are provisioned with the roles of the provider NodeParams, so the image must
+// provide what the provisioning scripts use on remote hosts: systemd, the ubuntu user with
+// passwordless sudo, sshd and a docker engine for the Docker runtime, as
+// DefaultDockerNodeImage does. The containers are privileged for that reason.
+//
+// Node.IP is the address of the container on its docker network, which the other containers
+// reach it at, but which the local machine may not, e.g. with Docker Desktop. The ports
+// published on the local machine are recorded when the container is created: HostPort
+// returns them, and the connections of the SDK to the node, e.g. Node.WaitForPort, go
+// through them. DockerProvider implements NodeProvider
+type DockerProvider struct {
+	nodeParams NodeParams
+	op         DockerProviderOp
+}
+
+// NewDockerProvider returns a DockerProvider creating nodes provisioned with nodeParams. The
+// nodes are not provisioned if nodeParams has no roles
+func NewDockerProvider(nodeParams NodeParams, opts ...DockerProviderOption) *DockerProvider {
+	config := sdkconfig.Get().WithNodeDefaults(nodeParams.Config)
+	op := DockerProviderOp{
+		image:          DefaultDockerNodeImage,
+		publishedPorts: []uint{config.OdysseygoAPIPort, config.OdysseygoP2PPort},
+	}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	return &DockerProvider{nodeParams: nodeParams, op: op}
+}
+
+// CreateNodes creates count containers and provisions them. The nodes created are returned
+// even if some of them failed to be provisioned, so that they can be destroyed
+func (p *DockerProvider) CreateNodes(ctx context.Context, count int) ([]Node, error) {
+	if err := p.ensureImage(ctx); err != nil {
+		return nil, err
+	}
+	nodes := []Node{}
+	for i := 0; i < count; i++ {
+		node, err := p.createNode(ctx)
+		if err != nil {
+			return nodes, err
+		}
+		nodes = append(nodes, node)
+	}
+	if len(p.nodeParams.Roles) == 0 {
+		return nodes, nil
+	}
+	toProvision := make([]*Node, 0, len(nodes))
+	for i := range nodes {
+		toProvision = append(toProvision, &nodes[i])
+	}
+	return nodes, ProvisionNodes(ctx, toProvision, &p.nodeParams, 0).Error()
+}
+
+// ensureImage builds DefaultDockerNodeImage if it is the image of the provider and is missing
+// from the docker engine
+func (p *DockerProvider) ensureImage(ctx context.Context) error {
+	if p.op.image != DefaultDockerNodeImage {
+		return nil
+	}
+	if _, err := dockerOutput(ctx, "image", "inspect", "--format", "{{.Id}}", p.op.image); err == nil {
+		return nil
+	}
+	_, err := dockerOutputWithInput(ctx, bytes.NewReader(dockerNodeDockerfile), "build", "--tag", p.op.image, "-")
+	return err
+}
+
+func (p *DockerProvider) createNode(ctx context.Context) (Node, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return Node{}, err
+	}
+	name := fmt.Sprintf("%s-%s", dockerNodeNamePrefix, hex.EncodeToString(suffix))
+	labels := map[string]string{}
+	if len(p.nodeParams.Roles) > 0 {
+		var err error
+		if labels, err = p.nodeParams.ResourceLabels(p.nodeParams.Roles[0]); err != nil {
+			return Node{}, err
+		}
+	}
+	// systemd runs as PID 1, and the docker engine of the node stores its data in a volume
+	args := []string{
+		"run", "--detach", "--privileged", "--cgroupns", "host", "--name", name, "--hostname", name,
+		"--tmpfs", "/run", "--tmpfs", "/run/lock", "--volume", "/var/lib/docker",
+	}
+	for key, value := range labels {
+		args = append(args, "--label", key+"="+value)
+	}
+	if p.op.network != "" {
+		args = append(args, "--network", p.op.network)
+	}
+	for _, port := range p.op.publishedPorts {
+		args = append(args, "--publish", fmt.Sprintf("127.0.0.1::%d", port))
+	}
+	args = append(args, p.op.image)
+	if _, err := dockerOutput(ctx, args...); err != nil {
+		return Node{}, err
+	}
+	ips, err := dockerOutput(ctx, "inspect", "--format", "{{range .NetworkSettings.Networks}}{{.IPAddress}} {{end}}", name)
+	if err == nil && len(strings.Fields(ips)) == 0 {
+		err = fmt.Errorf("%w: container %s has no IP address", ErrDockerCommand, name)
+	}
+	var hostPorts map[uint]string
+	if err == nil {
+		hostPorts, err = publishedPorts(ctx, name)
+	}
+	if err != nil {
+		return Node{}, errors.Join(err, removeContainer(ctx, name))
+	}
+	node := Node{
+		NodeID: name,
+		IP:     strings.Fields(ips)[0],
+		Roles:  p.nodeParams.Roles,
+		Labels: labels,
+	}
+	node.SetSSHClient(&dockerExecClient{container: name, ip: net.ParseIP(node.IP), hostPorts: hostPorts})
+	return node, nil
+}
+
+// publishedPorts returns the addresses of the local machine publishing the TCP ports of the
+// container, by port
+func publishedPorts(ctx context.Context, container string) (map[uint]string, error) {
+	output, err := dockerOutput(ctx, "port", container)
+	if err != nil {
+		return nil, err
+	}
+	hostPorts := map[uint]string{}
+	// one address is listed per line, e.g. 9650/tcp -> 127.0.0.1:32768, and per port for IPv4
+	// and IPv6
+	for _, line := range strings.Split(output, "\n") {
+		containerPort, hostAddr, ok := strings.Cut(line, " -> ")
+		if !ok || !strings.HasSuffix(containerPort, "/tcp") {
+			continue
+		}
+		port, err := strconv.ParseUint(strings.TrimSuffix(containerPort, "/tcp"), 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unexpected docker port output %q", ErrDockerCommand, line)
+		}
+		if _, ok := hostPorts[uint(port)]; !ok {
+			hostPorts[uint(port)] = strings.TrimSpace(hostAddr)
+		}
+	}
+	return hostPorts, nil
+}
+
+// DestroyNode removes the container of node
+func (p *DockerProvider) DestroyNode(ctx context.Context, node *Node) error {
+	return node.Destroy(ctx)
+}
+
+// HostPort returns the address of the local machine publishing containerPort of node, e.g.
+// 127.0.0.1:32768 for the odysseygo API port, as recorded when the container was created
+func (*DockerProvider) HostPort(node *Node, containerPort uint) (string, error) {
+	client, ok := node.connection.(*dockerExecClient)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNotDockerNode, node.NodeID)
+	}
+	hostAddr, ok := client.hostPorts[containerPort]
+	if !ok {
+		return "", fmt.Errorf("%w: %d of node %s", ErrNoContainerPort, containerPort, node.NodeID)
+	}
+	return hostAddr, nil
+}
+
+func removeContainer(ctx context.Context, container string) error {
+	_, err := dockerOutput(ctx, "rm", "--force", "--volumes", container)
+	return err
+}
+
+// dockerExecClient is the SSHClient of the nodes created by a DockerProvider, running the
+// commands in their container with docker exec
+type dockerExecClient struct {
+	container string
+	ip        net.IP
+
+	// hostPorts are the addresses of the local machine publishing the ports of the container
+	hostPorts map[uint]string
+}
+
+func (c *dockerExecClient) Run(ctx context.Context, env []string, script string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	args := []string{"exec"}
+	if stdin != nil {
+		args = append(args, "--interactive")
+	}
+	for _, item := range env {
+		args = append(args, "--env", item)
+	}
+	args = append(args, c.container, "sh", "-c", script)
+	return runDocker(ctx, stdin, stdout, stderr, args...)
+}
+
+// NewSftp fails as containers run no SFTP server, so that files are streamed through Run
+func (*dockerExecClient) NewSftp() (*sftp.Client, error) {
+	return nil, ErrSFTPUnavailable
+}
+
+// DialTCP connects to addr from the local machine. Loopback addresses are those of the
+// container. The ports of the container published on the local machine are dialed there, as
+// its docker network may not be reachable, e.g. with Docker Desktop
+func (c *dockerExecClient) DialTCP(addr *net.TCPAddr) (net.Conn, error) {
+	if addr.IP.IsLoopback() || addr.IP.Equal(c.ip) {
+		if hostAddr, ok := c.hostPorts[uint(addr.Port)]; ok {
+			return net.Dial("tcp", hostAddr)
+		}
+		addr = &net.TCPAddr{IP: c.ip, Port: addr.Port}
+	}
+	return net.DialTCP("tcp", nil, addr)
+}
+
+func (c *dockerExecClient) RemoteAddr() net.Addr {
+	return &net.TCPAddr{IP: c.ip}
+}
+
+func (*dockerExecClient) Close() error {
+	return nil
+}
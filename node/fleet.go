@@ -0,0 +1,101 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNodeUnhealthy is the result error of the nodes reported unhealthy by CheckNodesHealth
+var ErrNodeUnhealthy = errors.New("odysseygo is not healthy")
+
+// The fleet operations below run concurrently on the nodes, with at most limit nodes at once
+// or all of them if limit is not positive. Nodes not started when ctx is done fail with
+// ctx.Err(). A failure on a node does not stop the others: use NodeResults.Failed and
+// NodeResults.Succeeded to handle partial failures, e.g. to retry the failed nodes only
+
+// ProvisionNodes connects to the nodes and installs the nodeParams roles on them. nodeParams
+// is validated first: if invalid, all the nodes fail with the error of NodeParams.Validate
+// without being connected to
+func ProvisionNodes(ctx context.Context, nodes []*Node, nodeParams *NodeParams, limit int) *NodeResults {
+	paramsErr := nodeParams.Validate()
+	return RunOnNodes(nodes, limit, func(node *Node) (interface{}, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if paramsErr != nil {
+			return nil, paramsErr
+		}
+		// the runtime mode is kept on the node for its later operations
+		node.RuntimeMode = nodeParams.RuntimeMode
+		return nil, provisionHost(*node, nodeParams)
+	})
+}
+
+// UpgradeNodes upgrades odysseygo to odysseyGoVersion on the nodes, waiting up to
+// healthTimeout for each of them to be healthy
+func UpgradeNodes(ctx context.Context, nodes []*Node, odysseyGoVersion string, healthTimeout time.Duration, limit int) *NodeResults {
+	return RunOnNodes(nodes, limit, func(node *Node) (interface{}, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, node.UpgradeOdysseyGo(odysseyGoVersion, healthTimeout)
+	})
+}
+
+// CheckNodesHealth checks the odysseygo health of the nodes. Unhealthy nodes fail with
+// ErrNodeUnhealthy, and the value of each result tells if the node is healthy
+func CheckNodesHealth(ctx context.Context, nodes []*Node) *NodeResults {
+	return RunOnNodes(nodes, 0, func(node *Node) (interface{}, error) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		healthy, err := node.GetOdysseyGoHealth()
+		if err != nil {
+			return false, err
+		}
+		if !healthy {
+			return false, fmt.Errorf("%w on node %s", ErrNodeUnhealthy, node.NodeID)
+		}
+		return true, nil
+	})
+}
+
+// DestroyNodes destroys the nodes
+func DestroyNodes(ctx context.Context, nodes []*Node) *NodeResults {
+	return RunOnNodes(nodes, 0, func(node *Node) (interface{}, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, node.Destroy(ctx)
+	})
+}
+
+// RefreshHostKeys records the current host keys of the nodes in place of their known ones,
+// see Node.RefreshHostKey. Only refresh the nodes known to have been rebuilt, as a changed
+// host key may otherwise be an attempt to intercept the connections
+func RefreshHostKeys(ctx context.Context, nodes []*Node, limit int) *NodeResults {
+	return RunOnNodes(nodes, limit, func(node *Node) (interface{}, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, node.RefreshHostKey()
+	})
+}
+
+// WaitForNodesBootstrap waits up to timeout for each of the nodes to finish bootstrapping
+// chains, the primary network chains if none is given, e.g. before registering them as
+// validators. The value of each result is the last BootstrapStatus of the node, and nodes
+// not bootstrapped in time fail with ErrNotBootstrapped
+func WaitForNodesBootstrap(ctx context.Context, nodes []*Node, timeout time.Duration, limit int, chains ...string) *NodeResults {
+	return RunOnNodes(nodes, limit, func(node *Node) (interface{}, error) {
+		if err := ctx.Err(); err != nil {
+			return BootstrapStatus{}, err
+		}
+		return node.WaitForBootstrap(ctx, timeout, chains...)
+	})
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestroyNodes(t *testing.T) {
+	nodes := []*Node{{NodeID: "node-1"}, {NodeID: "node-2"}}
+	results := DestroyNodes(context.Background(), nodes)
+	require.Len(t, results.Failed(), 2)
+	for _, result := range results.Failed() {
+		assert.ErrorIs(t, result.Err, ErrCloudRemoved)
+	}
+}
+
+func TestFleetOperations_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	nodes := []*Node{{NodeID: "node-1"}, {NodeID: "node-2"}}
+	for _, results := range []*NodeResults{
+		ProvisionNodes(ctx, nodes, &NodeParams{}, 1),
+		UpgradeNodes(ctx, nodes, "v1.10.13", 0, 1),
+		CheckNodesHealth(ctx, nodes),
+		DestroyNodes(ctx, nodes),
+	} {
+		assert.Empty(t, results.Succeeded())
+		for _, result := range results.Failed() {
+			assert.ErrorIs(t, result.Err, context.Canceled)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+)
+
+// HardeningArea is a group of settings applied by RunSSHHardenHost
+type HardeningArea string
+
+const (
+	HardeningSecurityUpdates HardeningArea = "security-updates"
+	HardeningFail2Ban        HardeningArea = "fail2ban"
+	HardeningSSH             HardeningArea = "ssh"
+	HardeningSysctl          HardeningArea = "sysctl"
+)
+
+// hardeningReportPrefix starts the lines of the output of hardenHost.sh reporting a setting
+const hardeningReportPrefix = "HARDENING\t"
+
+var (
+	ErrInvalidSysctl  = errors.New("invalid sysctl setting")
+	ErrRootSSHLockout = errors.New("disabling root login would lock out the SSH user, which is root")
+
+	// sysctlKeyRegex matches kernel parameter names, e.g. net.core.somaxconn
+	sysctlKeyRegex = regexp.MustCompile(`^[a-z0-9_]+(\.[a-z0-9_-]+)+$`)
+	// sysctlValueRegex matches numeric kernel parameter values, e.g. "1024 65535"
+	sysctlValueRegex = regexp.MustCompile(`^[0-9]+( [0-9]+)*$`)
+)
+
+// hardeningSSHSettings disable the password and root logins over SSH. Nodes are only
+// accessed with SSH keys, by a non-root user with sudo
+var hardeningSSHSettings = []string{
+	"PasswordAuthentication no",
+	"KbdInteractiveAuthentication no",
+	"PermitEmptyPasswords no",
+	"PermitRootLogin no",
+	"MaxAuthTries 3",
+	"X11Forwarding no",
+}
+
+// DefaultHardeningSysctls are the kernel parameters set by RunSSHHardenHost, sized for the
+// thousands of peer and RPC connections of a validator
+var DefaultHardeningSysctls = map[string]string{
+	"fs.file-max":                        "2097152",
+	"net.core.somaxconn":                 "65535",
+	"net.core.netdev_max_backlog":        "65535",
+	"net.core.rmem_max":                  "16777216",
+	"net.core.wmem_max":                  "16777216",
+	"net.ipv4.ip_local_port_range":       "1024 65535",
+	"net.ipv4.tcp_max_syn_backlog":       "65535",
+	"net.ipv4.tcp_fin_timeout":           "15",
+	"net.ipv4.tcp_tw_reuse":              "1",
+	"net.ipv4.tcp_syncookies":            "1",
+	"net.ipv4.conf.all.rp_filter":        "1",
+	"net.ipv4.conf.all.accept_redirects": "0",
+	"net.ipv4.conf.all.send_redirects":   "0",
+}
+
+// HardeningParams configures the baseline hardening of a node. The zero value applies all of it
+type HardeningParams struct {
+	// SkipSecurityUpdates leaves the automatic security upgrades unconfigured: unattended-upgrades
+	// on apt hosts, dnf-automatic or yum-cron otherwise
+	SkipSecurityUpdates bool
+
+	// SkipFail2Ban leaves fail2ban uninstalled. Its jail bans for an hour the addresses failing
+	// 5 SSH logins in 10 minutes
+	SkipFail2Ban bool
+
+	// SkipSSH leaves the password and root logins over SSH enabled
+	SkipSSH bool
+
+	// SkipSysctl leaves the kernel parameters unchanged
+	SkipSysctl bool
+
+	// Sysctls overrides or adds kernel parameters to DefaultHardeningSysctls
+	Sysctls map[string]string
+}
+
+// sysctlSetting is a kernel parameter rendered in hardenHost.sh
+type sysctlSetting struct {
+	Key   string
+	Value string
+}
+
+// sysctls returns the kernel parameters of p sorted by key
+func (p HardeningParams) sysctls() ([]sysctlSetting, error) {
+	if p.SkipSysctl {
+		return nil, nil
+	}
+	values := map[string]string{}
+	for key, value := range DefaultHardeningSysctls {
+		values[key] = value
+	}
+	for key, value := range p.Sysctls {
+		values[key] = value
+	}
+	settings := make([]sysctlSetting, 0, len(values))
+	for key, value := range values {
+		if !sysctlKeyRegex.MatchString(key) || !sysctlValueRegex.MatchString(value) {
+			return nil, fmt.Errorf("%w %q = %q", ErrInvalidSysctl, key, value)
+		}
+		settings = append(settings, sysctlSetting{Key: key, Value: value})
+	}
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Key < settings[j].Key })
+	return settings, nil
+}
+
+// HardeningChange is a setting checked by RunSSHHardenHost
+type HardeningChange struct {
+	Area HardeningArea
+
+	// Setting describes the setting, e.g. "PermitRootLogin no" or "package fail2ban"
+	Setting string
+
+	// Changed tells if the setting was applied, or was already in place
+	Changed bool
+}
+
+// HardeningReport is the result of RunSSHHardenHost
+type HardeningReport struct {
+	NodeID  string
+	Changes []HardeningChange
+}
+
+// Applied returns the settings that were not in place before the hardening
+func (r HardeningReport) Applied() []HardeningChange {
+	applied := []HardeningChange{}
+	for _, change := range r.Changes {
+		if change.Changed {
+			applied = append(applied, change)
+		}
+	}
+	return applied
+}
+
+// String lists the applied settings of the report
+func (r HardeningReport) String() string {
+	applied := r.Applied()
+	if len(applied) == 0 {
+		return fmt.Sprintf("node %s: all %d hardening settings already in place", r.NodeID, len(r.Changes))
+	}
+	descriptions := make([]string, 0, len(applied))
+	for _, change := range applied {
+		descriptions = append(descriptions, fmt.Sprintf("%s: %s", change.Area, change.Setting))
+	}
+	return fmt.Sprintf("node %s: applied %d of %d hardening settings: %s", r.NodeID, len(applied), len(r.Changes), strings.Join(descriptions, ", "))
+}
+
+// RunSSHHardenHost applies a baseline hardening to the node: automatic security upgrades,
+// fail2ban on SSH, no password nor root login over SSH and kernel parameters for validator
+// workloads. Settings already in place are left untouched, and the report tells which ones
+// were applied. The SSH config is only reloaded once validated by sshd, so the current
+// sessions are kept
+func (h *Node) RunSSHHardenHost(params HardeningParams) (HardeningReport, error) {
+	sysctls, err := params.sysctls()
+	if err != nil {
+		return HardeningReport{}, err
+	}
+	if err := h.RequirePrivilege(); err != nil {
+		return HardeningReport{}, fmt.Errorf("hardening requires root privileges: %w", err)
+	}
+	if !params.SkipSSH && *h.privilege == PrivilegeRoot {
+		return HardeningReport{}, fmt.Errorf("node %s: %w", h.NodeID, ErrRootSSHLockout)
+	}
+	platform, err := h.DetectPlatform()
+	if err != nil {
+		return HardeningReport{}, err
+	}
+	if err := platform.Supported(); err != nil {
+		return HardeningReport{}, err
+	}
+	inputs := scriptInputs{
+		PackageManager:  string(platform.PackageManager),
+		SecurityUpdates: !params.SkipSecurityUpdates,
+		Fail2Ban:        !params.SkipFail2Ban,
+		Sysctls:         sysctls,
+	}
+	if !params.SkipSSH {
+		inputs.SSHSettings = hardeningSSHSettings
+	}
+	script, err := renderScript("Harden Host", "shell/hardenHost.sh", inputs)
+	if err != nil {
+		return HardeningReport{}, err
+	}
+	output, err := h.Command(nil, constants.SSHLongRunningScriptTimeout, script)
+	if err != nil {
+		return HardeningReport{}, fmt.Errorf("failed to harden node %s: %w: %s", h.NodeID, err, string(output))
+	}
+	report, err := parseHardeningOutput(string(output))
+	if err != nil {
+		return HardeningReport{}, fmt.Errorf("failed to harden node %s: %w", h.NodeID, err)
+	}
+	report.NodeID = h.NodeID
+	return report, nil
+}
+
+// parseHardeningOutput parses the settings reported by hardenHost.sh, ignoring the other lines
+func parseHardeningOutput(output string) (HardeningReport, error) {
+	report := HardeningReport{Changes: []HardeningChange{}}
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.HasPrefix(line, hardeningReportPrefix) {
+			continue
+		}
+		fields := strings.Split(strings.TrimPrefix(strings.TrimRight(line, "\r"), hardeningReportPrefix), "\t")
+		if len(fields) != 3 || (fields[2] != "changed" && fields[2] != "unchanged") {
+			return HardeningReport{}, fmt.Errorf("unexpected hardening output: %q", line)
+		}
+		report.Changes = append(report.Changes, HardeningChange{
+			Area:    HardeningArea(fields[0]),
+			Setting: fields[1],
+			Changed: fields[2] == "changed",
+		})
+	}
+	return report, nil
+}
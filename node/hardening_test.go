@@ -0,0 +1,108 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHardeningParams_Sysctls(t *testing.T) {
+	sysctls, err := HardeningParams{}.sysctls()
+	require.NoError(t, err)
+	require.Len(t, sysctls, len(DefaultHardeningSysctls))
+	assert.Equal(t, sysctlSetting{Key: "fs.file-max", Value: "2097152"}, sysctls[0])
+
+	sysctls, err = HardeningParams{Sysctls: map[string]string{
+		"net.core.somaxconn": "4096",
+		"vm.swappiness":      "10",
+	}}.sysctls()
+	require.NoError(t, err)
+	assert.Len(t, sysctls, len(DefaultHardeningSysctls)+1)
+	assert.Contains(t, sysctls, sysctlSetting{Key: "net.core.somaxconn", Value: "4096"})
+	assert.Contains(t, sysctls, sysctlSetting{Key: "vm.swappiness", Value: "10"})
+
+	sysctls, err = HardeningParams{SkipSysctl: true}.sysctls()
+	require.NoError(t, err)
+	assert.Empty(t, sysctls)
+
+	for key, value := range map[string]string{
+		"net.core.somaxconn; reboot": "1",
+		"somaxconn":                  "1",
+		"vm.swappiness":              "10\nreboot",
+	} {
+		_, err := HardeningParams{Sysctls: map[string]string{key: value}}.sysctls()
+		assert.ErrorIs(t, err, ErrInvalidSysctl)
+	}
+}
+
+func TestParseHardeningOutput(t *testing.T) {
+	report, err := parseHardeningOutput("Reading package lists...\n" +
+		"HARDENING\tfail2ban\tpackage fail2ban\tchanged\n" +
+		"HARDENING\tssh\tPermitRootLogin no\tunchanged\n" +
+		"HARDENING\tsysctl\tnet.core.somaxconn = 65535\tchanged\r\n" +
+		"Host hardening applied.\n")
+	require.NoError(t, err)
+	report.NodeID = "node-1"
+	assert.Equal(t, []HardeningChange{
+		{Area: HardeningFail2Ban, Setting: "package fail2ban", Changed: true},
+		{Area: HardeningSSH, Setting: "PermitRootLogin no"},
+		{Area: HardeningSysctl, Setting: "net.core.somaxconn = 65535", Changed: true},
+	}, report.Changes)
+	assert.Len(t, report.Applied(), 2)
+	assert.Equal(t,
+		"node node-1: applied 2 of 3 hardening settings: fail2ban: package fail2ban, sysctl: net.core.somaxconn = 65535",
+		report.String(),
+	)
+
+	report = HardeningReport{NodeID: "node-1", Changes: []HardeningChange{{Area: HardeningSSH, Setting: "MaxAuthTries 3"}}}
+	assert.Equal(t, "node node-1: all 1 hardening settings already in place", report.String())
+
+	_, err = parseHardeningOutput("HARDENING\tssh\tPermitRootLogin no\n")
+	assert.Error(t, err)
+}
+
+func TestRunSSHHardenHost_Refused(t *testing.T) {
+	mode := PrivilegeRoot
+	h := &Node{NodeID: "node-1", privilege: &mode}
+	_, err := h.RunSSHHardenHost(HardeningParams{})
+	assert.ErrorIs(t, err, ErrRootSSHLockout)
+
+	_, err = h.RunSSHHardenHost(HardeningParams{Sysctls: map[string]string{"vm.swappiness": "$(reboot)"}})
+	assert.ErrorIs(t, err, ErrInvalidSysctl)
+}
+
+func TestHardenHostScript(t *testing.T) {
+	sysctls, err := HardeningParams{}.sysctls()
+	require.NoError(t, err)
+	for packageManager, updates := range map[string]string{
+		"apt": "unattended-upgrades",
+		"dnf": "dnf-automatic",
+		"yum": "yum-cron",
+	} {
+		rendered, err := renderScript("Harden Host", "shell/hardenHost.sh", scriptInputs{
+			PackageManager:  packageManager,
+			SecurityUpdates: true,
+			Fail2Ban:        true,
+			SSHSettings:     hardeningSSHSettings,
+			Sysctls:         sysctls,
+		})
+		require.NoError(t, err)
+		assert.Contains(t, rendered, "ensure_package "+updates+" security-updates")
+		assert.Contains(t, rendered, "ensure_package fail2ban fail2ban")
+		assert.Contains(t, rendered, "\techo \"PermitRootLogin no\"\n")
+		assert.Contains(t, rendered, "ssh_report \"PasswordAuthentication no\"\n")
+		assert.Contains(t, rendered, "<<'EOF'\nfs.file-max = 2097152\n")
+		assert.Contains(t, rendered, "net.ipv4.tcp_tw_reuse = 1\nEOF\n")
+	}
+
+	rendered, err := renderScript("Harden Host", "shell/hardenHost.sh", scriptInputs{PackageManager: "apt"})
+	require.NoError(t, err)
+	assert.NotContains(t, rendered, "security-updates")
+	assert.NotContains(t, rendered, "ensure_package fail2ban")
+	assert.NotContains(t, rendered, "sshd")
+	assert.NotContains(t, rendered, "sysctl")
+}
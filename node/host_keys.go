@@ -0,0 +1,55 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	sdkconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/config"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/hostkeys"
+)
+
+// hostKeyPolicy returns how the host key of the node is verified
+func (h *Node) hostKeyPolicy() hostkeys.Policy {
+	if h.SSHConfig.HostKeyPolicy != "" {
+		return h.SSHConfig.HostKeyPolicy
+	}
+	if policy := sdkconfig.Get().SSH.HostKeyPolicy; policy != "" {
+		return hostkeys.Policy(policy)
+	}
+	return hostkeys.TrustOnFirstUse
+}
+
+// HostKeyStore returns the known_hosts file recording the host key of the node
+func (h *Node) HostKeyStore() (*hostkeys.Store, error) {
+	if h.SSHConfig.KnownHostsPath != "" {
+		return hostkeys.NewStore(h.SSHConfig.KnownHostsPath), nil
+	}
+	path, err := sdkconfig.Get().KnownHostsPath()
+	if err != nil {
+		return nil, err
+	}
+	return hostkeys.NewStore(path), nil
+}
+
+// RefreshHostKey forgets the recorded host key of the node and reconnects to it, recording
+// its current key. Use it when the node was legitimately rebuilt with a new host key, as
+// connections to it fail with hostkeys.ErrHostKeyMismatch otherwise
+func (h *Node) RefreshHostKey() error {
+	if h.connection != nil {
+		if _, ok := h.connection.(gophSSHClient); !ok {
+			return ErrUnsupportedSSHClient
+		}
+		if err := h.Disconnect(); err != nil {
+			return err
+		}
+		h.connection = nil
+	}
+	store, err := h.HostKeyStore()
+	if err != nil {
+		return err
+	}
+	if err := store.Forget(h.IP); err != nil {
+		return err
+	}
+	return h.connect(0, hostkeys.TrustOnFirstUse)
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/hostkeys"
+)
+
+// startHostKeyServer serves SSH handshakes on a local port with a new host key, accepting any
+// client, and returns the port
+func startHostKeyServer(t *testing.T) uint {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(private)
+	require.NoError(t, err)
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(signer)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				if _, chans, reqs, err := ssh.NewServerConn(conn, config); err == nil {
+					go ssh.DiscardRequests(reqs)
+					for newChannel := range chans {
+						_ = newChannel.Reject(ssh.Prohibited, "no channels")
+					}
+				}
+			}()
+		}
+	}()
+	return uint(listener.Addr().(*net.TCPAddr).Port)
+}
+
+func TestNewNodeConnection_HostKeys(t *testing.T) {
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "id_ed25519")
+	_, err := GenerateSSHKeyPair(keyPath)
+	require.NoError(t, err)
+	newNode := func(policy hostkeys.Policy) *Node {
+		return &Node{IP: "127.0.0.1", SSHConfig: SSHConfig{
+			User:           "ubuntu",
+			PrivateKeyPath: keyPath,
+			HostKeyPolicy:  policy,
+			KnownHostsPath: filepath.Join(dir, "known_hosts"),
+		}}
+	}
+	port := startHostKeyServer(t)
+
+	// the host is unknown
+	_, err = NewNodeConnection(newNode(hostkeys.Strict), port)
+	require.ErrorIs(t, err, hostkeys.ErrUnknownHost)
+
+	// the first connection records the host key, which is then trusted
+	client, err := NewNodeConnection(newNode(""), port)
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+	client, err = NewNodeConnection(newNode(hostkeys.Strict), port)
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+
+	// another key is recorded for the address, e.g. the connection is intercepted
+	otherPort := startHostKeyServer(t)
+	otherAddr := net.JoinHostPort("127.0.0.1", strconv.FormatUint(uint64(otherPort), 10))
+	store, err := newNode("").HostKeyStore()
+	require.NoError(t, err)
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ssh.NewPublicKey(public)
+	require.NoError(t, err)
+	require.NoError(t, store.Add(otherAddr, otherKey))
+	err = newNode("").Connect(otherPort)
+	require.ErrorIs(t, err, hostkeys.ErrHostKeyMismatch)
+	require.ErrorIs(t, err, ErrNotConnected)
+
+	// the known key is kept until refreshed
+	client, err = NewNodeConnection(newNode(hostkeys.InsecureIgnore), otherPort)
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+	_, err = NewNodeConnection(newNode(""), otherPort)
+	require.ErrorIs(t, err, hostkeys.ErrHostKeyMismatch)
+	require.NoError(t, store.Forget(otherAddr))
+	client, err = NewNodeConnection(newNode(""), otherPort)
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+
+	_, err = NewNodeConnection(newNode("yes"), port)
+	require.ErrorIs(t, err, hostkeys.ErrInvalidPolicy)
+}
+
+func TestNode_RefreshHostKey_UnsupportedClient(t *testing.T) {
+	node := &Node{}
+	node.SetSSHClient(&dockerExecClient{container: "node"})
+	require.ErrorIs(t, node.RefreshHostKey(), ErrUnsupportedSSHClient)
+}
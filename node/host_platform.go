@@ -0,0 +1,155 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+)
+
+var ErrUnsupportedPlatform = errors.New("unsupported host platform")
+
+// PackageManager is the system package manager available on a host
+type PackageManager string
+
+const (
+	UnknownPackageManager PackageManager = ""
+	Apt                   PackageManager = "apt"
+	Dnf                   PackageManager = "dnf"
+	Yum                   PackageManager = "yum"
+)
+
+const (
+	LinuxOS   = "linux"
+	WindowsOS = "windows"
+
+	AMD64Arch = "amd64"
+	ARM64Arch = "arm64"
+)
+
+// platformScript prints, one per line: kernel name, machine hardware name,
+// distribution ID and the path of the first package manager found
+const platformScript = `uname -s; uname -m; (. /etc/os-release 2>/dev/null && echo "$ID") || echo; ` +
+	`command -v apt-get || command -v dnf || command -v yum || echo`
+
+// HostPlatform describes the operating system and architecture of a host
+type HostPlatform struct {
+	// OS is the operating system of the host, e.g. linux or windows
+	OS string
+
+	// Arch is the CPU architecture of the host using Go/Docker naming, e.g. amd64 or arm64
+	Arch string
+
+	// Distro is the Linux distribution ID found in /etc/os-release, e.g. ubuntu or amzn
+	Distro string
+
+	// PackageManager is the package manager used to install dependencies on the host
+	PackageManager PackageManager
+}
+
+// DockerPlatform returns the docker platform string matching the host, e.g. linux/arm64
+func (p HostPlatform) DockerPlatform() string {
+	return fmt.Sprintf("%s/%s", p.OS, p.Arch)
+}
+
+// Supported checks if the SDK can provision the host
+func (p HostPlatform) Supported() error {
+	if p.OS != LinuxOS {
+		return fmt.Errorf("%w: %s hosts cannot be provisioned, only linux is supported", ErrUnsupportedPlatform, p.OS)
+	}
+	if p.Arch != AMD64Arch && p.Arch != ARM64Arch {
+		return fmt.Errorf("%w: %s architecture is not supported", ErrUnsupportedPlatform, p.Arch)
+	}
+	if p.PackageManager == UnknownPackageManager {
+		return fmt.Errorf("%w: no supported package manager (apt, dnf, yum) found on %s host", ErrUnsupportedPlatform, p.Distro)
+	}
+	return nil
+}
+
+// InstallPackagesCommand returns the shell command installing packages with the host package manager
+func (p HostPlatform) InstallPackagesCommand(packages ...string) (string, error) {
+	pkgs := strings.Join(packages, " ")
+	switch p.PackageManager {
+	case Apt:
+		return fmt.Sprintf("sudo DEBIAN_FRONTEND=noninteractive apt-get -y update && sudo DEBIAN_FRONTEND=noninteractive apt-get -y install %s", pkgs), nil
+	case Dnf, Yum:
+		return fmt.Sprintf("sudo %s -y install %s", p.PackageManager, pkgs), nil
+	default:
+		return "", fmt.Errorf("%w: unknown package manager", ErrUnsupportedPlatform)
+	}
+}
+
+// DetectPlatform detects the operating system, architecture and package manager of the node
+// over SSH. The result is cached on the node.
+func (h *Node) DetectPlatform() (HostPlatform, error) {
+	if h.platform != nil {
+		return *h.platform, nil
+	}
+	output, err := h.Command(nil, constants.SSHScriptTimeout, platformScript)
+	if err != nil {
+		// uname is not available on Windows, whose default OpenSSH shell is cmd.exe
+		if verOutput, verErr := h.Command(nil, constants.SSHScriptTimeout, "ver"); verErr == nil && strings.Contains(string(verOutput), "Windows") {
+			platform := HostPlatform{OS: WindowsOS, Arch: AMD64Arch}
+			h.platform = &platform
+			return platform, nil
+		}
+		return HostPlatform{}, fmt.Errorf("failed to detect platform of node %s: %w: %s", h.NodeID, err, string(output))
+	}
+	platform, err := parsePlatformOutput(string(output))
+	if err != nil {
+		return HostPlatform{}, fmt.Errorf("failed to detect platform of node %s: %w", h.NodeID, err)
+	}
+	h.platform = &platform
+	return platform, nil
+}
+
+func parsePlatformOutput(output string) (HostPlatform, error) {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) < 2 {
+		return HostPlatform{}, fmt.Errorf("unexpected platform output: %q", output)
+	}
+	for len(lines) < 4 {
+		lines = append(lines, "")
+	}
+	platform := HostPlatform{
+		OS:     normalizeOS(lines[0]),
+		Arch:   normalizeArch(lines[1]),
+		Distro: strings.TrimSpace(lines[2]),
+	}
+	switch pm := strings.TrimSpace(lines[3]); {
+	case strings.HasSuffix(pm, "apt-get"):
+		platform.PackageManager = Apt
+	case strings.HasSuffix(pm, "dnf"):
+		platform.PackageManager = Dnf
+	case strings.HasSuffix(pm, "yum"):
+		platform.PackageManager = Yum
+	}
+	return platform, nil
+}
+
+func normalizeOS(os string) string {
+	os = strings.ToLower(strings.TrimSpace(os))
+	switch {
+	case os == "linux":
+		return LinuxOS
+	case strings.Contains(os, "mingw"), strings.Contains(os, "msys"), strings.Contains(os, "cygwin"), strings.Contains(os, "windows"):
+		return WindowsOS
+	default:
+		return os
+	}
+}
+
+func normalizeArch(arch string) string {
+	switch arch = strings.ToLower(strings.TrimSpace(arch)); arch {
+	case "x86_64", "amd64":
+		return AMD64Arch
+	case "aarch64", "arm64", "armv8l":
+		return ARM64Arch
+	default:
+		return arch
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlatformOutput(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		expected    HostPlatform
+		expectError bool
+	}{
+		{
+			name:     "Ubuntu x86_64",
+			output:   "Linux\nx86_64\nubuntu\n/usr/bin/apt-get\n",
+			expected: HostPlatform{OS: LinuxOS, Arch: AMD64Arch, Distro: "ubuntu", PackageManager: Apt},
+		},
+		{
+			name:     "Amazon Linux on Graviton",
+			output:   "Linux\naarch64\namzn\n/usr/bin/dnf\n",
+			expected: HostPlatform{OS: LinuxOS, Arch: ARM64Arch, Distro: "amzn", PackageManager: Dnf},
+		},
+		{
+			name:     "CentOS with yum",
+			output:   "Linux\nx86_64\ncentos\n/usr/bin/yum\n",
+			expected: HostPlatform{OS: LinuxOS, Arch: AMD64Arch, Distro: "centos", PackageManager: Yum},
+		},
+		{
+			name:     "Windows with Git Bash",
+			output:   "MINGW64_NT-10.0-19045\nx86_64\n\n\n",
+			expected: HostPlatform{OS: WindowsOS, Arch: AMD64Arch},
+		},
+		{
+			name:     "Missing package manager",
+			output:   "Linux\nx86_64\nalpine\n",
+			expected: HostPlatform{OS: LinuxOS, Arch: AMD64Arch, Distro: "alpine"},
+		},
+		{
+			name:        "Invalid output",
+			output:      "Linux",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platform, err := parsePlatformOutput(tt.output)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, platform)
+		})
+	}
+}
+
+func TestHostPlatform_Supported(t *testing.T) {
+	tests := []struct {
+		name        string
+		platform    HostPlatform
+		expectError bool
+	}{
+		{
+			name:     "Linux amd64 apt",
+			platform: HostPlatform{OS: LinuxOS, Arch: AMD64Arch, PackageManager: Apt},
+		},
+		{
+			name:     "Linux arm64 dnf",
+			platform: HostPlatform{OS: LinuxOS, Arch: ARM64Arch, PackageManager: Dnf},
+		},
+		{
+			name:        "Windows",
+			platform:    HostPlatform{OS: WindowsOS, Arch: AMD64Arch},
+			expectError: true,
+		},
+		{
+			name:        "Unsupported arch",
+			platform:    HostPlatform{OS: LinuxOS, Arch: "riscv64", PackageManager: Apt},
+			expectError: true,
+		},
+		{
+			name:        "Unknown package manager",
+			platform:    HostPlatform{OS: LinuxOS, Arch: AMD64Arch, Distro: "alpine"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.platform.Supported()
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrUnsupportedPlatform)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHostPlatform_InstallPackagesCommand(t *testing.T) {
+	cmd, err := HostPlatform{PackageManager: Apt}.InstallPackagesCommand("git", "gcc")
+	require.NoError(t, err)
+	assert.Contains(t, cmd, "apt-get -y install git gcc")
+
+	cmd, err = HostPlatform{PackageManager: Yum}.InstallPackagesCommand("git")
+	require.NoError(t, err)
+	assert.Equal(t, "sudo yum -y install git", cmd)
+
+	_, err = HostPlatform{}.InstallPackagesCommand("git")
+	assert.ErrorIs(t, err, ErrUnsupportedPlatform)
+}
+
+func TestHostPlatform_DockerPlatform(t *testing.T) {
+	assert.Equal(t, "linux/arm64", HostPlatform{OS: LinuxOS, Arch: ARM64Arch}.DockerPlatform())
+	assert.Equal(t, "linux/amd64", HostPlatform{OS: LinuxOS, Arch: AMD64Arch}.DockerPlatform())
+}
+
+func TestNode_DetectPlatform_Cached(t *testing.T) {
+	cached := HostPlatform{OS: LinuxOS, Arch: ARM64Arch, PackageManager: Apt}
+	node := Node{NodeID: "test-node", platform: &cached}
+	platform, err := node.DetectPlatform()
+	require.NoError(t, err)
+	assert.Equal(t, cached, platform)
+}
+
+func TestRenderComposeFile_Platform(t *testing.T) {
+	composeData, err := renderComposeFile("templates/odysseygo.docker-compose.yml", "test", dockerComposeInputs{
+		WithOdysseygo:    true,
+		OdysseygoVersion: "v1.10.13",
+		Platform:         "linux/arm64",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(composeData), "platform: linux/arm64")
+
+	composeData, err = renderComposeFile("templates/odysseygo.docker-compose.yml", "test", dockerComposeInputs{
+		WithOdysseygo:    true,
+		OdysseygoVersion: "v1.10.13",
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, string(composeData), "platform:")
+}
+
+func TestSetupNodeScript_PackageManager(t *testing.T) {
+	shellScript, err := script.ReadFile("shell/setupNode.sh")
+	require.NoError(t, err)
+	tmpl, err := template.New("Setup Node").Parse(string(shellScript))
+	require.NoError(t, err)
+
+	var rendered bytes.Buffer
+	require.NoError(t, tmpl.Execute(&rendered, scriptInputs{PackageManager: string(Dnf)}))
+	assert.Contains(t, rendered.String(), "sudo dnf -y install")
+	assert.Contains(t, rendered.String(), "sudo dnf config-manager")
+	assert.NotContains(t, rendered.String(), "yum-config-manager")
+	assert.NotContains(t, rendered.String(), "apt-get")
+
+	rendered.Reset()
+	require.NoError(t, tmpl.Execute(&rendered, scriptInputs{PackageManager: string(Yum)}))
+	assert.Contains(t, rendered.String(), "sudo yum-config-manager")
+
+	rendered.Reset()
+	require.NoError(t, tmpl.Execute(&rendered, scriptInputs{PackageManager: string(Apt)}))
+	assert.Contains(t, rendered.String(), "apt-get")
+	assert.NotContains(t, rendered.String(), "rpm -q")
+}
+
+func TestSetupScripts_User(t *testing.T) {
+	for _, scriptPath := range []string{"shell/setupNode.sh", "shell/setupDockerService.sh"} {
+		rendered, err := renderScript("Setup", scriptPath, scriptInputs{
+			PackageManager: string(Dnf),
+			User:           "ec2-user",
+			Config:         constants.Config{}.WithHome("/home/ec2-user"),
+		})
+		require.NoError(t, err)
+		assert.Contains(t, rendered, "ec2-user", scriptPath)
+		assert.NotContains(t, rendered, "ubuntu", scriptPath)
+	}
+	// the user defaults to the one of the SDK config
+	rendered, err := renderScript("Setup Docker Service", "shell/setupDockerService.sh", scriptInputs{})
+	require.NoError(t, err)
+	assert.Contains(t, rendered, "User="+constants.RemoteHostUser)
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package hostkeys verifies the SSH host keys of the nodes against a known_hosts file, so that
+// secrets such as staking keys are not uploaded to a host impersonating a node
+package hostkeys
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// Policy is how a Store verifies the host keys
+type Policy string
+
+const (
+	// TrustOnFirstUse records the key of hosts seen for the first time, and rejects
+	// connections to known hosts presenting another key
+	TrustOnFirstUse Policy = "tofu"
+
+	// Strict rejects connections to hosts that are not in the known_hosts file
+	Strict Policy = "strict"
+
+	// InsecureIgnore accepts any host key. Connections can be intercepted
+	InsecureIgnore Policy = "insecure"
+)
+
+var (
+	ErrHostKeyMismatch = errors.New("host key mismatch, the connection may be intercepted")
+	ErrUnknownHost     = errors.New("host key is not known")
+	ErrInvalidPolicy   = errors.New("invalid host key policy")
+)
+
+// fileLock serializes the accesses to the known_hosts files, shared by the connections to
+// all the nodes
+var fileLock sync.Mutex
+
+// Store is a known_hosts file, in the OpenSSH format
+type Store struct {
+	path string
+}
+
+// NewStore returns the Store of the known_hosts file at path, created on first use
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the location of the known_hosts file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Callback returns the ssh.HostKeyCallback verifying the host keys with policy
+func (s *Store) Callback(policy Policy) (ssh.HostKeyCallback, error) {
+	switch policy {
+	case InsecureIgnore:
+		// #nosec G106
+		return ssh.InsecureIgnoreHostKey(), nil
+	case TrustOnFirstUse, Strict:
+	default:
+		return nil, fmt.Errorf("%w %q, expected %s, %s or %s", ErrInvalidPolicy, policy, TrustOnFirstUse, Strict, InsecureIgnore)
+	}
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		return s.verify(policy, hostname, remote, key)
+	}, nil
+}
+
+func (s *Store) verify(policy Policy, hostname string, remote net.Addr, key ssh.PublicKey) error {
+	fileLock.Lock()
+	defer fileLock.Unlock()
+	if err := s.ensureFile(); err != nil {
+		return err
+	}
+	callback, err := knownhosts.New(s.path)
+	if err != nil {
+		return err
+	}
+	err = callback(hostname, remote, key)
+	var keyErr *knownhosts.KeyError
+	if !errors.As(err, &keyErr) {
+		return err
+	}
+	host := knownhosts.Normalize(hostname)
+	if len(keyErr.Want) > 0 {
+		known := keyErr.Want[0]
+		return fmt.Errorf("%w: %s presented %s key %s, but %s:%d records %s key %s. If the host was rebuilt, refresh its host key",
+			ErrHostKeyMismatch, host, key.Type(), ssh.FingerprintSHA256(key), known.Filename, known.Line, known.Key.Type(), ssh.FingerprintSHA256(known.Key))
+	}
+	if policy == Strict {
+		return fmt.Errorf("%w: %s presented %s key %s, which is not in %s", ErrUnknownHost, host, key.Type(), ssh.FingerprintSHA256(key), s.path)
+	}
+	return s.add(host, key)
+}
+
+// Add records key as the host key of host, e.g. to trust a key read from the console of a
+// cloud provider before the first connection. host is an address, with an optional port
+func (s *Store) Add(host string, key ssh.PublicKey) error {
+	fileLock.Lock()
+	defer fileLock.Unlock()
+	if err := s.ensureFile(); err != nil {
+		return err
+	}
+	return s.add(knownhosts.Normalize(host), key)
+}
+
+func (s *Store) add(host string, key ssh.PublicKey) error {
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(file, knownhosts.Line([]string{host}, key)); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Forget removes the keys recorded for host, so that the next connection records its new
+// key. host is an address, with an optional port
+func (s *Store) Forget(host string) error {
+	fileLock.Lock()
+	defer fileLock.Unlock()
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	host = knownhosts.Normalize(host)
+	kept := bytes.Buffer{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !lineHasHost(line, host) {
+			kept.WriteString(line + "\n")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, kept.Bytes(), 0o600)
+}
+
+// lineHasHost returns whether the known_hosts line records a key of host. Hashed host names
+// are not matched, and the @cert-authority and @revoked lines are kept
+func lineHasHost(line string, host string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "@") {
+		return false
+	}
+	for _, pattern := range strings.Split(fields[0], ",") {
+		if pattern == host {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Store) ensureFile() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_RDONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
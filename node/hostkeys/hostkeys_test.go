@@ -0,0 +1,94 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package hostkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func testHostKey(t *testing.T) ssh.PublicKey {
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := ssh.NewPublicKey(public)
+	require.NoError(t, err)
+	return key
+}
+
+func TestStore_Callback(t *testing.T) {
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 22}
+	key, otherKey := testHostKey(t), testHostKey(t)
+
+	tests := []struct {
+		name        string
+		policy      Policy
+		known       ssh.PublicKey
+		expectedErr error
+		recorded    bool
+	}{
+		{name: "tofu records unknown host", policy: TrustOnFirstUse, recorded: true},
+		{name: "tofu accepts known key", policy: TrustOnFirstUse, known: key, recorded: true},
+		{name: "tofu rejects other key", policy: TrustOnFirstUse, known: otherKey, expectedErr: ErrHostKeyMismatch},
+		{name: "strict rejects unknown host", policy: Strict, expectedErr: ErrUnknownHost},
+		{name: "strict accepts known key", policy: Strict, known: key, recorded: true},
+		{name: "strict rejects other key", policy: Strict, known: otherKey, expectedErr: ErrHostKeyMismatch},
+		{name: "insecure accepts other key", policy: InsecureIgnore, known: otherKey},
+		{name: "invalid policy", policy: "yes", expectedErr: ErrInvalidPolicy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewStore(filepath.Join(t.TempDir(), "sdk", "known_hosts"))
+			if tt.known != nil {
+				require.NoError(t, store.Add("10.0.0.1", tt.known))
+			}
+			callback, err := store.Callback(tt.policy)
+			if err == nil {
+				err = callback("10.0.0.1:22", remote, key)
+			}
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.recorded {
+				// the key is accepted by a strict callback from now on
+				strict, err := store.Callback(Strict)
+				require.NoError(t, err)
+				require.NoError(t, strict("10.0.0.1:22", remote, key))
+			}
+		})
+	}
+}
+
+func TestStore_Forget(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "known_hosts"))
+	require.NoError(t, store.Forget("10.0.0.1"))
+
+	key, newKey := testHostKey(t), testHostKey(t)
+	require.NoError(t, store.Add("10.0.0.1", key))
+	require.NoError(t, store.Add("10.0.0.2", key))
+	require.NoError(t, store.Add("10.0.0.1:2222", key))
+	callback, err := store.Callback(TrustOnFirstUse)
+	require.NoError(t, err)
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 22}
+	require.ErrorIs(t, callback("10.0.0.1:22", remote, newKey), ErrHostKeyMismatch)
+
+	// the rebuilt host presents a new key
+	require.NoError(t, store.Forget("10.0.0.1"))
+	require.NoError(t, callback("10.0.0.1:22", remote, newKey))
+	require.NoError(t, callback("10.0.0.1:22", remote, newKey))
+
+	// the other hosts are kept
+	data, err := os.ReadFile(store.Path())
+	require.NoError(t, err)
+	require.Contains(t, string(data), "10.0.0.2 ")
+	require.Contains(t, string(data), "[10.0.0.1]:2222 ")
+}
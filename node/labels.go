@@ -0,0 +1,110 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+)
+
+// Standard label keys of the nodes managed by the SDK
+const (
+	LabelCluster   = "cluster"
+	LabelRole      = "role"
+	LabelNetwork   = "network"
+	LabelOwner     = "owner"
+	LabelCreatedBy = "created-by"
+
+	// CreatedByValue is the value of the LabelCreatedBy label
+	CreatedByValue = "odyssey-sdk"
+)
+
+// maxLabelLen is the maximum length of label keys and values, as accepted by both AWS tags
+// and GCP labels
+const maxLabelLen = 63
+
+var (
+	ErrInvalidLabel = errors.New("invalid label")
+
+	// labelRegex matches the label keys and values accepted by both AWS tags and GCP labels
+	labelRegex = regexp.MustCompile(`^[a-z0-9_-]*$`)
+)
+
+// ResourceLabels returns the labels of a node created with nodeParams for role: the
+// custom Labels of nodeParams plus the standard cluster, role, network, owner and
+// created-by labels. Standard labels take precedence over custom ones
+//
+// Cloud functionality has been removed from this SDK, so the labels are not applied to
+// cloud resources: set them into Node.Labels to track and filter nodes with FilterNodes
+func (p *NodeParams) ResourceLabels(role SupportedRole) (map[string]string, error) {
+	labels := map[string]string{}
+	for key, value := range p.Labels {
+		labels[key] = value
+	}
+	labels[LabelRole] = role.String()
+	labels[LabelCreatedBy] = CreatedByValue
+	if p.Network.Kind != odyssey.Undefined {
+		labels[LabelNetwork] = p.Network.Kind.String()
+	}
+	if p.ClusterName != "" {
+		labels[LabelCluster] = p.ClusterName
+	}
+	if p.Owner != "" {
+		labels[LabelOwner] = p.Owner
+	}
+	if err := ValidateLabels(labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// ValidateLabels checks that labels are accepted by both AWS and GCP: keys and values made
+// of lowercase letters, digits, underscores and dashes, up to 63 characters, with non empty
+// keys starting with a letter
+func ValidateLabels(labels map[string]string) error {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	// report the same error on each call
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := labels[key]
+		switch {
+		case key == "" || key[0] < 'a' || key[0] > 'z':
+			return fmt.Errorf("%w key %q: must start with a lowercase letter", ErrInvalidLabel, key)
+		case len(key) > maxLabelLen || !labelRegex.MatchString(key):
+			return fmt.Errorf("%w key %q: must be up to %d lowercase letters, digits, _ or -", ErrInvalidLabel, key, maxLabelLen)
+		case len(value) > maxLabelLen || !labelRegex.MatchString(value):
+			return fmt.Errorf("%w value %q of %s: must be up to %d lowercase letters, digits, _ or -", ErrInvalidLabel, value, key, maxLabelLen)
+		}
+	}
+	return nil
+}
+
+// HasLabels tells if the node has all the labels of selector
+func (h *Node) HasLabels(selector map[string]string) bool {
+	for key, value := range selector {
+		if nodeValue, ok := h.Labels[key]; !ok || nodeValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterNodes returns the nodes having all the labels of selector, e.g. to destroy the
+// nodes of a cluster
+func FilterNodes(nodes []Node, selector map[string]string) []Node {
+	filtered := []Node{}
+	for _, node := range nodes {
+		if node.HasLabels(selector) {
+			filtered = append(filtered, node)
+		}
+	}
+	return filtered
+}
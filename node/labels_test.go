@@ -0,0 +1,84 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+)
+
+func TestNodeParams_ResourceLabels(t *testing.T) {
+	params := &NodeParams{
+		Network:     odyssey.TestnetNetwork(),
+		ClusterName: "my-cluster",
+		Owner:       "ops",
+		Labels:      map[string]string{"team": "infra", LabelRole: "overridden"},
+	}
+	labels, err := params.ResourceLabels(RPCGateway)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"team":         "infra",
+		LabelRole:      "rpc-gateway",
+		LabelNetwork:   "testnet",
+		LabelCluster:   "my-cluster",
+		LabelOwner:     "ops",
+		LabelCreatedBy: CreatedByValue,
+	}, labels)
+
+	labels, err = (&NodeParams{}).ResourceLabels(Validator)
+	require.NoError(t, err)
+	assert.NotContains(t, labels, LabelNetwork)
+
+	params.ClusterName = "My Cluster"
+	_, err = params.ResourceLabels(Validator)
+	assert.ErrorIs(t, err, ErrInvalidLabel)
+}
+
+func TestValidateLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		wantErr bool
+	}{
+		{name: "valid", labels: map[string]string{"env": "prod_1", "team-a": ""}},
+		{name: "empty key", labels: map[string]string{"": "x"}, wantErr: true},
+		{name: "key starting with digit", labels: map[string]string{"1env": "x"}, wantErr: true},
+		{name: "uppercase value", labels: map[string]string{"env": "Prod"}, wantErr: true},
+		{name: "long value", labels: map[string]string{"env": strings.Repeat("a", maxLabelLen+1)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLabels(tt.labels)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidLabel)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFilterNodes(t *testing.T) {
+	nodes := []Node{
+		{NodeID: "node-1", Labels: map[string]string{LabelCluster: "a", LabelRole: "validator"}},
+		{NodeID: "node-2", Labels: map[string]string{LabelCluster: "a", LabelRole: "api"}},
+		{NodeID: "node-3", Labels: map[string]string{LabelCluster: "b", LabelRole: "validator"}},
+		{NodeID: "node-4"},
+	}
+	filtered := FilterNodes(nodes, map[string]string{LabelCluster: "a"})
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "node-1", filtered[0].NodeID)
+	assert.Equal(t, "node-2", filtered[1].NodeID)
+
+	filtered = FilterNodes(nodes, map[string]string{LabelCluster: "a", LabelRole: "validator"})
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "node-1", filtered[0].NodeID)
+
+	assert.Len(t, FilterNodes(nodes, nil), 4)
+}
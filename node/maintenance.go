@@ -0,0 +1,343 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+)
+
+// DefaultQuorumPercent is the percentage of the subnet weight kept online by maintenance
+// schedulers whose policy has no quorum percent, the default quorum of warp messages
+const DefaultQuorumPercent = 67
+
+var (
+	ErrInvalidMaintenanceWindow = errors.New("invalid maintenance window")
+	ErrInvalidMaintenancePolicy = errors.New("invalid maintenance policy")
+
+	// ErrQuorumAtRisk is the result error of the nodes whose maintenance would take more weight
+	// offline than the policy allows
+	ErrQuorumAtRisk = errors.New("maintenance would take too much stake offline")
+)
+
+// MaintenanceWindow is a weekly recurring period during which fleet operations may start
+type MaintenanceWindow struct {
+	// Weekdays on which the window opens, every day if empty
+	Weekdays []time.Weekday
+
+	// Start is the time of day the window opens at, as an offset from midnight
+	Start time.Duration
+
+	// Duration of the window, at most a day
+	Duration time.Duration
+
+	// Location of Start, UTC if nil
+	Location *time.Location
+}
+
+// Validate checks that the window opens within a day and lasts at most a day
+func (w MaintenanceWindow) Validate() error {
+	if w.Start < 0 || w.Start >= 24*time.Hour {
+		return fmt.Errorf("%w: start %s is not a time of day", ErrInvalidMaintenanceWindow, w.Start)
+	}
+	if w.Duration <= 0 || w.Duration > 24*time.Hour {
+		return fmt.Errorf("%w: duration %s is not between 0 and 24h", ErrInvalidMaintenanceWindow, w.Duration)
+	}
+	return nil
+}
+
+func (w MaintenanceWindow) location() *time.Location {
+	if w.Location == nil {
+		return time.UTC
+	}
+	return w.Location
+}
+
+func (w MaintenanceWindow) opensOn(day time.Weekday) bool {
+	if len(w.Weekdays) == 0 {
+		return true
+	}
+	for _, weekday := range w.Weekdays {
+		if weekday == day {
+			return true
+		}
+	}
+	return false
+}
+
+// openingOn returns the opening of the window on the day of t shifted by days, and whether
+// the window opens that day
+func (w MaintenanceWindow) openingOn(t time.Time, days int) (time.Time, bool) {
+	t = t.In(w.location())
+	midnight := time.Date(t.Year(), t.Month(), t.Day()+days, 0, 0, 0, 0, w.location())
+	return midnight.Add(w.Start), w.opensOn(midnight.Weekday())
+}
+
+// Contains tells if the window is open at t
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	// a window lasts at most a day, so only the windows opening on the day of t or the day
+	// before may be open
+	for days := 0; days >= -1; days-- {
+		opening, ok := w.openingOn(t, days)
+		if ok && !t.Before(opening) && t.Before(opening.Add(w.Duration)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns t if the window is open at t, or else its next opening after t
+func (w MaintenanceWindow) Next(t time.Time) time.Time {
+	if w.Contains(t) {
+		return t
+	}
+	for days := 0; days <= 7; days++ {
+		opening, ok := w.openingOn(t, days)
+		if ok && opening.After(t) {
+			return opening
+		}
+	}
+	// unreachable for valid windows, which open at least once a week
+	return t
+}
+
+// MaintenancePolicy tells when fleet operations may run and how much validator weight they may
+// take offline at once
+type MaintenancePolicy struct {
+	// Windows during which the operations may start on a node. Operations may start at any
+	// time if empty
+	Windows []MaintenanceWindow
+
+	// Weights are the validator weights of the nodes on the subnet, by Node.NodeID. Nodes
+	// without weight are not validators, and are only limited by MaxUnavailable
+	Weights map[string]uint64
+
+	// TotalWeight is the weight of all the validators of the subnet, including the ones outside
+	// of the fleet. The sum of Weights if zero
+	TotalWeight uint64
+
+	// QuorumPercent is the percentage of TotalWeight that must stay online,
+	// DefaultQuorumPercent if zero
+	QuorumPercent uint64
+
+	// MaxUnavailable is the maximum number of nodes taken offline at once, unlimited if not
+	// positive
+	MaxUnavailable int
+}
+
+// Validate checks the windows and the weights of the policy
+func (p MaintenancePolicy) Validate() error {
+	for _, w := range p.Windows {
+		if err := w.Validate(); err != nil {
+			return err
+		}
+	}
+	if p.QuorumPercent > 100 {
+		return fmt.Errorf("%w: quorum percent %d is above 100", ErrInvalidMaintenancePolicy, p.QuorumPercent)
+	}
+	if p.TotalWeight != 0 && p.TotalWeight < p.fleetWeight() {
+		return fmt.Errorf("%w: total weight %d is below the weight %d of the nodes", ErrInvalidMaintenancePolicy, p.TotalWeight, p.fleetWeight())
+	}
+	return nil
+}
+
+func (p MaintenancePolicy) fleetWeight() uint64 {
+	weight := uint64(0)
+	for _, w := range p.Weights {
+		weight += w
+	}
+	return weight
+}
+
+// MaxOfflineWeight returns the validator weight that may be offline at once while keeping the
+// quorum online
+func (p MaintenancePolicy) MaxOfflineWeight() uint64 {
+	total := p.TotalWeight
+	if total == 0 {
+		total = p.fleetWeight()
+	}
+	quorumPercent := p.QuorumPercent
+	if quorumPercent == 0 {
+		quorumPercent = DefaultQuorumPercent
+	}
+	// the quorum weight is rounded up
+	quorum := (total*quorumPercent + 99) / 100
+	return total - quorum
+}
+
+// InWindow tells if operations may start at t
+func (p MaintenancePolicy) InWindow(t time.Time) bool {
+	return len(p.Windows) == 0 || !p.NextWindow(t).After(t)
+}
+
+// NextWindow returns t if a window is open at t, or else the next opening of a window
+func (p MaintenancePolicy) NextWindow(t time.Time) time.Time {
+	if len(p.Windows) == 0 {
+		return t
+	}
+	next := p.Windows[0].Next(t)
+	for _, w := range p.Windows[1:] {
+		if opening := w.Next(t); opening.Before(next) {
+			next = opening
+		}
+	}
+	return next
+}
+
+// MaintenanceOperation is run on a node by a MaintenanceScheduler, the value returned being
+// the value of the result of the node
+type MaintenanceOperation func(ctx context.Context, node *Node) (interface{}, error)
+
+// UpgradeOperation upgrades odysseygo to odysseyGoVersion, see UpgradeNodes
+func UpgradeOperation(odysseyGoVersion string, healthTimeout time.Duration) MaintenanceOperation {
+	return func(_ context.Context, node *Node) (interface{}, error) {
+		return nil, node.UpgradeOdysseyGo(odysseyGoVersion, healthTimeout)
+	}
+}
+
+// BackupOperation backs up the database of the node into its subdirectory of destDir, the
+// value of the result being the archive path, see BackupScheduler
+func BackupOperation(destDir string) MaintenanceOperation {
+	return func(ctx context.Context, node *Node) (interface{}, error) {
+		nodeDir := filepath.Join(destDir, node.NodeID)
+		if err := os.MkdirAll(nodeDir, constants.DefaultPerms755); err != nil {
+			return nil, err
+		}
+		return node.BackupDatabase(ctx, nodeDir)
+	}
+}
+
+// RestartOperation restarts odysseygo and waits up to healthTimeout for it to be healthy
+func RestartOperation(healthTimeout time.Duration) MaintenanceOperation {
+	return func(_ context.Context, node *Node) (interface{}, error) {
+		if err := node.RunSSHRestartOdysseygo(); err != nil {
+			return nil, err
+		}
+		return nil, node.WaitForOdysseyGoHealth(healthTimeout)
+	}
+}
+
+// MaintenanceScheduler runs fleet operations that take nodes offline, e.g. upgrades, backups
+// and restarts, during the maintenance windows of its policy only, and on batches of nodes
+// whose validator weight keeps the quorum of the subnet online
+type MaintenanceScheduler struct {
+	policy MaintenancePolicy
+	now    func() time.Time
+	after  func(time.Duration) <-chan time.Time
+}
+
+// NewMaintenanceScheduler returns a scheduler applying policy
+func NewMaintenanceScheduler(policy MaintenancePolicy) (*MaintenanceScheduler, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &MaintenanceScheduler{
+		policy: policy,
+		now:    time.Now,
+		after:  time.After,
+	}, nil
+}
+
+// Plan returns the batches of nodes Run would take offline one after the other if all the
+// operations succeed. Nodes whose weight alone is above the max offline weight of the policy
+// fail the plan with ErrQuorumAtRisk
+func (s *MaintenanceScheduler) Plan(nodes []*Node) ([][]*Node, error) {
+	batches := [][]*Node{}
+	for remaining := nodes; len(remaining) > 0; {
+		batch, rest := s.nextBatch(remaining, s.policy.MaxOfflineWeight())
+		if len(batch) == 0 {
+			return nil, s.quorumError(remaining[0], s.policy.MaxOfflineWeight())
+		}
+		batches = append(batches, batch)
+		remaining = rest
+	}
+	return batches, nil
+}
+
+// nextBatch picks, in order, the nodes of the next batch whose weight fits in budget, and
+// returns them with the nodes left
+func (s *MaintenanceScheduler) nextBatch(nodes []*Node, budget uint64) ([]*Node, []*Node) {
+	batch, rest := []*Node{}, []*Node{}
+	weight := uint64(0)
+	for _, node := range nodes {
+		full := s.policy.MaxUnavailable > 0 && len(batch) == s.policy.MaxUnavailable
+		nodeWeight := s.policy.Weights[node.NodeID]
+		if full || weight+nodeWeight > budget {
+			rest = append(rest, node)
+			continue
+		}
+		batch = append(batch, node)
+		weight += nodeWeight
+	}
+	return batch, rest
+}
+
+func (s *MaintenanceScheduler) quorumError(node *Node, budget uint64) error {
+	return fmt.Errorf("%w: node %s has weight %d, while at most %d may be offline", ErrQuorumAtRisk, node.NodeID, s.policy.Weights[node.NodeID], budget)
+}
+
+// Run runs op on the nodes, batch after batch, each batch starting within a maintenance
+// window, waiting for the next one if needed. The nodes whose operation fails are considered
+// offline for the rest of the run, reducing the weight later batches may take offline: the
+// nodes that no longer fit fail with ErrQuorumAtRisk without being operated on. Nodes not
+// started when ctx is done fail with ctx.Err()
+func (s *MaintenanceScheduler) Run(ctx context.Context, nodes []*Node, op MaintenanceOperation) *NodeResults {
+	results := &NodeResults{}
+	failedWeight := uint64(0)
+	for remaining := nodes; len(remaining) > 0; {
+		budget := s.policy.MaxOfflineWeight() - min(failedWeight, s.policy.MaxOfflineWeight())
+		batch, rest := s.nextBatch(remaining, budget)
+		if len(batch) == 0 {
+			for _, node := range remaining {
+				results.AddResult(node.NodeID, nil, s.quorumError(node, budget))
+			}
+			break
+		}
+		if err := s.waitForWindow(ctx); err != nil {
+			for _, node := range remaining {
+				results.AddResult(node.NodeID, nil, err)
+			}
+			break
+		}
+		batchResults := RunOnNodes(batch, 0, func(node *Node) (interface{}, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return op(ctx, node)
+		})
+		for _, result := range batchResults.GetResults() {
+			results.AddResult(result.NodeID, result.Value, result.Err)
+			if result.Err != nil {
+				failedWeight += s.policy.Weights[result.NodeID]
+			}
+		}
+		remaining = rest
+	}
+	return results
+}
+
+// waitForWindow returns once a maintenance window is open, or with ctx.Err()
+func (s *MaintenanceScheduler) waitForWindow(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		now := s.now()
+		next := s.policy.NextWindow(now)
+		if !next.After(now) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.after(next.Sub(now)):
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceWindow(t *testing.T) {
+	// Saturdays from 22:00 to 02:00 UTC
+	w := MaintenanceWindow{Weekdays: []time.Weekday{time.Saturday}, Start: 22 * time.Hour, Duration: 4 * time.Hour}
+	require.NoError(t, w.Validate())
+	saturday := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		contains bool
+		next     time.Time
+	}{
+		{name: "before opening", at: saturday.Add(21 * time.Hour), next: saturday.Add(22 * time.Hour)},
+		{name: "at opening", at: saturday.Add(22 * time.Hour), contains: true, next: saturday.Add(22 * time.Hour)},
+		{name: "after midnight", at: saturday.Add(25 * time.Hour), contains: true, next: saturday.Add(25 * time.Hour)},
+		{name: "at closing", at: saturday.Add(26 * time.Hour), next: saturday.Add(7*24*time.Hour + 22*time.Hour)},
+		{name: "other weekday", at: saturday.Add(-24*time.Hour + 23*time.Hour), next: saturday.Add(22 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.contains, w.Contains(tt.at))
+			assert.Equal(t, tt.next, w.Next(tt.at))
+		})
+	}
+
+	// the opening follows the location of the window
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	w = MaintenanceWindow{Start: 3 * time.Hour, Duration: time.Hour, Location: paris}
+	assert.True(t, w.Contains(time.Date(2025, 1, 15, 2, 30, 0, 0, time.UTC)))
+	assert.False(t, w.Contains(time.Date(2025, 1, 15, 3, 30, 0, 0, time.UTC)))
+
+	require.ErrorIs(t, MaintenanceWindow{Start: 24 * time.Hour, Duration: time.Hour}.Validate(), ErrInvalidMaintenanceWindow)
+	require.ErrorIs(t, MaintenanceWindow{Duration: 25 * time.Hour}.Validate(), ErrInvalidMaintenanceWindow)
+}
+
+func TestMaintenancePolicy_MaxOfflineWeight(t *testing.T) {
+	weights := map[string]uint64{"node-1": 20, "node-2": 20, "node-3": 20}
+	assert.Equal(t, uint64(19), MaintenancePolicy{Weights: weights}.MaxOfflineWeight())
+	assert.Equal(t, uint64(33), MaintenancePolicy{Weights: weights, TotalWeight: 100}.MaxOfflineWeight())
+	assert.Equal(t, uint64(20), MaintenancePolicy{Weights: weights, TotalWeight: 100, QuorumPercent: 80}.MaxOfflineWeight())
+
+	require.ErrorIs(t, MaintenancePolicy{Weights: weights, TotalWeight: 50}.Validate(), ErrInvalidMaintenancePolicy)
+	require.ErrorIs(t, MaintenancePolicy{QuorumPercent: 101}.Validate(), ErrInvalidMaintenancePolicy)
+}
+
+func TestMaintenanceScheduler_Plan(t *testing.T) {
+	nodes := []*Node{{NodeID: "node-1"}, {NodeID: "node-2"}, {NodeID: "node-3"}, {NodeID: "api-1"}}
+	s, err := NewMaintenanceScheduler(MaintenancePolicy{
+		Weights:     map[string]uint64{"node-1": 20, "node-2": 10, "node-3": 10},
+		TotalWeight: 100,
+	})
+	require.NoError(t, err)
+
+	// at most 33 of weight may be offline, non validators fitting in any batch
+	batches, err := s.Plan(nodes)
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+	assert.Equal(t, []*Node{nodes[0], nodes[1], nodes[3]}, batches[0])
+	assert.Equal(t, []*Node{nodes[2]}, batches[1])
+
+	s.policy.MaxUnavailable = 1
+	batches, err = s.Plan(nodes)
+	require.NoError(t, err)
+	assert.Len(t, batches, 4)
+
+	s.policy.Weights["node-1"] = 40
+	_, err = s.Plan(nodes)
+	require.ErrorIs(t, err, ErrQuorumAtRisk)
+	require.ErrorContains(t, err, "node node-1 has weight 40")
+}
+
+// newTestMaintenanceScheduler returns a scheduler whose clock starts at now and jumps to the
+// end of the waits
+func newTestMaintenanceScheduler(t *testing.T, policy MaintenancePolicy, now time.Time) *MaintenanceScheduler {
+	s, err := NewMaintenanceScheduler(policy)
+	require.NoError(t, err)
+	s.now = func() time.Time { return now }
+	s.after = func(d time.Duration) <-chan time.Time {
+		now = now.Add(d)
+		ch := make(chan time.Time, 1)
+		ch <- now
+		return ch
+	}
+	return s
+}
+
+func TestMaintenanceScheduler_Run(t *testing.T) {
+	nodes := []*Node{{NodeID: "node-1"}, {NodeID: "node-2"}, {NodeID: "node-3"}}
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	window := MaintenanceWindow{Start: 2 * time.Hour, Duration: time.Hour}
+	s := newTestMaintenanceScheduler(t, MaintenancePolicy{
+		Windows:     []MaintenanceWindow{window},
+		Weights:     map[string]uint64{"node-1": 10, "node-2": 10, "node-3": 10},
+		TotalWeight: 100,
+	}, monday)
+
+	lock := sync.Mutex{}
+	startedAt := map[string]time.Time{}
+	results := s.Run(context.Background(), nodes, func(_ context.Context, node *Node) (interface{}, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		startedAt[node.NodeID] = s.now()
+		return node.NodeID + " done", nil
+	})
+	require.Empty(t, results.Failed())
+	assert.Equal(t, "node-3 done", results.GetResultMap()["node-3"])
+	// the run waits for the window, then takes 30 of weight offline at most
+	for _, node := range nodes {
+		assert.Equal(t, monday.Add(2*time.Hour), startedAt[node.NodeID])
+	}
+}
+
+func TestMaintenanceScheduler_RunFailures(t *testing.T) {
+	nodes := []*Node{{NodeID: "node-1"}, {NodeID: "node-2"}, {NodeID: "node-3"}, {NodeID: "api-1"}}
+	s := newTestMaintenanceScheduler(t, MaintenancePolicy{
+		Weights:        map[string]uint64{"node-1": 20, "node-2": 20, "node-3": 20},
+		TotalWeight:    100,
+		MaxUnavailable: 1,
+	}, time.Now())
+	errDown := errors.New("node did not come back")
+
+	// node-1 stays offline, leaving room for no other validator
+	ran := []string{}
+	results := s.Run(context.Background(), nodes, func(_ context.Context, node *Node) (interface{}, error) {
+		ran = append(ran, node.NodeID)
+		if node.NodeID == "node-1" {
+			return nil, errDown
+		}
+		return nil, nil
+	})
+	assert.Equal(t, []string{"node-1", "api-1"}, ran)
+	errs := results.GetErrorHostMap()
+	require.ErrorIs(t, errs["node-1"], errDown)
+	require.ErrorIs(t, errs["node-2"], ErrQuorumAtRisk)
+	require.ErrorIs(t, errs["node-3"], ErrQuorumAtRisk)
+	assert.NotContains(t, errs, "api-1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results = s.Run(ctx, nodes, func(context.Context, *Node) (interface{}, error) { return nil, nil })
+	assert.Len(t, results.Failed(), len(nodes))
+	require.ErrorIs(t, results.Failed()[0].Err, context.Canceled)
+}
@@ -7,10 +7,10 @@ import (
 	"bytes"
 	"embed"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
-	"text/template"
 
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
@@ -25,6 +25,16 @@ type configInputs struct {
 	Host           string
 	NodeID         string
 	ChainID        string
+	SubnetID       string
+
+	// security settings, see Security
+	TLS                bool
+	CA                 bool
+	InsecureSkipVerify bool
+	TenantID           string
+	BasicAuthUser      string
+	BasicAuthPassword  string
+	BasicAuthHash      string
 }
 
 //go:embed dashboards/*
@@ -33,6 +43,24 @@ var dashboards embed.FS
 //go:embed configs/*
 var configs embed.FS
 
+// ConfigOp holds the options of GenerateConfig and of the Write functions
+type ConfigOp struct {
+	templateOverrides fs.FS
+}
+
+// ConfigOption configures GenerateConfig and the Write functions
+type ConfigOption func(*ConfigOp)
+
+// WithTemplateOverrides generates configs from the files of overrides that have the path of an
+// embedded config template (e.g. configs/prometheus.yml) instead of the embedded one. The
+// templates can use the partials/*.tmpl files of overrides, see utils.ParseTemplate. A nil
+// overrides only uses the embedded templates
+func WithTemplateOverrides(overrides fs.FS) ConfigOption {
+	return func(op *ConfigOp) {
+		op.templateOverrides = overrides
+	}
+}
+
 func Setup(monitoringDir string) error {
 	return WriteMonitoringJSONFiles(monitoringDir)
 }
@@ -63,13 +91,17 @@ func WriteMonitoringJSONFiles(monitoringDir string) error {
 	return nil
 }
 
-func GenerateConfig(configPath string, configDesc string, templateVars configInputs) (string, error) {
-	configTemplate, err := configs.ReadFile(configPath)
+func GenerateConfig(configPath string, configDesc string, templateVars configInputs, opts ...ConfigOption) (string, error) {
+	op := ConfigOp{}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	configTemplate, err := utils.ReadFileWithOverrides(op.templateOverrides, configs, configPath)
 	if err != nil {
 		return "", err
 	}
 	var config bytes.Buffer
-	t, err := template.New(configDesc).Parse(string(configTemplate))
+	t, err := utils.ParseTemplate(configDesc, configTemplate, op.templateOverrides)
 	if err != nil {
 		return "", err
 	}
@@ -80,39 +112,63 @@ func GenerateConfig(configPath string, configDesc string, templateVars configInp
 	return config.String(), nil
 }
 
-func WritePrometheusConfig(filePath string, odysseyGoPorts []string, machinePorts []string, loadTestPorts []string) error {
-	config, err := GenerateConfig("configs/prometheus.yml", "Prometheus Config", configInputs{
+func WritePrometheusConfig(filePath string, odysseyGoPorts []string, machinePorts []string, loadTestPorts []string, opts ...ConfigOption) error {
+	return WritePrometheusConfigWithSecurity(filePath, odysseyGoPorts, machinePorts, loadTestPorts, nil, opts...)
+}
+
+// WritePrometheusConfigWithSecurity writes the Prometheus config, scraping Prometheus itself
+// with the TLS and basic auth settings of security
+func WritePrometheusConfigWithSecurity(filePath string, odysseyGoPorts []string, machinePorts []string, loadTestPorts []string, security *Security, opts ...ConfigOption) error {
+	inputs := configInputs{
 		OdysseyGoPorts: strings.Join(utils.AddSingleQuotes(odysseyGoPorts), ","),
 		MachinePorts:   strings.Join(utils.AddSingleQuotes(machinePorts), ","),
 		LoadTestPorts:  strings.Join(utils.AddSingleQuotes(loadTestPorts), ","),
-	})
+	}
+	inputs.applySecurity(security)
+	config, err := GenerateConfig("configs/prometheus.yml", "Prometheus Config", inputs, opts...)
 	if err != nil {
 		return err
 	}
 	return os.WriteFile(filePath, []byte(config), constants.WriteReadReadPerms)
 }
 
-func WriteLokiConfig(filePath string, port string) error {
-	config, err := GenerateConfig("configs/loki.yml", "Loki Config", configInputs{
+func WriteLokiConfig(filePath string, port string, opts ...ConfigOption) error {
+	return WriteLokiConfigWithSecurity(filePath, port, nil, opts...)
+}
+
+// WriteLokiConfigWithSecurity writes the Loki config with the TLS and multi-tenancy settings
+// of security
+func WriteLokiConfigWithSecurity(filePath string, port string, security *Security, opts ...ConfigOption) error {
+	inputs := configInputs{
 		Port: port,
-	})
+	}
+	inputs.applySecurity(security)
+	config, err := GenerateConfig("configs/loki.yml", "Loki Config", inputs, opts...)
 	if err != nil {
 		return err
 	}
 	return os.WriteFile(filePath, []byte(config), constants.WriteReadReadPerms)
 }
 
-func WritePromtailConfig(filePath string, lokiIP string, lokiPort string, host string, nodeID string, chainID string) error {
+func WritePromtailConfig(filePath string, lokiIP string, lokiPort string, host string, nodeID string, chainID string, opts ...ConfigOption) error {
+	return WritePromtailConfigWithSecurity(filePath, lokiIP, lokiPort, host, nodeID, chainID, nil, opts...)
+}
+
+// WritePromtailConfigWithSecurity writes the promtail config, pushing logs to Loki with the
+// TLS and tenant settings of security
+func WritePromtailConfigWithSecurity(filePath string, lokiIP string, lokiPort string, host string, nodeID string, chainID string, security *Security, opts ...ConfigOption) error {
 	if !utils.IsValidIP(lokiIP) {
 		return fmt.Errorf("invalid IP address: %s", lokiIP)
 	}
-	config, err := GenerateConfig("configs/promtail.yml", "Promtail Config", configInputs{
+	inputs := configInputs{
 		IP:      lokiIP,
 		Port:    lokiPort,
 		Host:    host,
 		NodeID:  nodeID,
 		ChainID: chainID,
-	})
+	}
+	inputs.applySecurity(security)
+	config, err := GenerateConfig("configs/promtail.yml", "Promtail Config", inputs, opts...)
 	if err != nil {
 		return err
 	}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
 	"github.com/stretchr/testify/assert"
@@ -438,3 +439,21 @@ func TestWriteMonitoringJSONFiles_DirectoryPermissions(t *testing.T) {
 	actualMode := info.Mode() & os.ModePerm // Mask out non-permission bits
 	assert.Equal(t, expectedMode, actualMode)
 }
+
+func TestGenerateConfig_TemplateOverrides(t *testing.T) {
+	overrides := WithTemplateOverrides(fstest.MapFS{
+		"configs/loki.yml": {Data: []byte("retention_period: 744h\nport: {{ .Port }}\n")},
+	})
+
+	config, err := GenerateConfig("configs/loki.yml", "Loki Config", configInputs{Port: "3100"}, overrides)
+	require.NoError(t, err)
+	assert.Equal(t, "retention_period: 744h\nport: 3100\n", config)
+
+	config, err = GenerateConfig("configs/loki.yml", "Loki Config", configInputs{Port: "3100"})
+	require.NoError(t, err)
+	assert.NotContains(t, config, "retention_period: 744h")
+
+	config, err = GenerateConfig("configs/prometheus.yml", "Prometheus Config", configInputs{}, overrides)
+	require.NoError(t, err)
+	assert.Contains(t, config, "scrape_configs")
+}
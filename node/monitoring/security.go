@@ -0,0 +1,151 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package monitoring
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+)
+
+const (
+	// TLSCertFileName is the name of the TLS certificate in the Loki and Prometheus config dirs
+	TLSCertFileName = "tls.crt"
+	// TLSKeyFileName is the name of the TLS key in the Loki and Prometheus config dirs
+	TLSKeyFileName = "tls.key"
+	// CAFileName is the name of the CA certificate in the promtail config dir
+	CAFileName = "ca.crt"
+	// PrometheusWebConfigFileName is the name of the Prometheus web config, holding its TLS
+	// and basic auth settings
+	PrometheusWebConfigFileName = "web.yml"
+)
+
+var (
+	ErrIncompleteTLS       = errors.New("both TLS certificate and key must be provided")
+	ErrIncompleteBasicAuth = errors.New("both basic auth user and password must be provided")
+
+	// only allow characters that need no escaping in the rendered configs and headers
+	safeValueRegexp = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+)
+
+// Security configures TLS, authentication and network exposure of the Loki and Prometheus
+// endpoints of a monitoring node. The zero value keeps plaintext, unauthenticated endpoints
+// published on all the interfaces of the host.
+type Security struct {
+	// TLSCertFile and TLSKeyFile are the local paths of the PEM certificate and key served by
+	// Loki and Prometheus. TLS is enabled when they are set
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// CAFile is the local path of the PEM CA certificate promtail uses to verify the Loki
+	// certificate. The system CAs are used when empty
+	CAFile string
+
+	// InsecureSkipVerify disables the verification of the Loki certificate by promtail,
+	// e.g. for self-signed certificates
+	InsecureSkipVerify bool
+
+	// BasicAuthUser and BasicAuthPassword protect the Prometheus endpoint with basic auth
+	BasicAuthUser     string
+	BasicAuthPassword string
+
+	// TenantID enables Loki multi-tenancy. Promtail pushes logs, and Grafana queries them,
+	// with the X-Scope-OrgID header set to TenantID
+	TenantID string
+
+	// BindIP is the host IP the Loki and Prometheus ports are published on, e.g. a private IP.
+	// All the interfaces are used when empty
+	BindIP string
+
+	// AllowedCIDRs are the only sources allowed by the host firewall to reach the Loki and
+	// Prometheus ports. All sources are allowed when empty
+	AllowedCIDRs []string
+}
+
+// TLSEnabled returns true if Loki and Prometheus are served over TLS
+func (s *Security) TLSEnabled() bool {
+	return s != nil && s.TLSCertFile != "" && s.TLSKeyFile != ""
+}
+
+// BasicAuthEnabled returns true if Prometheus is protected with basic auth
+func (s *Security) BasicAuthEnabled() bool {
+	return s != nil && s.BasicAuthUser != "" && s.BasicAuthPassword != ""
+}
+
+// Validate checks that the options are consistent and that the local files exist
+func (s *Security) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if (s.TLSCertFile == "") != (s.TLSKeyFile == "") {
+		return ErrIncompleteTLS
+	}
+	for _, file := range []string{s.TLSCertFile, s.TLSKeyFile, s.CAFile} {
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); err != nil {
+			return fmt.Errorf("invalid TLS file %s: %w", file, err)
+		}
+	}
+	if (s.BasicAuthUser == "") != (s.BasicAuthPassword == "") {
+		return ErrIncompleteBasicAuth
+	}
+	if s.BasicAuthUser != "" && !safeValueRegexp.MatchString(s.BasicAuthUser) {
+		return fmt.Errorf("invalid basic auth user %q: only letters, digits, '_', '.' and '-' are allowed", s.BasicAuthUser)
+	}
+	if s.TenantID != "" && !safeValueRegexp.MatchString(s.TenantID) {
+		return fmt.Errorf("invalid tenant ID %q: only letters, digits, '_', '.' and '-' are allowed", s.TenantID)
+	}
+	if s.BindIP != "" && net.ParseIP(s.BindIP) == nil {
+		return fmt.Errorf("invalid bind IP address: %s", s.BindIP)
+	}
+	for _, cidr := range s.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid allowed CIDR %s: %w", cidr, err)
+		}
+	}
+	return nil
+}
+
+// applySecurity sets the template inputs of security
+func (c *configInputs) applySecurity(security *Security) {
+	if security == nil {
+		return
+	}
+	c.TLS = security.TLSEnabled()
+	c.CA = security.CAFile != ""
+	c.InsecureSkipVerify = security.InsecureSkipVerify
+	c.TenantID = security.TenantID
+	if security.BasicAuthEnabled() {
+		c.BasicAuthUser = security.BasicAuthUser
+		c.BasicAuthPassword = strconv.Quote(security.BasicAuthPassword)
+	}
+}
+
+// WritePrometheusWebConfig writes the Prometheus web config enabling the TLS and basic auth
+// settings of security
+func WritePrometheusWebConfig(filePath string, security *Security, opts ...ConfigOption) error {
+	inputs := configInputs{}
+	inputs.applySecurity(security)
+	if security.BasicAuthEnabled() {
+		hash, err := bcrypt.GenerateFromPassword([]byte(security.BasicAuthPassword), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+		inputs.BasicAuthHash = string(hash)
+	}
+	config, err := GenerateConfig("configs/prometheus-web.yml", "Prometheus Web Config", inputs, opts...)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, []byte(config), constants.WriteReadUserOnlyPerms)
+}
@@ -0,0 +1,222 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package monitoring
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
+)
+
+func writeTestFile(t *testing.T, name string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte("test"), 0o600))
+	return path
+}
+
+func TestSecurity_Validate(t *testing.T) {
+	certFile := writeTestFile(t, "cert.pem")
+	keyFile := writeTestFile(t, "key.pem")
+	tests := []struct {
+		name        string
+		security    *Security
+		expectedErr error
+		errContains string
+	}{
+		{name: "nil", security: nil},
+		{name: "zero value", security: &Security{}},
+		{
+			name: "full",
+			security: &Security{
+				TLSCertFile:       certFile,
+				TLSKeyFile:        keyFile,
+				CAFile:            certFile,
+				BasicAuthUser:     "admin",
+				BasicAuthPassword: "p@ss w'rd$",
+				TenantID:          "odyssey-1",
+				BindIP:            "10.0.0.1",
+				AllowedCIDRs:      []string{"10.0.0.0/8", "2001:db8::/32"},
+			},
+		},
+		{name: "cert without key", security: &Security{TLSCertFile: certFile}, expectedErr: ErrIncompleteTLS},
+		{name: "key without cert", security: &Security{TLSKeyFile: keyFile}, expectedErr: ErrIncompleteTLS},
+		{
+			name:        "missing cert file",
+			security:    &Security{TLSCertFile: filepath.Join(t.TempDir(), "missing.pem"), TLSKeyFile: keyFile},
+			errContains: "invalid TLS file",
+		},
+		{name: "user without password", security: &Security{BasicAuthUser: "admin"}, expectedErr: ErrIncompleteBasicAuth},
+		{name: "password without user", security: &Security{BasicAuthPassword: "secret"}, expectedErr: ErrIncompleteBasicAuth},
+		{
+			name:        "invalid user",
+			security:    &Security{BasicAuthUser: "admin: x", BasicAuthPassword: "secret"},
+			errContains: "invalid basic auth user",
+		},
+		{name: "invalid tenant", security: &Security{TenantID: "a\nb"}, errContains: "invalid tenant ID"},
+		{name: "invalid bind IP", security: &Security{BindIP: "localhost"}, errContains: "invalid bind IP"},
+		{name: "invalid CIDR", security: &Security{AllowedCIDRs: []string{"10.0.0.1"}}, errContains: "invalid allowed CIDR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.security.Validate()
+			switch {
+			case tt.expectedErr != nil:
+				assert.ErrorIs(t, err, tt.expectedErr)
+			case tt.errContains != "":
+				assert.ErrorContains(t, err, tt.errContains)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSecurity_Enabled(t *testing.T) {
+	var security *Security
+	assert.False(t, security.TLSEnabled())
+	assert.False(t, security.BasicAuthEnabled())
+
+	security = &Security{TLSCertFile: "cert.pem", TLSKeyFile: "key.pem", BasicAuthUser: "admin", BasicAuthPassword: "secret"}
+	assert.True(t, security.TLSEnabled())
+	assert.True(t, security.BasicAuthEnabled())
+}
+
+func TestWritePrometheusWebConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), PrometheusWebConfigFileName)
+	security := &Security{
+		TLSCertFile:       "cert.pem",
+		TLSKeyFile:        "key.pem",
+		BasicAuthUser:     "admin",
+		BasicAuthPassword: "secret",
+	}
+	require.NoError(t, WritePrometheusWebConfig(configPath, security))
+
+	content, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	webConfig := struct {
+		TLSServerConfig struct {
+			CertFile string `yaml:"cert_file"`
+			KeyFile  string `yaml:"key_file"`
+		} `yaml:"tls_server_config"`
+		BasicAuthUsers map[string]string `yaml:"basic_auth_users"`
+	}{}
+	require.NoError(t, yaml.Unmarshal(content, &webConfig))
+	assert.Equal(t, "/etc/prometheus/"+TLSCertFileName, webConfig.TLSServerConfig.CertFile)
+	assert.Equal(t, "/etc/prometheus/"+TLSKeyFileName, webConfig.TLSServerConfig.KeyFile)
+	require.Contains(t, webConfig.BasicAuthUsers, "admin")
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(webConfig.BasicAuthUsers["admin"]), []byte("secret")))
+	assert.NotContains(t, string(content), "secret")
+}
+
+func TestWriteConfigsWithSecurity(t *testing.T) {
+	security := &Security{
+		TLSCertFile:        "cert.pem",
+		TLSKeyFile:         "key.pem",
+		CAFile:             "ca.pem",
+		InsecureSkipVerify: true,
+		BasicAuthUser:      "admin",
+		BasicAuthPassword:  `p"a$s`,
+		TenantID:           "odyssey",
+	}
+	dir := t.TempDir()
+
+	t.Run("prometheus", func(t *testing.T) {
+		configPath := filepath.Join(dir, "prometheus.yml")
+		require.NoError(t, WritePrometheusConfigWithSecurity(configPath, []string{"10.0.0.1:9650"}, []string{"10.0.0.1:9100"}, nil, security))
+		content, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		config := struct {
+			ScrapeConfigs []struct {
+				JobName   string `yaml:"job_name"`
+				Scheme    string `yaml:"scheme"`
+				BasicAuth struct {
+					Username string `yaml:"username"`
+					Password string `yaml:"password"`
+				} `yaml:"basic_auth"`
+			} `yaml:"scrape_configs"`
+		}{}
+		require.NoError(t, yaml.Unmarshal(content, &config))
+		require.NotEmpty(t, config.ScrapeConfigs)
+		assert.Equal(t, "prometheus", config.ScrapeConfigs[0].JobName)
+		assert.Equal(t, "https", config.ScrapeConfigs[0].Scheme)
+		assert.Equal(t, "admin", config.ScrapeConfigs[0].BasicAuth.Username)
+		assert.Equal(t, `p"a$s`, config.ScrapeConfigs[0].BasicAuth.Password)
+	})
+
+	t.Run("loki", func(t *testing.T) {
+		configPath := filepath.Join(dir, "loki.yml")
+		require.NoError(t, WriteLokiConfigWithSecurity(configPath, "3100", security))
+		content, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		config := struct {
+			AuthEnabled bool `yaml:"auth_enabled"`
+			Server      struct {
+				HTTPListenPort int `yaml:"http_listen_port"`
+				HTTPTLSConfig  struct {
+					CertFile string `yaml:"cert_file"`
+					KeyFile  string `yaml:"key_file"`
+				} `yaml:"http_tls_config"`
+			} `yaml:"server"`
+		}{}
+		require.NoError(t, yaml.Unmarshal(content, &config))
+		assert.True(t, config.AuthEnabled)
+		assert.Equal(t, 3100, config.Server.HTTPListenPort)
+		assert.Equal(t, "/etc/loki/"+TLSCertFileName, config.Server.HTTPTLSConfig.CertFile)
+		assert.Equal(t, "/etc/loki/"+TLSKeyFileName, config.Server.HTTPTLSConfig.KeyFile)
+	})
+
+	t.Run("promtail", func(t *testing.T) {
+		configPath := filepath.Join(dir, "promtail.yml")
+		require.NoError(t, WritePromtailConfigWithSecurity(configPath, "10.0.0.1", "23101", "host", "node", "", security))
+		content, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		config := struct {
+			Clients []struct {
+				URL       string `yaml:"url"`
+				TenantID  string `yaml:"tenant_id"`
+				TLSConfig struct {
+					CAFile             string `yaml:"ca_file"`
+					InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+				} `yaml:"tls_config"`
+			} `yaml:"clients"`
+		}{}
+		require.NoError(t, yaml.Unmarshal(content, &config))
+		require.Len(t, config.Clients, 1)
+		assert.Equal(t, "https://10.0.0.1:23101/loki/api/v1/push", config.Clients[0].URL)
+		assert.Equal(t, "odyssey", config.Clients[0].TenantID)
+		assert.Equal(t, "/etc/promtail/"+CAFileName, config.Clients[0].TLSConfig.CAFile)
+		assert.True(t, config.Clients[0].TLSConfig.InsecureSkipVerify)
+	})
+}
+
+func TestWriteConfigsWithoutSecurity(t *testing.T) {
+	dir := t.TempDir()
+
+	lokiPath := filepath.Join(dir, "loki.yml")
+	require.NoError(t, WriteLokiConfig(lokiPath, "3100"))
+	content, err := os.ReadFile(lokiPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "auth_enabled: false")
+	assert.NotContains(t, string(content), "http_tls_config")
+
+	promtailPath := filepath.Join(dir, "promtail.yml")
+	require.NoError(t, WritePromtailConfig(promtailPath, "10.0.0.1", "23101", "host", "node", ""))
+	content, err = os.ReadFile(promtailPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "- url: http://10.0.0.1:23101/loki/api/v1/push")
+	assert.NotContains(t, string(content), "tenant_id")
+	assert.NotContains(t, string(content), "tls_config")
+
+	prometheusPath := filepath.Join(dir, "prometheus.yml")
+	require.NoError(t, WritePrometheusConfig(prometheusPath, []string{"10.0.0.1:9650"}, nil, nil))
+	content, err = os.ReadFile(prometheusPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "basic_auth")
+	assert.NotContains(t, string(content), "scheme: https")
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package monitoring
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// subnetPanel is a time series panel of the dashboard of a subnet, querying a recording rule
+// of configs/subnet-rules.yml
+type subnetPanel struct {
+	title  string
+	record string
+	unit   string
+}
+
+var subnetPanels = []subnetPanel{
+	{title: "Block Height", record: "subnet:block_height:max", unit: "none"},
+	{title: "Blocks Accepted", record: "subnet:blocks_accepted:rate5m", unit: "blocks/s"},
+	{title: "Gas Used", record: "subnet:gas_used:rate5m", unit: "gas/s"},
+	{title: "Transaction Throughput", record: "subnet:txs_accepted:rate5m", unit: "tx/s"},
+}
+
+// SubnetRulesFileName is the name of the recording rules file of blockchainID in the rules
+// dir of Prometheus
+func SubnetRulesFileName(blockchainID string) string {
+	return fmt.Sprintf("subnet-%s.yml", blockchainID)
+}
+
+// SubnetDashboardFileName is the name of the Grafana dashboard file of blockchainID
+func SubnetDashboardFileName(blockchainID string) string {
+	return fmt.Sprintf("subnet-%s.json", blockchainID)
+}
+
+// GenerateSubnetRecordingRules returns the Prometheus recording rules aggregating the block
+// height, block rate, gas used and tx throughput of blockchainID over the odysseygo targets
+func GenerateSubnetRecordingRules(subnetID string, blockchainID string, opts ...ConfigOption) (string, error) {
+	return GenerateConfig("configs/subnet-rules.yml", "Subnet Recording Rules", configInputs{
+		SubnetID: subnetID,
+		ChainID:  blockchainID,
+	}, opts...)
+}
+
+// GenerateSubnetDashboard returns the Grafana dashboard JSON of blockchainID, charting the
+// recording rules of GenerateSubnetRecordingRules. name is the title of the dashboard,
+// blockchainID if empty
+func GenerateSubnetDashboard(subnetID string, blockchainID string, name string) ([]byte, error) {
+	if name == "" {
+		name = blockchainID
+	}
+	uid := "subnet-" + blockchainID
+	// grafana dashboard UIDs are limited to 40 characters
+	if len(uid) > 40 {
+		uid = uid[:40]
+	}
+	// panels query the default datasource, the Prometheus of the monitoring node
+	panels := make([]map[string]interface{}, 0, len(subnetPanels))
+	for i, panel := range subnetPanels {
+		panels = append(panels, map[string]interface{}{
+			"id":      i + 1,
+			"type":    "timeseries",
+			"title":   panel.title,
+			"gridPos": map[string]int{"h": 8, "w": 12, "x": 12 * (i % 2), "y": 8 * (i / 2)},
+			"fieldConfig": map[string]interface{}{
+				"defaults":  map[string]string{"unit": panel.unit},
+				"overrides": []interface{}{},
+			},
+			"targets": []map[string]string{{
+				"refId":        "A",
+				"expr":         fmt.Sprintf("%s{blockchain_id=%q}", panel.record, blockchainID),
+				"legendFormat": panel.title,
+			}},
+		})
+	}
+	return json.MarshalIndent(map[string]interface{}{
+		"uid":           uid,
+		"title":         fmt.Sprintf("Subnet %s", name),
+		"description":   fmt.Sprintf("Blockchain %s of subnet %s", blockchainID, subnetID),
+		"tags":          []string{"subnet", subnetID},
+		"editable":      true,
+		"schemaVersion": 39,
+		"refresh":       "30s",
+		"time":          map[string]string{"from": "now-6h", "to": "now"},
+		"panels":        panels,
+	}, "", "  ")
+}
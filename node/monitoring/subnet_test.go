@@ -0,0 +1,72 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package monitoring
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerateSubnetRecordingRules(t *testing.T) {
+	rules, err := GenerateSubnetRecordingRules("subnet1", "chain1")
+	require.NoError(t, err)
+
+	parsed := struct {
+		Groups []struct {
+			Name  string `yaml:"name"`
+			Rules []struct {
+				Record string            `yaml:"record"`
+				Expr   string            `yaml:"expr"`
+				Labels map[string]string `yaml:"labels"`
+			} `yaml:"rules"`
+		} `yaml:"groups"`
+	}{}
+	require.NoError(t, yaml.Unmarshal([]byte(rules), &parsed))
+	require.Len(t, parsed.Groups, 1)
+	records := []string{}
+	for _, rule := range parsed.Groups[0].Rules {
+		records = append(records, rule.Record)
+		assert.Contains(t, rule.Expr, "chain1")
+		assert.Equal(t, map[string]string{"subnet_id": "subnet1", "blockchain_id": "chain1"}, rule.Labels)
+	}
+	for _, panel := range subnetPanels {
+		assert.Contains(t, records, panel.record)
+	}
+}
+
+func TestGenerateSubnetDashboard(t *testing.T) {
+	blockchainID := strings.Repeat("b", 50)
+	dashboard, err := GenerateSubnetDashboard("subnet1", blockchainID, "")
+	require.NoError(t, err)
+
+	parsed := struct {
+		UID    string `json:"uid"`
+		Title  string `json:"title"`
+		Panels []struct {
+			Title   string `json:"title"`
+			Targets []struct {
+				Expr string `json:"expr"`
+			} `json:"targets"`
+		} `json:"panels"`
+	}{}
+	require.NoError(t, json.Unmarshal(dashboard, &parsed))
+	assert.Len(t, parsed.UID, 40)
+	assert.Equal(t, "Subnet "+blockchainID, parsed.Title)
+	require.Len(t, parsed.Panels, len(subnetPanels))
+	for i, panel := range parsed.Panels {
+		require.Len(t, panel.Targets, 1)
+		assert.Equal(t, subnetPanels[i].record+`{blockchain_id="`+blockchainID+`"}`, panel.Targets[0].Expr)
+	}
+
+	dashboard, err = GenerateSubnetDashboard("subnet1", "chain1", "My Subnet")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(dashboard, &parsed))
+	assert.Equal(t, "subnet-chain1", parsed.UID)
+	assert.Equal(t, "Subnet My Subnet", parsed.Title)
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/monitoring"
+)
+
+// uploadPrometheusSecurityFiles pushes the Prometheus web config and TLS files of security
+func (h *Node) uploadPrometheusSecurityFiles(security *monitoring.Security) error {
+	if !usePrometheusWebConfig(security) {
+		return nil
+	}
+	webConfig, err := os.CreateTemp("", constants.ServicePrometheus)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(webConfig.Name())
+	if err := monitoring.WritePrometheusWebConfig(webConfig.Name(), security, h.monitoringOptions()...); err != nil {
+		return err
+	}
+	if err := h.Upload(
+		webConfig.Name(),
+		h.config().ServicePath(constants.ServicePrometheus, monitoring.PrometheusWebConfigFileName),
+		constants.SSHFileOpsTimeout,
+	); err != nil {
+		return err
+	}
+	return h.uploadTLSFiles(constants.ServicePrometheus, security)
+}
+
+// uploadLokiSecurityFiles pushes the TLS files of security to the Loki config dir
+func (h *Node) uploadLokiSecurityFiles(security *monitoring.Security) error {
+	return h.uploadTLSFiles(constants.ServiceLoki, security)
+}
+
+// uploadPromtailSecurityFiles pushes the CA certificate of security to the promtail config dir
+func (h *Node) uploadPromtailSecurityFiles(security *monitoring.Security) error {
+	if !security.TLSEnabled() || security.CAFile == "" {
+		return nil
+	}
+	return h.Upload(
+		security.CAFile,
+		h.config().ServicePath(constants.ServicePromtail, monitoring.CAFileName),
+		constants.SSHFileOpsTimeout,
+	)
+}
+
+func (h *Node) uploadTLSFiles(service string, security *monitoring.Security) error {
+	if !security.TLSEnabled() {
+		return nil
+	}
+	for localFile, remoteName := range map[string]string{
+		security.TLSCertFile: monitoring.TLSCertFileName,
+		security.TLSKeyFile:  monitoring.TLSKeyFileName,
+	} {
+		if err := h.Upload(
+			localFile,
+			h.config().ServicePath(service, remoteName),
+			constants.SSHFileOpsTimeout,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setupMonitoringFirewall restricts the sources allowed to reach the Loki and Prometheus ports
+// to the allowed CIDRs of security
+func (h *Node) setupMonitoringFirewall(security *monitoring.Security) error {
+	if security == nil || len(security.AllowedCIDRs) == 0 {
+		return nil
+	}
+	return h.RunOverSSH(
+		"Setup Monitoring Firewall",
+		constants.SSHScriptTimeout,
+		"shell/setupMonitoringFirewall.sh",
+		scriptInputs{
+			FirewallPorts: []string{
+				strconv.FormatUint(uint64(h.config().PrometheusPort), 10),
+				strconv.FormatUint(uint64(h.config().LokiPort), 10),
+			},
+			AllowedCIDRs: security.AllowedCIDRs,
+		},
+	)
+}
+
+// monitoringComposeInputs returns the inputs of the monitoring compose file for security
+func monitoringComposeInputs(security *monitoring.Security) dockerComposeInputs {
+	return dockerComposeInputs{
+		MonitoringBindIP:    monitoringBindIP(security),
+		PrometheusWebConfig: usePrometheusWebConfig(security),
+	}
+}
+
+// usePrometheusWebConfig returns true if Prometheus needs a web config for security
+func usePrometheusWebConfig(security *monitoring.Security) bool {
+	return security.TLSEnabled() || security.BasicAuthEnabled()
+}
+
+// monitoringBindIP returns the host IP the Loki and Prometheus ports are published on, in the
+// format of compose port mappings
+func monitoringBindIP(security *monitoring.Security) string {
+	if security == nil || security.BindIP == "" {
+		return ""
+	}
+	if ip := net.ParseIP(security.BindIP); ip != nil && ip.To4() == nil {
+		return "[" + security.BindIP + "]"
+	}
+	return security.BindIP
+}
+
+func grafanaDataSourceInputs(security *monitoring.Security) remoteconfig.GrafanaDataSourceInputs {
+	if security == nil {
+		return remoteconfig.GrafanaDataSourceInputs{}
+	}
+	inputs := remoteconfig.GrafanaDataSourceInputs{
+		TLS:      security.TLSEnabled(),
+		TenantID: security.TenantID,
+	}
+	if security.BasicAuthEnabled() {
+		inputs.BasicAuthUser = security.BasicAuthUser
+		inputs.BasicAuthPassword = security.BasicAuthPassword
+	}
+	return inputs
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/monitoring"
+)
+
+func TestMonitoringComposeInputs(t *testing.T) {
+	tests := []struct {
+		name             string
+		security         *monitoring.Security
+		expectedPorts    []string
+		expectWebConfig  bool
+		unexpectedOutput string
+	}{
+		{
+			name:             "no security",
+			expectedPorts:    []string{`"9090:9090"`, `"23101:3100"`},
+			unexpectedOutput: "--web.config.file",
+		},
+		{
+			name:            "ipv4 bind and TLS",
+			security:        &monitoring.Security{BindIP: "10.0.0.1", TLSCertFile: "cert.pem", TLSKeyFile: "key.pem"},
+			expectedPorts:   []string{`"10.0.0.1:9090:9090"`, `"10.0.0.1:23101:3100"`},
+			expectWebConfig: true,
+		},
+		{
+			name:            "ipv6 bind and basic auth",
+			security:        &monitoring.Security{BindIP: "::1", BasicAuthUser: "admin", BasicAuthPassword: "secret"},
+			expectedPorts:   []string{`"[::1]:9090:9090"`, `"[::1]:23101:3100"`},
+			expectWebConfig: true,
+		},
+		{
+			name:             "tenant only",
+			security:         &monitoring.Security{TenantID: "odyssey"},
+			expectedPorts:    []string{`"9090:9090"`, `"23101:3100"`},
+			unexpectedOutput: "--web.config.file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			composeData, err := renderComposeFile("templates/monitoring.docker-compose.yml", "test", monitoringComposeInputs(tt.security))
+			require.NoError(t, err)
+			_, err = parseComposeContent(composeData)
+			require.NoError(t, err)
+			for _, port := range tt.expectedPorts {
+				assert.Contains(t, string(composeData), port)
+			}
+			if tt.expectWebConfig {
+				assert.Contains(t, string(composeData), "'--web.config.file=/etc/prometheus/web.yml'")
+			}
+			if tt.unexpectedOutput != "" {
+				assert.NotContains(t, string(composeData), tt.unexpectedOutput)
+			}
+		})
+	}
+}
+
+func TestGrafanaDataSourcesWithSecurity(t *testing.T) {
+	security := &monitoring.Security{
+		TLSCertFile:       "cert.pem",
+		TLSKeyFile:        "key.pem",
+		BasicAuthUser:     "admin",
+		BasicAuthPassword: `pa$$"word`,
+		TenantID:          "odyssey",
+	}
+	type dataSources struct {
+		DataSources []struct {
+			URL            string                 `yaml:"url"`
+			BasicAuth      bool                   `yaml:"basicAuth"`
+			BasicAuthUser  string                 `yaml:"basicAuthUser"`
+			JSONData       map[string]interface{} `yaml:"jsonData"`
+			SecureJSONData map[string]string      `yaml:"secureJsonData"`
+		} `yaml:"datasources"`
+	}
+
+	lokiData, err := remoteconfig.RenderGrafanaLokiDataSourceConfig(grafanaDataSourceInputs(security))
+	require.NoError(t, err)
+	loki := dataSources{}
+	require.NoError(t, yaml.Unmarshal(lokiData, &loki))
+	require.Len(t, loki.DataSources, 1)
+	assert.Equal(t, "https://loki:3100", loki.DataSources[0].URL)
+	assert.Equal(t, true, loki.DataSources[0].JSONData["tlsSkipVerify"])
+	assert.Equal(t, "X-Scope-OrgID", loki.DataSources[0].JSONData["httpHeaderName1"])
+	assert.Equal(t, "odyssey", loki.DataSources[0].SecureJSONData["httpHeaderValue1"])
+
+	promData, err := remoteconfig.RenderGrafanaPrometheusDataSourceConfigg(grafanaDataSourceInputs(security))
+	require.NoError(t, err)
+	prom := dataSources{}
+	require.NoError(t, yaml.Unmarshal(promData, &prom))
+	require.Len(t, prom.DataSources, 1)
+	assert.Equal(t, "https://prometheus:9090", prom.DataSources[0].URL)
+	assert.True(t, prom.DataSources[0].BasicAuth)
+	assert.Equal(t, "admin", prom.DataSources[0].BasicAuthUser)
+	// Grafana expands $ in provisioning files, so it is escaped as $$
+	assert.Equal(t, `pa$$$$"word`, prom.DataSources[0].SecureJSONData["basicAuthPassword"])
+
+	// without security the data sources are unchanged
+	lokiData, err = remoteconfig.RenderGrafanaLokiDataSourceConfig(grafanaDataSourceInputs(nil))
+	require.NoError(t, err)
+	assert.Contains(t, string(lokiData), "url: http://loki:3100")
+	assert.NotContains(t, string(lokiData), "secureJsonData")
+	promData, err = remoteconfig.RenderGrafanaPrometheusDataSourceConfigg(grafanaDataSourceInputs(nil))
+	require.NoError(t, err)
+	assert.Contains(t, string(promData), "url: http://prometheus:9090")
+	assert.NotContains(t, string(promData), "basicAuth")
+}
+
+func TestMonitoringFirewallScript(t *testing.T) {
+	shellScript, err := script.ReadFile("shell/setupMonitoringFirewall.sh")
+	require.NoError(t, err)
+	tmpl, err := template.New("firewall").Parse(string(shellScript))
+	require.NoError(t, err)
+	var rendered bytes.Buffer
+	require.NoError(t, tmpl.Execute(&rendered, scriptInputs{
+		FirewallPorts: []string{"9090", "23101"},
+		AllowedCIDRs:  []string{"10.0.0.0/8", "192.168.1.0/24"},
+	}))
+	output := rendered.String()
+	for _, port := range []string{"9090", "23101"} {
+		assert.Contains(t, output, "rule -p tcp -m conntrack --ctstate DNAT --ctorigdstport "+port+" --ctdir ORIGINAL -j DROP")
+		for _, cidr := range []string{"10.0.0.0/8", "192.168.1.0/24"} {
+			assert.Contains(t, output, "rule -p tcp -m conntrack --ctstate DNAT --ctorigdstport "+port+" --ctorigsrc "+cidr+" -j RETURN")
+		}
+	}
+}
+
+func TestMonitoringSecurity_NoOpWithoutSettings(t *testing.T) {
+	h := &Node{}
+	// none of these need a connection when there is nothing to push
+	assert.NoError(t, h.uploadPrometheusSecurityFiles(nil))
+	assert.NoError(t, h.uploadLokiSecurityFiles(&monitoring.Security{TenantID: "odyssey"}))
+	assert.NoError(t, h.uploadPromtailSecurityFiles(&monitoring.Security{CAFile: "ca.pem"}))
+	assert.NoError(t, h.setupMonitoringFirewall(&monitoring.Security{BindIP: "10.0.0.1"}))
+}
+
+func TestRunSSHSetupPromtailConfig_InvalidSecurity(t *testing.T) {
+	h := &Node{MonitoringSecurity: &monitoring.Security{TLSCertFile: "cert.pem"}}
+	assert.ErrorIs(t, h.RunSSHSetupPromtailConfig("127.0.0.1", 3100, "node-1", ""), monitoring.ErrIncompleteTLS)
+	h.MonitoringSecurity = &monitoring.Security{AllowedCIDRs: []string{"not-a-cidr"}}
+	assert.ErrorContains(t, h.RunSSHSetupLokiConfig(3100), "invalid allowed CIDR")
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/monitoring"
+	"github.com/DioneProtocol/odysseygo/ids"
+)
+
+// prometheusSubnetRules is the rule_files entry of the recording rules of the subnets,
+// relative to the Prometheus config
+const prometheusSubnetRules = "rules/*.yml"
+
+var ErrEmptySubnetID = errors.New("subnet ID is not provided")
+
+// SubnetMonitoring identifies the blockchain of a subnet charted by the monitoring node
+type SubnetMonitoring struct {
+	SubnetID     ids.ID
+	BlockchainID ids.ID
+
+	// Name is the title of the Grafana dashboard, the blockchain ID if empty
+	Name string
+}
+
+// AddSubnetMonitoring pushes the Prometheus recording rules and the Grafana dashboard of the
+// block height, gas used and tx throughput of subnet to the monitoring node h, then
+// hot-reloads Prometheus. Grafana picks the dashboard up without restart
+func (h *Node) AddSubnetMonitoring(ctx context.Context, subnet SubnetMonitoring) error {
+	if subnet.SubnetID == ids.Empty {
+		return ErrEmptySubnetID
+	}
+	if subnet.BlockchainID == ids.Empty {
+		return ErrEmptyBlockchainID
+	}
+	rules, err := monitoring.GenerateSubnetRecordingRules(subnet.SubnetID.String(), subnet.BlockchainID.String(), h.monitoringOptions()...)
+	if err != nil {
+		return err
+	}
+	dashboard, err := monitoring.GenerateSubnetDashboard(subnet.SubnetID.String(), subnet.BlockchainID.String(), subnet.Name)
+	if err != nil {
+		return err
+	}
+
+	rulesDir := h.config().ServicePath(constants.ServicePrometheus, "rules")
+	if err := h.MkdirAll(rulesDir, constants.SSHFileOpsTimeout); err != nil {
+		return err
+	}
+	rulesChanged, err := h.UploadBytesIfChanged(
+		[]byte(rules),
+		filepath.Join(rulesDir, monitoring.SubnetRulesFileName(subnet.BlockchainID.String())),
+		constants.SSHFileOpsTimeout,
+		true,
+	)
+	if err != nil {
+		return err
+	}
+	dashboardsDir := h.config().ServicePath(constants.ServiceGrafana, constants.DashboardsDir)
+	if err := h.MkdirAll(dashboardsDir, constants.SSHFileOpsTimeout); err != nil {
+		return err
+	}
+	if _, err := h.UploadBytesIfChanged(
+		dashboard,
+		filepath.Join(dashboardsDir, monitoring.SubnetDashboardFileName(subnet.BlockchainID.String())),
+		constants.SSHFileOpsTimeout,
+		true,
+	); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	configChanged, err := h.updatePrometheusConfig(ctx, addPrometheusRuleFiles)
+	if err != nil {
+		return err
+	}
+	if !rulesChanged && !configChanged {
+		return nil
+	}
+	return h.reloadPrometheus()
+}
+
+// addPrometheusRuleFiles adds the recording rules of the subnets to the rule files of a
+// Prometheus config, for the monitoring nodes set up before they were generated
+func addPrometheusRuleFiles(config []byte) ([]byte, error) {
+	doc, _, err := parsePrometheusConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	root := doc.Content[0]
+	ruleFiles := mappingValue(root, "rule_files")
+	if ruleFiles == nil {
+		ruleFiles = &yaml.Node{Kind: yaml.SequenceNode}
+		root.Content = append(root.Content, scalarNode("rule_files"), ruleFiles)
+	}
+	if ruleFiles.Kind != yaml.SequenceNode {
+		// an empty rule_files is null
+		*ruleFiles = yaml.Node{Kind: yaml.SequenceNode}
+	}
+	if slices.ContainsFunc(ruleFiles.Content, func(n *yaml.Node) bool { return n.Value == prometheusSubnetRules }) {
+		return config, nil
+	}
+	ruleFiles.Content = append(ruleFiles.Content, scalarNode(prometheusSubnetRules))
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update prometheus rule files: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,292 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+)
+
+const (
+	prometheusOdysseyGoJob = "odysseygo"
+	prometheusMachineJob   = "odysseygo-machine"
+	prometheusLoadTestJob  = "odysseygo-loadtest"
+	prometheusRelayerJob   = "awm-relayer"
+)
+
+// AddMonitoringTargets adds newNodes to the Prometheus scrape config of the monitoring node h
+// and hot-reloads Prometheus, leaving the existing targets and dashboards untouched.
+// Promtail on each of newNodes is configured to push its logs to the Loki of h, using the
+// MonitoringSecurity of h for the nodes that have none.
+func (h *Node) AddMonitoringTargets(ctx context.Context, newNodes []Node) error {
+	odysseyGoTargets, machineTargets, loadTestTargets := getPrometheusTargets(newNodes)
+	relayerTargets, relayerMachineTargets := getRelayerPrometheusTargets(newNodes)
+	if err := h.updatePrometheusTargets(ctx, func(config []byte) ([]byte, error) {
+		return addPrometheusTargets(config, map[string][]string{
+			prometheusOdysseyGoJob: unquoteTargets(odysseyGoTargets),
+			prometheusMachineJob:   unquoteTargets(append(machineTargets, relayerMachineTargets...)),
+			prometheusLoadTestJob:  unquoteTargets(loadTestTargets),
+			prometheusRelayerJob:   unquoteTargets(relayerTargets),
+		})
+	}); err != nil {
+		return err
+	}
+	var errs []error
+	for _, node := range newNodes {
+		if !isOdysseyGoNode(node) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if node.MonitoringSecurity == nil {
+			node.MonitoringSecurity = h.MonitoringSecurity
+		}
+		if err := node.setupPromtail(h.IP, h.config().LokiPort); err != nil {
+			errs = append(errs, fmt.Errorf("failed to setup promtail on node %s: %w", node.NodeID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RemoveMonitoringTargets removes nodes from the Prometheus scrape config of the monitoring
+// node h and hot-reloads Prometheus. Logs already collected by Loki are kept.
+func (h *Node) RemoveMonitoringTargets(ctx context.Context, nodes []Node) error {
+	ips := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		ips = append(ips, node.IP)
+	}
+	return h.updatePrometheusTargets(ctx, func(config []byte) ([]byte, error) {
+		return removePrometheusTargets(config, ips)
+	})
+}
+
+// updatePrometheusTargets applies update to the Prometheus config of h and reloads Prometheus
+// if the config changed
+func (h *Node) updatePrometheusTargets(ctx context.Context, update func([]byte) ([]byte, error)) error {
+	changed, err := h.updatePrometheusConfig(ctx, update)
+	if err != nil || !changed {
+		return err
+	}
+	return h.reloadPrometheus()
+}
+
+// updatePrometheusConfig applies update to the Prometheus config of h, returning whether
+// the config changed
+func (h *Node) updatePrometheusConfig(ctx context.Context, update func([]byte) ([]byte, error)) (bool, error) {
+	remoteConfig := h.config().ServicePath(constants.ServicePrometheus, "prometheus.yml")
+	config, err := h.ReadFileBytes(remoteConfig, constants.SSHFileOpsTimeout)
+	if err != nil {
+		return false, fmt.Errorf("failed to read prometheus config of monitoring node %s: %w", h.NodeID, err)
+	}
+	updated, err := update(config)
+	if err != nil {
+		return false, err
+	}
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	promConfig, err := os.CreateTemp("", constants.ServicePrometheus)
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(promConfig.Name())
+	if _, err := promConfig.Write(updated); err != nil {
+		return false, err
+	}
+	if err := promConfig.Close(); err != nil {
+		return false, err
+	}
+	return h.UploadIfChanged(promConfig.Name(), remoteConfig, constants.SSHFileOpsTimeout, true)
+}
+
+// reloadPrometheus makes Prometheus reload its config and rules
+func (h *Node) reloadPrometheus() error {
+	// prometheus reloads its configuration on SIGHUP without losing its state
+	if output, err := h.dockerCommandf(constants.SSHScriptTimeout, "docker kill --signal=SIGHUP %s", constants.ServicePrometheus); err != nil {
+		return fmt.Errorf("failed to reload prometheus: %w: %s", err, string(output))
+	}
+	return nil
+}
+
+// setupPromtail configures promtail to push logs to the Loki of monitoringIP, published on
+// lokiPort, and restarts it
+func (h *Node) setupPromtail(monitoringIP string, lokiPort uint) error {
+	if err := h.RunSSHSetupPromtailConfig(monitoringIP, int(lokiPort), h.NodeID, ""); err != nil {
+		return err
+	}
+	hasPromtail, err := h.hasComposeService(constants.ServicePromtail)
+	if err != nil || !hasPromtail {
+		return err
+	}
+	return h.RestartDockerComposeService(h.config().ComposeFile(), constants.ServicePromtail, constants.SSHScriptTimeout)
+}
+
+// addPrometheusTargets adds targets to the static configs of the given scrape jobs of a
+// Prometheus config, creating the jobs that do not exist yet
+func addPrometheusTargets(config []byte, targets map[string][]string) ([]byte, error) {
+	doc, scrapeConfigs, err := parsePrometheusConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range []string{prometheusOdysseyGoJob, prometheusMachineJob, prometheusLoadTestJob, prometheusRelayerJob} {
+		if len(targets[job]) == 0 {
+			continue
+		}
+		jobNode := findScrapeJob(scrapeConfigs, job)
+		if jobNode == nil {
+			jobNode = newScrapeJob(job)
+			scrapeConfigs.Content = append(scrapeConfigs.Content, jobNode)
+		}
+		targetsNode := staticTargets(jobNode)
+		for _, target := range targets[job] {
+			if !slices.ContainsFunc(targetsNode.Content, func(n *yaml.Node) bool { return n.Value == target }) {
+				targetsNode.Content = append(targetsNode.Content, scalarNode(target))
+			}
+		}
+	}
+	return yaml.Marshal(doc)
+}
+
+// removePrometheusTargets removes the targets on any of ips from all the static configs of
+// all the scrape jobs of a Prometheus config. The static configs left without targets are
+// removed, except the first one of a job losing all of them, which keeps its labels for the
+// targets added later
+func removePrometheusTargets(config []byte, ips []string) ([]byte, error) {
+	doc, scrapeConfigs, err := parsePrometheusConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	for _, jobNode := range scrapeConfigs.Content {
+		staticConfigs := mappingValue(jobNode, "static_configs")
+		if staticConfigs == nil || staticConfigs.Kind != yaml.SequenceNode || len(staticConfigs.Content) == 0 {
+			continue
+		}
+		first := staticConfigs.Content[0]
+		staticConfigs.Content = slices.DeleteFunc(staticConfigs.Content, func(staticConfig *yaml.Node) bool {
+			targets := mappingValue(staticConfig, "targets")
+			if targets == nil || targets.Kind != yaml.SequenceNode || len(targets.Content) == 0 {
+				return false
+			}
+			targets.Content = slices.DeleteFunc(targets.Content, func(n *yaml.Node) bool {
+				host, _, err := net.SplitHostPort(n.Value)
+				return err == nil && slices.Contains(ips, host)
+			})
+			return len(targets.Content) == 0
+		})
+		if len(staticConfigs.Content) == 0 {
+			staticConfigs.Content = []*yaml.Node{first}
+		}
+	}
+	return yaml.Marshal(doc)
+}
+
+func parsePrometheusConfig(config []byte) (*yaml.Node, *yaml.Node, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(config, doc); err != nil {
+		return nil, nil, fmt.Errorf("invalid prometheus config: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("invalid prometheus config: expected a mapping")
+	}
+	scrapeConfigs := mappingValue(doc.Content[0], "scrape_configs")
+	if scrapeConfigs == nil || scrapeConfigs.Kind != yaml.SequenceNode {
+		return nil, nil, fmt.Errorf("invalid prometheus config: scrape_configs not found")
+	}
+	return doc, scrapeConfigs, nil
+}
+
+func findScrapeJob(scrapeConfigs *yaml.Node, job string) *yaml.Node {
+	for _, jobNode := range scrapeConfigs.Content {
+		if name := mappingValue(jobNode, "job_name"); name != nil && name.Value == job {
+			return jobNode
+		}
+	}
+	return nil
+}
+
+func newScrapeJob(job string) *yaml.Node {
+	return &yaml.Node{
+		Kind: yaml.MappingNode,
+		Content: []*yaml.Node{
+			scalarNode("job_name"), scalarNode(job),
+			scalarNode("static_configs"), {
+				Kind: yaml.SequenceNode,
+				Content: []*yaml.Node{{
+					Kind: yaml.MappingNode,
+					Content: []*yaml.Node{
+						scalarNode("targets"), {Kind: yaml.SequenceNode, Style: yaml.FlowStyle},
+						scalarNode("labels"), {
+							Kind:    yaml.MappingNode,
+							Content: []*yaml.Node{scalarNode("alias"), scalarNode(job)},
+						},
+					},
+				}},
+			},
+		},
+	}
+}
+
+// staticTargets returns the targets sequence of the first static config of a scrape job,
+// creating it if needed. The existing keys are reused, their value being replaced if it is
+// not a sequence, e.g. null when empty
+func staticTargets(jobNode *yaml.Node) *yaml.Node {
+	staticConfigs := mappingValue(jobNode, "static_configs")
+	if staticConfigs == nil {
+		staticConfigs = &yaml.Node{Kind: yaml.SequenceNode}
+		jobNode.Content = append(jobNode.Content, scalarNode("static_configs"), staticConfigs)
+	}
+	if staticConfigs.Kind != yaml.SequenceNode {
+		*staticConfigs = yaml.Node{Kind: yaml.SequenceNode}
+	}
+	if len(staticConfigs.Content) == 0 {
+		staticConfigs.Content = append(staticConfigs.Content, &yaml.Node{Kind: yaml.MappingNode})
+	}
+	staticConfig := staticConfigs.Content[0]
+	if staticConfig.Kind != yaml.MappingNode {
+		*staticConfig = yaml.Node{Kind: yaml.MappingNode}
+	}
+	targets := mappingValue(staticConfig, "targets")
+	if targets == nil {
+		targets = &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		staticConfig.Content = append(staticConfig.Content, scalarNode("targets"), targets)
+	}
+	if targets.Kind != yaml.SequenceNode {
+		*targets = yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	}
+	return targets
+}
+
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func unquoteTargets(targets []string) []string {
+	unquoted := make([]string, 0, len(targets))
+	for _, target := range targets {
+		unquoted = append(unquoted, strings.Trim(target, "'"))
+	}
+	return unquoted
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/monitoring"
+)
+
+type testPrometheusConfig struct {
+	ScrapeConfigs []struct {
+		JobName       string `yaml:"job_name"`
+		StaticConfigs []struct {
+			Targets []string `yaml:"targets"`
+		} `yaml:"static_configs"`
+	} `yaml:"scrape_configs"`
+}
+
+func prometheusJobTargets(t *testing.T, config []byte) map[string][]string {
+	parsed := testPrometheusConfig{}
+	require.NoError(t, yaml.Unmarshal(config, &parsed))
+	targets := map[string][]string{}
+	for _, job := range parsed.ScrapeConfigs {
+		targets[job.JobName] = []string{}
+		for _, staticConfig := range job.StaticConfigs {
+			targets[job.JobName] = append(targets[job.JobName], staticConfig.Targets...)
+		}
+	}
+	return targets
+}
+
+func generatedPrometheusConfig(t *testing.T) []byte {
+	path := filepath.Join(t.TempDir(), "prometheus.yml")
+	require.NoError(t, monitoring.WritePrometheusConfig(path, []string{"10.0.0.1:9650"}, []string{"10.0.0.1:9100"}, nil))
+	config, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return config
+}
+
+func TestAddPrometheusTargets(t *testing.T) {
+	config := generatedPrometheusConfig(t)
+	odysseyGoTargets, machineTargets, loadTestTargets := getPrometheusTargets([]Node{
+		{IP: "10.0.0.1", Roles: []SupportedRole{Validator}},
+		{IP: "10.0.0.2", Roles: []SupportedRole{API}},
+		{IP: "10.0.0.3", Roles: []SupportedRole{Loadtest}},
+	})
+	updated, err := addPrometheusTargets(config, map[string][]string{
+		prometheusOdysseyGoJob: unquoteTargets(odysseyGoTargets),
+		prometheusMachineJob:   unquoteTargets(machineTargets),
+		prometheusLoadTestJob:  unquoteTargets(loadTestTargets),
+	})
+	require.NoError(t, err)
+
+	targets := prometheusJobTargets(t, updated)
+	assert.Equal(t, []string{"prometheus:9090"}, targets["prometheus"])
+	assert.Equal(t, []string{"10.0.0.1:9650", "10.0.0.2:9650"}, targets[prometheusOdysseyGoJob])
+	assert.Equal(t, []string{"10.0.0.1:9100", "10.0.0.2:9100"}, targets[prometheusMachineJob])
+	assert.Equal(t, []string{"10.0.0.3:8082"}, targets[prometheusLoadTestJob])
+	assert.Contains(t, string(updated), "metrics_path: '/ext/metrics'")
+}
+
+func TestRemovePrometheusTargets(t *testing.T) {
+	config, err := addPrometheusTargets(generatedPrometheusConfig(t), map[string][]string{
+		prometheusOdysseyGoJob: {"10.0.0.2:9650"},
+		prometheusMachineJob:   {"10.0.0.2:9100"},
+	})
+	require.NoError(t, err)
+
+	updated, err := removePrometheusTargets(config, []string{"10.0.0.1"})
+	require.NoError(t, err)
+	targets := prometheusJobTargets(t, updated)
+	assert.Equal(t, []string{"prometheus:9090"}, targets["prometheus"])
+	assert.Equal(t, []string{"10.0.0.2:9650"}, targets[prometheusOdysseyGoJob])
+	assert.Equal(t, []string{"10.0.0.2:9100"}, targets[prometheusMachineJob])
+}
+
+func TestAddPrometheusTargets_EmptyStaticConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{name: "null targets", config: "scrape_configs:\n  - job_name: odysseygo\n    static_configs:\n      - targets:\n"},
+		{name: "null static configs", config: "scrape_configs:\n  - job_name: odysseygo\n    static_configs:\n"},
+		{name: "null static config", config: "scrape_configs:\n  - job_name: odysseygo\n    static_configs:\n      -\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := addPrometheusTargets([]byte(tt.config), map[string][]string{prometheusOdysseyGoJob: {"10.0.0.1:9650"}})
+			require.NoError(t, err)
+			assert.Equal(t, 1, strings.Count(string(updated), "targets:"))
+			assert.Equal(t, []string{"10.0.0.1:9650"}, prometheusJobTargets(t, updated)[prometheusOdysseyGoJob])
+		})
+	}
+}
+
+func TestRemovePrometheusTargets_EmptiedStaticConfigs(t *testing.T) {
+	config := []byte(`scrape_configs:
+  - job_name: odysseygo
+    static_configs:
+      - targets: ['10.0.0.1:9650']
+        labels:
+          alias: validators
+      - targets: ['10.0.0.2:9650']
+        labels:
+          alias: api
+  - job_name: odysseygo-machine
+    static_configs:
+      - targets: ['10.0.0.1:9100', '10.0.0.2:9100']
+        labels:
+          alias: machine
+`)
+	updated, err := removePrometheusTargets(config, []string{"10.0.0.1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.2:9650"}, prometheusJobTargets(t, updated)[prometheusOdysseyGoJob])
+	assert.NotContains(t, string(updated), "validators")
+
+	// the last static config of a job is kept with its labels
+	updated, err = removePrometheusTargets(updated, []string{"10.0.0.2"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(updated), "targets: []"))
+	assert.Contains(t, string(updated), "alias: api")
+	assert.Contains(t, string(updated), "alias: machine")
+	updated, err = addPrometheusTargets(updated, map[string][]string{prometheusMachineJob: {"10.0.0.3:9100"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.3:9100"}, prometheusJobTargets(t, updated)[prometheusMachineJob])
+	assert.Equal(t, 2, strings.Count(string(updated), "targets:"))
+}
+
+func TestPrometheusTargets_InvalidConfig(t *testing.T) {
+	_, err := addPrometheusTargets([]byte("global: {}\n"), map[string][]string{prometheusOdysseyGoJob: {"10.0.0.1:9650"}})
+	assert.Error(t, err)
+	_, err = removePrometheusTargets([]byte("- a\n"), []string{"10.0.0.1"})
+	assert.Error(t, err)
+}
+
+func TestAddPrometheusRuleFiles(t *testing.T) {
+	ruleFiles := func(t *testing.T, config []byte) []string {
+		parsed := struct {
+			RuleFiles []string `yaml:"rule_files"`
+		}{}
+		require.NoError(t, yaml.Unmarshal(config, &parsed))
+		return parsed.RuleFiles
+	}
+	tests := []struct {
+		name     string
+		config   []byte
+		expected []string
+	}{
+		{name: "generated", config: generatedPrometheusConfig(t), expected: []string{prometheusSubnetRules}},
+		{name: "null", config: []byte("rule_files:\nscrape_configs: []\n"), expected: []string{prometheusSubnetRules}},
+		{name: "missing", config: []byte("scrape_configs: []\n"), expected: []string{prometheusSubnetRules}},
+		{
+			name:     "other rules",
+			config:   []byte("rule_files:\n  - alerts.yml\nscrape_configs: []\n"),
+			expected: []string{"alerts.yml", prometheusSubnetRules},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := addPrometheusRuleFiles(tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ruleFiles(t, updated))
+
+			// adding the rules again leaves the config unchanged
+			again, err := addPrometheusRuleFiles(updated)
+			require.NoError(t, err)
+			assert.Equal(t, updated, again)
+		})
+	}
+}
@@ -26,13 +26,13 @@ func (h *Node) OdysseygoTCPClient() (*net.Conn, error) {
 			return nil, err
 		}
 	}
-	odysseyGoEndpoint := strings.TrimPrefix(constants.LocalAPIEndpoint, "http://")
+	odysseyGoEndpoint := strings.TrimPrefix(h.config().LocalAPIEndpoint(), "http://")
 	odysseyGoAddr, err := net.ResolveTCPAddr("tcp", odysseyGoEndpoint)
 	if err != nil {
 		return nil, err
 	}
 	var proxy net.Conn
-	proxy, err = h.connection.DialTCP("tcp", nil, odysseyGoAddr)
+	proxy, err = h.connection.DialTCP(odysseyGoAddr)
 	if err != nil {
 		return nil, fmt.Errorf("unable to port forward to %s via %s", h.connection.RemoteAddr(), "ssh")
 	}
@@ -63,7 +63,7 @@ func (h *Node) Post(path string, requestBody string) ([]byte, error) {
 	if path == "" {
 		path = "/ext/info"
 	}
-	localhost, err := url.Parse(constants.LocalAPIEndpoint)
+	localhost, err := url.Parse(h.config().LocalAPIEndpoint())
 	if err != nil {
 		return nil, err
 	}
@@ -591,7 +591,8 @@ func TestNode_NetworkOperations_ContextHandling(t *testing.T) {
 		{
 			name: "Context with timeout",
 			ctx: func() context.Context {
-				ctx, _ := context.WithTimeout(context.Background(), 100*time.Millisecond)
+				ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+				t.Cleanup(cancel)
 				return ctx
 			}(),
 			expectError: true,
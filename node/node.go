@@ -7,8 +7,10 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net"
 	"net/http"
 	"os"
@@ -22,8 +24,12 @@ import (
 	"github.com/melbahja/goph"
 	"golang.org/x/crypto/ssh"
 
+	sdkconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/config"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/hostkeys"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/monitoring"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/progress"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
 )
 
@@ -38,8 +44,25 @@ type SSHConfig struct {
 
 	// Parameters to pass to the ssh command.
 	// See man ssh_config(5) for more information
-	// By defalult it's StrictHostKeyChecking=no
+	// By defalult it's StrictHostKeyChecking=no. The SDK connections verify the host key as
+	// set by HostKeyPolicy instead
 	Params map[string]string // additional parameters to pass to the ssh command
+
+	// SudoPassword is the password of User used by sudo when User is not allowed passwordless
+	// sudo. It is only sent to the node through stdin
+	SudoPassword string
+
+	// RunWithSudo runs docker commands as root, for nodes where User is not in the docker group.
+	// Docker commands are retried as root anyway if the docker daemon denies access to User
+	RunWithSudo bool
+
+	// HostKeyPolicy is how the host key of the node is verified, the SDK config one if empty,
+	// itself hostkeys.TrustOnFirstUse by default
+	HostKeyPolicy hostkeys.Policy
+
+	// KnownHostsPath is the known_hosts file recording the host key of the node, the SDK config
+	// one if empty
+	KnownHostsPath string
 }
 
 // Node is an output of CreateNodes
@@ -53,8 +76,21 @@ type Node struct {
 	// SSH configuration for the node
 	SSHConfig SSHConfig
 
-	// connection to the node
-	connection *goph.Client
+	// connection to the node, see SetSSHClient
+	connection SSHClient
+
+	// platform of the node, cached by DetectPlatform
+	platform *HostPlatform
+
+	// privileges of the SSH user, cached by DetectPrivilege
+	privilege *PrivilegeMode
+
+	// compose is the manager of the compose file of the node, cached by Compose
+	compose *ComposeManager
+
+	// sftpState records whether the SFTP subsystem of the connection is unavailable, see
+	// newSftp. It is reset with the connection
+	sftpState *sftpState
 
 	// Roles of the node
 	// Full list of node roles:
@@ -65,16 +101,63 @@ type Node struct {
 	// - Monitoring
 	Roles []SupportedRole
 
+	// Labels identify the node, e.g. its cluster and role, see NodeParams.ResourceLabels.
+	// Use FilterNodes to select nodes by label
+	Labels map[string]string
+
+	// MonitoringSecurity configures TLS, authentication and binding of the Loki and Prometheus
+	// endpoints. Set it on the monitoring node and on the nodes pushing their logs to it.
+	// Plaintext, unauthenticated endpoints are used when nil
+	MonitoringSecurity *monitoring.Security
+
+	// Preset configures the odysseygo flags of the node for its use case, e.g. an archive
+	// RPC node or a pruned validator. See NodePreset
+	Preset NodePreset
+
+	// ComposeExtras adds environment variables and volumes to the services of the compose
+	// files rendered for the node
+	ComposeExtras *ComposeExtras
+
+	// Config sets the ports and remote folders the node is provisioned and operated with,
+	// for deployments not using the standard ones. Its zero fields stand for the defaults,
+	// see constants.DefaultConfig
+	Config constants.Config
+
+	// RuntimeMode is how odysseygo runs on the node, DockerRuntime if empty. It is set by
+	// ProvisionNodes and AddRole, and selects how odysseygo is started, stopped, restarted and
+	// upgraded
+	RuntimeMode RuntimeMode
+
+	// ExecutionPolicy restricts the commands of the scripts and compose files rendered for
+	// the node before they run on it. They are not checked when nil
+	ExecutionPolicy *ExecutionPolicy
+
+	// TemplateOverrides customizes the compose files and service configs rendered for the
+	// node without forking the SDK, see TemplateOverridesDir for its layout. Only the
+	// embedded templates are used when nil
+	TemplateOverrides fs.FS
+
 	// Logger for node
 	Logger odyssey.LeveledLogger
 
+	// Progress receives the progress of long-running operations on the node, such as
+	// provisioning, upgrades and database transfers. Progress is not reported when nil
+	Progress progress.Reporter
+
 	// BLS provides a way to aggregate signatures off chain into a single signature that can be efficiently verified on chain.
 	// For more information about how BLS is used on the O-Chain, please head to https://docs.dione.network/cross-chain/odyssey-warp-messaging/deep-dive#bls-multi-signatures-with-public-key-aggregation
 	BlsSecretKey *bls.SecretKey
 }
 
-// NewNodeConnection creates a new SSH connection to the node
+// NewNodeConnection creates a new SSH connection to the node, verifying its host key as set by
+// its SSHConfig
 func NewNodeConnection(h *Node, port uint) (*goph.Client, error) {
+	return newNodeConnection(h, port, "")
+}
+
+// newNodeConnection creates a new SSH connection to the node, verifying its host key with
+// policy, or as set by its SSHConfig if empty
+func newNodeConnection(h *Node, port uint, policy hostkeys.Policy) (*goph.Client, error) {
 	if port == 0 {
 		port = constants.SSHTCPPort
 	}
@@ -83,22 +166,39 @@ func NewNodeConnection(h *Node, port uint) (*goph.Client, error) {
 		err  error
 	)
 
-	if h.SSHConfig.PrivateKeyPath == "" {
+	sshConfig := h.SSHConfig
+	if sshConfig.User == "" {
+		sshConfig.User = sdkconfig.Get().SSH.User
+	}
+	if sshConfig.PrivateKeyPath == "" {
+		sshConfig.PrivateKeyPath = sdkconfig.Get().SSH.PrivateKeyPath
+	}
+	if sshConfig.PrivateKeyPath == "" {
 		auth, err = goph.UseAgent()
 	} else {
-		auth, err = goph.Key(h.SSHConfig.PrivateKeyPath, "")
+		auth, err = goph.Key(sshConfig.PrivateKeyPath, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if policy == "" {
+		policy = h.hostKeyPolicy()
+	}
+	store, err := h.HostKeyStore()
+	if err != nil {
+		return nil, err
 	}
+	callback, err := store.Callback(policy)
 	if err != nil {
 		return nil, err
 	}
 	cl, err := goph.NewConn(&goph.Config{
-		User:    h.SSHConfig.User,
-		Addr:    h.IP,
-		Port:    port,
-		Auth:    auth,
-		Timeout: sshConnectionTimeout,
-		// #nosec G106
-		Callback: ssh.InsecureIgnoreHostKey(), // we don't verify node key ( similar to ansible)
+		User:     sshConfig.User,
+		Addr:     h.IP,
+		Port:     port,
+		Auth:     auth,
+		Timeout:  sshConnectionTimeout,
+		Callback: callback,
 	})
 	if err != nil {
 		return nil, err
@@ -106,37 +206,65 @@ func NewNodeConnection(h *Node, port uint) (*goph.Client, error) {
 	return cl, nil
 }
 
+// ErrNotConnected is returned when an SSH connection to a node cannot be established
+var ErrNotConnected = errors.New("failed to connect to node")
+
 // GetConnection returns the SSH connection client for the Node.
-// Returns a pointer to a goph.Client.
+// Returns a pointer to a goph.Client, or nil if the node uses another SSHClient.
 func (h *Node) GetConnection() *goph.Client {
-	return h.connection
+	if c, ok := h.connection.(gophSSHClient); ok {
+		return c.client
+	}
+	return nil
 }
 
 // GetSSHClient returns the SSH client for the Node.
-// Returns a pointer to an ssh.Client.
+// Returns a pointer to an ssh.Client, or nil if the node uses another SSHClient.
 func (h *Node) GetSSHClient() *ssh.Client {
-	if h.connection == nil {
-		return nil
+	if c := h.GetConnection(); c != nil {
+		return c.Client
 	}
-	return h.connection.Client
+	return nil
+}
+
+// config returns the Config of the node, its zero fields set to the defaults of the SDK config
+func (h *Node) config() constants.Config {
+	return sdkconfig.Get().WithNodeDefaults(h.Config.WithHome(h.ExpandHome("")))
 }
 
 // Connect starts a new SSH connection with the provided private key.
 func (h *Node) Connect(port uint) error {
+	return h.connect(port, "")
+}
+
+// connect starts a new SSH connection, verifying the host key with policy, or as set by
+// SSHConfig if empty. Rejected host keys are not retried
+func (h *Node) connect(port uint, policy hostkeys.Policy) error {
 	if port == 0 {
 		port = constants.SSHTCPPort
 	}
 	if h.connection != nil {
 		return nil
 	}
-	var err error
-	for i := 0; h.connection == nil && i < sshConnectionRetries; i++ {
-		h.connection, err = NewNodeConnection(h, port)
-		time.Sleep(constants.SSHSleepBetweenChecks)
-	}
+	client, err := utils.Retry(
+		context.Background(),
+		utils.RetryPolicy{
+			MaxAttempts:     sshConnectionRetries,
+			InitialInterval: sshConnectionRetryInterval,
+			MaxInterval:     constants.SSHSleepBetweenChecks,
+			Jitter:          0.2,
+			Retryable: func(err error) bool {
+				return !errors.Is(err, hostkeys.ErrHostKeyMismatch) && !errors.Is(err, hostkeys.ErrUnknownHost) && !errors.Is(err, hostkeys.ErrInvalidPolicy)
+			},
+		},
+		func(context.Context) (*goph.Client, error) {
+			return newNodeConnection(h, port, policy)
+		},
+	)
 	if err != nil {
-		return fmt.Errorf("failed to connect to node %s: %w", h.IP, err)
+		return fmt.Errorf("%w %s: %w", ErrNotConnected, h.IP, err)
 	}
+	h.SetSSHClient(gophSSHClient{client: client})
 	return nil
 }
 
@@ -153,6 +281,7 @@ func (h *Node) Disconnect() error {
 }
 
 // Upload uploads a local file to a remote file on the node.
+// Use UploadResumable for large files, to resume the upload after transient SSH failures.
 func (h *Node) Upload(localFile string, remoteFile string, timeout time.Duration) error {
 	if !h.Connected() {
 		if err := h.Connect(0); err != nil {
@@ -162,7 +291,7 @@ func (h *Node) Upload(localFile string, remoteFile string, timeout time.Duration
 	_, err := utils.CallWithTimeout(
 		"upload",
 		func() (interface{}, error) {
-			return nil, h.connection.Upload(localFile, remoteFile)
+			return nil, h.upload(localFile, remoteFile, timeout)
 		},
 		timeout,
 	)
@@ -188,7 +317,84 @@ func (h *Node) UploadBytes(data []byte, remoteFile string, timeout time.Duration
 	return h.Upload(tmpFile.Name(), remoteFile, timeout)
 }
 
+// UploadIfChanged uploads a local file to a remote file on the node only if their SHA-256
+// checksums differ. If backup is true, an existing remote file is copied to remoteFile.bak
+// before being overwritten. Returns whether the file was uploaded.
+func (h *Node) UploadIfChanged(localFile string, remoteFile string, timeout time.Duration, backup bool) (bool, error) {
+	localSum, err := utils.FileSHA256(localFile)
+	if err != nil {
+		return false, err
+	}
+	remoteSum, err := h.RemoteFileSHA256(remoteFile, timeout)
+	if err != nil {
+		return false, err
+	}
+	if localSum == remoteSum {
+		h.Logger.Infof("Skipping upload of %s to %s:%s, content unchanged", localFile, h.NodeID, remoteFile)
+		return false, nil
+	}
+	if backup && remoteSum != "" {
+		if err := h.BackupFile(remoteFile, timeout); err != nil {
+			return false, err
+		}
+	}
+	if err := h.Upload(localFile, remoteFile, timeout); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UploadBytesIfChanged is UploadIfChanged for a byte array.
+func (h *Node) UploadBytesIfChanged(data []byte, remoteFile string, timeout time.Duration, backup bool) (bool, error) {
+	tmpFile, err := os.CreateTemp("", "NodeUploadBytes-*.tmp")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(data); err != nil {
+		return false, err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return false, err
+	}
+	return h.UploadIfChanged(tmpFile.Name(), remoteFile, timeout, backup)
+}
+
+// RemoteFileSHA256 returns the hex encoded SHA-256 checksum of a remote file,
+// or an empty string if the file does not exist.
+func (h *Node) RemoteFileSHA256(remoteFile string, timeout time.Duration) (string, error) {
+	fileExists, err := h.FileExists(remoteFile)
+	if err != nil {
+		return "", err
+	}
+	if !fileExists {
+		return "", nil
+	}
+	output, err := h.Commandf(nil, timeout, "sha256sum %s", remoteFile)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, string(output))
+	}
+	return parseSHA256SumOutput(output)
+}
+
+// BackupFile copies a remote file to remoteFile.bak, preserving its permissions.
+func (h *Node) BackupFile(remoteFile string, timeout time.Duration) error {
+	if output, err := h.Commandf(nil, timeout, "cp -p %s %s.bak", remoteFile, remoteFile); err != nil {
+		return fmt.Errorf("%w: %s", err, string(output))
+	}
+	return nil
+}
+
+func parseSHA256SumOutput(output []byte) (string, error) {
+	fields := strings.Fields(string(output))
+	if len(fields) == 0 || len(fields[0]) != 64 {
+		return "", fmt.Errorf("unexpected sha256sum output: %q", string(output))
+	}
+	return fields[0], nil
+}
+
 // Download downloads a file from the remote server to the local machine.
+// Use DownloadResumable for large files, to resume the download after transient SSH failures.
 func (h *Node) Download(remoteFile string, localFile string, timeout time.Duration) error {
 	if !h.Connected() {
 		if err := h.Connect(0); err != nil {
@@ -201,7 +407,7 @@ func (h *Node) Download(remoteFile string, localFile string, timeout time.Durati
 	_, err := utils.CallWithTimeout(
 		"download",
 		func() (interface{}, error) {
-			return nil, h.connection.Download(remoteFile, localFile)
+			return nil, h.download(remoteFile, localFile, timeout)
 		},
 		timeout,
 	)
@@ -224,9 +430,21 @@ func (h *Node) ReadFileBytes(remoteFile string, timeout time.Duration) ([]byte,
 	return os.ReadFile(tmpFile.Name())
 }
 
+// sshUser returns the user the node is connected as, the user of the SDK config if its
+// SSHConfig has none
+func (h *Node) sshUser() string {
+	if h.SSHConfig.User != "" {
+		return h.SSHConfig.User
+	}
+	return sdkconfig.Get().SSH.User
+}
+
 // ExpandHome expands the ~ symbol to the home directory.
 func (h *Node) ExpandHome(path string) string {
-	userHome := filepath.Join("/home", h.SSHConfig.User)
+	userHome := filepath.Join("/home", h.sshUser())
+	if h.sshUser() == "root" {
+		userHome = "/root"
+	}
 	if path == "" {
 		return userHome
 	}
@@ -265,7 +483,13 @@ func (h *Node) UntimedMkdirAll(remoteDir string) error {
 			return err
 		}
 	}
-	sftp, err := h.connection.NewSftp()
+	sftp, err := h.newSftp()
+	if errors.Is(err, ErrSFTPUnavailable) {
+		if output, err := h.Commandf(nil, constants.SSHFileOpsTimeout, "mkdir -p %s", shellQuote(remoteDir)); err != nil {
+			return fmt.Errorf("%w: %s", err, string(output))
+		}
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -274,32 +498,37 @@ func (h *Node) UntimedMkdirAll(remoteDir string) error {
 }
 
 // Cmd returns a new command to be executed on the remote node.
+// It requires the SSH connection made by Connect, see SetSSHClient.
 func (h *Node) Cmd(ctx context.Context, name string, script string) (*goph.Cmd, error) {
 	if !h.Connected() {
 		if err := h.Connect(0); err != nil {
 			return nil, err
 		}
 	}
-	return h.connection.CommandContext(ctx, name, script)
+	client := h.GetConnection()
+	if client == nil {
+		return nil, fmt.Errorf("%w: Cmd requires a goph client", ErrUnsupportedSSHClient)
+	}
+	return client.CommandContext(ctx, name, script)
 }
 
-// Command executes a shell command on a remote node.
+// Command executes a shell command on a remote node, returning its stdout and stderr combined.
+// If the script calls sudo and sudo requires a password, SSHConfig.SudoPassword is used.
+// See RunCommand for the exit code and the streams apart.
 func (h *Node) Command(env []string, timeout time.Duration, script string) ([]byte, error) {
-	if !h.Connected() {
-		if err := h.Connect(0); err != nil {
-			return nil, err
-		}
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-	cmd, err := h.connection.CommandContext(ctx, "", script)
+	script, stdin, err := h.prepareSudo(script, nil)
 	if err != nil {
 		return nil, err
 	}
-	if env != nil {
-		cmd.Env = env
+	output, err := h.command(env, timeout, script, stdin)
+	if err != nil && usesSudo(script) {
+		err = sudoError(err, output)
 	}
-	output, err := cmd.CombinedOutput()
+	return output, err
+}
+
+func (h *Node) command(env []string, timeout time.Duration, script string, stdin io.Reader) ([]byte, error) {
+	_, output, err := h.runCommand(env, timeout, script, stdin)
 	return output, err
 }
 
@@ -315,16 +544,24 @@ func (h *Node) Forward(httpRequest string, timeout time.Duration) ([]byte, error
 			return nil, err
 		}
 	}
-	return utils.Retry(
+	response, err := utils.Retry(
+		context.Background(),
+		utils.RetryPolicy{
+			MaxAttempts:     sshForwardRetries,
+			AttemptTimeout:  timeout,
+			InitialInterval: constants.SSHSleepBetweenChecks,
+			Jitter:          0.2,
+		},
 		utils.WrapContext(
 			func() ([]byte, error) {
 				return h.UntimedForward(httpRequest)
 			},
 		),
-		timeout,
-		3,
-		fmt.Sprintf("failure on node %s post over ssh", h.IP),
 	)
+	if err != nil {
+		return nil, fmt.Errorf("failure on node %s post over ssh: %w", h.IP, err)
+	}
+	return response, nil
 }
 
 // UntimedForward forwards the TCP connection to a remote address.
@@ -335,20 +572,20 @@ func (h *Node) UntimedForward(httpRequest string) ([]byte, error) {
 			return nil, err
 		}
 	}
-	odysseyGoEndpoint := strings.TrimPrefix(constants.LocalAPIEndpoint, "http://")
+	odysseyGoEndpoint := strings.TrimPrefix(h.config().LocalAPIEndpoint(), "http://")
 	odysseyGoAddr, err := net.ResolveTCPAddr("tcp", odysseyGoEndpoint)
 	if err != nil {
 		return nil, err
 	}
 	var proxy net.Conn
 	if utils.IsE2E() {
-		odysseyGoEndpoint = fmt.Sprintf("%s:%d", utils.E2EConvertIP(h.IP), constants.OdysseygoAPIPort)
+		odysseyGoEndpoint = fmt.Sprintf("%s:%d", utils.E2EConvertIP(h.IP), h.config().OdysseygoAPIPort)
 		proxy, err = net.Dial("tcp", odysseyGoEndpoint)
 		if err != nil {
 			return nil, fmt.Errorf("unable to port forward E2E to %s", odysseyGoEndpoint)
 		}
 	} else {
-		proxy, err = h.connection.DialTCP("tcp", nil, odysseyGoAddr)
+		proxy, err = h.connection.DialTCP(odysseyGoAddr)
 		if err != nil {
 			return nil, fmt.Errorf("unable to port forward to %s via %s", h.connection.RemoteAddr(), "ssh")
 		}
@@ -385,7 +622,14 @@ func (h *Node) FileExists(path string) (bool, error) {
 		}
 	}
 
-	sftp, err := h.connection.NewSftp()
+	sftp, err := h.newSftp()
+	if errors.Is(err, ErrSFTPUnavailable) {
+		output, err := h.Commandf(nil, constants.SSHFileOpsTimeout, "if [ -e %s ]; then echo yes; fi", shellQuote(path))
+		if err != nil {
+			return false, fmt.Errorf("%w: %s", err, string(output))
+		}
+		return strings.TrimSpace(string(output)) == "yes", nil
+	}
 	if err != nil {
 		return false, err
 	}
@@ -409,12 +653,18 @@ func (h *Node) CreateTempFile() (string, error) {
 			return "", err
 		}
 	}
-	sftp, err := h.connection.NewSftp()
+	tmpFileName := filepath.Join("/tmp", utils.RandomString(10))
+	sftp, err := h.newSftp()
+	if errors.Is(err, ErrSFTPUnavailable) {
+		if output, err := h.Commandf(nil, constants.SSHFileOpsTimeout, "touch %s", tmpFileName); err != nil {
+			return "", fmt.Errorf("%w: %s", err, string(output))
+		}
+		return tmpFileName, nil
+	}
 	if err != nil {
 		return "", err
 	}
 	defer sftp.Close()
-	tmpFileName := filepath.Join("/tmp", utils.RandomString(10))
 	_, err = sftp.Create(tmpFileName)
 	if err != nil {
 		return "", err
@@ -429,12 +679,18 @@ func (h *Node) CreateTempDir() (string, error) {
 			return "", err
 		}
 	}
-	sftp, err := h.connection.NewSftp()
+	tmpDirName := filepath.Join("/tmp", utils.RandomString(10))
+	sftp, err := h.newSftp()
+	if errors.Is(err, ErrSFTPUnavailable) {
+		if output, err := h.Commandf(nil, constants.SSHFileOpsTimeout, "mkdir %s", tmpDirName); err != nil {
+			return "", fmt.Errorf("%w: %s", err, string(output))
+		}
+		return tmpDirName, nil
+	}
 	if err != nil {
 		return "", err
 	}
 	defer sftp.Close()
-	tmpDirName := filepath.Join("/tmp", utils.RandomString(10))
 	err = sftp.Mkdir(tmpDirName)
 	if err != nil {
 		return "", err
@@ -449,18 +705,23 @@ func (h *Node) Remove(path string, recursive bool) error {
 			return err
 		}
 	}
-	sftp, err := h.connection.NewSftp()
-	if err != nil {
-		return err
-	}
-	defer sftp.Close()
 	if recursive {
 		// return sftp.RemoveAll(path) is very slow
 		_, err := h.Commandf(nil, constants.SSHLongRunningScriptTimeout, "rm -rf %s", path)
 		return err
-	} else {
-		return sftp.Remove(path)
 	}
+	sftp, err := h.newSftp()
+	if errors.Is(err, ErrSFTPUnavailable) {
+		if output, err := h.Commandf(nil, constants.SSHFileOpsTimeout, "rm %s", shellQuote(path)); err != nil {
+			return fmt.Errorf("%w: %s", err, string(output))
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer sftp.Close()
+	return sftp.Remove(path)
 }
 
 // WaitForSSHShell waits for the SSH shell to be available on the node within the specified timeout.
@@ -503,29 +764,13 @@ func (h *Node) StreamSSHCommand(env []string, timeout time.Duration, command str
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	session, err := h.connection.NewSession()
-	if err != nil {
-		return err
-	}
-	defer session.Close()
-
-	stdout, err := session.StdoutPipe()
-	if err != nil {
-		return err
-	}
-	stderr, err := session.StderrPipe()
-	if err != nil {
-		return err
-	}
 	for _, item := range env {
-		envPair := strings.SplitN(item, "=", 2)
-		if len(envPair) != 2 {
+		if envPair := strings.SplitN(item, "=", 2); len(envPair) != 2 {
 			return fmt.Errorf("invalid env variable %s", item)
 		}
-		if err := session.Setenv(envPair[0], envPair[1]); err != nil {
-			return err
-		}
 	}
+	stdout, stdoutWriter := io.Pipe()
+	stderr, stderrWriter := io.Pipe()
 	// Use a WaitGroup to synchronize goroutines
 	var wg sync.WaitGroup
 	wg.Add(2)
@@ -535,6 +780,8 @@ func (h *Node) StreamSSHCommand(env []string, timeout time.Duration, command str
 		if err := consumeOutput(ctx, stdout); err != nil {
 			fmt.Printf("Error reading stdout: %v\n", err)
 		}
+		// do not block the command once ctx is done
+		_, _ = io.Copy(io.Discard, stdout)
 	}()
 
 	go func() {
@@ -542,12 +789,16 @@ func (h *Node) StreamSSHCommand(env []string, timeout time.Duration, command str
 		if err := consumeOutput(ctx, stderr); err != nil {
 			fmt.Printf("Error reading stderr: %v\n", err)
 		}
+		_, _ = io.Copy(io.Discard, stderr)
 	}()
 
-	if err := session.Run(command); err != nil {
+	err := h.connection.Run(ctx, env, command, nil, stdoutWriter, stderrWriter)
+	_ = stdoutWriter.Close()
+	_ = stderrWriter.Close()
+	wg.Wait()
+	if err != nil {
 		return fmt.Errorf("failed to run command %s: %w", command, err)
 	}
-	wg.Wait()
 	return nil
 }
 
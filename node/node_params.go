@@ -0,0 +1,119 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/releases"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/vm"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"golang.org/x/exp/slices"
+	"golang.org/x/mod/semver"
+)
+
+var (
+	ErrInvalidNodeCount         = errors.New("node count cannot be negative")
+	ErrNoRoles                  = errors.New("at least one role is required")
+	ErrUnsupportedRole          = errors.New("unsupported role")
+	ErrInvalidRoles             = errors.New("invalid combination of roles")
+	ErrNetworkRequired          = errors.New("network is required by validator and api nodes")
+	ErrOdysseyGoVersionRequired = errors.New("odysseygo version is required by validator and api nodes")
+	ErrInvalidVersion           = errors.New("invalid version")
+	ErrInvalidSubnetID          = errors.New("invalid subnet ID")
+)
+
+// Validate checks nodeParams before any node is provisioned with it: the combination of roles,
+// the fields required by each role, the versions and the labels. All the problems are returned
+// at once, each of them matching its error with errors.Is
+//
+// Count and SSHPrivateKeyPath were used to create cloud instances, which has been removed from
+// this SDK, so only Count is checked not to be negative
+func (p *NodeParams) Validate() error {
+	errs := []error{}
+	if p.Count < 0 {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrInvalidNodeCount, p.Count))
+	}
+	errs = append(errs, p.validateRoles()...)
+	if slices.Contains(p.Roles, Validator) || slices.Contains(p.Roles, API) {
+		errs = append(errs, p.validateOdysseyGo()...)
+	}
+	if slices.Contains(p.Roles, Relayer) {
+		errs = append(errs, p.Relayer.Validate())
+	}
+	if slices.Contains(p.Roles, RPCGateway) {
+		errs = append(errs, p.RPCGateway.Validate())
+	}
+	for _, role := range p.Roles {
+		if _, err := p.ResourceLabels(role); err != nil {
+			errs = append(errs, err)
+			// the custom labels are the same for all the roles
+			break
+		}
+	}
+	if p.Hardening != nil {
+		_, err := p.Hardening.sysctls()
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (p *NodeParams) validateRoles() []error {
+	if len(p.Roles) == 0 {
+		return []error{ErrNoRoles}
+	}
+	errs := []error{}
+	for _, role := range p.Roles {
+		if role < Validator || role > RPCGateway {
+			errs = append(errs, fmt.Errorf("%w %d", ErrUnsupportedRole, role))
+		}
+	}
+	if err := CheckRoles(p.Roles); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidRoles, err))
+	}
+	errs = append(errs, CheckRuntimeMode(p.RuntimeMode, p.Roles))
+	return errs
+}
+
+// validateOdysseyGo checks the fields used to install odysseygo on validator and api nodes
+func (p *NodeParams) validateOdysseyGo() []error {
+	errs := []error{}
+	if p.Network.Kind == odyssey.Undefined {
+		errs = append(errs, ErrNetworkRequired)
+	}
+	for _, subnetID := range p.SubnetIDs {
+		if _, err := ids.FromString(subnetID); err != nil {
+			errs = append(errs, fmt.Errorf("%w %q: %w", ErrInvalidSubnetID, subnetID, err))
+		}
+	}
+	if _, err := p.Preset.Config(); err != nil {
+		errs = append(errs, err)
+	}
+	odysseyGoVersionValid := false
+	switch {
+	case p.OdysseyGoVersion == "":
+		errs = append(errs, ErrOdysseyGoVersionRequired)
+	case p.OdysseyGoVersion == releases.Latest:
+	case !semver.IsValid(p.OdysseyGoVersion):
+		errs = append(errs, fmt.Errorf("%w: odysseygo version %q is not a semantic version, e.g. v1.10.13", ErrInvalidVersion, p.OdysseyGoVersion))
+	default:
+		odysseyGoVersionValid = true
+	}
+	if p.SubnetEVMVersion == "" {
+		return errs
+	}
+	if !semver.IsValid(p.SubnetEVMVersion) {
+		return append(errs, fmt.Errorf("%w: subnet-evm version %q is not a semantic version, e.g. v0.5.6", ErrInvalidVersion, p.SubnetEVMVersion))
+	}
+	if odysseyGoVersionValid {
+		// releases missing from the compatibility table are only warned about when provisioning
+		err := vm.CheckSubnetEVMCompatibility(p.OdysseyGoVersion, p.SubnetEVMVersion)
+		if !errors.Is(err, vm.ErrUnknownOdysseyGoVersion) && !errors.Is(err, vm.ErrUnknownSubnetEVMVersion) {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/releases"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeParams_Validate(t *testing.T) {
+	validAPI := func() NodeParams {
+		return NodeParams{
+			Roles:            []SupportedRole{API},
+			Network:          odyssey.TestnetNetwork(),
+			OdysseyGoVersion: "v1.10.13",
+		}
+	}
+	tests := []struct {
+		name         string
+		params       func(*NodeParams)
+		expectedErrs []error
+	}{
+		{name: "valid", params: func(*NodeParams) {}},
+		{name: "latest version", params: func(p *NodeParams) { p.OdysseyGoVersion = releases.Latest }},
+		{name: "monitor needs no network", params: func(p *NodeParams) { *p = NodeParams{Roles: []SupportedRole{Monitor}} }},
+		{name: "negative count", params: func(p *NodeParams) { p.Count = -1 }, expectedErrs: []error{ErrInvalidNodeCount}},
+		{name: "no roles", params: func(p *NodeParams) { p.Roles = nil }, expectedErrs: []error{ErrNoRoles}},
+		{name: "unsupported role", params: func(p *NodeParams) { p.Roles = []SupportedRole{SupportedRole(999)} }, expectedErrs: []error{ErrUnsupportedRole}},
+		{name: "invalid roles", params: func(p *NodeParams) { p.Roles = []SupportedRole{Monitor, Loadtest} }, expectedErrs: []error{ErrInvalidRoles}},
+		{
+			name: "systemd relayer",
+			params: func(p *NodeParams) {
+				p.Roles, p.RuntimeMode, p.Relayer = []SupportedRole{Relayer}, SystemdRuntime, &RelayerParams{ConfigFile: "relayer.json"}
+			},
+			expectedErrs: []error{ErrUnsupportedRuntimeMode},
+		},
+		{name: "relayer params", params: func(p *NodeParams) { p.Roles = append(p.Roles, Relayer) }, expectedErrs: []error{ErrRelayerParamsRequired}},
+		{name: "rpc gateway params", params: func(p *NodeParams) { p.Roles = append(p.Roles, RPCGateway) }, expectedErrs: []error{ErrRPCGatewayParamsRequired}},
+		{
+			name: "everything missing for odysseygo",
+			params: func(p *NodeParams) {
+				p.Network, p.OdysseyGoVersion, p.SubnetIDs = odyssey.UndefinedNetwork, "", []string{"not-a-subnet"}
+			},
+			expectedErrs: []error{ErrNetworkRequired, ErrOdysseyGoVersionRequired, ErrInvalidSubnetID},
+		},
+		{
+			name:         "invalid versions",
+			params:       func(p *NodeParams) { p.OdysseyGoVersion, p.SubnetEVMVersion = "1.10", "latest" },
+			expectedErrs: []error{ErrInvalidVersion},
+		},
+		{
+			name:         "incompatible versions",
+			params:       func(p *NodeParams) { p.OdysseyGoVersion, p.SubnetEVMVersion = "v1.10.9", "v0.5.0" },
+			expectedErrs: []error{vm.ErrIncompatibleVMVersion},
+		},
+		{name: "unknown versions are accepted", params: func(p *NodeParams) { p.OdysseyGoVersion, p.SubnetEVMVersion = "v1.99.0", "v0.5.6" }},
+		{name: "invalid label", params: func(p *NodeParams) { p.ClusterName = "Prod Cluster" }, expectedErrs: []error{ErrInvalidLabel}},
+		{name: "archive preset", params: func(p *NodeParams) { p.Preset = ArchiveRPCPreset }},
+		{name: "unsupported preset", params: func(p *NodeParams) { p.Preset = "archive" }, expectedErrs: []error{ErrUnsupportedPreset}},
+		{
+			name:         "invalid sysctl",
+			params:       func(p *NodeParams) { p.Hardening = &HardeningParams{Sysctls: map[string]string{"bad key": "1"}} },
+			expectedErrs: []error{ErrInvalidSysctl},
+		},
+		{
+			name: "all errors reported",
+			params: func(p *NodeParams) {
+				p.Count, p.Roles, p.OdysseyGoVersion, p.Owner = -2, []SupportedRole{Validator, RPCGateway}, "", "Bad Owner"
+			},
+			expectedErrs: []error{ErrInvalidNodeCount, ErrInvalidRoles, ErrOdysseyGoVersionRequired, ErrRPCGatewayParamsRequired, ErrInvalidLabel},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := validAPI()
+			tt.params(&params)
+			err := params.Validate()
+			if len(tt.expectedErrs) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			for _, expectedErr := range tt.expectedErrs {
+				assert.ErrorIs(t, err, expectedErr)
+			}
+		})
+	}
+}
+
+func TestProvisionNodes_InvalidParams(t *testing.T) {
+	nodes := []*Node{{NodeID: "node-1"}, {NodeID: "node-2"}}
+	results := ProvisionNodes(context.Background(), nodes, &NodeParams{}, 0)
+	require.Len(t, results.Failed(), 2)
+	for _, result := range results.Failed() {
+		assert.ErrorIs(t, result.Err, ErrNoRoles)
+	}
+}
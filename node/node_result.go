@@ -4,7 +4,11 @@ package node
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
 )
 
 // NodeResult is a struct that holds the result of a async command executed on a host
@@ -175,3 +179,68 @@ func (nr *NodeResults) Error() error {
 		return nil
 	}
 }
+
+// Get returns the result of nodeID, if any
+func (nr *NodeResults) Get(nodeID string) (NodeResult, bool) {
+	nr.Lock.Lock()
+	defer nr.Lock.Unlock()
+	for _, result := range nr.Results {
+		if result.NodeID == nodeID {
+			return result, true
+		}
+	}
+	return NodeResult{}, false
+}
+
+// Failed returns the results with an error, sorted by node ID
+func (nr *NodeResults) Failed() []NodeResult {
+	return nr.filter(func(result NodeResult) bool { return result.Err != nil })
+}
+
+// Succeeded returns the results without error, sorted by node ID
+func (nr *NodeResults) Succeeded() []NodeResult {
+	return nr.filter(func(result NodeResult) bool { return result.Err == nil })
+}
+
+func (nr *NodeResults) filter(keep func(NodeResult) bool) []NodeResult {
+	nr.Lock.Lock()
+	defer nr.Lock.Unlock()
+	filtered := []NodeResult{}
+	for _, result := range nr.Results {
+		if keep(result) {
+			filtered = append(filtered, result)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].NodeID < filtered[j].NodeID
+	})
+	return filtered
+}
+
+// Summary returns a one line description of the results, e.g.
+// "2/3 nodes succeeded; failed: node-2: node down"
+func (nr *NodeResults) Summary() string {
+	failed := nr.Failed()
+	summary := fmt.Sprintf("%d/%d nodes succeeded", nr.Len()-len(failed), nr.Len())
+	if len(failed) == 0 {
+		return summary
+	}
+	failures := make([]string, 0, len(failed))
+	for _, result := range failed {
+		failures = append(failures, fmt.Sprintf("%s: %s", result.NodeID, result.Err))
+	}
+	return summary + "; failed: " + strings.Join(failures, "; ")
+}
+
+// RunOnNodes calls fn on each node, with at most limit calls running at once, or all of them
+// if limit is not positive, and collects the value and error returned for each node
+func RunOnNodes(nodes []*Node, limit int, fn func(*Node) (interface{}, error)) *NodeResults {
+	results := &NodeResults{}
+	// errors are reported per node in results
+	_ = utils.ForEachConcurrent(nodes, limit, func(node *Node) error {
+		value, err := fn(node)
+		results.AddResult(node.NodeID, value, err)
+		return err
+	})
+	return results
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunOnNodes(t *testing.T) {
+	errDown := errors.New("node down")
+	nodes := []*Node{{NodeID: "node-1"}, {NodeID: "node-2"}, {NodeID: "node-3"}}
+
+	results := RunOnNodes(nodes, 2, func(node *Node) (interface{}, error) {
+		if node.NodeID == "node-2" {
+			return nil, errDown
+		}
+		return node.NodeID + "-done", nil
+	})
+
+	require.Len(t, results.GetResults(), 3)
+	assert.Equal(t, []string{"node-2"}, results.GetErrorHosts())
+	assert.ErrorIs(t, results.GetErrorHostMap()["node-2"], errDown)
+	values := results.GetResultMap()
+	assert.Equal(t, "node-1-done", values["node-1"])
+	assert.Equal(t, "node-3-done", values["node-3"])
+}
+
+func TestNodeResults_FailedSucceeded(t *testing.T) {
+	errDown := errors.New("node down")
+	results := &NodeResults{}
+	results.AddResult("node-3", nil, errDown)
+	results.AddResult("node-2", "ok", nil)
+	results.AddResult("node-1", nil, errors.New("timeout"))
+
+	failed := results.Failed()
+	require.Len(t, failed, 2)
+	assert.Equal(t, "node-1", failed[0].NodeID)
+	assert.Equal(t, "node-3", failed[1].NodeID)
+	assert.Equal(t, []NodeResult{{NodeID: "node-2", Value: "ok"}}, results.Succeeded())
+	assert.Equal(t, "1/3 nodes succeeded; failed: node-1: timeout; node-3: node down", results.Summary())
+
+	result, ok := results.Get("node-3")
+	require.True(t, ok)
+	assert.ErrorIs(t, result.Err, errDown)
+	_, ok = results.Get("node-4")
+	assert.False(t, ok)
+
+	assert.Equal(t, "0/0 nodes succeeded", (&NodeResults{}).Summary())
+	assert.Empty(t, (&NodeResults{}).Failed())
+}
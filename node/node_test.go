@@ -351,6 +351,69 @@ func TestNode_UploadBytes(t *testing.T) {
 	}
 }
 
+func TestNode_UploadIfChanged(t *testing.T) {
+	node := Node{
+		IP: "192.168.1.1",
+		SSHConfig: SSHConfig{
+			User:           "ubuntu",
+			PrivateKeyPath: "/path/to/key",
+		},
+	}
+
+	t.Run("Missing local file", func(t *testing.T) {
+		uploaded, err := node.UploadIfChanged("/nonexistent/local/file", "/remote/file", time.Second, true)
+		assert.Error(t, err)
+		assert.False(t, uploaded)
+	})
+
+	t.Run("Not connected - should connect first", func(t *testing.T) {
+		localFile, err := os.CreateTemp(t.TempDir(), "upload-*.txt")
+		assert.NoError(t, err)
+		assert.NoError(t, localFile.Close())
+		uploaded, err := node.UploadIfChanged(localFile.Name(), "/remote/file", time.Second, true)
+		assert.Error(t, err) // Will fail due to missing key file
+		assert.False(t, uploaded)
+	})
+}
+
+func TestParseSHA256SumOutput(t *testing.T) {
+	const checksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	tests := []struct {
+		name        string
+		output      string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "Valid output",
+			output:   checksum + "  /home/ubuntu/.odyssey-cli/services/docker-compose.yml\n",
+			expected: checksum,
+		},
+		{
+			name:        "Empty output",
+			output:      "",
+			expectError: true,
+		},
+		{
+			name:        "Unexpected output",
+			output:      "sha256sum: /remote/file: No such file or directory",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseSHA256SumOutput([]byte(tt.output))
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
 func TestNode_Download(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,107 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package nodemock provides mocks of the interfaces of the node package, to unit test code
+// driving nodes without hosts
+package nodemock
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/mock"
+)
+
+// ErrSFTPNotMocked is returned by the NewSftp of NewSSHClient, making the node transfer
+// files through Run
+var ErrSFTPNotMocked = errors.New("sftp is not mocked")
+
+// SSHClient is a testify mock of node.SSHClient. Set it on a node with node.SetSSHClient
+type SSHClient struct {
+	mock.Mock
+}
+
+// NewSSHClient returns an SSHClient whose NewSftp fails, so that the node falls back to
+// running shell commands for its file operations, and whose Close succeeds. The other
+// calls must be set up with On or OnScript
+func NewSSHClient() *SSHClient {
+	m := &SSHClient{}
+	m.On("NewSftp").Return(nil, ErrSFTPNotMocked).Maybe()
+	m.On("Close").Return(nil).Maybe()
+	return m
+}
+
+// OnScript sets up a Run of script, a string or an argument matcher such as
+// mock.MatchedBy, writing stdout to the command output and returning err
+func (m *SSHClient) OnScript(script interface{}, stdout string, err error) *mock.Call {
+	return m.On("Run", mock.Anything, mock.Anything, script, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			if w, ok := args.Get(4).(io.Writer); ok && w != nil {
+				_, _ = io.WriteString(w, stdout)
+			}
+		}).
+		Return(err)
+}
+
+func (m *SSHClient) Run(ctx context.Context, env []string, script string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	args := m.Called(ctx, env, script, readerArg(stdin), writerArg(stdout), writerArg(stderr))
+	return args.Error(0)
+}
+
+// readerArg and writerArg hide the state of the streams from testify, which prints the
+// arguments of the calls while other goroutines may be using them
+func readerArg(r io.Reader) interface{} {
+	if r == nil {
+		return nil
+	}
+	return reader{r}
+}
+
+func writerArg(w io.Writer) interface{} {
+	if w == nil {
+		return nil
+	}
+	return writer{w}
+}
+
+type reader struct {
+	io.Reader
+}
+
+func (reader) String() string {
+	return "io.Reader"
+}
+
+type writer struct {
+	io.Writer
+}
+
+func (writer) String() string {
+	return "io.Writer"
+}
+
+func (m *SSHClient) NewSftp() (*sftp.Client, error) {
+	args := m.Called()
+	client, _ := args.Get(0).(*sftp.Client)
+	return client, args.Error(1)
+}
+
+func (m *SSHClient) DialTCP(addr *net.TCPAddr) (net.Conn, error) {
+	args := m.Called(addr)
+	conn, _ := args.Get(0).(net.Conn)
+	return conn, args.Error(1)
+}
+
+func (m *SSHClient) RemoteAddr() net.Addr {
+	args := m.Called()
+	addr, _ := args.Get(0).(net.Addr)
+	return addr
+}
+
+func (m *SSHClient) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
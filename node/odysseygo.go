@@ -85,7 +85,7 @@ func (h *Node) GetOdysseyGoNetworkName() (string, error) {
 
 func (h *Node) GetOdysseyGoConfigData() (map[string]interface{}, error) {
 	// get remote node.json file
-	nodeJSON, err := h.ReadFileBytes(remoteconfig.GetRemoteOdysseyNodeConfig(), constants.SSHFileOpsTimeout)
+	nodeJSON, err := h.ReadFileBytes(remoteconfig.GetRemoteOdysseyNodeConfig(h.config()), constants.SSHFileOpsTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -102,7 +102,7 @@ func (h *Node) WaitForOdysseyGoHealth(timeout time.Duration) error {
 		return fmt.Errorf("node IP is empty")
 	}
 	start := time.Now()
-	if err := h.WaitForPort(constants.OdysseygoAPIPort, timeout); err != nil {
+	if err := h.WaitForPort(h.config().OdysseygoAPIPort, timeout); err != nil {
 		return err
 	}
 
@@ -0,0 +1,375 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/key"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/validator"
+	"github.com/DioneProtocol/odysseygo/api/info"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/signer"
+	"golang.org/x/mod/semver"
+)
+
+var (
+	ErrInvalidOnboardingPackage  = errors.New("invalid onboarding package")
+	ErrUnsignedOnboardingPackage = errors.New("onboarding package is not signed")
+	ErrOdysseyGoTooOld           = errors.New("odysseygo version is older than the version required by the subnet")
+	ErrInvalidOnboardingInfo     = errors.New("invalid onboarding info")
+)
+
+// onboardingNodeConfigKeys are the odysseygo flags the node config of an onboarding package
+// can set: the consensus and gossip parameters a subnet may require. The other flags belong to
+// the operator of the node
+var onboardingNodeConfigKeys = []string{
+	"snow-sample-size",
+	"snow-quorum-size",
+	"snow-virtuous-commit-threshold",
+	"snow-rogue-commit-threshold",
+	"snow-concurrent-repolls",
+	"snow-optimal-processing",
+	"snow-max-processing",
+	"snow-max-time-processing",
+	"consensus-accepted-frontier-gossip-frequency",
+	"consensus-app-concurrency",
+	"consensus-accepted-frontier-gossip-validator-size",
+	"consensus-accepted-frontier-gossip-non-validator-size",
+	"consensus-accepted-frontier-gossip-peer-size",
+	"consensus-on-accept-gossip-validator-size",
+	"consensus-on-accept-gossip-non-validator-size",
+	"consensus-on-accept-gossip-peer-size",
+	"consensus-app-gossip-validator-size",
+	"consensus-app-gossip-non-validator-size",
+	"consensus-app-gossip-peer-size",
+	"proposervm-use-current-height",
+}
+
+// OnboardingPackage holds what the operator of an existing node needs to validate a subnet. The
+// subnet owner creates it, e.g. with subnet.Subnet.OnboardingPackage, signs it with Sign and
+// hands the saved file to the operator, who runs Node.Onboard with it
+type OnboardingPackage struct {
+	// SubnetID is the subnet tracked by the node
+	SubnetID ids.ID `json:"subnetID"`
+
+	// OdysseyGoVersion is the oldest odysseygo release the subnet supports, e.g. v1.10.13.
+	// Any release is accepted if empty
+	OdysseyGoVersion string `json:"odysseyGoVersion,omitempty"`
+
+	// SubnetConfig is the content of the subnet config file of odysseygo, e.g.
+	// {"validatorOnly": true}. Not uploaded if empty
+	SubnetConfig json.RawMessage `json:"subnetConfig,omitempty"`
+
+	// NodeConfig holds the odysseygo flags set in the node config, e.g. the
+	// consensus parameters required by the subnet. Only the consensus and gossip
+	// parameters can be set, the other flags, such as network-id, are reserved to the
+	// operator
+	NodeConfig map[string]interface{} `json:"nodeConfig,omitempty"`
+
+	// Chains are the config files of the blockchains of the subnet, with their genesis
+	Chains []ChainConfig `json:"chains,omitempty"`
+
+	// Signer is the CB58 short ID of the key that signed the package
+	Signer string `json:"signer,omitempty"`
+
+	// Signature is the personal message signature of the package by Signer
+	Signature []byte `json:"signature,omitempty"`
+}
+
+// Validate checks that the package is complete and only sets the node flags it is allowed to
+func (p OnboardingPackage) Validate() error {
+	if p.SubnetID == ids.Empty {
+		return fmt.Errorf("%w: subnet ID is not provided", ErrInvalidOnboardingPackage)
+	}
+	if p.OdysseyGoVersion != "" && !semver.IsValid(p.OdysseyGoVersion) {
+		return fmt.Errorf("%w: odysseygo version %q is not a semantic version, e.g. v1.10.13", ErrInvalidOnboardingPackage, p.OdysseyGoVersion)
+	}
+	if len(p.SubnetConfig) > 0 && !json.Valid(p.SubnetConfig) {
+		return fmt.Errorf("%w: subnet config is not valid JSON", ErrInvalidOnboardingPackage)
+	}
+	flags := make([]string, 0, len(p.NodeConfig))
+	for flag := range p.NodeConfig {
+		flags = append(flags, flag)
+	}
+	sort.Strings(flags)
+	for _, flag := range flags {
+		if !utils.Belongs(onboardingNodeConfigKeys, flag) {
+			return fmt.Errorf("%w: node config flag %s is reserved to the node operator", ErrInvalidOnboardingPackage, flag)
+		}
+	}
+	for _, chainConfig := range p.Chains {
+		if err := chainConfig.Validate(); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidOnboardingPackage, err)
+		}
+	}
+	return nil
+}
+
+// signedBytes returns the message signed by Sign: the JSON of the package without its
+// signature
+func (p OnboardingPackage) signedBytes() ([]byte, error) {
+	p.Signature = nil
+	return json.Marshal(p)
+}
+
+// Sign sets sk as the signer of the package and signs it. The package must not be modified
+// afterwards
+func (p *OnboardingPackage) Sign(sk *key.SoftKey) error {
+	p.Signer = sk.Addresses()[0].String()
+	msg, err := p.signedBytes()
+	if err != nil {
+		return err
+	}
+	signature, err := sk.SignMessage(msg)
+	if err != nil {
+		return err
+	}
+	p.Signature = signature
+	return nil
+}
+
+// Verify checks that the package was signed by signer, the public key or any address of the
+// key of the subnet owner, obtained from the owner by other means than the package
+func (p OnboardingPackage) Verify(signer string) error {
+	if len(p.Signature) == 0 {
+		return ErrUnsignedOnboardingPackage
+	}
+	msg, err := p.signedBytes()
+	if err != nil {
+		return err
+	}
+	return key.VerifyMessage(signer, msg, p.Signature)
+}
+
+// Save writes the package to the JSON file path
+func (p OnboardingPackage) Save(path string) error {
+	return saveJSON(path, p)
+}
+
+// LoadOnboardingPackage reads the package saved in path by OnboardingPackage.Save
+func LoadOnboardingPackage(path string) (OnboardingPackage, error) {
+	p := OnboardingPackage{}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal(content, &p); err != nil {
+		return p, fmt.Errorf("%w %s: %w", ErrInvalidOnboardingPackage, path, err)
+	}
+	return p, nil
+}
+
+// OnboardingInfo is what the subnet owner needs from the operator to add their node as
+// subnet validator, returned by Node.Onboard
+type OnboardingInfo struct {
+	SubnetID ids.ID     `json:"subnetID"`
+	NodeID   ids.NodeID `json:"nodeID"`
+
+	// ProofOfPossession holds the BLS public key of the node and its proof of possession
+	ProofOfPossession *signer.ProofOfPossession `json:"proofOfPossession"`
+}
+
+// SubnetValidatorParams returns the params of the AddSubnetValidatorTx of the node, see
+// subnet.Subnet.AddValidator
+func (i OnboardingInfo) SubnetValidatorParams(duration time.Duration, weight uint64) validator.SubnetValidatorParams {
+	return validator.SubnetValidatorParams{
+		NodeID:   i.NodeID,
+		Duration: duration,
+		Weight:   weight,
+	}
+}
+
+// Save writes the info to the JSON file path, to be sent to the subnet owner
+func (i OnboardingInfo) Save(path string) error {
+	return saveJSON(path, i)
+}
+
+// LoadOnboardingInfo reads the info saved in path by OnboardingInfo.Save, checking its proof
+// of possession
+func LoadOnboardingInfo(path string) (OnboardingInfo, error) {
+	i := OnboardingInfo{}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return i, err
+	}
+	if err := json.Unmarshal(content, &i); err != nil {
+		return i, fmt.Errorf("%w %s: %w", ErrInvalidOnboardingInfo, path, err)
+	}
+	if i.NodeID == ids.EmptyNodeID {
+		return i, fmt.Errorf("%w %s: node ID is not provided", ErrInvalidOnboardingInfo, path)
+	}
+	if i.ProofOfPossession == nil {
+		return i, fmt.Errorf("%w %s: proof of possession is not provided", ErrInvalidOnboardingInfo, path)
+	}
+	if err := i.ProofOfPossession.Verify(); err != nil {
+		return i, fmt.Errorf("%w %s: %w", ErrInvalidOnboardingInfo, path, err)
+	}
+	return i, nil
+}
+
+// Onboard configures the node to validate the subnet of pkg, once pkg is verified to be signed
+// by the subnet owner signer, see OnboardingPackage.Verify. It checks the odysseygo version
+// of the node, makes it track the subnet, uploads the subnet, node and chain configs of pkg
+// and restarts odysseygo if needed to apply them. Returns the info to send to the subnet
+// owner so that they add the node as subnet validator
+func (h *Node) Onboard(pkg OnboardingPackage, signer string) (*OnboardingInfo, error) {
+	if !isOdysseyGoNode(*h) {
+		return nil, fmt.Errorf("%s is not a odysseygo node", h.NodeID)
+	}
+	if err := pkg.Verify(signer); err != nil {
+		return nil, err
+	}
+	if err := pkg.Validate(); err != nil {
+		return nil, err
+	}
+	if pkg.OdysseyGoVersion != "" {
+		version, err := getNodeVersion(h)
+		if err != nil {
+			return nil, err
+		}
+		if semver.Compare(version, pkg.OdysseyGoVersion) < 0 {
+			return nil, fmt.Errorf("%w: node %s runs %s, subnet %s requires %s", ErrOdysseyGoTooOld, h.NodeID, version, pkg.SubnetID, pkg.OdysseyGoVersion)
+		}
+	}
+	// odysseygo is restarted below, so the identity of the node is read first
+	onboardingInfo, err := getNodeIdentity(h)
+	if err != nil {
+		return nil, err
+	}
+	onboardingInfo.SubnetID = pkg.SubnetID
+	changed, err := h.uploadOnboardingConfigs(pkg)
+	if err != nil {
+		return nil, err
+	}
+	restarted, err := h.ConfigureSubnet(pkg.SubnetID, pkg.Chains)
+	if err != nil {
+		return nil, err
+	}
+	if changed && !restarted {
+		if err := h.RunSSHRestartOdysseygo(); err != nil {
+			return nil, err
+		}
+	}
+	return onboardingInfo, nil
+}
+
+// uploadOnboardingConfigs merges the node config of pkg into the remote odysseygo config and
+// uploads its subnet config, returning whether a file changed
+func (h *Node) uploadOnboardingConfigs(pkg OnboardingPackage) (bool, error) {
+	changed := false
+	if len(pkg.NodeConfig) > 0 {
+		nodeConfig, err := h.GetOdysseyGoConfigData()
+		if err != nil {
+			return false, err
+		}
+		maps.Copy(nodeConfig, pkg.NodeConfig)
+		nodeConfigBytes, err := json.MarshalIndent(nodeConfig, "", "\t")
+		if err != nil {
+			return false, err
+		}
+		if changed, err = h.UploadBytesIfChanged(nodeConfigBytes, remoteconfig.GetRemoteOdysseyNodeConfig(h.config()), constants.SSHFileOpsTimeout, true); err != nil {
+			return false, err
+		}
+	}
+	if len(pkg.SubnetConfig) > 0 {
+		subnetConfigFile := remoteconfig.GetRemoteOdysseySubnetConfig(h.config(), pkg.SubnetID.String())
+		if err := h.MkdirAll(filepath.Dir(subnetConfigFile), constants.SSHFileOpsTimeout); err != nil {
+			return false, err
+		}
+		uploaded, err := h.UploadBytesIfChanged(pkg.SubnetConfig, subnetConfigFile, constants.SSHFileOpsTimeout, true)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || uploaded
+	}
+	return changed, nil
+}
+
+// getNodeVersion returns the odysseygo release run by node, e.g. v1.10.13
+var getNodeVersion = func(node *Node) (string, error) {
+	reply := info.GetNodeVersionReply{}
+	if err := postInfo(node, "info.getNodeVersion", &reply); err != nil {
+		return "", err
+	}
+	return parseNodeVersion(reply.Version)
+}
+
+// parseNodeVersion returns the semantic version of the odysseygo version string, e.g.
+// v1.10.13 for odysseygo/1.10.13
+func parseNodeVersion(nodeVersion string) (string, error) {
+	_, version, _ := strings.Cut(nodeVersion, "/")
+	version = "v" + strings.TrimPrefix(version, "v")
+	if !semver.IsValid(version) {
+		return "", fmt.Errorf("%w: unable to parse odysseygo version %q", ErrInvalidVersion, nodeVersion)
+	}
+	return version, nil
+}
+
+// getNodeIdentity returns the node ID and BLS proof of possession of node
+var getNodeIdentity = func(node *Node) (*OnboardingInfo, error) {
+	reply := info.GetNodeIDReply{}
+	if err := postInfo(node, "info.getNodeID", &reply); err != nil {
+		return nil, err
+	}
+	if reply.NodePOP == nil {
+		return nil, fmt.Errorf("node %s has no BLS key", reply.NodeID)
+	}
+	return &OnboardingInfo{NodeID: reply.NodeID, ProofOfPossession: reply.NodePOP}, nil
+}
+
+// postInfo calls method of the info API of node, without params, decoding its result in reply
+func postInfo(node *Node, method string, reply interface{}) error {
+	request, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := node.Post("", string(request))
+	if err != nil {
+		return err
+	}
+	response := struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+	if err := json.Unmarshal(resp, &response); err != nil {
+		return err
+	}
+	if response.Error != nil {
+		return fmt.Errorf("%s failed on node %s: %s", method, node.NodeID, response.Error.Message)
+	}
+	if len(response.Result) == 0 {
+		return fmt.Errorf("unable to parse %s reply of node %s", method, node.NodeID)
+	}
+	return json.Unmarshal(response.Result, reply)
+}
+
+// saveJSON writes v as indented JSON to path, creating its dir
+func saveJSON(path string, v interface{}) error {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), constants.DefaultPerms755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, constants.WriteReadReadPerms)
+}
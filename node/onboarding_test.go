@@ -0,0 +1,157 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/key"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/bls"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOnboardingPackage() OnboardingPackage {
+	return OnboardingPackage{
+		SubnetID:         ids.ID{1},
+		OdysseyGoVersion: "v1.10.13",
+		SubnetConfig:     json.RawMessage(`{"validatorOnly":true}`),
+		NodeConfig:       map[string]interface{}{"snow-sample-size": float64(20)},
+		Chains:           []ChainConfig{{BlockchainID: ids.ID{2}, Genesis: []byte(`{"config":{}}`)}},
+	}
+}
+
+func TestOnboardingPackage_SignVerify(t *testing.T) {
+	owner, err := key.NewSoft()
+	require.NoError(t, err)
+	other, err := key.NewSoft()
+	require.NoError(t, err)
+
+	pkg := testOnboardingPackage()
+	require.ErrorIs(t, pkg.Verify(owner.Addresses()[0].String()), ErrUnsignedOnboardingPackage)
+
+	require.NoError(t, pkg.Sign(owner))
+	assert.Equal(t, owner.Addresses()[0].String(), pkg.Signer)
+	require.NoError(t, pkg.Verify(owner.Addresses()[0].String()))
+	require.NoError(t, pkg.Verify(owner.D()))
+	require.ErrorIs(t, pkg.Verify(other.Addresses()[0].String()), key.ErrInvalidSignature)
+
+	// the package survives a round trip through its file
+	path := filepath.Join(t.TempDir(), "onboarding", "mysubnet.json")
+	require.NoError(t, pkg.Save(path))
+	loaded, err := LoadOnboardingPackage(path)
+	require.NoError(t, err)
+	require.NoError(t, loaded.Verify(owner.Addresses()[0].String()))
+	assert.JSONEq(t, string(pkg.SubnetConfig), string(loaded.SubnetConfig))
+	loaded.SubnetConfig = pkg.SubnetConfig
+	assert.Equal(t, pkg, loaded)
+
+	// any change breaks the signature
+	loaded.NodeConfig["snow-sample-size"] = float64(1)
+	require.ErrorIs(t, loaded.Verify(owner.Addresses()[0].String()), key.ErrInvalidSignature)
+}
+
+func TestOnboardingPackage_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*OnboardingPackage)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*OnboardingPackage) {}},
+		{name: "any version", modify: func(p *OnboardingPackage) { p.OdysseyGoVersion = "" }},
+		{name: "empty subnet ID", modify: func(p *OnboardingPackage) { p.SubnetID = ids.Empty }, wantErr: "subnet ID is not provided"},
+		{name: "invalid version", modify: func(p *OnboardingPackage) { p.OdysseyGoVersion = "1.10.13" }, wantErr: "is not a semantic version"},
+		{name: "invalid subnet config", modify: func(p *OnboardingPackage) { p.SubnetConfig = json.RawMessage(`{`) }, wantErr: "subnet config is not valid JSON"},
+		{name: "reserved flag", modify: func(p *OnboardingPackage) { p.NodeConfig["network-id"] = "mainnet" }, wantErr: "network-id is reserved"},
+		{name: "tracked subnets", modify: func(p *OnboardingPackage) { p.NodeConfig[trackSubnetsKey] = "" }, wantErr: "track-subnets is reserved"},
+		{name: "public IP", modify: func(p *OnboardingPackage) { p.NodeConfig["public-ip"] = "1.2.3.4" }, wantErr: "public-ip is reserved"},
+		{name: "unlisted flag", modify: func(p *OnboardingPackage) { p.NodeConfig["http-host"] = "0.0.0.0" }, wantErr: "http-host is reserved"},
+		{name: "gossip parameter", modify: func(p *OnboardingPackage) { p.NodeConfig["consensus-app-gossip-validator-size"] = float64(10) }},
+		{name: "invalid chain", modify: func(p *OnboardingPackage) { p.Chains[0].BlockchainID = ids.Empty }, wantErr: ErrEmptyBlockchainID.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := testOnboardingPackage()
+			tt.modify(&pkg)
+			err := pkg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidOnboardingPackage)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNode_Onboard_Checks(t *testing.T) {
+	owner, err := key.NewSoft()
+	require.NoError(t, err)
+	ownerAddr := owner.Addresses()[0].String()
+	originalVersion := getNodeVersion
+	getNodeVersion = func(*Node) (string, error) { return "v1.10.9", nil }
+	t.Cleanup(func() { getNodeVersion = originalVersion })
+	node := &Node{NodeID: "node-1", Roles: []SupportedRole{Validator}}
+
+	pkg := testOnboardingPackage()
+	_, err = node.Onboard(pkg, ownerAddr)
+	require.ErrorIs(t, err, ErrUnsignedOnboardingPackage)
+
+	require.NoError(t, pkg.Sign(owner))
+	_, err = (&Node{NodeID: "monitoring-1", Roles: []SupportedRole{Monitor}}).Onboard(pkg, ownerAddr)
+	require.ErrorContains(t, err, "is not a odysseygo node")
+
+	other, err := key.NewSoft()
+	require.NoError(t, err)
+	_, err = node.Onboard(pkg, other.Addresses()[0].String())
+	require.ErrorIs(t, err, key.ErrInvalidSignature)
+
+	_, err = node.Onboard(pkg, ownerAddr)
+	require.ErrorIs(t, err, ErrOdysseyGoTooOld)
+	require.ErrorContains(t, err, "node node-1 runs v1.10.9")
+
+	getNodeVersion = func(*Node) (string, error) { return "", errors.New("connection refused") }
+	_, err = node.Onboard(pkg, ownerAddr)
+	require.ErrorContains(t, err, "connection refused")
+}
+
+func TestParseNodeVersion(t *testing.T) {
+	version, err := parseNodeVersion("odysseygo/1.10.13")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.10.13", version)
+
+	_, err = parseNodeVersion("odysseygo")
+	require.ErrorIs(t, err, ErrInvalidVersion)
+}
+
+func TestOnboardingInfo_SaveLoad(t *testing.T) {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	info := OnboardingInfo{
+		SubnetID:          ids.ID{1},
+		NodeID:            ids.NodeID{2},
+		ProofOfPossession: signer.NewProofOfPossession(sk),
+	}
+	path := filepath.Join(t.TempDir(), "node-info.json")
+	require.NoError(t, info.Save(path))
+	loaded, err := LoadOnboardingInfo(path)
+	require.NoError(t, err)
+	assert.Equal(t, info.NodeID, loaded.NodeID)
+	assert.Equal(t, info.ProofOfPossession.PublicKey, loaded.ProofOfPossession.PublicKey)
+	assert.Equal(t, ids.NodeID{2}, loaded.SubnetValidatorParams(time.Hour, 20).NodeID)
+
+	// a proof of possession of another key is rejected
+	otherSk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	info.ProofOfPossession.ProofOfPossession = signer.NewProofOfPossession(otherSk).ProofOfPossession
+	require.NoError(t, info.Save(path))
+	_, err = LoadOnboardingInfo(path)
+	require.ErrorIs(t, err, ErrInvalidOnboardingInfo)
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	ErrSSHKeyPermissions = errors.New("SSH private key is accessible by other users")
+	ErrInvalidSSHKey     = errors.New("invalid SSH private key")
+)
+
+// Preflight checks the environment before any node is provisioned, reporting all the
+// problems at once, each of them matching its error with errors.Is: the params, see
+// NodeParams.Validate, and the local SSH private key, which must exist, be a valid key and
+// only be accessible by its owner as required by ssh.
+//
+// Cloud resources, such as quotas, images, instance types and key pairs, are out of scope, as
+// the SDK does not create cloud instances: the tools creating them, e.g. Terraform, check them
+func Preflight(ctx context.Context, params *NodeParams) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	errs := []error{params.Validate()}
+	if params.SSHPrivateKeyPath != "" {
+		errs = append(errs, checkSSHPrivateKey(params.SSHPrivateKeyPath))
+	}
+	return errors.Join(errs...)
+}
+
+// checkSSHPrivateKey checks that the private key at path is a key only accessible by its
+// owner. Encrypted keys are valid, their passphrase being asked for when connecting
+func checkSSHPrivateKey(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("SSH private key: %w", err)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		return fmt.Errorf("%w: %s has permissions %s, run chmod 600 %s", ErrSSHKeyPermissions, path, info.Mode().Perm(), path)
+	}
+	key, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("SSH private key: %w", err)
+	}
+	var passphraseMissing *ssh.PassphraseMissingError
+	if _, err := ssh.ParseRawPrivateKey(key); err != nil && !errors.As(err, &passphraseMissing) {
+		return fmt.Errorf("%w %s: %w", ErrInvalidSSHKey, path, err)
+	}
+	return nil
+}
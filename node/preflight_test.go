@@ -0,0 +1,46 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflight(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "id_ed25519")
+	_, err := GenerateSSHKeyPair(keyPath)
+	require.NoError(t, err)
+	params := &NodeParams{
+		Roles:             []SupportedRole{API},
+		Network:           odyssey.TestnetNetwork(),
+		OdysseyGoVersion:  "v1.10.13",
+		SSHPrivateKeyPath: keyPath,
+	}
+	require.NoError(t, Preflight(context.Background(), params))
+
+	// all the problems are reported at once
+	require.NoError(t, os.Chmod(keyPath, 0o644))
+	params.OdysseyGoVersion = ""
+	err = Preflight(context.Background(), params)
+	require.ErrorIs(t, err, ErrSSHKeyPermissions)
+	require.ErrorIs(t, err, ErrOdysseyGoVersionRequired)
+
+	require.NoError(t, os.Chmod(keyPath, 0o600))
+	require.NoError(t, os.WriteFile(keyPath, []byte("not a key"), 0o600))
+	params.OdysseyGoVersion = "v1.10.13"
+	require.ErrorIs(t, Preflight(context.Background(), params), ErrInvalidSSHKey)
+
+	params.SSHPrivateKeyPath = filepath.Join(t.TempDir(), "missing")
+	require.ErrorIs(t, Preflight(context.Background(), params), os.ErrNotExist)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, Preflight(ctx, params), context.Canceled)
+}
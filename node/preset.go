@@ -0,0 +1,101 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"errors"
+	"fmt"
+
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+)
+
+// NodePreset configures the odysseygo flags of Validator and API nodes for a use case, so that
+// the flags of each use case don't have to be known
+type NodePreset string
+
+const (
+	// DefaultPreset keeps the odysseygo config of the SDK: state sync enabled, pruning and
+	// indexer disabled
+	DefaultPreset NodePreset = ""
+
+	// ArchiveRPCPreset is for API nodes serving the full history of the chains: the indexer
+	// is enabled, and the D-Chain is bootstrapped from genesis without state sync and keeps
+	// all its state
+	ArchiveRPCPreset NodePreset = "archive-rpc"
+
+	// PrunedValidatorPreset is for validators keeping their disk usage low: the D-Chain state
+	// syncs and prunes old state, and the indexer is disabled
+	PrunedValidatorPreset NodePreset = "pruned-validator"
+)
+
+var ErrUnsupportedPreset = errors.New("unsupported node preset")
+
+// PresetConfig is the odysseygo config and the disk sizing of a NodePreset
+type PresetConfig struct {
+	// IndexEnabled enables the odysseygo indexer API
+	IndexEnabled bool
+
+	// APIAdminEnabled enables the odysseygo admin API
+	APIAdminEnabled bool
+
+	// StateSyncEnabled and PruningEnabled configure the D-Chain database
+	StateSyncEnabled bool
+	PruningEnabled   bool
+
+	// MinDataVolumeGB is the recommended minimum size of the volume holding the odysseygo
+	// database. Provisioning warns about smaller volumes, see Node.ResizeDataVolume
+	MinDataVolumeGB uint64
+}
+
+// Config returns the odysseygo config and the disk sizing of p
+func (p NodePreset) Config() (PresetConfig, error) {
+	switch p {
+	case DefaultPreset:
+		return PresetConfig{
+			StateSyncEnabled: true,
+			MinDataVolumeGB:  300,
+		}, nil
+	case ArchiveRPCPreset:
+		return PresetConfig{
+			IndexEnabled:    true,
+			MinDataVolumeGB: 2000,
+		}, nil
+	case PrunedValidatorPreset:
+		return PresetConfig{
+			StateSyncEnabled: true,
+			PruningEnabled:   true,
+			MinDataVolumeGB:  300,
+		}, nil
+	default:
+		return PresetConfig{}, fmt.Errorf("%w %q", ErrUnsupportedPreset, p)
+	}
+}
+
+// apply sets the odysseygo flags of c in conf
+func (c PresetConfig) apply(conf *remoteconfig.OdysseyConfigInputs) {
+	conf.IndexEnabled = c.IndexEnabled
+	conf.APIAdminEnabled = c.APIAdminEnabled
+	conf.StateSyncEnabled = c.StateSyncEnabled
+	conf.PruningEnabled = c.PruningEnabled
+}
+
+// checkPresetDataVolume warns if the volume holding the odysseygo database of h is smaller
+// than recommended by its preset
+func (h *Node) checkPresetDataVolume() {
+	presetConfig, err := h.Preset.Config()
+	if err != nil {
+		return
+	}
+	usage, err := h.GetDataVolumeUsage()
+	if err != nil {
+		h.Logger.Warnf("cannot check the data volume size of node %s: %s", h.NodeID, err)
+		return
+	}
+	if float64(usage.SizeBytes) < float64(presetConfig.MinDataVolumeGB*bytesInGB)*minFilesystemSizeRatio {
+		h.Logger.Warnf(
+			"data volume of node %s is %d GB, less than the %d GB recommended by the %q preset",
+			h.NodeID, usage.SizeBytes/bytesInGB, presetConfig.MinDataVolumeGB, h.Preset,
+		)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"encoding/json"
+	"testing"
+
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodePreset_Config(t *testing.T) {
+	tests := []struct {
+		preset         NodePreset
+		expectedNode   map[string]interface{}
+		expectedDChain map[string]interface{}
+		minDataGB      uint64
+	}{
+		{
+			preset:         DefaultPreset,
+			expectedNode:   map[string]interface{}{"index-enabled": false, "api-admin-enabled": false},
+			expectedDChain: map[string]interface{}{"state-sync-enabled": true, "pruning-enabled": false},
+			minDataGB:      300,
+		},
+		{
+			preset:         ArchiveRPCPreset,
+			expectedNode:   map[string]interface{}{"index-enabled": true, "api-admin-enabled": false},
+			expectedDChain: map[string]interface{}{"state-sync-enabled": false, "pruning-enabled": false},
+			minDataGB:      2000,
+		},
+		{
+			preset:         PrunedValidatorPreset,
+			expectedNode:   map[string]interface{}{"index-enabled": false, "api-admin-enabled": false},
+			expectedDChain: map[string]interface{}{"state-sync-enabled": true, "pruning-enabled": true},
+			minDataGB:      300,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.preset), func(t *testing.T) {
+			presetConfig, err := tt.preset.Config()
+			require.NoError(t, err)
+			assert.Equal(t, tt.minDataGB, presetConfig.MinDataVolumeGB)
+
+			conf := remoteconfig.PrepareOdysseyConfig("10.0.0.1", "testnet", nil)
+			presetConfig.apply(&conf)
+			nodeConf, err := remoteconfig.RenderOdysseyNodeConfig(conf)
+			require.NoError(t, err)
+			rendered := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(nodeConf, &rendered))
+			for key, value := range tt.expectedNode {
+				assert.Equal(t, value, rendered[key], key)
+			}
+			dChainConf, err := remoteconfig.RenderOdysseyDChainConfig(conf)
+			require.NoError(t, err)
+			rendered = map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(dChainConf, &rendered))
+			assert.Equal(t, tt.expectedDChain, rendered)
+		})
+	}
+
+	_, err := NodePreset("archive").Config()
+	require.ErrorIs(t, err, ErrUnsupportedPreset)
+}
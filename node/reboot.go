@@ -0,0 +1,271 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+)
+
+const (
+	// DefaultRebootTimeout is how long Reboot waits for a node to be back when ctx has no
+	// deadline, and how long RollingReboot waits for each node to rejoin by default
+	DefaultRebootTimeout = 15 * time.Minute
+
+	// bootIDFile holds a random ID generated at every boot of a Linux host
+	bootIDFile = "/proc/sys/kernel/random/boot_id"
+
+	// rebootCommand reboots the host once the SSH command returned, so that the command is
+	// not killed with the connection
+	rebootCommand = "nohup sh -c 'sleep 2; systemctl reboot || reboot' >/dev/null 2>&1 &"
+)
+
+// ErrRebootAborted is the result error of the nodes not rebooted by RollingReboot after the
+// reboot of a previous node failed or would have put the subnet stake at risk
+var ErrRebootAborted = errors.New("rolling reboot aborted")
+
+// Reboot reboots the host of the node, e.g. to apply a kernel update. If wait is true, it
+// waits for the host to be booted again and reachable over SSH, then for odysseygo to be
+// healthy on odysseygo nodes, until ctx is done or for DefaultRebootTimeout if ctx has no
+// deadline
+func (h *Node) Reboot(ctx context.Context, wait bool) error {
+	bootID, err := h.bootID()
+	if err != nil {
+		return err
+	}
+	if _, err := h.SudoCommand(nil, constants.SSHScriptTimeout, rebootCommand); err != nil {
+		return fmt.Errorf("failed to reboot node %s: %w", h.NodeID, err)
+	}
+	_ = h.Disconnect()
+	h.connection = nil
+	if !wait {
+		return nil
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultRebootTimeout)
+		defer cancel()
+	}
+	if err := h.waitForBoot(ctx, bootID); err != nil {
+		return err
+	}
+	if !isOdysseyGoNode(*h) {
+		return nil
+	}
+	deadline, _ := ctx.Deadline()
+	return h.WaitForOdysseyGoHealth(time.Until(deadline))
+}
+
+// bootID returns the ID of the current boot of the host of the node
+func (h *Node) bootID() (string, error) {
+	output, err := h.Command(nil, constants.SSHScriptTimeout, "cat "+bootIDFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the boot ID of node %s: %w", h.NodeID, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// waitForBoot waits for the host of the node to be reachable over SSH with a boot ID other
+// than previousBootID, until ctx is done
+func (h *Node) waitForBoot(ctx context.Context, previousBootID string) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("node %s is not back from its reboot: %w", h.NodeID, ctx.Err())
+		case <-time.After(constants.SSHSleepBetweenChecks):
+		}
+		if err := h.Connect(0); err != nil {
+			continue
+		}
+		bootID, err := h.bootID()
+		if err == nil && bootID != previousBootID {
+			return nil
+		}
+		// the connection may have been made before the host went down
+		_ = h.Disconnect()
+		h.connection = nil
+	}
+}
+
+// UpdateSystem upgrades the system packages of the host of the node, including its kernel,
+// with its package manager. Reboot the node to run the new kernel
+func (h *Node) UpdateSystem() error {
+	platform, err := h.DetectPlatform()
+	if err != nil {
+		return err
+	}
+	if err := platform.Supported(); err != nil {
+		return err
+	}
+	script := fmt.Sprintf("%s -y upgrade", platform.PackageManager)
+	if platform.PackageManager == Apt {
+		script = "apt-get -y update && DEBIAN_FRONTEND=noninteractive apt-get -y -o Dpkg::Options::=--force-confold upgrade"
+	}
+	if output, err := h.SudoCommand(nil, constants.SSHLongRunningScriptTimeout, script); err != nil {
+		return fmt.Errorf("failed to update node %s: %w: %s", h.NodeID, err, output)
+	}
+	return nil
+}
+
+// RollingRebootOp holds the options of MaintenanceScheduler.RollingReboot
+type RollingRebootOp struct {
+	rejoinTimeout time.Duration
+	chains        []string
+	systemUpdate  bool
+	cordon        func(context.Context, *Node) error
+	uncordon      func(context.Context, *Node) error
+}
+
+// RollingRebootOption configures MaintenanceScheduler.RollingReboot
+type RollingRebootOption func(*RollingRebootOp)
+
+// WithRejoinTimeout sets how long each node has to be back, healthy and bootstrapped after
+// its reboot, DefaultRebootTimeout by default
+func WithRejoinTimeout(timeout time.Duration) RollingRebootOption {
+	return func(op *RollingRebootOp) {
+		op.rejoinTimeout = timeout
+	}
+}
+
+// WithRejoinChains sets the chains each node must have bootstrapped after its reboot, e.g.
+// the blockchains of the subnet it validates, the primary network chains by default
+func WithRejoinChains(chains ...string) RollingRebootOption {
+	return func(op *RollingRebootOp) {
+		op.chains = chains
+	}
+}
+
+// WithSystemUpdate makes RollingReboot update the system packages of each node before its
+// reboot, see Node.UpdateSystem
+func WithSystemUpdate() RollingRebootOption {
+	return func(op *RollingRebootOp) {
+		op.systemUpdate = true
+	}
+}
+
+// WithCordon makes RollingReboot call cordon before the reboot of each node, e.g. to take it
+// out of a load balancer, and uncordon once it rejoined. Nodes failing to rejoin are left
+// cordoned
+func WithCordon(cordon func(context.Context, *Node) error, uncordon func(context.Context, *Node) error) RollingRebootOption {
+	return func(op *RollingRebootOp) {
+		op.cordon = cordon
+		op.uncordon = uncordon
+	}
+}
+
+// rebootNode reboots node and waits for it to be back, see Node.Reboot
+var rebootNode = func(ctx context.Context, node *Node, systemUpdate bool) error {
+	if systemUpdate {
+		if err := node.UpdateSystem(); err != nil {
+			return err
+		}
+	}
+	return node.Reboot(ctx, true)
+}
+
+// waitForRejoin waits for node to be healthy and bootstrapped on chains, if an odysseygo node
+var waitForRejoin = func(ctx context.Context, node *Node, timeout time.Duration, chains []string) error {
+	if !isOdysseyGoNode(*node) {
+		return nil
+	}
+	if err := node.WaitForOdysseyGoHealth(timeout); err != nil {
+		return err
+	}
+	_, err := node.WaitForBootstrap(ctx, timeout, chains...)
+	return err
+}
+
+// isNodeHealthy tells if odysseygo is healthy on node
+var isNodeHealthy = func(node *Node) (bool, error) {
+	return node.GetOdysseyGoHealth()
+}
+
+// RollingReboot reboots the nodes one at a time, in order, each within a maintenance window
+// of the policy, e.g. after a kernel update with WithSystemUpdate. Each node is cordoned if
+// WithCordon is given, rebooted, and must rejoin, i.e. be healthy and bootstrapped, before the
+// next one is rebooted.
+//
+// Before each reboot, the fleet nodes with a weight in the policy are checked: the weight of
+// the unhealthy ones added to the weight of the rebooted node must not exceed the max offline
+// weight of the policy, or the reboot fails with ErrQuorumAtRisk. The validators outside of
+// the fleet are assumed online. Once a node fails, the rolling reboot stops and the nodes
+// left fail with ErrRebootAborted
+func (s *MaintenanceScheduler) RollingReboot(ctx context.Context, nodes []*Node, options ...RollingRebootOption) *NodeResults {
+	op := RollingRebootOp{rejoinTimeout: DefaultRebootTimeout}
+	for _, option := range options {
+		option(&op)
+	}
+	results := &NodeResults{}
+	for i, node := range nodes {
+		err := s.waitForWindow(ctx)
+		if err == nil {
+			err = s.checkOnlineWeight(node, nodes)
+		}
+		if err == nil {
+			err = rebootAndRejoin(ctx, node, op)
+		}
+		results.AddResult(node.NodeID, nil, err)
+		if err != nil {
+			for _, rest := range nodes[i+1:] {
+				results.AddResult(rest.NodeID, nil, fmt.Errorf("%w after node %s failed: %w", ErrRebootAborted, node.NodeID, err))
+			}
+			break
+		}
+	}
+	return results
+}
+
+// checkOnlineWeight returns ErrQuorumAtRisk if rebooting node, while the unhealthy nodes of
+// the fleet are offline, would take more weight offline than the policy allows
+func (s *MaintenanceScheduler) checkOnlineWeight(node *Node, fleet []*Node) error {
+	offline := s.policy.Weights[node.NodeID]
+	unhealthy := []string{}
+	for _, other := range fleet {
+		weight := s.policy.Weights[other.NodeID]
+		if other == node || weight == 0 {
+			continue
+		}
+		if healthy, err := isNodeHealthy(other); err != nil || !healthy {
+			offline += weight
+			unhealthy = append(unhealthy, other.NodeID)
+		}
+	}
+	if budget := s.policy.MaxOfflineWeight(); offline > budget {
+		return fmt.Errorf("%w: rebooting node %s would take weight %d offline with unhealthy nodes %v, while at most %d may be offline",
+			ErrQuorumAtRisk, node.NodeID, offline, unhealthy, budget)
+	}
+	return nil
+}
+
+// rebootAndRejoin cordons node, reboots it, waits for it to rejoin and uncordons it
+func rebootAndRejoin(ctx context.Context, node *Node, op RollingRebootOp) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if op.cordon != nil {
+		if err := op.cordon(ctx, node); err != nil {
+			return fmt.Errorf("failed to cordon node %s: %w", node.NodeID, err)
+		}
+	}
+	rebootCtx, cancel := context.WithTimeout(ctx, op.rejoinTimeout)
+	defer cancel()
+	if err := rebootNode(rebootCtx, node, op.systemUpdate); err != nil {
+		return err
+	}
+	deadline, _ := rebootCtx.Deadline()
+	if err := waitForRejoin(rebootCtx, node, time.Until(deadline), op.chains); err != nil {
+		return fmt.Errorf("node %s did not rejoin after its reboot: %w", node.NodeID, err)
+	}
+	if op.uncordon != nil {
+		if err := op.uncordon(ctx, node); err != nil {
+			return fmt.Errorf("failed to uncordon node %s: %w", node.NodeID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRollingReboot records the reboots and makes the nodes of unhealthy fail the health
+// checks and those of failing fail to rejoin
+func mockRollingReboot(t *testing.T, unhealthy map[string]bool, failing map[string]bool) *[]string {
+	originalReboot, originalRejoin, originalHealthy := rebootNode, waitForRejoin, isNodeHealthy
+	t.Cleanup(func() {
+		rebootNode, waitForRejoin, isNodeHealthy = originalReboot, originalRejoin, originalHealthy
+	})
+	rebooted := []string{}
+	rebootNode = func(_ context.Context, node *Node, _ bool) error {
+		rebooted = append(rebooted, node.NodeID)
+		return nil
+	}
+	waitForRejoin = func(_ context.Context, node *Node, _ time.Duration, _ []string) error {
+		if failing[node.NodeID] {
+			return errors.New("not bootstrapped")
+		}
+		return nil
+	}
+	isNodeHealthy = func(node *Node) (bool, error) {
+		return !unhealthy[node.NodeID], nil
+	}
+	return &rebooted
+}
+
+func TestMaintenanceScheduler_RollingReboot(t *testing.T) {
+	nodes := []*Node{{NodeID: "node-1"}, {NodeID: "node-2"}, {NodeID: "node-3"}, {NodeID: "node-4"}}
+	policy := MaintenancePolicy{Weights: map[string]uint64{"node-1": 20, "node-2": 20, "node-3": 20, "node-4": 20}}
+	s := newTestMaintenanceScheduler(t, policy, time.Now())
+
+	rebooted := mockRollingReboot(t, nil, nil)
+	cordoned := []string{}
+	results := s.RollingReboot(context.Background(), nodes, WithCordon(
+		func(_ context.Context, node *Node) error {
+			cordoned = append(cordoned, node.NodeID)
+			return nil
+		},
+		func(_ context.Context, node *Node) error {
+			assert.Equal(t, node.NodeID, cordoned[len(cordoned)-1])
+			return nil
+		},
+	))
+	require.Empty(t, results.Failed())
+	assert.Equal(t, []string{"node-1", "node-2", "node-3", "node-4"}, *rebooted)
+	assert.Equal(t, *rebooted, cordoned)
+
+	// node-2 failing to rejoin stops the reboot
+	rebooted = mockRollingReboot(t, nil, map[string]bool{"node-2": true})
+	results = s.RollingReboot(context.Background(), nodes)
+	assert.Equal(t, []string{"node-1", "node-2"}, *rebooted)
+	require.Len(t, results.Failed(), 3)
+	result, ok := results.Get("node-2")
+	require.True(t, ok)
+	assert.ErrorContains(t, result.Err, "did not rejoin")
+	result, ok = results.Get("node-4")
+	require.True(t, ok)
+	assert.ErrorIs(t, result.Err, ErrRebootAborted)
+
+	// with node-3 unhealthy, rebooting another node takes 40 of 80 offline, above the 26 allowed
+	rebooted = mockRollingReboot(t, map[string]bool{"node-3": true}, nil)
+	results = s.RollingReboot(context.Background(), nodes)
+	assert.Empty(t, *rebooted)
+	result, ok = results.Get("node-1")
+	require.True(t, ok)
+	require.ErrorIs(t, result.Err, ErrQuorumAtRisk)
+	assert.ErrorContains(t, result.Err, "[node-3]")
+	result, ok = results.Get("node-2")
+	require.True(t, ok)
+	assert.ErrorIs(t, result.Err, ErrRebootAborted)
+	assert.ErrorIs(t, result.Err, ErrQuorumAtRisk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results = s.RollingReboot(ctx, nodes)
+	require.Len(t, results.Failed(), 4)
+	assert.ErrorIs(t, results.Failed()[0].Err, context.Canceled)
+}
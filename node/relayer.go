@@ -0,0 +1,160 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+)
+
+var (
+	ErrRelayerParamsRequired  = errors.New("relayer params are required to install the AWM relayer on the node")
+	ErrEmptyRelayerConfigFile = errors.New("relayer config file is not provided")
+)
+
+// RelayerParams configures the AWM relayer of nodes with the Relayer role
+type RelayerParams struct {
+	// ConfigFile is the local path of the JSON config of the relayer, listing the source and
+	// destination blockchains to relay Warp messages between
+	ConfigFile string
+
+	// Version is the docker image tag of the relayer, constants.DefaultAWMRelayerVersion by default
+	Version string
+}
+
+// Validate checks that the relayer config file is set
+func (p *RelayerParams) Validate() error {
+	if p == nil {
+		return ErrRelayerParamsRequired
+	}
+	if p.ConfigFile == "" {
+		return ErrEmptyRelayerConfigFile
+	}
+	return nil
+}
+
+func (p *RelayerParams) version() string {
+	if p.Version == "" {
+		return constants.DefaultAWMRelayerVersion
+	}
+	return p.Version
+}
+
+// renderRelayerConfig returns the relayer config of configFile, setting the storage location
+// inside the container volume and the metricsPort scraped by Prometheus if they are not set
+func renderRelayerConfig(configFile string, metricsPort uint) ([]byte, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+	config := map[string]interface{}{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid relayer config file %s: %w", configFile, err)
+	}
+	if _, ok := config["storage-location"]; !ok {
+		config["storage-location"] = "/.awm-relayer/storage"
+	}
+	if _, ok := config["metrics-port"]; !ok {
+		config["metrics-port"] = metricsPort
+	}
+	return json.MarshalIndent(config, "", "  ")
+}
+
+// RunSSHSetupRelayerConfig uploads the AWM relayer config of params to the node
+func (h *Node) RunSSHSetupRelayerConfig(params *RelayerParams) (bool, error) {
+	if err := params.Validate(); err != nil {
+		return false, err
+	}
+	for _, folder := range remoteconfig.AWMRelayerFoldersToCreate(h.config()) {
+		if err := h.MkdirAll(folder, constants.SSHFileOpsTimeout); err != nil {
+			return false, err
+		}
+	}
+	config, err := renderRelayerConfig(params.ConfigFile, h.config().AWMRelayerMetricsPort)
+	if err != nil {
+		return false, err
+	}
+	configFile, err := os.CreateTemp("", constants.ServiceAWMRelayer)
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(configFile.Name())
+	if _, err := configFile.Write(config); err != nil {
+		return false, err
+	}
+	if err := configFile.Close(); err != nil {
+		return false, err
+	}
+	return h.UploadIfChanged(configFile.Name(), remoteconfig.GetRemoteAWMRelayerConfig(h.config()), constants.SSHFileOpsTimeout, true)
+}
+
+// ComposeSSHSetupRelayer sets up the AWM relayer of params on the node using docker-compose
+func (h *Node) ComposeSSHSetupRelayer(params *RelayerParams) error {
+	_, err := h.composeSSHSetupRelayer(params)
+	return err
+}
+
+func (h *Node) composeSSHSetupRelayer(params *RelayerParams) (bool, error) {
+	if !constants.DockerSupportEnabled {
+		return false, fmt.Errorf("Docker support functionality is disabled. Set constants.DockerSupportEnabled = true to enable")
+	}
+	if err := params.Validate(); err != nil {
+		return false, err
+	}
+	platform, err := h.DetectPlatform()
+	if err != nil {
+		return false, err
+	}
+	return h.composeOverSSH("Compose Relayer",
+		constants.SSHScriptTimeout,
+		"templates/relayer.docker-compose.yml",
+		dockerComposeInputs{
+			AWMRelayerVersion: params.version(),
+			Platform:          platform.DockerPlatform(),
+		})
+}
+
+// provisionRelayerHost installs the AWM relayer on the node, along with the monitoring agents
+// if the node does not run them yet
+func provisionRelayerHost(node Node, nodeParams *NodeParams) error {
+	if nodeParams == nil {
+		return ErrRelayerParamsRequired
+	}
+	if err := nodeParams.Relayer.Validate(); err != nil {
+		return err
+	}
+	if err := node.RunSSHSetupDockerService(); err != nil {
+		return err
+	}
+	configChanged, err := node.RunSSHSetupRelayerConfig(nodeParams.Relayer)
+	if err != nil {
+		return err
+	}
+	hasAgents, err := node.hasComposeService(constants.ServicePromtail)
+	if err != nil {
+		return err
+	}
+	if !hasAgents {
+		// provide dummy config for promtail
+		if err := node.RunSSHSetupPromtailConfig("127.0.0.1", int(node.config().LokiPort), node.NodeID, ""); err != nil {
+			return err
+		}
+		if _, err := node.composeSSHSetupAgents(); err != nil {
+			return err
+		}
+	}
+	if _, err := node.composeSSHSetupRelayer(nodeParams.Relayer); err != nil {
+		return err
+	}
+	// the relayer only reads its config on startup
+	if configChanged {
+		return node.RestartDockerComposeService(node.config().ComposeFile(), constants.ServiceAWMRelayer, constants.SSHScriptTimeout)
+	}
+	return nil
+}
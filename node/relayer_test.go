@@ -0,0 +1,94 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+)
+
+func TestRelayerParams_Validate(t *testing.T) {
+	var params *RelayerParams
+	assert.ErrorIs(t, params.Validate(), ErrRelayerParamsRequired)
+	assert.ErrorIs(t, (&RelayerParams{}).Validate(), ErrEmptyRelayerConfigFile)
+	assert.NoError(t, (&RelayerParams{ConfigFile: "config.json"}).Validate())
+
+	assert.Equal(t, constants.DefaultAWMRelayerVersion, (&RelayerParams{}).version())
+	assert.Equal(t, "v1.4.0", (&RelayerParams{Version: "v1.4.0"}).version())
+}
+
+func TestRenderRelayerConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("defaults are set", func(t *testing.T) {
+		configFile := filepath.Join(dir, "defaults.json")
+		require.NoError(t, os.WriteFile(configFile, []byte(`{"log-level":"info","source-blockchains":[]}`), 0o600))
+		data, err := renderRelayerConfig(configFile, constants.AWMRelayerMetricsPort)
+		require.NoError(t, err)
+		config := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(data, &config))
+		assert.Equal(t, "info", config["log-level"])
+		assert.Equal(t, []interface{}{}, config["source-blockchains"])
+		assert.Equal(t, "/.awm-relayer/storage", config["storage-location"])
+		assert.Equal(t, float64(constants.AWMRelayerMetricsPort), config["metrics-port"])
+	})
+
+	t.Run("user values are kept", func(t *testing.T) {
+		configFile := filepath.Join(dir, "custom.json")
+		require.NoError(t, os.WriteFile(configFile, []byte(`{"storage-location":"/data","metrics-port":9191}`), 0o600))
+		data, err := renderRelayerConfig(configFile, constants.AWMRelayerMetricsPort)
+		require.NoError(t, err)
+		config := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(data, &config))
+		assert.Equal(t, "/data", config["storage-location"])
+		assert.Equal(t, float64(9191), config["metrics-port"])
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		configFile := filepath.Join(dir, "invalid.json")
+		require.NoError(t, os.WriteFile(configFile, []byte(`{`), 0o600))
+		_, err := renderRelayerConfig(configFile, constants.AWMRelayerMetricsPort)
+		assert.ErrorContains(t, err, "invalid relayer config file")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := renderRelayerConfig(filepath.Join(dir, "missing.json"), constants.AWMRelayerMetricsPort)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestRenderRelayerComposeFile(t *testing.T) {
+	composeData, err := renderComposeFile("templates/relayer.docker-compose.yml", "test", dockerComposeInputs{AWMRelayerVersion: "v1.4.0"})
+	require.NoError(t, err)
+	content, err := parseComposeContent(composeData)
+	require.NoError(t, err)
+	require.Contains(t, content.Services, constants.ServiceAWMRelayer)
+	assert.Equal(t, constants.AWMRelayerDockerImage+":v1.4.0", content.Services[constants.ServiceAWMRelayer].Image)
+	assert.Contains(t, string(composeData), "/.awm-relayer/"+constants.AWMRelayerConfigFileName)
+}
+
+func TestProvisionRelayerHost_Validation(t *testing.T) {
+	node := Node{NodeID: "test-node"}
+	assert.ErrorIs(t, provisionRelayerHost(node, nil), ErrRelayerParamsRequired)
+	assert.ErrorIs(t, provisionRelayerHost(node, &NodeParams{}), ErrRelayerParamsRequired)
+	assert.ErrorIs(t, provisionRelayerHost(node, &NodeParams{Relayer: &RelayerParams{}}), ErrEmptyRelayerConfigFile)
+}
+
+func TestGetRelayerPrometheusTargets(t *testing.T) {
+	relayerTargets, machineTargets := getRelayerPrometheusTargets([]Node{
+		{IP: "10.0.0.1", Roles: []SupportedRole{Relayer}},
+		{IP: "10.0.0.2", Roles: []SupportedRole{API, Relayer}},
+		{IP: "10.0.0.3", Roles: []SupportedRole{Validator}},
+	})
+	assert.Equal(t, []string{"'10.0.0.1:9090'", "'10.0.0.2:9090'"}, relayerTargets)
+	// machine metrics of odysseygo nodes are already scraped by the odysseygo-machine job
+	assert.Equal(t, []string{"'10.0.0.1:9100'"}, machineTargets)
+}
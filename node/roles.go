@@ -0,0 +1,235 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrNodeParamsRequired = errors.New("node params are required to install odysseygo on the node")
+
+// roleServices lists the docker compose services that each role runs on a host
+var roleServices = map[SupportedRole][]string{
+	Validator: {constants.ServiceOdysseygo, constants.ServicePromtail, constants.ServiceNodeExporter},
+	API:       {constants.ServiceOdysseygo, constants.ServicePromtail, constants.ServiceNodeExporter},
+	Loadtest:  {constants.ServicePromtail, constants.ServiceNodeExporter},
+	Monitor: {
+		constants.ServicePrometheus,
+		constants.ServiceGrafana,
+		constants.ServiceLoki,
+		constants.ServiceNodeExporter,
+	},
+	Relayer:    {constants.ServiceAWMRelayer, constants.ServicePromtail, constants.ServiceNodeExporter},
+	RPCGateway: {constants.ServiceRPCGateway},
+}
+
+// AddRole installs the services required by role on an already provisioned node,
+// merging them into the existing remote compose file instead of re-rendering it.
+//
+// nodeParams is used for Validator and API roles when odysseygo is not yet
+// installed on the node. If odysseygo is already installed, its configuration is
+// left untouched. nodeParams.Relayer and nodeParams.RPCGateway are required for the
+// Relayer and RPCGateway roles.
+func (h *Node) AddRole(ctx context.Context, role SupportedRole, nodeParams *NodeParams) error {
+	if _, ok := roleServices[role]; !ok {
+		return fmt.Errorf("unsupported role %v", role)
+	}
+	if slices.Contains(h.Roles, role) {
+		return nil
+	}
+	roles := append(slices.Clone(h.Roles), role)
+	if err := CheckRoles(roles); err != nil {
+		return err
+	}
+	if err := CheckRuntimeMode(h.RuntimeMode, roles); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !h.Connected() {
+		if err := h.Connect(constants.SSHTCPPort); err != nil {
+			return err
+		}
+	}
+	switch role {
+	case Validator, API:
+		installed, err := h.hasOdysseyGo()
+		if err != nil {
+			return err
+		}
+		if !installed {
+			if nodeParams == nil {
+				return ErrNodeParamsRequired
+			}
+			if err := CheckRuntimeMode(nodeParams.RuntimeMode, roles); err != nil {
+				return err
+			}
+			if err := provisionOdysseyGo(*h, nodeParams); err != nil {
+				return err
+			}
+			h.RuntimeMode = nodeParams.RuntimeMode
+		}
+	case Loadtest:
+		if err := provisionLoadTestHost(*h); err != nil {
+			return err
+		}
+	case Monitor:
+		if err := provisionMonitoringHost(*h); err != nil {
+			return err
+		}
+	case Relayer:
+		if err := provisionRelayerHost(*h, nodeParams); err != nil {
+			return err
+		}
+	case RPCGateway:
+		if err := provisionRPCGatewayHost(*h, nodeParams); err != nil {
+			return err
+		}
+	}
+	h.Roles = roles
+	return nil
+}
+
+// RemoveRole stops and removes the services only used by role from the node,
+// keeping the services still needed by the remaining roles of the node.
+// Data folders of the removed services are kept on the host.
+func (h *Node) RemoveRole(ctx context.Context, role SupportedRole) error {
+	if !slices.Contains(h.Roles, role) {
+		return fmt.Errorf("node %s does not have role %s", h.NodeID, role.String())
+	}
+	remaining := utils.Filter(h.Roles, func(r SupportedRole) bool {
+		return r != role
+	})
+	if err := CheckRoles(remaining); err != nil {
+		return fmt.Errorf("cannot remove role %s from node %s: %w", role.String(), h.NodeID, err)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !h.Connected() {
+		if err := h.Connect(constants.SSHTCPPort); err != nil {
+			return err
+		}
+	}
+	if h.RuntimeMode == SystemdRuntime && (role == Validator || role == API) && !slices.Contains(remaining, Validator) && !slices.Contains(remaining, API) {
+		if err := h.removeNativeNode(); err != nil {
+			return err
+		}
+	}
+	composeFile := h.config().ComposeFile()
+	fileExists, err := h.FileExists(composeFile)
+	if err != nil {
+		return err
+	}
+	if fileExists {
+		services, err := h.ListRemoteComposeServices(composeFile, constants.SSHScriptTimeout)
+		if err != nil {
+			return err
+		}
+		toRemove := utils.Filter(servicesToRemove(role, remaining), func(s string) bool {
+			return slices.Contains(services, s)
+		})
+		if len(toRemove) > 0 {
+			if err := h.removeComposeServices(ctx, composeFile, toRemove); err != nil {
+				return err
+			}
+		}
+	}
+	h.Roles = remaining
+	return nil
+}
+
+func (h *Node) removeComposeServices(ctx context.Context, composeFile string, services []string) error {
+	h.Logger.Infof("Removing services %s from %s:%s", services, h.NodeID, composeFile)
+	if output, err := h.dockerCommandf(constants.SSHScriptTimeout, "docker compose -f %s rm --stop --force %s", composeFile, strings.Join(services, " ")); err != nil {
+		return fmt.Errorf("%w: %s", err, string(output))
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	content, err := h.GetRemoteComposeContent(composeFile, constants.SSHFileOpsTimeout)
+	if err != nil {
+		return err
+	}
+	updated, err := removeServicesFromCompose([]byte(content), services)
+	if err != nil {
+		return err
+	}
+	tmpFile, err := os.CreateTemp("", "odysseycli-docker-compose-*.yml")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(updated); err != nil {
+		return err
+	}
+	if err := h.PushComposeFile(tmpFile.Name(), composeFile, false); err != nil {
+		return err
+	}
+	return h.ValidateComposeFile(composeFile, constants.SSHScriptTimeout)
+}
+
+// hasComposeService checks if service is present in the remote compose file,
+// returning false if the node has no compose file yet
+func (h *Node) hasComposeService(service string) (bool, error) {
+	composeFile := h.config().ComposeFile()
+	fileExists, err := h.FileExists(composeFile)
+	if err != nil {
+		return false, err
+	}
+	if !fileExists {
+		return false, nil
+	}
+	return h.HasRemoteComposeService(composeFile, service, constants.SSHScriptTimeout)
+}
+
+// servicesToRemove returns the services of role that are not needed by any of the remaining roles
+func servicesToRemove(role SupportedRole, remaining []SupportedRole) []string {
+	return utils.Filter(roleServices[role], func(service string) bool {
+		return !utils.Any(remaining, func(r SupportedRole) bool {
+			return slices.Contains(roleServices[r], service)
+		})
+	})
+}
+
+// removeServicesFromCompose removes services from a docker compose file content,
+// preserving the order and formatting of the rest of the document
+func removeServicesFromCompose(content []byte, services []string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("invalid compose file: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("invalid compose file: expected a mapping at top level")
+	}
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "services" {
+			continue
+		}
+		servicesNode := root.Content[i+1]
+		if servicesNode.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("invalid compose file: services is not a mapping")
+		}
+		kept := []*yaml.Node{}
+		for j := 0; j+1 < len(servicesNode.Content); j += 2 {
+			if slices.Contains(services, servicesNode.Content[j].Value) {
+				continue
+			}
+			kept = append(kept, servicesNode.Content[j], servicesNode.Content[j+1])
+		}
+		servicesNode.Content = kept
+	}
+	return yaml.Marshal(&doc)
+}
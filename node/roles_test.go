@@ -0,0 +1,171 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestServicesToRemove(t *testing.T) {
+	tests := []struct {
+		name      string
+		role      SupportedRole
+		remaining []SupportedRole
+		expected  []string
+	}{
+		{
+			name:      "validator alone",
+			role:      Validator,
+			remaining: []SupportedRole{},
+			expected:  []string{constants.ServiceOdysseygo, constants.ServicePromtail, constants.ServiceNodeExporter},
+		},
+		{
+			name:      "monitor alone",
+			role:      Monitor,
+			remaining: nil,
+			expected:  []string{constants.ServicePrometheus, constants.ServiceGrafana, constants.ServiceLoki, constants.ServiceNodeExporter},
+		},
+		{
+			name:      "shared services are kept",
+			role:      Loadtest,
+			remaining: []SupportedRole{Validator},
+			expected:  []string{},
+		},
+		{
+			name:      "only exclusive services removed",
+			role:      Validator,
+			remaining: []SupportedRole{Loadtest},
+			expected:  []string{constants.ServiceOdysseygo},
+		},
+		{
+			name:      "relayer on api node keeps agents",
+			role:      Relayer,
+			remaining: []SupportedRole{API},
+			expected:  []string{constants.ServiceAWMRelayer},
+		},
+		{
+			name:      "standalone relayer",
+			role:      Relayer,
+			remaining: nil,
+			expected:  []string{constants.ServiceAWMRelayer, constants.ServicePromtail, constants.ServiceNodeExporter},
+		},
+		{
+			name:      "rpc gateway",
+			role:      RPCGateway,
+			remaining: []SupportedRole{API},
+			expected:  []string{constants.ServiceRPCGateway},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, servicesToRemove(tt.role, tt.remaining))
+		})
+	}
+}
+
+func TestRemoveServicesFromCompose(t *testing.T) {
+	composeData, err := renderComposeFile("templates/odysseygo.docker-compose.yml", "test", dockerComposeInputs{
+		OdysseygoVersion: "v1.10.13",
+		WithOdysseygo:    true,
+		WithMonitoring:   true,
+	})
+	require.NoError(t, err)
+
+	updated, err := removeServicesFromCompose(composeData, []string{constants.ServiceOdysseygo})
+	require.NoError(t, err)
+
+	var compose struct {
+		Name     string                 `yaml:"name"`
+		Services map[string]interface{} `yaml:"services"`
+	}
+	require.NoError(t, yaml.Unmarshal(updated, &compose))
+	assert.Equal(t, "odyssey-cli", compose.Name)
+	assert.NotContains(t, compose.Services, constants.ServiceOdysseygo)
+	assert.Contains(t, compose.Services, constants.ServicePromtail)
+	assert.Contains(t, compose.Services, constants.ServiceNodeExporter)
+}
+
+func TestRemoveServicesFromCompose_Invalid(t *testing.T) {
+	_, err := removeServicesFromCompose([]byte("- a\n- b\n"), []string{"a"})
+	assert.Error(t, err)
+
+	_, err = removeServicesFromCompose([]byte("services: [a]\n"), []string{"a"})
+	assert.Error(t, err)
+}
+
+func TestAddRole_Validation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("already has role", func(t *testing.T) {
+		node := Node{NodeID: "test-node", Roles: []SupportedRole{Validator}}
+		require.NoError(t, node.AddRole(ctx, Validator, nil))
+		assert.Equal(t, []SupportedRole{Validator}, node.Roles)
+	})
+
+	t.Run("invalid combination", func(t *testing.T) {
+		node := Node{NodeID: "test-node", Roles: []SupportedRole{Validator}}
+		err := node.AddRole(ctx, API, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot have both validator and api roles")
+		assert.Equal(t, []SupportedRole{Validator}, node.Roles)
+	})
+
+	t.Run("rpc gateway requires api", func(t *testing.T) {
+		node := Node{NodeID: "test-node", Roles: []SupportedRole{Validator}}
+		err := node.AddRole(ctx, RPCGateway, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "rpc-gateway role requires the api role")
+		assert.Equal(t, []SupportedRole{Validator}, node.Roles)
+	})
+
+	t.Run("unsupported role", func(t *testing.T) {
+		node := Node{NodeID: "test-node"}
+		err := node.AddRole(ctx, SupportedRole(999), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported role")
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		node := Node{NodeID: "test-node"}
+		err := node.AddRole(cancelledCtx, Monitor, nil)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, node.Roles)
+	})
+}
+
+func TestRemoveRole_Validation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("missing role", func(t *testing.T) {
+		node := Node{NodeID: "test-node", Roles: []SupportedRole{Validator}}
+		err := node.RemoveRole(ctx, Monitor)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not have role monitor")
+	})
+
+	t.Run("api still needed by rpc gateway", func(t *testing.T) {
+		node := Node{NodeID: "test-node", Roles: []SupportedRole{API, RPCGateway}}
+		err := node.RemoveRole(ctx, API)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "rpc-gateway role requires the api role")
+		assert.Equal(t, []SupportedRole{API, RPCGateway}, node.Roles)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		node := Node{NodeID: "test-node", Roles: []SupportedRole{Validator}}
+		err := node.RemoveRole(cancelledCtx, Validator)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, []SupportedRole{Validator}, node.Roles)
+	})
+}
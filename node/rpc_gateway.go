@@ -0,0 +1,352 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+)
+
+var (
+	ErrRPCGatewayParamsRequired = errors.New("rpc gateway params are required to install the RPC gateway on the node")
+	ErrIncompleteRPCGatewayTLS  = errors.New("both rpc gateway TLS certificate and key must be provided")
+	ErrInvalidRPCGatewayAPIKey  = errors.New("invalid rpc gateway API key")
+	ErrInvalidRPCGatewayMethod  = errors.New("invalid rpc gateway method pattern")
+	ErrInvalidRPCGatewayACME    = errors.New("invalid rpc gateway ACME settings")
+)
+
+var (
+	rpcGatewayAPIKeyNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+	rpcGatewayAPIKeyPattern     = regexp.MustCompile(`^[A-Za-z0-9_.~+/=-]{16,}$`)
+	rpcGatewayMethodPattern     = regexp.MustCompile(`^[A-Za-z0-9_.]+\*?$`)
+)
+
+// AdminRPCMethods are the JSON-RPC methods of the chains managing or debugging the node, e.g.
+// to block with RPCGatewayParams.BlockedMethods
+var AdminRPCMethods = []string{"admin_*", "debug_*", "personal_*", "miner_*"}
+
+// RPCGatewayParams configures the public RPC reverse proxy of nodes with the RPCGateway role
+type RPCGatewayParams struct {
+	// ServerName is the domain the gateway is served on. Any domain is accepted when empty
+	ServerName string
+
+	// TLSCertFile and TLSKeyFile are the local paths of the PEM certificate and key of
+	// ServerName. When set, the gateway serves HTTPS and redirects plain HTTP to it;
+	// otherwise it serves plain HTTP, e.g. behind a TLS terminating load balancer
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// ACMEEmail is the account email of the certificate of ServerName obtained with ACME,
+	// from Let's Encrypt unless ACMEServer is set, instead of TLSCertFile and TLSKeyFile.
+	// The certificate is obtained with the HTTP-01 challenge, so ServerName must resolve to
+	// the node, and is renewed by a systemd timer of the node
+	ACMEEmail string
+
+	// ACMEServer is the directory URL of the ACME server, e.g. the Let's Encrypt staging
+	// one, that of Let's Encrypt when empty
+	ACMEServer string
+
+	// RateLimit is the number of requests per second allowed to each client IP,
+	// constants.DefaultRPCGatewayRateLimit by default
+	RateLimit uint
+
+	// RateLimitBurst is the number of requests above RateLimit served before rejecting
+	// them with 429, twice RateLimit by default
+	RateLimitBurst uint
+
+	// APIKeys restrict the chain RPC endpoints to the clients sending one of the keys, in
+	// the X-API-Key header or the api_key query argument. Requests without a valid key are
+	// rejected with 401, and each key is rate limited instead of the client IPs. The health
+	// check stays public. The chain RPC endpoints are open to all when empty
+	APIKeys []RPCGatewayAPIKey
+
+	// AllowedMethods are the JSON-RPC methods served on the chain RPC endpoints, e.g.
+	// eth_* or platform.getHeight, patterns ending with * matching the methods starting
+	// with what precedes it. All methods are allowed when empty
+	AllowedMethods []string
+
+	// BlockedMethods are the JSON-RPC methods refused on the chain RPC endpoints, even if
+	// allowed, e.g. AdminRPCMethods. The messages of websocket subscriptions are not
+	// filtered
+	BlockedMethods []string
+
+	// AccessLogs logs the requests as JSON lines into the rpc-gateway folder of the
+	// odysseygo logs, shipped to Loki by promtail
+	AccessLogs bool
+}
+
+// RPCGatewayAPIKey is an API key of the RPC gateway
+type RPCGatewayAPIKey struct {
+	// Name identifies the client of the key in the access logs
+	Name string
+
+	// Key is the secret sent by the client, at least 16 characters among letters, digits
+	// and _.~+/=-
+	Key string
+
+	// RateLimit and RateLimitBurst limit the requests of the key as those of a client IP,
+	// those of the gateway by default
+	RateLimit      uint
+	RateLimitBurst uint
+}
+
+// Validate checks that the TLS files of the gateway are consistent and exist, and that its
+// API keys and method patterns are valid
+func (p *RPCGatewayParams) Validate() error {
+	if p == nil {
+		return ErrRPCGatewayParamsRequired
+	}
+	if (p.TLSCertFile == "") != (p.TLSKeyFile == "") {
+		return ErrIncompleteRPCGatewayTLS
+	}
+	for _, file := range []string{p.TLSCertFile, p.TLSKeyFile} {
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); err != nil {
+			return fmt.Errorf("invalid rpc gateway TLS file %s: %w", file, err)
+		}
+	}
+	if err := p.validateACME(); err != nil {
+		return err
+	}
+	names := map[string]bool{}
+	keys := map[string]bool{}
+	for _, key := range p.APIKeys {
+		switch {
+		case !rpcGatewayAPIKeyNamePattern.MatchString(key.Name):
+			return fmt.Errorf("%w: name %q must be letters, digits and _.-", ErrInvalidRPCGatewayAPIKey, key.Name)
+		case !rpcGatewayAPIKeyPattern.MatchString(key.Key):
+			return fmt.Errorf("%w: key of %s must be at least 16 letters, digits and _.~+/=-", ErrInvalidRPCGatewayAPIKey, key.Name)
+		case names[key.Name]:
+			return fmt.Errorf("%w: duplicate name %s", ErrInvalidRPCGatewayAPIKey, key.Name)
+		case keys[key.Key]:
+			return fmt.Errorf("%w: key of %s is already used", ErrInvalidRPCGatewayAPIKey, key.Name)
+		}
+		names[key.Name] = true
+		keys[key.Key] = true
+	}
+	for _, method := range append(slices.Clone(p.AllowedMethods), p.BlockedMethods...) {
+		if !rpcGatewayMethodPattern.MatchString(method) {
+			return fmt.Errorf("%w: %q", ErrInvalidRPCGatewayMethod, method)
+		}
+	}
+	return nil
+}
+
+func (p *RPCGatewayParams) validateACME() error {
+	if p.ACMEEmail == "" {
+		if p.ACMEServer != "" {
+			return fmt.Errorf("%w: ACMEServer requires ACMEEmail", ErrInvalidRPCGatewayACME)
+		}
+		return nil
+	}
+	switch {
+	case p.TLSCertFile != "":
+		return fmt.Errorf("%w: ACMEEmail and TLSCertFile are exclusive", ErrInvalidRPCGatewayACME)
+	case p.ServerName == "" || strings.ContainsAny(p.ServerName, " */;'\"$"):
+		return fmt.Errorf("%w: ACMEEmail requires a ServerName domain, got %q", ErrInvalidRPCGatewayACME, p.ServerName)
+	}
+	if _, err := mail.ParseAddress(p.ACMEEmail); err != nil || strings.ContainsAny(p.ACMEEmail, " <>'\"$") {
+		return fmt.Errorf("%w: invalid email %q", ErrInvalidRPCGatewayACME, p.ACMEEmail)
+	}
+	if p.ACMEServer != "" {
+		if u, err := url.Parse(p.ACMEServer); err != nil || u.Scheme != "https" || strings.ContainsAny(p.ACMEServer, " '\"$") {
+			return fmt.Errorf("%w: invalid ACME server %q", ErrInvalidRPCGatewayACME, p.ACMEServer)
+		}
+	}
+	return nil
+}
+
+func (p *RPCGatewayParams) configInputs() remoteconfig.RPCGatewayConfigInputs {
+	inputs := remoteconfig.RPCGatewayConfigInputs{
+		ServerName:     p.ServerName,
+		TLS:            p.TLSCertFile != "",
+		ACME:           p.ACMEEmail != "",
+		RateLimit:      p.RateLimit,
+		RateLimitBurst: p.RateLimitBurst,
+		AllowedMethods: p.AllowedMethods,
+		BlockedMethods: p.BlockedMethods,
+		AccessLog:      p.AccessLogs,
+	}
+	if inputs.RateLimit == 0 {
+		inputs.RateLimit = constants.DefaultRPCGatewayRateLimit
+	}
+	if inputs.RateLimitBurst == 0 {
+		inputs.RateLimitBurst = 2 * inputs.RateLimit
+	}
+	for _, key := range p.APIKeys {
+		keyInputs := remoteconfig.RPCGatewayAPIKeyInputs{
+			Name:           key.Name,
+			Key:            key.Key,
+			RateLimit:      key.RateLimit,
+			RateLimitBurst: key.RateLimitBurst,
+		}
+		if keyInputs.RateLimit == 0 {
+			keyInputs.RateLimit = inputs.RateLimit
+		}
+		if keyInputs.RateLimitBurst == 0 {
+			keyInputs.RateLimitBurst = 2 * keyInputs.RateLimit
+		}
+		inputs.APIKeys = append(inputs.APIKeys, keyInputs)
+	}
+	return inputs
+}
+
+// RunSSHSetupRPCGatewayConfig uploads the reverse proxy configs and TLS files of params to
+// the node, returning true if any of them changed. With ACME, the gateway serves HTTPS once
+// the certificate is obtained by RunSSHSetupRPCGatewayACME
+func (h *Node) RunSSHSetupRPCGatewayConfig(params *RPCGatewayParams) (bool, error) {
+	if err := params.Validate(); err != nil {
+		return false, err
+	}
+	config := h.config()
+	for _, folder := range remoteconfig.RPCGatewayFoldersToCreate(config) {
+		if err := h.MkdirAll(folder, constants.SSHFileOpsTimeout); err != nil {
+			return false, err
+		}
+	}
+	inputs := params.configInputs()
+	inputs.HTTPPort = config.RPCGatewayHTTPPort
+	inputs.HTTPSPort = config.RPCGatewayHTTPSPort
+	inputs.OdysseygoAPIPort = config.OdysseygoAPIPort
+	if inputs.ACME {
+		certExists, err := h.FileExists(remoteconfig.GetRemoteRPCGatewayACMECertificate(config, params.ServerName))
+		if err != nil {
+			return false, err
+		}
+		inputs.TLS = certExists
+	}
+	renders := []struct {
+		remoteFile string
+		render     func() ([]byte, error)
+	}{
+		{remoteconfig.GetRemoteRPCGatewayConfig(config), func() ([]byte, error) { return remoteconfig.RenderRPCGatewayConfig(inputs, h.renderOptions()...) }},
+		{remoteconfig.GetRemoteRPCGatewayMainConfig(config), func() ([]byte, error) { return remoteconfig.RenderRPCGatewayMainConfig(h.renderOptions()...) }},
+		{remoteconfig.GetRemoteRPCGatewayAPIKeys(config), func() ([]byte, error) { return remoteconfig.RenderRPCGatewayAPIKeys(inputs, h.renderOptions()...) }},
+		{remoteconfig.GetRemoteRPCGatewayMethodFilter(config), func() ([]byte, error) { return remoteconfig.RenderRPCGatewayMethodFilter(inputs, h.renderOptions()...) }},
+	}
+	uploads := map[string]string{}
+	for _, r := range renders {
+		content, err := r.render()
+		if err != nil {
+			return false, err
+		}
+		localFile, err := os.CreateTemp("", constants.ServiceRPCGateway)
+		if err != nil {
+			return false, err
+		}
+		defer os.Remove(localFile.Name())
+		if _, err := localFile.Write(content); err != nil {
+			localFile.Close()
+			return false, err
+		}
+		if err := localFile.Close(); err != nil {
+			return false, err
+		}
+		uploads[localFile.Name()] = r.remoteFile
+	}
+	if params.TLSCertFile != "" {
+		tlsDir := config.ServicePath(constants.ServiceRPCGateway, "tls")
+		uploads[params.TLSCertFile] = filepath.Join(tlsDir, "tls.crt")
+		uploads[params.TLSKeyFile] = filepath.Join(tlsDir, "tls.key")
+	}
+	changed := false
+	for localFile, remoteFile := range uploads {
+		uploaded, err := h.UploadIfChanged(localFile, remoteFile, constants.SSHFileOpsTimeout, false)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || uploaded
+	}
+	return changed, nil
+}
+
+// RunSSHSetupRPCGatewayACME obtains the certificate of params.ServerName with ACME, if not
+// obtained yet, and sets up a systemd timer renewing it and reloading the gateway. The
+// gateway must be running to serve the HTTP-01 challenge
+func (h *Node) RunSSHSetupRPCGatewayACME(params *RPCGatewayParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	if params.ACMEEmail == "" {
+		return fmt.Errorf("%w: ACMEEmail is required", ErrInvalidRPCGatewayACME)
+	}
+	return h.RunOverSSH(
+		"Setup RPC Gateway ACME",
+		constants.SSHLongRunningScriptTimeout,
+		"shell/setupRPCGatewayACME.sh",
+		scriptInputs{
+			ServerName:   params.ServerName,
+			ACMEEmail:    params.ACMEEmail,
+			ACMEServer:   params.ACMEServer,
+			CertbotImage: constants.CertbotDockerImage,
+			Config:       h.config(),
+		},
+	)
+}
+
+// ComposeSSHSetupRPCGateway sets up the RPC gateway on the node using docker-compose
+func (h *Node) ComposeSSHSetupRPCGateway() error {
+	_, err := h.composeSSHSetupRPCGateway()
+	return err
+}
+
+func (h *Node) composeSSHSetupRPCGateway() (bool, error) {
+	if !constants.DockerSupportEnabled {
+		return false, fmt.Errorf("Docker support functionality is disabled. Set constants.DockerSupportEnabled = true to enable")
+	}
+	return h.composeOverSSH("Compose RPC Gateway",
+		constants.SSHScriptTimeout,
+		"templates/rpc-gateway.docker-compose.yml",
+		dockerComposeInputs{})
+}
+
+// provisionRPCGatewayHost installs the RPC gateway in front of the odysseygo API of the node
+func provisionRPCGatewayHost(node Node, nodeParams *NodeParams) error {
+	if nodeParams == nil {
+		return ErrRPCGatewayParamsRequired
+	}
+	if err := nodeParams.RPCGateway.Validate(); err != nil {
+		return err
+	}
+	configChanged, err := node.RunSSHSetupRPCGatewayConfig(nodeParams.RPCGateway)
+	if err != nil {
+		return err
+	}
+	if _, err := node.composeSSHSetupRPCGateway(); err != nil {
+		return err
+	}
+	// nginx only reads its config and certificates on startup
+	restart := func() error {
+		return node.RestartDockerComposeService(node.config().ComposeFile(), constants.ServiceRPCGateway, constants.SSHScriptTimeout)
+	}
+	if configChanged {
+		if err := restart(); err != nil {
+			return err
+		}
+	}
+	if nodeParams.RPCGateway.ACMEEmail == "" {
+		return nil
+	}
+	if err := node.RunSSHSetupRPCGatewayACME(nodeParams.RPCGateway); err != nil {
+		return err
+	}
+	// serve the certificate once obtained
+	configChanged, err = node.RunSSHSetupRPCGatewayConfig(nodeParams.RPCGateway)
+	if err != nil || !configChanged {
+		return err
+	}
+	return restart()
+}
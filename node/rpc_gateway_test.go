@@ -0,0 +1,240 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+)
+
+func TestRPCGatewayParams_Validate(t *testing.T) {
+	certFile := filepath.Join(t.TempDir(), "tls.crt")
+	require.NoError(t, os.WriteFile(certFile, []byte("cert"), 0o600))
+	keyFile := filepath.Join(t.TempDir(), "tls.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("key"), 0o600))
+
+	tests := []struct {
+		name        string
+		params      *RPCGatewayParams
+		expectedErr error
+		errContains string
+	}{
+		{name: "nil", params: nil, expectedErr: ErrRPCGatewayParamsRequired},
+		{name: "plain http", params: &RPCGatewayParams{}},
+		{name: "tls", params: &RPCGatewayParams{ServerName: "rpc.example.com", TLSCertFile: certFile, TLSKeyFile: keyFile}},
+		{name: "cert without key", params: &RPCGatewayParams{TLSCertFile: certFile}, expectedErr: ErrIncompleteRPCGatewayTLS},
+		{name: "key without cert", params: &RPCGatewayParams{TLSKeyFile: keyFile}, expectedErr: ErrIncompleteRPCGatewayTLS},
+		{
+			name:        "missing cert file",
+			params:      &RPCGatewayParams{TLSCertFile: filepath.Join(t.TempDir(), "missing.crt"), TLSKeyFile: keyFile},
+			errContains: "invalid rpc gateway TLS file",
+		},
+		{
+			name: "api keys and methods",
+			params: &RPCGatewayParams{
+				APIKeys:        []RPCGatewayAPIKey{{Name: "wallet", Key: "0123456789abcdef"}, {Name: "indexer", Key: "fedcba9876543210", RateLimit: 100}},
+				AllowedMethods: []string{"eth_*", "net_version", "platform.getHeight"},
+				BlockedMethods: AdminRPCMethods,
+			},
+		},
+		{name: "short api key", params: &RPCGatewayParams{APIKeys: []RPCGatewayAPIKey{{Name: "wallet", Key: "secret"}}}, expectedErr: ErrInvalidRPCGatewayAPIKey},
+		{name: "api key with quote", params: &RPCGatewayParams{APIKeys: []RPCGatewayAPIKey{{Name: "wallet", Key: `0123456789abcdef"`}}}, expectedErr: ErrInvalidRPCGatewayAPIKey},
+		{name: "api key without name", params: &RPCGatewayParams{APIKeys: []RPCGatewayAPIKey{{Key: "0123456789abcdef"}}}, expectedErr: ErrInvalidRPCGatewayAPIKey},
+		{
+			name:        "duplicate api key",
+			params:      &RPCGatewayParams{APIKeys: []RPCGatewayAPIKey{{Name: "a", Key: "0123456789abcdef"}, {Name: "b", Key: "0123456789abcdef"}}},
+			expectedErr: ErrInvalidRPCGatewayAPIKey,
+		},
+		{name: "invalid method", params: &RPCGatewayParams{BlockedMethods: []string{"debug_*'];"}}, expectedErr: ErrInvalidRPCGatewayMethod},
+		{name: "acme", params: &RPCGatewayParams{ServerName: "rpc.example.com", ACMEEmail: "ops@example.com"}},
+		{name: "acme without server name", params: &RPCGatewayParams{ACMEEmail: "ops@example.com"}, expectedErr: ErrInvalidRPCGatewayACME},
+		{name: "acme with invalid email", params: &RPCGatewayParams{ServerName: "rpc.example.com", ACMEEmail: "ops"}, expectedErr: ErrInvalidRPCGatewayACME},
+		{
+			name:        "acme with tls files",
+			params:      &RPCGatewayParams{ServerName: "rpc.example.com", ACMEEmail: "ops@example.com", TLSCertFile: certFile, TLSKeyFile: keyFile},
+			expectedErr: ErrInvalidRPCGatewayACME,
+		},
+		{name: "acme server without email", params: &RPCGatewayParams{ACMEServer: "https://acme.example.com/directory"}, expectedErr: ErrInvalidRPCGatewayACME},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			switch {
+			case tt.expectedErr != nil:
+				assert.ErrorIs(t, err, tt.expectedErr)
+			case tt.errContains != "":
+				assert.ErrorContains(t, err, tt.errContains)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRPCGatewayParams_ConfigInputs(t *testing.T) {
+	inputs := (&RPCGatewayParams{}).configInputs()
+	assert.Equal(t, remoteconfig.RPCGatewayConfigInputs{
+		RateLimit:      constants.DefaultRPCGatewayRateLimit,
+		RateLimitBurst: 2 * constants.DefaultRPCGatewayRateLimit,
+	}, inputs)
+
+	inputs = (&RPCGatewayParams{
+		ServerName:     "rpc.example.com",
+		TLSCertFile:    "tls.crt",
+		TLSKeyFile:     "tls.key",
+		RateLimit:      5,
+		RateLimitBurst: 7,
+	}).configInputs()
+	assert.Equal(t, remoteconfig.RPCGatewayConfigInputs{
+		ServerName:     "rpc.example.com",
+		TLS:            true,
+		RateLimit:      5,
+		RateLimitBurst: 7,
+	}, inputs)
+
+	inputs = (&RPCGatewayParams{
+		ServerName: "rpc.example.com",
+		ACMEEmail:  "ops@example.com",
+		RateLimit:  5,
+		APIKeys:    []RPCGatewayAPIKey{{Name: "wallet", Key: "0123456789abcdef"}, {Name: "indexer", Key: "fedcba9876543210", RateLimit: 100}},
+	}).configInputs()
+	assert.True(t, inputs.ACME)
+	assert.False(t, inputs.TLS)
+	assert.Equal(t, []remoteconfig.RPCGatewayAPIKeyInputs{
+		{Name: "wallet", Key: "0123456789abcdef", RateLimit: 5, RateLimitBurst: 10},
+		{Name: "indexer", Key: "fedcba9876543210", RateLimit: 100, RateLimitBurst: 200},
+	}, inputs.APIKeys)
+}
+
+func TestRenderRPCGatewayConfig(t *testing.T) {
+	t.Run("tls", func(t *testing.T) {
+		config, err := remoteconfig.RenderRPCGatewayConfig((&RPCGatewayParams{
+			ServerName:  "rpc.example.com",
+			TLSCertFile: "tls.crt",
+			TLSKeyFile:  "tls.key",
+			RateLimit:   5,
+		}).configInputs())
+		require.NoError(t, err)
+		output := string(config)
+		assert.Contains(t, output, "rate=5r/s")
+		assert.Contains(t, output, "limit_req zone=rpc burst=10 nodelay;")
+		assert.Contains(t, output, "listen 443 ssl;")
+		assert.Contains(t, output, "ssl_certificate /etc/nginx/tls/tls.crt;")
+		assert.Contains(t, output, "return 301 https://$host$request_uri;")
+		assert.Contains(t, output, "server_name rpc.example.com;")
+		assert.Contains(t, output, "location /ext/bc/")
+		assert.Contains(t, output, "server 127.0.0.1:9650;")
+	})
+
+	t.Run("plain http", func(t *testing.T) {
+		config, err := remoteconfig.RenderRPCGatewayConfig((&RPCGatewayParams{}).configInputs())
+		require.NoError(t, err)
+		output := string(config)
+		assert.Contains(t, output, "listen 80;")
+		assert.Contains(t, output, "server_name _;")
+		assert.NotContains(t, output, "ssl")
+		assert.NotContains(t, output, "return 301")
+		assert.NotContains(t, output, "api_key")
+		assert.NotContains(t, output, "js_")
+		assert.NotContains(t, output, "access_log")
+	})
+
+	t.Run("api keys, method filter and access logs", func(t *testing.T) {
+		inputs := (&RPCGatewayParams{
+			APIKeys:        []RPCGatewayAPIKey{{Name: "wallet", Key: "0123456789abcdef"}, {Name: "indexer", Key: "fedcba9876543210", RateLimit: 100}},
+			BlockedMethods: AdminRPCMethods,
+			AccessLogs:     true,
+		}).configInputs()
+		config, err := remoteconfig.RenderRPCGatewayConfig(inputs)
+		require.NoError(t, err)
+		output := string(config)
+		assert.Contains(t, output, "include /etc/nginx/api-keys.conf;")
+		assert.NotContains(t, output, "0123456789abcdef")
+		assert.Contains(t, output, `"indexer" $api_client;`)
+		assert.Contains(t, output, "limit_req_zone $api_key_1 zone=api_key_1:1m rate=100r/s;")
+		assert.Contains(t, output, "return 401;")
+		assert.Contains(t, output, "limit_req zone=api_key_0 burst=40 nodelay;")
+		assert.Contains(t, output, "limit_req zone=api_key_1 burst=200 nodelay;")
+		assert.Contains(t, output, `proxy_set_header X-API-Key "";`)
+		assert.Contains(t, output, "js_import rpc from rpc.js;")
+		assert.Contains(t, output, "js_content rpc.filter;")
+		assert.Contains(t, output, "location @odysseygo {")
+		assert.Contains(t, output, `'"client":"$api_client",'`)
+		assert.Contains(t, output, "access_log /var/log/rpc-gateway/access.log rpc_gateway;")
+		// the health check stays public
+		assert.Contains(t, output, "location = /ext/health {\n        limit_req zone=rpc burst=40 nodelay;")
+
+		apiKeys, err := remoteconfig.RenderRPCGatewayAPIKeys(inputs)
+		require.NoError(t, err)
+		assert.Contains(t, string(apiKeys), `"0123456789abcdef" "wallet";`)
+		assert.Contains(t, string(apiKeys), `"fedcba9876543210" "indexer";`)
+
+		filter, err := remoteconfig.RenderRPCGatewayMethodFilter(inputs)
+		require.NoError(t, err)
+		assert.Contains(t, string(filter), "const allowed = [];")
+		assert.Contains(t, string(filter), `const blocked = ["admin_*", "debug_*", "personal_*", "miner_*"];`)
+
+		mainConfig, err := remoteconfig.RenderRPCGatewayMainConfig()
+		require.NoError(t, err)
+		assert.Contains(t, string(mainConfig), "load_module modules/ngx_http_js_module.so;")
+	})
+
+	t.Run("acme", func(t *testing.T) {
+		inputs := (&RPCGatewayParams{ServerName: "rpc.example.com", ACMEEmail: "ops@example.com"}).configInputs()
+		config, err := remoteconfig.RenderRPCGatewayConfig(inputs)
+		require.NoError(t, err)
+		output := string(config)
+		// the challenges are served over plain HTTP until the certificate is obtained
+		assert.Contains(t, output, "root /var/www/acme;")
+		assert.NotContains(t, output, "ssl")
+
+		inputs.TLS = true
+		config, err = remoteconfig.RenderRPCGatewayConfig(inputs)
+		require.NoError(t, err)
+		output = string(config)
+		assert.Contains(t, output, "ssl_certificate /etc/letsencrypt/live/rpc.example.com/fullchain.pem;")
+		assert.Contains(t, output, "ssl_certificate_key /etc/letsencrypt/live/rpc.example.com/privkey.pem;")
+		assert.Contains(t, output, "location /.well-known/acme-challenge/ {\n        root /var/www/acme;\n    }\n\n    location / {\n        return 301 https://$host$request_uri;")
+	})
+}
+
+func TestRenderRPCGatewayACMEScript(t *testing.T) {
+	script, err := renderScript("Setup RPC Gateway ACME", "shell/setupRPCGatewayACME.sh", scriptInputs{
+		ServerName:   "rpc.example.com",
+		ACMEEmail:    "ops@example.com",
+		CertbotImage: constants.CertbotDockerImage,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, script, "GATEWAY_DIR=/home/ubuntu/.odyssey-cli/services/rpc-gateway\n")
+	assert.Contains(t, script, "$GATEWAY_DIR/letsencrypt/live/rpc.example.com/fullchain.pem")
+	assert.Contains(t, script, "-d rpc.example.com")
+	assert.Contains(t, script, "--email ops@example.com")
+	assert.NotContains(t, script, "--server")
+	assert.Contains(t, script, "docker exec rpc-gateway nginx -s reload")
+	assert.NoError(t, (&ExecutionPolicy{}).Check(script))
+}
+
+func TestRenderRPCGatewayComposeFile(t *testing.T) {
+	composeData, err := renderComposeFile("templates/rpc-gateway.docker-compose.yml", "test", dockerComposeInputs{})
+	require.NoError(t, err)
+	content, err := parseComposeContent(composeData)
+	require.NoError(t, err)
+	require.Contains(t, content.Services, constants.ServiceRPCGateway)
+	assert.Equal(t, constants.RPCGatewayDockerImage, content.Services[constants.ServiceRPCGateway].Image)
+}
+
+func TestProvisionRPCGatewayHost_Validation(t *testing.T) {
+	node := Node{NodeID: "test-node"}
+	assert.ErrorIs(t, provisionRPCGatewayHost(node, nil), ErrRPCGatewayParamsRequired)
+	assert.ErrorIs(t, provisionRPCGatewayHost(node, &NodeParams{}), ErrRPCGatewayParamsRequired)
+	assert.ErrorIs(t, provisionRPCGatewayHost(node, &NodeParams{RPCGateway: &RPCGatewayParams{TLSCertFile: "tls.crt"}}), ErrIncompleteRPCGatewayTLS)
+	assert.ErrorIs(t, node.RunSSHSetupRPCGatewayACME(&RPCGatewayParams{}), ErrInvalidRPCGatewayACME)
+}
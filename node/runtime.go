@@ -0,0 +1,193 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/progress"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+)
+
+// RuntimeMode is how the services of a node are run
+type RuntimeMode string
+
+const (
+	// DockerRuntime runs the services as docker compose containers. It is the default mode
+	DockerRuntime RuntimeMode = "docker"
+
+	// SystemdRuntime runs odysseygo, promtail and the node exporter as native binaries with
+	// systemd units, for hosts forbidding Docker. Only Validator and API nodes support it
+	SystemdRuntime RuntimeMode = "systemd"
+)
+
+// the native binaries match the images of templates/odysseygo.docker-compose.yml
+const (
+	nativePromtailVersion     = "3.0.0"
+	nativeNodeExporterVersion = "1.7.0"
+)
+
+// nativeUnits are the systemd units installed by shell/setupNativeNode.sh, odysseygo first
+var nativeUnits = []string{"odysseygo.service", "promtail.service", "node-exporter.service"}
+
+var (
+	ErrUnsupportedRuntimeMode = errors.New("unsupported runtime mode")
+	ErrSystemdUnavailable     = errors.New("systemd is not available")
+)
+
+// CheckRuntimeMode checks that nodes with roles can run in mode
+func CheckRuntimeMode(mode RuntimeMode, roles []SupportedRole) error {
+	switch mode {
+	case "", DockerRuntime:
+		return nil
+	case SystemdRuntime:
+		for _, role := range roles {
+			if role != Validator && role != API {
+				return fmt.Errorf("%w: %s role requires the %s runtime", ErrUnsupportedRuntimeMode, role.String(), DockerRuntime)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w %q", ErrUnsupportedRuntimeMode, mode)
+	}
+}
+
+// SetupNativeNode installs odysseygo odysseyGoVersion, promtail and the node exporter as
+// systemd services on h, with the same node config as ComposeSSHSetupNode. The promtail config
+// is set up by RunSSHSetupPromtailConfig
+func (h *Node) SetupNativeNode(networkID string, subnetsToTrack []string, odysseyGoVersion string) error {
+	startTime := time.Now()
+	platform, err := h.nativePlatform()
+	if err != nil {
+		return err
+	}
+	for _, dir := range remoteconfig.RemoteFoldersToCreateOdysseygo(h.config()) {
+		if err := h.MkdirAll(dir, constants.SSHFileOpsTimeout); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+	if err := h.RunSSHRenderOdysseyNodeConfig(networkID, subnetsToTrack); err != nil {
+		return err
+	}
+	if err := h.installNativeNode(platform, odysseyGoVersion); err != nil {
+		return err
+	}
+	h.Logger.Infof("OdysseyGo %s running as a systemd service on %s[%s] after %s", odysseyGoVersion, h.NodeID, h.IP, time.Since(startTime))
+	return nil
+}
+
+// nativePlatform returns the platform of h, checking that it can run the native binaries
+func (h *Node) nativePlatform() (HostPlatform, error) {
+	if !h.HasSystemDAvailable() {
+		return HostPlatform{}, fmt.Errorf("%w on node %s", ErrSystemdUnavailable, h.NodeID)
+	}
+	platform, err := h.DetectPlatform()
+	if err != nil {
+		return HostPlatform{}, err
+	}
+	return platform, platform.Supported()
+}
+
+// installNativeNode installs odysseygo odysseyGoVersion, promtail and the node exporter with
+// their systemd units, run by the SSH user of h, and restarts them
+func (h *Node) installNativeNode(platform HostPlatform, odysseyGoVersion string) error {
+	inputs := nativeNodeScriptInputs(platform, odysseyGoVersion, h.config())
+	inputs.User = h.sshUser()
+	return h.RunOverSSH("Setup Native Node", constants.SSHLongRunningScriptTimeout, "shell/setupNativeNode.sh", inputs)
+}
+
+// upgradeNativeNode installs odysseygo odysseyGoVersion on h in the SystemdRuntime mode,
+// restarting its services
+func (h *Node) upgradeNativeNode(odysseyGoVersion string, tracker *progress.Tracker) error {
+	platform, err := h.nativePlatform()
+	if err != nil {
+		return err
+	}
+	tracker.Step(progress.StageRestart, fmt.Sprintf("installing odysseygo %s", odysseyGoVersion))
+	return h.installNativeNode(platform, odysseyGoVersion)
+}
+
+// systemctlOdysseyGo runs the systemctl command, e.g. start, on the odysseygo unit of h in the
+// SystemdRuntime mode
+func (h *Node) systemctlOdysseyGo(command string) error {
+	if output, err := h.Commandf(nil, constants.SSHLongRunningScriptTimeout, "sudo systemctl %s %s", command, nativeUnits[0]); err != nil {
+		return fmt.Errorf("failed to %s odysseygo: %w: %s", command, err, string(output))
+	}
+	return nil
+}
+
+// hasOdysseyGo tells whether odysseygo is set up on h: as a systemd unit in the
+// SystemdRuntime mode, or as a service of the compose file otherwise
+func (h *Node) hasOdysseyGo() (bool, error) {
+	if h.RuntimeMode == SystemdRuntime {
+		return h.FileExists("/etc/systemd/system/" + nativeUnits[0])
+	}
+	return h.hasComposeService(constants.ServiceOdysseygo)
+}
+
+// removeNativeNode stops and disables the systemd units of h in the SystemdRuntime mode. The
+// binaries and data folders are kept on the host
+func (h *Node) removeNativeNode() error {
+	h.Logger.Infof("Removing systemd units %s from %s", nativeUnits, h.NodeID)
+	if output, err := h.Commandf(nil, constants.SSHScriptTimeout, "sudo systemctl disable --now %s", strings.Join(nativeUnits, " ")); err != nil {
+		return fmt.Errorf("failed to remove systemd units: %w: %s", err, string(output))
+	}
+	return nil
+}
+
+// nativeNodeScriptInputs returns the release URLs of the native binaries for platform, and
+// the folders and ports of config
+func nativeNodeScriptInputs(platform HostPlatform, odysseyGoVersion string, config constants.Config) scriptInputs {
+	return scriptInputs{
+		Config:         config,
+		PackageManager: string(platform.PackageManager),
+		Arch:           platform.Arch,
+		OdysseyGoReleaseURL: utils.GetGithubReleaseAssetURL(
+			constants.DioneProtocolOrg,
+			constants.ServiceOdysseygo,
+			odysseyGoVersion,
+			fmt.Sprintf("odysseygo-linux-%s-%s.tar.gz", platform.Arch, odysseyGoVersion),
+		),
+		PromtailReleaseURL: utils.GetGithubReleaseAssetURL(
+			"grafana",
+			"loki",
+			"v"+nativePromtailVersion,
+			fmt.Sprintf("promtail-linux-%s.zip", platform.Arch),
+		),
+		NodeExporterReleaseURL: utils.GetGithubReleaseAssetURL(
+			"prometheus",
+			"node_exporter",
+			"v"+nativeNodeExporterVersion,
+			fmt.Sprintf("node_exporter-%s.linux-%s.tar.gz", nativeNodeExporterVersion, platform.Arch),
+		),
+	}
+}
+
+func provisionOdysseyGoNativeHost(node Node, nodeParams *NodeParams) error {
+	if err := node.RunSSHSetupTimeSync(nodeParams.NTPServers); err != nil {
+		return err
+	}
+	// provide dummy config for promtail
+	if err := node.RunSSHSetupPromtailConfig("127.0.0.1", int(node.config().LokiPort), node.NodeID, ""); err != nil {
+		return err
+	}
+	if err := node.SetupNativeNode(nodeParams.Network.HRP(), nodeParams.SubnetIDs, nodeParams.OdysseyGoVersion); err != nil {
+		return err
+	}
+	node.checkPresetDataVolume()
+	return nil
+}
+
+// provisionOdysseyGo provisions odysseygo on node in the runtime mode of nodeParams
+func provisionOdysseyGo(node Node, nodeParams *NodeParams) error {
+	if nodeParams.RuntimeMode == SystemdRuntime {
+		return provisionOdysseyGoNativeHost(node, nodeParams)
+	}
+	return provisionOdysseyGoHost(node, nodeParams)
+}
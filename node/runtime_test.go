@@ -0,0 +1,93 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/nodemock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRuntimeMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        RuntimeMode
+		roles       []SupportedRole
+		expectedErr error
+	}{
+		{name: "default", roles: []SupportedRole{Monitor}},
+		{name: "docker", mode: DockerRuntime, roles: []SupportedRole{API, RPCGateway}},
+		{name: "systemd validator", mode: SystemdRuntime, roles: []SupportedRole{Validator}},
+		{name: "systemd api", mode: SystemdRuntime, roles: []SupportedRole{API}},
+		{name: "systemd monitor", mode: SystemdRuntime, roles: []SupportedRole{Monitor}, expectedErr: ErrUnsupportedRuntimeMode},
+		{name: "systemd rpc gateway", mode: SystemdRuntime, roles: []SupportedRole{API, RPCGateway}, expectedErr: ErrUnsupportedRuntimeMode},
+		{name: "unknown", mode: "podman", roles: []SupportedRole{API}, expectedErr: ErrUnsupportedRuntimeMode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckRuntimeMode(tt.mode, tt.roles)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSetupNativeNodeScript(t *testing.T) {
+	platform := HostPlatform{OS: LinuxOS, Arch: ARM64Arch, Distro: "ubuntu", PackageManager: Apt}
+	inputs := nativeNodeScriptInputs(platform, "v1.10.13", constants.Config{OdysseyGoDir: "/data/odysseygo", MachineMetricsPort: 9200})
+	assert.Equal(t, "https://github.com/DioneProtocol/odysseygo/releases/download/v1.10.13/odysseygo-linux-arm64-v1.10.13.tar.gz", inputs.OdysseyGoReleaseURL)
+	assert.Equal(t, "https://github.com/grafana/loki/releases/download/v3.0.0/promtail-linux-arm64.zip", inputs.PromtailReleaseURL)
+	assert.Equal(t, "https://github.com/prometheus/node_exporter/releases/download/v1.7.0/node_exporter-1.7.0.linux-arm64.tar.gz", inputs.NodeExporterReleaseURL)
+
+	script, err := renderScript("Setup Native Node", "shell/setupNativeNode.sh", inputs)
+	require.NoError(t, err)
+	assert.Contains(t, script, "apt-get")
+	assert.Contains(t, script, inputs.OdysseyGoReleaseURL)
+	assert.Contains(t, script, "promtail-linux-arm64")
+	assert.Contains(t, script, "--config-file=/.odysseygo/configs/node.json")
+	assert.Contains(t, script, "BindPaths=/data/odysseygo:/.odysseygo")
+	assert.Contains(t, script, "BindReadOnlyPaths="+constants.DefaultServicesDir+"/promtail:/etc/promtail")
+	assert.Contains(t, script, "node_exporter --web.listen-address=:9200")
+	assert.Contains(t, script, "User="+constants.RemoteHostUser+"\nGroup="+constants.RemoteHostUser)
+	assert.NotContains(t, script, "docker")
+
+	inputs.User = "admin"
+	script, err = renderScript("Setup Native Node", "shell/setupNativeNode.sh", inputs)
+	require.NoError(t, err)
+	assert.Contains(t, script, "User=admin\nGroup=admin")
+	assert.NotContains(t, script, "User="+constants.RemoteHostUser)
+}
+
+func TestNode_SystemdRuntime(t *testing.T) {
+	client := nodemock.NewSSHClient()
+	client.OnScript("sudo systemctl restart odysseygo.service", "", nil).Once()
+	client.OnScript("sudo systemctl stop odysseygo.service", "", nil).Once()
+	client.OnScript("sudo systemctl start odysseygo.service", "", errors.New("exit status 1")).Once()
+	client.OnScript("if [ -e '/etc/systemd/system/odysseygo.service' ]; then echo yes; fi", "yes\n", nil).Once()
+	h := &Node{NodeID: "node-1", RuntimeMode: SystemdRuntime}
+	h.SetSSHClient(client)
+
+	require.NoError(t, h.RunSSHRestartOdysseygo())
+	require.NoError(t, h.RunSSHStopOdysseygo())
+	require.Error(t, h.RunSSHStartOdysseygo())
+	installed, err := h.hasOdysseyGo()
+	require.NoError(t, err)
+	assert.True(t, installed)
+	client.AssertExpectations(t)
+}
+
+func TestProvisionHost_UnsupportedRuntimeMode(t *testing.T) {
+	err := provisionHost(Node{NodeID: "node-1"}, &NodeParams{
+		Roles:       []SupportedRole{Monitor},
+		RuntimeMode: SystemdRuntime,
+	})
+	require.ErrorIs(t, err, ErrUnsupportedRuntimeMode)
+}
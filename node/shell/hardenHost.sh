@@ -0,0 +1,167 @@
+#!/usr/bin/env bash
+set -e
+
+# Each setting is reported on a line: HARDENING <area> <setting> <changed|unchanged>,
+# separated by tabs. Settings already in place are left untouched so the script is idempotent.
+report() {
+	printf 'HARDENING\t%s\t%s\t%s\n' "$1" "$2" "$3"
+}
+
+# ensure_file PATH writes stdin to PATH if its content differs, setting CHANGED
+ensure_file() {
+	local content
+	content=$(cat)
+	if sudo test -f "$1" && [ "$(sudo cat "$1")" = "$content" ]; then
+		CHANGED=unchanged
+	else
+		printf '%s\n' "$content" | sudo tee "$1" >/dev/null
+		CHANGED=changed
+	fi
+}
+
+# edit_file PATH SED_ARGS... edits PATH in place with sed, setting CHANGED
+edit_file() {
+	local path=$1 before
+	shift
+	before=$(sudo cat "$path")
+	sudo sed -i "$@" "$path"
+	if [ "$(sudo cat "$path")" = "$before" ]; then CHANGED=unchanged; else CHANGED=changed; fi
+}
+
+{{- if eq .PackageManager "dnf" "yum" }}
+installed() { rpm -q "$1" >/dev/null 2>&1; }
+install() { sudo {{ .PackageManager }} -y install "$1" >/dev/null; }
+{{- else }}
+export DEBIAN_FRONTEND=noninteractive
+APT_UPDATED=
+installed() { dpkg -s "$1" >/dev/null 2>&1; }
+install() {
+	if [ -z "$APT_UPDATED" ]; then
+		sudo apt-get -y update >/dev/null
+		APT_UPDATED=1
+	fi
+	sudo apt-get -y install "$1" >/dev/null
+}
+{{- end }}
+
+# ensure_package PACKAGE AREA installs PACKAGE if missing and reports it
+ensure_package() {
+	if installed "$1"; then
+		report "$2" "package $1" unchanged
+	else
+		install "$1"
+		report "$2" "package $1" changed
+	fi
+}
+{{ if .SecurityUpdates }}
+{{- if eq .PackageManager "dnf" }}
+ensure_package dnf-automatic security-updates
+edit_file /etc/dnf/automatic.conf \
+	-e 's/^upgrade_type *=.*/upgrade_type = security/' \
+	-e 's/^apply_updates *=.*/apply_updates = yes/'
+report security-updates "/etc/dnf/automatic.conf" $CHANGED
+if systemctl is-enabled --quiet dnf-automatic.timer; then
+	report security-updates "dnf-automatic.timer" unchanged
+else
+	sudo systemctl enable --now dnf-automatic.timer
+	report security-updates "dnf-automatic.timer" changed
+fi
+{{- else if eq .PackageManager "yum" }}
+ensure_package yum-cron security-updates
+edit_file /etc/yum/yum-cron.conf \
+	-e 's/^update_cmd *=.*/update_cmd = security/' \
+	-e 's/^apply_updates *=.*/apply_updates = yes/'
+report security-updates "/etc/yum/yum-cron.conf" $CHANGED
+if systemctl is-enabled --quiet yum-cron; then
+	report security-updates "yum-cron" unchanged
+else
+	sudo systemctl enable --now yum-cron
+	report security-updates "yum-cron" changed
+fi
+{{- else }}
+# the default unattended-upgrades origins only include the security updates
+ensure_package unattended-upgrades security-updates
+ensure_file /etc/apt/apt.conf.d/20auto-upgrades <<'EOF'
+APT::Periodic::Update-Package-Lists "1";
+APT::Periodic::Unattended-Upgrade "1";
+EOF
+report security-updates "/etc/apt/apt.conf.d/20auto-upgrades" $CHANGED
+{{- end }}
+{{ end }}
+{{- if .Fail2Ban }}
+{{- if eq .PackageManager "dnf" "yum" }}
+# fail2ban is packaged in EPEL
+ensure_package epel-release fail2ban
+{{- end }}
+ensure_package fail2ban fail2ban
+ensure_file /etc/fail2ban/jail.d/odyssey-sshd.local <<'EOF'
+[sshd]
+enabled = true
+backend = systemd
+maxretry = 5
+findtime = 10m
+bantime = 1h
+EOF
+report fail2ban "/etc/fail2ban/jail.d/odyssey-sshd.local" $CHANGED
+sudo systemctl enable --now fail2ban >/dev/null 2>&1
+if [ "$CHANGED" = changed ]; then
+	sudo systemctl restart fail2ban
+fi
+{{ end }}
+{{- if .SSHSettings }}
+# sshd keeps the first value of a setting, so the managed block goes first in sshd_config,
+# before the Include of sshd_config.d and the Match blocks
+SSHD_CONFIG=/etc/ssh/sshd_config
+SSHD_BEFORE=$(sudo sshd -T 2>/dev/null || true)
+sudo cp -p $SSHD_CONFIG $SSHD_CONFIG.odyssey-backup
+SSHD_NEW=$(
+	echo "# BEGIN odyssey hardening"
+	{{- range $setting := .SSHSettings }}
+	echo "{{ $setting }}"
+	{{- end }}
+	echo "# END odyssey hardening"
+	sudo sed '/^# BEGIN odyssey hardening$/,/^# END odyssey hardening$/d' $SSHD_CONFIG.odyssey-backup
+)
+ensure_file $SSHD_CONFIG <<<"$SSHD_NEW"
+if [ "$CHANGED" = changed ]; then
+	if ! sudo sshd -t; then
+		sudo mv $SSHD_CONFIG.odyssey-backup $SSHD_CONFIG
+		echo "sshd rejected the hardened config, restored the previous one" >&2
+		exit 1
+	fi
+	sudo systemctl reload ssh 2>/dev/null || sudo systemctl reload sshd
+fi
+sudo rm -f $SSHD_CONFIG.odyssey-backup
+SSHD_AFTER=$(sudo sshd -T 2>/dev/null || true)
+# a setting changed if it is in effect now and was not before
+ssh_report() {
+	local effective
+	effective=$(echo "$1" | tr '[:upper:]' '[:lower:]')
+	if ! printf '%s\n' "$SSHD_BEFORE" | grep -qxF "$effective" && printf '%s\n' "$SSHD_AFTER" | grep -qxF "$effective"; then
+		report ssh "$1" changed
+	else
+		report ssh "$1" unchanged
+	fi
+}
+{{- range $setting := .SSHSettings }}
+ssh_report "{{ $setting }}"
+{{- end }}
+{{ end }}
+{{- if .Sysctls }}
+# values are compared with their whitespace squeezed, as sysctl separates them with tabs
+{{- range $sysctl := .Sysctls }}
+if [ "$(echo $(sysctl -n {{ $sysctl.Key }} 2>/dev/null))" = "{{ $sysctl.Value }}" ]; then
+	report sysctl "{{ $sysctl.Key }} = {{ $sysctl.Value }}" unchanged
+else
+	report sysctl "{{ $sysctl.Key }} = {{ $sysctl.Value }}" changed
+fi
+{{- end }}
+ensure_file /etc/sysctl.d/99-odyssey-hardening.conf <<'EOF'
+{{- range $sysctl := .Sysctls }}
+{{ $sysctl.Key }} = {{ $sysctl.Value }}
+{{- end }}
+EOF
+# keys missing from the running kernel are skipped
+sudo sysctl -q -e -p /etc/sysctl.d/99-odyssey-hardening.conf
+{{ end }}
+echo "Host hardening applied."
@@ -20,11 +20,11 @@ Requires=docker.service
 After=docker.service
 
 [Service]
-User=ubuntu
-Group=ubuntu
+User={{ .User }}
+Group=docker
 Restart=on-failure
-ExecStart=/usr/bin/docker compose -f /home/ubuntu/.odyssey-cli/services/docker-compose.yml up 
-ExecStop=/usr/bin/docker compose -f /home/ubuntu/.odyssey-cli/services/docker-compose.yml down
+ExecStart=/usr/bin/docker compose -f {{ .Config.ComposeFile }} up 
+ExecStop=/usr/bin/docker compose -f {{ .Config.ComposeFile }} down
 
 [Install]
 WantedBy=multi-user.target
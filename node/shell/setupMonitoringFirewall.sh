@@ -0,0 +1,18 @@
+#!/usr/bin/env bash
+set -e
+
+# Docker publishes ports through the FORWARD chain, so rules restricting them go to DOCKER-USER.
+# Only connections DNATed to the published ports are matched, leaving the traffic between
+# containers untouched. Rules are checked before being inserted so the script is idempotent.
+rule() {
+	sudo iptables -C DOCKER-USER "$@" 2>/dev/null || sudo iptables -I DOCKER-USER "$@"
+}
+
+{{- range $port := .FirewallPorts }}
+rule -p tcp -m conntrack --ctstate DNAT --ctorigdstport {{ $port }} --ctdir ORIGINAL -j DROP
+{{- range $cidr := $.AllowedCIDRs }}
+rule -p tcp -m conntrack --ctstate DNAT --ctorigdstport {{ $port }} --ctorigsrc {{ $cidr }} -j RETURN
+{{- end }}
+{{- end }}
+
+echo "Firewall rules applied successfully."
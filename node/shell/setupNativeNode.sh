@@ -0,0 +1,81 @@
+#!/usr/bin/env bash
+set -e
+{{if eq .PackageManager "dnf" "yum"}}
+rpm -q curl tar unzip >/dev/null 2>&1 || sudo {{ .PackageManager }} -y install curl tar unzip
+{{else}}
+export DEBIAN_FRONTEND=noninteractive
+dpkg -s curl tar unzip >/dev/null 2>&1 || (sudo apt-get -y update && sudo apt-get -y install curl tar unzip)
+{{end}}
+TMP_DIR=$(mktemp -d)
+trap 'rm -rf "$TMP_DIR"' EXIT
+#name:TASK [install odysseygo]
+curl -fsSL -o "$TMP_DIR/odysseygo.tar.gz" "{{ .OdysseyGoReleaseURL }}"
+tar xzf "$TMP_DIR/odysseygo.tar.gz" -C "$TMP_DIR"
+sudo install -m 0755 "$(find "$TMP_DIR" -type f -name odysseygo | head -n 1)" /usr/local/bin/odysseygo
+#name:TASK [install promtail]
+curl -fsSL -o "$TMP_DIR/promtail.zip" "{{ .PromtailReleaseURL }}"
+unzip -o -q "$TMP_DIR/promtail.zip" -d "$TMP_DIR"
+sudo install -m 0755 "$TMP_DIR/promtail-linux-{{ .Arch }}" /usr/local/bin/promtail
+#name:TASK [install node exporter]
+curl -fsSL -o "$TMP_DIR/node_exporter.tar.gz" "{{ .NodeExporterReleaseURL }}"
+tar xzf "$TMP_DIR/node_exporter.tar.gz" -C "$TMP_DIR"
+sudo install -m 0755 "$(find "$TMP_DIR" -type f -name node_exporter | head -n 1)" /usr/local/bin/node_exporter
+#name:TASK [provide systemd units]
+# the units bind mount the host folders where the containers mount them, so that the node
+# and promtail configs are the same in both runtime modes
+sudo mkdir -p /.odysseygo /logs /etc/promtail
+cat <<EOF | sudo tee /etc/systemd/system/odysseygo.service
+[Unit]
+Description=OdysseyGo
+After=network-online.target
+Wants=network-online.target
+
+[Service]
+User={{ .User }}
+Group={{ .User }}
+BindPaths={{ .Config.OdysseyGoDir }}:/.odysseygo
+ExecStart=/usr/local/bin/odysseygo --config-file=/.odysseygo/configs/node.json
+Restart=always
+RestartSec=5
+LimitNOFILE=65536
+
+[Install]
+WantedBy=multi-user.target
+EOF
+cat <<EOF | sudo tee /etc/systemd/system/promtail.service
+[Unit]
+Description=Promtail
+After=network-online.target odysseygo.service
+
+[Service]
+User={{ .User }}
+Group={{ .User }}
+BindReadOnlyPaths={{ .Config.OdysseyGoDir }}/logs:/logs
+BindReadOnlyPaths={{ .Config.ServicesDir }}/promtail:/etc/promtail
+PrivateTmp=true
+ExecStart=/usr/local/bin/promtail -config.file=/etc/promtail/promtail.yml
+Restart=always
+RestartSec=5
+
+[Install]
+WantedBy=multi-user.target
+EOF
+cat <<EOF | sudo tee /etc/systemd/system/node-exporter.service
+[Unit]
+Description=Prometheus Node Exporter
+After=network-online.target
+
+[Service]
+User=nobody
+ExecStart=/usr/local/bin/node_exporter --web.listen-address=:{{ .Config.MachineMetricsPort }}
+Restart=always
+RestartSec=5
+
+[Install]
+WantedBy=multi-user.target
+EOF
+sudo systemctl daemon-reload
+sudo systemctl enable odysseygo.service promtail.service node-exporter.service
+sudo systemctl restart odysseygo.service promtail.service node-exporter.service
+
+echo "OdysseyGo, promtail and node exporter services installed and started."
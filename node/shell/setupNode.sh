@@ -1,4 +1,27 @@
 #!/usr/bin/env bash
+{{if eq .PackageManager "dnf" "yum"}}
+if ! rpm -q git gcc golang >/dev/null 2>&1; then
+    sudo {{ .PackageManager }} -y install ca-certificates curl gcc git golang
+fi
+
+if ! rpm -q docker-ce docker-ce-cli containerd.io docker-buildx-plugin docker-compose-plugin >/dev/null 2>&1; then
+    DOCKER_REPO=https://download.docker.com/linux/centos/docker-ce.repo
+    if . /etc/os-release && [ "$ID" = "fedora" ]; then
+        DOCKER_REPO=https://download.docker.com/linux/fedora/docker-ce.repo
+    fi
+{{- if eq .PackageManager "dnf" }}
+    sudo dnf -y install dnf-plugins-core
+    # dnf5 replaced --add-repo with the addrepo subcommand
+    sudo dnf config-manager --add-repo "$DOCKER_REPO" || sudo dnf config-manager addrepo --from-repofile="$DOCKER_REPO"
+{{- else }}
+    sudo yum -y install yum-utils
+    sudo yum-config-manager --add-repo "$DOCKER_REPO"
+{{- end }}
+    sudo {{ .PackageManager }} -y install docker-ce docker-ce-cli containerd.io docker-buildx-plugin docker-compose-plugin
+fi
+
+sudo systemctl enable --now docker
+{{else}}
 export DEBIAN_FRONTEND=noninteractive
 
 if ! dpkg -s busybox-static software-properties-common >/dev/null 2>&1; then
@@ -15,7 +38,7 @@ if ! dpkg -s docker-ce docker-ce-cli containerd.io docker-buildx-plugin docker-c
     echo deb [arch=$(dpkg --print-architecture) signed-by=/etc/apt/keyrings/docker.asc] https://download.docker.com/linux/ubuntu $(. /etc/os-release && echo \"$VERSION_CODENAME\") stable | sudo tee /etc/apt/sources.list.d/docker.list >/dev/null
     sudo apt-get -y update && sudo apt-get -y install docker-ce docker-ce-cli containerd.io docker-buildx-plugin docker-compose-plugin docker-compose
 fi
-
-sudo usermod -aG docker ubuntu
-sudo chgrp ubuntu /var/run/docker.sock
+{{end}}
+sudo usermod -aG docker {{ .User }}
+sudo chgrp docker /var/run/docker.sock
 sudo chmod +rw /var/run/docker.sock
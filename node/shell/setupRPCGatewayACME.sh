@@ -0,0 +1,38 @@
+#!/usr/bin/env bash
+set -e
+GATEWAY_DIR={{ .Config.ServicesDir }}/rpc-gateway
+CERTBOT="/usr/bin/docker run --rm -v $GATEWAY_DIR/letsencrypt:/etc/letsencrypt -v $GATEWAY_DIR/acme:/var/www/acme {{ .CertbotImage }}"
+# the gateway serves the HTTP-01 challenges written into the acme folder
+if [ ! -f $GATEWAY_DIR/letsencrypt/live/{{ .ServerName }}/fullchain.pem ]; then
+	sudo $CERTBOT certonly --webroot -w /var/www/acme -d {{ .ServerName }} \
+		--email {{ .ACMEEmail }} --agree-tos --non-interactive{{ with .ACMEServer }} --server {{ . }}{{ end }}
+fi
+
+cat <<EOT | sudo tee /etc/systemd/system/rpc-gateway-certificate.service
+[Unit]
+Description=Renew the ACME certificate of the RPC gateway
+Requires=docker.service
+After=docker.service
+
+[Service]
+Type=oneshot
+ExecStart=/bin/sh -c '$CERTBOT renew --webroot -w /var/www/acme --quiet && /usr/bin/docker exec rpc-gateway nginx -s reload'
+EOT
+
+cat <<EOT | sudo tee /etc/systemd/system/rpc-gateway-certificate.timer
+[Unit]
+Description=Renew the ACME certificate of the RPC gateway twice a day
+
+[Timer]
+OnCalendar=*-*-* 00,12:00:00
+RandomizedDelaySec=1h
+Persistent=true
+
+[Install]
+WantedBy=timers.target
+EOT
+
+sudo systemctl daemon-reload
+sudo systemctl enable --now rpc-gateway-certificate.timer
+
+echo "RPC gateway ACME certificate of {{ .ServerName }} configured."
@@ -0,0 +1,22 @@
+#!/usr/bin/env bash
+set -e
+{{if eq .PackageManager "dnf" "yum"}}
+rpm -q chrony >/dev/null 2>&1 || sudo {{ .PackageManager }} -y install chrony
+CONF=/etc/chrony.conf
+SERVICE=chronyd
+{{else}}
+export DEBIAN_FRONTEND=noninteractive
+dpkg -s chrony >/dev/null 2>&1 || (sudo apt-get -y update && sudo apt-get -y install chrony)
+CONF=/etc/chrony/chrony.conf
+SERVICE=chrony
+{{end}}
+{{- range $server := .NTPServers }}
+grep -q "^server {{ $server }} " $CONF || echo "server {{ $server }} iburst" | sudo tee -a $CONF >/dev/null
+{{- end }}
+# step the clock on large offsets instead of slewing it for hours
+grep -q "^makestep" $CONF || echo "makestep 1.0 3" | sudo tee -a $CONF >/dev/null
+sudo systemctl enable $SERVICE
+sudo systemctl restart $SERVICE
+sudo chronyc -a makestep >/dev/null || true
+
+echo "Time synchronization configured with chrony."
@@ -0,0 +1,225 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/sftp"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+)
+
+// ErrSFTPUnavailable is returned when the SFTP subsystem of a node cannot be started, e.g.
+// on hardened hosts where it is disabled. File operations then fall back to streaming
+// through remote shell commands
+var ErrSFTPUnavailable = errors.New("SFTP is not available")
+
+// sftpState records whether the SFTP subsystem of a connection is unavailable. It is shared
+// by the copies of a node, and guarded by its lock as they run concurrently
+type sftpState struct {
+	lock        sync.Mutex
+	unavailable bool
+}
+
+func (s *sftpState) isUnavailable() bool {
+	if s == nil {
+		return false
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.unavailable
+}
+
+func (s *sftpState) setUnavailable() {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.unavailable = true
+}
+
+// newSftp starts an SFTP session on the node. When it fails, ErrSFTPUnavailable is returned
+// so that callers use their shell fallback. If the node runs no SFTP server, the following
+// calls return ErrSFTPUnavailable without trying again, until the node reconnects. Other
+// failures, e.g. a dropped connection, are not remembered
+func (h *Node) newSftp() (*sftp.Client, error) {
+	if h.sftpState.isUnavailable() {
+		return nil, ErrSFTPUnavailable
+	}
+	client, err := h.connection.NewSftp()
+	if err != nil {
+		if sftpSubsystemUnavailable(err) {
+			h.sftpState.setUnavailable()
+			h.Logger.Warnf("SFTP is not available on node %s, falling back to shell streaming: %s", h.NodeID, err)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrSFTPUnavailable, err)
+	}
+	return client, nil
+}
+
+// sftpSubsystemUnavailable tells whether err, returned by SSHClient.NewSftp, means that the
+// node runs no SFTP server: the subsystem request was rejected, or the server closed the
+// session before the SFTP handshake
+func sftpSubsystemUnavailable(err error) bool {
+	return errors.Is(err, ErrSFTPUnavailable) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		strings.Contains(err.Error(), "subsystem request failed")
+}
+
+// upload copies localFile to remoteFile through SFTP, or through shell streaming if SFTP
+// is not available on the node
+func (h *Node) upload(localFile string, remoteFile string, timeout time.Duration) error {
+	local, err := os.Open(localFile)
+	if err != nil {
+		return err
+	}
+	defer local.Close()
+	client, err := h.newSftp()
+	if errors.Is(err, ErrSFTPUnavailable) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		return h.shellUpload(ctx, local, remoteFile)
+	}
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	remote, err := client.Create(remoteFile)
+	if err != nil {
+		return err
+	}
+	defer remote.Close()
+	_, err = io.Copy(remote, local)
+	return err
+}
+
+// download copies remoteFile to localFile through SFTP, or through shell streaming if SFTP
+// is not available on the node
+func (h *Node) download(remoteFile string, localFile string, timeout time.Duration) error {
+	local, err := os.Create(localFile)
+	if err != nil {
+		return err
+	}
+	defer local.Close()
+	client, err := h.newSftp()
+	if errors.Is(err, ErrSFTPUnavailable) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		err = h.shellDownload(ctx, remoteFile, local)
+	} else {
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		var remote *sftp.File
+		remote, err = client.Open(remoteFile)
+		if err != nil {
+			return err
+		}
+		defer remote.Close()
+		_, err = io.Copy(local, remote)
+	}
+	if err != nil {
+		return err
+	}
+	return local.Sync()
+}
+
+// shellUpload streams local to remoteFile as base64 through the stdin of a remote decoder,
+// verifying the SHA-256 checksum of the written file. remoteFile is removed on mismatch
+func (h *Node) shellUpload(ctx context.Context, local io.Reader, remoteFile string) error {
+	quoted := shellQuote(remoteFile)
+	hasher := sha256.New()
+	var output bytes.Buffer
+	script := fmt.Sprintf("base64 -d > %s && sha256sum %s", quoted, quoted)
+	encoder := base64EncodeReader(local, hasher)
+	// stop encoding if the command fails before reading all of it
+	defer encoder.Close()
+	if err := h.streamCommand(ctx, script, encoder, &output); err != nil {
+		return err
+	}
+	remoteSum, err := parseSHA256SumOutput(output.Bytes())
+	if err != nil {
+		return err
+	}
+	if localSum := hex.EncodeToString(hasher.Sum(nil)); localSum != remoteSum {
+		_, _ = h.Commandf(nil, constants.SSHFileOpsTimeout, "rm -f %s", quoted)
+		return fmt.Errorf("%w: uploaded %s with checksum %s instead of %s", ErrTransferChecksumMismatch, remoteFile, remoteSum, localSum)
+	}
+	return nil
+}
+
+// shellDownload streams remoteFile as base64 through the stdout of a remote encoder into
+// local, verifying the SHA-256 checksum of the received content
+func (h *Node) shellDownload(ctx context.Context, remoteFile string, local io.Writer) error {
+	quoted := shellQuote(remoteFile)
+	hasher := sha256.New()
+	decoder, wait := base64DecodeWriter(io.MultiWriter(local, hasher))
+	err := h.streamCommand(ctx, "base64 "+quoted, nil, decoder)
+	_ = decoder.CloseWithError(err)
+	if decodeErr := wait(); err == nil {
+		err = decodeErr
+	}
+	if err != nil {
+		return err
+	}
+	output, err := h.Commandf(nil, constants.SSHFileOpsTimeout, "sha256sum %s", quoted)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, string(output))
+	}
+	remoteSum, err := parseSHA256SumOutput(output)
+	if err != nil {
+		return err
+	}
+	if localSum := hex.EncodeToString(hasher.Sum(nil)); localSum != remoteSum {
+		return fmt.Errorf("%w: downloaded %s with checksum %s instead of %s", ErrTransferChecksumMismatch, remoteFile, localSum, remoteSum)
+	}
+	return nil
+}
+
+// base64EncodeReader returns a reader of the base64 encoding of r, hashing the content of r
+// into hasher as it is read
+func base64EncodeReader(r io.Reader, hasher hash.Hash) *io.PipeReader {
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		encoder := base64.NewEncoder(base64.StdEncoding, pipeWriter)
+		_, err := io.Copy(encoder, io.TeeReader(r, hasher))
+		if err == nil {
+			err = encoder.Close()
+		}
+		_ = pipeWriter.CloseWithError(err)
+	}()
+	return pipeReader
+}
+
+// base64DecodeWriter returns a writer decoding base64, line wrapped or not, into w. The
+// writer must be closed once all the content is written, and wait returns the decoding error
+func base64DecodeWriter(w io.Writer) (*io.PipeWriter, func() error) {
+	pipeReader, pipeWriter := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(w, base64.NewDecoder(base64.StdEncoding, pipeReader))
+		// unblock the writer if decoding fails
+		_ = pipeReader.CloseWithError(err)
+		done <- err
+	}()
+	return pipeWriter, func() error {
+		return <-done
+	}
+}
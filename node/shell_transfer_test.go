@@ -0,0 +1,93 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/nodemock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBase64Stream_RoundTrip(t *testing.T) {
+	content := make([]byte, 1024*1024+7)
+	_, err := rand.Read(content)
+	require.NoError(t, err)
+
+	hasher := sha256.New()
+	var decoded bytes.Buffer
+	decoder, wait := base64DecodeWriter(&decoded)
+	_, err = io.Copy(decoder, base64EncodeReader(bytes.NewReader(content), hasher))
+	require.NoError(t, err)
+	require.NoError(t, decoder.Close())
+	require.NoError(t, wait())
+
+	assert.Equal(t, content, decoded.Bytes())
+	expectedSum := sha256.Sum256(content)
+	assert.Equal(t, expectedSum[:], hasher.Sum(nil))
+}
+
+func TestBase64DecodeWriter(t *testing.T) {
+	// base64 wraps its output lines
+	var decoded bytes.Buffer
+	decoder, wait := base64DecodeWriter(&decoded)
+	_, err := io.Copy(decoder, strings.NewReader("aGVsbG8g\nd29ybGQ=\n"))
+	require.NoError(t, err)
+	require.NoError(t, decoder.Close())
+	require.NoError(t, wait())
+	assert.Equal(t, "hello world", decoded.String())
+
+	decoder, wait = base64DecodeWriter(io.Discard)
+	_, _ = io.Copy(decoder, strings.NewReader("not base64!"))
+	_ = decoder.Close()
+	assert.Error(t, wait())
+}
+
+func TestNewSftp_Unavailable(t *testing.T) {
+	client := &nodemock.SSHClient{}
+	client.On("NewSftp").Return(nil, errors.New("ssh: subsystem request failed")).Once()
+	h := &Node{NodeID: "node-1"}
+	h.SetSSHClient(client)
+
+	_, err := h.newSftp()
+	require.ErrorIs(t, err, ErrSFTPUnavailable)
+	// the rejected subsystem is remembered until the node reconnects
+	_, err = h.newSftp()
+	require.ErrorIs(t, err, ErrSFTPUnavailable)
+	client.AssertExpectations(t)
+
+	h.SetSSHClient(client)
+	client.On("NewSftp").Return(nil, errors.New("ssh: subsystem request failed")).Once()
+	_, err = h.newSftp()
+	require.ErrorIs(t, err, ErrSFTPUnavailable)
+	client.AssertExpectations(t)
+}
+
+func TestNewSftp_TransientError(t *testing.T) {
+	client := &nodemock.SSHClient{}
+	client.On("NewSftp").Return(nil, errors.New("ssh: rejected: connect failed (open failed)")).Twice()
+	h := &Node{NodeID: "node-1"}
+	h.SetSSHClient(client)
+
+	for i := 0; i < 2; i++ {
+		_, err := h.newSftp()
+		require.ErrorIs(t, err, ErrSFTPUnavailable)
+	}
+	client.AssertExpectations(t)
+	assert.False(t, h.sftpState.isUnavailable())
+}
+
+func TestSftpSubsystemUnavailable(t *testing.T) {
+	assert.True(t, sftpSubsystemUnavailable(errors.New("ssh: subsystem request failed")))
+	assert.True(t, sftpSubsystemUnavailable(io.EOF))
+	assert.True(t, sftpSubsystemUnavailable(ErrSFTPUnavailable))
+	assert.False(t, sftpSubsystemUnavailable(errors.New("ssh: rejected: connect failed (open failed)")))
+}
@@ -15,8 +15,10 @@ import (
 	"time"
 
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/instrumentation"
 	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/monitoring"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/progress"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
 )
 
@@ -40,6 +42,35 @@ type scriptInputs struct {
 	CheckoutCommit       bool
 	LoadTestResultFile   string
 	GrafanaPkg           string
+	PackageManager       string
+	FirewallPorts        []string
+	AllowedCIDRs         []string
+	NTPServers           []string
+	Arch                 string
+
+	// settings applied by hardenHost.sh
+	SecurityUpdates bool
+	Fail2Ban        bool
+	SSHSettings     []string
+	Sysctls         []sysctlSetting
+
+	// release URLs of the binaries installed by setupNativeNode.sh
+	OdysseyGoReleaseURL    string
+	PromtailReleaseURL     string
+	NodeExporterReleaseURL string
+
+	// settings applied by setupRPCGatewayACME.sh
+	ServerName   string
+	ACMEEmail    string
+	ACMEServer   string
+	CertbotImage string
+
+	// User is the SSH user of the node, added to the docker group and running the docker
+	// compose service or the native systemd units, constants.RemoteHostUser when empty
+	User string
+
+	// Config sets the remote folders and ports used by the scripts, the defaults when zero
+	Config constants.Config
 }
 
 //go:embed shell/*.sh
@@ -52,23 +83,25 @@ func (h *Node) RunOverSSH(
 	timeout time.Duration,
 	scriptPath string,
 	templateVars scriptInputs,
-) error {
+) (err error) {
+	_, end := instrumentation.StartRequest(context.Background(), instrumentation.ComponentNode, scriptDesc, h.NodeID)
+	defer func() { end(err) }()
 	startTime := time.Now()
-	shellScript, err := script.ReadFile(scriptPath)
+	script, err := renderScript(scriptDesc, scriptPath, templateVars)
 	if err != nil {
 		return err
 	}
-	var script bytes.Buffer
-	t, err := template.New(scriptDesc).Parse(string(shellScript))
-	if err != nil {
+	if err := h.checkExecutionPolicy(scriptDesc, script); err != nil {
 		return err
 	}
-	err = t.Execute(&script, templateVars)
-	if err != nil {
-		return err
+	// fail early with the cause rather than inside the script
+	if usesSudo(script) {
+		if err := h.RequirePrivilege(); err != nil {
+			return fmt.Errorf("%s requires root privileges: %w", scriptDesc, err)
+		}
 	}
 
-	if output, err := h.Command(nil, timeout, script.String()); err != nil {
+	if output, err := h.Command(nil, timeout, script); err != nil {
 		return fmt.Errorf("%w: %s", err, string(output))
 	}
 	executionTime := time.Since(startTime)
@@ -76,13 +109,43 @@ func (h *Node) RunOverSSH(
 	return nil
 }
 
+// renderScript renders the embedded script template at scriptPath with templateVars
+func renderScript(scriptDesc string, scriptPath string, templateVars scriptInputs) (string, error) {
+	shellScript, err := script.ReadFile(scriptPath)
+	if err != nil {
+		return "", err
+	}
+	templateVars.Config = templateVars.Config.WithDefaults()
+	if templateVars.User == "" {
+		templateVars.User = constants.RemoteHostUser
+	}
+	var rendered bytes.Buffer
+	t, err := template.New(scriptDesc).Parse(string(shellScript))
+	if err != nil {
+		return "", err
+	}
+	if err := t.Execute(&rendered, templateVars); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
 // RunSSHSetupNode runs script to setup sdk dependencies on a remote host over SSH.
+// Both amd64 and arm64 Linux hosts using apt, dnf or yum are supported.
 func (h *Node) RunSSHSetupNode() error {
+	platform, err := h.DetectPlatform()
+	if err != nil {
+		return err
+	}
+	if err := platform.Supported(); err != nil {
+		return err
+	}
+	h.Logger.Infof("Detected %s (%s, %s) on %s", platform.DockerPlatform(), platform.Distro, platform.PackageManager, h.NodeID)
 	if err := h.RunOverSSH(
 		"Setup Node",
 		constants.SSHLongRunningScriptTimeout,
 		"shell/setupNode.sh",
-		scriptInputs{},
+		scriptInputs{PackageManager: string(platform.PackageManager), User: h.sshUser()},
 	); err != nil {
 		return err
 	}
@@ -99,7 +162,7 @@ func (h *Node) RunSSHSetupDockerService() error {
 			"Setup Docker Service",
 			constants.SSHLongRunningScriptTimeout,
 			"shell/setupDockerService.sh",
-			scriptInputs{},
+			scriptInputs{Config: h.config(), User: h.sshUser()},
 		)
 	} else {
 		// no need to setup docker service
@@ -109,18 +172,49 @@ func (h *Node) RunSSHSetupDockerService() error {
 
 // RunSSHRestartOdysseygo runs script to restart odysseygo
 func (h *Node) RunSSHRestartOdysseygo() error {
-	remoteComposeFile := utils.GetRemoteComposeFile()
+	if h.RuntimeMode == SystemdRuntime {
+		return h.systemctlOdysseyGo("restart")
+	}
+	remoteComposeFile := h.config().ComposeFile()
 	return h.RestartDockerComposeService(remoteComposeFile, constants.ServiceOdysseygo, constants.SSHLongRunningScriptTimeout)
 }
 
 // RunSSHUpgradeOdysseygo runs script to upgrade odysseygo
 func (h *Node) RunSSHUpgradeOdysseygo(odysseyGoVersion string) error {
+	tracker := progress.NewTracker(h.Progress, h.NodeID, 2)
+	if err := h.upgradeOdysseygo(odysseyGoVersion, tracker); err != nil {
+		return err
+	}
+	tracker.Done(fmt.Sprintf("odysseygo %s set up", odysseyGoVersion))
+	return nil
+}
+
+// UpgradeOdysseyGo upgrades odysseygo to odysseyGoVersion and waits up to healthTimeout for it
+// to be healthy, reporting progress to h.Progress
+func (h *Node) UpgradeOdysseyGo(odysseyGoVersion string, healthTimeout time.Duration) error {
+	tracker := progress.NewTracker(h.Progress, h.NodeID, 3)
+	if err := h.upgradeOdysseygo(odysseyGoVersion, tracker); err != nil {
+		return err
+	}
+	tracker.Step(progress.StageHealth, "waiting for odysseygo to be healthy")
+	if err := h.WaitForOdysseyGoHealth(healthTimeout); err != nil {
+		return err
+	}
+	tracker.Done(fmt.Sprintf("odysseygo %s healthy", odysseyGoVersion))
+	return nil
+}
+
+func (h *Node) upgradeOdysseygo(odysseyGoVersion string, tracker *progress.Tracker) error {
+	if h.RuntimeMode == SystemdRuntime {
+		return h.upgradeNativeNode(odysseyGoVersion, tracker)
+	}
 	withMonitoring, err := h.WasNodeSetupWithMonitoring()
 	if err != nil {
 		return err
 	}
 
-	if err := h.ComposeOverSSH("Compose Node",
+	tracker.Step(progress.StageCompose, fmt.Sprintf("setting up odysseygo %s", odysseyGoVersion))
+	changed, err := h.composeOverSSH("Compose Node",
 		constants.SSHScriptTimeout,
 		"templates/odysseygo.docker-compose.yml",
 		dockerComposeInputs{
@@ -130,20 +224,32 @@ func (h *Node) RunSSHUpgradeOdysseygo(odysseyGoVersion string) error {
 			E2E:              utils.IsE2E(),
 			E2EIP:            utils.E2EConvertIP(h.IP),
 			E2ESuffix:        utils.E2ESuffix(h.IP),
-		}); err != nil {
+		})
+	if err != nil {
 		return err
 	}
+	if !changed {
+		h.Logger.Infof("odysseygo %s already set up on %s, skipping restart", odysseyGoVersion, h.NodeID)
+		return nil
+	}
+	tracker.Step(progress.StageRestart, "restarting odysseygo")
 	return h.RestartDockerCompose(constants.SSHLongRunningScriptTimeout)
 }
 
 // RunSSHStartOdysseygo runs script to start odysseygo
 func (h *Node) RunSSHStartOdysseygo() error {
-	return h.StartDockerComposeService(utils.GetRemoteComposeFile(), constants.ServiceOdysseygo, constants.SSHLongRunningScriptTimeout)
+	if h.RuntimeMode == SystemdRuntime {
+		return h.systemctlOdysseyGo("start")
+	}
+	return h.StartDockerComposeService(h.config().ComposeFile(), constants.ServiceOdysseygo, constants.SSHLongRunningScriptTimeout)
 }
 
 // RunSSHStopOdysseygo runs script to stop odysseygo
 func (h *Node) RunSSHStopOdysseygo() error {
-	return h.StopDockerComposeService(utils.GetRemoteComposeFile(), constants.ServiceOdysseygo, constants.SSHLongRunningScriptTimeout)
+	if h.RuntimeMode == SystemdRuntime {
+		return h.systemctlOdysseyGo("stop")
+	}
+	return h.StopDockerComposeService(h.config().ComposeFile(), constants.ServiceOdysseygo, constants.SSHLongRunningScriptTimeout)
 }
 
 // RunSSHUpgradeSubnetEVM runs script to upgrade subnet evm
@@ -154,72 +260,95 @@ func (h *Node) RunSSHUpgradeSubnetEVM(subnetEVMBinaryPath string) error {
 	return nil
 }
 
+// RunSSHSetupPrometheusConfig pushes the Prometheus config of the monitoring node h, along with
+// the TLS and basic auth files of h.MonitoringSecurity, if any
 func (h *Node) RunSSHSetupPrometheusConfig(odysseyGoPorts, machinePorts, loadTestPorts []string) error {
-	for _, folder := range remoteconfig.PrometheusFoldersToCreate() {
+	if err := h.MonitoringSecurity.Validate(); err != nil {
+		return err
+	}
+	for _, folder := range remoteconfig.PrometheusFoldersToCreate(h.config()) {
 		if err := h.MkdirAll(folder, constants.SSHFileOpsTimeout); err != nil {
 			return err
 		}
 	}
-	nodePrometheusConfigTemp := utils.GetRemoteComposeServicePath(constants.ServicePrometheus, "prometheus.yml")
+	nodePrometheusConfigTemp := h.config().ServicePath(constants.ServicePrometheus, "prometheus.yml")
 	promConfig, err := os.CreateTemp("", constants.ServicePrometheus)
 	if err != nil {
 		return err
 	}
 	defer os.Remove(promConfig.Name())
-	if err := monitoring.WritePrometheusConfig(promConfig.Name(), odysseyGoPorts, machinePorts, loadTestPorts); err != nil {
+	if err := monitoring.WritePrometheusConfigWithSecurity(promConfig.Name(), odysseyGoPorts, machinePorts, loadTestPorts, h.MonitoringSecurity, h.monitoringOptions()...); err != nil {
 		return err
 	}
-
-	return h.Upload(
+	if err := h.Upload(
 		promConfig.Name(),
 		nodePrometheusConfigTemp,
 		constants.SSHFileOpsTimeout,
-	)
+	); err != nil {
+		return err
+	}
+	return h.uploadPrometheusSecurityFiles(h.MonitoringSecurity)
 }
 
+// RunSSHSetupLokiConfig pushes the Loki config of the monitoring node h, along with the TLS
+// files of h.MonitoringSecurity, if any
 func (h *Node) RunSSHSetupLokiConfig(port int) error {
-	for _, folder := range remoteconfig.LokiFoldersToCreate() {
+	if err := h.MonitoringSecurity.Validate(); err != nil {
+		return err
+	}
+	for _, folder := range remoteconfig.LokiFoldersToCreate(h.config()) {
 		if err := h.MkdirAll(folder, constants.SSHFileOpsTimeout); err != nil {
 			return err
 		}
 	}
-	nodeLokiConfigTemp := utils.GetRemoteComposeServicePath(constants.ServiceLoki, "loki.yml")
+	nodeLokiConfigTemp := h.config().ServicePath(constants.ServiceLoki, "loki.yml")
 	lokiConfig, err := os.CreateTemp("", constants.ServiceLoki)
 	if err != nil {
 		return err
 	}
 	defer os.Remove(lokiConfig.Name())
-	if err := monitoring.WriteLokiConfig(lokiConfig.Name(), strconv.Itoa(port)); err != nil {
+	if err := monitoring.WriteLokiConfigWithSecurity(lokiConfig.Name(), strconv.Itoa(port), h.MonitoringSecurity, h.monitoringOptions()...); err != nil {
 		return err
 	}
-	return h.Upload(
+	if err := h.Upload(
 		lokiConfig.Name(),
 		nodeLokiConfigTemp,
 		constants.SSHFileOpsTimeout,
-	)
+	); err != nil {
+		return err
+	}
+	return h.uploadLokiSecurityFiles(h.MonitoringSecurity)
 }
 
+// RunSSHSetupPromtailConfig pushes the promtail config of h, sending logs to the Loki at
+// lokiIP:lokiPort with the TLS and tenant settings of h.MonitoringSecurity, if any
 func (h *Node) RunSSHSetupPromtailConfig(lokiIP string, lokiPort int, nodeID string, chainID string) error {
-	for _, folder := range remoteconfig.PromtailFoldersToCreate() {
+	if err := h.MonitoringSecurity.Validate(); err != nil {
+		return err
+	}
+	for _, folder := range remoteconfig.PromtailFoldersToCreate(h.config()) {
 		if err := h.MkdirAll(folder, constants.SSHFileOpsTimeout); err != nil {
 			return err
 		}
 	}
-	nodePromtailConfigTemp := utils.GetRemoteComposeServicePath(constants.ServicePromtail, "promtail.yml")
+	nodePromtailConfigTemp := h.config().ServicePath(constants.ServicePromtail, "promtail.yml")
 	promtailConfig, err := os.CreateTemp("", constants.ServicePromtail)
 	if err != nil {
 		return err
 	}
 	defer os.Remove(promtailConfig.Name())
 
-	if err := monitoring.WritePromtailConfig(promtailConfig.Name(), lokiIP, strconv.Itoa(lokiPort), lokiIP, nodeID, chainID); err != nil {
+	if err := monitoring.WritePromtailConfigWithSecurity(promtailConfig.Name(), lokiIP, strconv.Itoa(lokiPort), lokiIP, nodeID, chainID, h.MonitoringSecurity, h.monitoringOptions()...); err != nil {
 		return err
 	}
-	return h.Upload(
+	if err := h.Upload(
 		promtailConfig.Name(),
 		nodePromtailConfigTemp,
 		constants.SSHFileOpsTimeout,
-	)
+	); err != nil {
+		return err
+	}
+	return h.uploadPromtailSecurityFiles(h.MonitoringSecurity)
 }
 
 // RunSSHGetNewSubnetEVMRelease runs script to download new subnet evm
@@ -234,7 +363,7 @@ func (h *Node) RunSSHGetNewSubnetEVMRelease(subnetEVMReleaseURL, subnetEVMArchiv
 
 // RunSSHUploadStakingFiles uploads staking files to a remote host via SSH.
 func (h *Node) RunSSHUploadStakingFiles(keyPath string) error {
-	localStakingPath := "/home/ubuntu/.odysseygo/staking/"
+	localStakingPath := h.config().OdysseyGoPath("staking")
 	if err := h.MkdirAll(
 		localStakingPath,
 		constants.SSHFileOpsTimeout,
@@ -264,7 +393,7 @@ func (h *Node) RunSSHUploadStakingFiles(keyPath string) error {
 
 // RunSSHSetupMonitoringFolders sets up monitoring folders
 func (h *Node) RunSSHSetupMonitoringFolders() error {
-	for _, folder := range remoteconfig.RemoteFoldersToCreateMonitoring() {
+	for _, folder := range remoteconfig.RemoteFoldersToCreateMonitoring(h.config()) {
 		if err := h.MkdirAll(folder, constants.SSHFileOpsTimeout); err != nil {
 			return err
 		}
@@ -277,7 +406,7 @@ func (h *Node) RunSSHSetupMonitoringFolders() error {
 // logs
 // Cloud functionality has been removed from this SDK.
 func (h *Node) MonitorNodes(ctx context.Context, targets []Node, chainID string) error {
-	return fmt.Errorf("cloud functionality has been removed from this SDK. Please use local monitoring setup instead")
+	return fmt.Errorf("%w. Please use local monitoring setup instead", ErrCloudRemoved)
 }
 
 // SyncSubnets reconfigures odysseygo to sync subnets
@@ -313,7 +442,7 @@ func (h *Node) SyncSubnets(subnetsToTrack []string) error {
 
 func (h *Node) RunSSHCopyMonitoringDashboards(monitoringDashboardPath string) error {
 	// TODO: download dashboards from github instead
-	remoteDashboardsPath := utils.GetRemoteComposeServicePath("grafana", "dashboards")
+	remoteDashboardsPath := h.config().ServicePath("grafana", "dashboards")
 	if !utils.DirectoryExists(monitoringDashboardPath) {
 		return fmt.Errorf("%s does not exist", monitoringDashboardPath)
 	}
@@ -335,7 +464,7 @@ func (h *Node) RunSSHCopyMonitoringDashboards(monitoringDashboardPath string) er
 		}
 	}
 	if composeFileExists(*h) {
-		return h.RestartDockerComposeService(utils.GetRemoteComposeFile(), constants.ServiceGrafana, constants.SSHScriptTimeout)
+		return h.RestartDockerComposeService(h.config().ComposeFile(), constants.ServiceGrafana, constants.SSHScriptTimeout)
 	}
 	return nil
 }
@@ -0,0 +1,101 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/melbahja/goph"
+	"github.com/pkg/sftp"
+)
+
+// ErrUnsupportedSSHClient is returned by the operations that need the goph client made by
+// Connect when the node uses another SSHClient, see SetSSHClient
+var ErrUnsupportedSSHClient = errors.New("operation not supported by the SSH client")
+
+// SSHClient is the SSH connection a Node runs its commands and file transfers through.
+// Connect uses an SSHClient backed by goph. Set another one with SetSSHClient, e.g. a
+// nodemock.SSHClient to unit test code driving nodes without hosts
+type SSHClient interface {
+	// Run runs script on the remote host with env, reading stdin and writing the script
+	// output to stdout and stderr. The script is interrupted when ctx is done
+	Run(ctx context.Context, env []string, script string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error
+
+	// NewSftp starts an SFTP session. When it fails, the file operations of the Node fall
+	// back to streaming through Run
+	NewSftp() (*sftp.Client, error)
+
+	// DialTCP opens a TCP connection to addr from the remote host
+	DialTCP(addr *net.TCPAddr) (net.Conn, error)
+
+	// RemoteAddr is the address of the remote host
+	RemoteAddr() net.Addr
+
+	// Close closes the connection
+	Close() error
+}
+
+// gophSSHClient is the SSHClient made by Connect
+type gophSSHClient struct {
+	client *goph.Client
+}
+
+func (c gophSSHClient) Run(ctx context.Context, env []string, script string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	cmd, err := c.client.CommandContext(ctx, "", script)
+	if err != nil {
+		return err
+	}
+	defer cmd.Session.Close()
+	cmd.Env = env
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return cmd.Run()
+}
+
+func (c gophSSHClient) NewSftp() (*sftp.Client, error) {
+	return c.client.NewSftp()
+}
+
+func (c gophSSHClient) DialTCP(addr *net.TCPAddr) (net.Conn, error) {
+	return c.client.DialTCP("tcp", nil, addr)
+}
+
+func (c gophSSHClient) RemoteAddr() net.Addr {
+	return c.client.RemoteAddr()
+}
+
+func (c gophSSHClient) Close() error {
+	return c.client.Close()
+}
+
+// SetSSHClient makes h run its commands and file transfers through client instead of
+// connecting to h.IP
+func (h *Node) SetSSHClient(client SSHClient) {
+	h.connection = client
+	h.sftpState = &sftpState{}
+}
+
+// combinedOutput collects the stdout and stderr of a command, written concurrently
+type combinedOutput struct {
+	lock sync.Mutex
+	buf  []byte
+}
+
+func (o *combinedOutput) Write(p []byte) (int, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.buf = append(o.buf, p...)
+	return len(p), nil
+}
+
+func (o *combinedOutput) Bytes() []byte {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	return o.buf
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/nodemock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var _ SSHClient = (*nodemock.SSHClient)(nil)
+
+func TestNode_Command_SSHClient(t *testing.T) {
+	client := nodemock.NewSSHClient()
+	client.OnScript("uname -m", "x86_64\n", nil).Once()
+	client.OnScript("false", "failed\n", errors.New("exit status 1")).Once()
+	h := &Node{NodeID: "node-1"}
+	h.SetSSHClient(client)
+	require.True(t, h.Connected())
+
+	output, err := h.Command(nil, time.Second, "uname -m")
+	require.NoError(t, err)
+	assert.Equal(t, "x86_64\n", string(output))
+
+	output, err = h.Command(nil, time.Second, "false")
+	require.Error(t, err)
+	assert.Equal(t, "failed\n", string(output))
+	client.AssertExpectations(t)
+}
+
+func TestNode_FileExists_SSHClient(t *testing.T) {
+	client := nodemock.NewSSHClient()
+	client.OnScript(mock.MatchedBy(func(script string) bool {
+		return strings.Contains(script, "/home/ubuntu/exists")
+	}), "yes\n", nil)
+	client.OnScript(mock.MatchedBy(func(script string) bool {
+		return strings.Contains(script, "/home/ubuntu/missing")
+	}), "", nil)
+	h := &Node{NodeID: "node-1"}
+	h.SetSSHClient(client)
+
+	exists, err := h.FileExists("/home/ubuntu/exists")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = h.FileExists("/home/ubuntu/missing")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestNode_UploadBytes_SSHClient(t *testing.T) {
+	var uploaded []byte
+	client := nodemock.NewSSHClient()
+	client.On("Run", mock.Anything, mock.Anything, mock.MatchedBy(func(script string) bool {
+		return strings.HasPrefix(script, "base64 -d > ")
+	}), mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		encoded, err := io.ReadAll(args.Get(3).(io.Reader))
+		require.NoError(t, err)
+		uploaded, err = base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\n", ""))
+		require.NoError(t, err)
+		sum := sha256.Sum256(uploaded)
+		_, _ = fmt.Fprintf(args.Get(4).(io.Writer), "%s  /home/ubuntu/file\n", hex.EncodeToString(sum[:]))
+	}).Return(nil).Once()
+	h := &Node{NodeID: "node-1"}
+	h.SetSSHClient(client)
+
+	require.NoError(t, h.UploadBytes([]byte("content"), "/home/ubuntu/file", time.Second))
+	assert.Equal(t, "content", string(uploaded))
+	client.AssertExpectations(t)
+}
+
+func TestNode_Cmd_SSHClient(t *testing.T) {
+	h := &Node{NodeID: "node-1"}
+	h.SetSSHClient(nodemock.NewSSHClient())
+	assert.Nil(t, h.GetConnection())
+	assert.Nil(t, h.GetSSHClient())
+	_, err := h.Cmd(context.Background(), "", "ls")
+	require.ErrorIs(t, err, ErrUnsupportedSSHClient)
+	require.NoError(t, h.Disconnect())
+}
+
+// exitStatusError is an error carrying an exit status, as the ExitError of an SSH session
+type exitStatusError int
+
+func (e exitStatusError) Error() string {
+	return fmt.Sprintf("Process exited with status %d", int(e))
+}
+
+func (e exitStatusError) ExitStatus() int {
+	return int(e)
+}
+
+func TestNode_RunCommand_SSHClient(t *testing.T) {
+	client := nodemock.NewSSHClient()
+	client.On("Run", mock.Anything, mock.Anything, "make", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		_, _ = io.WriteString(args.Get(4).(io.Writer), "building\n")
+		_, _ = io.WriteString(args.Get(5).(io.Writer), "error: no rule\n")
+	}).Return(exitStatusError(2)).Once()
+	client.OnScript("uname -m", "x86_64\n", nil).Once()
+	client.OnScript("sleep 60", "", errors.New("connection lost")).Once()
+	h := &Node{NodeID: "node-1"}
+	h.SetSSHClient(client)
+
+	result, err := h.RunCommand(nil, time.Second, "make")
+	require.ErrorIs(t, err, ErrRemoteCommandFailed)
+	var commandErr *RemoteCommandError
+	require.ErrorAs(t, err, &commandErr)
+	assert.Equal(t, 2, commandErr.ExitCode)
+	assert.Equal(t, "building\n", string(result.Stdout))
+	assert.Equal(t, "error: no rule\n", string(result.Stderr))
+	assert.Equal(t, 2, result.ExitCode)
+
+	result, err = h.RunCommand(nil, time.Second, "uname -m")
+	require.NoError(t, err)
+	assert.Equal(t, "x86_64\n", string(result.Stdout))
+	assert.Empty(t, result.Stderr)
+	assert.Equal(t, 0, result.ExitCode)
+
+	// errors not coming from the command are not remote command failures
+	result, err = h.RunCommand(nil, time.Second, "sleep 60")
+	require.ErrorContains(t, err, "connection lost")
+	require.NotErrorIs(t, err, ErrRemoteCommandFailed)
+	assert.Equal(t, -1, result.ExitCode)
+	client.AssertExpectations(t)
+}
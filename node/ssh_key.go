@@ -0,0 +1,128 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+)
+
+// sshKeyComment is the comment of the SSH keys generated by GenerateSSHKeyPair
+const sshKeyComment = "odyssey-tooling-sdk"
+
+var ErrSSHKeyExists = errors.New("SSH key already exists")
+
+// GenerateSSHKeyPair generates an ed25519 SSH key pair, writing the private key to path and
+// the public key to path.pub, and returns the public key in authorized_keys format.
+// Existing keys are never overwritten: ErrSSHKeyExists is returned instead.
+//
+// Cloud key pair registration has been removed from this SDK: use Node.AuthorizeSSHKey, or
+// register the public key with the provider, to allow the key on the nodes
+func GenerateSSHKeyPair(path string) (string, error) {
+	for _, keyPath := range []string{path, path + ".pub"} {
+		if _, err := os.Stat(keyPath); err == nil {
+			return "", fmt.Errorf("%w: %s", ErrSSHKeyExists, keyPath)
+		}
+	}
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	privatePEM, err := ssh.MarshalPrivateKey(privateKey, sshKeyComment)
+	if err != nil {
+		return "", err
+	}
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPublicKey))) + " " + sshKeyComment
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, constants.WriteReadUserOnlyPerms)
+	if err != nil {
+		return "", err
+	}
+	if _, err := file.Write(pem.EncodeToMemory(privatePEM)); err != nil {
+		_ = file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path+".pub", []byte(authorizedKey+"\n"), constants.WriteReadReadPerms); err != nil {
+		return "", err
+	}
+	return authorizedKey, nil
+}
+
+// ReadSSHPublicKey returns the public key of the private key at path in authorized_keys
+// format, reading path.pub if present
+func ReadSSHPublicKey(path string) (string, error) {
+	if authorizedKey, err := os.ReadFile(path + ".pub"); err == nil {
+		return strings.TrimSpace(string(authorizedKey)), nil
+	}
+	privateKey, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	signer, err := ssh.ParsePrivateKey(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse SSH private key %s: %w", path, err)
+	}
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey()))), nil
+}
+
+// AuthorizeSSHKey adds authorizedKey to the authorized keys of the SSH user of the node,
+// unless already present
+func (h *Node) AuthorizeSSHKey(authorizedKey string) error {
+	authorizedKey = strings.TrimSpace(authorizedKey)
+	// the key is quoted in the script below
+	if strings.ContainsAny(authorizedKey, "\n'") {
+		return fmt.Errorf("invalid SSH public key %q", authorizedKey)
+	}
+	if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(authorizedKey)); err != nil {
+		return fmt.Errorf("invalid SSH public key %q: %w", authorizedKey, err)
+	}
+	script := strings.Join([]string{
+		"set -e",
+		"mkdir -p ~/.ssh && chmod 700 ~/.ssh",
+		"touch ~/.ssh/authorized_keys && chmod 600 ~/.ssh/authorized_keys",
+		fmt.Sprintf("grep -qxF '%s' ~/.ssh/authorized_keys || echo '%s' >> ~/.ssh/authorized_keys", authorizedKey, authorizedKey),
+	}, "\n")
+	if output, err := h.Command(nil, constants.SSHScriptTimeout, script); err != nil {
+		return fmt.Errorf("failed to authorize SSH key on %s: %w: %s", h.NodeID, err, string(output))
+	}
+	return nil
+}
+
+// SetupSSHKey makes the node use the SSH key at path, generating it with GenerateSSHKeyPair
+// if it does not exist yet. The key is authorized on the node through the current
+// connection, e.g. made with the SSH agent, and path is stored into the node SSHConfig so
+// that the next connections use it
+func (h *Node) SetupSSHKey(path string) error {
+	authorizedKey, err := ReadSSHPublicKey(path)
+	if errors.Is(err, os.ErrNotExist) {
+		authorizedKey, err = GenerateSSHKeyPair(path)
+	}
+	if err != nil {
+		return err
+	}
+	if err := h.AuthorizeSSHKey(authorizedKey); err != nil {
+		return err
+	}
+	h.SSHConfig.PrivateKeyPath = path
+	return nil
+}
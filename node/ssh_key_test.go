@@ -0,0 +1,47 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/melbahja/goph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSSHKeyPair(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "id_ed25519")
+	authorizedKey, err := GenerateSSHKeyPair(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(authorizedKey, "ssh-ed25519 "))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	// the private key can be used to connect to nodes
+	_, err = goph.Key(path, "")
+	require.NoError(t, err)
+
+	publicKey, err := ReadSSHPublicKey(path)
+	require.NoError(t, err)
+	assert.Equal(t, authorizedKey, publicKey)
+	// the public key is derived from the private key when path.pub is missing
+	require.NoError(t, os.Remove(path+".pub"))
+	publicKey, err = ReadSSHPublicKey(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(authorizedKey, publicKey))
+
+	_, err = GenerateSSHKeyPair(path)
+	assert.ErrorIs(t, err, ErrSSHKeyExists)
+}
+
+func TestAuthorizeSSHKey_InvalidKey(t *testing.T) {
+	h := &Node{}
+	assert.ErrorContains(t, h.AuthorizeSSHKey("not a key"), "invalid SSH public key")
+	assert.ErrorContains(t, h.AuthorizeSSHKey("ssh-ed25519 AAAA' && rm -rf ~ && echo '"), "invalid SSH public key")
+}
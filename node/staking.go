@@ -18,8 +18,8 @@ import (
 //   - The file containing the node's BLS information: signer.key (more information can be found at https://docs.dione.network/cross-chain/odyssey-warp-messaging/deep-dive#bls-multi-signatures-with-public-key-aggregation)
 //
 // and stores them in the provided directory in argument in local machine
-// and subsequently uploads these files into the remote host in /home/ubuntu/.odysseygo/staking/
-// directory
+// and subsequently uploads these files into the staking directory of the odysseygo directory of
+// the remote host, /home/ubuntu/.odysseygo/staking/ by default
 func (h *Node) ProvideStakingFiles(keyPath string) error {
 	if nodeID, err := GenerateStakingFiles(keyPath); err != nil {
 		return err
@@ -0,0 +1,212 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/vm"
+	"github.com/DioneProtocol/odysseygo/ids"
+)
+
+var (
+	ErrEmptyBlockchainID = errors.New("blockchain ID is not provided")
+	ErrEmptyChainConfig  = errors.New("chain config has no files to upload")
+)
+
+const trackSubnetsKey = "track-subnets"
+
+// ChainConfig holds the files of a blockchain uploaded to the chain config dir of odysseygo.
+// Empty files are not uploaded
+type ChainConfig struct {
+	// BlockchainID is the ID of the blockchain the files belong to
+	BlockchainID ids.ID
+
+	// Config is the content of config.json, the VM config of the blockchain
+	Config []byte
+
+	// Upgrade is the content of upgrade.json, the network upgrades of the blockchain
+	Upgrade []byte
+
+	// Genesis is the content of genesis.json, the genesis of the blockchain
+	Genesis []byte
+
+	// Alias is the friendly name of the blockchain and its VM, e.g. the chain name, used in
+	// RPC URLs such as /ext/bc/<alias>/rpc. The blockchain is not aliased when empty
+	Alias string
+
+	// VMID is the VM of the blockchain, required to set Alias
+	VMID ids.ID
+}
+
+// Validate checks that the blockchain ID and at least one file or the alias are set
+func (c ChainConfig) Validate() error {
+	if c.BlockchainID == ids.Empty {
+		return ErrEmptyBlockchainID
+	}
+	if c.Alias != "" {
+		return c.chainAlias().Validate()
+	}
+	if len(c.files(constants.Config{})) == 0 {
+		return fmt.Errorf("%w: %s", ErrEmptyChainConfig, c.BlockchainID)
+	}
+	return nil
+}
+
+func (c ChainConfig) chainAlias() ChainAlias {
+	return ChainAlias{Name: c.Alias, VMID: c.VMID, BlockchainID: c.BlockchainID}
+}
+
+// WithVMConfig returns a copy of c whose Config is the config.json of the Subnet-EVM
+// config vmConfig
+func (c ChainConfig) WithVMConfig(vmConfig vm.ChainConfig) (ChainConfig, error) {
+	configBytes, err := vmConfig.Marshal()
+	if err != nil {
+		return c, err
+	}
+	c.Config = configBytes
+	return c, nil
+}
+
+// files maps the remote paths of the chain config files, in the odysseygo directory of config,
+// to their content
+func (c ChainConfig) files(config constants.Config) map[string][]byte {
+	dir := remoteconfig.GetRemoteOdysseyChainConfigDir(config, c.BlockchainID.String())
+	files := map[string][]byte{}
+	for name, content := range map[string][]byte{
+		"config.json":  c.Config,
+		"upgrade.json": c.Upgrade,
+		"genesis.json": c.Genesis,
+	} {
+		if len(content) > 0 {
+			files[filepath.Join(dir, name)] = content
+		}
+	}
+	return files
+}
+
+// ConfigureSubnet makes odysseygo track subnetID, uploads the files of chainConfigs and sets
+// their aliases as ConfigureAliases does, then restarts odysseygo if needed to apply the
+// changes. Returns whether odysseygo was restarted
+func (h *Node) ConfigureSubnet(subnetID ids.ID, chainConfigs []ChainConfig) (bool, error) {
+	if !isOdysseyGoNode(*h) {
+		return false, fmt.Errorf("%s is not a odysseygo node", h.NodeID)
+	}
+	for _, chainConfig := range chainConfigs {
+		if err := chainConfig.Validate(); err != nil {
+			return false, err
+		}
+	}
+	changed, err := h.trackSubnet(subnetID)
+	if err != nil {
+		return false, err
+	}
+	for _, chainConfig := range chainConfigs {
+		uploaded, err := h.uploadChainConfig(chainConfig)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || uploaded
+	}
+	aliases := []ChainAlias{}
+	for _, chainConfig := range chainConfigs {
+		if chainConfig.Alias != "" {
+			aliases = append(aliases, chainConfig.chainAlias())
+		}
+	}
+	vmAliasesChanged, chainAliasesChanged, err := h.uploadAliases(aliases)
+	if err != nil {
+		return false, err
+	}
+	if !changed && !h.aliasesNeedRestart(aliases, vmAliasesChanged, chainAliasesChanged) {
+		return false, nil
+	}
+	// odysseygo only reads its configs on startup
+	if err := h.RunSSHRestartOdysseygo(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetChainConfig uploads vmConfig as the config.json of the Subnet-EVM blockchain
+// blockchainID, then restarts odysseygo if the file changed to apply it. Returns whether
+// odysseygo was restarted
+func (h *Node) SetChainConfig(blockchainID ids.ID, vmConfig vm.ChainConfig) (bool, error) {
+	if !isOdysseyGoNode(*h) {
+		return false, fmt.Errorf("%s is not a odysseygo node", h.NodeID)
+	}
+	chainConfig, err := ChainConfig{BlockchainID: blockchainID}.WithVMConfig(vmConfig)
+	if err != nil {
+		return false, err
+	}
+	if err := chainConfig.Validate(); err != nil {
+		return false, err
+	}
+	changed, err := h.uploadChainConfig(chainConfig)
+	if err != nil || !changed {
+		return false, err
+	}
+	if err := h.RunSSHRestartOdysseygo(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// uploadChainConfig uploads the files of chainConfig to its chain config dir, returning
+// whether a file changed
+func (h *Node) uploadChainConfig(chainConfig ChainConfig) (bool, error) {
+	if err := h.MkdirAll(remoteconfig.GetRemoteOdysseyChainConfigDir(h.config(), chainConfig.BlockchainID.String()), constants.SSHFileOpsTimeout); err != nil {
+		return false, err
+	}
+	changed := false
+	for remoteFile, content := range chainConfig.files(h.config()) {
+		uploaded, err := h.UploadBytesIfChanged(content, remoteFile, constants.SSHFileOpsTimeout, true)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || uploaded
+	}
+	return changed, nil
+}
+
+// trackSubnet adds subnetID to the tracked subnets of the remote odysseygo config,
+// returning whether the config changed
+func (h *Node) trackSubnet(subnetID ids.ID) (bool, error) {
+	nodeConfig, err := h.GetOdysseyGoConfigData()
+	if err != nil {
+		return false, err
+	}
+	if !addTrackedSubnet(nodeConfig, subnetID.String()) {
+		return false, nil
+	}
+	nodeConfigBytes, err := json.MarshalIndent(nodeConfig, "", "\t")
+	if err != nil {
+		return false, err
+	}
+	return h.UploadBytesIfChanged(nodeConfigBytes, remoteconfig.GetRemoteOdysseyNodeConfig(h.config()), constants.SSHFileOpsTimeout, true)
+}
+
+// addTrackedSubnet adds subnetID to the comma separated track-subnets of nodeConfig,
+// returning false if it was already tracked
+func addTrackedSubnet(nodeConfig map[string]interface{}, subnetID string) bool {
+	trackSubnets, _ := nodeConfig[trackSubnetsKey].(string)
+	subnets := []string{}
+	for _, subnet := range strings.Split(trackSubnets, ",") {
+		subnet = strings.TrimSpace(subnet)
+		if subnet == subnetID {
+			return false
+		}
+		if subnet != "" {
+			subnets = append(subnets, subnet)
+		}
+	}
+	nodeConfig[trackSubnetsKey] = strings.Join(append(subnets, subnetID), ",")
+	return true
+}
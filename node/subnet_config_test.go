@@ -0,0 +1,118 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/vm"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainConfig_Validate(t *testing.T) {
+	blockchainID := ids.ID{1}
+	tests := []struct {
+		name        string
+		chainConfig ChainConfig
+		expectedErr error
+	}{
+		{name: "config only", chainConfig: ChainConfig{BlockchainID: blockchainID, Config: []byte(`{}`)}},
+		{name: "genesis only", chainConfig: ChainConfig{BlockchainID: blockchainID, Genesis: []byte(`{}`)}},
+		{name: "empty blockchain ID", chainConfig: ChainConfig{Config: []byte(`{}`)}, expectedErr: ErrEmptyBlockchainID},
+		{name: "no files", chainConfig: ChainConfig{BlockchainID: blockchainID}, expectedErr: ErrEmptyChainConfig},
+		{name: "alias only", chainConfig: ChainConfig{BlockchainID: blockchainID, Alias: "mychain", VMID: ids.ID{2}}},
+		{name: "alias without VM ID", chainConfig: ChainConfig{BlockchainID: blockchainID, Alias: "mychain"}, expectedErr: ErrEmptyVMID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.chainConfig.Validate()
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestChainConfig_Files(t *testing.T) {
+	blockchainID := ids.ID{1}
+	chainDir := "/home/ubuntu/.odysseygo/configs/chains/" + blockchainID.String()
+	files := ChainConfig{
+		BlockchainID: blockchainID,
+		Config:       []byte(`{"log-level":"info"}`),
+		Genesis:      []byte(`{"config":{}}`),
+	}.files(constants.Config{})
+	assert.Equal(t, map[string][]byte{
+		chainDir + "/config.json":  []byte(`{"log-level":"info"}`),
+		chainDir + "/genesis.json": []byte(`{"config":{}}`),
+	}, files)
+}
+
+func TestChainConfig_WithVMConfig(t *testing.T) {
+	chainConfig, err := ChainConfig{BlockchainID: ids.ID{1}}.WithVMConfig(vm.ChainConfig{LogLevel: "info"})
+	require.NoError(t, err)
+	require.NoError(t, chainConfig.Validate())
+	assert.JSONEq(t, `{"log-level":"info"}`, string(chainConfig.Config))
+
+	_, err = ChainConfig{BlockchainID: ids.ID{1}}.WithVMConfig(vm.ChainConfig{LogLevel: "verbose"})
+	require.ErrorIs(t, err, vm.ErrInvalidChainConfig)
+}
+
+func TestAddTrackedSubnet(t *testing.T) {
+	tests := []struct {
+		name            string
+		nodeConfig      map[string]interface{}
+		expectedChanged bool
+		expectedTracked string
+	}{
+		{
+			name:            "no tracked subnets",
+			nodeConfig:      map[string]interface{}{"network-id": "testnet"},
+			expectedChanged: true,
+			expectedTracked: "subnet-b",
+		},
+		{
+			name:            "empty tracked subnets",
+			nodeConfig:      map[string]interface{}{trackSubnetsKey: ""},
+			expectedChanged: true,
+			expectedTracked: "subnet-b",
+		},
+		{
+			name:            "other subnets tracked",
+			nodeConfig:      map[string]interface{}{trackSubnetsKey: "subnet-a, subnet-c"},
+			expectedChanged: true,
+			expectedTracked: "subnet-a,subnet-c,subnet-b",
+		},
+		{
+			name:            "already tracked",
+			nodeConfig:      map[string]interface{}{trackSubnetsKey: "subnet-a,subnet-b"},
+			expectedTracked: "subnet-a,subnet-b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedChanged, addTrackedSubnet(tt.nodeConfig, "subnet-b"))
+			assert.Equal(t, tt.expectedTracked, tt.nodeConfig[trackSubnetsKey])
+		})
+	}
+	// other settings are preserved
+	nodeConfig := map[string]interface{}{"network-id": "testnet", "bootstrap-ips": "10.0.0.1:9651"}
+	require.True(t, addTrackedSubnet(nodeConfig, "subnet-b"))
+	assert.Equal(t, "testnet", nodeConfig["network-id"])
+	assert.Equal(t, "10.0.0.1:9651", nodeConfig["bootstrap-ips"])
+}
+
+func TestConfigureSubnet_Validation(t *testing.T) {
+	monitoringNode := &Node{NodeID: "monitoring", Roles: []SupportedRole{Monitor}}
+	_, err := monitoringNode.ConfigureSubnet(ids.ID{1}, nil)
+	assert.ErrorContains(t, err, "is not a odysseygo node")
+
+	validatorNode := &Node{NodeID: "validator", Roles: []SupportedRole{Validator}}
+	_, err = validatorNode.ConfigureSubnet(ids.ID{1}, []ChainConfig{{BlockchainID: ids.ID{2}}})
+	assert.ErrorIs(t, err, ErrEmptyChainConfig)
+}
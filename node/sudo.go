@@ -0,0 +1,228 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+)
+
+var (
+	ErrSudoUnavailable        = errors.New("root privileges are unavailable: the SSH user is not root and sudo is not installed")
+	ErrSudoPasswordRequired   = errors.New("sudo requires a password: set SSHConfig.SudoPassword or allow passwordless sudo for the SSH user")
+	ErrSudoPasswordRejected   = errors.New("sudo password was rejected or the SSH user is not allowed to use sudo")
+	ErrDockerPermissionDenied = errors.New("permission denied while connecting to the docker daemon")
+)
+
+// PrivilegeMode is how commands requiring root privileges are run on a node
+type PrivilegeMode int
+
+const (
+	// PrivilegeUnknown means the privileges of the SSH user have not been detected
+	PrivilegeUnknown PrivilegeMode = iota
+	// PrivilegeRoot means the SSH user is root and needs no escalation
+	PrivilegeRoot
+	// PrivilegeSudoNoPassword means the SSH user can use sudo without a password
+	PrivilegeSudoNoPassword
+	// PrivilegeSudoPassword means the SSH user can use sudo with SSHConfig.SudoPassword
+	PrivilegeSudoPassword
+	// PrivilegeSudoPasswordRequired means sudo requires a password but none is configured
+	PrivilegeSudoPasswordRequired
+	// PrivilegeNone means the SSH user is not root and sudo is not installed
+	PrivilegeNone
+)
+
+// String returns the string representation of the privilege mode
+func (m PrivilegeMode) String() string {
+	switch m {
+	case PrivilegeRoot:
+		return "root"
+	case PrivilegeSudoNoPassword:
+		return "sudo-nopasswd"
+	case PrivilegeSudoPassword:
+		return "sudo-password"
+	case PrivilegeSudoPasswordRequired:
+		return "sudo-password-required"
+	case PrivilegeNone:
+		return "none"
+	default:
+		return "unknown"
+	}
+}
+
+// CanEscalate returns true if commands can be run as root in this mode
+func (m PrivilegeMode) CanEscalate() bool {
+	return m == PrivilegeRoot || m == PrivilegeSudoNoPassword || m == PrivilegeSudoPassword
+}
+
+// privilegeScript prints root, nosudo, nopasswd or passwd depending on how the SSH user can
+// run commands as root
+const privilegeScript = `if [ "$(id -u)" = 0 ]; then echo root; ` +
+	`elif ! command -v sudo >/dev/null 2>&1; then echo nosudo; ` +
+	`elif sudo -n true >/dev/null 2>&1; then echo nopasswd; ` +
+	`else echo passwd; fi`
+
+// sudoAskPassPreamble reads the sudo password from the first line of stdin and makes every
+// sudo call of the script that follows get it through an askpass helper, so that the password
+// never appears in the command line and the stdin of the sudo commands is left untouched
+const sudoAskPassPreamble = `IFS= read -r ODYSSEY_SUDO_PASSWORD
+export ODYSSEY_SUDO_PASSWORD
+SUDO_ASKPASS=$(mktemp)
+export SUDO_ASKPASS
+trap 'rm -f "$SUDO_ASKPASS"' EXIT
+cat >"$SUDO_ASKPASS" <<'ODYSSEY_SUDO_ASKPASS'
+#!/bin/sh
+printf '%s\n' "$ODYSSEY_SUDO_PASSWORD"
+ODYSSEY_SUDO_ASKPASS
+chmod 700 "$SUDO_ASKPASS"
+sudo() { command sudo -A "$@"; }
+`
+
+var sudoCallRegexp = regexp.MustCompile(`(^|[\s;&|(])sudo\s`)
+
+// DetectPrivilege detects how the SSH user of the node can run commands as root, checking
+// SSHConfig.SudoPassword if sudo requires a password. The result is cached on the node.
+func (h *Node) DetectPrivilege() (PrivilegeMode, error) {
+	if h.privilege != nil {
+		return *h.privilege, nil
+	}
+	output, err := h.command(nil, constants.SSHScriptTimeout, privilegeScript, nil)
+	if err != nil {
+		return PrivilegeUnknown, fmt.Errorf("failed to detect privileges of node %s: %w: %s", h.NodeID, err, string(output))
+	}
+	mode, err := parsePrivilegeOutput(string(output))
+	if err != nil {
+		return PrivilegeUnknown, fmt.Errorf("failed to detect privileges of node %s: %w", h.NodeID, err)
+	}
+	if mode == PrivilegeSudoPasswordRequired && h.SSHConfig.SudoPassword != "" {
+		// -k ignores cached credentials so that the password is always checked
+		output, err := h.command(nil, constants.SSHScriptTimeout, "sudo -k -S -p '' true", sudoPasswordReader(h.SSHConfig.SudoPassword))
+		if err != nil {
+			return PrivilegeUnknown, fmt.Errorf("node %s: %w: %s", h.NodeID, ErrSudoPasswordRejected, strings.TrimSpace(string(output)))
+		}
+		mode = PrivilegeSudoPassword
+	}
+	h.privilege = &mode
+	return mode, nil
+}
+
+func parsePrivilegeOutput(output string) (PrivilegeMode, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	switch strings.TrimSpace(lines[len(lines)-1]) {
+	case "root":
+		return PrivilegeRoot, nil
+	case "nopasswd":
+		return PrivilegeSudoNoPassword, nil
+	case "passwd":
+		return PrivilegeSudoPasswordRequired, nil
+	case "nosudo":
+		return PrivilegeNone, nil
+	default:
+		return PrivilegeUnknown, fmt.Errorf("unexpected privilege output: %q", output)
+	}
+}
+
+// RequirePrivilege returns an error explaining why commands cannot be run as root on the node,
+// or nil if they can
+func (h *Node) RequirePrivilege() error {
+	mode, err := h.DetectPrivilege()
+	if err != nil {
+		return err
+	}
+	switch mode {
+	case PrivilegeSudoPasswordRequired:
+		return fmt.Errorf("node %s: %w", h.NodeID, ErrSudoPasswordRequired)
+	case PrivilegeNone:
+		return fmt.Errorf("node %s: %w", h.NodeID, ErrSudoUnavailable)
+	}
+	return nil
+}
+
+// SudoCommand executes a shell command as root on a remote node, using sudo unless the
+// SSH user is root
+func (h *Node) SudoCommand(env []string, timeout time.Duration, script string) ([]byte, error) {
+	if err := h.RequirePrivilege(); err != nil {
+		return nil, err
+	}
+	if *h.privilege != PrivilegeRoot {
+		script = "sudo sh -c " + shellQuote(script)
+	}
+	return h.Command(env, timeout, script)
+}
+
+// prepareSudo makes the sudo calls of script work when sudo requires SSHConfig.SudoPassword,
+// returning the script and stdin to run
+func (h *Node) prepareSudo(script string, stdin io.Reader) (string, io.Reader, error) {
+	if h.SSHConfig.SudoPassword == "" || !usesSudo(script) {
+		return script, stdin, nil
+	}
+	mode, err := h.DetectPrivilege()
+	if err != nil {
+		return "", nil, err
+	}
+	if mode != PrivilegeSudoPassword {
+		return script, stdin, nil
+	}
+	passwordReader := sudoPasswordReader(h.SSHConfig.SudoPassword)
+	if stdin != nil {
+		passwordReader = io.MultiReader(passwordReader, stdin)
+	}
+	return sudoAskPassPreamble + script, passwordReader, nil
+}
+
+// dockerCommandf runs a docker command on the node, as root if SSHConfig.RunWithSudo is set or
+// if the SSH user is not allowed to connect to the docker daemon
+func (h *Node) dockerCommandf(timeout time.Duration, format string, args ...interface{}) ([]byte, error) {
+	script := fmt.Sprintf(format, args...)
+	if h.SSHConfig.RunWithSudo {
+		return h.SudoCommand(nil, timeout, script)
+	}
+	output, err := h.Command(nil, timeout, script)
+	if err == nil || !isDockerPermissionDenied(output) {
+		return output, err
+	}
+	if privErr := h.RequirePrivilege(); privErr != nil {
+		return output, fmt.Errorf("%w on node %s and escalation failed: %w", ErrDockerPermissionDenied, h.NodeID, privErr)
+	}
+	h.Logger.Infof("Permission denied connecting to docker on %s, retrying with %s", h.NodeID, h.privilege)
+	return h.SudoCommand(nil, timeout, script)
+}
+
+// sudoError returns err annotated with the cause of a sudo failure found in output, if any
+func sudoError(err error, output []byte) error {
+	out := string(output)
+	switch {
+	case strings.Contains(out, "a password is required"), strings.Contains(out, "a terminal is required"):
+		return fmt.Errorf("%w: %w", ErrSudoPasswordRequired, err)
+	case strings.Contains(out, "incorrect password attempt"), strings.Contains(out, "is not in the sudoers file"):
+		return fmt.Errorf("%w: %w", ErrSudoPasswordRejected, err)
+	case strings.Contains(out, "sudo: command not found"), strings.Contains(out, "sudo: not found"):
+		return fmt.Errorf("%w: %w", ErrSudoUnavailable, err)
+	}
+	return err
+}
+
+func isDockerPermissionDenied(output []byte) bool {
+	out := strings.ToLower(string(output))
+	return strings.Contains(out, "permission denied") && strings.Contains(out, "docker daemon")
+}
+
+func usesSudo(script string) bool {
+	return sudoCallRegexp.MatchString(script)
+}
+
+func sudoPasswordReader(password string) io.Reader {
+	return strings.NewReader(password + "\n")
+}
+
+// shellQuote quotes s as a single POSIX shell word
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
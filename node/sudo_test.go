@@ -0,0 +1,207 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrivilegeOutput(t *testing.T) {
+	tests := []struct {
+		output   string
+		expected PrivilegeMode
+		wantErr  bool
+	}{
+		{output: "root\n", expected: PrivilegeRoot},
+		{output: "nopasswd\n", expected: PrivilegeSudoNoPassword},
+		{output: "passwd\n", expected: PrivilegeSudoPasswordRequired},
+		{output: "nosudo\n", expected: PrivilegeNone},
+		{output: "Welcome to Ubuntu\nnopasswd\n", expected: PrivilegeSudoNoPassword},
+		{output: "", wantErr: true},
+		{output: "unexpected\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			mode, err := parsePrivilegeOutput(tt.output)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, mode)
+		})
+	}
+}
+
+func TestPrivilegeMode(t *testing.T) {
+	tests := []struct {
+		mode        PrivilegeMode
+		name        string
+		canEscalate bool
+	}{
+		{mode: PrivilegeUnknown, name: "unknown"},
+		{mode: PrivilegeRoot, name: "root", canEscalate: true},
+		{mode: PrivilegeSudoNoPassword, name: "sudo-nopasswd", canEscalate: true},
+		{mode: PrivilegeSudoPassword, name: "sudo-password", canEscalate: true},
+		{mode: PrivilegeSudoPasswordRequired, name: "sudo-password-required"},
+		{mode: PrivilegeNone, name: "none"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.name, tt.mode.String())
+		assert.Equal(t, tt.canEscalate, tt.mode.CanEscalate(), tt.name)
+	}
+}
+
+func TestRequirePrivilege(t *testing.T) {
+	tests := []struct {
+		mode        PrivilegeMode
+		expectedErr error
+	}{
+		{mode: PrivilegeRoot},
+		{mode: PrivilegeSudoNoPassword},
+		{mode: PrivilegeSudoPassword},
+		{mode: PrivilegeSudoPasswordRequired, expectedErr: ErrSudoPasswordRequired},
+		{mode: PrivilegeNone, expectedErr: ErrSudoUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			mode := tt.mode
+			h := &Node{NodeID: "node-1", privilege: &mode}
+			err := h.RequirePrivilege()
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.ErrorContains(t, err, "node-1")
+		})
+	}
+}
+
+func TestSudoCommand_Unavailable(t *testing.T) {
+	mode := PrivilegeNone
+	h := &Node{privilege: &mode}
+	_, err := h.SudoCommand(nil, 0, "id -u")
+	assert.ErrorIs(t, err, ErrSudoUnavailable)
+}
+
+func TestUsesSudo(t *testing.T) {
+	tests := []struct {
+		script   string
+		expected bool
+	}{
+		{script: "sudo systemctl restart docker", expected: true},
+		{script: "set -e\nsudo tar -xzf -", expected: true},
+		{script: "cat <<EOF | sudo tee /etc/file", expected: true},
+		{script: "true && sudo rm -rf /tmp/x", expected: true},
+		{script: "docker compose -f compose.yml up -d"},
+		{script: "echo pseudo code"},
+		{script: "visudo -c"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, usesSudo(tt.script), tt.script)
+	}
+}
+
+func TestSudoError(t *testing.T) {
+	baseErr := errors.New("Process exited with status 1")
+	tests := []struct {
+		output      string
+		expectedErr error
+	}{
+		{output: "sudo: a password is required", expectedErr: ErrSudoPasswordRequired},
+		{output: "sudo: a terminal is required to read the password", expectedErr: ErrSudoPasswordRequired},
+		{output: "sudo: 1 incorrect password attempt", expectedErr: ErrSudoPasswordRejected},
+		{output: "ubuntu is not in the sudoers file.", expectedErr: ErrSudoPasswordRejected},
+		{output: "bash: sudo: command not found", expectedErr: ErrSudoUnavailable},
+		{output: "tar: Error is not recoverable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			err := sudoError(baseErr, []byte(tt.output))
+			assert.ErrorIs(t, err, baseErr)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Equal(t, baseErr, err)
+			}
+		})
+	}
+}
+
+func TestIsDockerPermissionDenied(t *testing.T) {
+	assert.True(t, isDockerPermissionDenied([]byte("permission denied while trying to connect to the Docker daemon socket at unix:///var/run/docker.sock")))
+	assert.False(t, isDockerPermissionDenied([]byte("no such service: odysseygo")))
+	assert.False(t, isDockerPermissionDenied([]byte("open /home/ubuntu/file: permission denied")))
+}
+
+func TestShellQuote(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+	for _, s := range []string{"echo hello", `it's "quoted" $HOME`, "a'b'c", ""} {
+		output, err := exec.Command("sh", "-c", "printf '%s' "+shellQuote(s)).Output()
+		require.NoError(t, err)
+		assert.Equal(t, s, string(output))
+	}
+}
+
+func TestPrepareSudo(t *testing.T) {
+	t.Run("no password configured", func(t *testing.T) {
+		h := &Node{}
+		script, stdin, err := h.prepareSudo("sudo true", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "sudo true", script)
+		assert.Nil(t, stdin)
+	})
+
+	t.Run("passwordless sudo", func(t *testing.T) {
+		mode := PrivilegeSudoNoPassword
+		h := &Node{SSHConfig: SSHConfig{SudoPassword: "secret"}, privilege: &mode}
+		script, stdin, err := h.prepareSudo("sudo true", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "sudo true", script)
+		assert.Nil(t, stdin)
+	})
+
+	t.Run("script without sudo", func(t *testing.T) {
+		h := &Node{SSHConfig: SSHConfig{SudoPassword: "secret"}}
+		script, stdin, err := h.prepareSudo("docker ps", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "docker ps", script)
+		assert.Nil(t, stdin)
+	})
+
+	t.Run("sudo with password", func(t *testing.T) {
+		mode := PrivilegeSudoPassword
+		h := &Node{SSHConfig: SSHConfig{SudoPassword: "s3cr'et"}, privilege: &mode}
+		script, stdin, err := h.prepareSudo("sudo tar -xzf -", strings.NewReader("archive"))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(script, sudoAskPassPreamble))
+		assert.True(t, strings.HasSuffix(script, "sudo tar -xzf -"))
+		assert.NotContains(t, script, "s3cr'et")
+		input, err := io.ReadAll(stdin)
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr'et\narchive", string(input))
+	})
+}
+
+func TestSudoAskPassPreamble(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash is not available")
+	}
+	// the askpass helper must print the password and the rest of stdin must be left untouched
+	cmd := exec.Command("bash", "-c", sudoAskPassPreamble+`"$SUDO_ASKPASS"; cat; type sudo >/dev/null && echo`)
+	cmd.Stdin = io.MultiReader(sudoPasswordReader(`p@ss w"rd`), strings.NewReader("data"))
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	assert.Equal(t, "p@ss w\"rd\ndata\n", string(output))
+}
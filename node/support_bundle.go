@@ -0,0 +1,320 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+)
+
+const (
+	supportBundlePrefix          = "support-bundle-"
+	supportBundleErrorsFile      = "errors.txt"
+	defaultSupportBundleLogLines = 1000
+	defaultSupportBundleAlerts   = 7 * 24 * time.Hour
+	supportBundleMaxConfigSize   = 1024 * 1024
+)
+
+// ErrSupportBundleIncomplete is the result error of the nodes some data could not be
+// collected from by SupportBundle
+var ErrSupportBundleIncomplete = errors.New("support bundle incomplete")
+
+// SupportBundleOp holds the options of SupportBundle
+type SupportBundleOp struct {
+	logLines        uint
+	alertHistory    time.Duration
+	grafanaUser     string
+	grafanaPassword string
+}
+
+// SupportBundleOption configures SupportBundle
+type SupportBundleOption func(*SupportBundleOp)
+
+// WithSupportBundleLogLines sets the number of the last log lines collected per service, 1000
+// by default. 0 collects all of them
+func WithSupportBundleLogLines(lines uint) SupportBundleOption {
+	return func(op *SupportBundleOp) {
+		op.logLines = lines
+	}
+}
+
+// WithSupportBundleAlertHistory sets how far back the Grafana alerts are collected, 7 days by
+// default
+func WithSupportBundleAlertHistory(history time.Duration) SupportBundleOption {
+	return func(op *SupportBundleOp) {
+		op.alertHistory = history
+	}
+}
+
+// WithSupportBundleGrafanaCredentials sets the credentials the Grafana alerts are queried
+// with, by default those of the admin set up by the monitoring compose file
+func WithSupportBundleGrafanaCredentials(user, password string) SupportBundleOption {
+	return func(op *SupportBundleOp) {
+		op.grafanaUser = user
+		op.grafanaPassword = password
+	}
+}
+
+// SupportBundle collects the data needed to troubleshoot the nodes into a .tar.gz archive
+// created in the local directory dir, returning its path, e.g. to attach it to a support
+// ticket. For each node, the archive holds under a directory named after its ID:
+//   - versions.txt: the kernel, docker and compose versions and the images of the services,
+//     or the kernel and odysseygo versions on SystemdRuntime nodes
+//   - compose/: the remote compose file, or status.txt: the status of the systemd units on
+//     SystemdRuntime nodes
+//   - logs/: the last log lines of each compose service or systemd unit
+//   - configs/: the odysseygo configs under odysseygo/, and the relayer and RPC gateway
+//     configs under services/ if installed, at their paths in the remote folders
+//   - health.json, version.json and metrics/: the odysseygo health, version and metrics
+//     and the node-exporter metrics
+//   - alerts.json: the recent Grafana alerts, on the monitoring nodes
+//   - errors.txt: the data that could not be collected
+//
+// The secrets found in the collected data are redacted with utils.RedactSecrets. The nodes
+// are collected from concurrently, at most limit at once or all of them if limit is not
+// positive. The data of a node is collected on a best effort basis: nodes some data could not
+// be collected from fail with ErrSupportBundleIncomplete, but the archive still holds the data
+// collected. An error is only returned if the archive could not be written
+func SupportBundle(ctx context.Context, nodes []*Node, dir string, limit int, options ...SupportBundleOption) (string, *NodeResults, error) {
+	op := &SupportBundleOp{
+		logLines:        defaultSupportBundleLogLines,
+		alertHistory:    defaultSupportBundleAlerts,
+		grafanaUser:     "admin",
+		grafanaPassword: "admin",
+	}
+	for _, option := range options {
+		option(op)
+	}
+	if err := os.MkdirAll(dir, constants.DefaultPerms755); err != nil {
+		return "", nil, err
+	}
+	now := time.Now().UTC()
+	bundles := make(map[string]map[string][]byte, len(nodes))
+	results := RunOnNodes(nodes, limit, func(node *Node) (interface{}, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		collector := &supportBundleCollector{node: node, op: op, now: now, files: map[string][]byte{}}
+		collector.collect()
+		return collector.files, collector.err()
+	})
+	for _, result := range results.GetResults() {
+		if files, ok := result.Value.(map[string][]byte); ok {
+			bundles[result.NodeID] = files
+		}
+	}
+	name := supportBundlePrefix + now.Format(backupTimestampFormat)
+	archivePath := filepath.Join(dir, name+".tar.gz")
+	if err := writeSupportBundle(archivePath, name, now, bundles); err != nil {
+		_ = os.Remove(archivePath)
+		return "", results, fmt.Errorf("failed to write support bundle %s: %w", archivePath, err)
+	}
+	return archivePath, results, nil
+}
+
+// supportBundleCollector collects the support bundle files of a node
+type supportBundleCollector struct {
+	node  *Node
+	op    *SupportBundleOp
+	now   time.Time
+	files map[string][]byte
+	errs  []string
+}
+
+// add records data as the file name, redacted, or err as failing to collect it
+func (c *supportBundleCollector) add(name string, data []byte, err error) {
+	if err != nil {
+		c.errs = append(c.errs, fmt.Sprintf("%s: %s", name, err))
+		return
+	}
+	c.files[name] = utils.RedactSecrets(data)
+}
+
+// command adds the output of script as the file name
+func (c *supportBundleCollector) command(name string, script string) {
+	output, err := c.node.Command(nil, constants.SSHScriptTimeout, script)
+	if err != nil {
+		err = fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	c.add(name, output, err)
+}
+
+func (c *supportBundleCollector) collect() {
+	var services []string
+	if c.node.RuntimeMode == SystemdRuntime {
+		services = c.collectNative()
+	} else {
+		services = c.collectCompose()
+	}
+
+	nodeConfig := c.node.config()
+	if utils.Belongs(services, constants.ServiceOdysseygo) {
+		c.configs(filepath.Dir(remoteconfig.GetRemoteOdysseyNodeConfig(nodeConfig)))
+		c.command("health.json", fmt.Sprintf("curl -s http://127.0.0.1:%d/ext/health", nodeConfig.OdysseygoAPIPort))
+		c.command("version.json", fmt.Sprintf(
+			`curl -sf -X POST -H 'content-type:application/json' --data '{"jsonrpc":"2.0","id":1,"method":"info.getNodeVersion"}' http://127.0.0.1:%d/ext/info`,
+			nodeConfig.OdysseygoAPIPort,
+		))
+		c.command("metrics/odysseygo.txt", fmt.Sprintf("curl -sf http://127.0.0.1:%d/ext/metrics", nodeConfig.OdysseygoAPIPort))
+	}
+	if utils.Belongs(services, constants.ServiceAWMRelayer) {
+		c.config(remoteconfig.GetRemoteAWMRelayerConfig(nodeConfig))
+	}
+	if utils.Belongs(services, constants.ServiceRPCGateway) {
+		c.config(remoteconfig.GetRemoteRPCGatewayConfig(nodeConfig))
+	}
+	if utils.Belongs(services, constants.ServiceNodeExporter) {
+		c.command("metrics/node-exporter.txt", fmt.Sprintf("curl -sf http://127.0.0.1:%d/metrics", nodeConfig.MachineMetricsPort))
+	}
+	if utils.Belongs(services, constants.ServiceGrafana) {
+		c.command("alerts.json", fmt.Sprintf(
+			"curl -sf -u '%s:%s' 'http://127.0.0.1:%d/api/annotations?type=alert&limit=5000&from=%d'",
+			c.op.grafanaUser, c.op.grafanaPassword, nodeConfig.GrafanaPort, c.now.Add(-c.op.alertHistory).UnixMilli(),
+		))
+	}
+}
+
+// collectCompose adds the compose file, the versions and the service logs of a node in the
+// DockerRuntime mode, and returns its services
+func (c *supportBundleCollector) collectCompose() []string {
+	compose := c.node.Compose()
+	content, err := compose.Content(constants.SSHFileOpsTimeout)
+	c.add(path.Join("compose", path.Base(compose.Path())), []byte(content), err)
+	versions, err := c.node.dockerCommandf(constants.SSHScriptTimeout,
+		"uname -srm; docker version --format 'docker {{.Server.Version}}'; docker compose version; docker compose -f %s images", compose.Path())
+	c.add("versions.txt", versions, err)
+
+	services, err := compose.Services(constants.SSHScriptTimeout)
+	if err != nil {
+		c.add("logs", nil, err)
+	}
+	for _, service := range services {
+		logs, err := compose.Service(service).Logs(c.op.logLines, constants.SSHLongRunningScriptTimeout)
+		c.add(path.Join("logs", service+".log"), logs, err)
+	}
+	return services
+}
+
+// collectNative adds the status of the systemd units, the versions and the unit logs of a
+// node in the SystemdRuntime mode, and returns its services
+func (c *supportBundleCollector) collectNative() []string {
+	units := strings.Join(nativeUnits, " ")
+	c.command("versions.txt", "uname -srm; /usr/local/bin/odysseygo --version")
+	// systemctl status exits with 3 if a unit is not running, which is what the file is for
+	c.command("status.txt", fmt.Sprintf("systemctl status --no-pager %s || true", units))
+	services := []string{constants.ServiceOdysseygo, constants.ServicePromtail, constants.ServiceNodeExporter}
+	for i, unit := range nativeUnits {
+		lines := ""
+		if c.op.logLines > 0 {
+			lines = fmt.Sprintf(" -n %d", c.op.logLines)
+		}
+		c.command(path.Join("logs", services[i]+".log"), fmt.Sprintf("sudo journalctl --no-pager -u %s%s", unit, lines))
+	}
+	return services
+}
+
+// configs adds the JSON files of the remote directory dir, except the oversized ones
+func (c *supportBundleCollector) configs(dir string) {
+	output, err := c.node.Commandf(nil, constants.SSHScriptTimeout,
+		"find %s -type f -name '*.json' -size -%dc", dir, supportBundleMaxConfigSize)
+	if err != nil {
+		c.add("configs", nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output))))
+		return
+	}
+	for _, file := range strings.Fields(string(output)) {
+		c.config(file)
+	}
+}
+
+// config adds the remote file under configs, see supportBundleConfigName
+func (c *supportBundleCollector) config(file string) {
+	data, err := c.node.ReadFileBytes(file, constants.SSHFileOpsTimeout)
+	c.add(supportBundleConfigName(c.node.config(), file), data, err)
+}
+
+// supportBundleConfigName returns the name of the remote file in the archive: its path
+// relative to the odysseygo or services directory of config, under configs/odysseygo or
+// configs/services, or its absolute path under configs for the files outside of them
+func supportBundleConfigName(config constants.Config, file string) string {
+	for _, dir := range []struct {
+		name string
+		path string
+	}{
+		{"odysseygo", config.OdysseyGoDir},
+		{constants.ServicesDir, config.ServicesDir},
+	} {
+		if rel, err := filepath.Rel(dir.path, file); err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+			return path.Join("configs", dir.name, filepath.ToSlash(rel))
+		}
+	}
+	return path.Join("configs", file)
+}
+
+// err returns the failures of the collection, if any
+func (c *supportBundleCollector) err() error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	c.files[supportBundleErrorsFile] = utils.RedactSecrets([]byte(strings.Join(c.errs, "\n") + "\n"))
+	return fmt.Errorf("%w on node %s: %s", ErrSupportBundleIncomplete, c.node.NodeID, strings.Join(c.errs, "; "))
+}
+
+// writeSupportBundle writes the files of the nodes into the .tar.gz archive archivePath,
+// under the directory name
+func writeSupportBundle(archivePath string, name string, modTime time.Time, bundles map[string]map[string][]byte) error {
+	archive, err := os.OpenFile(archivePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, constants.WriteReadUserOnlyPerms)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+	gzipWriter := gzip.NewWriter(archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	nodeIDs := make([]string, 0, len(bundles))
+	for nodeID := range bundles {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+	for _, nodeID := range nodeIDs {
+		files := bundles[nodeID]
+		fileNames := make([]string, 0, len(files))
+		for fileName := range files {
+			fileNames = append(fileNames, fileName)
+		}
+		sort.Strings(fileNames)
+		for _, fileName := range fileNames {
+			header := &tar.Header{
+				Name:    path.Join(name, nodeID, fileName),
+				Mode:    constants.WriteReadUserOnlyPerms,
+				Size:    int64(len(files[fileName])),
+				ModTime: modTime,
+			}
+			if err := tarWriter.WriteHeader(header); err != nil {
+				return err
+			}
+			if _, err := tarWriter.Write(files[fileName]); err != nil {
+				return err
+			}
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+	return archive.Close()
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node/nodemock"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// readSupportBundle returns the content of the files of the support bundle archivePath
+func readSupportBundle(t *testing.T, archivePath string) map[string]string {
+	archive, err := os.Open(archivePath)
+	require.NoError(t, err)
+	defer archive.Close()
+	gzipReader, err := gzip.NewReader(archive)
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+	files := map[string]string{}
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return files
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tarReader)
+		require.NoError(t, err)
+		files[header.Name[strings.Index(header.Name, "/")+1:]] = string(content)
+	}
+}
+
+func TestSupportBundle(t *testing.T) {
+	composeFile := utils.GetRemoteComposeFile()
+	composeContent := []byte("services:\n  grafana:\n    environment:\n      - GF_SECURITY_ADMIN_PASSWORD=admin\n")
+	sum := sha256.Sum256(composeContent)
+	client := nodemock.NewSSHClient()
+	client.OnScript("base64 '"+composeFile+"'", base64.StdEncoding.EncodeToString(composeContent), nil).Once()
+	client.OnScript("sha256sum '"+composeFile+"'", fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), composeFile), nil).Once()
+	client.OnScript(mock.MatchedBy(func(script string) bool {
+		return strings.HasPrefix(script, "uname -srm;")
+	}), "Linux 6.8.0 x86_64\ndocker 27.0.1\n", nil).Once()
+	client.OnScript("docker compose -f "+composeFile+" config --services", "grafana\nloki\n", nil).Once()
+	client.OnScript("docker compose -f "+composeFile+" logs --no-color --no-log-prefix --tail 10 grafana", "logger=server msg=started\n", nil).Once()
+	client.OnScript("docker compose -f "+composeFile+" logs --no-color --no-log-prefix --tail 10 loki", "", errors.New("exit status 1")).Once()
+	client.OnScript(mock.MatchedBy(func(script string) bool {
+		return strings.HasPrefix(script, "curl -sf -u 'viewer:pass' 'http://127.0.0.1:3000/api/annotations?type=alert")
+	}), `[{"alertName":"NodeDown","newState":"Alerting"}]`, nil).Once()
+	h := &Node{NodeID: "monitoring-1"}
+	h.SetSSHClient(client)
+
+	archivePath, results, err := SupportBundle(context.Background(), []*Node{h}, t.TempDir(), 0,
+		WithSupportBundleLogLines(10),
+		WithSupportBundleGrafanaCredentials("viewer", "pass"),
+	)
+	require.NoError(t, err)
+	require.Len(t, results.GetErrorHostMap(), 1)
+	require.ErrorIs(t, results.GetErrorHostMap()["monitoring-1"], ErrSupportBundleIncomplete)
+	client.AssertExpectations(t)
+
+	files := readSupportBundle(t, archivePath)
+	assert.Equal(t, "services:\n  grafana:\n    environment:\n      - GF_SECURITY_ADMIN_PASSWORD=[REDACTED]\n", files["monitoring-1/compose/docker-compose.yml"])
+	assert.Equal(t, "Linux 6.8.0 x86_64\ndocker 27.0.1\n", files["monitoring-1/versions.txt"])
+	assert.Equal(t, "logger=server msg=started\n", files["monitoring-1/logs/grafana.log"])
+	assert.Equal(t, `[{"alertName":"NodeDown","newState":"Alerting"}]`, files["monitoring-1/alerts.json"])
+	assert.NotContains(t, files, "monitoring-1/logs/loki.log")
+	assert.Contains(t, files["monitoring-1/errors.txt"], "logs/loki.log: ")
+}
+
+func TestSupportBundle_SystemdRuntime(t *testing.T) {
+	client := nodemock.NewSSHClient()
+	client.OnScript("uname -srm; /usr/local/bin/odysseygo --version", "Linux 6.8.0 x86_64\nodysseygo/1.10.13\n", nil).Once()
+	client.OnScript("systemctl status --no-pager odysseygo.service promtail.service node-exporter.service || true", "odysseygo.service - OdysseyGo\n", nil).Once()
+	client.OnScript("sudo journalctl --no-pager -u odysseygo.service -n 10", "started\n", nil).Once()
+	client.OnScript("sudo journalctl --no-pager -u promtail.service -n 10", "", nil).Once()
+	client.OnScript("sudo journalctl --no-pager -u node-exporter.service -n 10", "", nil).Once()
+	client.OnScript(mock.Anything, "", nil)
+	h := &Node{NodeID: "validator-1", RuntimeMode: SystemdRuntime}
+	h.SetSSHClient(client)
+
+	archivePath, _, err := SupportBundle(context.Background(), []*Node{h}, t.TempDir(), 0, WithSupportBundleLogLines(10))
+	require.NoError(t, err)
+
+	files := readSupportBundle(t, archivePath)
+	assert.Equal(t, "Linux 6.8.0 x86_64\nodysseygo/1.10.13\n", files["validator-1/versions.txt"])
+	assert.Equal(t, "odysseygo.service - OdysseyGo\n", files["validator-1/status.txt"])
+	assert.Equal(t, "started\n", files["validator-1/logs/odysseygo.log"])
+	assert.NotContains(t, files, "validator-1/compose/docker-compose.yml")
+}
+
+func TestSupportBundleConfigName(t *testing.T) {
+	defaults := constants.DefaultConfig()
+	custom := constants.Config{OdysseyGoDir: "/data/odysseygo", ServicesDir: "/srv/odyssey"}.WithDefaults()
+	tests := []struct {
+		name   string
+		config constants.Config
+		file   string
+		want   string
+	}{
+		{"default odysseygo config", defaults, "/home/ubuntu/.odysseygo/configs/node.json", "configs/odysseygo/configs/node.json"},
+		{"default service config", defaults, "/home/ubuntu/.odyssey-cli/services/awm-relayer/awm-relayer-config.json", "configs/services/awm-relayer/awm-relayer-config.json"},
+		{"custom odysseygo config", custom, "/data/odysseygo/configs/chains/C/config.json", "configs/odysseygo/configs/chains/C/config.json"},
+		{"custom service config", custom, "/srv/odyssey/rpc-gateway/nginx.conf", "configs/services/rpc-gateway/nginx.conf"},
+		{"outside of the folders", custom, "/data/odysseygo-old/node.json", "configs/data/odysseygo-old/node.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, supportBundleConfigName(tt.config, tt.file))
+		})
+	}
+}
@@ -16,6 +16,8 @@ const (
 	API
 	Loadtest
 	Monitor
+	Relayer
+	RPCGateway
 )
 
 // NewSupportedRole converts a string to a SupportedRole
@@ -29,6 +31,10 @@ func NewSupportedRole(s string) SupportedRole {
 		return Loadtest
 	case "monitor":
 		return Monitor
+	case "relayer":
+		return Relayer
+	case "rpc-gateway":
+		return RPCGateway
 	default:
 		return Monitor
 	}
@@ -45,6 +51,10 @@ func (r *SupportedRole) String() string {
 		return "loadtest"
 	case Monitor:
 		return "monitor"
+	case Relayer:
+		return "relayer"
+	case RPCGateway:
+		return "rpc-gateway"
 	default:
 		return "unknown"
 	}
@@ -61,5 +71,10 @@ func CheckRoles(roles []SupportedRole) error {
 	if slices.Contains(roles, Monitor) && len(roles) > 1 {
 		return fmt.Errorf("%v role cannot be combined with other roles", Monitor)
 	}
+	// the RPC gateway is a reverse proxy in front of the local odysseygo API,
+	// which must not be exposed publicly on validators
+	if slices.Contains(roles, RPCGateway) && !slices.Contains(roles, API) {
+		return fmt.Errorf("rpc-gateway role requires the api role")
+	}
 	return nil
 }
@@ -183,3 +183,36 @@ func TestCheckRoles(t *testing.T) {
 		})
 	}
 }
+
+func TestRelayerAndRPCGatewayRoles(t *testing.T) {
+	for name, role := range map[string]SupportedRole{"relayer": Relayer, "rpc-gateway": RPCGateway} {
+		assert.Equal(t, role, NewSupportedRole(name))
+		assert.Equal(t, name, role.String())
+	}
+
+	tests := []struct {
+		name        string
+		roles       []SupportedRole
+		errContains string
+	}{
+		{name: "standalone relayer", roles: []SupportedRole{Relayer}},
+		{name: "relayer on validator", roles: []SupportedRole{Validator, Relayer}},
+		{name: "relayer on api", roles: []SupportedRole{API, Relayer}},
+		{name: "rpc gateway on api", roles: []SupportedRole{API, RPCGateway}},
+		{name: "api with relayer and rpc gateway", roles: []SupportedRole{API, Relayer, RPCGateway}},
+		{name: "standalone rpc gateway", roles: []SupportedRole{RPCGateway}, errContains: "requires the api role"},
+		{name: "rpc gateway on validator", roles: []SupportedRole{Validator, RPCGateway}, errContains: "requires the api role"},
+		{name: "relayer on monitor", roles: []SupportedRole{Monitor, Relayer}, errContains: "cannot be combined"},
+		{name: "relayer on loadtest", roles: []SupportedRole{Loadtest, Relayer}, errContains: "cannot be combined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckRoles(tt.roles)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
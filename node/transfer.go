@@ -0,0 +1,453 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/progress"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+)
+
+const (
+	// DefaultTransferChunkSize is the amount of bytes sent per chunk by resumable transfers
+	DefaultTransferChunkSize = 64 * 1024 * 1024
+
+	// DefaultTransferRetries is the number of times resumable transfers retry a failed chunk
+	DefaultTransferRetries = 5
+
+	// DefaultTransferRetryDelay is the delay before the first retry of a failed chunk,
+	// doubled on each consecutive failure
+	DefaultTransferRetryDelay = 2 * time.Second
+
+	// partialFileSuffix is appended to the destination of resumable transfers until they
+	// complete, so that an interrupted transfer can be resumed
+	partialFileSuffix = ".part"
+
+	maxTransferRetryDelay = time.Minute
+)
+
+var (
+	ErrTransferRetriesExhausted = errors.New("transfer retries exhausted")
+	ErrTransferChecksumMismatch = errors.New("transferred file checksum mismatch")
+)
+
+// TransferProgressFunc receives the amount of bytes of a file transferred so far, resumed
+// bytes included, and the size of the file
+type TransferProgressFunc func(transferred int64, total int64)
+
+// TransferOp holds the options of UploadResumable and DownloadResumable
+type TransferOp struct {
+	chunkSize      int64
+	retries        int
+	retryDelay     time.Duration
+	bandwidthLimit int64
+	progress       TransferProgressFunc
+}
+
+// TransferOption configures UploadResumable and DownloadResumable
+type TransferOption func(*TransferOp)
+
+// WithChunkSize sets the amount of bytes sent per chunk. A failed chunk is resumed from
+// the last byte received
+func WithChunkSize(chunkSize int64) TransferOption {
+	return func(op *TransferOp) {
+		op.chunkSize = chunkSize
+	}
+}
+
+// WithTransferRetries sets the number of consecutive times a failed chunk is retried
+func WithTransferRetries(retries int) TransferOption {
+	return func(op *TransferOp) {
+		op.retries = retries
+	}
+}
+
+// WithTransferRetryDelay sets the delay before the first retry of a failed chunk
+func WithTransferRetryDelay(delay time.Duration) TransferOption {
+	return func(op *TransferOp) {
+		op.retryDelay = delay
+	}
+}
+
+// WithBandwidthLimit limits the transfer rate to bytesPerSecond. No limit is applied when
+// bytesPerSecond is not positive
+func WithBandwidthLimit(bytesPerSecond int64) TransferOption {
+	return func(op *TransferOp) {
+		op.bandwidthLimit = bytesPerSecond
+	}
+}
+
+// WithTransferProgress makes the transfer call progress as bytes are transferred
+func WithTransferProgress(progress TransferProgressFunc) TransferOption {
+	return func(op *TransferOp) {
+		op.progress = progress
+	}
+}
+
+func newTransferOp(opts ...TransferOption) TransferOp {
+	op := TransferOp{
+		chunkSize:  DefaultTransferChunkSize,
+		retries:    DefaultTransferRetries,
+		retryDelay: DefaultTransferRetryDelay,
+	}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	if op.chunkSize <= 0 {
+		op.chunkSize = DefaultTransferChunkSize
+	}
+	op.retries = max(op.retries, 0)
+	return op
+}
+
+// chunkTimeout is the time given to a chunk to be transferred
+func (op TransferOp) chunkTimeout() time.Duration {
+	timeout := constants.SSHLongRunningScriptTimeout
+	if op.bandwidthLimit > 0 {
+		timeout += time.Duration(op.chunkSize/op.bandwidthLimit) * time.Second
+	}
+	return timeout
+}
+
+// UploadResumable uploads a local file to a remote file on the node in chunks, retrying
+// failed chunks and reconnecting to the node as needed. Data is written to remoteFile.part,
+// which is renamed to remoteFile once its SHA-256 checksum matches the local file, so that
+// a failed upload is resumed by calling UploadResumable again
+func (h *Node) UploadResumable(ctx context.Context, localFile string, remoteFile string, opts ...TransferOption) error {
+	op := newTransferOp(opts...)
+	info, err := os.Stat(localFile)
+	if err != nil {
+		return err
+	}
+	partFile := remoteFile + partialFileSuffix
+	// the partial file exists even for empty files, for its checksum to be computed
+	if output, err := h.Commandf(nil, constants.SSHFileOpsTimeout, "mkdir -p %s && touch %s", shellQuote(filepath.Dir(remoteFile)), shellQuote(partFile)); err != nil {
+		return fmt.Errorf("failed to create %s on node %s: %w: %s", partFile, h.NodeID, err, string(output))
+	}
+	transfer := chunkedTransfer{
+		op:    op,
+		total: info.Size(),
+		offset: func() (int64, error) {
+			size, _, err := h.remoteFileSize(partFile)
+			// a larger partial file does not belong to this upload
+			if err == nil && size > info.Size() {
+				_, err = h.Commandf(nil, constants.SSHFileOpsTimeout, "rm -f %s", shellQuote(partFile))
+				size = 0
+			}
+			return size, err
+		},
+		send: func(ctx context.Context, offset int64, length int64) error {
+			file, err := os.Open(localFile)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			reader := io.NewSectionReader(file, offset, length)
+			return h.streamCommand(ctx, "cat >> "+shellQuote(partFile), op.throttle(ctx, reader), io.Discard)
+		},
+		reconnect: h.reconnect,
+		report:    h.transferReporter("Uploaded"),
+	}
+	if err := transfer.run(ctx); err != nil {
+		return fmt.Errorf("failed to upload %s to node %s: %w", localFile, h.NodeID, err)
+	}
+	localSum, err := utils.FileSHA256(localFile)
+	if err != nil {
+		return err
+	}
+	remoteSum, err := h.RemoteFileSHA256(partFile, constants.SSHLongRunningScriptTimeout)
+	if err != nil {
+		return err
+	}
+	if localSum != remoteSum {
+		_, _ = h.Commandf(nil, constants.SSHFileOpsTimeout, "rm -f %s", shellQuote(partFile))
+		return fmt.Errorf("%w: %s uploaded to node %s as %s", ErrTransferChecksumMismatch, localFile, h.NodeID, remoteFile)
+	}
+	if output, err := h.Commandf(nil, constants.SSHFileOpsTimeout, "mv -f %s %s", shellQuote(partFile), shellQuote(remoteFile)); err != nil {
+		return fmt.Errorf("failed to rename %s on node %s: %w: %s", partFile, h.NodeID, err, string(output))
+	}
+	return nil
+}
+
+// DownloadResumable downloads a remote file of the node to a local file in chunks,
+// retrying failed chunks and reconnecting to the node as needed. Data is written to
+// localFile.part, which is renamed to localFile once its SHA-256 checksum matches the
+// remote file, so that a failed download is resumed by calling DownloadResumable again
+func (h *Node) DownloadResumable(ctx context.Context, remoteFile string, localFile string, opts ...TransferOption) error {
+	op := newTransferOp(opts...)
+	total, exists, err := h.remoteFileSize(remoteFile)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%s does not exist on node %s", remoteFile, h.NodeID)
+	}
+	if err := os.MkdirAll(filepath.Dir(localFile), os.ModePerm); err != nil {
+		return err
+	}
+	partFile := localFile + partialFileSuffix
+	// the partial file exists even for empty files, for its checksum to be computed
+	part, err := os.OpenFile(partFile, os.O_WRONLY|os.O_CREATE, constants.WriteReadReadPerms)
+	if err != nil {
+		return err
+	}
+	if err := part.Close(); err != nil {
+		return err
+	}
+	transfer := chunkedTransfer{
+		op:    op,
+		total: total,
+		offset: func() (int64, error) {
+			info, err := os.Stat(partFile)
+			switch {
+			case errors.Is(err, os.ErrNotExist):
+				return 0, nil
+			case err != nil:
+				return 0, err
+			case info.Size() > total:
+				// a larger partial file does not belong to this download
+				return 0, os.Remove(partFile)
+			}
+			return info.Size(), nil
+		},
+		send: func(ctx context.Context, offset int64, length int64) error {
+			file, err := os.OpenFile(partFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, constants.WriteReadReadPerms)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			// tail offsets start at 1
+			script := fmt.Sprintf("tail -c +%d %s | head -c %d", offset+1, shellQuote(remoteFile), length)
+			return h.streamCommand(ctx, script, nil, op.throttleWriter(ctx, file))
+		},
+		reconnect: h.reconnect,
+		report:    h.transferReporter("Downloaded"),
+	}
+	if err := transfer.run(ctx); err != nil {
+		return fmt.Errorf("failed to download %s from node %s: %w", remoteFile, h.NodeID, err)
+	}
+	localSum, err := utils.FileSHA256(partFile)
+	if err != nil {
+		return err
+	}
+	remoteSum, err := h.RemoteFileSHA256(remoteFile, constants.SSHLongRunningScriptTimeout)
+	if err != nil {
+		return err
+	}
+	if localSum != remoteSum {
+		_ = os.Remove(partFile)
+		return fmt.Errorf("%w: %s downloaded from node %s as %s", ErrTransferChecksumMismatch, remoteFile, h.NodeID, localFile)
+	}
+	return os.Rename(partFile, localFile)
+}
+
+// remoteFileSize returns the size of remoteFile on the node and whether it exists
+func (h *Node) remoteFileSize(remoteFile string) (int64, bool, error) {
+	output, err := h.Commandf(nil, constants.SSHFileOpsTimeout, "if [ -e %s ]; then stat -c %%s %s; fi", shellQuote(remoteFile), shellQuote(remoteFile))
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get size of %s on node %s: %w: %s", remoteFile, h.NodeID, err, string(output))
+	}
+	return parseFileSize(string(output))
+}
+
+// parseFileSize parses the output of stat -c %s, empty if the file does not exist
+func parseFileSize(output string) (int64, bool, error) {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return 0, false, nil
+	}
+	size, err := strconv.ParseInt(output, 10, 64)
+	if err != nil || size < 0 {
+		return 0, false, fmt.Errorf("unexpected file size %q", output)
+	}
+	return size, true, nil
+}
+
+// reconnect replaces the SSH connection of the node, e.g. after a transfer failure
+func (h *Node) reconnect() error {
+	_ = h.Disconnect()
+	h.connection = nil
+	return h.Connect(0)
+}
+
+// transferReporter returns a TransferProgressFunc reporting progress events of the node
+func (h *Node) transferReporter(action string) TransferProgressFunc {
+	return func(transferred int64, total int64) {
+		percent := float64(100)
+		if total > 0 {
+			percent = float64(transferred) * 100 / float64(total)
+		}
+		h.Progress.Report(progress.Event{
+			Stage:   progress.StageTransfer,
+			Node:    h.NodeID,
+			Percent: percent,
+			Message: fmt.Sprintf("%s %dMB of %dMB", action, transferred/(1024*1024), total/(1024*1024)),
+		})
+	}
+}
+
+// chunkedTransfer transfers total bytes in chunks of op.chunkSize, resuming each chunk
+// from offset and retrying failed chunks up to op.retries consecutive times
+type chunkedTransfer struct {
+	op    TransferOp
+	total int64
+
+	// offset returns the amount of bytes already transferred
+	offset func() (int64, error)
+
+	// send transfers length bytes starting at offset
+	send func(ctx context.Context, offset int64, length int64) error
+
+	// reconnect is called before retrying a failed chunk
+	reconnect func() error
+
+	// report is called after each chunk
+	report TransferProgressFunc
+}
+
+func (t chunkedTransfer) run(ctx context.Context) error {
+	failures := 0
+	for {
+		offset, err := t.offset()
+		if err == nil && offset >= t.total {
+			return nil
+		}
+		if err == nil {
+			chunkCtx, cancel := context.WithTimeout(ctx, t.op.chunkTimeout())
+			length := min(t.op.chunkSize, t.total-offset)
+			err = t.send(chunkCtx, offset, length)
+			cancel()
+			if err == nil {
+				failures = 0
+				t.progress(offset + length)
+				continue
+			}
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if failures >= t.op.retries {
+			return fmt.Errorf("%w after %d attempts: %w", ErrTransferRetriesExhausted, failures+1, err)
+		}
+		if err := sleepContext(ctx, t.op.retryDelayAfter(failures)); err != nil {
+			return err
+		}
+		failures++
+		// the transfer is resumed from the offset of the next attempt even if reconnecting fails
+		if t.reconnect != nil {
+			_ = t.reconnect()
+		}
+	}
+}
+
+func (t chunkedTransfer) progress(transferred int64) {
+	if t.report != nil {
+		t.report(transferred, t.total)
+	}
+	if t.op.progress != nil {
+		t.op.progress(transferred, t.total)
+	}
+}
+
+// retryDelayAfter returns the delay before retrying a chunk that failed failures times
+func (op TransferOp) retryDelayAfter(failures int) time.Duration {
+	delay := op.retryDelay
+	for i := 0; i < failures && delay < maxTransferRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxTransferRetryDelay)
+}
+
+func (op TransferOp) throttle(ctx context.Context, r io.Reader) io.Reader {
+	if op.bandwidthLimit <= 0 {
+		return r
+	}
+	return throttledReader{Reader: r, ctx: ctx, limiter: newRateLimiter(op.bandwidthLimit)}
+}
+
+func (op TransferOp) throttleWriter(ctx context.Context, w io.Writer) io.Writer {
+	if op.bandwidthLimit <= 0 {
+		return w
+	}
+	return throttledWriter{Writer: w, ctx: ctx, limiter: newRateLimiter(op.bandwidthLimit)}
+}
+
+// rateLimiter delays transfers so that their average rate stays below bytesPerSecond
+type rateLimiter struct {
+	bytesPerSecond int64
+	start          time.Time
+	total          int64
+}
+
+func newRateLimiter(bytesPerSecond int64) *rateLimiter {
+	return &rateLimiter{bytesPerSecond: bytesPerSecond, start: time.Now()}
+}
+
+// delay accounts n transferred bytes at now and returns how long to wait before the next transfer
+func (l *rateLimiter) delay(n int, now time.Time) time.Duration {
+	l.total += int64(n)
+	expected := time.Duration(float64(l.total) / float64(l.bytesPerSecond) * float64(time.Second))
+	return expected - now.Sub(l.start)
+}
+
+func (l *rateLimiter) wait(ctx context.Context, n int) error {
+	return sleepContext(ctx, l.delay(n, time.Now()))
+}
+
+type throttledReader struct {
+	io.Reader
+	ctx     context.Context
+	limiter *rateLimiter
+}
+
+func (r throttledReader) Read(b []byte) (int, error) {
+	// keep bursts short on slow limits
+	if int64(len(b)) > r.limiter.bytesPerSecond {
+		b = b[:r.limiter.bytesPerSecond]
+	}
+	n, err := r.Reader.Read(b)
+	if waitErr := r.limiter.wait(r.ctx, n); waitErr != nil && err == nil {
+		err = waitErr
+	}
+	return n, err
+}
+
+type throttledWriter struct {
+	io.Writer
+	ctx     context.Context
+	limiter *rateLimiter
+}
+
+func (w throttledWriter) Write(b []byte) (int, error) {
+	n, err := w.Writer.Write(b)
+	if waitErr := w.limiter.wait(w.ctx, n); waitErr != nil && err == nil {
+		err = waitErr
+	}
+	return n, err
+}
+
+// sleepContext sleeps for d, returning early with the context error if ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransfer simulates a remote file receiving chunks, failing the sends listed in failures
+// after writing half of the chunk
+type fakeTransfer struct {
+	received   int64
+	sends      int
+	failures   map[int]bool
+	reconnects int
+}
+
+func (f *fakeTransfer) transfer(total int64, opts ...TransferOption) chunkedTransfer {
+	return chunkedTransfer{
+		op:     newTransferOp(append([]TransferOption{WithTransferRetryDelay(0)}, opts...)...),
+		total:  total,
+		offset: func() (int64, error) { return f.received, nil },
+		send: func(_ context.Context, offset int64, length int64) error {
+			f.sends++
+			if offset != f.received {
+				return errors.New("unexpected offset")
+			}
+			if f.failures[f.sends] {
+				f.received += length / 2
+				return errors.New("connection reset")
+			}
+			f.received += length
+			return nil
+		},
+		reconnect: func() error {
+			f.reconnects++
+			return nil
+		},
+	}
+}
+
+func TestChunkedTransfer_Run(t *testing.T) {
+	fake := &fakeTransfer{failures: map[int]bool{2: true, 3: true}}
+	progress := []int64{}
+	transfer := fake.transfer(100, WithChunkSize(40), WithTransferRetries(2), WithTransferProgress(func(transferred int64, total int64) {
+		assert.Equal(t, int64(100), total)
+		progress = append(progress, transferred)
+	}))
+	require.NoError(t, transfer.run(context.Background()))
+	assert.Equal(t, int64(100), fake.received)
+	assert.Equal(t, 2, fake.reconnects)
+	// failed chunks are resumed from the bytes received
+	assert.Equal(t, []int64{40, 100}, progress)
+}
+
+func TestChunkedTransfer_Resume(t *testing.T) {
+	fake := &fakeTransfer{received: 90}
+	require.NoError(t, fake.transfer(100, WithChunkSize(40)).run(context.Background()))
+	assert.Equal(t, 1, fake.sends)
+
+	fake = &fakeTransfer{received: 100}
+	require.NoError(t, fake.transfer(100).run(context.Background()))
+	assert.Equal(t, 0, fake.sends)
+}
+
+func TestChunkedTransfer_RetriesExhausted(t *testing.T) {
+	fake := &fakeTransfer{failures: map[int]bool{1: true, 2: true, 3: true}}
+	err := fake.transfer(100, WithChunkSize(40), WithTransferRetries(2)).run(context.Background())
+	require.ErrorIs(t, err, ErrTransferRetriesExhausted)
+	assert.Equal(t, 3, fake.sends)
+}
+
+func TestChunkedTransfer_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	fake := &fakeTransfer{failures: map[int]bool{1: true}}
+	transfer := fake.transfer(100)
+	transfer.send = func(context.Context, int64, int64) error {
+		cancel()
+		return errors.New("connection reset")
+	}
+	require.ErrorIs(t, transfer.run(ctx), context.Canceled)
+	assert.Equal(t, 0, fake.reconnects)
+}
+
+func TestTransferOp_RetryDelayAfter(t *testing.T) {
+	op := newTransferOp()
+	assert.Equal(t, DefaultTransferRetryDelay, op.retryDelayAfter(0))
+	assert.Equal(t, 4*DefaultTransferRetryDelay, op.retryDelayAfter(2))
+	assert.Equal(t, maxTransferRetryDelay, op.retryDelayAfter(100))
+}
+
+func TestNewTransferOp(t *testing.T) {
+	op := newTransferOp(WithChunkSize(0), WithTransferRetries(-1))
+	assert.Equal(t, int64(DefaultTransferChunkSize), op.chunkSize)
+	assert.Equal(t, 0, op.retries)
+	assert.Equal(t, int64(0), op.bandwidthLimit)
+}
+
+func TestRateLimiter_Delay(t *testing.T) {
+	start := time.Now()
+	limiter := &rateLimiter{bytesPerSecond: 1000, start: start}
+	assert.Equal(t, 500*time.Millisecond, limiter.delay(500, start))
+	assert.Equal(t, 500*time.Millisecond, limiter.delay(500, start.Add(500*time.Millisecond)))
+	// slower transfers are not delayed
+	assert.Negative(t, limiter.delay(100, start.Add(5*time.Second)))
+}
+
+func TestThrottledReader(t *testing.T) {
+	op := newTransferOp(WithBandwidthLimit(1000))
+	var out bytes.Buffer
+	start := time.Now()
+	_, err := io.Copy(&out, op.throttle(context.Background(), bytes.NewReader(make([]byte, 200))))
+	require.NoError(t, err)
+	assert.Equal(t, 200, out.Len())
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = io.Copy(io.Discard, op.throttle(ctx, bytes.NewReader(make([]byte, 2000))))
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestParseFileSize(t *testing.T) {
+	size, exists, err := parseFileSize("1234\n")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, int64(1234), size)
+
+	_, exists, err = parseFileSize("")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, _, err = parseFileSize("stat: cannot stat")
+	assert.Error(t, err)
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+// ErrNodeNotUpgradeReady is the result error of the nodes reported non-compliant by
+// UpgradeReadiness
+var ErrNodeNotUpgradeReady = errors.New("node is not ready for the network upgrade")
+
+// UpgradeReadinessReport describes how ready a node is for an upcoming network upgrade
+type UpgradeReadinessReport struct {
+	NodeID string
+
+	// Version is the odysseygo version run by the node when it was checked, and
+	// RequiredVersion the minimum version of the network upgrade
+	Version         string
+	RequiredVersion string
+
+	// TimeRemaining is the time left before the activation of the upgrade, negative once
+	// it is activated
+	TimeRemaining time.Duration
+
+	// Issues describe why the node is not ready, if it is not
+	Issues []string
+
+	// Upgraded tells if odysseygo was upgraded to RequiredVersion by UpgradeReadiness, see
+	// WithReadinessUpgrade
+	Upgraded bool
+}
+
+// Ready returns true if the node raised no issue
+func (r UpgradeReadinessReport) Ready() bool {
+	return len(r.Issues) == 0
+}
+
+// err returns ErrNodeNotUpgradeReady with the issues of r and the time remaining, if any
+func (r UpgradeReadinessReport) err() error {
+	if r.Ready() {
+		return nil
+	}
+	remaining := fmt.Sprintf("%s before activation", r.TimeRemaining.Round(time.Second))
+	if r.TimeRemaining <= 0 {
+		remaining = fmt.Sprintf("upgrade activated %s ago", (-r.TimeRemaining).Round(time.Second))
+	}
+	return fmt.Errorf("%w: node %s: %s (%s)", ErrNodeNotUpgradeReady, r.NodeID, strings.Join(r.Issues, "; "), remaining)
+}
+
+// UpgradeReadinessOp holds the options of UpgradeReadiness
+type UpgradeReadinessOp struct {
+	nodeConfig    map[string]interface{}
+	upgrade       bool
+	healthTimeout time.Duration
+}
+
+// UpgradeReadinessOption configures UpgradeReadiness
+type UpgradeReadinessOption func(*UpgradeReadinessOp)
+
+// WithRequiredNodeConfig makes UpgradeReadiness check that the odysseygo config of the nodes
+// holds the values of nodeConfig, e.g. flags the network upgrade depends on
+func WithRequiredNodeConfig(nodeConfig map[string]interface{}) UpgradeReadinessOption {
+	return func(op *UpgradeReadinessOp) {
+		op.nodeConfig = nodeConfig
+	}
+}
+
+// WithReadinessUpgrade makes UpgradeReadiness upgrade odysseygo to the required version on
+// the nodes running an older one, waiting up to healthTimeout for each of them to be healthy.
+// At most limit nodes are upgraded at once, so that the fleet is upgraded in rolling batches
+func WithReadinessUpgrade(healthTimeout time.Duration) UpgradeReadinessOption {
+	return func(op *UpgradeReadinessOp) {
+		op.upgrade = true
+		op.healthTimeout = healthTimeout
+	}
+}
+
+// getNodeConfig returns the odysseygo config of node
+var getNodeConfig = func(node *Node) (map[string]interface{}, error) {
+	return node.GetOdysseyGoConfigData()
+}
+
+// upgradeNode upgrades odysseygo to version on node, see Node.UpgradeOdysseyGo
+var upgradeNode = func(node *Node, version string, healthTimeout time.Duration) error {
+	return node.UpgradeOdysseyGo(version, healthTimeout)
+}
+
+// UpgradeReadiness checks the nodes against a network upgrade activated at activationTime,
+// which requires odysseygo requiredVersion or newer, e.g. v1.10.13. The value of each result
+// is the UpgradeReadinessReport of the node, and non-compliant nodes fail with
+// ErrNodeNotUpgradeReady and the time remaining before the activation. With
+// WithReadinessUpgrade, the nodes running an older odysseygo are upgraded and checked again
+func UpgradeReadiness(
+	ctx context.Context,
+	nodes []*Node,
+	requiredVersion string,
+	activationTime time.Time,
+	limit int,
+	options ...UpgradeReadinessOption,
+) *NodeResults {
+	op := UpgradeReadinessOp{}
+	for _, option := range options {
+		option(&op)
+	}
+	var versionErr error
+	if !semver.IsValid(requiredVersion) {
+		versionErr = fmt.Errorf("%w: %q", ErrInvalidVersion, requiredVersion)
+	}
+	return RunOnNodes(nodes, limit, func(node *Node) (interface{}, error) {
+		report := UpgradeReadinessReport{
+			NodeID:          node.NodeID,
+			RequiredVersion: requiredVersion,
+			TimeRemaining:   time.Until(activationTime),
+		}
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if versionErr != nil {
+			return report, versionErr
+		}
+		if err := checkUpgradeReadiness(node, &report, op); err != nil {
+			return report, err
+		}
+		if !report.Ready() && op.upgrade && semver.Compare(report.Version, requiredVersion) < 0 {
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+			if err := upgradeNode(node, requiredVersion, op.healthTimeout); err != nil {
+				return report, fmt.Errorf("failed to upgrade node %s to %s: %w", node.NodeID, requiredVersion, err)
+			}
+			report.Upgraded = true
+			if err := checkUpgradeReadiness(node, &report, op); err != nil {
+				return report, err
+			}
+		}
+		return report, report.err()
+	})
+}
+
+// checkUpgradeReadiness fills report with the version of node and the issues keeping it from
+// being ready
+func checkUpgradeReadiness(node *Node, report *UpgradeReadinessReport, op UpgradeReadinessOp) error {
+	report.Issues = nil
+	version, err := getNodeVersion(node)
+	if err != nil {
+		return err
+	}
+	report.Version = version
+	if semver.Compare(version, report.RequiredVersion) < 0 {
+		report.Issues = append(report.Issues, fmt.Sprintf("runs odysseygo %s, %s required", version, report.RequiredVersion))
+	}
+	if len(op.nodeConfig) == 0 {
+		return nil
+	}
+	nodeConfig, err := getNodeConfig(node)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(op.nodeConfig))
+	for key := range op.nodeConfig {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, ok := nodeConfig[key]
+		switch {
+		case !ok:
+			report.Issues = append(report.Issues, fmt.Sprintf("config has no %s", key))
+		case fmt.Sprint(value) != fmt.Sprint(op.nodeConfig[key]):
+			report.Issues = append(report.Issues, fmt.Sprintf("config sets %s to %v, %v required", key, value, op.nodeConfig[key]))
+		}
+	}
+	return nil
+}
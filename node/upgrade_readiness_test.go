@@ -0,0 +1,96 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockUpgradeReadiness(t *testing.T, versions map[string]string, nodeConfig map[string]interface{}, upgradeErr error) {
+	originalVersion, originalConfig, originalUpgrade := getNodeVersion, getNodeConfig, upgradeNode
+	t.Cleanup(func() {
+		getNodeVersion, getNodeConfig, upgradeNode = originalVersion, originalConfig, originalUpgrade
+	})
+	lock := &sync.Mutex{}
+	getNodeVersion = func(node *Node) (string, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		return versions[node.NodeID], nil
+	}
+	getNodeConfig = func(*Node) (map[string]interface{}, error) {
+		return nodeConfig, nil
+	}
+	upgradeNode = func(node *Node, version string, _ time.Duration) error {
+		if upgradeErr != nil {
+			return upgradeErr
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		versions[node.NodeID] = version
+		return nil
+	}
+}
+
+func TestUpgradeReadiness(t *testing.T) {
+	versions := map[string]string{"node-1": "v1.10.13", "node-2": "v1.10.9"}
+	mockUpgradeReadiness(t, versions, map[string]interface{}{"network-id": "testnet", "state-sync-enabled": false}, nil)
+	nodes := []*Node{{NodeID: "node-1"}, {NodeID: "node-2"}}
+	activation := time.Now().Add(48 * time.Hour)
+
+	results := UpgradeReadiness(context.Background(), nodes, "v1.10.13", activation, 1)
+	require.Len(t, results.Succeeded(), 1)
+	require.Len(t, results.Failed(), 1)
+	failed := results.Failed()[0]
+	assert.Equal(t, "node-2", failed.NodeID)
+	require.ErrorIs(t, failed.Err, ErrNodeNotUpgradeReady)
+	assert.Contains(t, failed.Err.Error(), "before activation")
+	report := failed.Value.(UpgradeReadinessReport)
+	assert.Equal(t, "v1.10.9", report.Version)
+	assert.Len(t, report.Issues, 1)
+	assert.Greater(t, report.TimeRemaining, 47*time.Hour)
+	assert.False(t, report.Upgraded)
+
+	results = UpgradeReadiness(context.Background(), nodes, "v1.10.13", activation, 1,
+		WithRequiredNodeConfig(map[string]interface{}{"state-sync-enabled": true, "partial-sync-primary-network": true}),
+	)
+	require.Len(t, results.Failed(), 2)
+	result, ok := results.Get("node-1")
+	require.True(t, ok)
+	assert.Len(t, result.Value.(UpgradeReadinessReport).Issues, 2)
+
+	results = UpgradeReadiness(context.Background(), nodes, "v1.10.13", activation, 1, WithReadinessUpgrade(time.Minute))
+	require.Empty(t, results.Failed())
+	result, ok = results.Get("node-2")
+	require.True(t, ok)
+	assert.True(t, result.Value.(UpgradeReadinessReport).Upgraded)
+	assert.Equal(t, "v1.10.13", versions["node-2"])
+}
+
+func TestUpgradeReadiness_Errors(t *testing.T) {
+	versions := map[string]string{"node-1": "v1.10.9"}
+	mockUpgradeReadiness(t, versions, nil, errors.New("health timeout"))
+	nodes := []*Node{{NodeID: "node-1"}}
+
+	results := UpgradeReadiness(context.Background(), nodes, "1.10.13", time.Now(), 0)
+	require.ErrorIs(t, results.Failed()[0].Err, ErrInvalidVersion)
+
+	results = UpgradeReadiness(context.Background(), nodes, "v1.10.13", time.Now().Add(-time.Hour), 0)
+	assert.Contains(t, results.Failed()[0].Err.Error(), "upgrade activated")
+
+	results = UpgradeReadiness(context.Background(), nodes, "v1.10.13", time.Now(), 0, WithReadinessUpgrade(time.Minute))
+	assert.ErrorContains(t, results.Failed()[0].Err, "health timeout")
+	assert.Equal(t, "v1.10.9", versions["node-1"])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results = UpgradeReadiness(ctx, nodes, "v1.10.13", time.Now(), 0)
+	require.ErrorIs(t, results.Failed()[0].Err, context.Canceled)
+}
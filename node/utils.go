@@ -8,11 +8,9 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 
-	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
 	remoteconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/node/config"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
 	"golang.org/x/exp/slices"
@@ -22,6 +20,10 @@ const (
 	maxResponseSize      = 102400          // 100KB should be enough to read the odysseygo response
 	sshConnectionTimeout = 3 * time.Second // usually takes less than 2
 	sshConnectionRetries = 5
+	// sshConnectionRetryInterval is the first delay between SSH connection attempts, doubling
+	// up to constants.SSHSleepBetweenChecks
+	sshConnectionRetryInterval = 250 * time.Millisecond
+	sshForwardRetries          = 3
 )
 
 // getDefaultProjectNameFromGCPCredentials returns the default GCP project name
@@ -86,6 +88,14 @@ func isLoadTestNode(node Node) bool {
 	return slices.Contains(node.Roles, Loadtest)
 }
 
+// isRelayerNode checks if the node has the Relayer role.
+//
+// - node *Node: The node to check.
+// bool
+func isRelayerNode(node Node) bool {
+	return slices.Contains(node.Roles, Relayer)
+}
+
 // getPrometheusTargets returns the Prometheus targets for the given nodes.
 //
 // Parameters:
@@ -101,27 +111,44 @@ func getPrometheusTargets(nodes []Node) ([]string, []string, []string) {
 	ltPorts := []string{}
 	for _, host := range nodes {
 		if isOdysseyGoNode(host) {
-			odysseyGoPorts = append(odysseyGoPorts, fmt.Sprintf("'%s:%s'", host.IP, strconv.Itoa(constants.OdysseygoAPIPort)))
-			machinePorts = append(machinePorts, fmt.Sprintf("'%s:%s'", host.IP, strconv.Itoa(constants.OdysseygoMachineMetricsPort)))
+			odysseyGoPorts = append(odysseyGoPorts, fmt.Sprintf("'%s:%d'", host.IP, host.config().OdysseygoAPIPort))
+			machinePorts = append(machinePorts, fmt.Sprintf("'%s:%d'", host.IP, host.config().MachineMetricsPort))
 		}
 		if isLoadTestNode(host) {
-			ltPorts = append(ltPorts, fmt.Sprintf("'%s:%s'", host.IP, strconv.Itoa(constants.OdysseygoLoadTestPort)))
+			ltPorts = append(ltPorts, fmt.Sprintf("'%s:%d'", host.IP, host.config().LoadTestPort))
 		}
 	}
 	return odysseyGoPorts, machinePorts, ltPorts
 }
 
+// getRelayerPrometheusTargets returns the Prometheus targets of the AWM relayers of the given nodes,
+// and the machine metrics targets of the relayer nodes not running odysseygo
+func getRelayerPrometheusTargets(nodes []Node) ([]string, []string) {
+	relayerPorts := []string{}
+	machinePorts := []string{}
+	for _, host := range nodes {
+		if !isRelayerNode(host) {
+			continue
+		}
+		relayerPorts = append(relayerPorts, fmt.Sprintf("'%s:%d'", host.IP, host.config().AWMRelayerMetricsPort))
+		if !isOdysseyGoNode(host) {
+			machinePorts = append(machinePorts, fmt.Sprintf("'%s:%d'", host.IP, host.config().MachineMetricsPort))
+		}
+	}
+	return relayerPorts, machinePorts
+}
+
 func composeFileExists(node Node) bool {
-	composeFileExists, _ := node.FileExists(utils.GetRemoteComposeFile())
+	composeFileExists, _ := node.FileExists(node.config().ComposeFile())
 	return composeFileExists
 }
 
 func genesisFileExists(node Node) bool {
-	genesisFileExists, _ := node.FileExists(remoteconfig.GetRemoteOdysseyGenesis())
+	genesisFileExists, _ := node.FileExists(remoteconfig.GetRemoteOdysseyGenesis(node.config()))
 	return genesisFileExists
 }
 
 func nodeConfigFileExists(node Node) bool {
-	nodeConfigFileExists, _ := node.FileExists(remoteconfig.GetRemoteOdysseyNodeConfig())
+	nodeConfigFileExists, _ := node.FileExists(remoteconfig.GetRemoteOdysseyNodeConfig(node.config()))
 	return nodeConfigFileExists
 }
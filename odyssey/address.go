@@ -0,0 +1,93 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package odyssey
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/formatting/address"
+)
+
+// Aliases of the chains with bech32 addresses
+const (
+	OChainAlias = "O"
+	AChainAlias = "A"
+	DChainAlias = "D"
+)
+
+var (
+	// ErrMalformedAddress is returned for addresses that are not <chain>-<bech32>
+	ErrMalformedAddress = errors.New("malformed address")
+	// ErrEmptyChainAlias is returned for addresses or formats without chain alias
+	ErrEmptyChainAlias = errors.New("address chain alias cannot be empty")
+	// ErrInvalidAddressLength is returned for addresses whose payload is not a 20 bytes short ID
+	ErrInvalidAddressLength = errors.New("address payload must be 20 bytes long")
+	// ErrWrongHRP is returned for well formed addresses of another network
+	ErrWrongHRP = errors.New("address HRP does not match the network")
+	// ErrWrongChain is returned for well formed addresses of another chain
+	ErrWrongChain = errors.New("address chain does not match the expected chain")
+)
+
+// ParseAddress splits a bech32 chain address such as O-dione1... into its chain alias, HRP
+// and short ID. Malformed addresses, including bad checksums, fail with ErrMalformedAddress;
+// use Network.ParseAddress to also check the HRP
+func ParseAddress(addr string) (string, string, ids.ShortID, error) {
+	chain, hrp, payload, err := address.Parse(addr)
+	if err != nil {
+		return "", "", ids.ShortEmpty, fmt.Errorf("%w %q: %w", ErrMalformedAddress, addr, err)
+	}
+	if chain == "" || strings.TrimSpace(chain) != chain {
+		return "", "", ids.ShortEmpty, fmt.Errorf("%w: %q", ErrEmptyChainAlias, addr)
+	}
+	if len(payload) != ids.ShortIDLen {
+		return "", "", ids.ShortEmpty, fmt.Errorf("%w: %q has %d bytes", ErrInvalidAddressLength, addr, len(payload))
+	}
+	shortID, err := ids.ToShortID(payload)
+	if err != nil {
+		return "", "", ids.ShortEmpty, fmt.Errorf("%w %q: %w", ErrMalformedAddress, addr, err)
+	}
+	return chain, hrp, shortID, nil
+}
+
+// FormatAddress formats shortID as a bech32 address of chain with hrp, e.g. O-dione1...
+func FormatAddress(chain string, hrp string, shortID ids.ShortID) (string, error) {
+	if chain == "" {
+		return "", ErrEmptyChainAlias
+	}
+	return address.Format(chain, hrp, shortID[:])
+}
+
+// ParseAddress parses addr as ParseAddress does, also checking it belongs to network.
+// Addresses of other networks fail with ErrWrongHRP
+func (n Network) ParseAddress(addr string) (string, ids.ShortID, error) {
+	chain, hrp, shortID, err := ParseAddress(addr)
+	if err != nil {
+		return "", ids.ShortEmpty, err
+	}
+	if hrp != n.HRP() {
+		return "", ids.ShortEmpty, fmt.Errorf("%w: %q has HRP %s, expected %s", ErrWrongHRP, addr, hrp, n.HRP())
+	}
+	return chain, shortID, nil
+}
+
+// ParseChainAddress parses addr as an address of chain on network, e.g. an O-Chain address.
+// Addresses of other chains fail with ErrWrongChain
+func (n Network) ParseChainAddress(chain string, addr string) (ids.ShortID, error) {
+	addrChain, shortID, err := n.ParseAddress(addr)
+	if err != nil {
+		return ids.ShortEmpty, err
+	}
+	if addrChain != chain {
+		return ids.ShortEmpty, fmt.Errorf("%w: %q is on chain %s, expected %s", ErrWrongChain, addr, addrChain, chain)
+	}
+	return shortID, nil
+}
+
+// FormatAddress formats shortID as a bech32 address of chain on network
+func (n Network) FormatAddress(chain string, shortID ids.ShortID) (string, error) {
+	return FormatAddress(chain, n.HRP(), shortID)
+}
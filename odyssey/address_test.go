@@ -0,0 +1,81 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package odyssey
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/formatting/address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormatAddress(t *testing.T) {
+	shortID := ids.GenerateTestShortID()
+	for _, chain := range []string{OChainAlias, AChainAlias, DChainAlias} {
+		addr, err := FormatAddress(chain, "dione", shortID)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(addr, chain+"-dione1"))
+
+		parsedChain, hrp, parsedID, err := ParseAddress(addr)
+		require.NoError(t, err)
+		assert.Equal(t, chain, parsedChain)
+		assert.Equal(t, "dione", hrp)
+		assert.Equal(t, shortID, parsedID)
+	}
+
+	_, err := FormatAddress("", "dione", shortID)
+	assert.ErrorIs(t, err, ErrEmptyChainAlias)
+}
+
+func TestParseAddress_Errors(t *testing.T) {
+	valid, err := FormatAddress(OChainAlias, "dione", ids.GenerateTestShortID())
+	require.NoError(t, err)
+	longPayload, err := address.Format(OChainAlias, "dione", make([]byte, 32))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		addr        string
+		expectedErr error
+	}{
+		{name: "empty", addr: "", expectedErr: ErrMalformedAddress},
+		{name: "no chain separator", addr: strings.TrimPrefix(valid, "O-"), expectedErr: ErrMalformedAddress},
+		{name: "bad checksum", addr: valid[:len(valid)-1] + "q", expectedErr: ErrMalformedAddress},
+		{name: "mixed case", addr: "O-" + strings.ToUpper(valid[2:4]) + valid[4:], expectedErr: ErrMalformedAddress},
+		{name: "empty chain", addr: "-" + valid[2:], expectedErr: ErrEmptyChainAlias},
+		{name: "wrong payload length", addr: longPayload, expectedErr: ErrInvalidAddressLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := ParseAddress(tt.addr)
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestNetwork_ParseAddress(t *testing.T) {
+	shortID := ids.GenerateTestShortID()
+	mainnet, testnet := MainnetNetwork(), TestnetNetwork()
+	addr, err := mainnet.FormatAddress(OChainAlias, shortID)
+	require.NoError(t, err)
+
+	chain, parsedID, err := mainnet.ParseAddress(addr)
+	require.NoError(t, err)
+	assert.Equal(t, OChainAlias, chain)
+	assert.Equal(t, shortID, parsedID)
+
+	_, _, err = testnet.ParseAddress(addr)
+	assert.ErrorIs(t, err, ErrWrongHRP)
+
+	parsedID, err = mainnet.ParseChainAddress(OChainAlias, addr)
+	require.NoError(t, err)
+	assert.Equal(t, shortID, parsedID)
+	_, err = mainnet.ParseChainAddress(AChainAlias, addr)
+	assert.ErrorIs(t, err, ErrWrongChain)
+	_, err = mainnet.ParseChainAddress(OChainAlias, "O-not-an-address")
+	assert.ErrorIs(t, err, ErrMalformedAddress)
+}
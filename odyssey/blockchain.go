@@ -0,0 +1,102 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package odyssey
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"github.com/DioneProtocol/odysseygo/api/info"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+)
+
+const bootstrapCheckFrequency = 2 * time.Second
+
+// BlockchainInfo describes a blockchain deployed on a network and where it can be reached
+type BlockchainInfo struct {
+	// Name is the (non-unique) human-readable name given to the blockchain on CreateChainTx
+	Name string
+
+	// BlockchainID is the ID of the CreateChainTx that created the blockchain
+	BlockchainID ids.ID
+
+	// SubnetID is the ID of the Subnet that validates the blockchain
+	SubnetID ids.ID
+
+	// VMID is the ID of the VM that the blockchain runs
+	VMID ids.ID
+
+	// RPCURL is the ready to use RPC endpoint for the blockchain (e.g. for MetaMask or a dapp)
+	RPCURL string
+
+	// WSURL is the websocket endpoint for the blockchain
+	WSURL string
+}
+
+// GetBlockchainInfo looks up a blockchain on the O-Chain of the given network, either by its
+// blockchain ID or by its name, and returns its IDs together with its RPC endpoints.
+//
+// As blockchain names are not unique, an error is returned if more than one blockchain
+// has the given name. Use the blockchain ID in that case.
+func GetBlockchainInfo(network Network, chainNameOrID string) (*BlockchainInfo, error) {
+	if chainNameOrID == "" {
+		return nil, fmt.Errorf("blockchain name or ID cannot be empty")
+	}
+	pClient := omegavm.NewClient(network.Endpoint)
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	blockchains, err := pClient.GetBlockchains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failure getting blockchains from %s: %w", network.Endpoint, err)
+	}
+	matches := utils.Filter(blockchains, func(b omegavm.APIBlockchain) bool {
+		return b.ID.String() == chainNameOrID || b.Name == chainNameOrID
+	})
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("blockchain %s not found on %s", chainNameOrID, network.Kind)
+	case 1:
+	default:
+		// an ID match is always unique, so this can only be a name clash
+		return nil, fmt.Errorf("found %d blockchains named %s on %s, please use the blockchain ID instead", len(matches), chainNameOrID, network.Kind)
+	}
+	return newBlockchainInfo(network, matches[0]), nil
+}
+
+func newBlockchainInfo(network Network, blockchain omegavm.APIBlockchain) *BlockchainInfo {
+	return &BlockchainInfo{
+		Name:         blockchain.Name,
+		BlockchainID: blockchain.ID,
+		SubnetID:     blockchain.SubnetID,
+		VMID:         blockchain.VMID,
+		RPCURL:       network.BlockchainEndpoint(blockchain.ID.String()),
+		WSURL:        network.BlockchainWSEndpoint(blockchain.ID.String()),
+	}
+}
+
+// IsChainBootstrapped checks whether the node behind the network endpoint has finished
+// bootstrapping the given blockchain
+func IsChainBootstrapped(network Network, blockchainID ids.ID) (bool, error) {
+	infoClient := info.NewClient(network.Endpoint)
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	return infoClient.IsBootstrapped(ctx, blockchainID.String())
+}
+
+// WaitForChainBootstrapped waits until the node behind the network endpoint has finished
+// bootstrapping the given blockchain, or until timeout is reached.
+// After a CreateChainTx is accepted, the RPC URL of the blockchain is only usable after
+// bootstrapping finishes.
+func WaitForChainBootstrapped(network Network, blockchainID ids.ID, timeout time.Duration) error {
+	infoClient := info.NewClient(network.Endpoint)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if _, err := info.AwaitBootstrapped(ctx, infoClient, blockchainID.String(), bootstrapCheckFrequency); err != nil {
+		return fmt.Errorf("timeout: blockchain %s is not bootstrapped on %s after %ds: %w", blockchainID, network.Endpoint, int(timeout.Seconds()), err)
+	}
+	return nil
+}
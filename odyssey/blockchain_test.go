@@ -0,0 +1,126 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package odyssey
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newJSONRPCServer returns a test server answering every JSON-RPC method found in results
+func newJSONRPCServer(t *testing.T, results map[string]interface{}) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Method string          `json:"method"`
+			ID     json.RawMessage `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		response := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+		}
+		if result, ok := results[request.Method]; ok {
+			response["result"] = result
+		} else {
+			response["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testBlockchains() []omegavm.APIBlockchain {
+	return []omegavm.APIBlockchain{
+		{ID: ids.GenerateTestID(), Name: "mychain", SubnetID: ids.GenerateTestID(), VMID: ids.GenerateTestID()},
+		{ID: ids.GenerateTestID(), Name: "dup", SubnetID: ids.GenerateTestID(), VMID: ids.GenerateTestID()},
+		{ID: ids.GenerateTestID(), Name: "dup", SubnetID: ids.GenerateTestID(), VMID: ids.GenerateTestID()},
+	}
+}
+
+func TestGetBlockchainInfo(t *testing.T) {
+	blockchains := testBlockchains()
+	server := newJSONRPCServer(t, map[string]interface{}{
+		"omega.getBlockchains": map[string]interface{}{"blockchains": blockchains},
+	})
+	network := NewNetwork(Devnet, constants.LocalID, server.URL)
+
+	t.Run("by name", func(t *testing.T) {
+		chainInfo, err := GetBlockchainInfo(network, "mychain")
+		require.NoError(t, err)
+		assert.Equal(t, blockchains[0].ID, chainInfo.BlockchainID)
+		assert.Equal(t, blockchains[0].SubnetID, chainInfo.SubnetID)
+		assert.Equal(t, blockchains[0].VMID, chainInfo.VMID)
+		assert.Equal(t, "mychain", chainInfo.Name)
+		assert.Equal(t, server.URL+"/ext/bc/"+blockchains[0].ID.String()+"/rpc", chainInfo.RPCURL)
+		assert.Equal(t, network.BlockchainWSEndpoint(blockchains[0].ID.String()), chainInfo.WSURL)
+	})
+
+	t.Run("by ID", func(t *testing.T) {
+		chainInfo, err := GetBlockchainInfo(network, blockchains[1].ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, blockchains[1].ID, chainInfo.BlockchainID)
+	})
+
+	t.Run("ambiguous name", func(t *testing.T) {
+		_, err := GetBlockchainInfo(network, "dup")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "please use the blockchain ID")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := GetBlockchainInfo(network, "missing")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		_, err := GetBlockchainInfo(network, "")
+		require.Error(t, err)
+	})
+}
+
+func TestGetBlockchainInfo_Error(t *testing.T) {
+	network := NewNetwork(Devnet, constants.LocalID, "http://invalid-endpoint:9999")
+	chainInfo, err := GetBlockchainInfo(network, "mychain")
+	assert.Error(t, err)
+	assert.Nil(t, chainInfo)
+}
+
+func TestWaitForChainBootstrapped(t *testing.T) {
+	blockchainID := ids.GenerateTestID()
+
+	t.Run("bootstrapped", func(t *testing.T) {
+		server := newJSONRPCServer(t, map[string]interface{}{
+			"info.isBootstrapped": map[string]interface{}{"isBootstrapped": true},
+		})
+		network := NewNetwork(Devnet, constants.LocalID, server.URL)
+		isBootstrapped, err := IsChainBootstrapped(network, blockchainID)
+		require.NoError(t, err)
+		assert.True(t, isBootstrapped)
+		require.NoError(t, WaitForChainBootstrapped(network, blockchainID, 5*time.Second))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		server := newJSONRPCServer(t, map[string]interface{}{
+			"info.isBootstrapped": map[string]interface{}{"isBootstrapped": false},
+		})
+		network := NewNetwork(Devnet, constants.LocalID, server.URL)
+		err := WaitForChainBootstrapped(network, blockchainID, 100*time.Millisecond)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not bootstrapped")
+	})
+}
@@ -0,0 +1,215 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package odyssey
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/DioneProtocol/odysseygo/cache"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/rpc"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/status"
+)
+
+// txCacheSize is the number of txs cached by each O-Chain client of a ClientFactory
+const txCacheSize = 1024
+
+// DefaultRateLimit is the rate limit of the endpoints of the shared client factory, unless
+// set with SetRateLimit
+var DefaultRateLimit = RateLimit{Rate: 20, Burst: 20}
+
+// RateLimit is the rate of the requests sent to an API endpoint
+type RateLimit struct {
+	// Rate is the number of requests per second. Zero disables the limit
+	Rate float64
+
+	// Burst is the number of requests sent at once, above Rate. 1 if zero
+	Burst int
+}
+
+func (l RateLimit) limit() rate.Limit {
+	if l.Rate <= 0 {
+		return rate.Inf
+	}
+	return rate.Limit(l.Rate)
+}
+
+func (l RateLimit) burst() int {
+	if l.Burst <= 0 {
+		return 1
+	}
+	return l.Burst
+}
+
+// ClientFactory hands out the O-Chain API clients of the SDK, one per endpoint, so that the
+// connections to an endpoint are reused and its requests are rate limited together. It is
+// safe for concurrent use
+type ClientFactory struct {
+	lock         sync.Mutex
+	defaultLimit RateLimit
+	limits       map[string]RateLimit
+	clients      map[string]*OChainClient
+	newClient    func(uri string) omegavm.Client
+}
+
+var sharedClientFactory = NewClientFactory(DefaultRateLimit)
+
+// SharedClientFactory returns the client factory used by the SDK, e.g. by multisig.GetOwners
+// and when a wallet exports a tx to sign offline
+func SharedClientFactory() *ClientFactory {
+	return sharedClientFactory
+}
+
+// NewClientFactory creates a client factory limiting the requests to each endpoint to
+// defaultLimit, unless set with SetRateLimit
+func NewClientFactory(defaultLimit RateLimit) *ClientFactory {
+	return &ClientFactory{
+		defaultLimit: defaultLimit,
+		limits:       map[string]RateLimit{},
+		clients:      map[string]*OChainClient{},
+		newClient:    omegavm.NewClient,
+	}
+}
+
+// SetRateLimit sets the rate limit of endpoint, applying to its client if already created
+func (f *ClientFactory) SetRateLimit(endpoint string, limit RateLimit) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.limits[endpoint] = limit
+	if client, ok := f.clients[endpoint]; ok {
+		client.limiter.SetLimit(limit.limit())
+		client.limiter.SetBurst(limit.burst())
+	}
+}
+
+// RateLimit returns the rate limit of endpoint
+func (f *ClientFactory) RateLimit(endpoint string) RateLimit {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.rateLimit(endpoint)
+}
+
+func (f *ClientFactory) rateLimit(endpoint string) RateLimit {
+	if limit, ok := f.limits[endpoint]; ok {
+		return limit
+	}
+	return f.defaultLimit
+}
+
+// OChain returns the O-Chain client of endpoint, creating it on first use
+func (f *ClientFactory) OChain(endpoint string) *OChainClient {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if client, ok := f.clients[endpoint]; ok {
+		return client
+	}
+	limit := f.rateLimit(endpoint)
+	client := &OChainClient{
+		Client:  f.newClient(endpoint),
+		limiter: rate.NewLimiter(limit.limit(), limit.burst()),
+		txs:     &cache.LRU[ids.ID, []byte]{Size: txCacheSize},
+	}
+	f.clients[endpoint] = client
+	return client
+}
+
+// OChainClient is an O-Chain API client whose queries used by the SDK wait for the rate
+// limit of its endpoint. Txs fetched with GetTx, e.g. the CreateSubnetTx of a subnet, are
+// immutable and cached. The other methods of omegavm.Client are sent right away
+type OChainClient struct {
+	omegavm.Client
+	limiter *rate.Limiter
+	txs     *cache.LRU[ids.ID, []byte]
+}
+
+// GetTx returns the bytes of txID, from the cache if already fetched
+func (c *OChainClient) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error) {
+	if txBytes, ok := c.txs.Get(txID); ok {
+		return txBytes, nil
+	}
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	txBytes, err := c.Client.GetTx(ctx, txID, options...)
+	if err != nil {
+		return nil, err
+	}
+	c.txs.Put(txID, txBytes)
+	return txBytes, nil
+}
+
+func (c *OChainClient) GetSubnets(ctx context.Context, subnetIDs []ids.ID, options ...rpc.Option) ([]omegavm.ClientSubnet, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.Client.GetSubnets(ctx, subnetIDs, options...)
+}
+
+func (c *OChainClient) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (*omegavm.GetTxStatusResponse, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.Client.GetTxStatus(ctx, txID, options...)
+}
+
+func (c *OChainClient) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (ids.ID, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return ids.Empty, err
+	}
+	return c.Client.IssueTx(ctx, txBytes, options...)
+}
+
+// AwaitTxDecided polls the status of txID every freq until it is committed, aborted or
+// dropped, each poll waiting for the rate limit
+func (c *OChainClient) AwaitTxDecided(ctx context.Context, txID ids.ID, freq time.Duration, options ...rpc.Option) (*omegavm.GetTxStatusResponse, error) {
+	ticker := time.NewTicker(freq)
+	defer ticker.Stop()
+	for {
+		res, err := c.GetTxStatus(ctx, txID, options...)
+		if err == nil {
+			switch res.Status {
+			case status.Committed, status.Aborted, status.Dropped:
+				return res, nil
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (c *OChainClient) GetCurrentValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]omegavm.ClientPermissionlessValidator, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.Client.GetCurrentValidators(ctx, subnetID, nodeIDs, options...)
+}
+
+func (c *OChainClient) GetBlockchains(ctx context.Context, options ...rpc.Option) ([]omegavm.APIBlockchain, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.Client.GetBlockchains(ctx, options...)
+}
+
+func (c *OChainClient) GetBalance(ctx context.Context, addrs []ids.ShortID, options ...rpc.Option) (*omegavm.GetBalanceResponse, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.Client.GetBalance(ctx, addrs, options...)
+}
+
+func (c *OChainClient) GetMinStake(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return 0, 0, err
+	}
+	return c.Client.GetMinStake(ctx, subnetID, options...)
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package odyssey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/rpc"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingOClient counts the queries sent to an endpoint
+type countingOClient struct {
+	omegavm.Client
+	getTx      int
+	getSubnets int
+}
+
+func (c *countingOClient) GetTx(_ context.Context, txID ids.ID, _ ...rpc.Option) ([]byte, error) {
+	c.getTx++
+	return txID[:], nil
+}
+
+func (c *countingOClient) GetSubnets(_ context.Context, subnetIDs []ids.ID, _ ...rpc.Option) ([]omegavm.ClientSubnet, error) {
+	c.getSubnets++
+	return []omegavm.ClientSubnet{{ID: subnetIDs[0], Threshold: 1}}, nil
+}
+
+func newCountingClientFactory(limit RateLimit) (*ClientFactory, map[string]*countingOClient) {
+	clients := map[string]*countingOClient{}
+	factory := NewClientFactory(limit)
+	factory.newClient = func(uri string) omegavm.Client {
+		clients[uri] = &countingOClient{}
+		return clients[uri]
+	}
+	return factory, clients
+}
+
+func TestClientFactory_OChain(t *testing.T) {
+	factory, clients := newCountingClientFactory(RateLimit{})
+	a := factory.OChain("http://a:9650")
+	assert.Same(t, a, factory.OChain("http://a:9650"))
+	assert.NotSame(t, a, factory.OChain("http://b:9650"))
+	assert.Len(t, clients, 2)
+}
+
+func TestOChainClient_GetTxCached(t *testing.T) {
+	factory, clients := newCountingClientFactory(RateLimit{})
+	client := factory.OChain("http://a:9650")
+	txID := ids.GenerateTestID()
+	for i := 0; i < 3; i++ {
+		txBytes, err := client.GetTx(context.Background(), txID)
+		require.NoError(t, err)
+		assert.Equal(t, txID[:], txBytes)
+	}
+	assert.Equal(t, 1, clients["http://a:9650"].getTx)
+
+	// subnets can change, so they are not cached
+	for i := 0; i < 3; i++ {
+		_, err := client.GetSubnets(context.Background(), []ids.ID{txID})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, clients["http://a:9650"].getSubnets)
+}
+
+func TestOChainClient_RateLimit(t *testing.T) {
+	factory, _ := newCountingClientFactory(RateLimit{Rate: 0.001, Burst: 1})
+	limited := factory.OChain("http://a:9650")
+	_, err := limited.GetSubnets(context.Background(), []ids.ID{ids.GenerateTestID()})
+	require.NoError(t, err)
+
+	// the next request would wait for ~1000s, past the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = limited.GetSubnets(ctx, []ids.ID{ids.GenerateTestID()})
+	assert.Error(t, err)
+
+	// limits are per endpoint, and apply to the clients already created
+	other := factory.OChain("http://b:9650")
+	_, err = other.GetSubnets(ctx, []ids.ID{ids.GenerateTestID()})
+	require.NoError(t, err)
+	factory.SetRateLimit("http://a:9650", RateLimit{})
+	assert.Equal(t, RateLimit{}, factory.RateLimit("http://a:9650"))
+	assert.Equal(t, RateLimit{Rate: 0.001, Burst: 1}, factory.RateLimit("http://b:9650"))
+	_, err = limited.GetSubnets(ctx, []ids.ID{ids.GenerateTestID()})
+	require.NoError(t, err)
+}
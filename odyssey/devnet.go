@@ -0,0 +1,190 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package odyssey
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/genesis"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/DioneProtocol/odysseygo/utils/units"
+)
+
+const (
+	// DefaultDevnetStakeAmount is the stake of each initial staker of a devnet, the minimum
+	// validator stake of custom networks
+	DefaultDevnetStakeAmount = 2 * units.KiloDione
+
+	// DefaultDevnetStakeDuration is the staking period of the initial stakers of a devnet, the
+	// maximum validator stake duration of custom networks
+	DefaultDevnetStakeDuration = 365 * 24 * time.Hour
+)
+
+var (
+	ErrStandardNetworkID  = errors.New("devnets cannot use the network ID of mainnet, testnet or local")
+	ErrNoDevnetStakers    = errors.New("devnet genesis needs at least one initial staker")
+	ErrDuplicateStaker    = errors.New("duplicate initial staker")
+	ErrEmptyRewardAddress = errors.New("initial staker has no reward address")
+
+	ErrStakedFundsAllocation = errors.New("cannot allocate funds to the staked funds address")
+)
+
+// DevnetStaker is a validator of the primary network of a devnet from genesis
+type DevnetStaker struct {
+	// NodeID is the ID of the node, derived from its staking certificate
+	NodeID ids.NodeID
+
+	// RewardAddress receives the validation rewards of the staker
+	RewardAddress ids.ShortID
+
+	// DelegationFee is the fee charged to delegators, in units of 1/10,000 percent
+	DelegationFee uint32
+}
+
+// DevnetAllocation is DIONE held by an address from genesis
+type DevnetAllocation struct {
+	Address ids.ShortID
+
+	// OChainAmount is available on the O-Chain, e.g. to create subnets and add validators
+	OChainAmount uint64
+
+	// AChainAmount is available on the A-Chain
+	AChainAmount uint64
+}
+
+// DevnetGenesisParams defines the primary network of a devnet
+type DevnetGenesisParams struct {
+	// NetworkID of the devnet, which cannot be the ID of mainnet, testnet or local
+	NetworkID uint32
+
+	// Stakers validate the primary network from genesis
+	Stakers []DevnetStaker
+
+	// Allocations fund the addresses of the devnet
+	Allocations []DevnetAllocation
+
+	// StakeAmount is staked by each staker, DefaultDevnetStakeAmount if zero. The stake
+	// is allocated to StakedFundsAddress, and returned to it at the end of the staking period
+	StakeAmount uint64
+
+	// StakedFundsAddress owns the stake of the stakers, the reward address of the first
+	// staker if empty. It cannot be the address of an allocation
+	StakedFundsAddress ids.ShortID
+
+	// StakeDuration is the staking period of the stakers, DefaultDevnetStakeDuration if zero
+	StakeDuration time.Duration
+
+	// StartTime is the genesis time, which cannot be in the future. Now if zero
+	StartTime time.Time
+
+	// DChainGenesis is the genesis of the D-Chain, the one of the local network if empty
+	DChainGenesis string
+
+	// Message is recorded in the genesis
+	Message string
+}
+
+// Config returns the odysseygo genesis config of the devnet
+func (p DevnetGenesisParams) Config() (*genesis.Config, error) {
+	switch p.NetworkID {
+	case constants.MainnetID, constants.TestnetID, constants.LocalID:
+		return nil, fmt.Errorf("%w: %d", ErrStandardNetworkID, p.NetworkID)
+	}
+	if len(p.Stakers) == 0 {
+		return nil, ErrNoDevnetStakers
+	}
+	config := &genesis.Config{
+		NetworkID:     p.NetworkID,
+		DChainGenesis: p.DChainGenesis,
+		Message:       p.Message,
+	}
+	if config.DChainGenesis == "" {
+		config.DChainGenesis = genesis.LocalConfig.DChainGenesis
+	}
+	startTime := p.StartTime
+	if startTime.IsZero() {
+		startTime = time.Now()
+	}
+	config.StartTime = uint64(startTime.Unix())
+	stakeDuration := p.StakeDuration
+	if stakeDuration == 0 {
+		stakeDuration = DefaultDevnetStakeDuration
+	}
+	config.InitialStakeDuration = uint64(stakeDuration / time.Second)
+
+	stakers := map[ids.NodeID]struct{}{}
+	for _, staker := range p.Stakers {
+		if _, ok := stakers[staker.NodeID]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateStaker, staker.NodeID)
+		}
+		stakers[staker.NodeID] = struct{}{}
+		if staker.RewardAddress == ids.ShortEmpty {
+			return nil, fmt.Errorf("%w: %s", ErrEmptyRewardAddress, staker.NodeID)
+		}
+		config.InitialStakers = append(config.InitialStakers, genesis.Staker{
+			NodeID:        staker.NodeID,
+			RewardAddress: staker.RewardAddress,
+			DelegationFee: staker.DelegationFee,
+		})
+	}
+
+	stakeAmount := p.StakeAmount
+	if stakeAmount == 0 {
+		stakeAmount = DefaultDevnetStakeAmount
+	}
+	stakedFundsAddress := p.StakedFundsAddress
+	if stakedFundsAddress == ids.ShortEmpty {
+		stakedFundsAddress = p.Stakers[0].RewardAddress
+	}
+	// the staked allocation is split evenly among the stakers when building the genesis
+	config.Allocations = append(config.Allocations, genesis.Allocation{
+		DIONEAddr:      stakedFundsAddress,
+		UnlockSchedule: []genesis.LockedAmount{{Amount: stakeAmount * uint64(len(p.Stakers))}},
+	})
+	config.InitialStakedFunds = []ids.ShortID{stakedFundsAddress}
+
+	for _, allocation := range p.Allocations {
+		// all the allocations of the staked funds address are staked
+		if allocation.Address == stakedFundsAddress {
+			return nil, fmt.Errorf("%w: %s", ErrStakedFundsAllocation, allocation.Address)
+		}
+		genesisAllocation := genesis.Allocation{
+			DIONEAddr:     allocation.Address,
+			InitialAmount: allocation.AChainAmount,
+		}
+		if allocation.OChainAmount > 0 {
+			genesisAllocation.UnlockSchedule = []genesis.LockedAmount{{Amount: allocation.OChainAmount}}
+		}
+		config.Allocations = append(config.Allocations, genesisAllocation)
+	}
+	return config, nil
+}
+
+// GenerateDevnetGenesis returns the genesis file of the primary network of a devnet, to be
+// given to its nodes with the genesis-file flag of odysseygo. The genesis is validated as
+// odysseygo does when loading it
+func GenerateDevnetGenesis(params DevnetGenesisParams) ([]byte, error) {
+	config, err := params.Config()
+	if err != nil {
+		return nil, err
+	}
+	unparsedConfig, err := config.Unparse()
+	if err != nil {
+		return nil, err
+	}
+	genesisBytes, err := json.MarshalIndent(unparsedConfig, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	stakingConfig := genesis.GetStakingConfig(params.NetworkID)
+	if _, _, _, err := genesis.FromFlag(params.NetworkID, base64.StdEncoding.EncodeToString(genesisBytes), &stakingConfig); err != nil {
+		return nil, fmt.Errorf("invalid devnet genesis: %w", err)
+	}
+	return genesisBytes, nil
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package odyssey
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/genesis"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateDevnetGenesis(t *testing.T) {
+	rewardAddr := ids.GenerateTestShortID()
+	fundedAddr := ids.GenerateTestShortID()
+	stakers := []DevnetStaker{
+		{NodeID: ids.GenerateTestNodeID(), RewardAddress: rewardAddr, DelegationFee: 20000},
+		{NodeID: ids.GenerateTestNodeID(), RewardAddress: rewardAddr, DelegationFee: 20000},
+	}
+	startTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	genesisBytes, err := GenerateDevnetGenesis(DevnetGenesisParams{
+		NetworkID:   1337,
+		Stakers:     stakers,
+		Allocations: []DevnetAllocation{{Address: fundedAddr, OChainAmount: 1000, AChainAmount: 500}},
+		StartTime:   startTime,
+		Message:     "devnet",
+	})
+	require.NoError(t, err)
+
+	unparsed := genesis.UnparsedConfig{}
+	require.NoError(t, json.Unmarshal(genesisBytes, &unparsed))
+	config, err := unparsed.Parse()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1337), config.NetworkID)
+	assert.Equal(t, uint64(startTime.Unix()), config.StartTime)
+	assert.Equal(t, uint64(DefaultDevnetStakeDuration/time.Second), config.InitialStakeDuration)
+	assert.Equal(t, []ids.ShortID{rewardAddr}, config.InitialStakedFunds)
+	require.Len(t, config.InitialStakers, 2)
+	assert.Equal(t, stakers[1].NodeID, config.InitialStakers[1].NodeID)
+	assert.Equal(t, genesis.LocalConfig.DChainGenesis, config.DChainGenesis)
+	assert.Equal(t, "devnet", config.Message)
+	supply, err := config.InitialSupply()
+	require.NoError(t, err)
+	assert.Equal(t, 2*DefaultDevnetStakeAmount+1500, supply)
+}
+
+func TestDevnetGenesisParams_Config(t *testing.T) {
+	staker := DevnetStaker{NodeID: ids.GenerateTestNodeID(), RewardAddress: ids.GenerateTestShortID()}
+	tests := []struct {
+		name        string
+		params      DevnetGenesisParams
+		expectedErr error
+	}{
+		{name: "valid", params: DevnetGenesisParams{NetworkID: 1337, Stakers: []DevnetStaker{staker}}},
+		{name: "mainnet", params: DevnetGenesisParams{NetworkID: constants.MainnetID, Stakers: []DevnetStaker{staker}}, expectedErr: ErrStandardNetworkID},
+		{name: "local", params: DevnetGenesisParams{NetworkID: constants.LocalID, Stakers: []DevnetStaker{staker}}, expectedErr: ErrStandardNetworkID},
+		{name: "no stakers", params: DevnetGenesisParams{NetworkID: 1337}, expectedErr: ErrNoDevnetStakers},
+		{name: "duplicate staker", params: DevnetGenesisParams{NetworkID: 1337, Stakers: []DevnetStaker{staker, staker}}, expectedErr: ErrDuplicateStaker},
+		{
+			name:        "no reward address",
+			params:      DevnetGenesisParams{NetworkID: 1337, Stakers: []DevnetStaker{{NodeID: ids.GenerateTestNodeID()}}},
+			expectedErr: ErrEmptyRewardAddress,
+		},
+		{
+			name: "allocation to staked funds address",
+			params: DevnetGenesisParams{
+				NetworkID:   1337,
+				Stakers:     []DevnetStaker{staker},
+				Allocations: []DevnetAllocation{{Address: staker.RewardAddress, OChainAmount: 1}},
+			},
+			expectedErr: ErrStakedFundsAllocation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.params.Config()
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+
+	// odysseygo rejects genesis starting in the future
+	_, err := GenerateDevnetGenesis(DevnetGenesisParams{NetworkID: 1337, Stakers: []DevnetStaker{staker}, StartTime: time.Now().Add(time.Hour)})
+	assert.ErrorContains(t, err, "invalid devnet genesis")
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package odyssey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odysseygo/api/health"
+	"github.com/DioneProtocol/odysseygo/api/info"
+)
+
+const (
+	// healthInitialBackoff and healthMaxBackoff bound the time IsUsable waits before checking
+	// again an endpoint found unusable
+	healthInitialBackoff = time.Second
+	healthMaxBackoff     = time.Minute
+)
+
+var (
+	ErrEndpointUnreachable = errors.New("endpoint unreachable")
+	ErrEndpointUnhealthy   = errors.New("endpoint unhealthy")
+)
+
+// healthChains are the aliases of the primary network chains whose bootstrap state is reported
+var healthChains = []string{"O", "A", "D"}
+
+// healthNow is the clock of the backoff of IsUsable. It is replaced in tests
+var healthNow = time.Now
+
+// HealthReport is the state of the node behind the endpoint of a network
+type HealthReport struct {
+	Endpoint string
+
+	// Latency is the round trip time of a request to the info API of the node
+	Latency time.Duration
+
+	NodeVersion string
+
+	// Healthy is the overall health reported by the health API of the node
+	Healthy bool
+
+	// FailingChecks are the names of the failing health checks, sorted
+	FailingChecks []string
+
+	// Bootstrapped tells, by chain alias, whether the node finished bootstrapping the chains
+	// of the primary network. Chains the node does not run are missing
+	Bootstrapped map[string]bool
+}
+
+// Err returns nil if the node is healthy and finished bootstrapping the O-Chain, or an error
+// matching ErrEndpointUnhealthy telling why
+func (r *HealthReport) Err() error {
+	switch {
+	case !r.Healthy && len(r.FailingChecks) > 0:
+		return fmt.Errorf("%w: %s: failing health checks: %s", ErrEndpointUnhealthy, r.Endpoint, strings.Join(r.FailingChecks, ", "))
+	case !r.Healthy:
+		return fmt.Errorf("%w: %s", ErrEndpointUnhealthy, r.Endpoint)
+	case !r.Bootstrapped["O"]:
+		return fmt.Errorf("%w: %s: O-Chain is not bootstrapped", ErrEndpointUnhealthy, r.Endpoint)
+	}
+	return nil
+}
+
+// CheckHealth queries the info and health APIs of the node behind the endpoint of n. The error
+// matches ErrEndpointUnreachable when the node does not answer, an unhealthy node being
+// reported by HealthReport.Err instead
+func (n Network) CheckHealth(ctx context.Context) (*HealthReport, error) {
+	report := &HealthReport{
+		Endpoint:     n.Endpoint,
+		Bootstrapped: map[string]bool{},
+	}
+	infoClient := info.NewClient(n.Endpoint)
+	start := time.Now()
+	version, err := withAPITimeout(ctx, func(ctx context.Context) (*info.GetNodeVersionReply, error) {
+		return infoClient.GetNodeVersion(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrEndpointUnreachable, n.Endpoint, err)
+	}
+	report.Latency = time.Since(start)
+	report.NodeVersion = version.Version
+
+	reply, err := withAPITimeout(ctx, func(ctx context.Context) (*health.APIReply, error) {
+		return health.NewClient(n.Endpoint).Health(ctx, nil)
+	})
+	if err != nil {
+		report.FailingChecks = []string{fmt.Sprintf("health API: %s", err)}
+	} else {
+		report.Healthy = reply.Healthy
+		for name, result := range reply.Checks {
+			if result.Error != nil {
+				report.FailingChecks = append(report.FailingChecks, name)
+			}
+		}
+		sort.Strings(report.FailingChecks)
+	}
+
+	for _, chain := range healthChains {
+		bootstrapped, err := withAPITimeout(ctx, func(ctx context.Context) (bool, error) {
+			return infoClient.IsBootstrapped(ctx, chain)
+		})
+		// the node fails for the chains it does not run
+		if err == nil {
+			report.Bootstrapped[chain] = bootstrapped
+		}
+	}
+	return report, ctx.Err()
+}
+
+// healthState is the outcome of the last failed IsUsable check of an endpoint
+type healthState struct {
+	err      error
+	failures int
+	retryAt  time.Time
+}
+
+var healthStates = struct {
+	sync.Mutex
+	byEndpoint map[string]*healthState
+}{byEndpoint: map[string]*healthState{}}
+
+// IsUsable returns nil if the node behind the endpoint of n is reachable, healthy and
+// finished bootstrapping the O-Chain, or an error matching ErrEndpointUnreachable or
+// ErrEndpointUnhealthy. Once an endpoint is found unusable, the calls fail at once with the
+// same error until a backoff doubling with each failure, up to a minute, elapses
+func (n Network) IsUsable(ctx context.Context) error {
+	healthStates.Lock()
+	state := healthStates.byEndpoint[n.Endpoint]
+	if state != nil && healthNow().Before(state.retryAt) {
+		healthStates.Unlock()
+		return state.err
+	}
+	healthStates.Unlock()
+
+	report, err := n.CheckHealth(ctx)
+	if err == nil {
+		err = report.Err()
+	}
+	// the checks interrupted by ctx tell nothing about the endpoint
+	if ctx.Err() != nil {
+		return err
+	}
+
+	healthStates.Lock()
+	defer healthStates.Unlock()
+	if err == nil {
+		delete(healthStates.byEndpoint, n.Endpoint)
+		return nil
+	}
+	state = healthStates.byEndpoint[n.Endpoint]
+	if state == nil {
+		state = &healthState{}
+		healthStates.byEndpoint[n.Endpoint] = state
+	}
+	state.err = err
+	state.failures++
+	backoff := healthInitialBackoff << min(state.failures-1, 6)
+	state.retryAt = healthNow().Add(min(backoff, healthMaxBackoff))
+	return err
+}
+
+// IsReady returns nil if the node behind the endpoint of n answers and finished bootstrapping
+// the O-Chain, or an error matching ErrEndpointUnreachable or ErrEndpointUnhealthy. Unlike
+// IsUsable, it makes a single request, ignores the other health checks of the node and
+// remembers nothing of the endpoint
+func (n Network) IsReady(ctx context.Context) error {
+	bootstrapped, err := withAPITimeout(ctx, func(ctx context.Context) (bool, error) {
+		return info.NewClient(n.Endpoint).IsBootstrapped(ctx, "O")
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrEndpointUnreachable, n.Endpoint, err)
+	}
+	if !bootstrapped {
+		return fmt.Errorf("%w: %s: O-Chain is not bootstrapped", ErrEndpointUnhealthy, n.Endpoint)
+	}
+	return nil
+}
+
+func withAPITimeout[T any](ctx context.Context, fn func(context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.APIRequestTimeout)
+	defer cancel()
+	return fn(ctx)
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package odyssey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setHealthNow makes the backoff of IsUsable use *now as clock, and forgets the endpoints
+// found unusable, until the end of the test
+func setHealthNow(t *testing.T, now *time.Time) {
+	healthNow = func() time.Time { return *now }
+	t.Cleanup(func() {
+		healthNow = time.Now
+		healthStates.Lock()
+		defer healthStates.Unlock()
+		healthStates.byEndpoint = map[string]*healthState{}
+	})
+}
+
+func TestCheckHealth(t *testing.T) {
+	now := time.Now()
+	setHealthNow(t, &now)
+	server := newJSONRPCServer(t, map[string]interface{}{
+		"info.getNodeVersion": map[string]interface{}{"version": "odyssey/1.10.13"},
+		"info.isBootstrapped": map[string]interface{}{"isBootstrapped": true},
+		"health.health":       map[string]interface{}{"healthy": true, "checks": map[string]interface{}{}},
+	})
+	network := NewNetwork(Devnet, constants.LocalID, server.URL)
+
+	report, err := network.CheckHealth(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, server.URL, report.Endpoint)
+	assert.Equal(t, "odyssey/1.10.13", report.NodeVersion)
+	assert.True(t, report.Healthy)
+	assert.Empty(t, report.FailingChecks)
+	assert.Equal(t, map[string]bool{"O": true, "A": true, "D": true}, report.Bootstrapped)
+	require.NoError(t, report.Err())
+	require.NoError(t, network.IsUsable(context.Background()))
+}
+
+func TestCheckHealth_Unhealthy(t *testing.T) {
+	now := time.Now()
+	setHealthNow(t, &now)
+	failure := "no peers"
+	server := newJSONRPCServer(t, map[string]interface{}{
+		"info.getNodeVersion": map[string]interface{}{"version": "odyssey/1.10.13"},
+		"health.health": map[string]interface{}{
+			"healthy": false,
+			"checks": map[string]interface{}{
+				"network":      map[string]interface{}{"error": failure},
+				"bootstrapped": map[string]interface{}{"error": failure},
+				"database":     map[string]interface{}{},
+			},
+		},
+	})
+	network := NewNetwork(Devnet, constants.LocalID, server.URL)
+
+	report, err := network.CheckHealth(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bootstrapped", "network"}, report.FailingChecks)
+	// the node fails for the chains whose bootstrap state is unknown
+	assert.Empty(t, report.Bootstrapped)
+	require.ErrorIs(t, report.Err(), ErrEndpointUnhealthy)
+	require.ErrorContains(t, report.Err(), "bootstrapped, network")
+
+	err = network.IsUsable(context.Background())
+	require.ErrorIs(t, err, ErrEndpointUnhealthy)
+}
+
+func TestIsUsable_Backoff(t *testing.T) {
+	now := time.Now()
+	setHealthNow(t, &now)
+	server := newJSONRPCServer(t, map[string]interface{}{})
+	server.Close()
+	network := NewNetwork(Devnet, constants.LocalID, server.URL)
+
+	err := network.IsUsable(context.Background())
+	require.ErrorIs(t, err, ErrEndpointUnreachable)
+
+	// the endpoint is not checked again before the backoff elapses
+	healthStates.Lock()
+	state := healthStates.byEndpoint[server.URL]
+	healthStates.Unlock()
+	require.NotNil(t, state)
+	assert.Equal(t, now.Add(healthInitialBackoff), state.retryAt)
+	require.Equal(t, err, network.IsUsable(context.Background()))
+	assert.Equal(t, 1, state.failures)
+
+	now = now.Add(healthInitialBackoff)
+	require.ErrorIs(t, network.IsUsable(context.Background()), ErrEndpointUnreachable)
+	assert.Equal(t, 2, state.failures)
+	assert.Equal(t, now.Add(2*healthInitialBackoff), state.retryAt)
+
+	// the backoff is bounded
+	state.failures = 20
+	now = state.retryAt
+	require.Error(t, network.IsUsable(context.Background()))
+	assert.Equal(t, now.Add(healthMaxBackoff), state.retryAt)
+
+	// interrupted checks are not recorded
+	now = state.retryAt
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Error(t, network.IsUsable(ctx))
+	assert.Equal(t, 21, state.failures)
+}
+
+func TestIsReady(t *testing.T) {
+	bootstrapped := map[string]interface{}{"isBootstrapped": true}
+	server := newJSONRPCServer(t, map[string]interface{}{
+		"info.isBootstrapped": bootstrapped,
+		// the other health checks do not matter
+		"health.health": map[string]interface{}{"healthy": false},
+	})
+	network := NewNetwork(Devnet, constants.LocalID, server.URL)
+	require.NoError(t, network.IsReady(context.Background()))
+
+	bootstrapped["isBootstrapped"] = false
+	require.ErrorIs(t, network.IsReady(context.Background()), ErrEndpointUnhealthy)
+
+	server.Close()
+	require.ErrorIs(t, network.IsReady(context.Background()), ErrEndpointUnreachable)
+	// nothing is remembered of the endpoint
+	healthStates.Lock()
+	defer healthStates.Unlock()
+	assert.NotContains(t, healthStates.byEndpoint, server.URL)
+}
@@ -4,6 +4,7 @@
 package odyssey
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -27,8 +28,48 @@ const (
 const (
 	TestnetAPIEndpoint = "https://testnode.dioneprotocol.com"
 	MainnetAPIEndpoint = "https://node.dioneprotocol.com"
+	LocalAPIEndpoint   = "http://127.0.0.1:9650"
 )
 
+// LocalNodeEnvEnabled makes TestnetNetwork use LocalAPIEndpoint, and NetworkFromURI map
+// LocalAPIEndpoint to the testnet, when the LOCAL_NODE environment variable is "true".
+//
+// Deprecated: use LocalNetwork or TestnetNetwork(WithLocalNode()) instead.
+var LocalNodeEnvEnabled = false
+
+// NetworkOp holds the options of TestnetNetwork and MainnetNetwork
+type NetworkOp struct {
+	endpoint string
+}
+
+// NetworkOption configures TestnetNetwork and MainnetNetwork
+type NetworkOption func(*NetworkOp)
+
+// WithEndpoint makes the network use the API endpoint endpoint instead of the public one
+func WithEndpoint(endpoint string) NetworkOption {
+	return func(op *NetworkOp) {
+		op.endpoint = endpoint
+	}
+}
+
+// WithLocalNode makes the network use the API of a node running on the local machine
+func WithLocalNode() NetworkOption {
+	return WithEndpoint(LocalAPIEndpoint)
+}
+
+func newNetworkOp(endpoint string, opts []NetworkOption) *NetworkOp {
+	op := &NetworkOp{endpoint: endpoint}
+	for _, opt := range opts {
+		opt(op)
+	}
+	return op
+}
+
+// localNodeFromEnv tells if the deprecated LOCAL_NODE environment variable applies
+func localNodeFromEnv() bool {
+	return LocalNodeEnvEnabled && os.Getenv("LOCAL_NODE") == "true"
+}
+
 func (nk NetworkKind) String() string {
 	switch nk {
 	case Mainnet:
@@ -41,15 +82,30 @@ func (nk NetworkKind) String() string {
 	return "invalid network"
 }
 
+// maxHRPLen is the maximum length of a bech32 human readable part
+const maxHRPLen = 83
+
+// ErrInvalidHRP is returned for human readable parts that cannot be used in bech32 addresses
+var ErrInvalidHRP = errors.New("invalid HRP")
+
 type Network struct {
 	Kind     NetworkKind
 	ID       uint32
 	Endpoint string
+
+	// CustomHRP overrides the HRP derived from the network ID, for private networks whose
+	// addresses use their own HRP
+	CustomHRP string
 }
 
 var UndefinedNetwork = Network{}
 
+// HRP returns the human readable part of the bech32 addresses of the network: CustomHRP if set,
+// otherwise the HRP of mainnet and testnet, or constants.FallbackHRP for any other network
 func (n Network) HRP() string {
+	if n.CustomHRP != "" {
+		return n.CustomHRP
+	}
 	switch n.ID {
 	case constants.TestnetID:
 		return constants.TestnetHRP // Returns "testnet"
@@ -78,20 +134,64 @@ func NewNetwork(kind NetworkKind, id uint32, endpoint string) Network {
 	}
 }
 
-func TestnetNetwork() Network {
+// NewCustomNetwork creates a devnet with network ID id, API endpoint endpoint and addresses
+// using hrp, e.g. for private networks not using the fallback HRP
+func NewCustomNetwork(id uint32, endpoint string, hrp string) (Network, error) {
+	return NewNetwork(Devnet, id, endpoint).WithHRP(hrp)
+}
+
+// WithHRP returns a copy of n whose addresses use hrp
+func (n Network) WithHRP(hrp string) (Network, error) {
+	if err := ValidateHRP(hrp); err != nil {
+		return UndefinedNetwork, err
+	}
+	n.CustomHRP = hrp
+	return n, nil
+}
+
+// ValidateHRP checks that hrp is a valid lowercase bech32 human readable part
+func ValidateHRP(hrp string) error {
+	if hrp == "" || len(hrp) > maxHRPLen {
+		return fmt.Errorf("%w %q: length must be between 1 and %d", ErrInvalidHRP, hrp, maxHRPLen)
+	}
+	for _, c := range hrp {
+		if c < 33 || c > 126 {
+			return fmt.Errorf("%w %q: invalid character %q", ErrInvalidHRP, hrp, c)
+		}
+	}
+	if strings.ToLower(hrp) != hrp {
+		return fmt.Errorf("%w %q: must be lowercase", ErrInvalidHRP, hrp)
+	}
+	return nil
+}
+
+// TestnetNetwork returns the testnet, reached through its public API endpoint unless
+// overridden by opts
+func TestnetNetwork(opts ...NetworkOption) Network {
 	endpoint := TestnetAPIEndpoint
-	if os.Getenv("LOCAL_NODE") == "true" {
-		endpoint = "http://127.0.0.1:9650"
+	if localNodeFromEnv() {
+		endpoint = LocalAPIEndpoint
 	}
-	return NewNetwork(Testnet, constants.TestnetID, endpoint)
+	return NewNetwork(Testnet, constants.TestnetID, newNetworkOp(endpoint, opts).endpoint)
+}
+
+// MainnetNetwork returns the mainnet, reached through its public API endpoint unless
+// overridden by opts
+func MainnetNetwork(opts ...NetworkOption) Network {
+	return NewNetwork(Mainnet, constants.MainnetID, newNetworkOp(MainnetAPIEndpoint, opts).endpoint)
 }
 
-func MainnetNetwork() Network {
-	return NewNetwork(Mainnet, constants.MainnetID, MainnetAPIEndpoint)
+// LocalNetwork returns the testnet reached through the API of a node synced with it at
+// endpoint, LocalAPIEndpoint if empty
+func LocalNetwork(endpoint string) Network {
+	if endpoint == "" {
+		endpoint = LocalAPIEndpoint
+	}
+	return TestnetNetwork(WithEndpoint(endpoint))
 }
 
 func DevnetNetwork() Network {
-	return NewNetwork(Devnet, 0, "http://127.0.0.1:9650")
+	return NewNetwork(Devnet, 0, LocalAPIEndpoint)
 }
 
 func (n Network) GenesisParams() *genesis.Params {
@@ -135,9 +235,10 @@ func NetworkFromURI(uri string) Network {
 		return TestnetNetwork()
 	case MainnetAPIEndpoint:
 		return MainnetNetwork()
-	case "http://127.0.0.1:9650":
-		// Local node endpoint - use testnet configuration when LOCAL_NODE=true
-		if os.Getenv("LOCAL_NODE") == "true" {
+	case LocalAPIEndpoint:
+		// Local node endpoint - use testnet configuration when the deprecated LOCAL_NODE
+		// environment variable is enabled
+		if localNodeFromEnv() {
 			return TestnetNetwork()
 		}
 		return UndefinedNetwork
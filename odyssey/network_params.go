@@ -0,0 +1,162 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package odyssey
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/amounts"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"github.com/DioneProtocol/odysseygo/api/info"
+	"github.com/DioneProtocol/odysseygo/genesis"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/version"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/reward"
+)
+
+var (
+	ErrStakeOutOfRange         = errors.New("stake amount out of range")
+	ErrStakeDurationOutOfRange = errors.New("stake duration out of range")
+	ErrDelegationFeeOutOfRange = errors.New("delegation fee out of range")
+)
+
+// NetworkParams are the staking, fee and upgrade parameters of a network
+type NetworkParams struct {
+	NetworkID uint32
+
+	// MinValidatorStake and MinDelegatorStake, in nDIONE, are fetched from the O-Chain
+	MinValidatorStake uint64
+	MinDelegatorStake uint64
+
+	// MaxValidatorStake, in nDIONE, the stake durations and the minimum delegation fee come
+	// from the staking config of the network ID, as the O-Chain API does not expose them
+	MaxValidatorStake         uint64
+	MinValidatorStakeDuration time.Duration
+	MaxValidatorStakeDuration time.Duration
+	MinDelegatorStakeDuration time.Duration
+	MaxDelegatorStakeDuration time.Duration
+
+	// MinDelegationFee is in the range [0, 1000000], 1000000 being 100%
+	MinDelegationFee uint32
+
+	// TxFees are fetched from the info API
+	TxFees TxFees
+
+	// Upgrades are the activation times of the network upgrades
+	Upgrades UpgradeTimes
+}
+
+// TxFees are the fees, in nDIONE, of the transactions of a network
+type TxFees struct {
+	Tx                         uint64
+	CreateAsset                uint64
+	CreateSubnet               uint64
+	TransformSubnet            uint64
+	CreateBlockchain           uint64
+	AddPrimaryNetworkValidator uint64
+	AddPrimaryNetworkDelegator uint64
+	AddSubnetValidator         uint64
+	AddSubnetDelegator         uint64
+}
+
+// UpgradeTimes are the activation times of the upgrades of a network
+type UpgradeTimes struct {
+	ApricotPhase3 time.Time
+	ApricotPhase4 time.Time
+	ApricotPhase5 time.Time
+	ApricotPhase6 time.Time
+	Banff         time.Time
+	Cortina       time.Time
+}
+
+// GetNetworkParams fetches the parameters of the network from its API endpoint
+func GetNetworkParams(network Network) (NetworkParams, error) {
+	infoClient := info.NewClient(network.Endpoint)
+	oClient := omegavm.NewClient(network.Endpoint)
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	networkID, err := infoClient.GetNetworkID(ctx)
+	if err != nil {
+		return NetworkParams{}, fmt.Errorf("failed to get network ID from %s: %w", network.Endpoint, err)
+	}
+	minValidatorStake, minDelegatorStake, err := oClient.GetMinStake(ctx, ids.Empty)
+	if err != nil {
+		return NetworkParams{}, fmt.Errorf("failed to get min stake from %s: %w", network.Endpoint, err)
+	}
+	fees, err := infoClient.GetTxFee(ctx)
+	if err != nil {
+		return NetworkParams{}, fmt.Errorf("failed to get tx fees from %s: %w", network.Endpoint, err)
+	}
+	staking := genesis.GetStakingConfig(networkID)
+	return NetworkParams{
+		NetworkID:                 networkID,
+		MinValidatorStake:         minValidatorStake,
+		MinDelegatorStake:         minDelegatorStake,
+		MaxValidatorStake:         staking.MaxValidatorStake,
+		MinValidatorStakeDuration: staking.MinValidatorStakeDuration,
+		MaxValidatorStakeDuration: staking.MaxValidatorStakeDuration,
+		MinDelegatorStakeDuration: staking.MinDelegatorStakeDuration,
+		MaxDelegatorStakeDuration: staking.MaxDelegatorStakeDuration,
+		MinDelegationFee:          staking.MinDelegationFee,
+		TxFees: TxFees{
+			Tx:                         uint64(fees.TxFee),
+			CreateAsset:                uint64(fees.CreateAssetTxFee),
+			CreateSubnet:               uint64(fees.CreateSubnetTxFee),
+			TransformSubnet:            uint64(fees.TransformSubnetTxFee),
+			CreateBlockchain:           uint64(fees.CreateBlockchainTxFee),
+			AddPrimaryNetworkValidator: uint64(fees.AddPrimaryNetworkValidatorFee),
+			AddPrimaryNetworkDelegator: uint64(fees.AddPrimaryNetworkDelegatorFee),
+			AddSubnetValidator:         uint64(fees.AddSubnetValidatorFee),
+			AddSubnetDelegator:         uint64(fees.AddSubnetDelegatorFee),
+		},
+		Upgrades: UpgradeTimes{
+			ApricotPhase3: version.GetApricotPhase3Time(networkID),
+			ApricotPhase4: version.GetApricotPhase4Time(networkID),
+			ApricotPhase5: version.GetApricotPhase5Time(networkID),
+			ApricotPhase6: version.GetApricotPhase6Time(networkID),
+			Banff:         version.GetBanffTime(networkID),
+			Cortina:       version.GetCortinaTime(networkID),
+		},
+	}, nil
+}
+
+// ValidatePrimaryNetworkValidator checks the stake amount, in nDIONE, the stake duration and
+// the delegation fee of a Primary Network validator against the network parameters, so that
+// out of range validators are rejected before their tx is issued. All the violations are
+// returned at once
+func (p NetworkParams) ValidatePrimaryNetworkValidator(stakeAmount uint64, duration time.Duration, delegationFee uint32) error {
+	return errors.Join(
+		p.ValidateValidatorStake(stakeAmount),
+		p.ValidateValidatorStakeDuration(duration),
+		p.ValidateDelegationFee(delegationFee),
+	)
+}
+
+// ValidateValidatorStake checks that stakeAmount, in nDIONE, can be staked by a Primary
+// Network validator
+func (p NetworkParams) ValidateValidatorStake(stakeAmount uint64) error {
+	if stakeAmount < p.MinValidatorStake || (p.MaxValidatorStake > 0 && stakeAmount > p.MaxValidatorStake) {
+		return fmt.Errorf("%w: %s must be between %s and %s", ErrStakeOutOfRange, amounts.Amount(stakeAmount), amounts.Amount(p.MinValidatorStake), amounts.Amount(p.MaxValidatorStake))
+	}
+	return nil
+}
+
+// ValidateValidatorStakeDuration checks that a Primary Network validator can stake for duration
+func (p NetworkParams) ValidateValidatorStakeDuration(duration time.Duration) error {
+	if duration < p.MinValidatorStakeDuration || (p.MaxValidatorStakeDuration > 0 && duration > p.MaxValidatorStakeDuration) {
+		return fmt.Errorf("%w: %s must be between %s and %s", ErrStakeDurationOutOfRange, duration, p.MinValidatorStakeDuration, p.MaxValidatorStakeDuration)
+	}
+	return nil
+}
+
+// ValidateDelegationFee checks that a Primary Network validator can charge delegationFee
+func (p NetworkParams) ValidateDelegationFee(delegationFee uint32) error {
+	if delegationFee < p.MinDelegationFee || delegationFee > reward.PercentDenominator {
+		return fmt.Errorf("%w: %d must be between %d and %d", ErrDelegationFeeOutOfRange, delegationFee, p.MinDelegationFee, reward.PercentDenominator)
+	}
+	return nil
+}
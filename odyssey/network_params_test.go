@@ -0,0 +1,80 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package odyssey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/genesis"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/DioneProtocol/odysseygo/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNetworkParams(t *testing.T) {
+	server := newJSONRPCServer(t, map[string]interface{}{
+		"info.getNetworkID": map[string]interface{}{"networkID": "12345"},
+		"omega.getMinStake": map[string]interface{}{"minValidatorStake": "2000", "minDelegatorStake": "25"},
+		"info.getTxFee":     map[string]interface{}{"txFee": "1000", "createSubnetTxFee": "100000", "addSubnetValidatorFee": "1000"},
+	})
+	params, err := GetNetworkParams(NewNetwork(Devnet, 0, server.URL))
+	require.NoError(t, err)
+	staking := genesis.GetStakingConfig(constants.LocalID)
+	assert.Equal(t, uint32(constants.LocalID), params.NetworkID)
+	assert.Equal(t, uint64(2000), params.MinValidatorStake)
+	assert.Equal(t, uint64(25), params.MinDelegatorStake)
+	assert.Equal(t, staking.MaxValidatorStake, params.MaxValidatorStake)
+	assert.Equal(t, staking.MinValidatorStakeDuration, params.MinValidatorStakeDuration)
+	assert.Equal(t, staking.MinDelegationFee, params.MinDelegationFee)
+	assert.Equal(t, TxFees{Tx: 1000, CreateSubnet: 100000, AddSubnetValidator: 1000}, params.TxFees)
+	assert.Equal(t, version.GetCortinaTime(constants.LocalID), params.Upgrades.Cortina)
+
+	_, err = GetNetworkParams(NewNetwork(Devnet, 0, newJSONRPCServer(t, map[string]interface{}{}).URL))
+	assert.Error(t, err)
+}
+
+func TestNetworkParams_ValidatePrimaryNetworkValidator(t *testing.T) {
+	params := NetworkParams{
+		MinValidatorStake:         2000,
+		MaxValidatorStake:         3000,
+		MinValidatorStakeDuration: 24 * time.Hour,
+		MaxValidatorStakeDuration: 365 * 24 * time.Hour,
+		MinDelegationFee:          20000,
+	}
+	tests := []struct {
+		name          string
+		stakeAmount   uint64
+		duration      time.Duration
+		delegationFee uint32
+		expectedErr   error
+	}{
+		{name: "valid", stakeAmount: 2000, duration: 24 * time.Hour, delegationFee: 20000},
+		{name: "stake too low", stakeAmount: 1999, duration: 24 * time.Hour, delegationFee: 20000, expectedErr: ErrStakeOutOfRange},
+		{name: "stake too high", stakeAmount: 3001, duration: 24 * time.Hour, delegationFee: 20000, expectedErr: ErrStakeOutOfRange},
+		{name: "duration too short", stakeAmount: 2000, duration: time.Hour, delegationFee: 20000, expectedErr: ErrStakeDurationOutOfRange},
+		{name: "duration too long", stakeAmount: 2000, duration: 366 * 24 * time.Hour, delegationFee: 20000, expectedErr: ErrStakeDurationOutOfRange},
+		{name: "fee too low", stakeAmount: 2000, duration: 24 * time.Hour, delegationFee: 100, expectedErr: ErrDelegationFeeOutOfRange},
+		{name: "fee above 100%", stakeAmount: 2000, duration: 24 * time.Hour, delegationFee: 1000001, expectedErr: ErrDelegationFeeOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := params.ValidatePrimaryNetworkValidator(tt.stakeAmount, tt.duration, tt.delegationFee)
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestNetworkParams_ValidatePrimaryNetworkValidator_AllViolations(t *testing.T) {
+	params := NetworkParams{MinValidatorStake: 2000, MinValidatorStakeDuration: time.Hour, MinDelegationFee: 20000}
+	err := params.ValidatePrimaryNetworkValidator(1, time.Minute, 1)
+	require.ErrorIs(t, err, ErrStakeOutOfRange)
+	require.ErrorIs(t, err, ErrStakeDurationOutOfRange)
+	require.ErrorIs(t, err, ErrDelegationFeeOutOfRange)
+}
@@ -5,10 +5,13 @@ package odyssey
 
 import (
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/DioneProtocol/odysseygo/ids"
 	"github.com/DioneProtocol/odysseygo/utils/constants"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNetworkKind_String(t *testing.T) {
@@ -86,6 +89,14 @@ func TestNetwork_HRP(t *testing.T) {
 			},
 			expected: constants.FallbackHRP,
 		},
+		{
+			name: "Custom HRP",
+			network: Network{
+				ID:        constants.TestnetID,
+				CustomHRP: "private",
+			},
+			expected: "private",
+		},
 	}
 
 	for _, tt := range tests {
@@ -96,6 +107,29 @@ func TestNetwork_HRP(t *testing.T) {
 	}
 }
 
+func TestNewCustomNetwork(t *testing.T) {
+	network, err := NewCustomNetwork(1337, "http://10.0.0.1:9650", "private")
+	require.NoError(t, err)
+	assert.Equal(t, Devnet, network.Kind)
+	assert.Equal(t, uint32(1337), network.ID)
+	assert.Equal(t, "private", network.HRP())
+
+	shortID := ids.GenerateTestShortID()
+	addr, err := network.FormatAddress(OChainAlias, shortID)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(addr, "O-private1"))
+	parsed, err := network.ParseChainAddress(OChainAlias, addr)
+	require.NoError(t, err)
+	assert.Equal(t, shortID, parsed)
+	_, err = DevnetNetwork().ParseChainAddress(OChainAlias, addr)
+	assert.ErrorIs(t, err, ErrWrongHRP)
+
+	for _, hrp := range []string{"", "Private", "pri vate", strings.Repeat("a", maxHRPLen+1)} {
+		_, err := NewCustomNetwork(1337, "http://10.0.0.1:9650", hrp)
+		assert.ErrorIs(t, err, ErrInvalidHRP, hrp)
+	}
+}
+
 func TestNetworkFromNetworkID(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -210,6 +244,7 @@ func TestTestnetNetwork(t *testing.T) {
 
 	// Test with LOCAL_NODE environment variable set to true
 	t.Run("local node endpoint", func(t *testing.T) {
+		enableLocalNodeEnv(t)
 		os.Setenv("LOCAL_NODE", "true")
 		defer os.Unsetenv("LOCAL_NODE")
 
@@ -219,6 +254,22 @@ func TestTestnetNetwork(t *testing.T) {
 		assert.Equal(t, "http://127.0.0.1:9650", network.Endpoint)
 	})
 
+	// LOCAL_NODE is ignored unless LocalNodeEnvEnabled is set
+	t.Run("local node env not enabled", func(t *testing.T) {
+		os.Setenv("LOCAL_NODE", "true")
+		defer os.Unsetenv("LOCAL_NODE")
+
+		assert.Equal(t, TestnetAPIEndpoint, TestnetNetwork().Endpoint)
+		assert.Equal(t, UndefinedNetwork, NetworkFromURI(LocalAPIEndpoint))
+	})
+
+	t.Run("endpoint options", func(t *testing.T) {
+		assert.Equal(t, LocalAPIEndpoint, TestnetNetwork(WithLocalNode()).Endpoint)
+		assert.Equal(t, "http://10.0.0.1:9650", TestnetNetwork(WithEndpoint("http://10.0.0.1:9650")).Endpoint)
+		assert.Equal(t, "http://10.0.0.1:9650", MainnetNetwork(WithEndpoint("http://10.0.0.1:9650")).Endpoint)
+		assert.Equal(t, constants.MainnetID, MainnetNetwork(WithLocalNode()).ID)
+	})
+
 	// Test with LOCAL_NODE environment variable set to false
 	t.Run("local node false", func(t *testing.T) {
 		os.Setenv("LOCAL_NODE", "false")
@@ -231,6 +282,21 @@ func TestTestnetNetwork(t *testing.T) {
 	})
 }
 
+func TestLocalNetwork(t *testing.T) {
+	network := LocalNetwork("")
+	assert.Equal(t, Testnet, network.Kind)
+	assert.Equal(t, constants.TestnetID, network.ID)
+	assert.Equal(t, LocalAPIEndpoint, network.Endpoint)
+
+	assert.Equal(t, "http://10.0.0.1:9650", LocalNetwork("http://10.0.0.1:9650").Endpoint)
+}
+
+// enableLocalNodeEnv enables the deprecated LOCAL_NODE environment variable for the test
+func enableLocalNodeEnv(t *testing.T) {
+	LocalNodeEnvEnabled = true
+	t.Cleanup(func() { LocalNodeEnvEnabled = false })
+}
+
 func TestMainnetNetwork(t *testing.T) {
 	network := MainnetNetwork()
 
@@ -471,6 +537,7 @@ func TestNetworkFromURI(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Handle special case for LOCAL_NODE=true
 			if tt.name == "Local node endpoint with LOCAL_NODE=true" {
+				enableLocalNodeEnv(t)
 				os.Setenv("LOCAL_NODE", "true")
 				defer os.Unsetenv("LOCAL_NODE")
 				expected := TestnetNetwork() // Get expected value with env var set
@@ -493,6 +560,7 @@ func TestNetworkFromURI(t *testing.T) {
 func TestNetworkFromURI_LocalNodeEnvironment(t *testing.T) {
 	// Test the LOCAL_NODE environment variable behavior specifically
 	t.Run("LOCAL_NODE=true", func(t *testing.T) {
+		enableLocalNodeEnv(t)
 		os.Setenv("LOCAL_NODE", "true")
 		defer os.Unsetenv("LOCAL_NODE")
 
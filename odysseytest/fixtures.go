@@ -0,0 +1,71 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package odysseytest
+
+import (
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+)
+
+// NewKeychain returns a keychain of n new keys
+func NewKeychain(n int) (*secp256k1fx.Keychain, error) {
+	factory := secp256k1.Factory{}
+	kc := secp256k1fx.NewKeychain()
+	for i := 0; i < n; i++ {
+		sk, err := factory.NewPrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		kc.Add(sk)
+	}
+	return kc, nil
+}
+
+// Owners returns the owners addrs of a UTXO or subnet, threshold of them being required to
+// spend it or sign for it
+func Owners(threshold uint32, addrs ...ids.ShortID) *secp256k1fx.OutputOwners {
+	owners := &secp256k1fx.OutputOwners{
+		Threshold: threshold,
+		Addrs:     append([]ids.ShortID{}, addrs...),
+	}
+	utils.Sort(owners.Addrs)
+	return owners
+}
+
+// NewUTXO returns a spendable UTXO of amount of assetID, e.g. Server.DIONEAssetID(), owned by
+// owners, with a new tx ID
+func NewUTXO(assetID ids.ID, amount uint64, owners *secp256k1fx.OutputOwners) *dione.UTXO {
+	return &dione.UTXO{
+		UTXOID: dione.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  dione.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          amount,
+			OutputOwners: *owners,
+		},
+	}
+}
+
+// NewCreateSubnetTx returns a CreateSubnetTx of networkID creating a subnet owned by owners,
+// without inputs nor credentials. Its ID is the ID of the subnet
+func NewCreateSubnetTx(networkID uint32, owners *secp256k1fx.OutputOwners) (*txs.Tx, error) {
+	// the random memo gives each subnet its own ID
+	memo := ids.GenerateTestID()
+	tx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: dione.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: constants.OmegaChainID,
+			Memo:         memo[:],
+		}},
+		Owner: owners,
+	}}
+	if err := tx.Initialize(txs.Codec); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
@@ -0,0 +1,533 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package odysseytest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/api"
+	"github.com/DioneProtocol/odysseygo/api/health"
+	"github.com/DioneProtocol/odysseygo/api/info"
+	"github.com/DioneProtocol/odysseygo/genesis"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/DioneProtocol/odysseygo/utils/formatting"
+	"github.com/DioneProtocol/odysseygo/utils/formatting/address"
+	odysseyjson "github.com/DioneProtocol/odysseygo/utils/json"
+	"github.com/DioneProtocol/odysseygo/version"
+	"github.com/DioneProtocol/odysseygo/vms/alpha"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/status"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DefaultNetworkID is the network ID of the servers created without WithNetworkID
+const DefaultNetworkID = constants.LocalID
+
+var (
+	ErrUnknownMethod = errors.New("unknown method")
+	ErrUnknownTx     = errors.New("unknown tx")
+	ErrDuplicateTx   = errors.New("duplicate tx")
+)
+
+// ServerOp configures a Server
+type ServerOp struct {
+	networkID    uint32
+	dioneAssetID ids.ID
+	txFees       genesis.TxFeeConfig
+}
+
+// ServerOption configures NewServer
+type ServerOption func(*ServerOp)
+
+// WithNetworkID makes the server report networkID instead of DefaultNetworkID
+func WithNetworkID(networkID uint32) ServerOption {
+	return func(op *ServerOp) {
+		op.networkID = networkID
+	}
+}
+
+// WithDIONEAssetID makes the server report assetID as the DIONE asset
+func WithDIONEAssetID(assetID ids.ID) ServerOption {
+	return func(op *ServerOp) {
+		op.dioneAssetID = assetID
+	}
+}
+
+// WithTxFees makes the server report fees instead of the fees of the local network
+func WithTxFees(fees genesis.TxFeeConfig) ServerOption {
+	return func(op *ServerOp) {
+		op.txFees = fees
+	}
+}
+
+// issuedTx is an O-Chain tx known to the server
+type issuedTx struct {
+	tx     *txs.Tx
+	status status.Status
+}
+
+// Server is an in-process fake of the API of a node, serving the info, O-Chain, A-Chain and
+// D-Chain calls used by the wallets and multisig txs of the SDK from canned state: the O-Chain
+// UTXOs, txs and subnets added to it. Issued O-Chain txs are decided at once, spending their
+// inputs, adding their outputs and, for CreateSubnetTxs, their subnet. Signatures are not
+// verified. A Server is safe for concurrent use
+type Server struct {
+	op       ServerOp
+	server   *httptest.Server
+	aChainID ids.ID
+	dChainID ids.ID
+
+	lock        sync.Mutex
+	utxos       map[ids.ID]*dione.UTXO
+	txs         map[ids.ID]*issuedTx
+	subnets     map[ids.ID]*secp256k1fx.OutputOwners
+	issued      []*txs.Tx
+	issueStatus status.Status
+	issueErr    error
+	failing     []string
+	results     map[string]interface{}
+	calls       map[string]int
+}
+
+// NewServer starts a server without UTXOs, txs nor subnets. The caller should Close it
+func NewServer(opts ...ServerOption) *Server {
+	op := ServerOp{
+		networkID:    DefaultNetworkID,
+		dioneAssetID: ids.ID{'D', 'I', 'O', 'N', 'E'},
+		txFees:       genesis.LocalParams.TxFeeConfig,
+	}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	s := &Server{
+		op:          op,
+		aChainID:    ids.ID{'A'},
+		dChainID:    ids.ID{'D'},
+		utxos:       map[ids.ID]*dione.UTXO{},
+		txs:         map[ids.ID]*issuedTx{},
+		subnets:     map[ids.ID]*secp256k1fx.OutputOwners{},
+		issueStatus: status.Committed,
+		results:     map[string]interface{}{},
+		calls:       map[string]int{},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Close shuts down the server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// URI returns the API endpoint of the server, to use as wallet URI or network endpoint
+func (s *Server) URI() string {
+	return s.server.URL
+}
+
+// Network returns a devnet reached through the server
+func (s *Server) Network() odyssey.Network {
+	return odyssey.NewNetwork(odyssey.Devnet, s.op.networkID, s.URI())
+}
+
+// DIONEAssetID returns the ID of the DIONE asset reported by the server
+func (s *Server) DIONEAssetID() ids.ID {
+	return s.op.dioneAssetID
+}
+
+// AddUTXOs adds utxos to the O-Chain UTXOs of the server
+func (s *Server) AddUTXOs(utxos ...*dione.UTXO) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, utxo := range utxos {
+		s.utxos[utxo.InputID()] = utxo
+	}
+}
+
+// AddTx makes the server know the O-Chain tx with txStatus, as if issued by someone else.
+// Committed txs do not change the UTXOs nor the subnets of the server
+func (s *Server) AddTx(tx *txs.Tx, txStatus status.Status) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.txs[tx.ID()] = &issuedTx{tx: tx, status: txStatus}
+}
+
+// AddSubnet creates a committed subnet owned by owners and returns its ID, the ID of its
+// CreateSubnetTx
+func (s *Server) AddSubnet(owners *secp256k1fx.OutputOwners) (ids.ID, error) {
+	tx, err := NewCreateSubnetTx(s.op.networkID, owners)
+	if err != nil {
+		return ids.Empty, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.txs[tx.ID()] = &issuedTx{tx: tx, status: status.Committed}
+	s.subnets[tx.ID()] = owners
+	return tx.ID(), nil
+}
+
+// SetIssueStatus decides the txs issued from now on with txStatus, e.g. status.Dropped,
+// instead of status.Committed. Only committed txs change the state of the server
+func (s *Server) SetIssueStatus(txStatus status.Status) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.issueStatus = txStatus
+}
+
+// FailHealthChecks makes the server report the health checks names as failing, and itself
+// as unhealthy, until called without names
+func (s *Server) FailHealthChecks(names ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.failing = names
+}
+
+// FailIssueTx makes the server reject the txs issued from now on with err, until called
+// with nil
+func (s *Server) FailIssueTx(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.issueErr = err
+}
+
+// SetResult makes the server answer the calls of method with result, e.g. for the calls it
+// does not serve from its state, such as omega.getCurrentSupply. result is JSON encoded
+func (s *Server) SetResult(method string, result interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.results[method] = result
+}
+
+// IssuedTxs returns the O-Chain txs accepted by issueTx, in issuance order
+func (s *Server) IssuedTxs() []*txs.Tx {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]*txs.Tx{}, s.issued...)
+}
+
+// UTXOs returns the O-Chain UTXOs of the server
+func (s *Server) UTXOs() []*dione.UTXO {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	utxos := make([]*dione.UTXO, 0, len(s.utxos))
+	for _, utxo := range s.utxos {
+		utxos = append(utxos, utxo)
+	}
+	return utxos
+}
+
+// Calls returns the number of calls of method, e.g. "omega.issueTx", served so far
+func (s *Server) Calls(method string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.calls[method]
+}
+
+type rpcRequest struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	ID     json.RawMessage `json:"id"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	Version string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// serveHTTP answers the JSON-RPC calls of all the endpoints of a node, which are told apart by
+// the namespace of their methods
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	req := rpcRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp := rpcResponse{Version: "2.0", ID: req.ID}
+	result, err := s.call(req.Method, req.Params)
+	if err != nil {
+		resp.Error = &rpcError{Code: -32000, Message: err.Error()}
+	} else {
+		resp.Result = result
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) call(method string, params json.RawMessage) (interface{}, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.calls[method]++
+	if result, ok := s.results[method]; ok {
+		return result, nil
+	}
+	switch method {
+	case "info.getNetworkID":
+		return info.GetNetworkIDReply{NetworkID: odysseyjson.Uint32(s.op.networkID)}, nil
+	case "info.getNodeVersion":
+		return info.GetNodeVersionReply{Version: "odyssey/" + version.Current.String()}, nil
+	case "info.isBootstrapped":
+		args := info.IsBootstrappedArgs{}
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, err
+		}
+		if _, err := s.getBlockchainID(args.Chain); err != nil {
+			return nil, err
+		}
+		return info.IsBootstrappedResponse{IsBootstrapped: true}, nil
+	case "health.health":
+		return s.health(), nil
+	case "info.getTxFee":
+		fees := s.op.txFees
+		return info.GetTxFeeResponse{
+			TxFee:                         odysseyjson.Uint64(fees.TxFee),
+			CreateAssetTxFee:              odysseyjson.Uint64(fees.CreateAssetTxFee),
+			CreateSubnetTxFee:             odysseyjson.Uint64(fees.CreateSubnetTxFee),
+			TransformSubnetTxFee:          odysseyjson.Uint64(fees.TransformSubnetTxFee),
+			CreateBlockchainTxFee:         odysseyjson.Uint64(fees.CreateBlockchainTxFee),
+			AddPrimaryNetworkValidatorFee: odysseyjson.Uint64(fees.AddPrimaryNetworkValidatorFee),
+			AddPrimaryNetworkDelegatorFee: odysseyjson.Uint64(fees.AddPrimaryNetworkDelegatorFee),
+			AddSubnetValidatorFee:         odysseyjson.Uint64(fees.AddSubnetValidatorFee),
+			AddSubnetDelegatorFee:         odysseyjson.Uint64(fees.AddSubnetDelegatorFee),
+		}, nil
+	case "info.getBlockchainID":
+		args := info.GetBlockchainIDArgs{}
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, err
+		}
+		return s.getBlockchainID(args.Alias)
+	case "alpha.getAssetDescription":
+		return alpha.GetAssetDescriptionReply{
+			FormattedAssetID: alpha.FormattedAssetID{AssetID: s.op.dioneAssetID},
+			Name:             "Dione",
+			Symbol:           "DIONE",
+			Denomination:     9,
+		}, nil
+	case "omega.getUTXOs":
+		return s.getUTXOs(params)
+	case "alpha.getUTXOs", "dione.getUTXOs":
+		// the A-Chain and D-Chain of the server have no UTXOs
+		return s.utxosReply(nil)
+	case "omega.getTx":
+		return s.getTx(params)
+	case "omega.getTxStatus":
+		return s.getTxStatus(params)
+	case "omega.issueTx":
+		return s.issueTx(params)
+	case "omega.getSubnets":
+		return s.getSubnets(params)
+	case "eth_getBalance", "eth_getTransactionCount":
+		// the D-Chain accounts of the server are empty
+		return (*hexutil.Big)(big.NewInt(0)), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownMethod, method)
+}
+
+func (s *Server) health() health.APIReply {
+	reply := health.APIReply{Checks: map[string]health.Result{}, Healthy: len(s.failing) == 0}
+	for _, name := range s.failing {
+		failure := "failing"
+		reply.Checks[name] = health.Result{Error: &failure}
+	}
+	return reply
+}
+
+func (s *Server) getBlockchainID(alias string) (info.GetBlockchainIDReply, error) {
+	switch alias {
+	case "O":
+		return info.GetBlockchainIDReply{BlockchainID: constants.OmegaChainID}, nil
+	case "A":
+		return info.GetBlockchainIDReply{BlockchainID: s.aChainID}, nil
+	case "D":
+		return info.GetBlockchainIDReply{BlockchainID: s.dChainID}, nil
+	}
+	return info.GetBlockchainIDReply{}, fmt.Errorf("there is no chain with alias/ID '%s'", alias)
+}
+
+// getUTXOs returns in a single page the O-Chain UTXOs owned by the addresses of the call.
+// The server has no atomic UTXOs
+func (s *Server) getUTXOs(params json.RawMessage) (*api.GetUTXOsReply, error) {
+	args := api.GetUTXOsArgs{}
+	if err := json.Unmarshal(params, &args); err != nil {
+		return nil, err
+	}
+	if args.SourceChain != "" && args.SourceChain != constants.OmegaChainID.String() {
+		return s.utxosReply(nil)
+	}
+	addrs := make([]ids.ShortID, len(args.Addresses))
+	for i, addr := range args.Addresses {
+		var err error
+		if addrs[i], err = parseAddress(addr); err != nil {
+			return nil, err
+		}
+	}
+	owned := []*dione.UTXO{}
+	for _, utxo := range s.utxos {
+		if isOwnedByAny(utxo, addrs) {
+			owned = append(owned, utxo)
+		}
+	}
+	return s.utxosReply(owned)
+}
+
+// parseAddress parses addr, either a short ID or a chain address, as the API of a node does
+func parseAddress(addr string) (ids.ShortID, error) {
+	if shortID, err := ids.ShortFromString(addr); err == nil {
+		return shortID, nil
+	}
+	return address.ParseToID(addr)
+}
+
+func isOwnedByAny(utxo *dione.UTXO, addrs []ids.ShortID) bool {
+	out, ok := utxo.Out.(interface{ Addresses() [][]byte })
+	if !ok {
+		return false
+	}
+	for _, owner := range out.Addresses() {
+		for _, addr := range addrs {
+			if addr == ids.ShortID(owner) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *Server) utxosReply(utxos []*dione.UTXO) (*api.GetUTXOsReply, error) {
+	endAddr, err := address.Format("O", constants.GetHRP(s.op.networkID), ids.ShortEmpty.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	reply := &api.GetUTXOsReply{
+		NumFetched: odysseyjson.Uint64(len(utxos)),
+		UTXOs:      make([]string, len(utxos)),
+		EndIndex:   api.Index{Address: endAddr, UTXO: ids.Empty.String()},
+		Encoding:   formatting.Hex,
+	}
+	for i, utxo := range utxos {
+		utxoBytes, err := txs.Codec.Marshal(txs.Version, utxo)
+		if err != nil {
+			return nil, err
+		}
+		if reply.UTXOs[i], err = formatting.Encode(formatting.Hex, utxoBytes); err != nil {
+			return nil, err
+		}
+	}
+	return reply, nil
+}
+
+func (s *Server) getTx(params json.RawMessage) (*api.FormattedTx, error) {
+	args := api.GetTxArgs{}
+	if err := json.Unmarshal(params, &args); err != nil {
+		return nil, err
+	}
+	issued, ok := s.txs[args.TxID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTx, args.TxID)
+	}
+	txHex, err := formatting.Encode(formatting.Hex, issued.tx.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return &api.FormattedTx{Tx: txHex, Encoding: formatting.Hex}, nil
+}
+
+func (s *Server) getTxStatus(params json.RawMessage) (*omegavm.GetTxStatusResponse, error) {
+	args := omegavm.GetTxStatusArgs{}
+	if err := json.Unmarshal(params, &args); err != nil {
+		return nil, err
+	}
+	issued, ok := s.txs[args.TxID]
+	if !ok {
+		return &omegavm.GetTxStatusResponse{Status: status.Unknown}, nil
+	}
+	reply := &omegavm.GetTxStatusResponse{Status: issued.status}
+	if issued.status == status.Dropped {
+		reply.Reason = "dropped by the test server"
+	}
+	return reply, nil
+}
+
+// issueTx decides the tx at once with the issue status of the server
+func (s *Server) issueTx(params json.RawMessage) (*api.JSONTxID, error) {
+	args := api.FormattedTx{}
+	if err := json.Unmarshal(params, &args); err != nil {
+		return nil, err
+	}
+	if s.issueErr != nil {
+		return nil, s.issueErr
+	}
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tx: %w", err)
+	}
+	txID := tx.ID()
+	if _, ok := s.txs[txID]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrDuplicateTx, txID)
+	}
+	s.txs[txID] = &issuedTx{tx: tx, status: s.issueStatus}
+	s.issued = append(s.issued, tx)
+	if s.issueStatus == status.Committed {
+		for inputID := range tx.Unsigned.InputIDs() {
+			delete(s.utxos, inputID)
+		}
+		for _, utxo := range tx.UTXOs() {
+			s.utxos[utxo.InputID()] = utxo
+		}
+		if createSubnetTx, ok := tx.Unsigned.(*txs.CreateSubnetTx); ok {
+			if owners, ok := createSubnetTx.Owner.(*secp256k1fx.OutputOwners); ok {
+				s.subnets[txID] = owners
+			}
+		}
+	}
+	return &api.JSONTxID{TxID: txID}, nil
+}
+
+func (s *Server) getSubnets(params json.RawMessage) (*omegavm.GetSubnetsResponse, error) {
+	args := omegavm.GetSubnetsArgs{}
+	if err := json.Unmarshal(params, &args); err != nil {
+		return nil, err
+	}
+	reply := &omegavm.GetSubnetsResponse{Subnets: []omegavm.APISubnet{}}
+	for _, subnetID := range args.IDs {
+		owners, ok := s.subnets[subnetID]
+		if !ok {
+			continue
+		}
+		controlKeys := make([]string, len(owners.Addrs))
+		for i, addr := range owners.Addrs {
+			controlKey, err := address.Format("O", constants.GetHRP(s.op.networkID), addr.Bytes())
+			if err != nil {
+				return nil, err
+			}
+			controlKeys[i] = controlKey
+		}
+		reply.Subnets = append(reply.Subnets, omegavm.APISubnet{
+			ID:          subnetID,
+			ControlKeys: controlKeys,
+			Threshold:   odysseyjson.Uint32(owners.Threshold),
+		})
+	}
+	return reply, nil
+}
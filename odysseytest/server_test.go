@@ -0,0 +1,173 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package odysseytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/multisig"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/utils/units"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/status"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWallet(t *testing.T, srv *Server, kc *secp256k1fx.Keychain, oChainTxIDs ...ids.ID) wallet.Wallet {
+	w, err := wallet.New(context.Background(), &primary.WalletConfig{
+		URI:              srv.URI(),
+		DIONEKeychain:    kc,
+		OChainTxsToFetch: set.Of(oChainTxIDs...),
+	})
+	require.NoError(t, err)
+	return w
+}
+
+func TestServer_CreateSubnet(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	kc, err := NewKeychain(1)
+	require.NoError(t, err)
+	addr := kc.Addresses().List()[0]
+	srv.AddUTXOs(NewUTXO(srv.DIONEAssetID(), units.Dione, Owners(1, addr)))
+
+	w := newTestWallet(t, srv, kc)
+	assert.Equal(t, srv.URI(), w.URI())
+	assert.Equal(t, uint32(DefaultNetworkID), w.O().NetworkID())
+	balances, err := w.O().Builder().GetBalance()
+	require.NoError(t, err)
+	assert.Equal(t, units.Dione, balances[srv.DIONEAssetID()])
+
+	// the subnet of the issued tx is served with its owners, and the fee is spent at once
+	tx, err := w.O().IssueCreateSubnetTx(Owners(1, addr))
+	require.NoError(t, err)
+	require.Len(t, srv.IssuedTxs(), 1)
+	controlKeys, threshold, err := multisig.GetOwners(srv.Network(), tx.ID())
+	require.NoError(t, err)
+	assert.Equal(t, []ids.ShortID{addr}, controlKeys)
+	assert.Equal(t, uint32(1), threshold)
+	utxos := srv.UTXOs()
+	require.Len(t, utxos, 1)
+	assert.Equal(t, units.Dione-srv.op.txFees.CreateSubnetTxFee, utxos[0].Out.(*secp256k1fx.TransferOutput).Amt)
+
+	_, _, err = multisig.GetOwners(srv.Network(), ids.GenerateTestID())
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestServer_MultisigCommit(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	kcA, err := NewKeychain(1)
+	require.NoError(t, err)
+	kcB, err := NewKeychain(1)
+	require.NoError(t, err)
+	a, b := kcA.Addresses().List()[0], kcB.Addresses().List()[0]
+	srv.AddUTXOs(NewUTXO(srv.DIONEAssetID(), units.Dione, Owners(1, a)))
+	subnetID, err := srv.AddSubnet(Owners(2, a, b))
+	require.NoError(t, err)
+
+	// a builds and partially signs a chain creation, b completes it
+	wA := newTestWallet(t, srv, kcA, subnetID)
+	wA.SetSubnetAuthMultisig([]ids.ShortID{a, b})
+	utx, err := wA.O().Builder().NewCreateChainTx(subnetID, []byte("{}"), constants.OmegaChainID, nil, "test")
+	require.NoError(t, err)
+	tx, err := wA.O().Signer().SignUnsigned(context.Background(), utx)
+	require.NoError(t, err)
+	ms := multisig.New(tx)
+	ms.Network = srv.Network()
+	_, err = ms.Commit(context.Background(), srv.Network())
+	require.ErrorIs(t, err, multisig.ErrThresholdNotMet)
+
+	wB := newTestWallet(t, srv, kcB)
+	report, err := wB.AppendSignatures(ms)
+	require.NoError(t, err)
+	require.True(t, report.Complete())
+
+	result, err := ms.Commit(context.Background(), srv.Network())
+	require.NoError(t, err)
+	assert.Equal(t, status.Committed, result.Status)
+	assert.False(t, result.AlreadyIssued)
+	require.Len(t, srv.IssuedTxs(), 1)
+	assert.Equal(t, tx.ID(), srv.IssuedTxs()[0].ID())
+
+	// committing again finds the tx on the O-Chain
+	result, err = ms.Commit(context.Background(), srv.Network())
+	require.NoError(t, err)
+	assert.True(t, result.AlreadyIssued)
+	assert.Equal(t, 1, srv.Calls("omega.issueTx"))
+}
+
+func TestServer_IssueFailures(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	tx, err := NewCreateSubnetTx(DefaultNetworkID, Owners(1, ids.GenerateTestShortID()))
+	require.NoError(t, err)
+	ms := multisig.New(tx)
+
+	srv.FailIssueTx(errors.New("mempool is full"))
+	_, err = ms.Commit(context.Background(), srv.Network(), multisig.WithCommitRetries(1, 0))
+	require.ErrorContains(t, err, "mempool is full")
+
+	srv.FailIssueTx(nil)
+	srv.SetIssueStatus(status.Dropped)
+	_, err = ms.Commit(context.Background(), srv.Network())
+	require.ErrorIs(t, err, multisig.ErrTxDropped)
+	assert.Empty(t, srv.UTXOs())
+
+	// txs added to the server are known to it
+	known, err := NewCreateSubnetTx(DefaultNetworkID, Owners(1, ids.GenerateTestShortID()))
+	require.NoError(t, err)
+	srv.AddTx(known, status.Committed)
+	result, err := multisig.New(known).Commit(context.Background(), srv.Network())
+	require.NoError(t, err)
+	assert.True(t, result.AlreadyIssued)
+}
+
+func TestOwners(t *testing.T) {
+	addrs := []ids.ShortID{{3}, {1}, {2}}
+	owners := Owners(2, addrs...)
+	assert.Equal(t, []ids.ShortID{{1}, {2}, {3}}, owners.Addrs)
+	assert.Equal(t, []ids.ShortID{{3}, {1}, {2}}, addrs)
+	require.NoError(t, owners.Verify())
+}
+
+func TestServer_FailHealthChecks(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.FailHealthChecks("network")
+	config := &primary.WalletConfig{
+		URI:           srv.URI(),
+		DIONEKeychain: secp256k1fx.NewKeychain(),
+	}
+	err := srv.Network().IsUsable(context.Background())
+	require.ErrorIs(t, err, odyssey.ErrEndpointUnhealthy)
+	require.ErrorContains(t, err, "network")
+
+	// wallets only need the O-Chain to be bootstrapped
+	_, err = wallet.New(context.Background(), config)
+	require.NoError(t, err)
+}
+
+func TestServer_SetResult(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := omegavm.NewClient(srv.URI())
+	_, _, err := client.GetCurrentSupply(context.Background(), constants.PrimaryNetworkID)
+	require.ErrorContains(t, err, ErrUnknownMethod.Error())
+
+	srv.SetResult("omega.getCurrentSupply", map[string]interface{}{"supply": "1000", "height": "10"})
+	supply, height, err := client.GetCurrentSupply(context.Background(), constants.PrimaryNetworkID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), supply)
+	assert.Equal(t, uint64(10), height)
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package progress
+
+import "sync"
+
+// Stage identifies the step of a long-running operation an Event belongs to
+type Stage string
+
+const (
+	StageConnect   Stage = "connect"
+	StageProvision Stage = "provision"
+	StageCompose   Stage = "compose"
+	StageRestart   Stage = "restart"
+	StageHealth    Stage = "health"
+	StageTransfer  Stage = "transfer"
+	StageBuildTx   Stage = "build-tx"
+	StageIssueTx   Stage = "issue-tx"
+	StageSign      Stage = "sign"
+	StageDone      Stage = "done"
+)
+
+// UnknownPercent is the Percent of events whose progress cannot be estimated, such as
+// transfers of unknown size
+const UnknownPercent = -1
+
+// Event is a progress update of a long-running operation
+type Event struct {
+	// Stage is the step of the operation
+	Stage Stage
+
+	// Node is the ID of the node the event relates to, empty for operations not bound to a node
+	Node string
+
+	// Percent is the overall completion of the operation from 0 to 100, or UnknownPercent
+	Percent float64
+
+	// Message is a human readable description of the event
+	Message string
+}
+
+// Reporter receives the progress events of an operation. It may be called from multiple
+// goroutines when an operation runs on several nodes concurrently
+type Reporter func(Event)
+
+// Report sends e to r. It is a no-op on a nil Reporter
+func (r Reporter) Report(e Event) {
+	if r != nil {
+		r(e)
+	}
+}
+
+// ToChannel returns a Reporter sending the events to ch. Sends block until ch is read,
+// so ch should be buffered or consumed by another goroutine
+func ToChannel(ch chan<- Event) Reporter {
+	return func(e Event) {
+		ch <- e
+	}
+}
+
+// Tracker reports the progress of an operation made of a known number of steps
+type Tracker struct {
+	lock     sync.Mutex
+	reporter Reporter
+	node     string
+	total    int
+	done     int
+}
+
+// NewTracker creates a Tracker reporting to reporter the progress of total steps
+// run on node
+func NewTracker(reporter Reporter, node string, total int) *Tracker {
+	return &Tracker{
+		reporter: reporter,
+		node:     node,
+		total:    total,
+	}
+}
+
+// Step reports that a step of stage starts, with the completion of the steps done so far
+func (t *Tracker) Step(stage Stage, message string) {
+	t.lock.Lock()
+	percent := t.percent()
+	t.done++
+	t.lock.Unlock()
+	t.reporter.Report(Event{Stage: stage, Node: t.node, Percent: percent, Message: message})
+}
+
+// Done reports that the operation completed
+func (t *Tracker) Done(message string) {
+	t.lock.Lock()
+	t.done = t.total
+	t.lock.Unlock()
+	t.reporter.Report(Event{Stage: StageDone, Node: t.node, Percent: 100, Message: message})
+}
+
+func (t *Tracker) percent() float64 {
+	if t.total <= 0 || t.done >= t.total {
+		return 100
+	}
+	return float64(t.done) * 100 / float64(t.total)
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package progress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReporter_Nil(t *testing.T) {
+	var reporter Reporter
+	assert.NotPanics(t, func() {
+		reporter.Report(Event{Stage: StageConnect})
+		NewTracker(nil, "node-1", 2).Step(StageConnect, "connecting")
+	})
+}
+
+func TestToChannel(t *testing.T) {
+	ch := make(chan Event, 1)
+	ToChannel(ch).Report(Event{Stage: StageHealth, Node: "node-1", Percent: 50, Message: "waiting"})
+	require.Len(t, ch, 1)
+	assert.Equal(t, Event{Stage: StageHealth, Node: "node-1", Percent: 50, Message: "waiting"}, <-ch)
+}
+
+func TestTracker(t *testing.T) {
+	events := []Event{}
+	tracker := NewTracker(func(e Event) { events = append(events, e) }, "node-1", 4)
+	tracker.Step(StageConnect, "connecting")
+	tracker.Step(StageProvision, "provisioning validator")
+	tracker.Step(StageCompose, "composing")
+	tracker.Done("provisioned")
+
+	require.Len(t, events, 4)
+	for i, percent := range []float64{0, 25, 50, 100} {
+		assert.Equal(t, percent, events[i].Percent)
+		assert.Equal(t, "node-1", events[i].Node)
+	}
+	assert.Equal(t, StageProvision, events[1].Stage)
+	assert.Equal(t, "provisioning validator", events[1].Message)
+	assert.Equal(t, StageDone, events[3].Stage)
+}
+
+func TestTracker_MoreStepsThanTotal(t *testing.T) {
+	events := []Event{}
+	tracker := NewTracker(func(e Event) { events = append(events, e) }, "", 1)
+	tracker.Step(StageBuildTx, "building")
+	tracker.Step(StageIssueTx, "issuing")
+	require.Len(t, events, 2)
+	assert.Equal(t, float64(0), events[0].Percent)
+	assert.Equal(t, float64(100), events[1].Percent)
+}
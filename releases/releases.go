@@ -0,0 +1,337 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package releases discovers the GitHub releases of the DioneProtocol binaries, e.g. to
+// install the latest odysseygo release instead of a hard-coded tag
+package releases
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// Latest is the version selecting the latest release, e.g. as NodeParams.OdysseyGoVersion
+	Latest = "latest"
+
+	DefaultCacheTTL   = 10 * time.Minute
+	odysseyGoRepoName = "odysseygo"
+)
+
+var (
+	ErrNoRelease        = errors.New("no release found")
+	ErrAssetNotFound    = errors.New("release asset not found")
+	ErrNoChecksum       = errors.New("no checksum published for release asset")
+	ErrChecksumMismatch = errors.New("release asset checksum mismatch")
+)
+
+var odysseyGoClient = NewClient(constants.DioneProtocolOrg, odysseyGoRepoName)
+
+// Release is a GitHub release
+type Release struct {
+	Version    string  `json:"tag_name"`
+	PreRelease bool    `json:"prerelease"`
+	Draft      bool    `json:"draft"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Asset returns the asset of the release named name
+func (r Release) Asset(name string) (Asset, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
+// ClientOp holds the options of NewClient
+type ClientOp struct {
+	authToken  string
+	cacheTTL   time.Duration
+	baseURL    string
+	httpClient *http.Client
+}
+
+// ClientOption configures NewClient
+type ClientOption func(*ClientOp)
+
+// WithAuthToken authenticates the GitHub API requests, avoiding their rate limit
+func WithAuthToken(authToken string) ClientOption {
+	return func(op *ClientOp) {
+		op.authToken = authToken
+	}
+}
+
+// WithCacheTTL sets how long the release list is cached, DefaultCacheTTL by default.
+// Caching is disabled if cacheTTL is not positive
+func WithCacheTTL(cacheTTL time.Duration) ClientOption {
+	return func(op *ClientOp) {
+		op.cacheTTL = cacheTTL
+	}
+}
+
+// WithBaseURL sets the URL of the GitHub API, e.g. for GitHub Enterprise, the one of
+// utils.GetGithubReleasesURL by default
+func WithBaseURL(baseURL string) ClientOption {
+	return func(op *ClientOp) {
+		op.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithHTTPClient sets the HTTP client of the requests, http.DefaultClient by default
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(op *ClientOp) {
+		op.httpClient = httpClient
+	}
+}
+
+// VersionOp holds the options of LatestVersion and LatestOdysseygoVersion
+type VersionOp struct {
+	preReleases bool
+}
+
+// VersionOption configures LatestVersion and LatestOdysseygoVersion
+type VersionOption func(*VersionOp)
+
+// WithPreReleases makes pre-releases candidates for the latest version
+func WithPreReleases() VersionOption {
+	return func(op *VersionOp) {
+		op.preReleases = true
+	}
+}
+
+// DownloadOp holds the options of DownloadAsset
+type DownloadOp struct {
+	unverifiedFallback bool
+}
+
+// DownloadOption configures DownloadAsset
+type DownloadOption func(*DownloadOp)
+
+// WithUnverifiedFallback downloads the asset unverified if the release publishes no checksum
+// for it, instead of failing with ErrNoChecksum. A published checksum is still checked
+func WithUnverifiedFallback() DownloadOption {
+	return func(op *DownloadOp) {
+		op.unverifiedFallback = true
+	}
+}
+
+// Client lists the releases of a GitHub repository, caching them
+type Client struct {
+	org  string
+	repo string
+	op   ClientOp
+
+	lock      sync.Mutex
+	releases  []Release
+	fetchedAt time.Time
+}
+
+// NewClient returns a client for the releases of org/repo
+func NewClient(org, repo string, opts ...ClientOption) *Client {
+	op := ClientOp{
+		cacheTTL:   DefaultCacheTTL,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	return &Client{
+		org:  org,
+		repo: repo,
+		op:   op,
+	}
+}
+
+// OdysseyGo returns the shared client of the odysseygo releases
+func OdysseyGo() *Client {
+	return odysseyGoClient
+}
+
+// Releases returns the published releases, newest first. Drafts and non semver tags are
+// skipped
+func (c *Client) Releases(ctx context.Context) ([]Release, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.releases != nil && time.Since(c.fetchedAt) < c.op.cacheTTL {
+		return c.releases, nil
+	}
+	releasesURL := utils.GetGithubReleasesURL(c.org, c.repo)
+	if c.op.baseURL != "" {
+		releasesURL = fmt.Sprintf("%s/repos/%s/%s/releases", c.op.baseURL, c.org, c.repo)
+	}
+	body, err := c.get(ctx, releasesURL)
+	if err != nil {
+		return nil, err
+	}
+	var fetched []Release
+	if err := json.Unmarshal(body, &fetched); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s/%s releases: %w", c.org, c.repo, err)
+	}
+	releases := []Release{}
+	for _, release := range fetched {
+		if !release.Draft && semver.IsValid(release.Version) {
+			releases = append(releases, release)
+		}
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		return semver.Compare(releases[i].Version, releases[j].Version) > 0
+	})
+	c.releases = releases
+	c.fetchedAt = time.Now()
+	return releases, nil
+}
+
+// Release returns the release of version, or the latest stable one if version is Latest
+func (c *Client) Release(ctx context.Context, version string) (Release, error) {
+	releases, err := c.Releases(ctx)
+	if err != nil {
+		return Release{}, err
+	}
+	for _, release := range releases {
+		if (version == Latest && !release.PreRelease) || release.Version == version {
+			return release, nil
+		}
+	}
+	return Release{}, fmt.Errorf("%w for %s/%s matching %s", ErrNoRelease, c.org, c.repo, version)
+}
+
+// LatestVersion returns the version of the latest stable release, or of the latest release
+// WithPreReleases
+func (c *Client) LatestVersion(ctx context.Context, opts ...VersionOption) (string, error) {
+	op := VersionOp{}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	releases, err := c.Releases(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, release := range releases {
+		if op.preReleases || !release.PreRelease {
+			return release.Version, nil
+		}
+	}
+	return "", fmt.Errorf("%w for %s/%s", ErrNoRelease, c.org, c.repo)
+}
+
+// DownloadAsset downloads the asset assetName of the release of version, checking its SHA-256
+// against the checksum published with the release, either as an assetName.sha256 asset or as
+// a line of a checksums asset in the sha256sum format
+func (c *Client) DownloadAsset(ctx context.Context, version, assetName string, opts ...DownloadOption) ([]byte, error) {
+	op := DownloadOp{}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	release, err := c.Release(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	asset, ok := release.Asset(assetName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s in %s", ErrAssetNotFound, assetName, release.Version)
+	}
+	expectedSum, err := c.releaseAssetChecksum(ctx, release, assetName)
+	if err != nil && !(errors.Is(err, ErrNoChecksum) && op.unverifiedFallback) {
+		return nil, err
+	}
+	content, err := c.get(ctx, asset.URL)
+	if err != nil {
+		return nil, err
+	}
+	if expectedSum == "" {
+		return content, nil
+	}
+	sum := sha256.Sum256(content)
+	if actualSum := hex.EncodeToString(sum[:]); actualSum != expectedSum {
+		return nil, fmt.Errorf("%w for %s: expected %s, got %s", ErrChecksumMismatch, assetName, expectedSum, actualSum)
+	}
+	return content, nil
+}
+
+// AssetChecksum returns the hex SHA-256 of the asset assetName of the release of version, as
+// published with the release, see DownloadAsset
+func (c *Client) AssetChecksum(ctx context.Context, version, assetName string) (string, error) {
+	release, err := c.Release(ctx, version)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := release.Asset(assetName); !ok {
+		return "", fmt.Errorf("%w: %s in %s", ErrAssetNotFound, assetName, release.Version)
+	}
+	return c.releaseAssetChecksum(ctx, release, assetName)
+}
+
+// releaseAssetChecksum returns the hex SHA-256 published for assetName in release
+func (c *Client) releaseAssetChecksum(ctx context.Context, release Release, assetName string) (string, error) {
+	if checksumAsset, ok := release.Asset(assetName + ".sha256"); ok {
+		content, err := c.get(ctx, checksumAsset.URL)
+		if err != nil {
+			return "", err
+		}
+		if fields := strings.Fields(string(content)); len(fields) > 0 {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	for _, checksumAsset := range release.Assets {
+		if !strings.Contains(strings.ToLower(checksumAsset.Name), "checksums") {
+			continue
+		}
+		content, err := c.get(ctx, checksumAsset.URL)
+		if err != nil {
+			return "", err
+		}
+		if sum, ok := findChecksum(content, assetName); ok {
+			return sum, nil
+		}
+	}
+	return "", fmt.Errorf("%w %s in %s", ErrNoChecksum, assetName, release.Version)
+}
+
+// findChecksum returns the checksum of assetName in the sha256sum output content
+func findChecksum(content []byte, assetName string) (string, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == assetName {
+			return strings.ToLower(fields[0]), true
+		}
+	}
+	return "", false
+}
+
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	return utils.HTTPGetWithClient(ctx, c.op.httpClient, url, c.op.authToken)
+}
+
+// LatestOdysseygoVersion returns the latest odysseygo release to run on network. Mainnet
+// nodes only run stable releases, so WithPreReleases is ignored for mainnet
+func LatestOdysseygoVersion(ctx context.Context, network odyssey.Network, opts ...VersionOption) (string, error) {
+	if network.Kind == odyssey.Mainnet {
+		opts = nil
+	}
+	return OdysseyGo().LatestVersion(ctx, opts...)
+}
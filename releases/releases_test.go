@@ -0,0 +1,139 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package releases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReleasesServer(t *testing.T, releases func(baseURL string) []Release, files map[string]string) (*httptest.Server, *int32) {
+	listCalls := new(int32)
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/DioneProtocol/odysseygo/releases" {
+			atomic.AddInt32(listCalls, 1)
+			require.NoError(t, json.NewEncoder(w).Encode(releases(server.URL)))
+			return
+		}
+		content, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+	return server, listCalls
+}
+
+func TestClient_LatestVersion(t *testing.T) {
+	server, listCalls := newReleasesServer(t, func(string) []Release {
+		return []Release{
+			{Version: "v1.10.9"},
+			{Version: "v1.10.11", PreRelease: true},
+			{Version: "v1.10.12", Draft: true},
+			{Version: "v1.10.10"},
+			{Version: "nightly"},
+		}
+	}, nil)
+	client := NewClient("DioneProtocol", "odysseygo", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	version, err := client.LatestVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.10.10", version)
+
+	version, err = client.LatestVersion(ctx, WithPreReleases())
+	require.NoError(t, err)
+	assert.Equal(t, "v1.10.11", version)
+
+	releases, err := client.Releases(ctx)
+	require.NoError(t, err)
+	require.Len(t, releases, 3)
+	assert.Equal(t, "v1.10.9", releases[2].Version)
+	// the releases are cached
+	assert.Equal(t, int32(1), atomic.LoadInt32(listCalls))
+
+	uncached := NewClient("DioneProtocol", "odysseygo", WithBaseURL(server.URL), WithCacheTTL(0))
+	_, err = uncached.Releases(ctx)
+	require.NoError(t, err)
+	_, err = uncached.Releases(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(listCalls))
+
+	empty, _ := newReleasesServer(t, func(string) []Release { return []Release{{Version: "v1.0.0", PreRelease: true}} }, nil)
+	_, err = NewClient("DioneProtocol", "odysseygo", WithBaseURL(empty.URL)).LatestVersion(ctx)
+	require.ErrorIs(t, err, ErrNoRelease)
+}
+
+func TestClient_DownloadAsset(t *testing.T) {
+	const archive = "odysseygo-linux-amd64-v1.10.10.tar.gz"
+	content := "archive content"
+	sum := sha256.Sum256([]byte(content))
+	checksums := hex.EncodeToString(sum[:]) + "  " + archive + "\n"
+	server, _ := newReleasesServer(t, func(baseURL string) []Release {
+		return []Release{
+			{
+				Version: "v1.10.10",
+				Assets: []Asset{
+					{Name: archive, URL: baseURL + "/download/" + archive},
+					{Name: "checksums.txt", URL: baseURL + "/download/checksums.txt"},
+					{Name: "unchecked.tar.gz", URL: baseURL + "/download/unchecked.tar.gz"},
+				},
+			},
+			{
+				Version: "v1.10.9",
+				Assets: []Asset{
+					{Name: archive, URL: baseURL + "/download/tampered"},
+					{Name: archive + ".sha256", URL: baseURL + "/download/archive.sha256"},
+				},
+			},
+		}
+	}, map[string]string{
+		"/download/" + archive:       content,
+		"/download/checksums.txt":    checksums,
+		"/download/unchecked.tar.gz": "unchecked",
+		"/download/tampered":         "tampered content",
+		"/download/archive.sha256":   checksums,
+	})
+	client := NewClient("DioneProtocol", "odysseygo", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	downloaded, err := client.DownloadAsset(ctx, Latest, archive)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(downloaded))
+
+	_, err = client.DownloadAsset(ctx, "v1.10.9", archive)
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+
+	_, err = client.DownloadAsset(ctx, "v1.10.10", "unchecked.tar.gz")
+	require.ErrorIs(t, err, ErrNoChecksum)
+	downloaded, err = client.DownloadAsset(ctx, "v1.10.10", "unchecked.tar.gz", WithUnverifiedFallback())
+	require.NoError(t, err)
+	assert.Equal(t, "unchecked", string(downloaded))
+	_, err = client.DownloadAsset(ctx, "v1.10.9", archive, WithUnverifiedFallback())
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+
+	_, err = client.DownloadAsset(ctx, "v1.10.10", "missing.tar.gz")
+	require.ErrorIs(t, err, ErrAssetNotFound)
+
+	_, err = client.DownloadAsset(ctx, "v1.0.0", archive)
+	require.ErrorIs(t, err, ErrNoRelease)
+
+	checksum, err := client.AssetChecksum(ctx, "v1.10.10", archive)
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(sum[:]), checksum)
+	_, err = client.AssetChecksum(ctx, "v1.10.10", "missing.tar.gz")
+	require.ErrorIs(t, err, ErrAssetNotFound)
+}
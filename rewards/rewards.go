@@ -0,0 +1,155 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rewards
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"github.com/DioneProtocol/odysseygo/api"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/formatting"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/reward"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+)
+
+var (
+	ErrUndefinedNetwork = errors.New("network has no genesis parameters")
+	ErrNotValidator     = errors.New("node is not a current primary network validator")
+)
+
+// AccruedRewards are the rewards accrued so far by a current Primary Network validator
+type AccruedRewards struct {
+	// TxID is the ID of the tx that added the validator
+	TxID ids.ID
+
+	// EndTime is when the validator stops validating and its rewards are paid
+	EndTime time.Time
+
+	// PotentialReward is the reward, in nDIONE, the validator receives at EndTime if it
+	// meets the uptime requirement
+	PotentialReward uint64
+
+	// DelegateeReward is the amount, in nDIONE, accrued by the validator from delegation fees
+	DelegateeReward uint64
+}
+
+// EstimateReward estimates the reward, in nDIONE, of staking amount nDIONE on the Primary
+// Network of network for duration.
+//
+// Primary Network validators share the DIONE minted every minting period proportionally to
+// their stake, so the estimate assumes the current supply and total stake of the network stay
+// unchanged for duration. Fee rewards are not included.
+func EstimateReward(amount uint64, duration time.Duration, network odyssey.Network) (uint64, error) {
+	genesisParams := network.GenesisParams()
+	if genesisParams == nil {
+		return 0, ErrUndefinedNetwork
+	}
+	client := omegavm.NewClient(network.Endpoint)
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	currentSupply, _, err := client.GetCurrentSupply(ctx, ids.Empty)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get current supply: %w", err)
+	}
+	totalStake, err := client.GetTotalStake(ctx, ids.Empty)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get total stake: %w", err)
+	}
+	return estimateMintReward(amount, duration, currentSupply, totalStake, genesisParams.MintConfig), nil
+}
+
+// estimateMintReward returns the share of amount in the DIONE minted during duration, once
+// amount is added to totalStake
+func estimateMintReward(amount uint64, duration time.Duration, currentSupply uint64, totalStake uint64, config reward.MintConfig) uint64 {
+	if amount == 0 || duration <= 0 || config.MintingPeriod <= 0 {
+		return 0
+	}
+	mintAmount := new(big.Int).SetUint64(currentSupply)
+	mintAmount.Mul(mintAmount, new(big.Int).SetUint64(config.MintRate))
+	mintAmount.Div(mintAmount, new(big.Int).SetUint64(reward.PercentDenominator))
+	if maxMintAmount := new(big.Int).SetUint64(config.MaxMintAmount); mintAmount.Cmp(maxMintAmount) > 0 {
+		mintAmount = maxMintAmount
+	}
+	result := mintAmount
+	result.Mul(result, new(big.Int).SetInt64(int64(duration)))
+	result.Mul(result, new(big.Int).SetUint64(amount))
+	denominator := new(big.Int).SetInt64(int64(config.MintingPeriod))
+	denominator.Mul(denominator, new(big.Int).Add(new(big.Int).SetUint64(totalStake), new(big.Int).SetUint64(amount)))
+	result.Div(result, denominator)
+	if !result.IsUint64() {
+		return 0
+	}
+	return result.Uint64()
+}
+
+// GetAccruedRewards returns the rewards accrued by nodeID, a current Primary Network validator
+// of network
+func GetAccruedRewards(network odyssey.Network, nodeID ids.NodeID) (AccruedRewards, error) {
+	client := omegavm.NewClient(network.Endpoint)
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	validators, err := client.GetCurrentValidators(ctx, ids.Empty, []ids.NodeID{nodeID})
+	if err != nil {
+		return AccruedRewards{}, fmt.Errorf("failed to get current validators: %w", err)
+	}
+	for _, validator := range validators {
+		if validator.NodeID != nodeID {
+			continue
+		}
+		rewards := AccruedRewards{
+			TxID:    validator.TxID,
+			EndTime: time.Unix(int64(validator.EndTime), 0),
+		}
+		if validator.PotentialReward != nil {
+			rewards.PotentialReward = *validator.PotentialReward
+		}
+		if validator.AccruedDelegateeReward != nil {
+			rewards.DelegateeReward = *validator.AccruedDelegateeReward
+		}
+		return rewards, nil
+	}
+	return AccruedRewards{}, fmt.Errorf("%w: %s", ErrNotValidator, nodeID)
+}
+
+// GetRewardUTXOs returns the UTXOs paid as reward to the staker added by txID on network,
+// once its staking period is over
+func GetRewardUTXOs(network odyssey.Network, txID ids.ID) ([]*dione.UTXO, error) {
+	client := omegavm.NewClient(network.Endpoint)
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	utxosBytes, err := client.GetRewardUTXOs(ctx, &api.GetTxArgs{
+		TxID:     txID,
+		Encoding: formatting.Hex,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reward UTXOs of tx %s: %w", txID, err)
+	}
+	utxos := make([]*dione.UTXO, 0, len(utxosBytes))
+	for _, utxoBytes := range utxosBytes {
+		utxo := &dione.UTXO{}
+		if _, err := txs.GenesisCodec.Unmarshal(utxoBytes, utxo); err != nil {
+			return nil, fmt.Errorf("failed to parse reward UTXO of tx %s: %w", txID, err)
+		}
+		utxos = append(utxos, utxo)
+	}
+	return utxos, nil
+}
+
+// RewardAmount returns the total amount, in nDIONE, of the reward UTXOs
+func RewardAmount(utxos []*dione.UTXO) uint64 {
+	total := uint64(0)
+	for _, utxo := range utxos {
+		if out, ok := utxo.Out.(dione.Amounter); ok {
+			total += out.Amount()
+		}
+	}
+	return total
+}
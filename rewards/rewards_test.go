@@ -0,0 +1,158 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rewards
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odysseytest"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/formatting"
+	"github.com/DioneProtocol/odysseygo/utils/units"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/reward"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateMintReward(t *testing.T) {
+	config := reward.MintConfig{
+		MintingPeriod: 365 * 24 * time.Hour,
+		MaxMintAmount: 500 * units.MegaDione,
+		MintRate:      40_000, // 4%
+	}
+	tests := []struct {
+		name          string
+		amount        uint64
+		duration      time.Duration
+		currentSupply uint64
+		totalStake    uint64
+		expected      uint64
+	}{
+		{
+			name:          "full period, half of the stake",
+			amount:        100 * units.MegaDione,
+			duration:      365 * 24 * time.Hour,
+			currentSupply: 1000 * units.MegaDione,
+			totalStake:    100 * units.MegaDione,
+			expected:      20 * units.MegaDione,
+		},
+		{
+			name:          "half period, whole stake",
+			amount:        100 * units.MegaDione,
+			duration:      365 * 12 * time.Hour,
+			currentSupply: 1000 * units.MegaDione,
+			expected:      20 * units.MegaDione,
+		},
+		{
+			name:          "mint amount capped",
+			amount:        100 * units.MegaDione,
+			duration:      365 * 24 * time.Hour,
+			currentSupply: 15_000 * units.MegaDione,
+			expected:      500 * units.MegaDione,
+		},
+		{
+			name:          "zero amount",
+			duration:      time.Hour,
+			currentSupply: 1000 * units.MegaDione,
+		},
+		{
+			name:          "zero duration",
+			amount:        100 * units.MegaDione,
+			currentSupply: 1000 * units.MegaDione,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, estimateMintReward(tt.amount, tt.duration, tt.currentSupply, tt.totalStake, config))
+		})
+	}
+}
+
+func TestEstimateReward(t *testing.T) {
+	srv := odysseytest.NewServer()
+	defer srv.Close()
+	srv.SetResult("omega.getCurrentSupply", map[string]interface{}{"supply": "1000000000000000000", "height": "10"})
+	srv.SetResult("omega.getTotalStake", map[string]interface{}{"stake": "100000000000000000", "weight": "100000000000000000"})
+	network := odyssey.NewNetwork(odyssey.Testnet, 0, srv.URI())
+	estimate, err := EstimateReward(100*units.MegaDione, 365*24*time.Hour, network)
+	require.NoError(t, err)
+	mintConfig := network.GenesisParams().MintConfig
+	assert.Equal(t, estimateMintReward(100*units.MegaDione, 365*24*time.Hour, 1000*units.MegaDione, 100*units.MegaDione, mintConfig), estimate)
+	assert.NotZero(t, estimate)
+
+	_, err = EstimateReward(units.Dione, time.Hour, odyssey.UndefinedNetwork)
+	assert.ErrorIs(t, err, ErrUndefinedNetwork)
+}
+
+func TestGetAccruedRewards(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+	txID := ids.GenerateTestID()
+	srv := odysseytest.NewServer()
+	defer srv.Close()
+	srv.SetResult("omega.getCurrentValidators", map[string]interface{}{
+		"validators": []interface{}{
+			map[string]interface{}{
+				"txID":                   txID.String(),
+				"startTime":              "1700000000",
+				"endTime":                "1710000000",
+				"weight":                 "2000000000000",
+				"nodeID":                 nodeID.String(),
+				"potentialReward":        "12345",
+				"accruedDelegateeReward": "678",
+			},
+		},
+	})
+	network := odyssey.NewNetwork(odyssey.Testnet, 0, srv.URI())
+
+	rewards, err := GetAccruedRewards(network, nodeID)
+	require.NoError(t, err)
+	assert.Equal(t, AccruedRewards{
+		TxID:            txID,
+		EndTime:         time.Unix(1710000000, 0),
+		PotentialReward: 12345,
+		DelegateeReward: 678,
+	}, rewards)
+
+	_, err = GetAccruedRewards(network, ids.GenerateTestNodeID())
+	assert.ErrorIs(t, err, ErrNotValidator)
+}
+
+func TestGetRewardUTXOs(t *testing.T) {
+	assetID := ids.GenerateTestID()
+	encodedUTXOs := []string{}
+	for _, amount := range []uint64{1000, 234} {
+		utxo := &dione.UTXO{
+			UTXOID: dione.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  dione.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amount,
+				OutputOwners: secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{ids.GenerateTestShortID()}},
+			},
+		}
+		utxoBytes, err := txs.GenesisCodec.Marshal(txs.Version, utxo)
+		require.NoError(t, err)
+		encoded, err := formatting.Encode(formatting.Hex, utxoBytes)
+		require.NoError(t, err)
+		encodedUTXOs = append(encodedUTXOs, encoded)
+	}
+	srv := odysseytest.NewServer()
+	defer srv.Close()
+	srv.SetResult("omega.getRewardUTXOs", map[string]interface{}{
+		"numFetched": "2",
+		"utxos":      encodedUTXOs,
+		"encoding":   "hex",
+	})
+
+	utxos, err := GetRewardUTXOs(odyssey.NewNetwork(odyssey.Testnet, 0, srv.URI()), ids.GenerateTestID())
+	require.NoError(t, err)
+	require.Len(t, utxos, 2)
+	assert.Equal(t, assetID, utxos[0].AssetID())
+	assert.Equal(t, uint64(1234), RewardAmount(utxos))
+}
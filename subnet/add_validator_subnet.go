@@ -19,15 +19,22 @@ import (
 )
 
 var (
-	ErrEmptyValidatorNodeID   = errors.New("validator node id is not provided")
-	ErrEmptyValidatorDuration = errors.New("validator duration is not provided")
+	ErrEmptyValidatorNodeID   = validator.ErrEmptyNodeID
+	ErrEmptyValidatorDuration = validator.ErrEmptyDuration
 	ErrEmptySubnetID          = errors.New("subnet ID is not provided")
 	ErrEmptySubnetAuth        = errors.New("no subnet auth keys is provided")
+	ErrEmptyControlKeys       = errors.New("control keys are not provided")
+	ErrEmptyThreshold         = errors.New("threshold is not provided")
+	ErrEmptySubnetAuthKeys    = errors.New("subnet authkeys are not provided")
+	ErrEmptyVMID              = errors.New("vm ID is not provided")
+	ErrEmptySubnetName        = errors.New("subnet name is not provided")
+	ErrEmptyGenesis           = errors.New("genesis is not provided")
 )
 
 // AddValidator adds validator to subnet
 // Before an Odyssey Node can be added as a validator to a Subnet, the node must already be
-// tracking the subnet, which can be done by calling SyncSubnets in node package
+// tracking the subnet, which can be done by calling SyncSubnets in node package.
+// The fee is paid by the fee payer of wallet if set, see wallet.SetFeePayer
 func (c *Subnet) AddValidator(wallet wallet.Wallet, validatorInput validator.SubnetValidatorParams) (*multisig.Multisig, error) {
 	if validatorInput.NodeID == ids.EmptyNodeID {
 		return nil, ErrEmptyValidatorNodeID
@@ -44,6 +51,10 @@ func (c *Subnet) AddValidator(wallet wallet.Wallet, validatorInput validator.Sub
 	if len(c.DeployInfo.SubnetAuthKeys) == 0 {
 		return nil, ErrEmptySubnetAuth
 	}
+	memoOptions, err := c.memoOptions(validatorInput.Memo)
+	if err != nil {
+		return nil, err
+	}
 
 	wallet.SetSubnetAuthMultisig(c.DeployInfo.SubnetAuthKeys)
 
@@ -56,7 +67,7 @@ func (c *Subnet) AddValidator(wallet wallet.Wallet, validatorInput validator.Sub
 		Subnet: c.SubnetID,
 	}
 
-	unsignedTx, err := wallet.O().Builder().NewAddSubnetValidatorTx(validator)
+	unsignedTx, err := wallet.O().Builder().NewAddSubnetValidatorTx(validator, memoOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("error building tx: %w", err)
 	}
@@ -0,0 +1,280 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DioneProtocol/subnet-evm/core"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrUnknownAllocationFormat = errors.New("unknown allocation file format, expected .csv or .json")
+	ErrEmptyAllocation         = errors.New("allocation file has no entries")
+	ErrInvalidAllocationAddr   = errors.New("invalid allocation address")
+	ErrInvalidAllocationAmount = errors.New("invalid allocation amount")
+	ErrInvalidVesting          = errors.New("invalid allocation vesting time")
+	ErrDuplicateAllocation     = errors.New("duplicated allocation address")
+	ErrSupplyCapExceeded       = errors.New("allocation total exceeds the supply cap")
+	ErrVestingUnsupported      = errors.New("genesis balances cannot be vested, use a vesting contract")
+)
+
+// AirdropEntry is an entry of an airdrop file
+type AirdropEntry struct {
+	// Address is the D-Chain style address receiving the funds
+	Address common.Address
+
+	// Amount is the allocated balance, in wei
+	Amount *big.Int
+
+	// VestingEnd is the time until which the funds are expected to be locked, if any
+	VestingEnd time.Time
+
+	// Source locates the entry in its file for error messages, e.g. airdrop.csv:3
+	Source string
+}
+
+// AllocationOp holds the options of AllocationFromFile
+type AllocationOp struct {
+	supplyCap       *big.Int
+	unlockedVesting bool
+}
+
+// AllocationOption configures AllocationFromFile
+type AllocationOption func(*AllocationOp)
+
+// WithSupplyCap makes AllocationFromFile fail if the allocated total exceeds supplyCap wei
+func WithSupplyCap(supplyCap *big.Int) AllocationOption {
+	return func(op *AllocationOp) {
+		op.supplyCap = supplyCap
+	}
+}
+
+// WithUnlockedVesting makes AllocationFromFile allocate vested entries as regular balances.
+// Plain genesis balances cannot be locked, so vested entries are rejected by default: use
+// SubnetEVMParams.Vesting to lock them in a vesting contract instead
+func WithUnlockedVesting() AllocationOption {
+	return func(op *AllocationOp) {
+		op.unlockedVesting = true
+	}
+}
+
+// AllocationFromFile parses the airdrop list at path into a genesis allocation that can be
+// set as SubnetEVMParams.Allocation. The file is either:
+//   - a CSV file with address,amount[,vesting] records and an optional header row
+//   - a JSON array of {"address": ..., "amount": ..., "vesting": ...} objects
+//
+// Amounts are in wei, either decimal or 0x prefixed hex. Vesting is the optional unlock time,
+// either RFC3339, YYYY-MM-DD or unix seconds. Mixed case addresses must have a valid EIP-55
+// checksum, and each address can only be allocated once
+func AllocationFromFile(path string, opts ...AllocationOption) (core.GenesisAlloc, error) {
+	entries, err := ParseAirdropFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return AllocationFromEntries(entries, opts...)
+}
+
+// AllocationFromEntries validates entries and converts them into a genesis allocation,
+// as AllocationFromFile does
+func AllocationFromEntries(entries []AirdropEntry, opts ...AllocationOption) (core.GenesisAlloc, error) {
+	op := AllocationOp{}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	if len(entries) == 0 {
+		return nil, ErrEmptyAllocation
+	}
+	allocation := core.GenesisAlloc{}
+	sources := map[common.Address]string{}
+	total := new(big.Int)
+	for _, entry := range entries {
+		if source, found := sources[entry.Address]; found {
+			return nil, fmt.Errorf("%s: %w %s, already allocated at %s", entry.Source, ErrDuplicateAllocation, entry.Address, source)
+		}
+		if entry.Amount == nil || entry.Amount.Sign() < 0 {
+			return nil, fmt.Errorf("%s: %w for %s: amount must be non negative", entry.Source, ErrInvalidAllocationAmount, entry.Address)
+		}
+		if !entry.VestingEnd.IsZero() && !op.unlockedVesting {
+			return nil, fmt.Errorf("%s: %w: %s vests until %s", entry.Source, ErrVestingUnsupported, entry.Address, entry.VestingEnd.Format(time.RFC3339))
+		}
+		sources[entry.Address] = entry.Source
+		allocation[entry.Address] = core.GenesisAccount{Balance: new(big.Int).Set(entry.Amount)}
+		total.Add(total, entry.Amount)
+	}
+	if op.supplyCap != nil && total.Cmp(op.supplyCap) > 0 {
+		return nil, fmt.Errorf("%w: total %s, cap %s", ErrSupplyCapExceeded, total, op.supplyCap)
+	}
+	return allocation, nil
+}
+
+// ParseAirdropFile parses the entries of the CSV or JSON airdrop file at path, in file order.
+// See AllocationFromFile for the file formats
+func ParseAirdropFile(path string) ([]AirdropEntry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	name := filepath.Base(path)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return parseAirdropCSV(name, content)
+	case ".json":
+		return parseAirdropJSON(name, content)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownAllocationFormat, path)
+}
+
+func parseAirdropCSV(name string, content []byte) ([]AirdropEntry, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	entries := []AirdropEntry{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		line, _ := reader.FieldPos(0)
+		source := fmt.Sprintf("%s:%d", name, line)
+		if len(entries) == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "address") {
+			continue
+		}
+		if len(record) < 2 || len(record) > 3 {
+			return nil, fmt.Errorf("%s: expected address,amount[,vesting], found %d fields", source, len(record))
+		}
+		vesting := ""
+		if len(record) == 3 {
+			vesting = record[2]
+		}
+		entry, err := parseAirdropEntry(source, record[0], record[1], vesting)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// airdropJSONEntry is an entry of a JSON airdrop file
+type airdropJSONEntry struct {
+	Address string         `json:"address"`
+	Amount  numberOrString `json:"amount"`
+	Vesting numberOrString `json:"vesting,omitempty"`
+}
+
+// numberOrString is a JSON value given either as a string or as a number, e.g. amounts too
+// large for JSON parsers using floats are usually given as strings
+type numberOrString string
+
+func (v *numberOrString) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*v = numberOrString(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("expected a string or a number, found %s", data)
+	}
+	*v = numberOrString(n)
+	return nil
+}
+
+func parseAirdropJSON(name string, content []byte) ([]AirdropEntry, error) {
+	jsonEntries := []airdropJSONEntry{}
+	if err := json.Unmarshal(content, &jsonEntries); err != nil {
+		return nil, fmt.Errorf("%s: invalid JSON airdrop list: %w", name, err)
+	}
+	entries := make([]AirdropEntry, 0, len(jsonEntries))
+	for i, jsonEntry := range jsonEntries {
+		source := fmt.Sprintf("%s entry %d", name, i+1)
+		entry, err := parseAirdropEntry(source, jsonEntry.Address, string(jsonEntry.Amount), string(jsonEntry.Vesting))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func parseAirdropEntry(source string, addr string, amount string, vesting string) (AirdropEntry, error) {
+	entry := AirdropEntry{Source: source}
+	var err error
+	if entry.Address, err = parseAllocationAddress(addr); err != nil {
+		return AirdropEntry{}, fmt.Errorf("%s: %w", source, err)
+	}
+	if entry.Amount, err = parseAllocationAmount(amount); err != nil {
+		return AirdropEntry{}, fmt.Errorf("%s: %w", source, err)
+	}
+	if entry.VestingEnd, err = parseVesting(vesting); err != nil {
+		return AirdropEntry{}, fmt.Errorf("%s: %w", source, err)
+	}
+	return entry, nil
+}
+
+// parseAllocationAddress parses a hex address, checking the EIP-55 checksum of mixed case ones
+func parseAllocationAddress(s string) (common.Address, error) {
+	s = strings.TrimSpace(s)
+	if !common.IsHexAddress(s) {
+		return common.Address{}, fmt.Errorf("%w %q", ErrInvalidAllocationAddr, s)
+	}
+	addr := common.HexToAddress(s)
+	hexPart := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	isMixedCase := strings.ToLower(hexPart) != hexPart && strings.ToUpper(hexPart) != hexPart
+	if isMixedCase && "0x"+hexPart != addr.Hex() {
+		return common.Address{}, fmt.Errorf("%w %q: bad checksum, expected %s", ErrInvalidAllocationAddr, s, addr.Hex())
+	}
+	return addr, nil
+}
+
+// parseAllocationAmount parses a decimal or 0x prefixed hex amount
+func parseAllocationAmount(s string) (*big.Int, error) {
+	s = strings.TrimSpace(s)
+	digits, base := s, 10
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		digits, base = s[2:], 16
+	}
+	amount, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		return nil, fmt.Errorf("%w %q: expected a decimal or 0x prefixed hex integer", ErrInvalidAllocationAmount, s)
+	}
+	if amount.Sign() < 0 {
+		return nil, fmt.Errorf("%w %q: amount must be non negative", ErrInvalidAllocationAmount, s)
+	}
+	return amount, nil
+}
+
+// parseVesting parses an optional unlock time, either RFC3339, YYYY-MM-DD or unix seconds
+func parseVesting(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if seconds, err := strconv.ParseInt(s, 10, 64); err == nil && seconds > 0 {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w %q: expected RFC3339, YYYY-MM-DD or unix seconds", ErrInvalidVesting, s)
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ewoqEVMAddress  = "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"
+	otherEVMAddress = "0x0000000000000000000000000000000000000001"
+)
+
+func writeAirdropFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestAllocationFromFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		content     string
+		opts        []AllocationOption
+		expected    map[string]int64
+		expectedErr error
+	}{
+		{
+			name:     "csv with header",
+			file:     "airdrop.csv",
+			content:  "address,amount\n" + ewoqEVMAddress + ",1000\n" + otherEVMAddress + ",0x10\n",
+			expected: map[string]int64{ewoqEVMAddress: 1000, otherEVMAddress: 16},
+		},
+		{
+			name:     "json with string and number amounts",
+			file:     "airdrop.json",
+			content:  `[{"address": "` + ewoqEVMAddress + `", "amount": "1000"}, {"address": "` + otherEVMAddress + `", "amount": 16}]`,
+			expected: map[string]int64{ewoqEVMAddress: 1000, otherEVMAddress: 16},
+		},
+		{
+			name:     "lowercase address",
+			file:     "airdrop.csv",
+			content:  "0x8db97c7cece249c2b98bdc0226cc4c2a57bf52fc,5\n",
+			expected: map[string]int64{ewoqEVMAddress: 5},
+		},
+		{
+			name:        "bad checksum",
+			file:        "airdrop.csv",
+			content:     "0x8Db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC,5\n",
+			expectedErr: ErrInvalidAllocationAddr,
+		},
+		{
+			name:        "invalid amount",
+			file:        "airdrop.csv",
+			content:     ewoqEVMAddress + ",1.5\n",
+			expectedErr: ErrInvalidAllocationAmount,
+		},
+		{
+			name:        "negative amount",
+			file:        "airdrop.json",
+			content:     `[{"address": "` + ewoqEVMAddress + `", "amount": -1}]`,
+			expectedErr: ErrInvalidAllocationAmount,
+		},
+		{
+			name:        "duplicated address",
+			file:        "airdrop.csv",
+			content:     ewoqEVMAddress + ",1\n" + "0x8db97c7cece249c2b98bdc0226cc4c2a57bf52fc,2\n",
+			expectedErr: ErrDuplicateAllocation,
+		},
+		{
+			name:        "supply cap exceeded",
+			file:        "airdrop.csv",
+			content:     ewoqEVMAddress + ",600\n" + otherEVMAddress + ",600\n",
+			opts:        []AllocationOption{WithSupplyCap(big.NewInt(1000))},
+			expectedErr: ErrSupplyCapExceeded,
+		},
+		{
+			name:        "vesting rejected",
+			file:        "airdrop.csv",
+			content:     ewoqEVMAddress + ",600,2030-01-01\n",
+			expectedErr: ErrVestingUnsupported,
+		},
+		{
+			name:     "vesting unlocked",
+			file:     "airdrop.csv",
+			content:  ewoqEVMAddress + ",600,2030-01-01\n",
+			opts:     []AllocationOption{WithUnlockedVesting()},
+			expected: map[string]int64{ewoqEVMAddress: 600},
+		},
+		{
+			name:        "invalid vesting",
+			file:        "airdrop.csv",
+			content:     ewoqEVMAddress + ",600,next year\n",
+			expectedErr: ErrInvalidVesting,
+		},
+		{
+			name:        "empty",
+			file:        "airdrop.csv",
+			content:     "address,amount\n",
+			expectedErr: ErrEmptyAllocation,
+		},
+		{
+			name:        "unknown format",
+			file:        "airdrop.txt",
+			content:     ewoqEVMAddress + ",1\n",
+			expectedErr: ErrUnknownAllocationFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocation, err := AllocationFromFile(writeAirdropFile(t, tt.file, tt.content), tt.opts...)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, allocation, len(tt.expected))
+			for addr, balance := range tt.expected {
+				account, found := allocation[common.HexToAddress(addr)]
+				require.True(t, found, addr)
+				assert.Equal(t, big.NewInt(balance), account.Balance)
+			}
+		})
+	}
+}
+
+func TestParseAirdropFile_Sources(t *testing.T) {
+	path := writeAirdropFile(t, "airdrop.csv", "address,amount,vesting\n# team\n"+ewoqEVMAddress+",1,1893456000\n"+otherEVMAddress+",bad\n")
+	_, err := ParseAirdropFile(path)
+	require.ErrorIs(t, err, ErrInvalidAllocationAmount)
+	assert.Contains(t, err.Error(), "airdrop.csv:4")
+
+	path = writeAirdropFile(t, "airdrop.csv", "address,amount,vesting\n"+ewoqEVMAddress+",1,1893456000\n")
+	entries, err := ParseAirdropFile(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "airdrop.csv:2", entries[0].Source)
+	assert.Equal(t, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), entries[0].VestingEnd)
+}
@@ -0,0 +1,13 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import "github.com/DioneProtocol/odyssey-tooling-sdk-go/vm"
+
+// CompatibleVMVersions returns the Subnet-EVM releases an odysseygo release, e.g. v1.10.9,
+// can run, newest first. Nodes running any other Subnet-EVM release fail to start the chain.
+// See vm.CheckSubnetEVMCompatibility
+func CompatibleVMVersions(odysseyGoVersion string) ([]string, error) {
+	return vm.CompatibleSubnetEVMVersions(odysseyGoVersion)
+}
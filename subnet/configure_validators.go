@@ -0,0 +1,67 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"context"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node"
+	"github.com/DioneProtocol/odysseygo/ids"
+)
+
+// ChainConfig returns a config of blockchainID aliasing it and the subnet VM with the subnet
+// name, so that its RPC URLs can use the name. Set its files, e.g. the VM config with
+// WithVMConfig, and push it to the validators with ConfigureValidators once the blockchain
+// is created
+func (c *Subnet) ChainConfig(blockchainID ids.ID) node.ChainConfig {
+	return node.ChainConfig{
+		BlockchainID: blockchainID,
+		Alias:        c.Name,
+		VMID:         c.VMID,
+	}
+}
+
+// ConfigureValidators makes the nodes track subnetID and uploads the config files of its
+// blockchains to them, setting their aliases, restarting odysseygo on the nodes whose configuration changed.
+// Nodes are configured concurrently; nodes not started when ctx is done fail with ctx.Err().
+// The value of each node result is true if odysseygo was restarted on the node
+func ConfigureValidators(
+	ctx context.Context,
+	nodes []*node.Node,
+	subnetID ids.ID,
+	chainConfigs []node.ChainConfig,
+) (*node.NodeResults, error) {
+	if subnetID == ids.Empty {
+		return nil, ErrEmptySubnetID
+	}
+	for _, chainConfig := range chainConfigs {
+		if err := chainConfig.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return node.RunOnNodes(nodes, 0, func(n *node.Node) (interface{}, error) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		return n.ConfigureSubnet(subnetID, chainConfigs)
+	}), nil
+}
+
+// OnboardingPackage returns the onboarding package of the subnet for the operators of the
+// nodes joining its validators, with the config of blockchainID holding the subnet genesis.
+// Set its subnet and node configs, sign it with node.OnboardingPackage.Sign and save it for
+// the operators, who run node.Node.Onboard with it
+func (c *Subnet) OnboardingPackage(blockchainID ids.ID, odysseyGoVersion string) (node.OnboardingPackage, error) {
+	if c.SubnetID == ids.Empty {
+		return node.OnboardingPackage{}, ErrEmptySubnetID
+	}
+	chainConfig := c.ChainConfig(blockchainID)
+	chainConfig.Genesis = c.Genesis
+	pkg := node.OnboardingPackage{
+		SubnetID:         c.SubnetID,
+		OdysseyGoVersion: odysseyGoVersion,
+		Chains:           []node.ChainConfig{chainConfig},
+	}
+	return pkg, pkg.Validate()
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node"
+	"github.com/DioneProtocol/odysseygo/ids"
+)
+
+func TestConfigureValidators_Validation(t *testing.T) {
+	nodes := []*node.Node{{NodeID: "node-1", Roles: []node.SupportedRole{node.Validator}}}
+
+	_, err := ConfigureValidators(context.Background(), nodes, ids.Empty, nil)
+	assert.ErrorIs(t, err, ErrEmptySubnetID)
+
+	_, err = ConfigureValidators(context.Background(), nodes, ids.ID{1}, []node.ChainConfig{{Config: []byte(`{}`)}})
+	assert.ErrorIs(t, err, node.ErrEmptyBlockchainID)
+}
+
+func TestConfigureValidators_PerNodeResults(t *testing.T) {
+	nodes := []*node.Node{
+		{NodeID: "monitoring-1", Roles: []node.SupportedRole{node.Monitor}},
+		{NodeID: "monitoring-2", Roles: []node.SupportedRole{node.Monitor}},
+	}
+	results, err := ConfigureValidators(context.Background(), nodes, ids.ID{1}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, results.Len())
+	errs := results.GetErrorHostMap()
+	require.Len(t, errs, 2)
+	assert.ErrorContains(t, errs["monitoring-1"], "is not a odysseygo node")
+	assert.ErrorContains(t, errs["monitoring-2"], "is not a odysseygo node")
+}
+
+func TestConfigureValidators_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	nodes := []*node.Node{{NodeID: "node-1", IP: "127.0.0.1", Roles: []node.SupportedRole{node.Validator}}}
+	results, err := ConfigureValidators(ctx, nodes, ids.ID{1}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, results.Len())
+	assert.ErrorIs(t, results.GetErrorHostMap()["node-1"], context.Canceled)
+	assert.Equal(t, false, results.GetResultMap()["node-1"])
+}
+
+func TestSubnet_ChainConfig(t *testing.T) {
+	s := &Subnet{Name: "mychain", VMID: ids.ID{2}}
+	chainConfig := s.ChainConfig(ids.ID{1})
+	assert.Equal(t, node.ChainConfig{BlockchainID: ids.ID{1}, Alias: "mychain", VMID: ids.ID{2}}, chainConfig)
+	assert.NoError(t, chainConfig.Validate())
+}
+
+func TestSubnet_OnboardingPackage(t *testing.T) {
+	s := &Subnet{Name: "mychain", VMID: ids.ID{2}, Genesis: []byte(`{"config":{}}`)}
+	_, err := s.OnboardingPackage(ids.ID{1}, "v1.10.13")
+	assert.ErrorIs(t, err, ErrEmptySubnetID)
+
+	s.SubnetID = ids.ID{3}
+	pkg, err := s.OnboardingPackage(ids.ID{1}, "v1.10.13")
+	require.NoError(t, err)
+	assert.Equal(t, node.OnboardingPackage{
+		SubnetID:         ids.ID{3},
+		OdysseyGoVersion: "v1.10.13",
+		Chains: []node.ChainConfig{
+			{BlockchainID: ids.ID{1}, Alias: "mychain", VMID: ids.ID{2}, Genesis: []byte(`{"config":{}}`)},
+		},
+	}, pkg)
+
+	_, err = s.OnboardingPackage(ids.ID{1}, "1.10")
+	assert.ErrorIs(t, err, node.ErrInvalidOnboardingPackage)
+}
@@ -8,21 +8,37 @@ import (
 	"fmt"
 
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/multisig"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/node"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/progress"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
 
 	"github.com/DioneProtocol/odysseygo/ids"
 	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
 	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary/common"
 )
 
+// memoOptions returns the options setting memo on a tx of the subnet, the memo of
+// DeployInfo if empty
+func (c *Subnet) memoOptions(memo []byte) ([]common.Option, error) {
+	if len(memo) == 0 {
+		memo = c.DeployInfo.Memo
+	}
+	return wallet.MemoOptions(memo)
+}
+
 // CreateSubnetTx creates uncommitted CreateSubnetTx
 // keychain in wallet will be used to build, sign and pay for the transaction
 func (c *Subnet) CreateSubnetTx(wallet wallet.Wallet) (*multisig.Multisig, error) {
 	if c.DeployInfo.ControlKeys == nil {
-		return nil, fmt.Errorf("control keys are not provided")
+		return nil, ErrEmptyControlKeys
 	}
 	if c.DeployInfo.Threshold == 0 {
-		return nil, fmt.Errorf("threshold is not provided")
+		return nil, ErrEmptyThreshold
+	}
+	memoOptions, err := c.memoOptions(nil)
+	if err != nil {
+		return nil, err
 	}
 	addrs := c.DeployInfo.ControlKeys
 	owners := &secp256k1fx.OutputOwners{
@@ -32,6 +48,7 @@ func (c *Subnet) CreateSubnetTx(wallet wallet.Wallet) (*multisig.Multisig, error
 	}
 	unsignedTx, err := wallet.O().Builder().NewCreateSubnetTx(
 		owners,
+		memoOptions...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error building tx: %w", err)
@@ -44,22 +61,27 @@ func (c *Subnet) CreateSubnetTx(wallet wallet.Wallet) (*multisig.Multisig, error
 }
 
 // CreateBlockchainTx creates uncommitted CreateChainTx
-// keychain in wallet will be used to build, sign and pay for the transaction
+// keychain in wallet will be used to build, sign and pay for the transaction. Set a fee payer
+// with wallet.SetFeePayer for a sponsor to pay it while the subnet auth keys only authorize it
 func (c *Subnet) CreateBlockchainTx(wallet wallet.Wallet) (*multisig.Multisig, error) {
 	if c.SubnetID == ids.Empty {
-		return nil, fmt.Errorf("subnet ID is not provided")
+		return nil, ErrEmptySubnetID
 	}
 	if c.DeployInfo.SubnetAuthKeys == nil {
-		return nil, fmt.Errorf("subnet authkeys are not provided")
+		return nil, ErrEmptySubnetAuthKeys
 	}
 	if c.Genesis == nil {
-		return nil, fmt.Errorf("threshold is not provided")
+		return nil, ErrEmptyGenesis
 	}
 	if c.VMID == ids.Empty {
-		return nil, fmt.Errorf("vm ID is not provided")
+		return nil, ErrEmptyVMID
 	}
 	if c.Name == "" {
-		return nil, fmt.Errorf("subnet name is not provided")
+		return nil, ErrEmptySubnetName
+	}
+	memoOptions, err := c.memoOptions(nil)
+	if err != nil {
+		return nil, err
 	}
 	wallet.SetSubnetAuthMultisig(c.DeployInfo.SubnetAuthKeys)
 
@@ -71,6 +93,7 @@ func (c *Subnet) CreateBlockchainTx(wallet wallet.Wallet) (*multisig.Multisig, e
 		c.VMID,
 		fxIDs,
 		c.Name,
+		memoOptions...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error building tx: %w", err)
@@ -81,3 +104,99 @@ func (c *Subnet) CreateBlockchainTx(wallet wallet.Wallet) (*multisig.Multisig, e
 	}
 	return multisig.New(&tx), nil
 }
+
+// DeployOp holds the options of Deploy
+type DeployOp struct {
+	monitoringNode *node.Node
+}
+
+// DeployOption configures Deploy
+type DeployOption func(*DeployOp)
+
+// WithMonitoringNode makes Deploy push the recording rules and dashboard of the deployed
+// blockchain to monitoringNode, see AddMonitoring
+func WithMonitoringNode(monitoringNode *node.Node) DeployOption {
+	return func(op *DeployOp) {
+		op.monitoringNode = monitoringNode
+	}
+}
+
+// AddMonitoring pushes the Prometheus recording rules and the Grafana dashboard of
+// blockchainID, titled with the subnet name, to monitoringNode
+func (c *Subnet) AddMonitoring(ctx context.Context, monitoringNode *node.Node, blockchainID ids.ID) error {
+	return monitoringNode.AddSubnetMonitoring(ctx, node.SubnetMonitoring{
+		SubnetID:     c.SubnetID,
+		BlockchainID: blockchainID,
+		Name:         c.Name,
+	})
+}
+
+// DeployResult describes a subnet deployed by DeployWithResult
+type DeployResult struct {
+	SubnetID     ids.ID
+	BlockchainID ids.ID
+
+	// GasToken is the metadata of the native token of the blockchain, if set
+	GasToken *GasToken
+
+	// GenesisContracts are the addresses of the contracts deployed in the genesis of the
+	// blockchain, e.g. its wrapped native token
+	GenesisContracts GenesisContracts
+}
+
+// Deploy creates the subnet and its blockchain, reporting the progress of building and
+// issuing each transaction to reporter. Keychain in wallet must hold enough control keys
+// to fully sign both transactions. Returns the ID of the created blockchain
+func (c *Subnet) Deploy(wallet wallet.Wallet, reporter progress.Reporter, opts ...DeployOption) (ids.ID, error) {
+	result, err := c.DeployWithResult(wallet, reporter, opts...)
+	return result.BlockchainID, err
+}
+
+// DeployWithResult is Deploy returning the IDs of the subnet and its blockchain, with the
+// metadata of its native token and the addresses of its genesis contracts
+func (c *Subnet) DeployWithResult(wallet wallet.Wallet, reporter progress.Reporter, opts ...DeployOption) (DeployResult, error) {
+	result := DeployResult{
+		GasToken:         c.GasToken,
+		GenesisContracts: c.GenesisContracts,
+	}
+	op := &DeployOp{}
+	for _, opt := range opts {
+		opt(op)
+	}
+	steps := 4
+	if op.monitoringNode != nil {
+		steps++
+	}
+	tracker := progress.NewTracker(reporter, "", steps)
+
+	tracker.Step(progress.StageBuildTx, "building CreateSubnetTx")
+	subnetTx, err := c.CreateSubnetTx(wallet)
+	if err != nil {
+		return result, err
+	}
+	tracker.Step(progress.StageIssueTx, "issuing CreateSubnetTx")
+	if _, err := c.Commit(*subnetTx, wallet, true); err != nil {
+		return result, err
+	}
+
+	tracker.Step(progress.StageBuildTx, "building CreateChainTx")
+	chainTx, err := c.CreateBlockchainTx(wallet)
+	if err != nil {
+		return result, err
+	}
+	tracker.Step(progress.StageIssueTx, "issuing CreateChainTx")
+	blockchainID, err := c.Commit(*chainTx, wallet, true)
+	if err != nil {
+		return result, err
+	}
+	result.SubnetID = c.SubnetID
+	result.BlockchainID = blockchainID
+	if op.monitoringNode != nil {
+		tracker.Step(progress.StageProvision, fmt.Sprintf("adding the dashboard of blockchain %s to monitoring node %s", blockchainID, op.monitoringNode.NodeID))
+		if err := c.AddMonitoring(context.Background(), op.monitoringNode, blockchainID); err != nil {
+			return result, fmt.Errorf("blockchain %s deployed, but failed to add its monitoring: %w", blockchainID, err)
+		}
+	}
+	tracker.Done(fmt.Sprintf("blockchain %s deployed on subnet %s", blockchainID, c.SubnetID))
+	return result, nil
+}
@@ -0,0 +1,247 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/subnet-evm/core"
+)
+
+var (
+	ErrNotCreateSubnetTx      = errors.New("tx is not a CreateSubnetTx")
+	ErrNotCreateChainTx       = errors.New("tx is not a CreateChainTx")
+	ErrBlockchainNotFound     = errors.New("blockchain not found in subnet")
+	ErrBlockchainIDRequired   = errors.New("subnet has several blockchains, blockchain ID is required")
+	ErrUnsupportedSubnetOwner = errors.New("unsupported subnet owner")
+)
+
+// newDescribeClient returns the O-Chain client queried by Describe. It is replaced in tests
+var newDescribeClient = func(network odyssey.Network) omegavm.Client {
+	return odyssey.SharedClientFactory().OChain(network.Endpoint)
+}
+
+// Description is a subnet deployed on a network, as recorded on the O-Chain
+type Description struct {
+	SubnetID ids.ID
+
+	// CreationControlKeys and CreationThreshold are the owners set by the CreateSubnetTx,
+	// which may have been transferred since
+	CreationControlKeys []ids.ShortID
+	CreationThreshold   uint32
+
+	// CreationMemo is the memo of the CreateSubnetTx
+	CreationMemo []byte
+
+	// ControlKeys and Threshold are the current owners of the subnet
+	ControlKeys []ids.ShortID
+	Threshold   uint32
+
+	// Blockchains are the blockchains created in the subnet
+	Blockchains []BlockchainDescription
+
+	// Validators are the current validators of the subnet
+	Validators []ValidatorDescription
+}
+
+// BlockchainDescription is a blockchain of a subnet, as created by its CreateChainTx
+type BlockchainDescription struct {
+	BlockchainID ids.ID
+	Name         string
+	VMID         ids.ID
+
+	// VMName is the name VMID was derived from by New, if it is such a name
+	VMName string
+
+	FxIDs   []ids.ID
+	Genesis []byte
+	Memo    []byte
+
+	// SubnetEVM is recovered from Genesis if the blockchain runs Subnet-EVM. Vesting is part of
+	// its Allocation
+	SubnetEVM *SubnetEVMParams
+}
+
+// ValidatorDescription is a current validator of a subnet
+type ValidatorDescription struct {
+	NodeID    ids.NodeID
+	Weight    uint64
+	StartTime time.Time
+	EndTime   time.Time
+
+	// TxID is the tx that added the validator
+	TxID ids.ID
+}
+
+// Describe looks up on the O-Chain of network the CreateSubnetTx of subnetID, the CreateChainTx
+// of its blockchains, its current owners and its current validators, so that a subnet deployed
+// elsewhere can be managed with the SDK, see Description.Subnet
+func Describe(network odyssey.Network, subnetID ids.ID) (*Description, error) {
+	client := newDescribeClient(network)
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+
+	createSubnetTx, err := getUnsignedTx(ctx, client, subnetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the CreateSubnetTx of subnet %s: %w", subnetID, err)
+	}
+	createSubnet, ok := createSubnetTx.(*txs.CreateSubnetTx)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotCreateSubnetTx, subnetID)
+	}
+	owner, ok := createSubnet.Owner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil, fmt.Errorf("%w %T of subnet %s", ErrUnsupportedSubnetOwner, createSubnet.Owner, subnetID)
+	}
+	description := &Description{
+		SubnetID:            subnetID,
+		CreationControlKeys: owner.Addrs,
+		CreationThreshold:   owner.Threshold,
+		CreationMemo:        createSubnet.Memo,
+	}
+
+	subnets, err := client.GetSubnets(ctx, []ids.ID{subnetID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the owners of subnet %s: %w", subnetID, err)
+	}
+	if len(subnets) == 0 {
+		return nil, fmt.Errorf("subnet %s not found", subnetID)
+	}
+	description.ControlKeys = subnets[0].ControlKeys
+	description.Threshold = subnets[0].Threshold
+
+	blockchains, err := client.GetBlockchains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the blockchains of subnet %s: %w", subnetID, err)
+	}
+	for _, blockchain := range blockchains {
+		if blockchain.SubnetID != subnetID {
+			continue
+		}
+		blockchainDescription, err := describeBlockchain(ctx, client, blockchain.ID)
+		if err != nil {
+			return nil, err
+		}
+		description.Blockchains = append(description.Blockchains, blockchainDescription)
+	}
+
+	validators, err := client.GetCurrentValidators(ctx, subnetID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the validators of subnet %s: %w", subnetID, err)
+	}
+	for _, validator := range validators {
+		description.Validators = append(description.Validators, ValidatorDescription{
+			NodeID:    validator.NodeID,
+			Weight:    validator.Weight,
+			StartTime: time.Unix(int64(validator.StartTime), 0),
+			EndTime:   time.Unix(int64(validator.EndTime), 0),
+			TxID:      validator.TxID,
+		})
+	}
+	return description, nil
+}
+
+func describeBlockchain(ctx context.Context, client omegavm.Client, blockchainID ids.ID) (BlockchainDescription, error) {
+	unsignedTx, err := getUnsignedTx(ctx, client, blockchainID)
+	if err != nil {
+		return BlockchainDescription{}, fmt.Errorf("failed to get the CreateChainTx of blockchain %s: %w", blockchainID, err)
+	}
+	createChain, ok := unsignedTx.(*txs.CreateChainTx)
+	if !ok {
+		return BlockchainDescription{}, fmt.Errorf("%w: %s", ErrNotCreateChainTx, blockchainID)
+	}
+	return BlockchainDescription{
+		BlockchainID: blockchainID,
+		Name:         createChain.ChainName,
+		VMID:         createChain.VMID,
+		VMName:       vmName(createChain.VMID),
+		FxIDs:        createChain.FxIDs,
+		Genesis:      createChain.GenesisData,
+		Memo:         createChain.Memo,
+		SubnetEVM:    subnetEVMParams(createChain.GenesisData),
+	}, nil
+}
+
+func getUnsignedTx(ctx context.Context, client omegavm.Client, txID ids.ID) (txs.UnsignedTx, error) {
+	txBytes, err := client.GetTx(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	return tx.Unsigned, nil
+}
+
+// vmName returns the name vmID was derived from, or an empty string if vmID is not
+// a printable name padded with zeros
+func vmName(vmID ids.ID) string {
+	name := string(bytes.TrimRight(vmID[:], "\x00"))
+	if name == "" {
+		return ""
+	}
+	for _, r := range name {
+		if r < ' ' || r > '~' {
+			return ""
+		}
+	}
+	return name
+}
+
+// subnetEVMParams returns the Subnet-EVM params of genesis, or nil if it is not a Subnet-EVM
+// genesis
+func subnetEVMParams(genesis []byte) *SubnetEVMParams {
+	evmGenesis := core.Genesis{}
+	if err := evmGenesis.UnmarshalJSON(genesis); err != nil || evmGenesis.Config == nil || evmGenesis.Config.ChainID == nil {
+		return nil
+	}
+	return &SubnetEVMParams{
+		ChainID:     evmGenesis.Config.ChainID,
+		FeeConfig:   evmGenesis.Config.FeeConfig,
+		Allocation:  evmGenesis.Alloc,
+		Precompiles: evmGenesis.Config.GenesisPrecompiles,
+	}
+}
+
+// Subnet returns the Subnet of blockchainID to manage it with the SDK, e.g. to add validators
+// after setting its SubnetAuthKeys. blockchainID can be empty if the subnet has at most one
+// blockchain
+func (d *Description) Subnet(blockchainID ids.ID) (*Subnet, error) {
+	subnet := &Subnet{SubnetID: d.SubnetID}
+	subnet.SetSubnetControlParams(d.ControlKeys, d.Threshold)
+	if blockchainID == ids.Empty {
+		switch len(d.Blockchains) {
+		case 0:
+			return subnet, nil
+		case 1:
+			blockchainID = d.Blockchains[0].BlockchainID
+		default:
+			return nil, fmt.Errorf("%w: %d blockchains in subnet %s", ErrBlockchainIDRequired, len(d.Blockchains), d.SubnetID)
+		}
+	}
+	for _, blockchain := range d.Blockchains {
+		if blockchain.BlockchainID != blockchainID {
+			continue
+		}
+		subnet.Name = blockchain.VMName
+		if subnet.Name == "" {
+			subnet.Name = blockchain.Name
+		}
+		subnet.VMID = blockchain.VMID
+		subnet.Genesis = blockchain.Genesis
+		return subnet, nil
+	}
+	return nil, fmt.Errorf("%w %s: %s", ErrBlockchainNotFound, d.SubnetID, blockchainID)
+}
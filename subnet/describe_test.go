@@ -0,0 +1,189 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/rpc"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/subnet-evm/commontype"
+	"github.com/DioneProtocol/subnet-evm/core"
+	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// describeOChain serves the O-Chain state queried by Describe
+type describeOChain struct {
+	omegavm.Client
+	txs         map[ids.ID][]byte
+	subnets     []omegavm.ClientSubnet
+	blockchains []omegavm.APIBlockchain
+	validators  []omegavm.ClientPermissionlessValidator
+}
+
+func (c *describeOChain) GetTx(_ context.Context, txID ids.ID, _ ...rpc.Option) ([]byte, error) {
+	txBytes, ok := c.txs[txID]
+	if !ok {
+		return nil, ErrBlockchainNotFound
+	}
+	return txBytes, nil
+}
+
+func (c *describeOChain) GetSubnets(context.Context, []ids.ID, ...rpc.Option) ([]omegavm.ClientSubnet, error) {
+	return c.subnets, nil
+}
+
+func (c *describeOChain) GetBlockchains(context.Context, ...rpc.Option) ([]omegavm.APIBlockchain, error) {
+	return c.blockchains, nil
+}
+
+func (c *describeOChain) GetCurrentValidators(context.Context, ids.ID, []ids.NodeID, ...rpc.Option) ([]omegavm.ClientPermissionlessValidator, error) {
+	return c.validators, nil
+}
+
+func testTxBytes(t *testing.T, unsignedTx txs.UnsignedTx) []byte {
+	tx := &txs.Tx{Unsigned: unsignedTx}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return tx.Bytes()
+}
+
+func TestDescribe(t *testing.T) {
+	subnetID, blockchainID, otherBlockchainID := ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()
+	creationKey, controlKey := ids.GenerateTestShortID(), ids.GenerateTestShortID()
+	nodeID := ids.GenerateTestNodeID()
+	evmVMID, err := vmID("subnetevm")
+	require.NoError(t, err)
+	evmGenesis, err := createEvmGenesis(&SubnetEVMParams{
+		ChainID:     big.NewInt(123456),
+		FeeConfig:   commontype.FeeConfig{GasLimit: big.NewInt(8000000), MinBaseFee: big.NewInt(25000000000), TargetGas: big.NewInt(15000000), BaseFeeChangeDenominator: big.NewInt(36), MinBlockGasCost: big.NewInt(0), MaxBlockGasCost: big.NewInt(1000000), TargetBlockRate: 2, BlockGasCostStep: big.NewInt(200000)},
+		Allocation:  core.GenesisAlloc{common.HexToAddress("0x01"): {Balance: big.NewInt(1000)}},
+		Precompiles: params.Precompiles{},
+	})
+	require.NoError(t, err)
+
+	oChain := &describeOChain{
+		txs: map[ids.ID][]byte{
+			subnetID: testTxBytes(t, &txs.CreateSubnetTx{
+				BaseTx: txs.BaseTx{BaseTx: dione.BaseTx{Memo: []byte("created elsewhere")}},
+				Owner:  &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{creationKey}},
+			}),
+			blockchainID: testTxBytes(t, &txs.CreateChainTx{
+				SubnetID:    subnetID,
+				ChainName:   "mychain",
+				VMID:        evmVMID,
+				GenesisData: evmGenesis,
+				SubnetAuth:  &secp256k1fx.Input{},
+			}),
+		},
+		subnets: []omegavm.ClientSubnet{{ID: subnetID, ControlKeys: []ids.ShortID{controlKey}, Threshold: 1}},
+		blockchains: []omegavm.APIBlockchain{
+			{ID: blockchainID, SubnetID: subnetID},
+			{ID: otherBlockchainID, SubnetID: ids.GenerateTestID()},
+		},
+		validators: []omegavm.ClientPermissionlessValidator{
+			{ClientStaker: omegavm.ClientStaker{NodeID: nodeID, Weight: 20, StartTime: 1_700_000_000, EndTime: 1_800_000_000}},
+		},
+	}
+	original := newDescribeClient
+	t.Cleanup(func() { newDescribeClient = original })
+	newDescribeClient = func(odyssey.Network) omegavm.Client { return oChain }
+
+	description, err := Describe(odyssey.TestnetNetwork(), subnetID)
+	require.NoError(t, err)
+	require.Equal(t, []ids.ShortID{creationKey}, description.CreationControlKeys)
+	require.Equal(t, []byte("created elsewhere"), description.CreationMemo)
+	require.Equal(t, []ids.ShortID{controlKey}, description.ControlKeys)
+	require.Equal(t, uint32(1), description.Threshold)
+	require.Equal(t, []ValidatorDescription{{
+		NodeID:    nodeID,
+		Weight:    20,
+		StartTime: time.Unix(1_700_000_000, 0),
+		EndTime:   time.Unix(1_800_000_000, 0),
+	}}, description.Validators)
+
+	require.Len(t, description.Blockchains, 1)
+	blockchain := description.Blockchains[0]
+	require.Equal(t, "mychain", blockchain.Name)
+	require.Equal(t, "subnetevm", blockchain.VMName)
+	require.NotNil(t, blockchain.SubnetEVM)
+	require.Equal(t, big.NewInt(123456), blockchain.SubnetEVM.ChainID)
+	require.Equal(t, big.NewInt(1000), blockchain.SubnetEVM.Allocation[common.HexToAddress("0x01")].Balance)
+
+	subnet, err := description.Subnet(ids.Empty)
+	require.NoError(t, err)
+	require.Equal(t, "subnetevm", subnet.Name)
+	require.Equal(t, subnetID, subnet.SubnetID)
+	require.Equal(t, evmVMID, subnet.VMID)
+	require.Equal(t, evmGenesis, subnet.Genesis)
+	require.Equal(t, []ids.ShortID{controlKey}, subnet.DeployInfo.ControlKeys)
+
+	_, err = description.Subnet(otherBlockchainID)
+	require.ErrorIs(t, err, ErrBlockchainNotFound)
+
+	// the subnet ID must be the one of a CreateSubnetTx
+	_, err = Describe(odyssey.TestnetNetwork(), blockchainID)
+	require.ErrorIs(t, err, ErrNotCreateSubnetTx)
+}
+
+func TestDescription_Subnet(t *testing.T) {
+	tests := []struct {
+		name         string
+		blockchains  []BlockchainDescription
+		blockchainID ids.ID
+		expectedName string
+		expectedErr  error
+	}{
+		{
+			name: "no blockchain",
+		},
+		{
+			name:         "vm name",
+			blockchains:  []BlockchainDescription{{BlockchainID: ids.ID{1}, Name: "chain", VMName: "vm"}},
+			expectedName: "vm",
+		},
+		{
+			name:         "chain name if the VM ID is not a name",
+			blockchains:  []BlockchainDescription{{BlockchainID: ids.ID{1}, Name: "chain"}},
+			blockchainID: ids.ID{1},
+			expectedName: "chain",
+		},
+		{
+			name:        "several blockchains",
+			blockchains: []BlockchainDescription{{BlockchainID: ids.ID{1}}, {BlockchainID: ids.ID{2}}},
+			expectedErr: ErrBlockchainIDRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			description := &Description{SubnetID: ids.ID{9}, Blockchains: tt.blockchains}
+			subnet, err := description.Subnet(tt.blockchainID)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedName, subnet.Name)
+			require.Equal(t, ids.ID{9}, subnet.SubnetID)
+		})
+	}
+}
+
+func TestVMName(t *testing.T) {
+	id, err := vmID("subnetevm")
+	require.NoError(t, err)
+	require.Equal(t, "subnetevm", vmName(id))
+	require.Empty(t, vmName(ids.Empty))
+	require.Empty(t, vmName(ids.ID{0xff, 'a'}))
+}
@@ -0,0 +1,236 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/DioneProtocol/subnet-evm/core"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// maxGasTokenDecimals is the largest number of decimals of the native token of a chain, whose
+// amounts are in wei in the EVM
+const maxGasTokenDecimals = 18
+
+const (
+	// wrappedTokenNameSlot, wrappedTokenSymbolSlot and wrappedTokenDecimalsSlot are the storage
+	// slots of the metadata of the WETH9 contract
+	wrappedTokenNameSlot     = 0
+	wrappedTokenSymbolSlot   = 1
+	wrappedTokenDecimalsSlot = 2
+)
+
+var (
+	//go:embed contracts/weth9.hex
+	weth9Hex string
+
+	// weth9Code is the runtime bytecode of WETH9, deployed when WrappedNativeTokenParams.Code
+	// is not set
+	weth9Code = common.FromHex(strings.TrimSpace(weth9Hex))
+)
+
+var (
+	// DefaultWrappedNativeTokenAddress is the genesis address of the wrapped native token when
+	// WrappedNativeTokenParams.Address is not set
+	DefaultWrappedNativeTokenAddress = common.HexToAddress("0x0300000000000000000000000000000000000001")
+
+	// DefaultMulticallAddress is the genesis address of the multicall contract when
+	// MulticallParams.Address is not set
+	DefaultMulticallAddress = common.HexToAddress("0x0300000000000000000000000000000000000002")
+
+	// Multicall3Address is the address of Multicall3 on most EVM chains, where tools look for it
+	// without configuration. Only deploy the published Multicall3 runtime bytecode there
+	Multicall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+)
+
+var (
+	ErrInvalidGasToken    = errors.New("invalid gas token")
+	ErrEmptyMulticallCode = errors.New("multicall contract code cannot be empty")
+)
+
+// GasToken is the metadata of the native token of a Subnet-EVM chain, used by wallets and
+// explorers, and by the wrapped native token of the chain
+type GasToken struct {
+	Name   string
+	Symbol string
+
+	// Decimals is the number of decimals the token amounts are displayed with, usually 18
+	Decimals uint8
+}
+
+// Validate checks that the token has a name and a symbol, and at most 18 decimals
+func (t GasToken) Validate() error {
+	switch {
+	case t.Name == "":
+		return fmt.Errorf("%w: name cannot be empty", ErrInvalidGasToken)
+	case t.Symbol == "":
+		return fmt.Errorf("%w: symbol cannot be empty", ErrInvalidGasToken)
+	case t.Decimals > maxGasTokenDecimals:
+		return fmt.Errorf("%w: %d decimals, at most %d are supported", ErrInvalidGasToken, t.Decimals, maxGasTokenDecimals)
+	}
+	return nil
+}
+
+// WrappedNativeTokenParams deploys a wrapped native token at block 0, named "Wrapped <name>"
+// with symbol "W<symbol>" after the gas token of the chain.
+//
+// The SDK deploys WETH9 unless Code is set, to the runtime bytecode of a contract with the
+// storage layout of WETH9, whose metadata is initialized in the genesis:
+//
+//	string name;     // slot 0
+//	string symbol;   // slot 1
+//	uint8 decimals;  // slot 2
+//	mapping(address => uint256) balanceOf;                     // slot 3
+//	mapping(address => mapping(address => uint256)) allowance; // slot 4
+type WrappedNativeTokenParams struct {
+	// Address is the genesis address of the token. DefaultWrappedNativeTokenAddress is used
+	// when zero
+	Address common.Address
+
+	// Code is the runtime bytecode of the token. WETH9 is deployed when empty
+	Code []byte
+}
+
+// code returns the runtime bytecode of the token
+func (p *WrappedNativeTokenParams) code() []byte {
+	if len(p.Code) == 0 {
+		return weth9Code
+	}
+	return p.Code
+}
+
+// GenesisAddress returns the genesis address of the token
+func (p *WrappedNativeTokenParams) GenesisAddress() common.Address {
+	if p.Address == (common.Address{}) {
+		return DefaultWrappedNativeTokenAddress
+	}
+	return p.Address
+}
+
+// GenesisAccount returns the genesis account of the wrapped native token of gasToken
+func (p *WrappedNativeTokenParams) GenesisAccount(gasToken GasToken) (core.GenesisAccount, error) {
+	if err := gasToken.Validate(); err != nil {
+		return core.GenesisAccount{}, err
+	}
+	storage := map[common.Hash]common.Hash{}
+	setStorageString(storage, wrappedTokenNameSlot, "Wrapped "+gasToken.Name)
+	setStorageString(storage, wrappedTokenSymbolSlot, "W"+gasToken.Symbol)
+	storage[common.BigToHash(big.NewInt(wrappedTokenDecimalsSlot))] = common.BigToHash(big.NewInt(int64(gasToken.Decimals)))
+	return core.GenesisAccount{
+		Code:    p.code(),
+		Storage: storage,
+		Balance: new(big.Int),
+	}, nil
+}
+
+// setStorageString stores value at slot as solidity does for a string state variable: in the
+// slot with twice its length if shorter than 32 bytes, or else from the keccak of the slot,
+// the slot holding twice its length plus one
+func setStorageString(storage map[common.Hash]common.Hash, slot int64, value string) {
+	slotHash := common.BigToHash(big.NewInt(slot))
+	data := []byte(value)
+	if len(data) < common.HashLength {
+		word := common.Hash{}
+		copy(word[:], data)
+		word[common.HashLength-1] = byte(2 * len(data))
+		storage[slotHash] = word
+		return
+	}
+	storage[slotHash] = common.BigToHash(big.NewInt(int64(2*len(data) + 1)))
+	dataSlot := crypto.Keccak256Hash(slotHash.Bytes()).Big()
+	for i := 0; i < len(data); i += common.HashLength {
+		word := common.Hash{}
+		copy(word[:], data[i:])
+		storage[common.BigToHash(dataSlot)] = word
+		dataSlot = new(big.Int).Add(dataSlot, big.NewInt(1))
+	}
+}
+
+// MulticallParams deploys a multicall contract at block 0, batching read calls into a single
+// RPC request. The SDK ships no multicall bytecode: Code is the runtime bytecode of any
+// stateless multicall contract, e.g. the published Multicall3 one, deployed at
+// Multicall3Address
+type MulticallParams struct {
+	// Address is the genesis address of the contract. DefaultMulticallAddress is used when zero
+	Address common.Address
+
+	// Code is the runtime bytecode of the contract, required
+	Code []byte
+}
+
+// GenesisAddress returns the genesis address of the contract
+func (p *MulticallParams) GenesisAddress() common.Address {
+	if p.Address == (common.Address{}) {
+		return DefaultMulticallAddress
+	}
+	return p.Address
+}
+
+// GenesisContracts are the addresses of the contracts deployed in the genesis of a Subnet-EVM
+// chain, zero for the contracts not deployed
+type GenesisContracts struct {
+	Vesting            common.Address
+	WrappedNativeToken common.Address
+	Multicall          common.Address
+}
+
+// genesisContracts returns the addresses of the contracts deployed by params
+func genesisContracts(params *SubnetEVMParams) GenesisContracts {
+	contracts := GenesisContracts{}
+	if params.Vesting != nil {
+		contracts.Vesting = params.Vesting.Address()
+	}
+	if params.WrappedNativeToken != nil {
+		contracts.WrappedNativeToken = params.WrappedNativeToken.GenesisAddress()
+	}
+	if params.Multicall != nil {
+		contracts.Multicall = params.Multicall.GenesisAddress()
+	}
+	return contracts
+}
+
+// withGenesisContracts returns allocation with the wrapped native token and multicall accounts
+// of params added
+func withGenesisContracts(allocation core.GenesisAlloc, params *SubnetEVMParams) (core.GenesisAlloc, error) {
+	accounts := map[common.Address]core.GenesisAccount{}
+	if params.WrappedNativeToken != nil {
+		if params.GasToken == nil {
+			return nil, fmt.Errorf("%w: the wrapped native token requires the gas token metadata", ErrInvalidGasToken)
+		}
+		account, err := params.WrappedNativeToken.GenesisAccount(*params.GasToken)
+		if err != nil {
+			return nil, err
+		}
+		accounts[params.WrappedNativeToken.GenesisAddress()] = account
+	}
+	if params.Multicall != nil {
+		if len(params.Multicall.Code) == 0 {
+			return nil, ErrEmptyMulticallCode
+		}
+		if _, found := accounts[params.Multicall.GenesisAddress()]; found {
+			return nil, fmt.Errorf("%w: multicall address %s", ErrDuplicateAllocation, params.Multicall.GenesisAddress())
+		}
+		accounts[params.Multicall.GenesisAddress()] = core.GenesisAccount{
+			Code:    params.Multicall.Code,
+			Balance: new(big.Int),
+		}
+	}
+	withContracts := core.GenesisAlloc{}
+	for addr, allocated := range allocation {
+		withContracts[addr] = allocated
+	}
+	for addr, account := range accounts {
+		if _, found := withContracts[addr]; found {
+			return nil, fmt.Errorf("%w: genesis contract address %s", ErrDuplicateAllocation, addr)
+		}
+		withContracts[addr] = account
+	}
+	return withContracts, nil
+}
@@ -0,0 +1,224 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/vm"
+	"github.com/DioneProtocol/subnet-evm/accounts/abi"
+	"github.com/DioneProtocol/subnet-evm/core"
+	"github.com/DioneProtocol/subnet-evm/core/rawdb"
+	"github.com/DioneProtocol/subnet-evm/core/state"
+	"github.com/DioneProtocol/subnet-evm/core/vm/runtime"
+	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasToken_Validate(t *testing.T) {
+	require.NoError(t, GasToken{Name: "Dione", Symbol: "DIONE", Decimals: 18}.Validate())
+	require.ErrorIs(t, GasToken{Symbol: "DIONE"}.Validate(), ErrInvalidGasToken)
+	require.ErrorIs(t, GasToken{Name: "Dione"}.Validate(), ErrInvalidGasToken)
+	require.ErrorIs(t, GasToken{Name: "Dione", Symbol: "DIONE", Decimals: 19}.Validate(), ErrInvalidGasToken)
+}
+
+func TestSetStorageString(t *testing.T) {
+	storage := map[common.Hash]common.Hash{}
+	setStorageString(storage, 0, "WDIONE")
+	short := common.Hash{}
+	copy(short[:], "WDIONE")
+	short[31] = 12
+	assert.Equal(t, short, storage[common.Hash{}])
+
+	// strings of 32 bytes or more are stored from the keccak of their slot
+	long := strings.Repeat("a", 40)
+	setStorageString(storage, 1, long)
+	slot := common.BigToHash(big.NewInt(1))
+	assert.Equal(t, common.BigToHash(big.NewInt(81)), storage[slot])
+	dataSlot := crypto.Keccak256Hash(slot.Bytes()).Big()
+	assert.Equal(t, common.BytesToHash([]byte(long[:32])), storage[common.BigToHash(dataSlot)])
+	second := common.Hash{}
+	copy(second[:], long[32:])
+	assert.Equal(t, second, storage[common.BigToHash(new(big.Int).Add(dataSlot, big.NewInt(1)))])
+	assert.Len(t, storage, 4)
+}
+
+func TestWithGenesisContracts(t *testing.T) {
+	gasToken := &GasToken{Name: "Dione", Symbol: "DIONE", Decimals: 18}
+	tests := []struct {
+		name        string
+		params      SubnetEVMParams
+		allocation  core.GenesisAlloc
+		expectedErr error
+	}{
+		{
+			name:        "wrapped token without gas token",
+			params:      SubnetEVMParams{WrappedNativeToken: &WrappedNativeTokenParams{Code: []byte{0x00}}},
+			expectedErr: ErrInvalidGasToken,
+		},
+		{
+			name:   "default code",
+			params: SubnetEVMParams{GasToken: gasToken, WrappedNativeToken: &WrappedNativeTokenParams{}, Multicall: &MulticallParams{Code: []byte{0x00}}},
+		},
+		{
+			name:        "multicall without code",
+			params:      SubnetEVMParams{Multicall: &MulticallParams{Address: Multicall3Address}},
+			expectedErr: ErrEmptyMulticallCode,
+		},
+		{
+			name: "same addresses",
+			params: SubnetEVMParams{
+				GasToken:           gasToken,
+				WrappedNativeToken: &WrappedNativeTokenParams{Code: []byte{0x00}},
+				Multicall:          &MulticallParams{Address: DefaultWrappedNativeTokenAddress, Code: []byte{0x00}},
+			},
+			expectedErr: ErrDuplicateAllocation,
+		},
+		{
+			name:        "allocated address",
+			params:      SubnetEVMParams{Multicall: &MulticallParams{Code: []byte{0x00}}},
+			allocation:  core.GenesisAlloc{DefaultMulticallAddress: core.GenesisAccount{Balance: big.NewInt(1)}},
+			expectedErr: ErrDuplicateAllocation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := withGenesisContracts(tt.allocation, &tt.params)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestNew_GenesisContracts(t *testing.T) {
+	multicallAddress := common.HexToAddress("0x0300000000000000000000000000000000000002")
+	subnetParams := &SubnetParams{
+		SubnetEVM: &SubnetEVMParams{
+			ChainID:            big.NewInt(123456),
+			FeeConfig:          vm.StarterFeeConfig,
+			Allocation:         core.GenesisAlloc{common.HexToAddress(ewoqEVMAddress): core.GenesisAccount{Balance: big.NewInt(1)}},
+			Precompiles:        params.Precompiles{},
+			GasToken:           &GasToken{Name: "Dione", Symbol: "DIONE", Decimals: 18},
+			WrappedNativeToken: &WrappedNativeTokenParams{Code: []byte{0x60, 0x00}},
+			Multicall:          &MulticallParams{Address: multicallAddress, Code: []byte{0x60, 0x01}},
+		},
+		Name: "TestSubnet",
+	}
+	subnet, err := New(subnetParams)
+	require.NoError(t, err)
+	assert.Equal(t, subnetParams.SubnetEVM.GasToken, subnet.GasToken)
+	assert.Equal(t, GenesisContracts{
+		WrappedNativeToken: DefaultWrappedNativeTokenAddress,
+		Multicall:          multicallAddress,
+	}, subnet.GenesisContracts)
+
+	genesis := core.Genesis{}
+	require.NoError(t, json.Unmarshal(subnet.Genesis, &genesis))
+	require.Len(t, genesis.Alloc, 3)
+	wrapped := genesis.Alloc[DefaultWrappedNativeTokenAddress]
+	assert.Equal(t, []byte{0x60, 0x00}, wrapped.Code)
+	name := common.Hash{}
+	copy(name[:], "Wrapped Dione")
+	name[31] = 26
+	assert.Equal(t, name, wrapped.Storage[common.Hash{}])
+	assert.Equal(t, common.BigToHash(big.NewInt(18)), wrapped.Storage[common.BigToHash(big.NewInt(2))])
+	assert.Equal(t, []byte{0x60, 0x01}, genesis.Alloc[multicallAddress].Code)
+
+	subnetParams.SubnetEVM.GasToken.Decimals = 24
+	_, err = New(subnetParams)
+	require.ErrorIs(t, err, ErrInvalidGasToken)
+}
+
+// wethABI is the ABI of the WETH9 functions called by the tests
+const wethABI = `[
+	{"name":"name","type":"function","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"name":"symbol","type":"function","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"name":"decimals","type":"function","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+	{"name":"balanceOf","type":"function","inputs":[{"name":"","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"name":"deposit","type":"function","inputs":[],"outputs":[]},
+	{"name":"withdraw","type":"function","inputs":[{"name":"wad","type":"uint256"}],"outputs":[]}
+]`
+
+// genesisContractsEVM runs calls against the genesis allocation of a Subnet-EVM chain
+type genesisContractsEVM struct {
+	t      *testing.T
+	config *runtime.Config
+}
+
+func newGenesisContractsEVM(t *testing.T, allocation core.GenesisAlloc, sender common.Address) *genesisContractsEVM {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	for addr, account := range allocation {
+		statedb.SetCode(addr, account.Code)
+		statedb.SetBalance(addr, account.Balance)
+		for key, value := range account.Storage {
+			statedb.SetState(addr, key, value)
+		}
+	}
+	return &genesisContractsEVM{
+		t: t,
+		config: &runtime.Config{
+			ChainConfig: params.TestChainConfig,
+			State:       statedb,
+			Origin:      sender,
+			BlockNumber: big.NewInt(7),
+			GasLimit:    10_000_000,
+		},
+	}
+}
+
+// call calls method of contract with value, and returns the unpacked outputs, or the revert
+// data with an error
+func (e *genesisContractsEVM) call(contract common.Address, contractABI abi.ABI, value int64, method string, args ...any) ([]any, []byte, error) {
+	input, err := contractABI.Pack(method, args...)
+	require.NoError(e.t, err)
+	e.config.Value = big.NewInt(value)
+	output, _, err := runtime.Call(contract, input, e.config)
+	if err != nil {
+		return nil, output, err
+	}
+	outputs, err := contractABI.Unpack(method, output)
+	require.NoError(e.t, err)
+	return outputs, output, nil
+}
+
+func TestGenesisContracts_DefaultCode(t *testing.T) {
+	sender := common.HexToAddress("0x0100000000000000000000000000000000000010")
+	allocation, err := withGenesisContracts(
+		core.GenesisAlloc{sender: {Balance: big.NewInt(1_000_000)}},
+		&SubnetEVMParams{
+			GasToken:           &GasToken{Name: "Dione", Symbol: "DIONE", Decimals: 18},
+			WrappedNativeToken: &WrappedNativeTokenParams{},
+		},
+	)
+	require.NoError(t, err)
+	evm := newGenesisContractsEVM(t, allocation, sender)
+	weth, err := abi.JSON(strings.NewReader(wethABI))
+	require.NoError(t, err)
+	wrapped := DefaultWrappedNativeTokenAddress
+
+	outputs, _, err := evm.call(wrapped, weth, 0, "name")
+	require.NoError(t, err)
+	assert.Equal(t, "Wrapped Dione", outputs[0])
+	outputs, _, err = evm.call(wrapped, weth, 0, "symbol")
+	require.NoError(t, err)
+	assert.Equal(t, "WDIONE", outputs[0])
+	outputs, _, err = evm.call(wrapped, weth, 0, "decimals")
+	require.NoError(t, err)
+	assert.Equal(t, uint8(18), outputs[0])
+
+	_, _, err = evm.call(wrapped, weth, 1_000, "deposit")
+	require.NoError(t, err)
+	_, _, err = evm.call(wrapped, weth, 0, "withdraw", big.NewInt(400))
+	require.NoError(t, err)
+	outputs, _, err = evm.call(wrapped, weth, 0, "balanceOf", sender)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(600), outputs[0])
+	assert.Equal(t, big.NewInt(1_000_000-600), evm.config.State.GetBalance(sender))
+}
@@ -0,0 +1,344 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/DioneProtocol/subnet-evm/core"
+	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+
+	// registers the precompiles of Subnet-EVM, so that their configs are parsed from genesis
+	_ "github.com/DioneProtocol/subnet-evm/precompile/registry"
+)
+
+var ErrInvalidGenesis = errors.New("invalid Subnet-EVM genesis")
+
+// GenesisChangeKind tells how an allocation or a precompile config differs between two
+// genesis
+type GenesisChangeKind string
+
+const (
+	GenesisAdded   GenesisChangeKind = "added"
+	GenesisRemoved GenesisChangeKind = "removed"
+	GenesisChanged GenesisChangeKind = "changed"
+)
+
+// GenesisFieldChange is a value that differs between two genesis, at its dotted JSON path,
+// e.g. config.feeConfig.gasLimit. The values are decoded from JSON, with numbers as
+// json.Number. Old is nil if the value was added, and New if it was removed
+type GenesisFieldChange struct {
+	Path string
+	Old  any
+	New  any
+}
+
+// AllocationChange is an account of the genesis allocation that was added, removed or
+// changed. Old is nil if it was added, and New if it was removed
+type AllocationChange struct {
+	Address common.Address
+	Kind    GenesisChangeKind
+	Old     *core.GenesisAccount
+	New     *core.GenesisAccount
+}
+
+// BalanceDelta returns the balance of New minus the balance of Old
+func (c AllocationChange) BalanceDelta() *big.Int {
+	return new(big.Int).Sub(accountBalance(c.New), accountBalance(c.Old))
+}
+
+// PrecompileChange is a precompile config of the genesis, keyed by its config key, e.g.
+// feeManagerConfig, that was added, removed or changed. Fields are its changed values, at
+// paths relative to the config
+type PrecompileChange struct {
+	Key    string
+	Kind   GenesisChangeKind
+	Old    precompileconfig.Config
+	New    precompileconfig.Config
+	Fields []GenesisFieldChange
+}
+
+// GenesisDiff is the structured diff of two Subnet-EVM genesis, from the first one to the
+// second one
+type GenesisDiff struct {
+	// Fields are the changed values of the genesis and of its chain config, outside of the
+	// allocation and the precompile configs
+	Fields []GenesisFieldChange
+
+	// Allocations are the changed accounts of the allocation, ordered by address
+	Allocations []AllocationChange
+
+	// Precompiles are the changed precompile configs, ordered by key
+	Precompiles []PrecompileChange
+}
+
+// Empty returns true if the two genesis are equivalent
+func (d *GenesisDiff) Empty() bool {
+	return len(d.Fields) == 0 && len(d.Allocations) == 0 && len(d.Precompiles) == 0
+}
+
+// String renders the diff for review, one change per line, prefixed by + for additions, -
+// for removals and ~ for changes
+func (d *GenesisDiff) String() string {
+	var sb strings.Builder
+	for _, field := range d.Fields {
+		writeFieldChange(&sb, "", field)
+	}
+	for _, alloc := range d.Allocations {
+		switch alloc.Kind {
+		case GenesisAdded:
+			fmt.Fprintf(&sb, "+ alloc %s: balance %s\n", alloc.Address, accountBalance(alloc.New))
+		case GenesisRemoved:
+			fmt.Fprintf(&sb, "- alloc %s: balance %s\n", alloc.Address, accountBalance(alloc.Old))
+		default:
+			fmt.Fprintf(&sb, "~ alloc %s: %s\n", alloc.Address, strings.Join(accountChanges(alloc.Old, alloc.New), ", "))
+		}
+	}
+	for _, precompile := range d.Precompiles {
+		switch precompile.Kind {
+		case GenesisAdded:
+			fmt.Fprintf(&sb, "+ precompile %s\n", precompile.Key)
+		case GenesisRemoved:
+			fmt.Fprintf(&sb, "- precompile %s\n", precompile.Key)
+		default:
+			for _, field := range precompile.Fields {
+				writeFieldChange(&sb, precompile.Key+".", field)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// DiffGenesis returns the structured diff of the Subnet-EVM genesis a and b, e.g. of the
+// testnet and mainnet launches of a chain, or of a proposed genesis and the one deployed,
+// as found by subnet.Describe. The genesis are compared as parsed by Subnet-EVM, so
+// formatting, key order and hex or decimal numbers make no difference
+func DiffGenesis(a, b []byte) (*GenesisDiff, error) {
+	genesisA, err := parseEVMGenesis(a)
+	if err != nil {
+		return nil, fmt.Errorf("first genesis: %w", err)
+	}
+	genesisB, err := parseEVMGenesis(b)
+	if err != nil {
+		return nil, fmt.Errorf("second genesis: %w", err)
+	}
+	diff := &GenesisDiff{}
+
+	// precompile configs are inlined in the chain config, so they are left out of its fields
+	precompileKeys := map[string]bool{}
+	for key := range genesisA.Config.GenesisPrecompiles {
+		precompileKeys[key] = true
+	}
+	for key := range genesisB.Config.GenesisPrecompiles {
+		precompileKeys[key] = true
+	}
+	fieldsA, err := genesisFields(genesisA, precompileKeys)
+	if err != nil {
+		return nil, err
+	}
+	fieldsB, err := genesisFields(genesisB, precompileKeys)
+	if err != nil {
+		return nil, err
+	}
+	diff.Fields = diffJSONValues("", fieldsA, fieldsB, nil)
+
+	diff.Allocations = diffAllocations(genesisA.Alloc, genesisB.Alloc)
+
+	diff.Precompiles, err = diffPrecompiles(genesisA, genesisB, precompileKeys)
+	if err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+func parseEVMGenesis(genesisBytes []byte) (*core.Genesis, error) {
+	genesis := &core.Genesis{}
+	if err := genesis.UnmarshalJSON(genesisBytes); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidGenesis, err)
+	}
+	if genesis.Config == nil {
+		return nil, fmt.Errorf("%w: no chain config", ErrInvalidGenesis)
+	}
+	return genesis, nil
+}
+
+// genesisFields returns the JSON values of genesis as encoded by Subnet-EVM, without its
+// allocation and the precompileKeys of its chain config
+func genesisFields(genesis *core.Genesis, precompileKeys map[string]bool) (map[string]any, error) {
+	genesisBytes, err := genesis.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	fields, err := decodeJSONObject(genesisBytes)
+	if err != nil {
+		return nil, err
+	}
+	delete(fields, "alloc")
+	if config, ok := fields["config"].(map[string]any); ok {
+		for key := range precompileKeys {
+			delete(config, key)
+		}
+	}
+	return fields, nil
+}
+
+func decodeJSONObject(data []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	object := map[string]any{}
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// diffJSONValues appends to changes the values that differ between a and b at path,
+// recursing into the objects. Arrays are compared as a whole
+func diffJSONValues(path string, a, b any, changes []GenesisFieldChange) []GenesisFieldChange {
+	objectA, isObjectA := a.(map[string]any)
+	objectB, isObjectB := b.(map[string]any)
+	if !isObjectA || !isObjectB {
+		if !reflect.DeepEqual(a, b) {
+			changes = append(changes, GenesisFieldChange{Path: path, Old: a, New: b})
+		}
+		return changes
+	}
+	keys := []string{}
+	for key := range objectA {
+		keys = append(keys, key)
+	}
+	for key := range objectB {
+		if _, ok := objectA[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+		changes = diffJSONValues(keyPath, objectA[key], objectB[key], changes)
+	}
+	return changes
+}
+
+func diffAllocations(a, b core.GenesisAlloc) []AllocationChange {
+	changes := []AllocationChange{}
+	for address, accountA := range a {
+		accountA := accountA
+		accountB, ok := b[address]
+		switch {
+		case !ok:
+			changes = append(changes, AllocationChange{Address: address, Kind: GenesisRemoved, Old: &accountA})
+		case len(accountChanges(&accountA, &accountB)) > 0:
+			changes = append(changes, AllocationChange{Address: address, Kind: GenesisChanged, Old: &accountA, New: &accountB})
+		}
+	}
+	for address, accountB := range b {
+		accountB := accountB
+		if _, ok := a[address]; !ok {
+			changes = append(changes, AllocationChange{Address: address, Kind: GenesisAdded, New: &accountB})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return bytes.Compare(changes[i].Address[:], changes[j].Address[:]) < 0
+	})
+	return changes
+}
+
+// accountChanges describes what differs between the genesis accounts a and b
+func accountChanges(a, b *core.GenesisAccount) []string {
+	changes := []string{}
+	if balanceA, balanceB := accountBalance(a), accountBalance(b); balanceA.Cmp(balanceB) != 0 {
+		changes = append(changes, fmt.Sprintf("balance %s -> %s", balanceA, balanceB))
+	}
+	if a.Nonce != b.Nonce {
+		changes = append(changes, fmt.Sprintf("nonce %d -> %d", a.Nonce, b.Nonce))
+	}
+	if !bytes.Equal(a.Code, b.Code) {
+		changes = append(changes, "code changed")
+	}
+	if !maps.Equal(a.Storage, b.Storage) {
+		changes = append(changes, "storage changed")
+	}
+	return changes
+}
+
+func accountBalance(account *core.GenesisAccount) *big.Int {
+	if account == nil || account.Balance == nil {
+		return big.NewInt(0)
+	}
+	return account.Balance
+}
+
+func diffPrecompiles(a, b *core.Genesis, precompileKeys map[string]bool) ([]PrecompileChange, error) {
+	keys := []string{}
+	for key := range precompileKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	changes := []PrecompileChange{}
+	for _, key := range keys {
+		configA, inA := a.Config.GenesisPrecompiles[key]
+		configB, inB := b.Config.GenesisPrecompiles[key]
+		switch {
+		case !inA:
+			changes = append(changes, PrecompileChange{Key: key, Kind: GenesisAdded, New: configB})
+		case !inB:
+			changes = append(changes, PrecompileChange{Key: key, Kind: GenesisRemoved, Old: configA})
+		case !configA.Equal(configB):
+			fields, err := diffPrecompileConfigs(configA, configB)
+			if err != nil {
+				return nil, fmt.Errorf("precompile %s: %w", key, err)
+			}
+			changes = append(changes, PrecompileChange{Key: key, Kind: GenesisChanged, Old: configA, New: configB, Fields: fields})
+		}
+	}
+	return changes, nil
+}
+
+func diffPrecompileConfigs(a, b precompileconfig.Config) ([]GenesisFieldChange, error) {
+	values := []map[string]any{}
+	for _, config := range []precompileconfig.Config{a, b} {
+		configBytes, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		value, err := decodeJSONObject(configBytes)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return diffJSONValues("", values[0], values[1], nil), nil
+}
+
+func writeFieldChange(sb *strings.Builder, prefix string, field GenesisFieldChange) {
+	switch {
+	case field.Old == nil:
+		fmt.Fprintf(sb, "+ %s%s: %s\n", prefix, field.Path, jsonString(field.New))
+	case field.New == nil:
+		fmt.Fprintf(sb, "- %s%s: %s\n", prefix, field.Path, jsonString(field.Old))
+	default:
+		fmt.Fprintf(sb, "~ %s%s: %s -> %s\n", prefix, field.Path, jsonString(field.Old), jsonString(field.New))
+	}
+}
+
+func jsonString(value any) string {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(valueBytes)
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/vm"
+	"github.com/DioneProtocol/subnet-evm/core"
+	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/feemanager"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	diffAdmin    = common.HexToAddress("0x0100000000000000000000000000000000000001")
+	diffManager  = common.HexToAddress("0x0100000000000000000000000000000000000002")
+	diffTreasury = common.HexToAddress("0x0100000000000000000000000000000000000003")
+	diffFaucet   = common.HexToAddress("0x0100000000000000000000000000000000000004")
+)
+
+// testDiffGenesis returns the genesis of chainID built as createEvmGenesis does, with a fixed
+// timestamp
+func testDiffGenesis(t *testing.T, chainID int64, alloc core.GenesisAlloc, precompiles params.Precompiles) []byte {
+	config := *params.SubnetEVMDefaultChainConfig
+	config.ChainID = big.NewInt(chainID)
+	config.FeeConfig = vm.StarterFeeConfig
+	config.GenesisPrecompiles = precompiles
+	genesis := core.Genesis{
+		Config:     &config,
+		Timestamp:  1_700_000_000,
+		Difficulty: vm.Difficulty,
+		GasLimit:   vm.StarterFeeConfig.GasLimit.Uint64(),
+		Alloc:      alloc,
+	}
+	genesisBytes, err := genesis.MarshalJSON()
+	require.NoError(t, err)
+	return genesisBytes
+}
+
+func TestDiffGenesis(t *testing.T) {
+	testnetAlloc := core.GenesisAlloc{
+		diffAdmin:    {Balance: big.NewInt(1_000)},
+		diffTreasury: {Balance: big.NewInt(5_000)},
+		diffFaucet:   {Balance: big.NewInt(100)},
+	}
+	testnet := testDiffGenesis(t, 123456, testnetAlloc, params.Precompiles{
+		txallowlist.ConfigKey:  txallowlist.NewConfig(nil, []common.Address{diffAdmin}, nil, nil),
+		nativeminter.ConfigKey: nativeminter.NewConfig(nil, []common.Address{diffAdmin}, nil, nil, nil),
+	})
+
+	t.Run("same genesis", func(t *testing.T) {
+		// the genesis are compared as parsed, whatever their formatting
+		indented := map[string]any{}
+		require.NoError(t, json.Unmarshal(testnet, &indented))
+		reformatted, err := json.MarshalIndent(indented, "", "    ")
+		require.NoError(t, err)
+		diff, err := DiffGenesis(testnet, reformatted)
+		require.NoError(t, err)
+		assert.True(t, diff.Empty())
+		assert.Empty(t, diff.String())
+	})
+
+	t.Run("testnet to mainnet", func(t *testing.T) {
+		mainnetFeeConfig := vm.StarterFeeConfig
+		mainnetFeeConfig.GasLimit = big.NewInt(15_000_000)
+		mainnetAlloc := core.GenesisAlloc{
+			diffAdmin:    {Balance: big.NewInt(1_000)},
+			diffTreasury: {Balance: big.NewInt(9_000), Nonce: 1},
+			diffManager:  {Balance: big.NewInt(50)},
+		}
+		config := *params.SubnetEVMDefaultChainConfig
+		config.ChainID = big.NewInt(654321)
+		config.FeeConfig = mainnetFeeConfig
+		config.GenesisPrecompiles = params.Precompiles{
+			txallowlist.ConfigKey: txallowlist.NewConfig(nil, []common.Address{diffAdmin}, nil, []common.Address{diffManager}),
+			feemanager.ConfigKey:  feemanager.NewConfig(nil, []common.Address{diffAdmin}, nil, nil, nil),
+		}
+		genesis := core.Genesis{
+			Config:     &config,
+			Timestamp:  1_700_000_000,
+			Difficulty: vm.Difficulty,
+			GasLimit:   mainnetFeeConfig.GasLimit.Uint64(),
+			Alloc:      mainnetAlloc,
+		}
+		mainnet, err := genesis.MarshalJSON()
+		require.NoError(t, err)
+
+		diff, err := DiffGenesis(testnet, mainnet)
+		require.NoError(t, err)
+		assert.False(t, diff.Empty())
+
+		paths := []string{}
+		for _, field := range diff.Fields {
+			paths = append(paths, field.Path)
+		}
+		assert.Equal(t, []string{"config.chainId", "config.feeConfig.gasLimit", "gasLimit"}, paths)
+		assert.Equal(t, GenesisFieldChange{Path: "config.chainId", Old: json.Number("123456"), New: json.Number("654321")}, diff.Fields[0])
+
+		require.Len(t, diff.Allocations, 3)
+		assert.Equal(t, diffManager, diff.Allocations[0].Address)
+		assert.Equal(t, GenesisAdded, diff.Allocations[0].Kind)
+		assert.Equal(t, big.NewInt(50), diff.Allocations[0].BalanceDelta())
+		assert.Equal(t, diffTreasury, diff.Allocations[1].Address)
+		assert.Equal(t, GenesisChanged, diff.Allocations[1].Kind)
+		assert.Equal(t, big.NewInt(4_000), diff.Allocations[1].BalanceDelta())
+		assert.Equal(t, diffFaucet, diff.Allocations[2].Address)
+		assert.Equal(t, GenesisRemoved, diff.Allocations[2].Kind)
+		assert.Equal(t, big.NewInt(-100), diff.Allocations[2].BalanceDelta())
+
+		require.Len(t, diff.Precompiles, 3)
+		assert.Equal(t, nativeminter.ConfigKey, diff.Precompiles[0].Key)
+		assert.Equal(t, GenesisRemoved, diff.Precompiles[0].Kind)
+		assert.Equal(t, feemanager.ConfigKey, diff.Precompiles[1].Key)
+		assert.Equal(t, GenesisAdded, diff.Precompiles[1].Kind)
+		assert.Equal(t, txallowlist.ConfigKey, diff.Precompiles[2].Key)
+		assert.Equal(t, GenesisChanged, diff.Precompiles[2].Kind)
+		require.Len(t, diff.Precompiles[2].Fields, 1)
+		assert.Equal(t, "managerAddresses", diff.Precompiles[2].Fields[0].Path)
+		assert.Nil(t, diff.Precompiles[2].Fields[0].Old)
+
+		assert.Equal(t, `~ config.chainId: 123456 -> 654321
+~ config.feeConfig.gasLimit: 8000000 -> 15000000
+~ gasLimit: "0x7a1200" -> "0xe4e1c0"
++ alloc 0x0100000000000000000000000000000000000002: balance 50
+~ alloc 0x0100000000000000000000000000000000000003: balance 5000 -> 9000, nonce 0 -> 1
+- alloc 0x0100000000000000000000000000000000000004: balance 100
+- precompile contractNativeMinterConfig
++ precompile feeManagerConfig
++ txAllowListConfig.managerAddresses: ["0x0100000000000000000000000000000000000002"]
+`, diff.String())
+	})
+
+	t.Run("invalid genesis", func(t *testing.T) {
+		_, err := DiffGenesis(testnet, []byte("{"))
+		require.ErrorIs(t, err, ErrInvalidGenesis)
+		_, err = DiffGenesis([]byte(`{"alloc": {}}`), testnet)
+		require.ErrorIs(t, err, ErrInvalidGenesis)
+	})
+}
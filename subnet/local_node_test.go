@@ -0,0 +1,11 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import "github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+
+func init() {
+	// the tests run against a local node when LOCAL_NODE=true
+	odyssey.LocalNodeEnvEnabled = true
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/validator"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/chain/o"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubnet_CreateSubnetTx_Memo(t *testing.T) {
+	addr := ids.GenerateTestShortID()
+	dioneAssetID := ids.GenerateTestID()
+	utxos := primary.NewChainUTXOs(constants.OmegaChainID, primary.NewUTXOs())
+	require.NoError(t, utxos.AddUTXO(context.Background(), constants.OmegaChainID, &dione.UTXO{
+		UTXOID: dione.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  dione.Asset{ID: dioneAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          1000,
+			OutputOwners: secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr}},
+		},
+	}))
+	backend := o.NewBackend(
+		o.NewContext(constants.TestnetID, dioneAssetID, 0, 0, 0, 0, 0, 0, 0, 0),
+		utxos,
+		map[ids.ID]*txs.Tx{},
+	)
+	oWallet := o.NewWallet(o.NewBuilder(set.Of(addr), backend), o.NewSigner(secp256k1fx.NewKeychain(), backend), nil, backend)
+	w := wallet.Wallet{Wallet: primary.NewWallet(oWallet, nil, nil)}
+
+	tests := []struct {
+		name       string
+		memo       []byte
+		walletMemo []byte
+		wantMemo   []byte
+		wantErr    error
+	}{
+		{name: "no memo"},
+		{name: "deploy memo", memo: []byte("OPS-1234"), wantMemo: []byte("OPS-1234")},
+		{name: "wallet memo", walletMemo: []byte("OPS-5678"), wantMemo: []byte("OPS-5678")},
+		{name: "deploy memo over wallet memo", memo: []byte("OPS-1234"), walletMemo: []byte("OPS-5678"), wantMemo: []byte("OPS-1234")},
+		{name: "too large", memo: bytes.Repeat([]byte{'a'}, dione.MaxMemoSize+1), wantErr: wallet.ErrMemoTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := w
+			require.NoError(t, w.SetMemo(tt.walletMemo))
+			c := &Subnet{}
+			c.SetSubnetControlParams([]ids.ShortID{addr}, 1)
+			c.DeployInfo.Memo = tt.memo
+			ms, err := c.CreateSubnetTx(w)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			utx, ok := ms.OChainTx.Unsigned.(*txs.CreateSubnetTx)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantMemo, []byte(utx.Memo))
+		})
+	}
+}
+
+func TestSubnet_AddValidator_MemoTooLarge(t *testing.T) {
+	c := &Subnet{SubnetID: ids.GenerateTestID()}
+	c.SetParams([]ids.ShortID{ids.GenerateTestShortID()}, []ids.ShortID{ids.GenerateTestShortID()}, 1)
+	tooLarge := bytes.Repeat([]byte{'a'}, dione.MaxMemoSize+1)
+
+	_, err := c.AddValidator(wallet.Wallet{}, validator.SubnetValidatorParams{
+		NodeID:   ids.GenerateTestNodeID(),
+		Duration: time.Hour,
+		Memo:     tooLarge,
+	})
+	assert.ErrorIs(t, err, wallet.ErrMemoTooLarge)
+
+	c.DeployInfo.Memo = tooLarge
+	_, err = c.AddValidator(wallet.Wallet{}, validator.SubnetValidatorParams{
+		NodeID:   ids.GenerateTestNodeID(),
+		Duration: time.Hour,
+	})
+	assert.ErrorIs(t, err, wallet.ErrMemoTooLarge)
+}
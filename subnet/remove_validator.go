@@ -0,0 +1,207 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/multisig"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+)
+
+// DefaultMinValidators is the number of validators a subnet is expected to keep after a
+// removal, below which a single validator going offline can halt its chains
+const DefaultMinValidators = 4
+
+var (
+	ErrInvalidMinValidators   = errors.New("min validators cannot be negative")
+	ErrUnsafeValidatorRemoval = errors.New("removing the validator puts the subnet at risk")
+)
+
+// ValidatorRemovalAnalysis describes the validator set of a subnet before and after the
+// removal of one of its validators
+type ValidatorRemovalAnalysis struct {
+	NodeID ids.NodeID
+
+	// Weight is the weight of the removed validator
+	Weight uint64
+
+	// ValidatorCount and SubnetWeight are the number and the total weight of the subnet
+	// validators before the removal
+	ValidatorCount int
+	SubnetWeight   uint64
+
+	// RemainingValidators and RemainingWeight are the number and the total weight of the
+	// subnet validators after the removal
+	RemainingValidators int
+	RemainingWeight     uint64
+
+	// Warnings describe why the removal is unsafe, if it is
+	Warnings []string
+}
+
+// Safe returns true if the removal raised no warning
+func (a *ValidatorRemovalAnalysis) Safe() bool {
+	return len(a.Warnings) == 0
+}
+
+// err returns ErrUnsafeValidatorRemoval with the warnings of a, if any
+func (a *ValidatorRemovalAnalysis) err() error {
+	if a.Safe() {
+		return nil
+	}
+	return fmt.Errorf("%w %s: %s", ErrUnsafeValidatorRemoval, a.NodeID, strings.Join(a.Warnings, "; "))
+}
+
+// ValidatorRemovalOp holds the options of AnalyzeValidatorRemoval and RemoveValidator
+type ValidatorRemovalOp struct {
+	minValidators  int
+	quorumFraction float64
+	force          bool
+}
+
+// ValidatorRemovalOption configures AnalyzeValidatorRemoval and RemoveValidator
+type ValidatorRemovalOption func(*ValidatorRemovalOp)
+
+// WithMinValidators sets the number of validators the subnet must keep after the removal,
+// DefaultMinValidators by default
+func WithMinValidators(count int) ValidatorRemovalOption {
+	return func(op *ValidatorRemovalOp) {
+		op.minValidators = count
+	}
+}
+
+// WithRemovalQuorumFraction sets the share of the subnet weight the other validators must
+// hold after the removal, DefaultQuorumFraction by default
+func WithRemovalQuorumFraction(fraction float64) ValidatorRemovalOption {
+	return func(op *ValidatorRemovalOp) {
+		op.quorumFraction = fraction
+	}
+}
+
+// WithForceRemoval makes RemoveValidator build the tx even if the removal is unsafe, e.g. to
+// remove a validator that is already offline for good
+func WithForceRemoval() ValidatorRemovalOption {
+	return func(op *ValidatorRemovalOp) {
+		op.force = true
+	}
+}
+
+func newValidatorRemovalOp(opts []ValidatorRemovalOption) (*ValidatorRemovalOp, error) {
+	op := &ValidatorRemovalOp{minValidators: DefaultMinValidators, quorumFraction: DefaultQuorumFraction}
+	for _, opt := range opts {
+		opt(op)
+	}
+	if op.minValidators < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidMinValidators, op.minValidators)
+	}
+	if op.quorumFraction < 0 || op.quorumFraction > 1 {
+		return nil, fmt.Errorf("%w: %f", ErrInvalidQuorumFraction, op.quorumFraction)
+	}
+	return op, nil
+}
+
+// AnalyzeValidatorRemoval computes the validator set of the subnet after the removal of the
+// validator nodeID, queried from the O-Chain API of uri. The analysis warns if fewer than
+// the min validators would remain, or if the other validators would hold less than the
+// quorum fraction of the subnet weight
+func (c *Subnet) AnalyzeValidatorRemoval(uri string, nodeID ids.NodeID, opts ...ValidatorRemovalOption) (*ValidatorRemovalAnalysis, error) {
+	if nodeID == ids.EmptyNodeID {
+		return nil, ErrEmptyValidatorNodeID
+	}
+	if c.SubnetID == ids.Empty {
+		return nil, ErrEmptySubnetID
+	}
+	op, err := newValidatorRemovalOp(opts)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := getSubnetValidators(uri, c.SubnetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the validators of subnet %s: %w", c.SubnetID, err)
+	}
+	return analyzeValidatorRemoval(validators, nodeID, op)
+}
+
+// RemoveValidator builds the RemoveSubnetValidatorTx of the validator nodeID, signed with the
+// keys of wallet, to sign with the subnet auth keys and commit. The removal is analyzed first
+// with AnalyzeValidatorRemoval, and refused with ErrUnsafeValidatorRemoval if unsafe unless
+// WithForceRemoval is given, since removing too many validators halts the subnet chains. The
+// analysis is returned along with the tx
+func (c *Subnet) RemoveValidator(
+	wallet wallet.Wallet,
+	nodeID ids.NodeID,
+	opts ...ValidatorRemovalOption,
+) (*multisig.Multisig, *ValidatorRemovalAnalysis, error) {
+	if len(c.DeployInfo.SubnetAuthKeys) == 0 {
+		return nil, nil, ErrEmptySubnetAuth
+	}
+	op, err := newValidatorRemovalOp(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	analysis, err := c.AnalyzeValidatorRemoval(wallet.URI(), nodeID, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := analysis.err(); err != nil && !op.force {
+		return nil, analysis, err
+	}
+	memoOptions, err := c.memoOptions(nil)
+	if err != nil {
+		return nil, analysis, err
+	}
+
+	wallet.SetSubnetAuthMultisig(c.DeployInfo.SubnetAuthKeys)
+	unsignedTx, err := wallet.O().Builder().NewRemoveSubnetValidatorTx(nodeID, c.SubnetID, memoOptions...)
+	if err != nil {
+		return nil, analysis, fmt.Errorf("error building tx: %w", err)
+	}
+	tx := txs.Tx{Unsigned: unsignedTx}
+	if err := wallet.O().Signer().Sign(context.Background(), &tx); err != nil {
+		return nil, analysis, fmt.Errorf("error signing tx: %w", err)
+	}
+	return multisig.New(&tx), analysis, nil
+}
+
+// analyzeValidatorRemoval analyzes the removal of nodeID among the current validators of a
+// subnet
+func analyzeValidatorRemoval(
+	validators []omegavm.ClientPermissionlessValidator,
+	nodeID ids.NodeID,
+	op *ValidatorRemovalOp,
+) (*ValidatorRemovalAnalysis, error) {
+	analysis := &ValidatorRemovalAnalysis{NodeID: nodeID, ValidatorCount: len(validators)}
+	found := false
+	for _, v := range validators {
+		analysis.SubnetWeight += v.Weight
+		if v.NodeID == nodeID {
+			found = true
+			analysis.Weight = v.Weight
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrValidatorNotFound, nodeID)
+	}
+	analysis.RemainingValidators = analysis.ValidatorCount - 1
+	analysis.RemainingWeight = analysis.SubnetWeight - analysis.Weight
+
+	if analysis.RemainingValidators < op.minValidators {
+		analysis.Warnings = append(analysis.Warnings, fmt.Sprintf(
+			"%d validators remain, fewer than %d", analysis.RemainingValidators, op.minValidators,
+		))
+	}
+	if analysis.RemainingWeight == 0 || float64(analysis.RemainingWeight) < op.quorumFraction*float64(analysis.SubnetWeight) {
+		analysis.Warnings = append(analysis.Warnings, fmt.Sprintf(
+			"other validators hold %d of %d, quorum is %.2f", analysis.RemainingWeight, analysis.SubnetWeight, op.quorumFraction,
+		))
+	}
+	return analysis, nil
+}
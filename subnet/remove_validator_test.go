@@ -0,0 +1,79 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+)
+
+func TestAnalyzeValidatorRemoval(t *testing.T) {
+	end := time.Now().Add(time.Hour)
+	nodeIDs := []ids.NodeID{ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()}
+	validators := make([]omegavm.ClientPermissionlessValidator, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		validators = append(validators, testSubnetValidator(nodeID, 20, end))
+	}
+	tests := []struct {
+		name             string
+		validators       []omegavm.ClientPermissionlessValidator
+		opts             []ValidatorRemovalOption
+		expectedWarnings int
+	}{
+		{name: "safe", validators: validators},
+		{name: "too few validators", validators: validators[:4], expectedWarnings: 1},
+		{name: "min validators lowered", validators: validators[:4], opts: []ValidatorRemovalOption{WithMinValidators(3)}},
+		{name: "quorum at risk", validators: validators[:2], opts: []ValidatorRemovalOption{WithMinValidators(1)}, expectedWarnings: 1},
+		{name: "last validator", validators: validators[:1], opts: []ValidatorRemovalOption{WithRemovalQuorumFraction(0)}, expectedWarnings: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := newValidatorRemovalOp(tt.opts)
+			require.NoError(t, err)
+			analysis, err := analyzeValidatorRemoval(tt.validators, nodeIDs[0], op)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(20), analysis.Weight)
+			assert.Equal(t, len(tt.validators)-1, analysis.RemainingValidators)
+			assert.Equal(t, uint64(20*(len(tt.validators)-1)), analysis.RemainingWeight)
+			assert.Len(t, analysis.Warnings, tt.expectedWarnings)
+			assert.Equal(t, tt.expectedWarnings == 0, analysis.Safe())
+		})
+	}
+
+	op, err := newValidatorRemovalOp(nil)
+	require.NoError(t, err)
+	_, err = analyzeValidatorRemoval(validators, ids.GenerateTestNodeID(), op)
+	require.ErrorIs(t, err, ErrValidatorNotFound)
+	_, err = newValidatorRemovalOp([]ValidatorRemovalOption{WithMinValidators(-1)})
+	require.ErrorIs(t, err, ErrInvalidMinValidators)
+}
+
+func TestSubnet_RemoveValidator_Unsafe(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+	subnet := &Subnet{SubnetID: ids.GenerateTestID(), DeployInfo: DeployParams{SubnetAuthKeys: []ids.ShortID{ids.GenerateTestShortID()}}}
+	_, _, err := (&Subnet{SubnetID: subnet.SubnetID}).RemoveValidator(wallet.Wallet{}, nodeID)
+	require.ErrorIs(t, err, ErrEmptySubnetAuth)
+	_, _, err = subnet.RemoveValidator(wallet.Wallet{}, ids.EmptyNodeID)
+	require.ErrorIs(t, err, ErrEmptyValidatorNodeID)
+
+	original := getSubnetValidators
+	t.Cleanup(func() { getSubnetValidators = original })
+	getSubnetValidators = func(string, ids.ID) ([]omegavm.ClientPermissionlessValidator, error) {
+		return []omegavm.ClientPermissionlessValidator{
+			testSubnetValidator(nodeID, 20, time.Now().Add(time.Hour)),
+			testSubnetValidator(ids.GenerateTestNodeID(), 20, time.Now().Add(time.Hour)),
+		}, nil
+	}
+	_, analysis, err := subnet.RemoveValidator(wallet.Wallet{}, nodeID)
+	require.ErrorIs(t, err, ErrUnsafeValidatorRemoval)
+	assert.Equal(t, 1, analysis.RemainingValidators)
+	assert.Len(t, analysis.Warnings, 2)
+}
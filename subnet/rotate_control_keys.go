@@ -0,0 +1,125 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/addressbook"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/multisig"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/formatting/address"
+)
+
+var (
+	ErrRotationThresholdTooHigh = errors.New("threshold is larger than the number of control keys")
+	ErrDuplicatedControlKey     = errors.New("duplicated control key")
+	ErrRotationLabelsMismatch   = errors.New("number of labels does not match the number of control keys")
+	ErrRotationNotApplied       = errors.New("subnet owners do not match the rotated control keys")
+)
+
+// ControlKeyRotation describes the new owners of a subnet. The O-Chain of the odysseygo
+// version the SDK is built with has no ownership transfer tx, so the rotation is issued out of
+// the SDK and checked afterwards with VerifyControlKeyRotation
+type ControlKeyRotation struct {
+	// ControlKeys are the new control keys of the subnet
+	ControlKeys []ids.ShortID
+
+	// Threshold is the number of signatures of ControlKeys required to authorize subnet
+	// transactions after the rotation
+	Threshold uint32
+
+	// Labels optionally names ControlKeys in an address book, Labels[i] naming ControlKeys[i].
+	// Empty labels are skipped
+	Labels []string
+}
+
+// Validate refuses unsafe rotations, such as a threshold no set of control keys can reach
+func (r ControlKeyRotation) Validate() error {
+	if len(r.ControlKeys) == 0 {
+		return ErrEmptyControlKeys
+	}
+	if r.Threshold == 0 {
+		return ErrEmptyThreshold
+	}
+	if int(r.Threshold) > len(r.ControlKeys) {
+		return fmt.Errorf("%w: threshold %d for %d keys", ErrRotationThresholdTooHigh, r.Threshold, len(r.ControlKeys))
+	}
+	seen := map[ids.ShortID]struct{}{}
+	for _, key := range r.ControlKeys {
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicatedControlKey, key)
+		}
+		seen[key] = struct{}{}
+	}
+	if len(r.Labels) != 0 && len(r.Labels) != len(r.ControlKeys) {
+		return ErrRotationLabelsMismatch
+	}
+	return nil
+}
+
+// matches tells if controlKeys and threshold are the owners set by the rotation
+func (r ControlKeyRotation) matches(controlKeys []ids.ShortID, threshold uint32) bool {
+	if threshold != r.Threshold || len(controlKeys) != len(r.ControlKeys) {
+		return false
+	}
+	keys := map[ids.ShortID]struct{}{}
+	for _, key := range controlKeys {
+		keys[key] = struct{}{}
+	}
+	for _, key := range r.ControlKeys {
+		if _, ok := keys[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifyControlKeyRotation checks on network that the subnet is owned by the control keys and
+// threshold of rotation, bypassing any cached owners. On success the control keys and threshold
+// of c are updated to the new owners
+func (c *Subnet) VerifyControlKeyRotation(network odyssey.Network, rotation ControlKeyRotation) error {
+	if c.SubnetID == ids.Empty {
+		return ErrEmptySubnetID
+	}
+	if err := rotation.Validate(); err != nil {
+		return err
+	}
+	controlKeys, threshold, err := multisig.SharedOwnersCache().Refresh(network, c.SubnetID)
+	if err != nil {
+		return err
+	}
+	if !rotation.matches(controlKeys, threshold) {
+		return fmt.Errorf("%w: subnet %s has threshold %d of %d keys", ErrRotationNotApplied, c.SubnetID, threshold, len(controlKeys))
+	}
+	c.SetSubnetControlParams(rotation.ControlKeys, rotation.Threshold)
+	return nil
+}
+
+// UpdateAddressBook adds to book the O-Chain addresses on network of the labeled control keys
+// of the rotation, replacing the O-Chain address of existing entries with the same label
+func (r ControlKeyRotation) UpdateAddressBook(book *addressbook.AddressBook, network odyssey.Network) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	for i, label := range r.Labels {
+		if label == "" {
+			continue
+		}
+		addr, err := address.Format("O", network.HRP(), r.ControlKeys[i][:])
+		if err != nil {
+			return err
+		}
+		entry, _ := book.Get(label)
+		entry.Label = label
+		entry.OChainAddress = addr
+		if err := book.Add(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/addressbook"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/formatting/address"
+)
+
+func TestControlKeyRotation_Validate(t *testing.T) {
+	keyA, keyB := ids.GenerateTestShortID(), ids.GenerateTestShortID()
+	tests := []struct {
+		name        string
+		rotation    ControlKeyRotation
+		expectedErr error
+	}{
+		{
+			name:     "valid",
+			rotation: ControlKeyRotation{ControlKeys: []ids.ShortID{keyA, keyB}, Threshold: 2, Labels: []string{"alice", ""}},
+		},
+		{
+			name:        "no control keys",
+			rotation:    ControlKeyRotation{Threshold: 1},
+			expectedErr: ErrEmptyControlKeys,
+		},
+		{
+			name:        "zero threshold",
+			rotation:    ControlKeyRotation{ControlKeys: []ids.ShortID{keyA}},
+			expectedErr: ErrEmptyThreshold,
+		},
+		{
+			name:        "threshold larger than key count",
+			rotation:    ControlKeyRotation{ControlKeys: []ids.ShortID{keyA, keyB}, Threshold: 3},
+			expectedErr: ErrRotationThresholdTooHigh,
+		},
+		{
+			name:        "duplicated key",
+			rotation:    ControlKeyRotation{ControlKeys: []ids.ShortID{keyA, keyA}, Threshold: 2},
+			expectedErr: ErrDuplicatedControlKey,
+		},
+		{
+			name:        "labels mismatch",
+			rotation:    ControlKeyRotation{ControlKeys: []ids.ShortID{keyA, keyB}, Threshold: 1, Labels: []string{"alice"}},
+			expectedErr: ErrRotationLabelsMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rotation.Validate()
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestControlKeyRotation_Matches(t *testing.T) {
+	keyA, keyB := ids.GenerateTestShortID(), ids.GenerateTestShortID()
+	rotation := ControlKeyRotation{ControlKeys: []ids.ShortID{keyA, keyB}, Threshold: 1}
+
+	assert.True(t, rotation.matches([]ids.ShortID{keyB, keyA}, 1))
+	assert.False(t, rotation.matches([]ids.ShortID{keyA, keyB}, 2))
+	assert.False(t, rotation.matches([]ids.ShortID{keyA}, 1))
+	assert.False(t, rotation.matches([]ids.ShortID{keyA, ids.GenerateTestShortID()}, 1))
+}
+
+func TestSubnet_VerifyControlKeyRotation_Validation(t *testing.T) {
+	rotation := ControlKeyRotation{ControlKeys: []ids.ShortID{ids.GenerateTestShortID()}, Threshold: 1}
+	err := (&Subnet{}).VerifyControlKeyRotation(odyssey.TestnetNetwork(), rotation)
+	assert.ErrorIs(t, err, ErrEmptySubnetID)
+
+	s := &Subnet{SubnetID: ids.GenerateTestID()}
+	err = s.VerifyControlKeyRotation(odyssey.TestnetNetwork(), ControlKeyRotation{ControlKeys: rotation.ControlKeys, Threshold: 2})
+	assert.ErrorIs(t, err, ErrRotationThresholdTooHigh)
+}
+
+func TestControlKeyRotation_UpdateAddressBook(t *testing.T) {
+	network := odyssey.TestnetNetwork()
+	keyA, keyB := ids.GenerateTestShortID(), ids.GenerateTestShortID()
+	book := addressbook.New()
+	require.NoError(t, book.Add(addressbook.Entry{Label: "alice", DChainAddress: "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"}))
+
+	rotation := ControlKeyRotation{ControlKeys: []ids.ShortID{keyA, keyB}, Threshold: 1, Labels: []string{"alice", ""}}
+	require.NoError(t, rotation.UpdateAddressBook(book, network))
+
+	require.Len(t, book.Entries(), 1)
+	alice, ok := book.Get("alice")
+	require.True(t, ok)
+	expected, err := address.Format("O", network.HRP(), keyA[:])
+	require.NoError(t, err)
+	assert.Equal(t, expected, alice.OChainAddress)
+	assert.Equal(t, "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC", alice.DChainAddress)
+}
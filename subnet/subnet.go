@@ -5,13 +5,15 @@ package subnet
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"math/big"
 	"os"
 	"time"
 
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/addressbook"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/multisig"
 	utilsSDK "github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
@@ -53,8 +55,22 @@ type SubnetEVMParams struct {
 	FeeConfig commontype.FeeConfig
 
 	// Allocation specifies the initial state that is part of the genesis block.
+	// Use AllocationFromFile to build it from a CSV or JSON airdrop list
 	Allocation core.GenesisAlloc
 
+	// Vesting optionally locks genesis funds in a vesting contract, in addition to Allocation
+	Vesting *VestingParams
+
+	// GasToken optionally sets the name, symbol and decimals of the native token of the chain
+	GasToken *GasToken
+
+	// WrappedNativeToken optionally deploys a WETH-style wrapped native token in the genesis,
+	// named after GasToken, which is then required
+	WrappedNativeToken *WrappedNativeTokenParams
+
+	// Multicall optionally deploys a multicall contract in the genesis
+	Multicall *MulticallParams
+
 	// Ethereum uses Precompiles to efficiently implement cryptographic primitives within the EVM
 	// instead of re-implementing the same primitives in Solidity.
 	//
@@ -64,6 +80,12 @@ type SubnetEVMParams struct {
 	//
 	// For more information regarding Precompiles, head to https://docs.dione.network/build/vm/evm/intro.
 	Precompiles params.Precompiles
+
+	// Version and OdysseyGoVersion are the Subnet-EVM and odysseygo releases run by the chain
+	// validators, e.g. v0.5.6 and v1.10.9. When both are set, New refuses incompatible releases,
+	// see CompatibleVMVersions
+	Version          string
+	OdysseyGoVersion string
 }
 
 type CustomVMParams struct {
@@ -102,6 +124,13 @@ type Subnet struct {
 
 	// DeployInfo contains all the necessary information for createSubnetTx
 	DeployInfo DeployParams
+
+	// GasToken is the metadata of the native token of the Subnet-EVM chain, if set
+	GasToken *GasToken
+
+	// GenesisContracts are the addresses of the contracts deployed in the genesis of the
+	// Subnet-EVM chain
+	GenesisContracts GenesisContracts
 }
 
 func (c *Subnet) SetParams(controlKeys []ids.ShortID, subnetAuthKeys []ids.ShortID, threshold uint32) {
@@ -127,6 +156,28 @@ func (c *Subnet) SetSubnetAuthKeys(subnetAuthKeys []ids.ShortID) {
 	c.DeployInfo.SubnetAuthKeys = subnetAuthKeys
 }
 
+// SetSubnetControlParamsFromBook is SetSubnetControlParams with the control keys given as labels
+// of book or O-Chain addresses. book may be nil to only accept addresses
+func (c *Subnet) SetSubnetControlParamsFromBook(book *addressbook.AddressBook, controlKeys []string, threshold uint32) error {
+	controlKeyIDs, err := book.ResolveShortIDs(controlKeys)
+	if err != nil {
+		return fmt.Errorf("invalid control keys: %w", err)
+	}
+	c.SetSubnetControlParams(controlKeyIDs, threshold)
+	return nil
+}
+
+// SetSubnetAuthKeysFromBook is SetSubnetAuthKeys with the subnet auth keys given as labels of
+// book or O-Chain addresses. book may be nil to only accept addresses
+func (c *Subnet) SetSubnetAuthKeysFromBook(book *addressbook.AddressBook, subnetAuthKeys []string) error {
+	subnetAuthKeyIDs, err := book.ResolveShortIDs(subnetAuthKeys)
+	if err != nil {
+		return fmt.Errorf("invalid subnet auth keys: %w", err)
+	}
+	c.SetSubnetAuthKeys(subnetAuthKeyIDs)
+	return nil
+}
+
 type DeployParams struct {
 	// ControlKeys is a list of O-Chain addresses that are authorized to create new chains and add
 	// new validators to the Subnet
@@ -135,12 +186,17 @@ type DeployParams struct {
 	// SubnetAuthKeys is a list of O-Chain addresses that will be used to sign transactions that
 	// will modify the Subnet.
 	//
-	// SubnetAuthKeys has to be a subset of ControlKeys
+	// SubnetAuthKeys has to be a subset of ControlKeys. Use Keychain.SubnetAuth to compute
+	// them from the keys available locally
 	SubnetAuthKeys []ids.ShortID
 
 	// Threshold is the minimum number of signatures needed before a transaction can be issued
 	// Number of addresses in SubnetAuthKeys has to be more than or equal to Threshold number
 	Threshold uint32
+
+	// Memo tags the txs built for the Subnet, e.g. with the ID of the ticket of the operation,
+	// to reconcile them later. It is at most dione.MaxMemoSize bytes
+	Memo []byte
 }
 
 // New takes SubnetParams as input and creates Subnet as an output
@@ -168,6 +224,11 @@ func New(subnetParams *SubnetParams) (*Subnet, error) {
 	case subnetParams.GenesisFilePath != "":
 		genesisBytes, err = os.ReadFile(subnetParams.GenesisFilePath)
 	case subnetParams.SubnetEVM != nil:
+		if subnetParams.SubnetEVM.Version != "" && subnetParams.SubnetEVM.OdysseyGoVersion != "" {
+			if err := vm.CheckSubnetEVMCompatibility(subnetParams.SubnetEVM.OdysseyGoVersion, subnetParams.SubnetEVM.Version); err != nil {
+				return nil, err
+			}
+		}
 		genesisBytes, err = createEvmGenesis(subnetParams.SubnetEVM)
 	default:
 	}
@@ -184,6 +245,10 @@ func New(subnetParams *SubnetParams) (*Subnet, error) {
 		VMID:    vmID,
 		Genesis: genesisBytes,
 	}
+	if subnetParams.SubnetEVM != nil {
+		subnet.GasToken = subnetParams.SubnetEVM.GasToken
+		subnet.GenesisContracts = genesisContracts(subnetParams.SubnetEVM)
+	}
 	return &subnet, nil
 }
 
@@ -195,7 +260,8 @@ func createEvmGenesis(
 	subnetEVMParams *SubnetEVMParams,
 ) ([]byte, error) {
 	genesis := core.Genesis{}
-	genesis.Timestamp = *utils.TimeToNewUint64(time.Now())
+	genesisTime := time.Now()
+	genesis.Timestamp = *utils.TimeToNewUint64(genesisTime)
 
 	conf := params.SubnetEVMDefaultChainConfig
 	conf.MandatoryNetworkUpgrades = params.MandatoryNetworkUpgrades{}
@@ -210,10 +276,26 @@ func createEvmGenesis(
 		return nil, fmt.Errorf("genesis params fee config cannot be empty")
 	}
 
-	if subnetEVMParams.Allocation == nil {
+	if subnetEVMParams.Allocation == nil && subnetEVMParams.Vesting == nil {
 		return nil, fmt.Errorf("genesis params allocation cannot be empty")
 	}
 	allocation := subnetEVMParams.Allocation
+	if subnetEVMParams.Vesting != nil {
+		allocation, err = withVesting(allocation, subnetEVMParams.Vesting, genesisTime)
+		if err != nil {
+			return nil, fmt.Errorf("genesis params vesting: %w", err)
+		}
+	}
+
+	if subnetEVMParams.GasToken != nil {
+		if err := subnetEVMParams.GasToken.Validate(); err != nil {
+			return nil, fmt.Errorf("genesis params gas token: %w", err)
+		}
+	}
+	allocation, err = withGenesisContracts(allocation, subnetEVMParams)
+	if err != nil {
+		return nil, fmt.Errorf("genesis params contracts: %w", err)
+	}
 
 	if subnetEVMParams.Precompiles == nil {
 		return nil, fmt.Errorf("genesis params precompiles cannot be empty")
@@ -261,41 +343,40 @@ func (c *Subnet) Commit(ms multisig.Multisig, wallet wallet.Wallet, waitForTxAcc
 		return ids.Empty, err
 	}
 	if !isReady {
-		return ids.Empty, errors.New("tx is not fully signed so can't be committed")
+		return ids.Empty, multisig.ErrThresholdNotMet
 	}
 	tx, err := ms.GetWrappedOChainTx()
 	if err != nil {
 		return ids.Empty, err
 	}
-	const (
-		repeats             = 3
-		sleepBetweenRepeats = 2 * time.Second
+	// TODO: split error checking and recovery between issuing and waiting for status
+	_, issueTxErr := utilsSDK.Retry(
+		context.Background(),
+		utilsSDK.RetryPolicy{
+			MaxAttempts:     3,
+			AttemptTimeout:  constants.APIRequestLargeTimeout,
+			InitialInterval: 2 * time.Second,
+			Jitter:          0.5,
+		},
+		func(ctx context.Context) (struct{}, error) {
+			options := []commonOdysseyGo.Option{commonOdysseyGo.WithContext(ctx)}
+			if !waitForTxAcceptance {
+				options = append(options, commonOdysseyGo.WithAssumeDecided())
+			}
+			if err := wallet.O().IssueTx(tx, options...); err != nil {
+				if ctx.Err() != nil {
+					return struct{}{}, fmt.Errorf("timeout issuing/verifying tx with ID %s: %w", tx.ID(), err)
+				}
+				return struct{}{}, fmt.Errorf("error issuing tx with ID %s: %w", tx.ID(), err)
+			}
+			return struct{}{}, nil
+		},
 	)
-	var issueTxErr error
-	for i := 0; i < repeats; i++ {
-		ctx, cancel := utilsSDK.GetAPILargeContext()
-		defer cancel()
-		options := []commonOdysseyGo.Option{commonOdysseyGo.WithContext(ctx)}
-		if !waitForTxAcceptance {
-			options = append(options, commonOdysseyGo.WithAssumeDecided())
-		}
-		// TODO: split error checking and recovery between issuing and waiting for status
-		issueTxErr = wallet.O().IssueTx(tx, options...)
-		if issueTxErr == nil {
-			break
-		}
-		if ctx.Err() != nil {
-			issueTxErr = fmt.Errorf("timeout issuing/verifying tx with ID %s: %w", tx.ID(), issueTxErr)
-		} else {
-			issueTxErr = fmt.Errorf("error issuing tx with ID %s: %w", tx.ID(), issueTxErr)
-		}
-		time.Sleep(sleepBetweenRepeats)
-	}
 	if issueTxErr != nil {
 		return ids.Empty, fmt.Errorf("issue tx error %w", issueTxErr)
 	}
 	if _, ok := ms.OChainTx.Unsigned.(*txs.CreateSubnetTx); ok {
 		c.SubnetID = tx.ID()
 	}
-	return tx.ID(), issueTxErr
+	return tx.ID(), nil
 }
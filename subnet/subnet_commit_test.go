@@ -11,331 +11,136 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"github.com/DioneProtocol/odyssey-tooling-sdk-go/keychain"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/multisig"
-	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odysseytest"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/validator"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
 	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/utils/units"
 	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
 	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
 	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
 )
 
-// createTestWalletForCommit creates a test wallet for commit testing
-func createTestWalletForCommit(t *testing.T) wallet.Wallet {
-	ctx := context.Background()
-	network := odyssey.TestnetNetwork()
-
-	tempKeyPath := t.TempDir() + "/test.pk"
-
-	keychain, err := keychain.NewKeychain(network, tempKeyPath, nil)
+// newCommitTestKey returns a keychain of a new key and its address
+func newCommitTestKey(t *testing.T) (*secp256k1fx.Keychain, ids.ShortID) {
+	kc, err := odysseytest.NewKeychain(1)
 	require.NoError(t, err)
-	require.NotNil(t, keychain)
+	return kc, kc.Addresses().List()[0]
+}
 
-	testWallet, err := wallet.New(
-		ctx,
-		&primary.WalletConfig{
-			URI:              network.Endpoint,
-			DIONEKeychain:    keychain.Keychain,
-			EthKeychain:      secp256k1fx.NewKeychain(),
-			OChainTxsToFetch: nil,
-		},
-	)
+// newCommitTestWallet returns a wallet of kc on srv, its key being funded with 5 DIONE, and
+// knowing the owners of subnetIDs
+func newCommitTestWallet(t *testing.T, srv *odysseytest.Server, kc *secp256k1fx.Keychain, subnetIDs ...ids.ID) wallet.Wallet {
+	srv.AddUTXOs(odysseytest.NewUTXO(srv.DIONEAssetID(), 5*units.Dione, odysseytest.Owners(1, kc.Addresses().List()[0])))
+	testWallet, err := wallet.New(context.Background(), &primary.WalletConfig{
+		URI:              srv.URI(),
+		DIONEKeychain:    kc,
+		EthKeychain:      secp256k1fx.NewKeychain(),
+		OChainTxsToFetch: set.Of(subnetIDs...),
+	})
 	require.NoError(t, err)
-	require.NotNil(t, testWallet)
-
 	return testWallet
 }
 
-// TestCommit_UndefinedMultisig tests Commit with undefined multisig
 func TestCommit_UndefinedMultisig(t *testing.T) {
+	srv := odysseytest.NewServer()
+	defer srv.Close()
+	kc, _ := newCommitTestKey(t)
+	testWallet := newCommitTestWallet(t, srv, kc)
 	subnet := createTestSubnet(t)
-	testWallet := createTestWalletForCommit(t)
-
-	// Test with undefined multisig
-	undefinedMultisig := multisig.Multisig{}
-	assert.True(t, undefinedMultisig.Undefined())
-
-	txID, err := subnet.Commit(undefinedMultisig, testWallet, false)
-	assert.Equal(t, ids.Empty, txID)
-	assert.Error(t, err)
-	assert.Equal(t, multisig.ErrUndefinedTx, err)
-}
-
-// TestCommit_NotReadyToCommit tests Commit when multisig is not ready
-func TestCommit_NotReadyToCommit(t *testing.T) {
-	subnet := createTestSubnet(t)
-	testWallet := createTestWalletForCommit(t)
-
-	// Create a mock multisig that's defined but not ready
-	// We can't easily create a real multisig without funds, so we test the error paths
-	mockMultisig := multisig.Multisig{}
-
-	// Test undefined multisig (this is the main error path we can test)
-	txID, err := subnet.Commit(mockMultisig, testWallet, false)
-	assert.Equal(t, ids.Empty, txID)
-	assert.Error(t, err)
-	assert.Equal(t, multisig.ErrUndefinedTx, err)
-}
-
-// TestCommit_ErrorHandling tests Commit error handling
-func TestCommit_ErrorHandling(t *testing.T) {
-	subnet := createTestSubnet(t)
-	testWallet := createTestWalletForCommit(t)
-
-	// Test all error conditions we can trigger without network calls
 
-	// 1. Test undefined multisig
-	undefinedMultisig := multisig.Multisig{}
-	txID, err := subnet.Commit(undefinedMultisig, testWallet, false)
-	assert.Equal(t, ids.Empty, txID)
-	assert.Error(t, err)
-	assert.Equal(t, multisig.ErrUndefinedTx, err)
-
-	// 2. Test with zero-value multisig (should be undefined)
-	zeroMultisig := multisig.Multisig{}
-	txID, err = subnet.Commit(zeroMultisig, testWallet, false)
-	assert.Equal(t, ids.Empty, txID)
-	assert.Error(t, err)
-	assert.Equal(t, multisig.ErrUndefinedTx, err)
-}
-
-// TestCommit_ValidationLogic tests the validation logic in Commit
-func TestCommit_ValidationLogic(t *testing.T) {
-	// Test the validation logic for Commit function
-	// This tests the conditions that would cause errors without actually calling the function
-
-	// Test undefined multisig
-	multisigUndefined := true
-	if multisigUndefined {
-		// This would return multisig.ErrUndefinedTx
-		assert.True(t, multisigUndefined)
+	for _, waitForTxAcceptance := range []bool{true, false} {
+		txID, err := subnet.Commit(multisig.Multisig{}, testWallet, waitForTxAcceptance)
+		require.ErrorIs(t, err, multisig.ErrUndefinedTx)
+		assert.Equal(t, ids.Empty, txID)
 	}
-
-	// Test not ready to commit
-	notReady := false
-	if !notReady {
-		// This would return "tx is not fully signed so can't be committed"
-		assert.False(t, notReady)
-	}
-}
-
-// TestCommit_WithWaitForTxAcceptance tests Commit with different waitForTxAcceptance values
-func TestCommit_WithWaitForTxAcceptance(t *testing.T) {
-	subnet := createTestSubnet(t)
-	testWallet := createTestWalletForCommit(t)
-
-	// Test with waitForTxAcceptance = true
-	undefinedMultisig := multisig.Multisig{}
-	txID, err := subnet.Commit(undefinedMultisig, testWallet, true)
-	assert.Equal(t, ids.Empty, txID)
-	assert.Error(t, err)
-	assert.Equal(t, multisig.ErrUndefinedTx, err)
-
-	// Test with waitForTxAcceptance = false
-	txID, err = subnet.Commit(undefinedMultisig, testWallet, false)
-	assert.Equal(t, ids.Empty, txID)
-	assert.Error(t, err)
-	assert.Equal(t, multisig.ErrUndefinedTx, err)
-}
-
-// TestCommit_SubnetIDUpdate tests that Commit updates SubnetID for CreateSubnetTx
-func TestCommit_SubnetIDUpdate(t *testing.T) {
-	subnet := createTestSubnet(t)
-	testWallet := createTestWalletForCommit(t)
-
-	// Test that Commit would update SubnetID if it were successful
-	// We can't test the actual update without a real transaction, but we can test the logic
-
-	// Verify initial SubnetID is empty
 	assert.Equal(t, ids.Empty, subnet.SubnetID)
-
-	// Test with undefined multisig (should not update SubnetID)
-	undefinedMultisig := multisig.Multisig{}
-	txID, err := subnet.Commit(undefinedMultisig, testWallet, false)
-	assert.Equal(t, ids.Empty, txID)
-	assert.Error(t, err)
-	assert.Equal(t, ids.Empty, subnet.SubnetID) // Should remain empty
+	assert.Zero(t, srv.Calls("omega.issueTx"))
 }
 
-// TestCommit_NetworkIntegration tests Commit network integration (without actual network calls)
-func TestCommit_NetworkIntegration(t *testing.T) {
-	subnet := createTestSubnet(t)
-	testWallet := createTestWalletForCommit(t)
-
-	// Test that Commit would attempt network integration if multisig were ready
-	// We can't test actual network calls without funds, but we can test the error paths
-
-	undefinedMultisig := multisig.Multisig{}
-	txID, err := subnet.Commit(undefinedMultisig, testWallet, false)
-	assert.Equal(t, ids.Empty, txID)
-	assert.Error(t, err)
-	assert.Equal(t, multisig.ErrUndefinedTx, err)
-}
-
-// TestCommit_RetryLogic tests Commit retry logic (without actual retries)
-func TestCommit_RetryLogic(t *testing.T) {
-	subnet := createTestSubnet(t)
-	testWallet := createTestWalletForCommit(t)
-
-	// Test that Commit would retry on network errors if multisig were ready
-	// We can't test actual retries without network calls, but we can test the error paths
-
-	undefinedMultisig := multisig.Multisig{}
-	txID, err := subnet.Commit(undefinedMultisig, testWallet, false)
-	assert.Equal(t, ids.Empty, txID)
-	assert.Error(t, err)
-	assert.Equal(t, multisig.ErrUndefinedTx, err)
-}
-
-// TestCommit_ContextHandling tests Commit context handling
-func TestCommit_ContextHandling(t *testing.T) {
-	addTestDelay() // Add delay to avoid rate limiting
-	subnet := createTestSubnet(t)
-	testWallet := createTestWalletForCommit(t)
+func TestCommit_CreateSubnetTx(t *testing.T) {
+	for _, waitForTxAcceptance := range []bool{true, false} {
+		srv := odysseytest.NewServer()
+		defer srv.Close()
+		kc, addr := newCommitTestKey(t)
+		testWallet := newCommitTestWallet(t, srv, kc)
+		subnet := createTestSubnet(t)
+		subnet.SetSubnetControlParams([]ids.ShortID{addr}, 1)
 
-	// Test that Commit would handle context properly if multisig were ready
-	// We can't test actual context handling without network calls, but we can test the error paths
-
-	undefinedMultisig := multisig.Multisig{}
-	txID, err := subnet.Commit(undefinedMultisig, testWallet, false)
-	assert.Equal(t, ids.Empty, txID)
-	assert.Error(t, err)
-	assert.Equal(t, multisig.ErrUndefinedTx, err)
-}
-
-// TestCommit_TransactionTypes tests Commit with different transaction types
-func TestCommit_TransactionTypes(t *testing.T) {
-	subnet := createTestSubnet(t)
-	testWallet := createTestWalletForCommit(t)
-
-	// Test that Commit would handle different transaction types if multisig were ready
-	// We can't test actual transaction types without real transactions, but we can test the error paths
-
-	undefinedMultisig := multisig.Multisig{}
-	txID, err := subnet.Commit(undefinedMultisig, testWallet, false)
-	assert.Equal(t, ids.Empty, txID)
-	assert.Error(t, err)
-	assert.Equal(t, multisig.ErrUndefinedTx, err)
-}
-
-// TestCommit_EdgeCases tests Commit edge cases
-func TestCommit_EdgeCases(t *testing.T) {
-	subnet := createTestSubnet(t)
-	testWallet := createTestWalletForCommit(t)
-
-	// Test various edge cases for Commit
-
-	// 1. Test with zero-value multisig
-	zeroMultisig := multisig.Multisig{}
-	txID, err := subnet.Commit(zeroMultisig, testWallet, false)
-	assert.Equal(t, ids.Empty, txID)
-	assert.Error(t, err)
-	assert.Equal(t, multisig.ErrUndefinedTx, err)
-}
-
-// TestCommit_ComprehensiveErrorPaths tests all error paths in Commit
-func TestCommit_ComprehensiveErrorPaths(t *testing.T) {
-	subnet := createTestSubnet(t)
-	testWallet := createTestWalletForCommit(t)
-
-	// Test all possible error paths in Commit function
-
-	// 1. Undefined multisig
-	undefinedMultisig := multisig.Multisig{}
-	txID, err := subnet.Commit(undefinedMultisig, testWallet, false)
-	assert.Equal(t, ids.Empty, txID)
-	assert.Error(t, err)
-	assert.Equal(t, multisig.ErrUndefinedTx, err)
-
-	// 2. Test that IsReadyToCommit would be called if multisig were defined
-	// We can't test this without a real multisig, but we can verify the error path
-
-	// 3. Test that GetWrappedOChainTx would be called if multisig were ready
-	// We can't test this without a real multisig, but we can verify the error path
-
-	// 4. Test that network submission would be attempted if transaction were ready
-	// We can't test this without funds, but we can verify the error path
-}
-
-// TestCommit_IntegrationWithOtherFunctions tests Commit integration with other functions
-func TestCommit_IntegrationWithOtherFunctions(t *testing.T) {
-	addTestDelay() // Add delay to avoid rate limiting
-	subnet := createTestSubnet(t)
-	testWallet := createTestWalletForCommit(t)
-
-	// Test that Commit integrates properly with other subnet functions
-
-	// 1. Test that CreateSubnetTx would create a multisig that could be committed
-	createSubnetMultisig, err := subnet.CreateSubnetTx(testWallet)
-	if err != nil {
-		// Expected due to insufficient funds
-		assert.Contains(t, err.Error(), "insufficient funds")
-		assert.Nil(t, createSubnetMultisig)
-	} else {
-		// If we get here, we have a real multisig
-		require.NotNil(t, createSubnetMultisig)
-		assert.False(t, createSubnetMultisig.Undefined())
-
-		// Test that this multisig could be committed (without actually committing)
-		tx, err := createSubnetMultisig.GetWrappedOChainTx()
+		ms, err := subnet.CreateSubnetTx(testWallet)
+		require.NoError(t, err)
+		txID, err := subnet.Commit(*ms, testWallet, waitForTxAcceptance)
 		require.NoError(t, err)
-		require.NotNil(t, tx)
 
-		// Verify it's a CreateSubnetTx
-		_, ok := tx.Unsigned.(*txs.CreateSubnetTx)
-		assert.True(t, ok)
+		// the subnet ID is the ID of its CreateSubnetTx
+		require.Len(t, srv.IssuedTxs(), 1)
+		assert.Equal(t, txID, srv.IssuedTxs()[0].ID())
+		assert.IsType(t, &txs.CreateSubnetTx{}, srv.IssuedTxs()[0].Unsigned)
+		assert.Equal(t, txID, subnet.SubnetID)
+		controlKeys, threshold, err := multisig.GetOwners(srv.Network(), subnet.SubnetID)
+		require.NoError(t, err)
+		assert.Equal(t, []ids.ShortID{addr}, controlKeys)
+		assert.Equal(t, uint32(1), threshold)
 	}
+}
 
-	// 2. Test that CreateBlockchainTx would create a multisig that could be committed
-	subnetID := ids.GenerateTestID()
+func TestCommit_SubnetTxs(t *testing.T) {
+	srv := odysseytest.NewServer()
+	defer srv.Close()
+	kc, addr := newCommitTestKey(t)
+	subnetID, err := srv.AddSubnet(odysseytest.Owners(1, addr))
+	require.NoError(t, err)
+	testWallet := newCommitTestWallet(t, srv, kc, subnetID)
+	subnet := createTestSubnet(t)
 	subnet.SetSubnetID(subnetID)
+	subnet.SetSubnetAuthKeys([]ids.ShortID{addr})
 
-	createBlockchainMultisig, err := subnet.CreateBlockchainTx(testWallet)
-	if err != nil {
-		// Expected due to insufficient funds
-		assert.Contains(t, err.Error(), "insufficient funds")
-		assert.Nil(t, createBlockchainMultisig)
-	} else {
-		// If we get here, we have a real multisig
-		require.NotNil(t, createBlockchainMultisig)
-		assert.False(t, createBlockchainMultisig.Undefined())
-
-		// Test that this multisig could be committed (without actually committing)
-		tx, err := createBlockchainMultisig.GetWrappedOChainTx()
-		require.NoError(t, err)
-		require.NotNil(t, tx)
-
-		// Verify it's a CreateChainTx
-		_, ok := tx.Unsigned.(*txs.CreateChainTx)
-		assert.True(t, ok)
-	}
+	chainMultisig, err := subnet.CreateBlockchainTx(testWallet)
+	require.NoError(t, err)
+	// the owners of the subnet are fetched from the server
+	chainMultisig.Network = srv.Network()
+	chainTxID, err := subnet.Commit(*chainMultisig, testWallet, true)
+	require.NoError(t, err)
 
-	// 3. Test that AddValidator would create a multisig that could be committed
-	validatorParams := validator.SubnetValidatorParams{
+	validatorMultisig, err := subnet.AddValidator(testWallet, validator.SubnetValidatorParams{
 		NodeID:   ids.GenerateTestNodeID(),
 		Duration: time.Hour,
-		Weight:   20,
-	}
+	})
+	require.NoError(t, err)
+	validatorMultisig.Network = srv.Network()
+	validatorTxID, err := subnet.Commit(*validatorMultisig, testWallet, false)
+	require.NoError(t, err)
 
-	addValidatorMultisig, err := subnet.AddValidator(testWallet, validatorParams)
-	if err != nil {
-		// Expected due to insufficient funds
-		assert.Contains(t, err.Error(), "insufficient funds")
-		assert.Nil(t, addValidatorMultisig)
-	} else {
-		// If we get here, we have a real multisig
-		require.NotNil(t, addValidatorMultisig)
-		assert.False(t, addValidatorMultisig.Undefined())
+	// the subnet ID is only set by CreateSubnetTxs
+	assert.Equal(t, subnetID, subnet.SubnetID)
+	issued := srv.IssuedTxs()
+	require.Len(t, issued, 2)
+	assert.Equal(t, chainTxID, issued[0].ID())
+	assert.IsType(t, &txs.CreateChainTx{}, issued[0].Unsigned)
+	assert.Equal(t, validatorTxID, issued[1].ID())
+	assert.IsType(t, &txs.AddSubnetValidatorTx{}, issued[1].Unsigned)
+}
 
-		// Test that this multisig could be committed (without actually committing)
-		tx, err := addValidatorMultisig.GetWrappedOChainTx()
-		require.NoError(t, err)
-		require.NotNil(t, tx)
+func TestCommit_ThresholdNotMet(t *testing.T) {
+	srv := odysseytest.NewServer()
+	defer srv.Close()
+	kc, addr := newCommitTestKey(t)
+	cosigner := ids.GenerateTestShortID()
+	subnetID, err := srv.AddSubnet(odysseytest.Owners(2, addr, cosigner))
+	require.NoError(t, err)
+	testWallet := newCommitTestWallet(t, srv, kc, subnetID)
+	subnet := createTestSubnet(t)
+	subnet.SetSubnetID(subnetID)
+	subnet.SetSubnetAuthKeys([]ids.ShortID{addr, cosigner})
 
-		// Verify it's an AddSubnetValidatorTx
-		_, ok := tx.Unsigned.(*txs.AddSubnetValidatorTx)
-		assert.True(t, ok)
-	}
+	// the tx lacks the signature of the cosigner
+	ms, err := subnet.CreateBlockchainTx(testWallet)
+	require.NoError(t, err)
+	ms.Network = srv.Network()
+	txID, err := subnet.Commit(*ms, testWallet, true)
+	require.ErrorIs(t, err, multisig.ErrThresholdNotMet)
+	assert.Equal(t, ids.Empty, txID)
+	assert.Zero(t, srv.Calls("omega.issueTx"))
 }
@@ -12,7 +12,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/addressbook"
 	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/formatting/address"
 	"github.com/DioneProtocol/subnet-evm/commontype"
 	"github.com/DioneProtocol/subnet-evm/core"
 	"github.com/DioneProtocol/subnet-evm/params"
@@ -297,6 +299,28 @@ func TestSubnet_SetSubnetAuthKeys(t *testing.T) {
 	assert.Equal(t, subnetAuthKeys, subnet.DeployInfo.SubnetAuthKeys)
 }
 
+func TestSubnet_SetKeysFromBook(t *testing.T) {
+	aliceID := ids.GenerateTestShortID()
+	bobID := ids.GenerateTestShortID()
+	aliceAddr, err := address.Format("O", "testnet", aliceID[:])
+	require.NoError(t, err)
+	bobAddr, err := address.Format("O", "testnet", bobID[:])
+	require.NoError(t, err)
+	book := addressbook.New()
+	require.NoError(t, book.Add(addressbook.Entry{Label: "alice", OChainAddress: aliceAddr}))
+
+	subnet := &Subnet{}
+	require.NoError(t, subnet.SetSubnetControlParamsFromBook(book, []string{"alice", bobAddr}, 2))
+	assert.Equal(t, []ids.ShortID{aliceID, bobID}, subnet.DeployInfo.ControlKeys)
+	assert.Equal(t, uint32(2), subnet.DeployInfo.Threshold)
+	require.NoError(t, subnet.SetSubnetAuthKeysFromBook(nil, []string{bobAddr}))
+	assert.Equal(t, []ids.ShortID{bobID}, subnet.DeployInfo.SubnetAuthKeys)
+
+	err = subnet.SetSubnetAuthKeysFromBook(book, []string{"carol"})
+	assert.ErrorContains(t, err, "invalid subnet auth keys")
+	assert.Equal(t, []ids.ShortID{bobID}, subnet.DeployInfo.SubnetAuthKeys)
+}
+
 func TestSubnet_SetSubnetID(t *testing.T) {
 	subnet := &Subnet{}
 	subnetID := ids.GenerateTestID()
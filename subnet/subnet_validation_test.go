@@ -7,8 +7,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/progress"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/validator"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/vm"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
 	"github.com/DioneProtocol/odysseygo/ids"
 )
 
@@ -255,3 +259,122 @@ func TestSubnet_Commit_ValidationLogic(t *testing.T) {
 		assert.False(t, notReady)
 	}
 }
+
+func TestSubnet_SentinelErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		subnet      *Subnet
+		create      func(*Subnet) error
+		expectedErr error
+	}{
+		{
+			name:   "CreateSubnetTx without control keys",
+			subnet: &Subnet{},
+			create: func(s *Subnet) error {
+				_, err := s.CreateSubnetTx(wallet.Wallet{})
+				return err
+			},
+			expectedErr: ErrEmptyControlKeys,
+		},
+		{
+			name: "CreateSubnetTx without threshold",
+			subnet: &Subnet{
+				DeployInfo: DeployParams{ControlKeys: []ids.ShortID{ids.GenerateTestShortID()}},
+			},
+			create: func(s *Subnet) error {
+				_, err := s.CreateSubnetTx(wallet.Wallet{})
+				return err
+			},
+			expectedErr: ErrEmptyThreshold,
+		},
+		{
+			name:   "CreateBlockchainTx without subnet ID",
+			subnet: &Subnet{},
+			create: func(s *Subnet) error {
+				_, err := s.CreateBlockchainTx(wallet.Wallet{})
+				return err
+			},
+			expectedErr: ErrEmptySubnetID,
+		},
+		{
+			name: "CreateBlockchainTx without genesis",
+			subnet: &Subnet{
+				SubnetID:   ids.GenerateTestID(),
+				VMID:       ids.GenerateTestID(),
+				Name:       "test",
+				DeployInfo: DeployParams{SubnetAuthKeys: []ids.ShortID{ids.GenerateTestShortID()}},
+			},
+			create: func(s *Subnet) error {
+				_, err := s.CreateBlockchainTx(wallet.Wallet{})
+				return err
+			},
+			expectedErr: ErrEmptyGenesis,
+		},
+		{
+			name: "CreateBlockchainTx without VM ID",
+			subnet: &Subnet{
+				SubnetID:   ids.GenerateTestID(),
+				Genesis:    []byte("test genesis"),
+				DeployInfo: DeployParams{SubnetAuthKeys: []ids.ShortID{ids.GenerateTestShortID()}},
+			},
+			create: func(s *Subnet) error {
+				_, err := s.CreateBlockchainTx(wallet.Wallet{})
+				return err
+			},
+			expectedErr: ErrEmptyVMID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.create(tt.subnet), tt.expectedErr)
+		})
+	}
+}
+
+func TestSubnet_Deploy_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		subnet      *Subnet
+		expectedErr error
+	}{
+		{
+			name:        "nil control keys",
+			subnet:      &Subnet{DeployInfo: DeployParams{Threshold: 1}},
+			expectedErr: ErrEmptyControlKeys,
+		},
+		{
+			name:        "zero threshold",
+			subnet:      &Subnet{DeployInfo: DeployParams{ControlKeys: []ids.ShortID{ids.GenerateTestShortID()}}},
+			expectedErr: ErrEmptyThreshold,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := []progress.Event{}
+			blockchainID, err := tt.subnet.Deploy(wallet.Wallet{}, func(e progress.Event) { events = append(events, e) })
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, ids.Empty, blockchainID)
+			// only the build of the subnet tx is reported
+			assert.Len(t, events, 1)
+			assert.Equal(t, progress.StageBuildTx, events[0].Stage)
+			assert.Equal(t, float64(0), events[0].Percent)
+		})
+	}
+}
+
+func TestNew_IncompatibleSubnetEVMVersion(t *testing.T) {
+	_, err := New(&SubnetParams{
+		Name: "test",
+		SubnetEVM: &SubnetEVMParams{
+			Version:          "v0.5.3",
+			OdysseyGoVersion: "v1.10.9",
+		},
+	})
+	require.ErrorIs(t, err, vm.ErrIncompatibleVMVersion)
+
+	versions, err := CompatibleVMVersions("v1.10.9")
+	require.NoError(t, err)
+	require.Contains(t, versions, "v0.5.6")
+}
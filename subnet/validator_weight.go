@@ -0,0 +1,227 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/multisig"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+)
+
+// DefaultQuorumFraction is the share of the subnet weight the other validators must hold while
+// a validator is removed to change its weight
+const DefaultQuorumFraction = 2.0 / 3
+
+var (
+	ErrEmptyValidatorWeight     = errors.New("validator weight is not provided")
+	ErrValidatorNotFound        = errors.New("node is not a current validator of the subnet")
+	ErrValidatorWeightUnchanged = errors.New("validator already has the requested weight")
+	ErrWeightBelowQuorum        = errors.New("removing the validator drops the subnet weight below quorum")
+	ErrInvalidQuorumFraction    = errors.New("quorum fraction must be between 0 and 1")
+	ErrValidatorEndTooClose     = errors.New("validator ends before it can be re-added")
+	ErrWeightChangeNotRemoved   = errors.New("validator is still in the subnet validator set")
+)
+
+// ValidatorWeightChange is the coordinated change of the weight of a subnet validator. The
+// O-Chain has no tx setting the weight of a validator, so the validator is removed, then
+// re-added with the new weight until its current end time:
+//   - Remove is signed by the subnet auth keys, e.g. with multisig files, and committed
+//   - CompleteValidatorWeightChange then builds the tx re-adding the validator, which is signed
+//     and committed the same way
+//
+// The validator does not validate the subnet between the two commits
+type ValidatorWeightChange struct {
+	NodeID    ids.NodeID
+	OldWeight uint64
+	NewWeight uint64
+
+	// End is the end time of the validator, kept when re-adding it
+	End time.Time
+
+	// SubnetWeight is the total weight of the subnet validators before the change
+	SubnetWeight uint64
+
+	// Remove is the RemoveSubnetValidatorTx to commit first
+	Remove *multisig.Multisig
+}
+
+// WeightChangeOp holds the options of SetValidatorWeight
+type WeightChangeOp struct {
+	quorumFraction float64
+}
+
+// WeightChangeOption configures SetValidatorWeight
+type WeightChangeOption func(*WeightChangeOp)
+
+// WithQuorumFraction sets the share of the subnet weight the other validators must hold while
+// the validator is removed, DefaultQuorumFraction by default. With zero, only the removal of
+// the last validator is refused
+func WithQuorumFraction(fraction float64) WeightChangeOption {
+	return func(op *WeightChangeOp) {
+		op.quorumFraction = fraction
+	}
+}
+
+// getSubnetValidators queries the current validators of subnetID from the O-Chain API of uri
+var getSubnetValidators = func(uri string, subnetID ids.ID) ([]omegavm.ClientPermissionlessValidator, error) {
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	return omegavm.NewClient(uri).GetCurrentValidators(ctx, subnetID, nil)
+}
+
+// SetValidatorWeight starts changing the weight of the subnet validator nodeID to newWeight,
+// returning the change with its RemoveSubnetValidatorTx to sign and commit. The change is
+// refused if the other validators would hold less than the quorum fraction of the subnet
+// weight while the validator is removed. See ValidatorWeightChange for the whole workflow
+func (c *Subnet) SetValidatorWeight(
+	wallet wallet.Wallet,
+	nodeID ids.NodeID,
+	newWeight uint64,
+	opts ...WeightChangeOption,
+) (*ValidatorWeightChange, error) {
+	op := &WeightChangeOp{quorumFraction: DefaultQuorumFraction}
+	for _, opt := range opts {
+		opt(op)
+	}
+	if err := c.checkWeightChangeParams(nodeID, newWeight, op.quorumFraction); err != nil {
+		return nil, err
+	}
+	memoOptions, err := c.memoOptions(nil)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := getSubnetValidators(wallet.URI(), c.SubnetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the validators of subnet %s: %w", c.SubnetID, err)
+	}
+	change, err := planWeightChange(validators, nodeID, newWeight, op.quorumFraction, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	wallet.SetSubnetAuthMultisig(c.DeployInfo.SubnetAuthKeys)
+	unsignedTx, err := wallet.O().Builder().NewRemoveSubnetValidatorTx(nodeID, c.SubnetID, memoOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("error building tx: %w", err)
+	}
+	tx := txs.Tx{Unsigned: unsignedTx}
+	if err := wallet.O().Signer().Sign(context.Background(), &tx); err != nil {
+		return nil, fmt.Errorf("error signing tx: %w", err)
+	}
+	change.Remove = multisig.New(&tx)
+	return change, nil
+}
+
+// CompleteValidatorWeightChange builds the AddSubnetValidatorTx re-adding the validator of
+// change with its new weight, once change.Remove is committed
+func (c *Subnet) CompleteValidatorWeightChange(wallet wallet.Wallet, change *ValidatorWeightChange) (*multisig.Multisig, error) {
+	if c.SubnetID == ids.Empty {
+		return nil, ErrEmptySubnetID
+	}
+	if len(c.DeployInfo.SubnetAuthKeys) == 0 {
+		return nil, ErrEmptySubnetAuth
+	}
+	memoOptions, err := c.memoOptions(nil)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := getSubnetValidators(wallet.URI(), c.SubnetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the validators of subnet %s: %w", c.SubnetID, err)
+	}
+	for _, v := range validators {
+		if v.NodeID == change.NodeID {
+			return nil, fmt.Errorf("%w: %s", ErrWeightChangeNotRemoved, change.NodeID)
+		}
+	}
+	if !change.End.After(time.Now()) {
+		return nil, fmt.Errorf("%w: %s", ErrValidatorEndTooClose, change.End)
+	}
+
+	wallet.SetSubnetAuthMultisig(c.DeployInfo.SubnetAuthKeys)
+	unsignedTx, err := wallet.O().Builder().NewAddSubnetValidatorTx(&txs.SubnetValidator{
+		Validator: txs.Validator{
+			NodeID: change.NodeID,
+			End:    uint64(change.End.Unix()),
+			Wght:   change.NewWeight,
+		},
+		Subnet: c.SubnetID,
+	}, memoOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("error building tx: %w", err)
+	}
+	tx := txs.Tx{Unsigned: unsignedTx}
+	if err := wallet.O().Signer().Sign(context.Background(), &tx); err != nil {
+		return nil, fmt.Errorf("error signing tx: %w", err)
+	}
+	return multisig.New(&tx), nil
+}
+
+func (c *Subnet) checkWeightChangeParams(nodeID ids.NodeID, newWeight uint64, quorumFraction float64) error {
+	if nodeID == ids.EmptyNodeID {
+		return ErrEmptyValidatorNodeID
+	}
+	if newWeight == 0 {
+		return ErrEmptyValidatorWeight
+	}
+	if quorumFraction < 0 || quorumFraction > 1 {
+		return fmt.Errorf("%w: %f", ErrInvalidQuorumFraction, quorumFraction)
+	}
+	if c.SubnetID == ids.Empty {
+		return ErrEmptySubnetID
+	}
+	if len(c.DeployInfo.SubnetAuthKeys) == 0 {
+		return ErrEmptySubnetAuth
+	}
+	return nil
+}
+
+// planWeightChange checks the change of the weight of nodeID among the current validators of
+// a subnet at now
+func planWeightChange(
+	validators []omegavm.ClientPermissionlessValidator,
+	nodeID ids.NodeID,
+	newWeight uint64,
+	quorumFraction float64,
+	now time.Time,
+) (*ValidatorWeightChange, error) {
+	change := &ValidatorWeightChange{NodeID: nodeID, NewWeight: newWeight}
+	found := false
+	for _, v := range validators {
+		change.SubnetWeight += v.Weight
+		if v.NodeID == nodeID {
+			found = true
+			change.OldWeight = v.Weight
+			change.End = time.Unix(int64(v.EndTime), 0)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrValidatorNotFound, nodeID)
+	}
+	if change.OldWeight == newWeight {
+		return nil, fmt.Errorf("%w: %d", ErrValidatorWeightUnchanged, newWeight)
+	}
+	if !change.End.After(now) {
+		return nil, fmt.Errorf("%w: %s", ErrValidatorEndTooClose, change.End)
+	}
+	remainingWeight := change.SubnetWeight - change.OldWeight
+	if remainingWeight == 0 || float64(remainingWeight) < quorumFraction*float64(change.SubnetWeight) {
+		return nil, fmt.Errorf(
+			"%w: other validators hold %d of %d, quorum is %.2f",
+			ErrWeightBelowQuorum,
+			remainingWeight,
+			change.SubnetWeight,
+			quorumFraction,
+		)
+	}
+	return change, nil
+}
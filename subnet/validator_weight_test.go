@@ -0,0 +1,101 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+)
+
+func testSubnetValidator(nodeID ids.NodeID, weight uint64, end time.Time) omegavm.ClientPermissionlessValidator {
+	return omegavm.ClientPermissionlessValidator{
+		ClientStaker: omegavm.ClientStaker{NodeID: nodeID, Weight: weight, EndTime: uint64(end.Unix())},
+	}
+}
+
+func TestPlanWeightChange(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	end := now.Add(24 * time.Hour)
+	nodeA, nodeB, nodeC := ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	validators := []omegavm.ClientPermissionlessValidator{
+		testSubnetValidator(nodeA, 20, end),
+		testSubnetValidator(nodeB, 20, end),
+		testSubnetValidator(nodeC, 20, end),
+	}
+	tests := []struct {
+		name           string
+		validators     []omegavm.ClientPermissionlessValidator
+		nodeID         ids.NodeID
+		newWeight      uint64
+		quorumFraction float64
+		expectedErr    error
+	}{
+		{name: "within quorum", validators: validators, nodeID: nodeA, newWeight: 40, quorumFraction: DefaultQuorumFraction},
+		{name: "not a validator", validators: validators, nodeID: ids.GenerateTestNodeID(), newWeight: 40, expectedErr: ErrValidatorNotFound},
+		{name: "unchanged", validators: validators, nodeID: nodeA, newWeight: 20, expectedErr: ErrValidatorWeightUnchanged},
+		{
+			name:           "below quorum",
+			validators:     validators[:2],
+			nodeID:         nodeA,
+			newWeight:      40,
+			quorumFraction: DefaultQuorumFraction,
+			expectedErr:    ErrWeightBelowQuorum,
+		},
+		{name: "below quorum allowed", validators: validators[:2], nodeID: nodeA, newWeight: 40, quorumFraction: 0.5},
+		{name: "last validator", validators: validators[:1], nodeID: nodeA, newWeight: 40, expectedErr: ErrWeightBelowQuorum},
+		{
+			name:        "validator ended",
+			validators:  []omegavm.ClientPermissionlessValidator{testSubnetValidator(nodeA, 20, now), testSubnetValidator(nodeB, 20, end)},
+			nodeID:      nodeA,
+			newWeight:   40,
+			expectedErr: ErrValidatorEndTooClose,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change, err := planWeightChange(tt.validators, tt.nodeID, tt.newWeight, tt.quorumFraction, now)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, uint64(20), change.OldWeight)
+			assert.Equal(t, tt.newWeight, change.NewWeight)
+			assert.Equal(t, end, change.End)
+			assert.Equal(t, uint64(20*len(tt.validators)), change.SubnetWeight)
+		})
+	}
+}
+
+func TestSubnet_SetValidatorWeight_Params(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+	subnet := &Subnet{SubnetID: ids.GenerateTestID(), DeployInfo: DeployParams{SubnetAuthKeys: []ids.ShortID{ids.GenerateTestShortID()}}}
+	_, err := subnet.SetValidatorWeight(wallet.Wallet{}, ids.EmptyNodeID, 40)
+	require.ErrorIs(t, err, ErrEmptyValidatorNodeID)
+	_, err = subnet.SetValidatorWeight(wallet.Wallet{}, nodeID, 0)
+	require.ErrorIs(t, err, ErrEmptyValidatorWeight)
+	_, err = subnet.SetValidatorWeight(wallet.Wallet{}, nodeID, 40, WithQuorumFraction(1.5))
+	require.ErrorIs(t, err, ErrInvalidQuorumFraction)
+	_, err = (&Subnet{}).SetValidatorWeight(wallet.Wallet{}, nodeID, 40)
+	require.ErrorIs(t, err, ErrEmptySubnetID)
+
+	original := getSubnetValidators
+	t.Cleanup(func() { getSubnetValidators = original })
+	getSubnetValidators = func(string, ids.ID) ([]omegavm.ClientPermissionlessValidator, error) {
+		return []omegavm.ClientPermissionlessValidator{testSubnetValidator(nodeID, 20, time.Now().Add(time.Hour))}, nil
+	}
+	_, err = subnet.SetValidatorWeight(wallet.Wallet{}, nodeID, 40)
+	require.ErrorIs(t, err, ErrWeightBelowQuorum)
+
+	// the validator must be removed before being re-added
+	_, err = subnet.CompleteValidatorWeightChange(wallet.Wallet{}, &ValidatorWeightChange{NodeID: nodeID, NewWeight: 40})
+	require.ErrorIs(t, err, ErrWeightChangeNotRemoved)
+}
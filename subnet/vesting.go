@@ -0,0 +1,179 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/DioneProtocol/subnet-evm/core"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultVestingContractAddress is the genesis address of the vesting contract when
+// VestingParams.ContractAddress is not set
+var DefaultVestingContractAddress = common.HexToAddress("0x0300000000000000000000000000000000000000")
+
+const (
+	// vestingSchedulesSlot is the storage slot of the mapping(address => Schedule) of the
+	// vesting contract
+	vestingSchedulesSlot = 0
+	// vestingBeneficiariesSlot is the storage slot of the address[] of beneficiaries of the
+	// vesting contract
+	vestingBeneficiariesSlot = 1
+)
+
+var (
+	ErrEmptyVestingCode      = errors.New("vesting contract code cannot be empty")
+	ErrEmptyVestingSchedules = errors.New("vesting schedules cannot be empty")
+	ErrInvalidVestingPlan    = errors.New("invalid vesting schedule")
+)
+
+// VestingSchedule locks Amount for Beneficiary: nothing is releasable before Start+Cliff,
+// then the amount vests linearly from Start to Start+Duration
+type VestingSchedule struct {
+	Beneficiary common.Address
+
+	// Amount is the vested balance, in wei
+	Amount *big.Int
+
+	// Start is the beginning of the vesting. The genesis timestamp is used when zero
+	Start time.Time
+
+	// Cliff is the time after Start before which nothing can be released
+	Cliff time.Duration
+
+	// Duration is the time after Start at which the whole amount is released
+	Duration time.Duration
+}
+
+// VestingParams locks genesis funds in a vesting contract deployed at block 0.
+//
+// The SDK ships no vesting contract: ContractCode is the runtime bytecode of a reviewed
+// vesting contract, whose storage is initialized in the genesis with the following layout:
+//
+//	struct Schedule {
+//		uint256 amount;
+//		uint64 start;    // unix seconds
+//		uint64 cliff;    // unix seconds, start + cliff duration
+//		uint64 duration; // seconds
+//		uint256 released;
+//	}
+//	mapping(address => Schedule) public schedules; // slot 0
+//	address[] public beneficiaries;                // slot 1
+//
+// The contract balance is the sum of the vested amounts, released to each beneficiary by the
+// contract as it vests
+type VestingParams struct {
+	// ContractAddress is the genesis address of the vesting contract.
+	// DefaultVestingContractAddress is used when zero
+	ContractAddress common.Address
+
+	// ContractCode is the runtime bytecode of the vesting contract, required
+	ContractCode []byte
+
+	Schedules []VestingSchedule
+}
+
+// Address returns the genesis address of the vesting contract
+func (p *VestingParams) Address() common.Address {
+	if p.ContractAddress == (common.Address{}) {
+		return DefaultVestingContractAddress
+	}
+	return p.ContractAddress
+}
+
+// Validate checks that the vesting contract has code and can be initialized with the schedules
+func (p *VestingParams) Validate() error {
+	if len(p.ContractCode) == 0 {
+		return ErrEmptyVestingCode
+	}
+	if len(p.Schedules) == 0 {
+		return ErrEmptyVestingSchedules
+	}
+	beneficiaries := map[common.Address]struct{}{}
+	for i, schedule := range p.Schedules {
+		if _, found := beneficiaries[schedule.Beneficiary]; found {
+			return fmt.Errorf("%w %s", ErrDuplicateAllocation, schedule.Beneficiary)
+		}
+		beneficiaries[schedule.Beneficiary] = struct{}{}
+		switch {
+		case schedule.Beneficiary == (common.Address{}):
+			return fmt.Errorf("%w %d: beneficiary cannot be empty", ErrInvalidVestingPlan, i)
+		case schedule.Amount == nil || schedule.Amount.Sign() <= 0:
+			return fmt.Errorf("%w %d: amount of %s must be positive", ErrInvalidVestingPlan, i, schedule.Beneficiary)
+		case schedule.Duration < time.Second:
+			return fmt.Errorf("%w %d: duration of %s must be at least one second", ErrInvalidVestingPlan, i, schedule.Beneficiary)
+		case schedule.Cliff < 0 || schedule.Cliff > schedule.Duration:
+			return fmt.Errorf("%w %d: cliff of %s must be between 0 and the duration", ErrInvalidVestingPlan, i, schedule.Beneficiary)
+		}
+	}
+	return nil
+}
+
+// GenesisAccount returns the genesis account of the vesting contract: its code, the storage
+// initializing the schedules, and a balance covering all of them. Schedules without start
+// begin at genesisTime
+func (p *VestingParams) GenesisAccount(genesisTime time.Time) (core.GenesisAccount, error) {
+	if err := p.Validate(); err != nil {
+		return core.GenesisAccount{}, err
+	}
+	storage := map[common.Hash]common.Hash{}
+	balance := new(big.Int)
+	beneficiariesData := crypto.Keccak256Hash(common.BigToHash(big.NewInt(vestingBeneficiariesSlot)).Bytes()).Big()
+	for i, schedule := range p.Schedules {
+		start := schedule.Start
+		if start.IsZero() {
+			start = genesisTime
+		}
+		cliff := start.Add(schedule.Cliff)
+		// start, cliff and duration are packed into a single slot, first member lowest
+		packed := new(big.Int).SetUint64(uint64(schedule.Duration / time.Second))
+		packed.Lsh(packed, 64).Or(packed, new(big.Int).SetUint64(uint64(cliff.Unix())))
+		packed.Lsh(packed, 64).Or(packed, new(big.Int).SetUint64(uint64(start.Unix())))
+
+		scheduleSlot := vestingMappingSlot(schedule.Beneficiary, vestingSchedulesSlot)
+		storage[common.BigToHash(scheduleSlot)] = common.BigToHash(schedule.Amount)
+		storage[common.BigToHash(new(big.Int).Add(scheduleSlot, big.NewInt(1)))] = common.BigToHash(packed)
+
+		elementSlot := new(big.Int).Add(beneficiariesData, big.NewInt(int64(i)))
+		storage[common.BigToHash(elementSlot)] = common.BytesToHash(schedule.Beneficiary.Bytes())
+
+		balance.Add(balance, schedule.Amount)
+	}
+	storage[common.BigToHash(big.NewInt(vestingBeneficiariesSlot))] = common.BigToHash(big.NewInt(int64(len(p.Schedules))))
+	return core.GenesisAccount{
+		Code:    p.ContractCode,
+		Storage: storage,
+		Balance: balance,
+	}, nil
+}
+
+// vestingMappingSlot returns the storage slot of key in a solidity mapping at slot
+func vestingMappingSlot(key common.Address, slot int64) *big.Int {
+	return crypto.Keccak256Hash(
+		common.BytesToHash(key.Bytes()).Bytes(),
+		common.BigToHash(big.NewInt(slot)).Bytes(),
+	).Big()
+}
+
+// withVesting returns allocation with the vesting contract account added
+func withVesting(allocation core.GenesisAlloc, vesting *VestingParams, genesisTime time.Time) (core.GenesisAlloc, error) {
+	account, err := vesting.GenesisAccount(genesisTime)
+	if err != nil {
+		return nil, err
+	}
+	withContract := core.GenesisAlloc{}
+	for addr, allocated := range allocation {
+		withContract[addr] = allocated
+	}
+	if _, found := withContract[vesting.Address()]; found {
+		return nil, fmt.Errorf("%w: vesting contract address %s", ErrDuplicateAllocation, vesting.Address())
+	}
+	withContract[vesting.Address()] = account
+	return withContract, nil
+}
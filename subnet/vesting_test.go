@@ -0,0 +1,129 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/subnet-evm/commontype"
+	"github.com/DioneProtocol/subnet-evm/core"
+	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVestingParams_Validate(t *testing.T) {
+	beneficiary := common.HexToAddress(ewoqEVMAddress)
+	valid := VestingSchedule{Beneficiary: beneficiary, Amount: big.NewInt(100), Cliff: time.Hour, Duration: 24 * time.Hour}
+	tests := []struct {
+		name        string
+		params      VestingParams
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			params: VestingParams{ContractCode: []byte{0x00}, Schedules: []VestingSchedule{valid}},
+		},
+		{
+			name:        "no code",
+			params:      VestingParams{Schedules: []VestingSchedule{valid}},
+			expectedErr: ErrEmptyVestingCode,
+		},
+		{
+			name:        "no schedules",
+			params:      VestingParams{ContractCode: []byte{0x00}},
+			expectedErr: ErrEmptyVestingSchedules,
+		},
+		{
+			name:        "duplicated beneficiary",
+			params:      VestingParams{ContractCode: []byte{0x00}, Schedules: []VestingSchedule{valid, valid}},
+			expectedErr: ErrDuplicateAllocation,
+		},
+		{
+			name: "cliff after end",
+			params: VestingParams{ContractCode: []byte{0x00}, Schedules: []VestingSchedule{
+				{Beneficiary: beneficiary, Amount: big.NewInt(100), Cliff: 48 * time.Hour, Duration: 24 * time.Hour},
+			}},
+			expectedErr: ErrInvalidVestingPlan,
+		},
+		{
+			name: "zero amount",
+			params: VestingParams{ContractCode: []byte{0x00}, Schedules: []VestingSchedule{
+				{Beneficiary: beneficiary, Amount: big.NewInt(0), Duration: time.Hour},
+			}},
+			expectedErr: ErrInvalidVestingPlan,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.params.Validate(), tt.expectedErr)
+		})
+	}
+}
+
+func TestVestingParams_GenesisAccount(t *testing.T) {
+	genesisTime := time.Unix(1700000000, 0)
+	beneficiaryA := common.HexToAddress(ewoqEVMAddress)
+	beneficiaryB := common.HexToAddress(otherEVMAddress)
+	vesting := VestingParams{
+		ContractCode: []byte{0x60, 0x00},
+		Schedules: []VestingSchedule{
+			{Beneficiary: beneficiaryA, Amount: big.NewInt(100), Cliff: time.Hour, Duration: 2 * time.Hour},
+			{Beneficiary: beneficiaryB, Amount: big.NewInt(50), Start: time.Unix(1800000000, 0), Duration: time.Minute},
+		},
+	}
+	account, err := vesting.GenesisAccount(genesisTime)
+	require.NoError(t, err)
+	assert.Equal(t, vesting.ContractCode, account.Code)
+	assert.Equal(t, big.NewInt(150), account.Balance)
+	assert.Equal(t, DefaultVestingContractAddress, vesting.Address())
+
+	slotA := crypto.Keccak256Hash(common.BytesToHash(beneficiaryA.Bytes()).Bytes(), common.Hash{}.Bytes()).Big()
+	assert.Equal(t, common.BigToHash(big.NewInt(100)), account.Storage[common.BigToHash(slotA)])
+	packed := account.Storage[common.BigToHash(new(big.Int).Add(slotA, big.NewInt(1)))].Big()
+	mask := new(big.Int).SetUint64(^uint64(0))
+	assert.Equal(t, uint64(1700000000), new(big.Int).And(packed, mask).Uint64())
+	assert.Equal(t, uint64(1700003600), new(big.Int).And(new(big.Int).Rsh(packed, 64), mask).Uint64())
+	assert.Equal(t, uint64(7200), new(big.Int).Rsh(packed, 128).Uint64())
+
+	beneficiariesSlot := common.BigToHash(big.NewInt(1))
+	assert.Equal(t, common.BigToHash(big.NewInt(2)), account.Storage[beneficiariesSlot])
+	secondElement := new(big.Int).Add(crypto.Keccak256Hash(beneficiariesSlot.Bytes()).Big(), big.NewInt(1))
+	assert.Equal(t, common.BytesToHash(beneficiaryB.Bytes()), account.Storage[common.BigToHash(secondElement)])
+}
+
+func TestCreateEvmGenesis_Vesting(t *testing.T) {
+	vesting := &VestingParams{
+		ContractCode: []byte{0x60, 0x00},
+		Schedules: []VestingSchedule{
+			{Beneficiary: common.HexToAddress(ewoqEVMAddress), Amount: big.NewInt(100), Duration: time.Hour},
+		},
+	}
+	allocation := core.GenesisAlloc{
+		common.HexToAddress(otherEVMAddress): core.GenesisAccount{Balance: big.NewInt(1)},
+	}
+	evmParams := &SubnetEVMParams{
+		ChainID:     big.NewInt(123456),
+		FeeConfig:   commontype.FeeConfig{GasLimit: big.NewInt(8000000)},
+		Allocation:  allocation,
+		Vesting:     vesting,
+		Precompiles: params.Precompiles{},
+	}
+	genesisBytes, err := createEvmGenesis(evmParams)
+	require.NoError(t, err)
+	genesis := core.Genesis{}
+	require.NoError(t, json.Unmarshal(genesisBytes, &genesis))
+	require.Len(t, genesis.Alloc, 2)
+	assert.Equal(t, big.NewInt(100), genesis.Alloc[DefaultVestingContractAddress].Balance)
+	assert.Len(t, allocation, 1, "the given allocation is not modified")
+
+	allocation[DefaultVestingContractAddress] = core.GenesisAccount{Balance: big.NewInt(1)}
+	_, err = createEvmGenesis(evmParams)
+	require.ErrorIs(t, err, ErrDuplicateAllocation)
+}
@@ -83,42 +83,6 @@ func AppendSlices[T any](slices ...[]T) []T {
 	return result
 }
 
-// Retry retries the given function until it succeeds or the maximum number of attempts is reached.
-func Retry[T any](
-	fn func(context.Context) (T, error),
-	attempTimeout time.Duration,
-	maxAttempts int,
-	errMsg string,
-) (T, error) {
-	const defaultAttempTimeout = 2 * time.Second
-	if attempTimeout == 0 {
-		attempTimeout = defaultAttempTimeout
-	}
-	var (
-		result T
-		err    error
-	)
-	for attempt := 0; attempt < maxAttempts; attempt++ {
-		start := time.Now()
-		ctx, cancel := context.WithTimeout(context.Background(), attempTimeout)
-		defer cancel()
-		result, err = fn(ctx)
-		if err == nil {
-			return result, nil
-		}
-		elapsed := time.Since(start)
-		if elapsed < attempTimeout {
-			time.Sleep(attempTimeout - elapsed)
-		}
-	}
-	return result, fmt.Errorf(
-		"%s: maximum retry attempts %d reached: last err = %w",
-		errMsg,
-		maxAttempts,
-		err,
-	)
-}
-
 // WrapContext adds a context based timeout to a given function
 func WrapContext[T any](
 	f func() (T, error),
@@ -412,68 +412,6 @@ func TestAppendSlices(t *testing.T) {
 	}
 }
 
-// Mock function for testing retries.
-func mockFunction() (interface{}, error) {
-	return nil, errors.New("error occurred")
-}
-
-func mockSuccessFunction() (interface{}, error) {
-	return "success", nil
-}
-
-func TestRetry(t *testing.T) {
-	success := "success"
-	// Test with a function that always returns an error.
-	result, err := Retry(WrapContext(mockFunction), 100*time.Millisecond, 3, "test error")
-	if err == nil {
-		t.Errorf("Expected an error, got nil")
-	}
-	if result != nil {
-		t.Errorf("Expected nil result, got %v", result)
-	}
-
-	// Test with a function that succeeds on the first attempt.
-	result, err = Retry(WrapContext(mockSuccessFunction), 100*time.Millisecond, 3, "test error")
-	if err != nil {
-		t.Errorf("Expected no error, got %v", err)
-	}
-	if result != success {
-		t.Errorf("Expected 'success' result, got %v", result)
-	}
-
-	// Test with a function that succeeds after multiple attempts.
-	count := 0
-	fn := func() (interface{}, error) {
-		count++
-		if count < 3 {
-			return nil, errors.New("error occurred")
-		}
-		return success, nil
-	}
-	result, err = Retry(WrapContext(fn), 100*time.Millisecond, 5, "test error")
-	if err != nil {
-		t.Errorf("Expected no error, got %v", err)
-	}
-	if result != success {
-		t.Errorf("Expected 'success' result, got %v", result)
-	}
-
-	// Test with invalid retry interval (should use default).
-	result, err = Retry(WrapContext(mockFunction), 0, 3, "test error")
-	if err == nil {
-		t.Errorf("Expected an error, got nil")
-	}
-	if result != nil {
-		t.Errorf("Expected nil result, got %v", result)
-	}
-
-	// Test error message format
-	result, err = Retry(WrapContext(mockFunction), 100*time.Millisecond, 2, "custom error")
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "custom error")
-	assert.Contains(t, err.Error(), "maximum retry attempts 2 reached")
-}
-
 func TestWrapContext(t *testing.T) {
 	// Test with function that completes before timeout
 	fn := func() (string, error) {
@@ -0,0 +1,95 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ItemError is the error returned for the item at Index of a concurrent operation
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+func (e ItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e ItemError) Unwrap() error {
+	return e.Err
+}
+
+// ConcurrentError aggregates the errors of the items of ForEachConcurrent and CollectConcurrent,
+// sorted by item index. errors.Is and errors.As match any of the item errors
+type ConcurrentError struct {
+	Errors []ItemError
+}
+
+func (e *ConcurrentError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, itemErr := range e.Errors {
+		msgs = append(msgs, itemErr.Error())
+	}
+	return fmt.Sprintf("%d operations failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+func (e *ConcurrentError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, itemErr := range e.Errors {
+		errs = append(errs, itemErr)
+	}
+	return errs
+}
+
+// ForEachConcurrent calls fn for each item, with at most limit calls running at once, or all
+// of them if limit is not positive. It waits for all the calls and returns a *ConcurrentError
+// with the failed items, or nil if all succeeded
+func ForEachConcurrent[T any](items []T, limit int, fn func(T) error) error {
+	_, err := CollectConcurrent(items, limit, func(item T) (struct{}, error) {
+		return struct{}{}, fn(item)
+	})
+	return err
+}
+
+// CollectConcurrent is ForEachConcurrent for functions returning a value. The values are
+// returned in the order of items, along with the errors of the failed items; the value of a
+// failed item is whatever fn returned with the error
+func CollectConcurrent[T, U any](items []T, limit int, fn func(T) (U, error)) ([]U, error) {
+	if limit <= 0 || limit > len(items) {
+		limit = len(items)
+	}
+	values := make([]U, len(items))
+	var (
+		lock   sync.Mutex
+		errs   []ItemError
+		wg     sync.WaitGroup
+		tokens = make(chan struct{}, limit)
+	)
+	for i, item := range items {
+		tokens <- struct{}{}
+		wg.Add(1)
+		go func(i int, item T) {
+			defer wg.Done()
+			defer func() { <-tokens }()
+			value, err := fn(item)
+			values[i] = value
+			if err != nil {
+				lock.Lock()
+				errs = append(errs, ItemError{Index: i, Err: err})
+				lock.Unlock()
+			}
+		}(i, item)
+	}
+	wg.Wait()
+	if len(errs) == 0 {
+		return values, nil
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Index < errs[j].Index
+	})
+	return values, &ConcurrentError{Errors: errs}
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectConcurrent(t *testing.T) {
+	values, err := CollectConcurrent([]int{1, 2, 3, 4}, 2, func(i int) (string, error) {
+		return fmt.Sprint(i * 10), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10", "20", "30", "40"}, values)
+
+	values, err = CollectConcurrent([]int{}, 0, func(i int) (string, error) {
+		return "", nil
+	})
+	require.NoError(t, err)
+	assert.Empty(t, values)
+}
+
+func TestCollectConcurrent_Errors(t *testing.T) {
+	errOdd := errors.New("odd item")
+	values, err := CollectConcurrent([]int{1, 2, 3, 4, 5}, 0, func(i int) (int, error) {
+		if i%2 == 1 {
+			return 0, errOdd
+		}
+		return i, nil
+	})
+	require.Error(t, err)
+	assert.Equal(t, []int{0, 2, 0, 4, 0}, values)
+	assert.ErrorIs(t, err, errOdd)
+
+	var concurrentErr *ConcurrentError
+	require.ErrorAs(t, err, &concurrentErr)
+	require.Len(t, concurrentErr.Errors, 3)
+	for i, index := range []int{0, 2, 4} {
+		assert.Equal(t, index, concurrentErr.Errors[i].Index)
+	}
+	assert.Contains(t, err.Error(), "3 operations failed")
+}
+
+func TestForEachConcurrent_Limit(t *testing.T) {
+	tests := []struct {
+		name        string
+		limit       int
+		expectedMax int32
+	}{
+		{name: "limited", limit: 2, expectedMax: 2},
+		{name: "unlimited", limit: 0, expectedMax: 6},
+		{name: "limit above item count", limit: 10, expectedMax: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var running, maxRunning int32
+			err := ForEachConcurrent(make([]int, 6), tt.limit, func(int) error {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					current := atomic.LoadInt32(&maxRunning)
+					if n <= current || atomic.CompareAndSwapInt32(&maxRunning, current, n) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMax, atomic.LoadInt32(&maxRunning))
+		})
+	}
+}
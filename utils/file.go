@@ -3,8 +3,15 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
 )
 
 // FileExists checks if a file exists.
@@ -37,6 +44,35 @@ func DirectoryExists(dirName string) bool {
 	return info.IsDir()
 }
 
+// FileSHA256 returns the hex encoded SHA-256 checksum of a local file
+func FileSHA256(filename string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ReadFileWithOverrides reads name from overrides if it is set and contains the file,
+// falling back to fsys otherwise
+func ReadFileWithOverrides(overrides fs.FS, fsys fs.FS, name string) ([]byte, error) {
+	if overrides != nil {
+		content, err := fs.ReadFile(overrides, name)
+		if err == nil {
+			return content, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return fs.ReadFile(fsys, name)
+}
+
 // ExpandHome expands ~ symbol to home directory
 func ExpandHome(path string) string {
 	if path == "" {
@@ -50,13 +86,14 @@ func ExpandHome(path string) string {
 	return path
 }
 
-// RemoteComposeFile returns the path to the remote docker-compose file
+// GetRemoteComposeFile returns the default path to the remote docker-compose file, see
+// constants.Config.ComposeFile
 func GetRemoteComposeFile() string {
-	return filepath.Join("/home/ubuntu/.odyssey-cli/", "services", "docker-compose.yml")
+	return constants.DefaultConfig().ComposeFile()
 }
 
-// GetRemoteComposeServicePath returns the path to the remote service directory
+// GetRemoteComposeServicePath returns the default path to the remote service directory, see
+// constants.Config.ServicePath
 func GetRemoteComposeServicePath(serviceName string, dirs ...string) string {
-	servicePrefix := filepath.Join("/home/ubuntu/.odyssey-cli/", "services", serviceName)
-	return filepath.Join(append([]string{servicePrefix}, dirs...)...)
+	return constants.DefaultConfig().ServicePath(serviceName, dirs...)
 }
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -245,6 +246,44 @@ func TestExpandHome(t *testing.T) {
 	}
 }
 
+func TestFileSHA256(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "test.txt")
+	require.NoError(t, os.WriteFile(testFile, []byte("hello"), 0o600))
+
+	checksum, err := FileSHA256(testFile)
+	require.NoError(t, err)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", checksum)
+
+	_, err = FileSHA256(filepath.Join(tempDir, "missing.txt"))
+	assert.Error(t, err)
+}
+
+func TestReadFileWithOverrides(t *testing.T) {
+	fsys := fstest.MapFS{
+		"templates/a.yml": {Data: []byte("embedded a")},
+		"templates/b.yml": {Data: []byte("embedded b")},
+	}
+	overrides := fstest.MapFS{
+		"templates/a.yml": {Data: []byte("override a")},
+	}
+
+	content, err := ReadFileWithOverrides(overrides, fsys, "templates/a.yml")
+	require.NoError(t, err)
+	assert.Equal(t, "override a", string(content))
+
+	content, err = ReadFileWithOverrides(overrides, fsys, "templates/b.yml")
+	require.NoError(t, err)
+	assert.Equal(t, "embedded b", string(content))
+
+	content, err = ReadFileWithOverrides(nil, fsys, "templates/a.yml")
+	require.NoError(t, err)
+	assert.Equal(t, "embedded a", string(content))
+
+	_, err = ReadFileWithOverrides(overrides, fsys, "templates/missing.yml")
+	assert.Error(t, err)
+}
+
 func TestGetRemoteComposeFile(t *testing.T) {
 	result := GetRemoteComposeFile()
 
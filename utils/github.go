@@ -20,6 +20,8 @@ func GetGithubReleasesURL(org, repo string) string {
 }
 
 // GetLatestGithubReleaseVersion returns the latest available release version from github
+//
+// Deprecated: use releases.Client.LatestVersion, which caches the releases
 func GetLatestGithubReleaseVersion(org, repo, authToken string) (string, error) {
 	url := GetLatestGithubReleaseURL(org, repo)
 	jsonBytes, err := HTTPGet(url, authToken)
@@ -40,6 +42,9 @@ func GetLatestGithubReleaseVersion(org, repo, authToken string) (string, error)
 	return version, nil
 }
 
+// GetAllGithubReleaseVersions returns the versions of the releases on github, latest first
+//
+// Deprecated: use releases.Client.Releases, which caches the releases
 func GetAllGithubReleaseVersions(org, repo, authToken string) ([]string, error) {
 	url := GetGithubReleasesURL(org, repo)
 	jsonBytes, err := HTTPGet(url, authToken)
@@ -65,6 +70,8 @@ func GetAllGithubReleaseVersions(org, repo, authToken string) ([]string, error)
 }
 
 // GetLatestGithubPreReleaseVersion returns the latest available pre release version from github
+//
+// Deprecated: use releases.Client.LatestVersion with releases.WithPreReleases
 func GetLatestGithubPreReleaseVersion(org, repo, authToken string) (string, error) {
 	releases, err := GetAllGithubReleaseVersions(org, repo, authToken)
 	if err != nil {
@@ -3,13 +3,19 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 )
 
 func HTTPGet(url, authToken string) ([]byte, error) {
-	request, err := http.NewRequest("GET", url, nil)
+	return HTTPGetWithClient(context.Background(), http.DefaultClient, url, authToken)
+}
+
+// HTTPGetWithClient downloads url with httpClient, authenticated by authToken if not empty
+func HTTPGetWithClient(ctx context.Context, httpClient *http.Client, url, authToken string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed downloading %s: %w", url, err)
 	}
@@ -17,14 +23,14 @@ func HTTPGet(url, authToken string) ([]byte, error) {
 		// to avoid rate limitation issues
 		request.Header.Set("authorization", fmt.Sprintf("Bearer %s", authToken))
 	}
-	resp, err := http.DefaultClient.Do(request)
+	resp, err := httpClient.Do(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed downloading %s: %w", url, err)
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("failed downloading %s: unexpected http status code: %d", url, resp.StatusCode)
 	}
-	defer resp.Body.Close()
 	bs, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed downloading %s: %w", url, err)
@@ -0,0 +1,128 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+const (
+	DefaultRetryInitialInterval = time.Second
+	DefaultRetryMaxInterval     = 30 * time.Second
+	DefaultRetryMultiplier      = 2
+)
+
+// ErrRetriesExhausted is returned by Retry once the attempts or the time of its policy are
+// exhausted, wrapping the last error
+var ErrRetriesExhausted = errors.New("retries exhausted")
+
+// RetryPolicy configures Retry. Its zero value retries all errors with the default exponential
+// backoff until ctx is done
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of calls, unlimited if not positive
+	MaxAttempts int
+
+	// MaxElapsedTime stops the retries that would start after this time since the first call,
+	// unlimited if zero
+	MaxElapsedTime time.Duration
+
+	// AttemptTimeout is the timeout of the context given to each call, none if zero
+	AttemptTimeout time.Duration
+
+	// InitialInterval is the delay before the first retry, DefaultRetryInitialInterval if zero
+	InitialInterval time.Duration
+
+	// Multiplier multiplies the delay after each retry, DefaultRetryMultiplier if below 1
+	Multiplier float64
+
+	// MaxInterval caps the delay between retries, DefaultRetryMaxInterval if zero
+	MaxInterval time.Duration
+
+	// Jitter randomizes each delay by up to this fraction of it, e.g. 0.5 for delays between
+	// 50% and 150% of the backoff, so that the clients failing together don't retry together
+	Jitter float64
+
+	// Retryable tells if a failed call is retried, all errors being retried if nil. The
+	// errors not retryable are returned as is
+	Retryable func(error) bool
+}
+
+// backoff returns the delay before the retry following a delay of previous, zero for the first
+// retry, without jitter
+func (p RetryPolicy) backoff(previous time.Duration) time.Duration {
+	if previous == 0 {
+		if p.InitialInterval > 0 {
+			return p.InitialInterval
+		}
+		return DefaultRetryInitialInterval
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = DefaultRetryMultiplier
+	}
+	maxInterval := p.MaxInterval
+	if maxInterval == 0 {
+		maxInterval = DefaultRetryMaxInterval
+	}
+	next := time.Duration(float64(previous) * multiplier)
+	if next > maxInterval || next < previous {
+		return maxInterval
+	}
+	return next
+}
+
+// jitter randomizes delay by up to factor of it, r being uniform in [0, 1)
+func jitter(delay time.Duration, factor float64, r float64) time.Duration {
+	if factor <= 0 {
+		return delay
+	}
+	return time.Duration(float64(delay) * (1 + factor*(2*r-1)))
+}
+
+// Retry calls fn until it succeeds, fails with an error not retryable, or policy is exhausted,
+// waiting between the calls with an exponential backoff. The retries stop when ctx is done, ctx
+// being the parent of the context given to fn
+func Retry[T any](ctx context.Context, policy RetryPolicy, fn func(context.Context) (T, error)) (T, error) {
+	start := time.Now()
+	var delay time.Duration
+	for attempt := 1; ; attempt++ {
+		result, err := callAttempt(ctx, policy.AttemptTimeout, fn)
+		if err == nil {
+			return result, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return result, fmt.Errorf("retry interrupted after %d attempts: %w (last err = %w)", attempt, ctxErr, err)
+		}
+		if policy.Retryable != nil && !policy.Retryable(err) {
+			return result, err
+		}
+		delay = policy.backoff(delay)
+		wait := jitter(delay, policy.Jitter, rand.Float64()) // #nosec G404
+		elapsed := time.Since(start)
+		if (policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts) ||
+			(policy.MaxElapsedTime > 0 && elapsed+wait > policy.MaxElapsedTime) {
+			return result, fmt.Errorf("%w after %d attempts in %s: last err = %w", ErrRetriesExhausted, attempt, elapsed.Round(time.Millisecond), err)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, fmt.Errorf("retry interrupted after %d attempts: %w (last err = %w)", attempt, ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+// callAttempt calls fn with a context derived from ctx, timing out after timeout if positive
+func callAttempt[T any](ctx context.Context, timeout time.Duration, fn func(context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return fn(ctx)
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	errFailed := errors.New("error occurred")
+	errPermanent := errors.New("permanent error")
+	policy := RetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond}
+	tests := []struct {
+		name             string
+		policy           RetryPolicy
+		failures         int
+		err              error
+		expectedAttempts int
+		expectedErr      error
+	}{
+		{name: "first attempt", policy: policy, expectedAttempts: 1},
+		{name: "after failures", policy: policy, failures: 2, err: errFailed, expectedAttempts: 3},
+		{name: "attempts exhausted", policy: policy, failures: 10, err: errFailed, expectedAttempts: 3, expectedErr: ErrRetriesExhausted},
+		{
+			name:             "not retryable",
+			policy:           RetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond, Retryable: func(err error) bool { return !errors.Is(err, errPermanent) }},
+			failures:         10,
+			err:              errPermanent,
+			expectedAttempts: 1,
+			expectedErr:      errPermanent,
+		},
+		{
+			name:             "elapsed time exhausted",
+			policy:           RetryPolicy{InitialInterval: 20 * time.Millisecond, MaxElapsedTime: 50 * time.Millisecond},
+			failures:         10,
+			err:              errFailed,
+			expectedAttempts: 2,
+			expectedErr:      ErrRetriesExhausted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			result, err := Retry(context.Background(), tt.policy, func(context.Context) (string, error) {
+				attempts++
+				if attempts <= tt.failures {
+					return "", tt.err
+				}
+				return "success", nil
+			})
+			assert.Equal(t, tt.expectedAttempts, attempts)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "success", result)
+		})
+	}
+}
+
+func TestRetry_Context(t *testing.T) {
+	// each attempt times out
+	attempts := 0
+	_, err := Retry(context.Background(), RetryPolicy{MaxAttempts: 2, AttemptTimeout: 10 * time.Millisecond, InitialInterval: time.Millisecond},
+		WrapContext(func() (interface{}, error) {
+			attempts++
+			time.Sleep(50 * time.Millisecond)
+			return nil, nil
+		}),
+	)
+	require.ErrorIs(t, err, ErrRetriesExhausted)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 2, attempts)
+
+	// the retries stop once the parent context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = Retry(ctx, RetryPolicy{InitialInterval: time.Hour}, func(context.Context) (int, error) {
+		return 0, errors.New("unreachable")
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "unreachable")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{InitialInterval: time.Second, MaxInterval: 5 * time.Second}
+	delays := []time.Duration{}
+	var delay time.Duration
+	for i := 0; i < 5; i++ {
+		delay = policy.backoff(delay)
+		delays = append(delays, delay)
+	}
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}, delays)
+
+	constant := RetryPolicy{InitialInterval: time.Second, Multiplier: 1}
+	assert.Equal(t, time.Second, constant.backoff(constant.backoff(0)))
+	assert.Equal(t, DefaultRetryInitialInterval, RetryPolicy{}.backoff(0))
+
+	assert.Equal(t, time.Second, jitter(time.Second, 0, 0))
+	assert.Equal(t, 500*time.Millisecond, jitter(time.Second, 0.5, 0))
+	assert.Equal(t, time.Second, jitter(time.Second, 0.5, 0.5))
+	assert.Equal(t, 1250*time.Millisecond, jitter(time.Second, 0.5, 0.75))
+}
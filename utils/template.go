@@ -0,0 +1,143 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"gopkg.in/yaml.v3"
+)
+
+// TemplatePartialsDir is the directory of the template overrides holding the partial
+// templates, see ParseTemplate
+const TemplatePartialsDir = "partials"
+
+// templateDefaultPorts are the ports returned by the defaultPort template function
+var templateDefaultPorts = map[string]int{
+	"api":               constants.OdysseygoAPIPort,
+	"p2p":               constants.OdysseygoP2PPort,
+	"grafana":           constants.OdysseygoGrafanaPort,
+	"loki":              constants.OdysseygoLokiPort,
+	"prometheus":        constants.OdysseygoMonitoringPort,
+	"node-exporter":     constants.OdysseygoMachineMetricsPort,
+	"loadtest":          constants.OdysseygoLoadTestPort,
+	"awm-relayer":       constants.AWMRelayerMetricsPort,
+	"rpc-gateway-http":  constants.RPCGatewayHTTPPort,
+	"rpc-gateway-https": constants.RPCGatewayHTTPSPort,
+}
+
+// TemplateFuncs returns the functions available to the compose and monitoring templates:
+//   - defaultPort name: the default port of api, p2p, grafana, loki, prometheus, node-exporter,
+//     loadtest, awm-relayer, rpc-gateway-http or rpc-gateway-https
+//   - normalizeVersion version: version prefixed with v, e.g. 1.10.13 to v1.10.13, leaving
+//     tags not starting with a digit such as latest unchanged
+//   - indent spaces text: text with each non empty line indented by spaces
+//   - toYaml value: value marshaled to YAML, without trailing newline
+//   - envList env: the sorted KEY=VALUE entries of the env map
+//
+// ParseTemplate adds include to them
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"defaultPort":      defaultPort,
+		"normalizeVersion": NormalizeVersion,
+		"indent":           Indent,
+		"toYaml":           toYAML,
+		"envList":          envList,
+	}
+}
+
+// ParseTemplate parses content as the template name with TemplateFuncs, along with the
+// partial templates found in the TemplatePartialsDir directory of overrides, if any. The
+// partial partials/extra.tmpl is executed with {{ template "extra" . }}, or with
+// {{ include "extra" . }} which returns its output, so that it can be piped to indent, and
+// renders nothing if the partial does not exist
+func ParseTemplate(name string, content []byte, overrides fs.FS) (*template.Template, error) {
+	t := template.New(name).Funcs(TemplateFuncs())
+	t.Funcs(template.FuncMap{
+		"include": func(name string, data interface{}) (string, error) {
+			if t.Lookup(name) == nil {
+				return "", nil
+			}
+			var buf bytes.Buffer
+			if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+				return "", err
+			}
+			return buf.String(), nil
+		},
+	})
+	if _, err := t.Parse(string(content)); err != nil {
+		return nil, err
+	}
+	if overrides == nil {
+		return t, nil
+	}
+	partials, err := fs.Glob(overrides, path.Join(TemplatePartialsDir, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	for _, partial := range partials {
+		partialContent, err := fs.ReadFile(overrides, partial)
+		if err != nil {
+			return nil, err
+		}
+		partialName := strings.TrimSuffix(path.Base(partial), ".tmpl")
+		if _, err := t.New(partialName).Parse(string(partialContent)); err != nil {
+			return nil, fmt.Errorf("invalid partial %s: %w", partial, err)
+		}
+	}
+	return t, nil
+}
+
+// NormalizeVersion prefixes version with v, e.g. 1.10.13 to v1.10.13. Versions already
+// prefixed and tags not starting with a digit, such as latest, are returned unchanged
+func NormalizeVersion(version string) string {
+	if version == "" || version[0] < '0' || version[0] > '9' {
+		return version
+	}
+	return "v" + version
+}
+
+// Indent indents each non empty line of text by spaces
+func Indent(spaces int, text string) string {
+	prefix := strings.Repeat(" ", spaces)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func defaultPort(name string) (int, error) {
+	port, ok := templateDefaultPorts[name]
+	if !ok {
+		return 0, errors.New("unknown default port " + name)
+	}
+	return port, nil
+}
+
+func toYAML(value interface{}) (string, error) {
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+func envList(env map[string]string) []string {
+	entries := make([]string, 0, len(env))
+	for key, value := range env {
+		entries = append(entries, key+"="+value)
+	}
+	sort.Strings(entries)
+	return entries
+}
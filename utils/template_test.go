@@ -0,0 +1,47 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package utils
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func executeTemplate(t *testing.T, content string, overrides fstest.MapFS, data interface{}) (string, error) {
+	tmpl, err := ParseTemplate("test", []byte(content), overrides)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	return buf.String(), err
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	out, err := executeTemplate(t, `{{ defaultPort "api" }} {{ normalizeVersion "1.10.13" }} {{ normalizeVersion "v1.10.13" }} {{ normalizeVersion "latest" }}`, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "9650 v1.10.13 v1.10.13 latest", out)
+
+	out, err = executeTemplate(t, "env:\n{{ toYaml (envList .) | indent 2 }}", nil, map[string]string{"B": "2", "A": "1"})
+	require.NoError(t, err)
+	assert.Equal(t, "env:\n  - A=1\n  - B=2", out)
+
+	_, err = executeTemplate(t, `{{ defaultPort "unknown" }}`, nil, nil)
+	require.ErrorContains(t, err, "unknown default port")
+}
+
+func TestParseTemplate_Partials(t *testing.T) {
+	overrides := fstest.MapFS{
+		"partials/greeting.tmpl": {Data: []byte("hello {{ . }}\n")},
+	}
+	out, err := executeTemplate(t, `{{ template "greeting" . }}{{ include "greeting" . | indent 2 }}[{{ include "missing" . }}]`, overrides, "world")
+	require.NoError(t, err)
+	assert.Equal(t, "hello world\n  hello world\n[]", out)
+
+	_, err = ParseTemplate("test", []byte("{{ .X }}"), fstest.MapFS{
+		"partials/broken.tmpl": {Data: []byte("{{ if }}")},
+	})
+	require.ErrorContains(t, err, "invalid partial partials/broken.tmpl")
+}
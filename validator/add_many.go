@@ -0,0 +1,259 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/multisig"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/signer"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/status"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary/common"
+)
+
+const (
+	// maxAddManyConcurrency is the maximum number of validator txs awaiting acceptance at once
+	maxAddManyConcurrency = 8
+	defaultSubnetWeight   = 20
+	txPollFrequency       = time.Second
+)
+
+var (
+	ErrEmptyNodeID            = errors.New("validator node id is not provided")
+	ErrEmptyDuration          = errors.New("validator duration is not provided")
+	ErrEmptyProofOfPossession = errors.New("validator proof of possession is not provided")
+	ErrEmptyDelegationFee     = errors.New("validator delegation fee is not provided")
+	ErrEmptyWalletURI         = errors.New("wallet has no API endpoint")
+)
+
+// ValidatorParams describes one of the validators added by AddMany
+type ValidatorParams struct {
+	// NodeID is the unique identifier of the node to be added as a validator
+	NodeID ids.NodeID
+
+	// Duration is how long the node will be validating
+	Duration time.Duration
+
+	// Weight is the amount of DIONE staked, denominated in nDIONE, for Primary Network validators,
+	// and the sampling weight for Subnet validators. Subnet validators weight is 20 by default
+	Weight uint64
+
+	// DelegationFee is the percent fee charged to delegators of a Primary Network validator.
+	// When not set, the minimum delegation fee of the wallet network is used
+	DelegationFee uint32
+
+	// ProofOfPossession is the BLS proof of possession of the node.
+	// Required for Primary Network validators
+	ProofOfPossession *signer.ProofOfPossession
+}
+
+// AddResult is the outcome of adding a single validator with AddMany
+type AddResult struct {
+	NodeID ids.NodeID
+
+	// TxID is the ID of the issued tx, if any
+	TxID ids.ID
+
+	// Skipped is true when the node was already validating the subnet, so no tx was issued
+	Skipped bool
+
+	// Err is the error that prevented the validator from being added, if any
+	Err error
+}
+
+// AddMany builds, signs and issues the txs adding validators to subnetID, or to the Primary
+// Network if subnetID is ids.Empty, and waits for their acceptance.
+// Txs are built and issued one at a time from the wallet, while up to maxAddManyConcurrency
+// of them await acceptance at once.
+//
+// Nodes that are already validating subnetID are skipped, so AddMany can be called again with
+// the same validators to resume after a partial failure. Failures of single validators are
+// reported in their AddResult; the returned error is only set when no validator could be processed.
+//
+// For Subnet validators, the subnet auth keys must be set in the wallet with
+// SetSubnetAuthMultisig, and the wallet must be able to fully sign the txs.
+func AddMany(
+	ctx context.Context,
+	wallet wallet.Wallet,
+	subnetID ids.ID,
+	validators []ValidatorParams,
+) ([]AddResult, error) {
+	if len(validators) == 0 {
+		return nil, nil
+	}
+	uri := wallet.URI()
+	if uri == "" {
+		return nil, ErrEmptyWalletURI
+	}
+	client := omegavm.NewClient(uri)
+	nodeIDs := make([]ids.NodeID, 0, len(validators))
+	for _, validator := range validators {
+		nodeIDs = append(nodeIDs, validator.NodeID)
+	}
+	current, err := client.GetCurrentValidators(ctx, subnetID, nodeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current validators of subnet %s: %w", subnetID, err)
+	}
+	validating := set.Set[ids.NodeID]{}
+	for _, validator := range current {
+		validating.Add(validator.NodeID)
+	}
+	var networkParams *odyssey.NetworkParams
+	if subnetID == ids.Empty {
+		network := wallet.Network()
+		network.Endpoint = uri
+		params, err := odyssey.GetNetworkParams(network)
+		if err != nil {
+			return nil, err
+		}
+		networkParams = &params
+	}
+
+	// the wallet tracks spent UTXOs, so txs must be built and issued sequentially
+	issueLock := sync.Mutex{}
+	add := func(ctx context.Context, params ValidatorParams) (ids.ID, error) {
+		issueLock.Lock()
+		tx, err := buildAddValidatorTx(ctx, wallet, subnetID, params, networkParams)
+		if err == nil {
+			err = wallet.O().IssueTx(tx, common.WithContext(ctx), common.WithAssumeDecided())
+		}
+		issueLock.Unlock()
+		if err != nil {
+			return ids.Empty, err
+		}
+		txStatus, err := client.AwaitTxDecided(ctx, tx.ID(), txPollFrequency)
+		if err != nil {
+			return tx.ID(), fmt.Errorf("failed to await tx %s: %w", tx.ID(), err)
+		}
+		if txStatus.Status != status.Committed {
+			return tx.ID(), fmt.Errorf("tx %s was not committed: %s %s", tx.ID(), txStatus.Status, txStatus.Reason)
+		}
+		return tx.ID(), nil
+	}
+	return addMany(ctx, validators, validating, maxAddManyConcurrency, add), nil
+}
+
+// addMany calls add for each validator not in validating, with at most concurrency calls
+// in flight, and returns the results in the same order as validators
+func addMany(
+	ctx context.Context,
+	validators []ValidatorParams,
+	validating set.Set[ids.NodeID],
+	concurrency int,
+	add func(context.Context, ValidatorParams) (ids.ID, error),
+) []AddResult {
+	results := make([]AddResult, len(validators))
+	pending := []int{}
+	for i, validator := range validators {
+		results[i].NodeID = validator.NodeID
+		if validating.Contains(validator.NodeID) {
+			results[i].Skipped = true
+			continue
+		}
+		pending = append(pending, i)
+	}
+	// errors are reported per validator in results
+	_ = utils.ForEachConcurrent(pending, concurrency, func(i int) error {
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			return err
+		}
+		results[i].TxID, results[i].Err = add(ctx, validators[i])
+		if results[i].Err != nil {
+			results[i].Err = fmt.Errorf("failed to add validator %s: %w", validators[i].NodeID, results[i].Err)
+		}
+		return results[i].Err
+	})
+	return results
+}
+
+// buildAddValidatorTx builds and signs the tx adding a validator to subnetID. Primary Network
+// validators are checked against networkParams, unless nil
+func buildAddValidatorTx(
+	ctx context.Context,
+	wallet wallet.Wallet,
+	subnetID ids.ID,
+	params ValidatorParams,
+	networkParams *odyssey.NetworkParams,
+) (*txs.Tx, error) {
+	if params.NodeID == ids.EmptyNodeID {
+		return nil, ErrEmptyNodeID
+	}
+	if params.Duration == 0 {
+		return nil, ErrEmptyDuration
+	}
+	validator := &txs.SubnetValidator{
+		Validator: txs.Validator{
+			NodeID: params.NodeID,
+			End:    uint64(time.Now().Add(params.Duration).Unix()),
+			Wght:   params.Weight,
+		},
+		Subnet: subnetID,
+	}
+	var (
+		unsignedTx txs.UnsignedTx
+		err        error
+	)
+	if subnetID == ids.Empty {
+		if params.ProofOfPossession == nil {
+			return nil, ErrEmptyProofOfPossession
+		}
+		if params.DelegationFee == 0 && networkParams != nil {
+			params.DelegationFee = networkParams.MinDelegationFee
+		}
+		if params.DelegationFee == 0 {
+			return nil, ErrEmptyDelegationFee
+		}
+		if networkParams != nil {
+			if err := networkParams.ValidatePrimaryNetworkValidator(params.Weight, params.Duration, params.DelegationFee); err != nil {
+				return nil, err
+			}
+		}
+		owner := &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{wallet.Addresses()[0]},
+		}
+		unsignedTx, err = wallet.O().Builder().NewAddPermissionlessValidatorTx(
+			validator,
+			params.ProofOfPossession,
+			wallet.O().DIONEAssetID(),
+			owner,
+			owner,
+			params.DelegationFee,
+		)
+	} else {
+		if validator.Wght == 0 {
+			validator.Wght = defaultSubnetWeight
+		}
+		unsignedTx, err = wallet.O().Builder().NewAddSubnetValidatorTx(validator)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error building tx: %w", err)
+	}
+	tx := txs.Tx{Unsigned: unsignedTx}
+	if err := wallet.O().Signer().Sign(ctx, &tx); err != nil {
+		return nil, fmt.Errorf("error signing tx: %w", err)
+	}
+	if subnetID != ids.Empty {
+		isReady, err := multisig.New(&tx).IsReadyToCommit()
+		if err != nil {
+			return nil, err
+		}
+		if !isReady {
+			return nil, multisig.ErrThresholdNotMet
+		}
+	}
+	return &tx, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package validator
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/wallet"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddMany_NoValidators(t *testing.T) {
+	results, err := AddMany(context.Background(), wallet.Wallet{}, ids.Empty, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestAddMany_EmptyWalletURI(t *testing.T) {
+	_, err := AddMany(context.Background(), wallet.Wallet{}, ids.Empty, []ValidatorParams{{NodeID: ids.GenerateTestNodeID()}})
+	assert.ErrorIs(t, err, ErrEmptyWalletURI)
+}
+
+func TestAddMany_Results(t *testing.T) {
+	validators := []ValidatorParams{}
+	txIDs := map[ids.NodeID]ids.ID{}
+	for i := 0; i < 20; i++ {
+		nodeID := ids.GenerateTestNodeID()
+		validators = append(validators, ValidatorParams{NodeID: nodeID, Duration: time.Hour})
+		txIDs[nodeID] = ids.GenerateTestID()
+	}
+	validating := set.Of(validators[0].NodeID, validators[5].NodeID)
+	failing := validators[7].NodeID
+	errFailing := errors.New("insufficient funds")
+
+	var inFlight, maxInFlight int32
+	results := addMany(context.Background(), validators, validating, 3, func(_ context.Context, params ValidatorParams) (ids.ID, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if params.NodeID == failing {
+			return ids.Empty, errFailing
+		}
+		return txIDs[params.NodeID], nil
+	})
+
+	require.Len(t, results, len(validators))
+	assert.LessOrEqual(t, maxInFlight, int32(3))
+	for i, result := range results {
+		assert.Equal(t, validators[i].NodeID, result.NodeID)
+		switch {
+		case validating.Contains(result.NodeID):
+			assert.True(t, result.Skipped)
+			assert.Equal(t, ids.Empty, result.TxID)
+			assert.NoError(t, result.Err)
+		case result.NodeID == failing:
+			assert.ErrorIs(t, result.Err, errFailing)
+		default:
+			assert.False(t, result.Skipped)
+			assert.NoError(t, result.Err)
+			assert.Equal(t, txIDs[result.NodeID], result.TxID)
+		}
+	}
+}
+
+func TestAddMany_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	validators := []ValidatorParams{{NodeID: ids.GenerateTestNodeID()}, {NodeID: ids.GenerateTestNodeID()}}
+	results := addMany(ctx, validators, set.Set[ids.NodeID]{}, 1, func(context.Context, ValidatorParams) (ids.ID, error) {
+		return ids.Empty, nil
+	})
+	for _, result := range results {
+		if result.Err != nil {
+			assert.ErrorIs(t, result.Err, context.Canceled)
+		}
+	}
+}
+
+func TestBuildAddValidatorTx_Validation(t *testing.T) {
+	tests := []struct {
+		name          string
+		subnetID      ids.ID
+		params        ValidatorParams
+		networkParams *odyssey.NetworkParams
+		expectedErr   error
+	}{
+		{
+			name:        "empty node ID",
+			params:      ValidatorParams{Duration: time.Hour},
+			expectedErr: ErrEmptyNodeID,
+		},
+		{
+			name:        "empty duration",
+			params:      ValidatorParams{NodeID: ids.GenerateTestNodeID()},
+			expectedErr: ErrEmptyDuration,
+		},
+		{
+			name:        "primary network without proof of possession",
+			params:      ValidatorParams{NodeID: ids.GenerateTestNodeID(), Duration: time.Hour},
+			expectedErr: ErrEmptyProofOfPossession,
+		},
+		{
+			name: "primary network stake below minimum",
+			params: ValidatorParams{
+				NodeID:            ids.GenerateTestNodeID(),
+				Duration:          time.Hour,
+				Weight:            1,
+				ProofOfPossession: &signer.ProofOfPossession{},
+			},
+			networkParams: &odyssey.NetworkParams{MinValidatorStake: 2, MinDelegationFee: 20000},
+			expectedErr:   odyssey.ErrStakeOutOfRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildAddValidatorTx(context.Background(), wallet.Wallet{}, tt.subnetID, tt.params, tt.networkParams)
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
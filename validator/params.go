@@ -0,0 +1,57 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs/executor"
+)
+
+var ErrInvalidStartTime = errors.New("validator start time is invalid")
+
+// ValidateParams checks params against the parameters of network before a Primary Network
+// validator is added, so that invalid validators are rejected before any fee is spent.
+// All the violations are returned at once, each of them matching its error with errors.Is
+func ValidateParams(network odyssey.Network, params PrimaryNetworkValidatorParams) error {
+	networkParams, err := odyssey.GetNetworkParams(network)
+	if err != nil {
+		return err
+	}
+	return params.Validate(networkParams)
+}
+
+// Validate checks the validator params against networkParams, returning all the violations at
+// once. A zero DelegationFee is valid, as the minimum delegation fee is used instead
+func (p PrimaryNetworkValidatorParams) Validate(networkParams odyssey.NetworkParams) error {
+	return p.validate(networkParams, time.Now())
+}
+
+func (p PrimaryNetworkValidatorParams) validate(networkParams odyssey.NetworkParams, now time.Time) error {
+	errs := []error{}
+	if p.NodeID == ids.EmptyNodeID {
+		errs = append(errs, ErrEmptyNodeID)
+	}
+	errs = append(errs, networkParams.ValidateValidatorStake(p.StakeAmount))
+	if p.Duration == 0 {
+		errs = append(errs, ErrEmptyDuration)
+	} else {
+		errs = append(errs, networkParams.ValidateValidatorStakeDuration(p.Duration))
+	}
+	if p.DelegationFee != 0 {
+		errs = append(errs, networkParams.ValidateDelegationFee(p.DelegationFee))
+	}
+	if !p.StartTime.IsZero() {
+		if !p.StartTime.After(now) {
+			errs = append(errs, fmt.Errorf("%w: %s is not in the future", ErrInvalidStartTime, p.StartTime))
+		} else if maxStartTime := now.Add(executor.MaxFutureStartTime); p.StartTime.After(maxStartTime) {
+			errs = append(errs, fmt.Errorf("%w: %s is after %s", ErrInvalidStartTime, p.StartTime, maxStartTime))
+		}
+	}
+	return errors.Join(errs...)
+}
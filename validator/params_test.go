@@ -0,0 +1,102 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrimaryNetworkValidatorParams_Validate(t *testing.T) {
+	networkParams := odyssey.NetworkParams{
+		MinValidatorStake:         2000,
+		MaxValidatorStake:         3000,
+		MinValidatorStakeDuration: 24 * time.Hour,
+		MaxValidatorStakeDuration: 365 * 24 * time.Hour,
+		MinDelegationFee:          20000,
+	}
+	now := time.Unix(1700000000, 0)
+	valid := PrimaryNetworkValidatorParams{
+		NodeID:      ids.GenerateTestNodeID(),
+		Duration:    48 * time.Hour,
+		StakeAmount: 2000,
+	}
+	tests := []struct {
+		name   string
+		modify func(*PrimaryNetworkValidatorParams)
+		errs   []error
+	}{
+		{
+			name:   "valid",
+			modify: func(*PrimaryNetworkValidatorParams) {},
+		},
+		{
+			name: "valid with start time and delegation fee",
+			modify: func(p *PrimaryNetworkValidatorParams) {
+				p.StartTime = now.Add(time.Hour)
+				p.DelegationFee = 30000
+			},
+		},
+		{
+			name:   "empty node id",
+			modify: func(p *PrimaryNetworkValidatorParams) { p.NodeID = ids.EmptyNodeID },
+			errs:   []error{ErrEmptyNodeID},
+		},
+		{
+			name:   "empty duration",
+			modify: func(p *PrimaryNetworkValidatorParams) { p.Duration = 0 },
+			errs:   []error{ErrEmptyDuration},
+		},
+		{
+			name:   "stake above maximum",
+			modify: func(p *PrimaryNetworkValidatorParams) { p.StakeAmount = 3001 },
+			errs:   []error{odyssey.ErrStakeOutOfRange},
+		},
+		{
+			name:   "start time in the past",
+			modify: func(p *PrimaryNetworkValidatorParams) { p.StartTime = now.Add(-time.Second) },
+			errs:   []error{ErrInvalidStartTime},
+		},
+		{
+			name:   "start time too far",
+			modify: func(p *PrimaryNetworkValidatorParams) { p.StartTime = now.Add(15 * 24 * time.Hour) },
+			errs:   []error{ErrInvalidStartTime},
+		},
+		{
+			name: "all violations",
+			modify: func(p *PrimaryNetworkValidatorParams) {
+				p.NodeID = ids.EmptyNodeID
+				p.StakeAmount = 1
+				p.Duration = time.Hour
+				p.DelegationFee = 1
+				p.StartTime = now
+			},
+			errs: []error{
+				ErrEmptyNodeID,
+				odyssey.ErrStakeOutOfRange,
+				odyssey.ErrStakeDurationOutOfRange,
+				odyssey.ErrDelegationFeeOutOfRange,
+				ErrInvalidStartTime,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := valid
+			tt.modify(&params)
+			err := params.validate(networkParams, now)
+			if len(tt.errs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, expected := range tt.errs {
+				require.ErrorIs(t, err, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/utils"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+)
+
+// DefaultUptimeRequirement is the minimum uptime percentage a validator needs to be rewarded
+const DefaultUptimeRequirement = 80.0
+
+var (
+	ErrInvalidUptimeRequirement = errors.New("uptime requirement must be between 0 and 100")
+	ErrNoUptimeEndpoint         = errors.New("no API endpoint could report validator uptimes")
+)
+
+// ValidatorUptime is the uptime of a validator as observed by the queried API nodes
+type ValidatorUptime struct {
+	NodeID ids.NodeID
+
+	// Uptime is the average of the uptime percentages reported by the observers
+	Uptime float64
+
+	// MinUptime is the lowest uptime percentage reported by the observers
+	MinUptime float64
+
+	// Observers is the number of API nodes that reported an uptime for the validator
+	Observers int
+
+	// ConnectedObservers is the number of API nodes currently connected to the validator
+	ConnectedObservers int
+
+	// BelowRequirement is true if Uptime is lower than the uptime requirement of the report
+	BelowRequirement bool
+}
+
+// UptimeReportResult aggregates the validator uptimes of a subnet reported by several API nodes
+type UptimeReportResult struct {
+	SubnetID ids.ID
+
+	// Requirement is the uptime percentage validators are checked against
+	Requirement float64
+
+	// Validators are the current validators of the subnet, sorted by node ID
+	Validators []ValidatorUptime
+
+	// EndpointErrors holds the error of each API endpoint that could not be queried
+	EndpointErrors map[string]error
+}
+
+// BelowRequirement returns the validators whose uptime is lower than the requirement
+func (r *UptimeReportResult) BelowRequirement() []ValidatorUptime {
+	below := []ValidatorUptime{}
+	for _, v := range r.Validators {
+		if v.BelowRequirement {
+			below = append(below, v)
+		}
+	}
+	return below
+}
+
+// Disconnected returns the validators no queried API node is connected to
+func (r *UptimeReportResult) Disconnected() []ValidatorUptime {
+	disconnected := []ValidatorUptime{}
+	for _, v := range r.Validators {
+		if v.ConnectedObservers == 0 {
+			disconnected = append(disconnected, v)
+		}
+	}
+	return disconnected
+}
+
+// UptimeOp holds the options of UptimeReport
+type UptimeOp struct {
+	endpoints   []string
+	requirement float64
+}
+
+// UptimeOption configures UptimeReport
+type UptimeOption func(*UptimeOp)
+
+// WithUptimeEndpoints sets the API endpoints queried for uptimes, instead of the network endpoint
+func WithUptimeEndpoints(endpoints ...string) UptimeOption {
+	return func(op *UptimeOp) {
+		op.endpoints = endpoints
+	}
+}
+
+// WithUptimeRequirement sets the uptime percentage validators are checked against,
+// DefaultUptimeRequirement by default
+func WithUptimeRequirement(requirement float64) UptimeOption {
+	return func(op *UptimeOp) {
+		op.requirement = requirement
+	}
+}
+
+// getCurrentValidators queries the current validators of subnetID from the O-Chain API of endpoint
+var getCurrentValidators = func(endpoint string, subnetID ids.ID) ([]omegavm.ClientPermissionlessValidator, error) {
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	return omegavm.NewClient(endpoint).GetCurrentValidators(ctx, subnetID, nil)
+}
+
+// UptimeReport queries the current validators of subnetID and their uptimes from the API nodes
+// of network, aggregates the uptimes reported by each node and flags the validators below the
+// uptime requirement. Endpoints that fail are listed in the report; an error is only returned
+// if none of them could be queried
+func UptimeReport(network odyssey.Network, subnetID ids.ID, opts ...UptimeOption) (*UptimeReportResult, error) {
+	op := &UptimeOp{
+		endpoints:   []string{network.Endpoint},
+		requirement: DefaultUptimeRequirement,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+	if op.requirement < 0 || op.requirement > 100 {
+		return nil, fmt.Errorf("%w: %.2f", ErrInvalidUptimeRequirement, op.requirement)
+	}
+	if len(op.endpoints) == 0 {
+		return nil, ErrNoUptimeEndpoint
+	}
+
+	observations := make(map[string][]omegavm.ClientPermissionlessValidator, len(op.endpoints))
+	endpointErrors := map[string]error{}
+	validators, err := utils.CollectConcurrent(op.endpoints, 0, func(endpoint string) ([]omegavm.ClientPermissionlessValidator, error) {
+		return getCurrentValidators(endpoint, subnetID)
+	})
+	var concurrentErr *utils.ConcurrentError
+	if errors.As(err, &concurrentErr) {
+		for _, itemErr := range concurrentErr.Errors {
+			endpointErrors[op.endpoints[itemErr.Index]] = itemErr.Err
+		}
+	}
+	for i, endpoint := range op.endpoints {
+		if _, failed := endpointErrors[endpoint]; !failed {
+			observations[endpoint] = validators[i]
+		}
+	}
+	if len(observations) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNoUptimeEndpoint, endpointErrors)
+	}
+	return &UptimeReportResult{
+		SubnetID:       subnetID,
+		Requirement:    op.requirement,
+		Validators:     aggregateUptimes(observations, op.requirement),
+		EndpointErrors: endpointErrors,
+	}, nil
+}
+
+// aggregateUptimes merges the validators reported by each endpoint into one uptime per validator
+func aggregateUptimes(observations map[string][]omegavm.ClientPermissionlessValidator, requirement float64) []ValidatorUptime {
+	uptimes := map[ids.NodeID]*ValidatorUptime{}
+	for _, validators := range observations {
+		for _, v := range validators {
+			uptime, ok := uptimes[v.NodeID]
+			if !ok {
+				uptime = &ValidatorUptime{NodeID: v.NodeID}
+				uptimes[v.NodeID] = uptime
+			}
+			if v.Connected != nil && *v.Connected {
+				uptime.ConnectedObservers++
+			}
+			if v.Uptime == nil {
+				continue
+			}
+			observed := float64(*v.Uptime)
+			if uptime.Observers == 0 || observed < uptime.MinUptime {
+				uptime.MinUptime = observed
+			}
+			// running average of the observed uptimes
+			uptime.Uptime += (observed - uptime.Uptime) / float64(uptime.Observers+1)
+			uptime.Observers++
+		}
+	}
+	result := make([]ValidatorUptime, 0, len(uptimes))
+	for _, uptime := range uptimes {
+		// validators nobody reported an uptime for cannot be proven to meet the requirement
+		uptime.BelowRequirement = uptime.Observers == 0 || uptime.Uptime < requirement
+		result = append(result, *uptime)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NodeID.Less(result[j].NodeID)
+	})
+	return result
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func observedValidator(nodeID ids.NodeID, uptime float32, connected bool) omegavm.ClientPermissionlessValidator {
+	v := omegavm.ClientPermissionlessValidator{Uptime: &uptime, Connected: &connected}
+	v.NodeID = nodeID
+	return v
+}
+
+func TestAggregateUptimes(t *testing.T) {
+	healthy, flaky, unknown := ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	observations := map[string][]omegavm.ClientPermissionlessValidator{
+		"node-a": {observedValidator(healthy, 99, true), observedValidator(flaky, 90, true)},
+		"node-b": {observedValidator(healthy, 97, true), observedValidator(flaky, 60, false)},
+		"node-c": {{ClientStaker: omegavm.ClientStaker{NodeID: unknown}}},
+	}
+
+	uptimes := aggregateUptimes(observations, DefaultUptimeRequirement)
+	require.Len(t, uptimes, 3)
+	byNodeID := map[ids.NodeID]ValidatorUptime{}
+	for i, u := range uptimes {
+		if i > 0 {
+			assert.True(t, uptimes[i-1].NodeID.Less(u.NodeID))
+		}
+		byNodeID[u.NodeID] = u
+	}
+
+	assert.Equal(t, ValidatorUptime{NodeID: healthy, Uptime: 98, MinUptime: 97, Observers: 2, ConnectedObservers: 2}, byNodeID[healthy])
+	assert.Equal(t, ValidatorUptime{NodeID: flaky, Uptime: 75, MinUptime: 60, Observers: 2, ConnectedObservers: 1, BelowRequirement: true}, byNodeID[flaky])
+	assert.Equal(t, ValidatorUptime{NodeID: unknown, BelowRequirement: true}, byNodeID[unknown])
+}
+
+func TestUptimeReport(t *testing.T) {
+	original := getCurrentValidators
+	defer func() { getCurrentValidators = original }()
+
+	healthy, down := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	getCurrentValidators = func(endpoint string, _ ids.ID) ([]omegavm.ClientPermissionlessValidator, error) {
+		if endpoint == "http://broken" {
+			return nil, errors.New("connection refused")
+		}
+		return []omegavm.ClientPermissionlessValidator{observedValidator(healthy, 100, true), observedValidator(down, 10, false)}, nil
+	}
+	network := odyssey.NewNetwork(odyssey.Devnet, 1337, "http://api")
+	subnetID := ids.GenerateTestID()
+
+	report, err := UptimeReport(network, subnetID, WithUptimeEndpoints("http://api", "http://broken"))
+	require.NoError(t, err)
+	assert.Equal(t, subnetID, report.SubnetID)
+	assert.Equal(t, DefaultUptimeRequirement, report.Requirement)
+	require.Len(t, report.Validators, 2)
+	assert.Contains(t, report.EndpointErrors, "http://broken")
+	require.Len(t, report.BelowRequirement(), 1)
+	assert.Equal(t, down, report.BelowRequirement()[0].NodeID)
+	require.Len(t, report.Disconnected(), 1)
+	assert.Equal(t, down, report.Disconnected()[0].NodeID)
+
+	report, err = UptimeReport(network, subnetID, WithUptimeRequirement(5))
+	require.NoError(t, err)
+	assert.Empty(t, report.BelowRequirement())
+
+	_, err = UptimeReport(network, subnetID, WithUptimeEndpoints("http://broken"))
+	assert.ErrorIs(t, err, ErrNoUptimeEndpoint)
+
+	_, err = UptimeReport(network, subnetID, WithUptimeEndpoints())
+	assert.ErrorIs(t, err, ErrNoUptimeEndpoint)
+
+	_, err = UptimeReport(network, subnetID, WithUptimeRequirement(101))
+	assert.ErrorIs(t, err, ErrInvalidUptimeRequirement)
+}
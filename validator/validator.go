@@ -20,13 +20,19 @@ type PrimaryNetworkValidatorParams struct {
 
 	// StakeAmount is the amount of Odyssey tokens (DIONE) to stake in this validator, which is
 	// denominated in nDIONE. StakeAmount has to be greater than or equal to minimum stake required
-	// for the specified network
+	// for the specified network. Use the amounts package to convert from DIONE, e.g.
+	// uint64(amounts.MustParse("2000 DIONE"))
 	StakeAmount uint64
 
 	// DelegationFee is the percent fee this validator will charge when others delegate stake to it
 	// When DelegationFee is not set, the minimum delegation fee for the specified network will be set
 	// For more information on delegation fee, please head to https://docs.dione.network/nodes/validate/node-validator#delegation-fee-rate
 	DelegationFee uint32
+
+	// StartTime is when the node starts validating the Primary Network. It has to be in the future,
+	// and at most executor.MaxFutureStartTime away. When StartTime is not set, the node starts
+	// validating as soon as the transaction is accepted
+	StartTime time.Time
 }
 
 type SubnetValidatorParams struct {
@@ -39,4 +45,7 @@ type SubnetValidatorParams struct {
 	// Weight is the validator's weight when sampling validators.
 	// Weight for subnet validators is set to 20 by default
 	Weight uint64
+	// Memo tags the AddSubnetValidatorTx, e.g. with the ID of the ticket of the operation.
+	// The memo of the subnet DeployParams is used when not set
+	Memo []byte
 }
@@ -0,0 +1,145 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrInvalidChainConfig = errors.New("invalid chain config")
+
+	// chainConfigLogLevels are the log levels accepted by Subnet-EVM
+	chainConfigLogLevels = []string{"trace", "debug", "info", "warn", "error", "crit"}
+)
+
+// ChainConfig is the node-side config of a Subnet-EVM blockchain, written to the config.json
+// file of its chain config dir. Options left unset are not written, so Subnet-EVM uses its
+// default for them
+type ChainConfig struct {
+	// Pruning keeps only the recent state tries when enabled
+	Pruning *bool
+
+	// CommitInterval is the number of blocks between state trie commits, required to be
+	// positive when Pruning is enabled
+	CommitInterval *uint64
+
+	// StateSyncEnabled makes the node bootstrap from a state summary instead of replaying
+	// the blocks
+	StateSyncEnabled *bool
+
+	// StateSyncMinBlocks is the minimum number of blocks the chain must be ahead of the
+	// node for it to state sync
+	StateSyncMinBlocks *uint64
+
+	// LogLevel is the log level of the VM: trace, debug, info, warn, error or crit
+	LogLevel string
+
+	// FeeRecipient is the hex address receiving the fees of the blocks built by the node.
+	// The genesis of the blockchain must allow fee recipients
+	FeeRecipient string
+
+	// RPCGasCap is the maximum gas of eth_call and eth_estimateGas
+	RPCGasCap *uint64
+
+	// RPCTxFeeCap is the maximum fee, in DIONE, of a tx sent through the RPC
+	RPCTxFeeCap *float64
+
+	// EthAPIs are the eth APIs enabled on the RPC, e.g. eth, eth-filter, net, web3
+	EthAPIs []string
+
+	// Extra holds the Subnet-EVM options with no field above, by config.json key
+	Extra map[string]interface{}
+}
+
+// Bool returns a pointer to b, to set the bool options of ChainConfig
+func Bool(b bool) *bool {
+	return &b
+}
+
+// Uint64 returns a pointer to n, to set the uint64 options of ChainConfig
+func Uint64(n uint64) *uint64 {
+	return &n
+}
+
+// Float64 returns a pointer to f, to set the float64 options of ChainConfig
+func Float64(f float64) *float64 {
+	return &f
+}
+
+// Validate checks the options of c as Subnet-EVM does on startup
+func (c ChainConfig) Validate() error {
+	if c.Pruning != nil && *c.Pruning && c.CommitInterval != nil && *c.CommitInterval == 0 {
+		return fmt.Errorf("%w: commit interval cannot be 0 with pruning enabled", ErrInvalidChainConfig)
+	}
+	if c.LogLevel != "" && !slices.Contains(chainConfigLogLevels, c.LogLevel) {
+		return fmt.Errorf("%w: unknown log level %q", ErrInvalidChainConfig, c.LogLevel)
+	}
+	if c.FeeRecipient != "" && !common.IsHexAddress(c.FeeRecipient) {
+		return fmt.Errorf("%w: fee recipient %q is not a hex address", ErrInvalidChainConfig, c.FeeRecipient)
+	}
+	if c.RPCTxFeeCap != nil && *c.RPCTxFeeCap < 0 {
+		return fmt.Errorf("%w: negative RPC tx fee cap %f", ErrInvalidChainConfig, *c.RPCTxFeeCap)
+	}
+	typedOptions := c.typedOptions()
+	for key := range c.Extra {
+		if _, ok := typedOptions[key]; ok {
+			return fmt.Errorf("%w: extra option %q is set by a field", ErrInvalidChainConfig, key)
+		}
+	}
+	return nil
+}
+
+// Marshal validates c and returns the content of its config.json file
+func (c ChainConfig) Marshal() ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	options := map[string]interface{}{}
+	for key, value := range c.Extra {
+		options[key] = value
+	}
+	for key, value := range c.typedOptions() {
+		options[key] = value
+	}
+	return json.MarshalIndent(options, "", "  ")
+}
+
+// typedOptions maps the config.json keys of the fields of c to the set values
+func (c ChainConfig) typedOptions() map[string]interface{} {
+	options := map[string]interface{}{}
+	if c.Pruning != nil {
+		options["pruning-enabled"] = *c.Pruning
+	}
+	if c.CommitInterval != nil {
+		options["commit-interval"] = *c.CommitInterval
+	}
+	if c.StateSyncEnabled != nil {
+		options["state-sync-enabled"] = *c.StateSyncEnabled
+	}
+	if c.StateSyncMinBlocks != nil {
+		options["state-sync-min-blocks"] = *c.StateSyncMinBlocks
+	}
+	if c.LogLevel != "" {
+		options["log-level"] = c.LogLevel
+	}
+	if c.FeeRecipient != "" {
+		options["feeRecipient"] = c.FeeRecipient
+	}
+	if c.RPCGasCap != nil {
+		options["rpc-gas-cap"] = *c.RPCGasCap
+	}
+	if c.RPCTxFeeCap != nil {
+		options["rpc-tx-fee-cap"] = *c.RPCTxFeeCap
+	}
+	if len(c.EthAPIs) > 0 {
+		options["eth-apis"] = c.EthAPIs
+	}
+	return options
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      ChainConfig
+		expectedErr error
+	}{
+		{name: "empty", config: ChainConfig{}},
+		{
+			name: "all options",
+			config: ChainConfig{
+				Pruning:            Bool(true),
+				CommitInterval:     Uint64(4096),
+				StateSyncEnabled:   Bool(true),
+				StateSyncMinBlocks: Uint64(300000),
+				LogLevel:           "debug",
+				FeeRecipient:       "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC",
+				RPCGasCap:          Uint64(50_000_000),
+				RPCTxFeeCap:        Float64(100),
+				EthAPIs:            []string{"eth", "eth-filter"},
+				Extra:              map[string]interface{}{"allow-unfinalized-queries": true},
+			},
+		},
+		{name: "pruning with no commit interval", config: ChainConfig{Pruning: Bool(true), CommitInterval: Uint64(0)}, expectedErr: ErrInvalidChainConfig},
+		{name: "unknown log level", config: ChainConfig{LogLevel: "verbose"}, expectedErr: ErrInvalidChainConfig},
+		{name: "invalid fee recipient", config: ChainConfig{FeeRecipient: "P-dione1abc"}, expectedErr: ErrInvalidChainConfig},
+		{name: "negative tx fee cap", config: ChainConfig{RPCTxFeeCap: Float64(-1)}, expectedErr: ErrInvalidChainConfig},
+		{
+			name:        "extra option set by a field",
+			config:      ChainConfig{LogLevel: "info", Extra: map[string]interface{}{"log-level": "debug"}},
+			expectedErr: ErrInvalidChainConfig,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestChainConfig_Marshal(t *testing.T) {
+	configBytes, err := ChainConfig{}.Marshal()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(configBytes))
+
+	configBytes, err = ChainConfig{
+		Pruning:          Bool(false),
+		StateSyncEnabled: Bool(true),
+		LogLevel:         "info",
+		FeeRecipient:     "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC",
+		RPCGasCap:        Uint64(50_000_000),
+		EthAPIs:          []string{"eth"},
+		Extra:            map[string]interface{}{"allow-unfinalized-queries": true},
+	}.Marshal()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"pruning-enabled": false,
+		"state-sync-enabled": true,
+		"log-level": "info",
+		"feeRecipient": "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC",
+		"rpc-gas-cap": 50000000,
+		"eth-apis": ["eth"],
+		"allow-unfinalized-queries": true
+	}`, string(configBytes))
+
+	_, err = ChainConfig{LogLevel: "verbose"}.Marshal()
+	require.ErrorIs(t, err, ErrInvalidChainConfig)
+}
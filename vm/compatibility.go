@@ -0,0 +1,122 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DioneProtocol/odysseygo/version"
+	"golang.org/x/mod/semver"
+)
+
+var (
+	ErrUnknownOdysseyGoVersion = errors.New("unknown odysseygo version")
+	ErrUnknownSubnetEVMVersion = errors.New("unknown subnet-evm version")
+	ErrIncompatibleVMVersion   = errors.New("incompatible vm version")
+)
+
+// subnetEVMRPCChainVMProtocol maps the Subnet-EVM releases to the RPC chain VM protocol
+// version they implement, as published in the compatibility.json file of Subnet-EVM.
+// A node can only run a VM implementing its own RPC chain VM protocol version
+var subnetEVMRPCChainVMProtocol = map[string]uint{
+	"v0.5.6":  28,
+	"v0.5.5":  28,
+	"v0.5.4":  28,
+	"v0.5.3":  27,
+	"v0.5.2":  26,
+	"v0.5.1":  26,
+	"v0.5.0":  25,
+	"v0.4.12": 24,
+	"v0.4.11": 24,
+	"v0.4.10": 23,
+	"v0.4.9":  23,
+	"v0.4.8":  22,
+	"v0.4.7":  21,
+	"v0.4.6":  20,
+	"v0.4.5":  20,
+	"v0.4.4":  19,
+	"v0.4.3":  19,
+	"v0.4.2":  18,
+	"v0.4.1":  18,
+	"v0.4.0":  17,
+	"v0.3.0":  16,
+}
+
+// OdysseyGoRPCChainVMProtocol returns the RPC chain VM protocol version of an odysseygo
+// release, e.g. v1.10.9
+func OdysseyGoRPCChainVMProtocol(odysseyGoVersion string) (uint, error) {
+	odysseyGoVersion = normalizeVersion(odysseyGoVersion)
+	if odysseyGoVersion == version.Current.String() {
+		return version.RPCChainVMProtocol, nil
+	}
+	for protocol, releases := range version.RPCChainVMProtocolCompatibility {
+		for _, release := range releases {
+			if release.String() == odysseyGoVersion {
+				return protocol, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w %s", ErrUnknownOdysseyGoVersion, odysseyGoVersion)
+}
+
+// SubnetEVMRPCChainVMProtocol returns the RPC chain VM protocol version of a Subnet-EVM
+// release, e.g. v0.5.6
+func SubnetEVMRPCChainVMProtocol(subnetEVMVersion string) (uint, error) {
+	protocol, ok := subnetEVMRPCChainVMProtocol[normalizeVersion(subnetEVMVersion)]
+	if !ok {
+		return 0, fmt.Errorf("%w %s", ErrUnknownSubnetEVMVersion, subnetEVMVersion)
+	}
+	return protocol, nil
+}
+
+// CompatibleSubnetEVMVersions returns the Subnet-EVM releases an odysseygo release can run,
+// newest first
+func CompatibleSubnetEVMVersions(odysseyGoVersion string) ([]string, error) {
+	protocol, err := OdysseyGoRPCChainVMProtocol(odysseyGoVersion)
+	if err != nil {
+		return nil, err
+	}
+	compatible := []string{}
+	for subnetEVMVersion, subnetEVMProtocol := range subnetEVMRPCChainVMProtocol {
+		if subnetEVMProtocol == protocol {
+			compatible = append(compatible, subnetEVMVersion)
+		}
+	}
+	sort.Slice(compatible, func(i, j int) bool {
+		return semver.Compare(compatible[i], compatible[j]) > 0
+	})
+	return compatible, nil
+}
+
+// CheckSubnetEVMCompatibility returns ErrIncompatibleVMVersion if an odysseygo release cannot
+// run a Subnet-EVM release, as the node would fail to start the VM
+func CheckSubnetEVMCompatibility(odysseyGoVersion, subnetEVMVersion string) error {
+	odysseyGoProtocol, err := OdysseyGoRPCChainVMProtocol(odysseyGoVersion)
+	if err != nil {
+		return err
+	}
+	subnetEVMProtocol, err := SubnetEVMRPCChainVMProtocol(subnetEVMVersion)
+	if err != nil {
+		return err
+	}
+	if odysseyGoProtocol != subnetEVMProtocol {
+		return fmt.Errorf(
+			"%w: subnet-evm %s implements RPC chain VM protocol %d while odysseygo %s requires %d",
+			ErrIncompatibleVMVersion, normalizeVersion(subnetEVMVersion), subnetEVMProtocol, normalizeVersion(odysseyGoVersion), odysseyGoProtocol,
+		)
+	}
+	return nil
+}
+
+// normalizeVersion adds the v prefix of the release tags to version, e.g. 1.10.9 -> v1.10.9
+func normalizeVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if version != "" && !strings.HasPrefix(version, "v") {
+		return "v" + version
+	}
+	return version
+}
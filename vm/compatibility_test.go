@@ -0,0 +1,66 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompatibleSubnetEVMVersions(t *testing.T) {
+	versions, err := CompatibleSubnetEVMVersions("v1.10.9")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v0.5.6", "v0.5.5", "v0.5.4"}, versions)
+
+	versions, err = CompatibleSubnetEVMVersions("1.10.1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v0.5.2", "v0.5.1"}, versions)
+
+	_, err = CompatibleSubnetEVMVersions("v9.9.9")
+	require.ErrorIs(t, err, ErrUnknownOdysseyGoVersion)
+}
+
+func TestCheckSubnetEVMCompatibility(t *testing.T) {
+	tests := []struct {
+		name             string
+		odysseyGoVersion string
+		subnetEVMVersion string
+		expectedErr      error
+	}{
+		{
+			name:             "compatible",
+			odysseyGoVersion: "v1.10.10",
+			subnetEVMVersion: "v0.5.6",
+		},
+		{
+			name:             "compatible without v prefix",
+			odysseyGoVersion: "1.10.5",
+			subnetEVMVersion: "0.5.3",
+		},
+		{
+			name:             "incompatible",
+			odysseyGoVersion: "v1.10.9",
+			subnetEVMVersion: "v0.5.3",
+			expectedErr:      ErrIncompatibleVMVersion,
+		},
+		{
+			name:             "unknown subnet-evm",
+			odysseyGoVersion: "v1.10.9",
+			subnetEVMVersion: "v0.9.0",
+			expectedErr:      ErrUnknownSubnetEVMVersion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSubnetEVMCompatibility(tt.odysseyGoVersion, tt.subnetEVMVersion)
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
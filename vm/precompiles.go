@@ -31,6 +31,24 @@ import (
 // 	return warpConfig
 // }
 
+// AllowListRole returns the role of address in allowListConfig, e.g. the genesis config of a
+// precompile: AdminRole, ManagerRole, EnabledRole, or NoRole if it is in none of its lists.
+// The admins may have changed the roles on the chain since, see evm.AllowListRole
+func AllowListRole(
+	allowListConfig allowlist.AllowListConfig,
+	address common.Address,
+) allowlist.Role {
+	switch {
+	case utils.Belongs(allowListConfig.AdminAddresses, address):
+		return allowlist.AdminRole
+	case utils.Belongs(allowListConfig.ManagerAddresses, address):
+		return allowlist.ManagerRole
+	case utils.Belongs(allowListConfig.EnabledAddresses, address):
+		return allowlist.EnabledRole
+	}
+	return allowlist.NoRole
+}
+
 // adds an address to the given allowlist, as an Allowed address,
 // if it is not yet Admin, Manager or Allowed
 func addAddressToAllowed(
@@ -38,26 +56,7 @@ func addAddressToAllowed(
 	addressStr string,
 ) allowlist.AllowListConfig {
 	address := common.HexToAddress(addressStr)
-	allowed := false
-	if utils.Belongs(
-		allowListConfig.AdminAddresses,
-		address,
-	) {
-		allowed = true
-	}
-	if utils.Belongs(
-		allowListConfig.ManagerAddresses,
-		address,
-	) {
-		allowed = true
-	}
-	if utils.Belongs(
-		allowListConfig.EnabledAddresses,
-		address,
-	) {
-		allowed = true
-	}
-	if !allowed {
+	if AllowListRole(allowListConfig, address).IsNoRole() {
 		allowListConfig.EnabledAddresses = append(
 			allowListConfig.EnabledAddresses,
 			address,
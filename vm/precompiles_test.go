@@ -120,3 +120,25 @@ func TestAddAddressToAllowed(t *testing.T) {
 		}
 	})
 }
+
+func TestAllowListRole(t *testing.T) {
+	admin := common.HexToAddress("0x01")
+	manager := common.HexToAddress("0x02")
+	enabled := common.HexToAddress("0x03")
+	allowListConfig := allowlist.AllowListConfig{
+		AdminAddresses:   []common.Address{admin},
+		ManagerAddresses: []common.Address{manager},
+		EnabledAddresses: []common.Address{enabled},
+	}
+	tests := map[common.Address]allowlist.Role{
+		admin:                       allowlist.AdminRole,
+		manager:                     allowlist.ManagerRole,
+		enabled:                     allowlist.EnabledRole,
+		common.HexToAddress("0x04"): allowlist.NoRole,
+	}
+	for address, expected := range tests {
+		if role := AllowListRole(allowListConfig, address); role != expected {
+			t.Errorf("Expected role %s for %s, got %s", expected, address.Hex(), role)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/math"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary/common"
+)
+
+var (
+	ErrInvalidThreshold = errors.New("threshold must be between 1 and the number of control keys")
+	ErrEmptySubnetID    = errors.New("subnet ID cannot be empty")
+	ErrEmptyChainName   = errors.New("blockchain name cannot be empty")
+	ErrEmptyVMID        = errors.New("VM ID cannot be empty")
+	ErrEmptyGenesis     = errors.New("genesis cannot be empty")
+)
+
+// CreateSubnetResult describes a subnet created by CreateSubnet
+type CreateSubnetResult struct {
+	// SubnetID is the ID of the subnet, which is the ID of its CreateSubnetTx
+	SubnetID ids.ID
+	TxID     ids.ID
+
+	// Fee is the amount of nDIONE burned by the tx
+	Fee uint64
+}
+
+// CreateBlockchainResult describes a blockchain created by CreateBlockchain
+type CreateBlockchainResult struct {
+	SubnetID ids.ID
+
+	// BlockchainID is the ID of the blockchain, which is the ID of its CreateChainTx
+	BlockchainID ids.ID
+	TxID         ids.ID
+
+	// Fee is the amount of nDIONE burned by the tx
+	Fee uint64
+}
+
+// CreateSubnet builds, signs and issues a CreateSubnetTx owned by threshold of controlKeys,
+// and waits for it to be accepted. The subnet is owned by the first key of the wallet if no
+// control key is given. It is meant for setups whose wallet holds enough keys to sign the
+// tx: use subnet.Subnet.CreateSubnetTx and multisig for the others
+func (w *Wallet) CreateSubnet(ctx context.Context, controlKeys []ids.ShortID, threshold uint32) (*CreateSubnetResult, error) {
+	if w.Wallet == nil {
+		return nil, ErrOfflineWalletNoNetwork
+	}
+	if len(controlKeys) == 0 {
+		addrs := w.Addresses()
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("%w: the wallet holds no key", ErrInvalidThreshold)
+		}
+		controlKeys, threshold = addrs[:1], 1
+	}
+	if threshold == 0 || int(threshold) > len(controlKeys) {
+		return nil, fmt.Errorf("%w: %d of %d", ErrInvalidThreshold, threshold, len(controlKeys))
+	}
+	owners := &secp256k1fx.OutputOwners{Threshold: threshold, Addrs: append([]ids.ShortID{}, controlKeys...)}
+	owners.Sort()
+	tx, err := w.O().IssueCreateSubnetTx(owners, common.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create subnet: %w", err)
+	}
+	fee, err := burnedFee(tx.Unsigned.(*txs.CreateSubnetTx).BaseTx, w.O().DIONEAssetID())
+	if err != nil {
+		return nil, err
+	}
+	return &CreateSubnetResult{SubnetID: tx.ID(), TxID: tx.ID(), Fee: fee}, nil
+}
+
+// CreateBlockchain builds, signs and issues a CreateChainTx of a blockchain named chainName
+// on subnetID, running vmID with genesis, and waits for it to be accepted. The wallet must
+// hold enough control keys of the subnet to sign the tx, and know its owners: subnets not
+// created by the wallet must be in the OChainTxsToFetch of its config
+func (w *Wallet) CreateBlockchain(
+	ctx context.Context,
+	subnetID ids.ID,
+	chainName string,
+	vmID ids.ID,
+	genesis []byte,
+) (*CreateBlockchainResult, error) {
+	switch {
+	case w.Wallet == nil:
+		return nil, ErrOfflineWalletNoNetwork
+	case subnetID == ids.Empty:
+		return nil, ErrEmptySubnetID
+	case chainName == "":
+		return nil, ErrEmptyChainName
+	case vmID == ids.Empty:
+		return nil, ErrEmptyVMID
+	case len(genesis) == 0:
+		return nil, ErrEmptyGenesis
+	}
+	tx, err := w.O().IssueCreateChainTx(subnetID, genesis, vmID, nil, chainName, common.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blockchain %s on subnet %s: %w", chainName, subnetID, err)
+	}
+	fee, err := burnedFee(tx.Unsigned.(*txs.CreateChainTx).BaseTx, w.O().DIONEAssetID())
+	if err != nil {
+		return nil, err
+	}
+	return &CreateBlockchainResult{SubnetID: subnetID, BlockchainID: tx.ID(), TxID: tx.ID(), Fee: fee}, nil
+}
+
+// burnedFee returns the amount of assetID consumed and not produced by tx
+func burnedFee(tx txs.BaseTx, assetID ids.ID) (uint64, error) {
+	var consumed, produced uint64
+	var err error
+	for _, in := range tx.Ins {
+		if in.AssetID() == assetID {
+			if consumed, err = math.Add64(consumed, in.In.Amount()); err != nil {
+				return 0, err
+			}
+		}
+	}
+	for _, out := range tx.Outs {
+		if out.AssetID() == assetID {
+			if produced, err = math.Add64(produced, out.Out.Amount()); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return math.Sub(consumed, produced)
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odysseytest"
+	"github.com/DioneProtocol/odysseygo/genesis"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/units"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWallet_CreateSubnetAndBlockchain(t *testing.T) {
+	srv := odysseytest.NewServer()
+	defer srv.Close()
+	kc, err := odysseytest.NewKeychain(1)
+	require.NoError(t, err)
+	addr := kc.Addresses().List()[0]
+	srv.AddUTXOs(odysseytest.NewUTXO(srv.DIONEAssetID(), units.Dione, odysseytest.Owners(1, addr)))
+	w, err := New(context.Background(), &primary.WalletConfig{URI: srv.URI(), DIONEKeychain: kc})
+	require.NoError(t, err)
+	fees := genesis.LocalParams.TxFeeConfig
+
+	_, err = w.CreateSubnet(context.Background(), []ids.ShortID{addr}, 2)
+	require.ErrorIs(t, err, ErrInvalidThreshold)
+
+	subnet, err := w.CreateSubnet(context.Background(), nil, 0)
+	require.NoError(t, err)
+	assert.Equal(t, subnet.TxID, subnet.SubnetID)
+	assert.Equal(t, fees.CreateSubnetTxFee, subnet.Fee)
+	require.Len(t, srv.IssuedTxs(), 1)
+	assert.Equal(t, subnet.TxID, srv.IssuedTxs()[0].ID())
+
+	_, err = w.CreateBlockchain(context.Background(), subnet.SubnetID, "", ids.GenerateTestID(), []byte("{}"))
+	require.ErrorIs(t, err, ErrEmptyChainName)
+	chain, err := w.CreateBlockchain(context.Background(), subnet.SubnetID, "test", ids.GenerateTestID(), []byte("{}"))
+	require.NoError(t, err)
+	assert.Equal(t, subnet.SubnetID, chain.SubnetID)
+	assert.Equal(t, chain.TxID, chain.BlockchainID)
+	assert.Equal(t, fees.CreateBlockchainTxFee, chain.Fee)
+	require.Len(t, srv.IssuedTxs(), 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = w.CreateSubnet(ctx, nil, 0)
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = (&Wallet{}).CreateSubnet(context.Background(), nil, 0)
+	require.ErrorIs(t, err, ErrOfflineWalletNoNetwork)
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/stakeable"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/chain/o"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary/common"
+)
+
+var (
+	ErrFeePayerNotInKeychain = errors.New("fee payer is not in the wallet keychain")
+	ErrFeePayerNotSupported  = errors.New("fee payer is only supported by the wallets made by New")
+)
+
+// WithFeePayer makes the wallet pay the O-Chain txs with the UTXOs of feePayer only, see
+// Wallet.SetFeePayer
+func WithFeePayer(feePayer ids.ShortID) WalletOption {
+	return func(op *WalletOp) {
+		op.feePayer = feePayer
+	}
+}
+
+// SetFeePayer makes the O-Chain txs built by the wallet spend the UTXOs of feePayer only, and
+// send their change back to it, while the subnet txs are still authorized by the keys set
+// with SetSubnetAuthMultisig. A sponsor account can thus pay the CreateChainTx and
+// AddSubnetValidatorTx of a subnet owned by a multisig, the control keys only signing the
+// subnet auth. feePayer must be in the wallet keychain. ids.ShortEmpty restores the default
+// of spending the UTXOs of any wallet address
+func (w *Wallet) SetFeePayer(feePayer ids.ShortID) error {
+	if feePayer == ids.ShortEmpty {
+		w.feePayer = ids.ShortEmpty
+		return nil
+	}
+	if w.reservations == nil {
+		return ErrFeePayerNotSupported
+	}
+	if !w.reservations.addrs.Contains(feePayer) {
+		return fmt.Errorf("%w: %s", ErrFeePayerNotInKeychain, feePayer)
+	}
+	w.feePayer = feePayer
+	return nil
+}
+
+// FeePayer returns the address paying the O-Chain txs of the wallet, or ids.ShortEmpty if any
+// wallet address does
+func (w *Wallet) FeePayer() ids.ShortID {
+	return w.feePayer
+}
+
+// feePayerOptions returns options followed by the change owner of feePayer
+func feePayerOptions(options []common.Option, feePayer ids.ShortID) []common.Option {
+	withChangeOwner := make([]common.Option, 0, len(options)+1)
+	withChangeOwner = append(withChangeOwner, options...)
+	return append(withChangeOwner, common.WithChangeOwner(&secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{feePayer},
+	}))
+}
+
+// feePayerBackend hides from the builder it backs the O-Chain UTXOs feePayer cannot spend
+// alone
+type feePayerBackend struct {
+	o.BuilderBackend
+	feePayer set.Set[ids.ShortID]
+}
+
+func (b *feePayerBackend) UTXOs(ctx context.Context, sourceChainID ids.ID) ([]*dione.UTXO, error) {
+	utxos, err := b.BuilderBackend.UTXOs(ctx, sourceChainID)
+	if err != nil || sourceChainID != constants.OmegaChainID {
+		return utxos, err
+	}
+	now := uint64(time.Now().Unix())
+	spendable := make([]*dione.UTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		out := utxo.Out
+		if lockedOut, ok := out.(*stakeable.LockOut); ok {
+			out = lockedOut.TransferableOut
+		}
+		transferOut, ok := out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			continue
+		}
+		if _, ok := common.MatchOwners(&transferOut.OutputOwners, b.feePayer, now); ok {
+			spendable = append(spendable, utxo)
+		}
+	}
+	return spendable, nil
+}
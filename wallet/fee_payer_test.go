@@ -0,0 +1,82 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/keychain"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/chain/o"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWallet_SetFeePayer(t *testing.T) {
+	factory := secp256k1.Factory{}
+	sponsorKey, err := factory.NewPrivateKey()
+	require.NoError(t, err)
+	controlKey, err := factory.NewPrivateKey()
+	require.NoError(t, err)
+	sponsor, control, remote := sponsorKey.Address(), controlKey.Address(), ids.GenerateTestShortID()
+	kc := secp256k1fx.NewKeychain(sponsorKey, controlKey)
+
+	dioneAssetID := ids.GenerateTestID()
+	utxos := primary.NewChainUTXOs(constants.OmegaChainID, primary.NewUTXOs())
+	for addr, amount := range map[ids.ShortID]uint64{sponsor: 1000, control: 5000} {
+		require.NoError(t, utxos.AddUTXO(context.Background(), constants.OmegaChainID, &dione.UTXO{
+			UTXOID: dione.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  dione.Asset{ID: dioneAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amount,
+				OutputOwners: secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr}},
+			},
+		}))
+	}
+	subnetID := ids.GenerateTestID()
+	backend := o.NewBackend(
+		o.NewContext(constants.TestnetID, dioneAssetID, 0, 0, 0, 0, 0, 0, 100, 0),
+		utxos,
+		map[ids.ID]*txs.Tx{subnetID: {Unsigned: &txs.CreateSubnetTx{
+			Owner: &secp256k1fx.OutputOwners{Threshold: 2, Addrs: []ids.ShortID{control, remote}},
+		}}},
+	)
+	w := Wallet{
+		Wallet:       primary.NewWallet(o.NewWallet(o.NewBuilder(kc.Addresses(), backend), nil, nil, backend), nil, nil),
+		Keychain:     keychain.NewKeychainFromExisting(kc, odyssey.Network{}),
+		reservations: newUTXOReservations(backend, kc.Addresses(), WalletOp{}),
+	}
+
+	require.ErrorIs(t, w.SetFeePayer(remote), ErrFeePayerNotInKeychain)
+	require.NoError(t, w.SetFeePayer(sponsor))
+	assert.Equal(t, sponsor, w.FeePayer())
+	w.SetSubnetAuthMultisig([]ids.ShortID{control, remote})
+
+	vdr := &txs.SubnetValidator{Validator: txs.Validator{NodeID: ids.GenerateTestNodeID(), Wght: 20}, Subnet: subnetID}
+	utx, err := w.O().Builder().NewAddSubnetValidatorTx(vdr)
+	require.NoError(t, err)
+	require.Len(t, utx.Ins, 1)
+	assert.Equal(t, uint64(1000), utx.Ins[0].In.Amount())
+	require.Len(t, utx.Outs, 1)
+	assert.Equal(t, []ids.ShortID{sponsor}, utx.Outs[0].Out.(*secp256k1fx.TransferOutput).Addrs)
+	assert.Len(t, utx.SubnetAuth.(*secp256k1fx.Input).SigIndices, 2)
+
+	// the UTXOs of the control keys are not spent once the fee payer has none left
+	_, err = w.O().Builder().NewAddSubnetValidatorTx(vdr)
+	require.Error(t, err)
+
+	require.NoError(t, w.SetFeePayer(ids.ShortEmpty))
+	_, err = w.O().Builder().NewAddSubnetValidatorTx(vdr)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, (&Wallet{}).SetFeePayer(sponsor), ErrFeePayerNotSupported)
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/key"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary/common"
+)
+
+// fundBatchMaxOutputs keeps the txs of FundBatch well below the O-Chain tx size limit.
+// Each tx pays the CreateSubnetTx fee, so keys are funded by as few txs as possible
+const fundBatchMaxOutputs = 128
+
+var (
+	ErrFundMainnet       = errors.New("funding batches of keys is only allowed on test networks")
+	ErrNoKeysToFund      = errors.New("no keys to fund")
+	ErrInvalidFundAmount = errors.New("amount to fund must be positive")
+)
+
+// FundBatch sends amountEach nDIONE on the O-Chain from the wallet keys, e.g. a faucet key,
+// to each of keys, as generated by key.GenerateBatch. Keys are funded by txs of up to 128
+// outputs, whose IDs are returned in issuance order. It is refused on mainnet
+func (w *Wallet) FundBatch(ctx context.Context, keys []*key.SoftKey, amountEach uint64) ([]ids.ID, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeysToFund
+	}
+	if amountEach == 0 {
+		return nil, ErrInvalidFundAmount
+	}
+	if w.Wallet == nil {
+		return nil, ErrOfflineWalletNoNetwork
+	}
+	oWallet := w.O()
+	if oWallet.NetworkID() == constants.MainnetID {
+		return nil, ErrFundMainnet
+	}
+	txIDs := []ids.ID{}
+	for _, outputs := range fundBatchOutputs(oWallet.DIONEAssetID(), keys, amountEach, fundBatchMaxOutputs) {
+		tx, err := oWallet.IssueBaseTx(outputs, common.WithContext(ctx))
+		if err != nil {
+			return txIDs, fmt.Errorf("failed to fund %d keys after %d txs: %w", len(keys), len(txIDs), err)
+		}
+		txIDs = append(txIDs, tx.ID())
+	}
+	return txIDs, nil
+}
+
+// fundBatchOutputs returns the outputs sending amountEach of assetID to each of keys, grouped
+// by maxOutputs
+func fundBatchOutputs(assetID ids.ID, keys []*key.SoftKey, amountEach uint64, maxOutputs int) [][]*dione.TransferableOutput {
+	batches := [][]*dione.TransferableOutput{}
+	for start := 0; start < len(keys); start += maxOutputs {
+		batch := keys[start:min(start+maxOutputs, len(keys))]
+		outputs := make([]*dione.TransferableOutput, 0, len(batch))
+		for _, k := range batch {
+			outputs = append(outputs, &dione.TransferableOutput{
+				Asset: dione.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: amountEach,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{k.Addresses()[0]},
+					},
+				},
+			})
+		}
+		batches = append(batches, outputs)
+	}
+	return batches
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/key"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/keychain"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFundBatchOutputs(t *testing.T) {
+	keys, err := key.GenerateBatch(5, filepath.Join(t.TempDir(), "keys"))
+	require.NoError(t, err)
+	assetID := ids.GenerateTestID()
+
+	batches := fundBatchOutputs(assetID, keys, 1000, 2)
+	require.Len(t, batches, 3)
+	assert.Len(t, batches[0], 2)
+	assert.Len(t, batches[2], 1)
+	i := 0
+	for _, outputs := range batches {
+		for _, output := range outputs {
+			assert.Equal(t, assetID, output.AssetID())
+			out, ok := output.Out.(*secp256k1fx.TransferOutput)
+			require.True(t, ok)
+			assert.Equal(t, uint64(1000), out.Amt)
+			assert.Equal(t, keys[i].Addresses(), out.Addrs)
+			i++
+		}
+	}
+}
+
+func TestFundBatch_Errors(t *testing.T) {
+	keys, err := key.GenerateBatch(1, filepath.Join(t.TempDir(), "keys"))
+	require.NoError(t, err)
+	w := NewOffline(keychain.NewKeychainFromExisting(secp256k1fx.NewKeychain(), odyssey.TestnetNetwork()))
+
+	_, err = w.FundBatch(context.Background(), nil, 1000)
+	assert.ErrorIs(t, err, ErrNoKeysToFund)
+	_, err = w.FundBatch(context.Background(), keys, 0)
+	assert.ErrorIs(t, err, ErrInvalidFundAmount)
+	_, err = w.FundBatch(context.Background(), keys, 1000)
+	assert.ErrorIs(t, err, ErrOfflineWalletNoNetwork)
+}
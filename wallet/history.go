@@ -0,0 +1,202 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package wallet
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/constants"
+	"github.com/DioneProtocol/odysseygo/ids"
+)
+
+// TxStatus is the outcome of an issued tx as seen by the wallet
+type TxStatus string
+
+const (
+	// TxStatusIssued is the status of a tx accepted by the API node, not known to be committed yet
+	TxStatusIssued TxStatus = "issued"
+	// TxStatusCommitted is the status of a tx committed on chain
+	TxStatusCommitted TxStatus = "committed"
+	// TxStatusFailed is the status of a tx whose issuance or acceptance failed
+	TxStatusFailed TxStatus = "failed"
+)
+
+// TxRecord is the history entry of a tx issued by a wallet
+type TxRecord struct {
+	TxID ids.ID `json:"txID"`
+
+	// Kind is the type of the unsigned tx, e.g. CreateSubnetTx
+	Kind string `json:"kind"`
+
+	// Params is the JSON encoding of the unsigned tx
+	Params json.RawMessage `json:"params,omitempty"`
+
+	// NetworkID is the ID of the network the tx was issued to
+	NetworkID uint32 `json:"networkID"`
+
+	// Endpoint is the API endpoint of the wallet
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Timestamp is the time of the last status update of the tx
+	Timestamp time.Time `json:"timestamp"`
+
+	Status TxStatus `json:"status"`
+
+	// Error is the issuance error of failed txs
+	Error string `json:"error,omitempty"`
+}
+
+// TxHistoryStore persists the records of the txs issued by a wallet.
+// Implementations must be safe for concurrent use
+type TxHistoryStore interface {
+	// Record saves record, replacing any previous record of the same tx
+	Record(record TxRecord) error
+
+	// Records returns the latest record of each tx, in the order the txs were first recorded
+	Records() ([]TxRecord, error)
+}
+
+// MemoryTxHistory is a TxHistoryStore kept in memory
+type MemoryTxHistory struct {
+	lock    sync.Mutex
+	records []TxRecord
+}
+
+// NewMemoryTxHistory creates an empty in memory tx history
+func NewMemoryTxHistory() *MemoryTxHistory {
+	return &MemoryTxHistory{}
+}
+
+func (h *MemoryTxHistory) Record(record TxRecord) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.records = upsertRecord(h.records, record)
+	return nil
+}
+
+func (h *MemoryTxHistory) Records() ([]TxRecord, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return append([]TxRecord{}, h.records...), nil
+}
+
+// FileTxHistory is a TxHistoryStore appending the records as JSON lines to a local file
+type FileTxHistory struct {
+	lock sync.Mutex
+	path string
+}
+
+// NewFileTxHistory creates a tx history stored at path. The file is created on the first record
+func NewFileTxHistory(path string) *FileTxHistory {
+	return &FileTxHistory{path: path}
+}
+
+func (h *FileTxHistory) Record(record TxRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	file, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, constants.WriteReadUserOnlyPerms)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (h *FileTxHistory) Records() ([]TxRecord, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	file, err := os.Open(h.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []TxRecord{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	records := []TxRecord{}
+	scanner := bufio.NewScanner(file)
+	// params of txs such as CreateChainTx include the whole genesis
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var record TxRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("invalid tx history record at %s:%d: %w", h.path, line, err)
+		}
+		records = upsertRecord(records, record)
+	}
+	return records, scanner.Err()
+}
+
+func upsertRecord(records []TxRecord, record TxRecord) []TxRecord {
+	for i := range records {
+		if records[i].TxID == record.TxID {
+			records[i] = record
+			return records
+		}
+	}
+	return append(records, record)
+}
+
+// TxFilter selects tx history records. Zero fields match any record
+type TxFilter struct {
+	Kind      string
+	Status    TxStatus
+	NetworkID uint32
+	// Since and Until bound the record timestamps, inclusive
+	Since time.Time
+	Until time.Time
+}
+
+func (f TxFilter) matches(record TxRecord) bool {
+	return (f.Kind == "" || record.Kind == f.Kind) &&
+		(f.Status == "" || record.Status == f.Status) &&
+		(f.NetworkID == 0 || record.NetworkID == f.NetworkID) &&
+		(f.Since.IsZero() || !record.Timestamp.Before(f.Since)) &&
+		(f.Until.IsZero() || !record.Timestamp.After(f.Until))
+}
+
+// QueryTxHistory returns the records of store matching filter, oldest first
+func QueryTxHistory(store TxHistoryStore, filter TxFilter) ([]TxRecord, error) {
+	records, err := store.Records()
+	if err != nil {
+		return nil, err
+	}
+	matching := []TxRecord{}
+	for _, record := range records {
+		if filter.matches(record) {
+			matching = append(matching, record)
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		return matching[i].Timestamp.Before(matching[j].Timestamp)
+	})
+	return matching, nil
+}
+
+// FindTx returns the record of txID in store, if any
+func FindTx(store TxHistoryStore, txID ids.ID) (TxRecord, bool, error) {
+	records, err := store.Records()
+	if err != nil {
+		return TxRecord{}, false, err
+	}
+	for _, record := range records {
+		if record.TxID == txID {
+			return record, true, nil
+		}
+	}
+	return TxRecord{}, false, nil
+}
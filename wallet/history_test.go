@@ -0,0 +1,166 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/instrumentation"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/chain/o"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary/common"
+)
+
+func TestTxHistoryStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) TxHistoryStore{
+		"memory": func(*testing.T) TxHistoryStore { return NewMemoryTxHistory() },
+		"file": func(t *testing.T) TxHistoryStore {
+			return NewFileTxHistory(filepath.Join(t.TempDir(), "history.jsonl"))
+		},
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			records, err := store.Records()
+			require.NoError(t, err)
+			assert.Empty(t, records)
+
+			start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			subnetTxID, chainTxID := ids.GenerateTestID(), ids.GenerateTestID()
+			require.NoError(t, store.Record(TxRecord{TxID: subnetTxID, Kind: "CreateSubnetTx", NetworkID: 5, Timestamp: start, Status: TxStatusIssued}))
+			require.NoError(t, store.Record(TxRecord{TxID: chainTxID, Kind: "CreateChainTx", NetworkID: 5, Timestamp: start.Add(time.Minute), Status: TxStatusFailed, Error: "boom"}))
+			require.NoError(t, store.Record(TxRecord{TxID: subnetTxID, Kind: "CreateSubnetTx", NetworkID: 5, Timestamp: start.Add(2 * time.Minute), Status: TxStatusCommitted}))
+
+			records, err = store.Records()
+			require.NoError(t, err)
+			require.Len(t, records, 2)
+			assert.Equal(t, subnetTxID, records[0].TxID)
+			assert.Equal(t, TxStatusCommitted, records[0].Status)
+
+			record, found, err := FindTx(store, chainTxID)
+			require.NoError(t, err)
+			require.True(t, found)
+			assert.Equal(t, "boom", record.Error)
+			_, found, err = FindTx(store, ids.GenerateTestID())
+			require.NoError(t, err)
+			assert.False(t, found)
+
+			committed, err := QueryTxHistory(store, TxFilter{Status: TxStatusCommitted})
+			require.NoError(t, err)
+			require.Len(t, committed, 1)
+			assert.Equal(t, subnetTxID, committed[0].TxID)
+
+			// sorted by timestamp: the failed chain tx was last updated before the subnet tx
+			all, err := QueryTxHistory(store, TxFilter{NetworkID: 5, Since: start})
+			require.NoError(t, err)
+			require.Len(t, all, 2)
+			assert.Equal(t, chainTxID, all[0].TxID)
+
+			none, err := QueryTxHistory(store, TxFilter{Kind: "CreateChainTx", Until: start})
+			require.NoError(t, err)
+			assert.Empty(t, none)
+		})
+	}
+}
+
+// fakeOWallet issues txs calling the post issuance function, then failing with issueErr
+type fakeOWallet struct {
+	o.Wallet
+	issueErr error
+}
+
+func (*fakeOWallet) NetworkID() uint32 {
+	return 5
+}
+
+func (w *fakeOWallet) IssueTx(tx *txs.Tx, options ...common.Option) error {
+	if f := common.NewOptions(options).PostIssuanceFunc(); f != nil {
+		f(tx.ID())
+	}
+	return w.issueErr
+}
+
+func TestHistoryOWallet_IssueTx(t *testing.T) {
+	newTx := func(t *testing.T) *txs.Tx {
+		tx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{Owner: &secp256k1fx.OutputOwners{Threshold: 1}}}
+		require.NoError(t, tx.Initialize(txs.Codec))
+		return tx
+	}
+	errNotCommitted := errors.New("not committed")
+	tests := []struct {
+		name           string
+		issueErr       error
+		options        []common.Option
+		expectedStatus TxStatus
+	}{
+		{name: "committed", expectedStatus: TxStatusCommitted},
+		{name: "assume decided", options: []common.Option{common.WithAssumeDecided()}, expectedStatus: TxStatusIssued},
+		{name: "failed", issueErr: errNotCommitted, expectedStatus: TxStatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryTxHistory()
+			w := &historyOWallet{Wallet: &fakeOWallet{issueErr: tt.issueErr}, history: store, endpoint: "http://api"}
+			tx := newTx(t)
+			postIssued := false
+			options := append(tt.options, common.WithPostIssuanceFunc(func(ids.ID) { postIssued = true }))
+
+			err := w.IssueTx(tx, options...)
+			assert.ErrorIs(t, err, tt.issueErr)
+			assert.True(t, postIssued)
+
+			record, found, err := FindTx(store, tx.ID())
+			require.NoError(t, err)
+			require.True(t, found)
+			assert.Equal(t, tt.expectedStatus, record.Status)
+			assert.Equal(t, "CreateSubnetTx", record.Kind)
+			assert.Equal(t, uint32(5), record.NetworkID)
+			assert.Equal(t, "http://api", record.Endpoint)
+			assert.NotEmpty(t, record.Params)
+			if tt.issueErr != nil {
+				assert.Equal(t, tt.issueErr.Error(), record.Error)
+			}
+		})
+	}
+}
+
+func TestHistoryOWallet_Instrumentation(t *testing.T) {
+	errNotCommitted := errors.New("not committed")
+	requestErrs := []error{}
+	issued := []instrumentation.Tx{}
+	t.Cleanup(instrumentation.Register(instrumentation.Hooks{
+		OnRequestEnd: func(_ context.Context, req instrumentation.Request, _ time.Duration, err error) {
+			assert.Equal(t, instrumentation.Request{Component: instrumentation.ComponentWallet, Operation: "IssueTx", Target: "http://api"}, req)
+			requestErrs = append(requestErrs, err)
+		},
+		OnTxIssued: func(_ context.Context, tx instrumentation.Tx) {
+			issued = append(issued, tx)
+		},
+	}))
+
+	// without history, txs are only reported to the hooks
+	w := &historyOWallet{Wallet: &fakeOWallet{issueErr: errNotCommitted}, endpoint: "http://api"}
+	tx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{Owner: &secp256k1fx.OutputOwners{Threshold: 1}}}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	assert.ErrorIs(t, w.IssueTx(tx), errNotCommitted)
+	assert.Equal(t, []error{errNotCommitted}, requestErrs)
+	assert.Equal(t, []instrumentation.Tx{{ID: tx.ID(), Kind: "CreateSubnetTx", Chain: "O", Endpoint: "http://api"}}, issued)
+}
+
+func TestWallet_SetTxHistory(t *testing.T) {
+	w := Wallet{}
+	assert.Nil(t, w.TxHistory())
+	store := NewMemoryTxHistory()
+	w.SetTxHistory(store)
+	assert.Equal(t, store, w.TxHistory())
+}
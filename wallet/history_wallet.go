@@ -0,0 +1,349 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/instrumentation"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/signer"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/chain/o"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary/common"
+)
+
+// SetTxHistory makes the wallet record every O-Chain tx it issues into store. Recording is
+// best effort: a store failure never fails the issuance of a tx. A nil store disables recording
+func (w *Wallet) SetTxHistory(store TxHistoryStore) {
+	w.history = store
+}
+
+// TxHistory returns the tx history store of the wallet, or nil if txs are not recorded
+func (w *Wallet) TxHistory() TxHistoryStore {
+	return w.history
+}
+
+// O returns the O-Chain wallet, recording the issued txs when a tx history is set,
+// reporting them when instrumentation hooks are registered, reserving their UTXOs and paying
+// them with the fee payer if any for the wallets made by New, and checking them against the
+// signing policy if any
+func (w Wallet) O() o.Wallet {
+	if w.history == nil && !instrumentation.Enabled() && w.reservations == nil && w.policy == nil {
+		return w.Wallet.O()
+	}
+	return &historyOWallet{
+		Wallet:       w.Wallet.O(),
+		history:      w.history,
+		endpoint:     w.URI(),
+		reservations: w.reservations,
+		options:      w.options,
+		policy:       w.policy,
+		walletAddrs:  w.keychainAddrs(),
+		feePayer:     w.feePayer,
+	}
+}
+
+// historyOWallet records the txs issued by the O-Chain wallet it wraps, if history is set,
+// reports them to the instrumentation hooks, and reserves their UTXOs if reservations is set.
+// The Issue*Tx helpers are overridden so that they go through the Builder and IssueTx below
+type historyOWallet struct {
+	o.Wallet
+	history  TxHistoryStore
+	endpoint string
+
+	reservations *utxoReservations
+	// options of the wallet, applied to the builder reserving the UTXOs
+	options []common.Option
+	// feePayer pays the txs built by the builder reserving the UTXOs, if not empty
+	feePayer ids.ShortID
+
+	// policy checked before signing the txs held by walletAddrs, if set
+	policy      *SigningPolicy
+	walletAddrs set.Set[ids.ShortID]
+}
+
+// Builder returns the builder of the wrapped wallet, or one reserving the UTXOs of the txs
+// it builds if reservations is set
+func (w *historyOWallet) Builder() o.Builder {
+	if w.reservations == nil {
+		return w.Wallet.Builder()
+	}
+	return w.reservations.builder(w.options, w.feePayer)
+}
+
+// Signer returns the signer of the wrapped wallet, or one checking the txs against the
+// signing policy before signing them if policy is set
+func (w *historyOWallet) Signer() o.Signer {
+	if w.policy == nil {
+		return w.Wallet.Signer()
+	}
+	return &policySigner{
+		Signer:      w.Wallet.Signer(),
+		policy:      w.policy,
+		walletAddrs: w.walletAddrs,
+	}
+}
+
+// txKind is the type name of the unsigned tx of tx, e.g. CreateSubnetTx
+func txKind(tx *txs.Tx) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", tx.Unsigned), "*txs.")
+}
+
+func (w *historyOWallet) record(tx *txs.Tx, status TxStatus, issueErr error) {
+	if w.history == nil {
+		return
+	}
+	record := TxRecord{
+		TxID:      tx.ID(),
+		Kind:      txKind(tx),
+		NetworkID: w.NetworkID(),
+		Endpoint:  w.endpoint,
+		Timestamp: time.Now().UTC(),
+		Status:    status,
+	}
+	if params, err := json.Marshal(tx.Unsigned); err == nil {
+		record.Params = params
+	}
+	if issueErr != nil {
+		record.Error = issueErr.Error()
+	}
+	// recording is best effort, see SetTxHistory
+	_ = w.history.Record(record)
+}
+
+func (w *historyOWallet) IssueTx(tx *txs.Tx, options ...common.Option) error {
+	defer w.reservations.serialize()()
+	return w.issueTx(tx, options...)
+}
+
+func (w *historyOWallet) issueTx(tx *txs.Tx, options ...common.Option) error {
+	ops := common.NewOptions(options)
+	ctx, end := instrumentation.StartRequest(ops.Context(), instrumentation.ComponentWallet, "IssueTx", w.endpoint)
+	postIssuance := ops.PostIssuanceFunc()
+	issued := false
+	options = append(options, common.WithContext(ctx), common.WithPostIssuanceFunc(func(txID ids.ID) {
+		issued = true
+		w.record(tx, TxStatusIssued, nil)
+		instrumentation.RecordTxIssued(ctx, instrumentation.Tx{
+			ID:       txID,
+			Kind:     txKind(tx),
+			Chain:    "O",
+			Endpoint: w.endpoint,
+		})
+		if postIssuance != nil {
+			postIssuance(txID)
+		}
+	}))
+	err := w.Wallet.IssueTx(tx, options...)
+	// the UTXOs of an accepted tx are removed from the wallet. Those of an issued tx whose
+	// acceptance is not known stay reserved until the reservation times out
+	if err == nil || !issued {
+		w.reservations.release(tx.Unsigned.InputIDs())
+	}
+	switch {
+	case err != nil:
+		w.record(tx, TxStatusFailed, err)
+	case !ops.AssumeDecided():
+		w.record(tx, TxStatusCommitted, nil)
+	}
+	end(err)
+	return err
+}
+
+func (w *historyOWallet) IssueUnsignedTx(utx txs.UnsignedTx, options ...common.Option) (*txs.Tx, error) {
+	defer w.reservations.serialize()()
+	return w.issueUnsignedTx(utx, options...)
+}
+
+func (w *historyOWallet) issueUnsignedTx(utx txs.UnsignedTx, options ...common.Option) (*txs.Tx, error) {
+	ops := common.NewOptions(options)
+	tx, err := w.Signer().SignUnsigned(ops.Context(), utx)
+	if err != nil {
+		w.reservations.release(utx.InputIDs())
+		return nil, err
+	}
+	return tx, w.issueTx(tx, options...)
+}
+
+// issue builds the unsigned tx with build, then signs and issues it
+func (w *historyOWallet) issue(build func() (txs.UnsignedTx, error), options []common.Option) (*txs.Tx, error) {
+	defer w.reservations.serialize()()
+	utx, err := build()
+	if err != nil {
+		return nil, err
+	}
+	return w.issueUnsignedTx(utx, options...)
+}
+
+func (w *historyOWallet) IssueBaseTx(
+	outputs []*dione.TransferableOutput,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.issue(func() (txs.UnsignedTx, error) {
+		return w.Builder().NewBaseTx(outputs, options...)
+	}, options)
+}
+
+func (w *historyOWallet) IssueAddValidatorTx(
+	vdr *txs.Validator,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	shares uint32,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.issue(func() (txs.UnsignedTx, error) {
+		return w.Builder().NewAddValidatorTx(vdr, rewardsOwner, shares, options...)
+	}, options)
+}
+
+func (w *historyOWallet) IssueAddSubnetValidatorTx(
+	vdr *txs.SubnetValidator,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.issue(func() (txs.UnsignedTx, error) {
+		return w.Builder().NewAddSubnetValidatorTx(vdr, options...)
+	}, options)
+}
+
+func (w *historyOWallet) IssueRemoveSubnetValidatorTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.issue(func() (txs.UnsignedTx, error) {
+		return w.Builder().NewRemoveSubnetValidatorTx(nodeID, subnetID, options...)
+	}, options)
+}
+
+func (w *historyOWallet) IssueAddDelegatorTx(
+	vdr *txs.Validator,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.issue(func() (txs.UnsignedTx, error) {
+		return w.Builder().NewAddDelegatorTx(vdr, rewardsOwner, options...)
+	}, options)
+}
+
+func (w *historyOWallet) IssueCreateChainTx(
+	subnetID ids.ID,
+	genesis []byte,
+	vmID ids.ID,
+	fxIDs []ids.ID,
+	chainName string,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.issue(func() (txs.UnsignedTx, error) {
+		return w.Builder().NewCreateChainTx(subnetID, genesis, vmID, fxIDs, chainName, options...)
+	}, options)
+}
+
+func (w *historyOWallet) IssueCreateSubnetTx(
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.issue(func() (txs.UnsignedTx, error) {
+		return w.Builder().NewCreateSubnetTx(owner, options...)
+	}, options)
+}
+
+func (w *historyOWallet) IssueImportTx(
+	chainID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.issue(func() (txs.UnsignedTx, error) {
+		return w.Builder().NewImportTx(chainID, to, options...)
+	}, options)
+}
+
+func (w *historyOWallet) IssueExportTx(
+	chainID ids.ID,
+	outputs []*dione.TransferableOutput,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.issue(func() (txs.UnsignedTx, error) {
+		return w.Builder().NewExportTx(chainID, outputs, options...)
+	}, options)
+}
+
+func (w *historyOWallet) IssueTransformSubnetTx(
+	subnetID ids.ID,
+	assetID ids.ID,
+	initialSupply uint64,
+	maxSupply uint64,
+	minConsumptionRate uint64,
+	maxConsumptionRate uint64,
+	minValidatorStake uint64,
+	maxValidatorStake uint64,
+	minValidatorStakeDuration time.Duration,
+	maxValidatorStakeDuration time.Duration,
+	minDelegatorStakeDuration time.Duration,
+	maxDelegatorStakeDuration time.Duration,
+	minDelegationFee uint32,
+	minDelegatorStake uint64,
+	maxValidatorWeightFactor byte,
+	uptimeRequirement uint32,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.issue(func() (txs.UnsignedTx, error) {
+		return w.Builder().NewTransformSubnetTx(
+			subnetID,
+			assetID,
+			initialSupply,
+			maxSupply,
+			minConsumptionRate,
+			maxConsumptionRate,
+			minValidatorStake,
+			maxValidatorStake,
+			minValidatorStakeDuration,
+			maxValidatorStakeDuration,
+			minDelegatorStakeDuration,
+			maxDelegatorStakeDuration,
+			minDelegationFee,
+			minDelegatorStake,
+			maxValidatorWeightFactor,
+			uptimeRequirement,
+			options...,
+		)
+	}, options)
+}
+
+func (w *historyOWallet) IssueAddPermissionlessValidatorTx(
+	vdr *txs.SubnetValidator,
+	signer signer.Signer,
+	assetID ids.ID,
+	validationRewardsOwner *secp256k1fx.OutputOwners,
+	delegationRewardsOwner *secp256k1fx.OutputOwners,
+	shares uint32,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.issue(func() (txs.UnsignedTx, error) {
+		return w.Builder().NewAddPermissionlessValidatorTx(
+			vdr,
+			signer,
+			assetID,
+			validationRewardsOwner,
+			delegationRewardsOwner,
+			shares,
+			options...,
+		)
+	}, options)
+}
+
+func (w *historyOWallet) IssueAddPermissionlessDelegatorTx(
+	vdr *txs.SubnetValidator,
+	assetID ids.ID,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.issue(func() (txs.UnsignedTx, error) {
+		return w.Builder().NewAddPermissionlessDelegatorTx(vdr, assetID, rewardsOwner, options...)
+	}, options)
+}
@@ -0,0 +1,213 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/keychain"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
+)
+
+var (
+	ErrUnknownKeychain = errors.New("unknown keychain")
+	ErrKeychainExists  = errors.New("keychain already added")
+	ErrNoEndpoint      = errors.New("network has no API endpoint")
+)
+
+// ManagerOp configures a Manager
+type ManagerOp struct {
+	walletOptions []WalletOption
+	maxAge        time.Duration
+}
+
+// ManagerOption configures NewManager
+type ManagerOption func(*ManagerOp)
+
+// WithWalletOptions creates the wallets of the manager with opts
+func WithWalletOptions(opts ...WalletOption) ManagerOption {
+	return func(op *ManagerOp) {
+		op.walletOptions = append(op.walletOptions, opts...)
+	}
+}
+
+// WithMaxWalletAge recreates the wallets of the manager requested more than maxAge after
+// their creation, refetching their UTXOs
+func WithMaxWalletAge(maxAge time.Duration) ManagerOption {
+	return func(op *ManagerOp) {
+		op.maxAge = maxAge
+	}
+}
+
+// sessionKey identifies the wallet of a keychain on a network
+type sessionKey struct {
+	networkKind odyssey.NetworkKind
+	networkID   uint32
+	endpoint    string
+	keychain    string
+}
+
+// session holds the wallet of a keychain on a network, created on first use
+type session struct {
+	lock      sync.Mutex
+	wallet    *Wallet
+	txIDs     set.Set[ids.ID]
+	createdAt time.Time
+	stale     bool
+}
+
+// Manager maintains the wallets of several keychains on several networks, e.g. mainnet,
+// testnet and devnets, for services working on all of them. Each keychain has a single wallet
+// per network, created on its first use and shared by its callers, so that the UTXOs and
+// O-Chain txs of the keychain are fetched once, and its txs reserve different UTXOs. The API
+// clients of the wallets share the default HTTP client, pooling the connections to each
+// endpoint. A Manager is safe for concurrent use
+type Manager struct {
+	op        ManagerOp
+	lock      sync.Mutex
+	keychains map[string]*keychain.Keychain
+	sessions  map[sessionKey]*session
+
+	// newWallet creates the wallets, New outside of tests
+	newWallet func(context.Context, *primary.WalletConfig, ...WalletOption) (Wallet, error)
+}
+
+// NewManager returns a manager without keychains
+func NewManager(opts ...ManagerOption) *Manager {
+	op := ManagerOp{}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	return &Manager{
+		op:        op,
+		keychains: map[string]*keychain.Keychain{},
+		sessions:  map[sessionKey]*session{},
+		newWallet: New,
+	}
+}
+
+// AddKeychain adds kc to the manager as name, to get its wallets with Wallet
+func (m *Manager) AddKeychain(name string, kc *keychain.Keychain) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.keychains[name]; ok {
+		return fmt.Errorf("%w: %s", ErrKeychainExists, name)
+	}
+	m.keychains[name] = kc
+	return nil
+}
+
+// RemoveKeychain removes the keychain name and its wallets from the manager
+func (m *Manager) RemoveKeychain(name string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.keychains, name)
+	for key := range m.sessions {
+		if key.keychain == name {
+			delete(m.sessions, key)
+		}
+	}
+}
+
+// Wallet returns the wallet of the keychain name on network, e.g. odyssey.MainnetNetwork(),
+// creating it on first use or when it was refreshed or is older than the max wallet age. The O-Chain
+// txs oChainTxIDs, e.g. the CreateSubnetTxs of the subnets the wallet signs for, are fetched
+// if the wallet does not know them yet, the wallet being recreated to do so.
+//
+// The returned wallets are copies sharing their UTXOs and reservations
+func (m *Manager) Wallet(ctx context.Context, network odyssey.Network, name string, oChainTxIDs ...ids.ID) (Wallet, error) {
+	if network.Endpoint == "" {
+		return Wallet{}, fmt.Errorf("%w: %s", ErrNoEndpoint, network.Kind)
+	}
+	m.lock.Lock()
+	kc, ok := m.keychains[name]
+	if !ok {
+		m.lock.Unlock()
+		return Wallet{}, fmt.Errorf("%w: %s", ErrUnknownKeychain, name)
+	}
+	key := sessionKey{
+		networkKind: network.Kind,
+		networkID:   network.ID,
+		endpoint:    network.Endpoint,
+		keychain:    name,
+	}
+	s, ok := m.sessions[key]
+	if !ok {
+		s = &session{}
+		m.sessions[key] = s
+	}
+	m.lock.Unlock()
+
+	// the wallets of other sessions are created concurrently
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.wallet != nil && !s.stale && !m.expired(s) && knowsAll(s.txIDs, oChainTxIDs) {
+		return *s.wallet, nil
+	}
+	txIDs := set.Of(oChainTxIDs...)
+	txIDs.Union(s.txIDs)
+	wallet, err := m.newWallet(
+		ctx,
+		&primary.WalletConfig{
+			URI:              network.Endpoint,
+			DIONEKeychain:    kc.Keychain,
+			OChainTxsToFetch: txIDs,
+		},
+		m.op.walletOptions...,
+	)
+	if err != nil {
+		return Wallet{}, fmt.Errorf("failure creating wallet of keychain %s on %s: %w", name, network.Endpoint, err)
+	}
+	wallet.SetNetwork(network)
+	s.wallet = &wallet
+	s.txIDs = txIDs
+	s.createdAt = time.Now()
+	s.stale = false
+	return wallet, nil
+}
+
+// knowsAll tells if all txIDs are in known
+func knowsAll(known set.Set[ids.ID], txIDs []ids.ID) bool {
+	for _, txID := range txIDs {
+		if !known.Contains(txID) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Manager) expired(s *session) bool {
+	return m.op.maxAge > 0 && time.Since(s.createdAt) > m.op.maxAge
+}
+
+// Refresh makes the next call to Wallet recreate the wallets of the keychain name, on all
+// networks, e.g. once its UTXOs were spent by another process
+func (m *Manager) Refresh(name string) {
+	m.lock.Lock()
+	sessions := []*session{}
+	for key, s := range m.sessions {
+		if key.keychain == name {
+			sessions = append(sessions, s)
+		}
+	}
+	m.lock.Unlock()
+	for _, s := range sessions {
+		s.lock.Lock()
+		s.stale = true
+		s.lock.Unlock()
+	}
+}
+
+// Close drops the wallets of the manager, which creates new wallets if used again
+func (m *Manager) Close() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.sessions = map[sessionKey]*session{}
+}
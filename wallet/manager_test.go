@@ -0,0 +1,164 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/keychain"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWallets replaces the wallet creation of m, recording the config of each created wallet
+type fakeWallets struct {
+	lock    sync.Mutex
+	configs []primary.WalletConfig
+	err     error
+}
+
+func newFakeWallets(m *Manager) *fakeWallets {
+	fake := &fakeWallets{}
+	m.newWallet = func(_ context.Context, config *primary.WalletConfig, _ ...WalletOption) (Wallet, error) {
+		fake.lock.Lock()
+		defer fake.lock.Unlock()
+		if fake.err != nil {
+			return Wallet{}, fake.err
+		}
+		fake.configs = append(fake.configs, *config)
+		return Wallet{
+			Keychain: keychain.NewKeychainFromExisting(config.DIONEKeychain, odyssey.UndefinedNetwork),
+			config:   config,
+		}, nil
+	}
+	return fake
+}
+
+func (f *fakeWallets) created() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return len(f.configs)
+}
+
+func newManagerTestKeychain() *keychain.Keychain {
+	kc := keychain.NewKeychainFromExisting(secp256k1fx.NewKeychain(), odyssey.UndefinedNetwork)
+	return &kc
+}
+
+func TestManager_Wallet(t *testing.T) {
+	m := NewManager()
+	fake := newFakeWallets(m)
+	require.NoError(t, m.AddKeychain("deployer", newManagerTestKeychain()))
+	require.NoError(t, m.AddKeychain("ops", newManagerTestKeychain()))
+	require.ErrorIs(t, m.AddKeychain("ops", newManagerTestKeychain()), ErrKeychainExists)
+	devnet := odyssey.NewNetwork(odyssey.Devnet, 1337, "http://10.0.0.1:9650")
+
+	_, err := m.Wallet(context.Background(), odyssey.TestnetNetwork(), "unknown")
+	require.ErrorIs(t, err, ErrUnknownKeychain)
+	_, err = m.Wallet(context.Background(), odyssey.UndefinedNetwork, "deployer")
+	require.ErrorIs(t, err, ErrNoEndpoint)
+
+	// each keychain has a wallet per network, routed to its endpoint
+	w, err := m.Wallet(context.Background(), odyssey.TestnetNetwork(), "deployer")
+	require.NoError(t, err)
+	assert.Equal(t, odyssey.TestnetAPIEndpoint, w.URI())
+	assert.Equal(t, odyssey.TestnetNetwork(), w.Network())
+	_, err = m.Wallet(context.Background(), odyssey.TestnetNetwork(), "deployer")
+	require.NoError(t, err)
+	assert.Equal(t, 1, fake.created())
+
+	w, err = m.Wallet(context.Background(), devnet, "deployer")
+	require.NoError(t, err)
+	assert.Equal(t, devnet, w.Network())
+	_, err = m.Wallet(context.Background(), odyssey.MainnetNetwork(), "ops")
+	require.NoError(t, err)
+	assert.Equal(t, 3, fake.created())
+
+	// removed keychains lose their wallets
+	m.RemoveKeychain("ops")
+	_, err = m.Wallet(context.Background(), odyssey.MainnetNetwork(), "ops")
+	require.ErrorIs(t, err, ErrUnknownKeychain)
+
+	fake.err = errors.New("connection refused")
+	_, err = m.Wallet(context.Background(), odyssey.MainnetNetwork(), "deployer")
+	require.ErrorIs(t, err, fake.err)
+}
+
+func TestManager_Refresh(t *testing.T) {
+	m := NewManager()
+	fake := newFakeWallets(m)
+	require.NoError(t, m.AddKeychain("deployer", newManagerTestKeychain()))
+	testnet := odyssey.TestnetNetwork()
+	subnetTx, otherSubnetTx := ids.GenerateTestID(), ids.GenerateTestID()
+
+	// the O-Chain txs are fetched lazily, keeping the ones already fetched
+	_, err := m.Wallet(context.Background(), testnet, "deployer", subnetTx)
+	require.NoError(t, err)
+	_, err = m.Wallet(context.Background(), testnet, "deployer")
+	require.NoError(t, err)
+	_, err = m.Wallet(context.Background(), testnet, "deployer", subnetTx)
+	require.NoError(t, err)
+	require.Equal(t, 1, fake.created())
+	_, err = m.Wallet(context.Background(), testnet, "deployer", otherSubnetTx)
+	require.NoError(t, err)
+	require.Equal(t, 2, fake.created())
+	assert.Equal(t, set.Of(subnetTx, otherSubnetTx), fake.configs[1].OChainTxsToFetch)
+
+	m.Refresh("deployer")
+	_, err = m.Wallet(context.Background(), testnet, "deployer")
+	require.NoError(t, err)
+	require.Equal(t, 3, fake.created())
+	assert.Equal(t, set.Of(subnetTx, otherSubnetTx), fake.configs[2].OChainTxsToFetch)
+
+	m.Close()
+	_, err = m.Wallet(context.Background(), testnet, "deployer")
+	require.NoError(t, err)
+	require.Equal(t, 4, fake.created())
+	assert.Empty(t, fake.configs[3].OChainTxsToFetch)
+}
+
+func TestManager_MaxWalletAge(t *testing.T) {
+	m := NewManager(WithMaxWalletAge(10 * time.Millisecond))
+	fake := newFakeWallets(m)
+	require.NoError(t, m.AddKeychain("deployer", newManagerTestKeychain()))
+
+	_, err := m.Wallet(context.Background(), odyssey.TestnetNetwork(), "deployer")
+	require.NoError(t, err)
+	_, err = m.Wallet(context.Background(), odyssey.TestnetNetwork(), "deployer")
+	require.NoError(t, err)
+	require.Equal(t, 1, fake.created())
+
+	time.Sleep(20 * time.Millisecond)
+	_, err = m.Wallet(context.Background(), odyssey.TestnetNetwork(), "deployer")
+	require.NoError(t, err)
+	require.Equal(t, 2, fake.created())
+}
+
+func TestManager_ConcurrentWallets(t *testing.T) {
+	m := NewManager()
+	fake := newFakeWallets(m)
+	require.NoError(t, m.AddKeychain("deployer", newManagerTestKeychain()))
+	networks := []odyssey.Network{odyssey.TestnetNetwork(), odyssey.MainnetNetwork()}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(network odyssey.Network) {
+			defer wg.Done()
+			_, err := m.Wallet(context.Background(), network, "deployer")
+			assert.NoError(t, err)
+		}(networks[i%len(networks)])
+	}
+	wg.Wait()
+	assert.Equal(t, 2, fake.created())
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package wallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary/common"
+)
+
+var ErrMemoTooLarge = errors.New("memo is too large")
+
+// ValidateMemo checks memo fits in the memo field of a tx
+func ValidateMemo(memo []byte) error {
+	if len(memo) > dione.MaxMemoSize {
+		return fmt.Errorf("%w: %d bytes, max is %d", ErrMemoTooLarge, len(memo), dione.MaxMemoSize)
+	}
+	return nil
+}
+
+// MemoOptions returns the options setting memo on a tx built by O().Builder() or issued
+// by O(), none if memo is empty
+func MemoOptions(memo []byte) ([]common.Option, error) {
+	if err := ValidateMemo(memo); err != nil {
+		return nil, err
+	}
+	if len(memo) == 0 {
+		return nil, nil
+	}
+	return []common.Option{common.WithMemo(memo)}, nil
+}
+
+// SetMemo sets the memo of the txs built and issued by the wallet from now on, e.g. the ID
+// of the ticket of an operation, to reconcile its txs later. Transfers get it when issued
+// with O().IssueBaseTx, as by FundBatch. Memos set on a tx, as with MemoOptions, take
+// precedence. An empty memo clears it
+func (w *Wallet) SetMemo(memo []byte) error {
+	if err := ValidateMemo(memo); err != nil {
+		return err
+	}
+	if w.Wallet == nil {
+		return ErrOfflineWalletNoNetwork
+	}
+	w.memo = memo
+	w.options = append(w.options, common.WithMemo(memo))
+	w.Wallet = primary.NewWalletWithOptions(w.Wallet, w.options...)
+	return nil
+}
+
+// Memo returns the memo set by SetMemo
+func (w *Wallet) Memo() []byte {
+	return w.memo
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/chain/o"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMemoTestWallet returns a wallet building O-Chain txs from a UTXO of addr, without
+// network access
+func newMemoTestWallet(t *testing.T, addr ids.ShortID) Wallet {
+	dioneAssetID := ids.GenerateTestID()
+	utxos := primary.NewChainUTXOs(constants.OmegaChainID, primary.NewUTXOs())
+	require.NoError(t, utxos.AddUTXO(context.Background(), constants.OmegaChainID, &dione.UTXO{
+		UTXOID: dione.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  dione.Asset{ID: dioneAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          1000,
+			OutputOwners: secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr}},
+		},
+	}))
+	backend := o.NewBackend(
+		o.NewContext(constants.TestnetID, dioneAssetID, 0, 0, 0, 0, 0, 0, 0, 0),
+		utxos,
+		map[ids.ID]*txs.Tx{},
+	)
+	builder := o.NewBuilder(set.Of(addr), backend)
+	return Wallet{Wallet: primary.NewWallet(o.NewWallet(builder, nil, nil, backend), nil, nil)}
+}
+
+func TestValidateMemo(t *testing.T) {
+	tests := []struct {
+		name    string
+		memo    []byte
+		wantErr error
+	}{
+		{name: "empty"},
+		{name: "ticket ID", memo: []byte("OPS-1234")},
+		{name: "max size", memo: bytes.Repeat([]byte{'a'}, dione.MaxMemoSize)},
+		{name: "too large", memo: bytes.Repeat([]byte{'a'}, dione.MaxMemoSize+1), wantErr: ErrMemoTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMemo(tt.memo)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				_, err = MemoOptions(tt.memo)
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			options, err := MemoOptions(tt.memo)
+			require.NoError(t, err)
+			if len(tt.memo) == 0 {
+				assert.Empty(t, options)
+			} else {
+				assert.Len(t, options, 1)
+			}
+		})
+	}
+}
+
+func TestWallet_SetMemo(t *testing.T) {
+	addr := ids.GenerateTestShortID()
+	owner := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr}}
+	w := newMemoTestWallet(t, addr)
+
+	// txs are built without memo by default
+	utx, err := w.O().Builder().NewCreateSubnetTx(owner)
+	require.NoError(t, err)
+	assert.Empty(t, utx.Memo)
+
+	require.NoError(t, w.SetMemo([]byte("OPS-1234")))
+	assert.Equal(t, []byte("OPS-1234"), w.Memo())
+	utx, err = w.O().Builder().NewCreateSubnetTx(owner)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("OPS-1234"), []byte(utx.Memo))
+
+	// the memo of a tx takes precedence
+	options, err := MemoOptions([]byte("OPS-5678"))
+	require.NoError(t, err)
+	utx, err = w.O().Builder().NewCreateSubnetTx(owner, options...)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("OPS-5678"), []byte(utx.Memo))
+
+	// too large memos are refused, keeping the previous one
+	assert.ErrorIs(t, w.SetMemo(bytes.Repeat([]byte{'a'}, dione.MaxMemoSize+1)), ErrMemoTooLarge)
+	assert.Equal(t, []byte("OPS-1234"), w.Memo())
+
+	require.NoError(t, w.SetMemo(nil))
+	utx, err = w.O().Builder().NewCreateSubnetTx(owner)
+	require.NoError(t, err)
+	assert.Empty(t, utx.Memo)
+
+	offline := Wallet{}
+	assert.ErrorIs(t, offline.SetMemo([]byte("OPS-1234")), ErrOfflineWalletNoNetwork)
+}
@@ -0,0 +1,364 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	sdkkeychain "github.com/DioneProtocol/odyssey-tooling-sdk-go/keychain"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/multisig"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/database"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/keychain"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+	"github.com/DioneProtocol/odysseygo/utils/formatting"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/chain/o"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
+)
+
+// OfflineTxFileVersion is the version of the files written by OfflineTx.ToFile
+const OfflineTxFileVersion = 1
+
+var (
+	ErrNoOfflineSigner        = errors.New("keychain holds none of the keys signing the tx")
+	ErrInvalidOfflineTxFile   = errors.New("invalid offline tx file")
+	ErrOfflineWalletNoNetwork = errors.New("offline wallet cannot reach the network")
+)
+
+// OfflineTx is an unsigned O-Chain tx with the state a wallet needs to sign it without
+// network access: the UTXOs consumed by its inputs and the txs creating the subnets it
+// needs the auth of.
+//
+// Air-gapped signing goes as follows:
+//   - online, a wallet whose keychain holds the addresses of the signers, e.g. from
+//     NewWatchOnlyKeychain, builds the tx with O().Builder() and exports it with
+//     ExportOfflineTx and OfflineTx.ToFile
+//   - on the air-gapped machine, a wallet from NewOffline signs the tx read by
+//     OfflineTxFromFile with SignOfflineTx, and writes it with multisig.Multisig.ToFile
+//   - online, the signed tx read with multisig.Multisig.FromFile is issued with
+//     multisig.Multisig.Commit
+type OfflineTx struct {
+	// Unsigned is the tx to sign
+	Unsigned txs.UnsignedTx
+
+	// UTXOs are the UTXOs consumed by the inputs of the tx
+	UTXOs []OfflineUTXO
+
+	// SubnetTxs are the txs creating the subnets the tx needs the auth of
+	SubnetTxs []*txs.Tx
+}
+
+// OfflineUTXO is a UTXO consumed by an OfflineTx, SourceChainID being the chain it was
+// produced on, e.g. the source chain of an import
+type OfflineUTXO struct {
+	SourceChainID ids.ID
+	UTXO          *dione.UTXO
+}
+
+// NewWatchOnlyKeychain returns a keychain of addrs holding no key, for an online wallet
+// building the txs signed offline
+func NewWatchOnlyKeychain(addrs ...ids.ShortID) keychain.Keychain {
+	return watchOnlyKeychain{addrs: set.Of(addrs...)}
+}
+
+type watchOnlyKeychain struct {
+	addrs set.Set[ids.ShortID]
+}
+
+func (watchOnlyKeychain) Get(ids.ShortID) (keychain.Signer, bool) {
+	return nil, false
+}
+
+func (kc watchOnlyKeychain) Addresses() set.Set[ids.ShortID] {
+	return kc.addrs
+}
+
+// NewOffline returns a wallet signing OfflineTxs with kc, without network access. Only
+// SignOfflineTx, AppendSignatures and the keychain methods can be used on it
+func NewOffline(kc sdkkeychain.Keychain) Wallet {
+	return Wallet{Keychain: kc}
+}
+
+// ExportOfflineTx fetches the state needed to sign utx offline. utx is built by the
+// wallet, e.g. with O().Builder()
+func (w *Wallet) ExportOfflineTx(ctx context.Context, utx txs.UnsignedTx) (*OfflineTx, error) {
+	if w.Wallet == nil {
+		return nil, ErrOfflineWalletNoNetwork
+	}
+	state, err := primary.FetchState(ctx, w.URI(), w.Keychain.Addresses())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the UTXOs of the wallet: %w", err)
+	}
+	client := odyssey.SharedClientFactory().OChain(w.URI())
+	return exportOfflineTx(ctx, utx, &stateBackend{utxos: state.UTXOs, client: client})
+}
+
+// exportOfflineTx records the UTXOs and txs of backend read when signing utx
+func exportOfflineTx(ctx context.Context, utx txs.UnsignedTx, backend o.SignerBackend) (*OfflineTx, error) {
+	recorder := &recordingBackend{backend: backend, recorded: newOfflineBackend()}
+	// signing with an empty keychain reads the state the signers need without signing
+	if _, err := o.NewSigner(secp256k1fx.NewKeychain(), recorder).SignUnsigned(ctx, utx); err != nil {
+		return nil, err
+	}
+	return &OfflineTx{
+		Unsigned:  utx,
+		UTXOs:     append([]OfflineUTXO{}, recorder.utxos...),
+		SubnetTxs: append([]*txs.Tx{}, recorder.txs...),
+	}, nil
+}
+
+// SignOfflineTx signs offlineTx with the wallet keychain, without network access, once
+// checked against the signing policy of the wallet if any. The tx may need the signatures of
+// other keychains, see multisig.Multisig.IsReadyToCommit
+func (w *Wallet) SignOfflineTx(ctx context.Context, offlineTx *OfflineTx) (*multisig.Multisig, error) {
+	if w.Keychain.Keychain == nil {
+		return nil, ErrNoKeychain
+	}
+	backend := newOfflineBackend()
+	for _, utxo := range offlineTx.UTXOs {
+		backend.addUTXO(utxo)
+	}
+	for _, tx := range offlineTx.SubnetTxs {
+		backend.txs[tx.ID()] = tx
+	}
+	tx := &txs.Tx{Unsigned: offlineTx.Unsigned}
+	cancel, err := w.authorizeSigning(tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.NewSigner(w.Keychain, backend).Sign(ctx, tx); err != nil {
+		cancel()
+		return nil, err
+	}
+	if !hasSignature(tx) {
+		cancel()
+		return nil, ErrNoOfflineSigner
+	}
+	if err := tx.Initialize(txs.Codec); err != nil {
+		return nil, fmt.Errorf("error initializing signed tx: %w", err)
+	}
+	return multisig.New(tx), nil
+}
+
+// hasSignature tells if a credential of tx holds a signature
+func hasSignature(tx *txs.Tx) bool {
+	emptySig := [secp256k1.SignatureLen]byte{}
+	for _, credIntf := range tx.Creds {
+		cred, ok := credIntf.(*secp256k1fx.Credential)
+		if !ok {
+			continue
+		}
+		for _, sig := range cred.Sigs {
+			if sig != emptySig {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// offlineTxFile is the JSON content of an offline tx file, holding the codec bytes of the
+// tx, UTXOs and subnet txs
+type offlineTxFile struct {
+	Version   int               `json:"version"`
+	Unsigned  string            `json:"unsignedTx"`
+	UTXOs     []offlineUTXOFile `json:"utxos"`
+	SubnetTxs []string          `json:"subnetTxs"`
+}
+
+type offlineUTXOFile struct {
+	SourceChainID ids.ID `json:"sourceChainID"`
+	UTXO          string `json:"utxo"`
+}
+
+// ToFile writes offlineTx to path, to be read by OfflineTxFromFile on the signing machine
+func (offlineTx *OfflineTx) ToFile(path string) error {
+	content := offlineTxFile{
+		Version:   OfflineTxFileVersion,
+		UTXOs:     []offlineUTXOFile{},
+		SubnetTxs: []string{},
+	}
+	var err error
+	if content.Unsigned, err = encodeCodecHex(&offlineTx.Unsigned); err != nil {
+		return fmt.Errorf("couldn't encode unsigned tx: %w", err)
+	}
+	for _, utxo := range offlineTx.UTXOs {
+		utxoHex, err := encodeCodecHex(utxo.UTXO)
+		if err != nil {
+			return fmt.Errorf("couldn't encode UTXO %s: %w", utxo.UTXO.InputID(), err)
+		}
+		content.UTXOs = append(content.UTXOs, offlineUTXOFile{SourceChainID: utxo.SourceChainID, UTXO: utxoHex})
+	}
+	for _, tx := range offlineTx.SubnetTxs {
+		txHex, err := formatting.Encode(formatting.Hex, tx.Bytes())
+		if err != nil {
+			return fmt.Errorf("couldn't encode subnet tx %s: %w", tx.ID(), err)
+		}
+		content.SubnetTxs = append(content.SubnetTxs, txHex)
+	}
+	fileBytes, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, fileBytes, 0o600)
+}
+
+// OfflineTxFromFile reads the offline tx written by OfflineTx.ToFile at path
+func OfflineTxFromFile(path string) (*OfflineTx, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var content offlineTxFile
+	if err := json.Unmarshal(fileBytes, &content); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOfflineTxFile, err)
+	}
+	if content.Version != OfflineTxFileVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidOfflineTxFile, content.Version)
+	}
+	offlineTx := &OfflineTx{
+		UTXOs:     []OfflineUTXO{},
+		SubnetTxs: []*txs.Tx{},
+	}
+	if err := decodeCodecHex(content.Unsigned, &offlineTx.Unsigned); err != nil {
+		return nil, fmt.Errorf("%w: unsigned tx: %w", ErrInvalidOfflineTxFile, err)
+	}
+	for _, utxoFile := range content.UTXOs {
+		utxo := &dione.UTXO{}
+		if err := decodeCodecHex(utxoFile.UTXO, utxo); err != nil {
+			return nil, fmt.Errorf("%w: UTXO: %w", ErrInvalidOfflineTxFile, err)
+		}
+		offlineTx.UTXOs = append(offlineTx.UTXOs, OfflineUTXO{SourceChainID: utxoFile.SourceChainID, UTXO: utxo})
+	}
+	for _, txHex := range content.SubnetTxs {
+		txBytes, err := formatting.Decode(formatting.Hex, txHex)
+		if err != nil {
+			return nil, fmt.Errorf("%w: subnet tx: %w", ErrInvalidOfflineTxFile, err)
+		}
+		tx, err := txs.Parse(txs.Codec, txBytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: subnet tx: %w", ErrInvalidOfflineTxFile, err)
+		}
+		offlineTx.SubnetTxs = append(offlineTx.SubnetTxs, tx)
+	}
+	return offlineTx, nil
+}
+
+func encodeCodecHex(value interface{}) (string, error) {
+	valueBytes, err := txs.Codec.Marshal(txs.Version, value)
+	if err != nil {
+		return "", err
+	}
+	return formatting.Encode(formatting.Hex, valueBytes)
+}
+
+func decodeCodecHex(valueHex string, dest interface{}) error {
+	valueBytes, err := formatting.Decode(formatting.Hex, valueHex)
+	if err != nil {
+		return err
+	}
+	_, err = txs.Codec.Unmarshal(valueBytes, dest)
+	return err
+}
+
+// offlineBackend is the o.SignerBackend of the state of an OfflineTx
+type offlineBackend struct {
+	// utxos are indexed by source chain ID and UTXO ID
+	utxos map[ids.ID]map[ids.ID]*dione.UTXO
+	txs   map[ids.ID]*txs.Tx
+}
+
+func newOfflineBackend() *offlineBackend {
+	return &offlineBackend{
+		utxos: map[ids.ID]map[ids.ID]*dione.UTXO{},
+		txs:   map[ids.ID]*txs.Tx{},
+	}
+}
+
+func (b *offlineBackend) addUTXO(utxo OfflineUTXO) {
+	if b.utxos[utxo.SourceChainID] == nil {
+		b.utxos[utxo.SourceChainID] = map[ids.ID]*dione.UTXO{}
+	}
+	b.utxos[utxo.SourceChainID][utxo.UTXO.InputID()] = utxo.UTXO
+}
+
+func (b *offlineBackend) GetUTXO(_ context.Context, chainID, utxoID ids.ID) (*dione.UTXO, error) {
+	utxo, ok := b.utxos[chainID][utxoID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return utxo, nil
+}
+
+func (b *offlineBackend) GetTx(_ context.Context, txID ids.ID) (*txs.Tx, error) {
+	tx, ok := b.txs[txID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return tx, nil
+}
+
+// recordingBackend records the state read from backend into recorded
+type recordingBackend struct {
+	backend  o.SignerBackend
+	recorded *offlineBackend
+	utxos    []OfflineUTXO
+	txs      []*txs.Tx
+}
+
+func (b *recordingBackend) GetUTXO(ctx context.Context, chainID, utxoID ids.ID) (*dione.UTXO, error) {
+	if utxo, err := b.recorded.GetUTXO(ctx, chainID, utxoID); err == nil {
+		return utxo, nil
+	}
+	utxo, err := b.backend.GetUTXO(ctx, chainID, utxoID)
+	if err != nil {
+		return nil, err
+	}
+	offlineUTXO := OfflineUTXO{SourceChainID: chainID, UTXO: utxo}
+	b.recorded.addUTXO(offlineUTXO)
+	b.utxos = append(b.utxos, offlineUTXO)
+	return utxo, nil
+}
+
+func (b *recordingBackend) GetTx(ctx context.Context, txID ids.ID) (*txs.Tx, error) {
+	if tx, err := b.recorded.GetTx(ctx, txID); err == nil {
+		return tx, nil
+	}
+	tx, err := b.backend.GetTx(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+	b.recorded.txs[txID] = tx
+	b.txs = append(b.txs, tx)
+	return tx, nil
+}
+
+// stateBackend is the o.SignerBackend of the UTXOs fetched by primary.FetchState, fetching
+// the txs from the O-Chain API
+type stateBackend struct {
+	utxos  primary.UTXOs
+	client omegavm.Client
+}
+
+func (b *stateBackend) GetUTXO(ctx context.Context, chainID, utxoID ids.ID) (*dione.UTXO, error) {
+	return b.utxos.GetUTXO(ctx, chainID, constants.OmegaChainID, utxoID)
+}
+
+func (b *stateBackend) GetTx(ctx context.Context, txID ids.ID) (*txs.Tx, error) {
+	txBytes, err := b.client.GetTx(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+	return txs.Parse(txs.Codec, txBytes)
+}
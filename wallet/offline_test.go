@@ -0,0 +1,139 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/keychain"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfflineTx_SignRoundTrip(t *testing.T) {
+	factory := secp256k1.Factory{}
+	feeKey, err := factory.NewPrivateKey()
+	require.NoError(t, err)
+	authKey, err := factory.NewPrivateKey()
+	require.NoError(t, err)
+	dioneAssetID := ids.GenerateTestID()
+
+	// the online state: a UTXO of the fee key and a subnet owned by the auth key
+	utxo := &dione.UTXO{
+		UTXOID: dione.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  dione.Asset{ID: dioneAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          1000,
+			OutputOwners: secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{feeKey.Address()}},
+		},
+	}
+	subnetTx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: dione.BaseTx{NetworkID: constants.TestnetID}},
+		Owner:  &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{authKey.Address()}},
+	}}
+	require.NoError(t, subnetTx.Initialize(txs.Codec))
+	online := newOfflineBackend()
+	online.addUTXO(OfflineUTXO{SourceChainID: constants.OmegaChainID, UTXO: utxo})
+	online.txs[subnetTx.ID()] = subnetTx
+
+	utx := &txs.AddSubnetValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: dione.BaseTx{
+			NetworkID:    constants.TestnetID,
+			BlockchainID: constants.OmegaChainID,
+			Ins: []*dione.TransferableInput{{
+				UTXOID: utxo.UTXOID,
+				Asset:  utxo.Asset,
+				In:     &secp256k1fx.TransferInput{Amt: 1000, Input: secp256k1fx.Input{SigIndices: []uint32{0}}},
+			}},
+		}},
+		SubnetValidator: txs.SubnetValidator{Subnet: subnetTx.ID()},
+		SubnetAuth:      &secp256k1fx.Input{SigIndices: []uint32{0}},
+	}
+	offlineTx, err := exportOfflineTx(context.Background(), utx, online)
+	require.NoError(t, err)
+	require.Len(t, offlineTx.UTXOs, 1)
+	require.Len(t, offlineTx.SubnetTxs, 1)
+
+	path := filepath.Join(t.TempDir(), "tx.offline")
+	require.NoError(t, offlineTx.ToFile(path))
+	imported, err := OfflineTxFromFile(path)
+	require.NoError(t, err)
+	var unsigned txs.UnsignedTx = utx
+	expectedBytes, err := txs.Codec.Marshal(txs.Version, &unsigned)
+	require.NoError(t, err)
+	importedBytes, err := txs.Codec.Marshal(txs.Version, &imported.Unsigned)
+	require.NoError(t, err)
+	assert.Equal(t, expectedBytes, importedBytes)
+
+	// the air-gapped machine holds both keys
+	w := NewOffline(keychain.NewKeychainFromExisting(secp256k1fx.NewKeychain(feeKey, authKey), odyssey.TestnetNetwork()))
+	ms, err := w.SignOfflineTx(context.Background(), imported)
+	require.NoError(t, err)
+	tx := ms.OChainTx
+	require.Len(t, tx.Creds, 2)
+	unsignedBytes, err := txs.Codec.Marshal(txs.Version, &tx.Unsigned)
+	require.NoError(t, err)
+	for i, key := range []*secp256k1.PrivateKey{feeKey, authKey} {
+		sig := tx.Creds[i].(*secp256k1fx.Credential).Sigs[0]
+		publicKey, err := factory.RecoverPublicKey(unsignedBytes, sig[:])
+		require.NoError(t, err)
+		assert.Equal(t, key.Address(), publicKey.Address())
+	}
+}
+
+func TestOfflineTx_Errors(t *testing.T) {
+	factory := secp256k1.Factory{}
+	key, err := factory.NewPrivateKey()
+	require.NoError(t, err)
+	utx := &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: dione.BaseTx{
+			NetworkID:    constants.TestnetID,
+			BlockchainID: constants.OmegaChainID,
+			Ins: []*dione.TransferableInput{{
+				UTXOID: dione.UTXOID{TxID: ids.GenerateTestID()},
+				In:     &secp256k1fx.TransferInput{Amt: 1, Input: secp256k1fx.Input{SigIndices: []uint32{0}}},
+			}},
+		}},
+		Owner: &secp256k1fx.OutputOwners{},
+	}
+
+	// the UTXO is unknown, so no key can sign it
+	w := NewOffline(keychain.NewKeychainFromExisting(secp256k1fx.NewKeychain(key), odyssey.TestnetNetwork()))
+	_, err = w.SignOfflineTx(context.Background(), &OfflineTx{Unsigned: utx})
+	require.ErrorIs(t, err, ErrNoOfflineSigner)
+
+	noKeyWallet := NewOffline(keychain.Keychain{})
+	_, err = noKeyWallet.SignOfflineTx(context.Background(), &OfflineTx{Unsigned: utx})
+	require.ErrorIs(t, err, ErrNoKeychain)
+
+	_, err = w.ExportOfflineTx(context.Background(), utx)
+	require.ErrorIs(t, err, ErrOfflineWalletNoNetwork)
+
+	path := filepath.Join(t.TempDir(), "tx.offline")
+	require.NoError(t, (&OfflineTx{Unsigned: utx}).ToFile(path))
+	_, err = OfflineTxFromFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 2}`), 0o600))
+	_, err = OfflineTxFromFile(path)
+	require.ErrorIs(t, err, ErrInvalidOfflineTxFile)
+}
+
+func TestWatchOnlyKeychain(t *testing.T) {
+	addr := ids.GenerateTestShortID()
+	kc := NewWatchOnlyKeychain(addr)
+	addrs := kc.Addresses()
+	assert.True(t, addrs.Contains(addr))
+	_, ok := kc.Get(addr)
+	assert.False(t, ok)
+}
@@ -0,0 +1,334 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/amounts"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+	"github.com/DioneProtocol/odysseygo/utils/hashing"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/stakeable"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/chain/o"
+)
+
+var (
+	// ErrPolicyViolation is matched by the errors of the txs breaking a signing policy, see
+	// PolicyViolationError
+	ErrPolicyViolation = errors.New("signing policy violation")
+
+	ErrTxKindNotAllowed         = errors.New("tx kind not allowed")
+	ErrTxAmountLimitExceeded    = errors.New("tx amount limit exceeded")
+	ErrDailyAmountLimitExceeded = errors.New("daily amount limit exceeded")
+	ErrDestinationNotAllowed    = errors.New("destination not allowed")
+	ErrCoSignersMissing         = errors.New("required co-signers did not sign")
+)
+
+// policyWindow is the rolling window of SigningPolicy.MaxAmountPerDay
+const policyWindow = 24 * time.Hour
+
+// policyNow is the clock of the daily limit of the signing policies. It is replaced in tests
+var policyNow = time.Now
+
+// PolicyViolationError is returned when the wallet refuses to sign a tx breaking its signing
+// policy. It matches ErrPolicyViolation and Rule, e.g. ErrDestinationNotAllowed
+type PolicyViolationError struct {
+	// Kind is the type name of the unsigned tx, e.g. ExportTx
+	Kind string
+
+	// Rule is the broken rule, one of ErrTxKindNotAllowed, ErrTxAmountLimitExceeded,
+	// ErrDailyAmountLimitExceeded, ErrDestinationNotAllowed or ErrCoSignersMissing
+	Rule error
+
+	// Details tells how the tx breaks the rule
+	Details string
+}
+
+func (e *PolicyViolationError) Error() string {
+	return fmt.Sprintf("%s: %s: %s: %s", ErrPolicyViolation, e.Kind, e.Rule, e.Details)
+}
+
+func (e *PolicyViolationError) Unwrap() error {
+	return e.Rule
+}
+
+func (e *PolicyViolationError) Is(target error) bool {
+	return target == ErrPolicyViolation
+}
+
+// SigningPolicy holds the rules checked before the wallet signs an O-Chain tx, its signer
+// refusing the txs breaking them with a PolicyViolationError. The zero value of a rule
+// disables it.
+//
+// The amount of a tx is the sum of its outputs, including the stake and the exported outputs,
+// not owned by the wallet only, fees excluded. The O-Chain outputs are in DIONE, but for the
+// assets of the elastic subnets which are summed up with it
+type SigningPolicy struct {
+	// AllowedTxKinds are the type names of the unsigned txs the wallet may sign, e.g.
+	// ExportTx, AddSubnetValidatorTx or CreateChainTx
+	AllowedTxKinds []string
+
+	// MaxAmountPerTx is the max amount of a tx, in nDIONE
+	MaxAmountPerTx uint64
+
+	// MaxAmountPerDay is the max amount of the txs signed in the last 24 hours, in nDIONE.
+	// The txs are counted once signed, whether or not they are issued later on. The limit is
+	// shared by the wallets of the policy
+	MaxAmountPerDay uint64
+
+	// AllowedDestinations are the addresses, besides those of the wallet, the outputs of the
+	// txs may be owned by
+	AllowedDestinations []ids.ShortID
+
+	// RequiredCoSigners are the addresses that must have signed a tx before the wallet does,
+	// e.g. the approvers of a custodial service adding their signatures with
+	// Wallet.AppendSignatures or Wallet.SignOfflineTx
+	RequiredCoSigners []ids.ShortID
+
+	lock sync.Mutex
+	// spends are the amounts of the txs signed within policyWindow, oldest first
+	spends []*policySpend
+}
+
+type policySpend struct {
+	time   time.Time
+	amount uint64
+}
+
+// WithSigningPolicy makes the wallet check the O-Chain txs against policy before signing them
+func WithSigningPolicy(policy *SigningPolicy) WalletOption {
+	return func(op *WalletOp) {
+		op.policy = policy
+	}
+}
+
+// SetSigningPolicy makes the wallet check the O-Chain txs against policy before signing them,
+// with O().Signer(), the Issue*Tx helpers of O(), AppendSignatures and SignOfflineTx. A nil
+// policy disables the checks
+func (w *Wallet) SetSigningPolicy(policy *SigningPolicy) {
+	w.policy = policy
+}
+
+// SigningPolicy returns the signing policy of the wallet, or nil if it has none
+func (w *Wallet) SigningPolicy() *SigningPolicy {
+	return w.policy
+}
+
+// keychainAddrs returns the addresses of the wallet keychain, if any
+func (w *Wallet) keychainAddrs() set.Set[ids.ShortID] {
+	if w.Keychain.Keychain == nil {
+		return set.Set[ids.ShortID]{}
+	}
+	return w.Keychain.Addresses()
+}
+
+// authorizeSigning checks tx against the signing policy of the wallet, if any, counting it
+// in the daily limit until cancel is called
+func (w *Wallet) authorizeSigning(tx *txs.Tx) (func(), error) {
+	if w.policy == nil {
+		return func() {}, nil
+	}
+	return w.policy.authorize(tx, w.keychainAddrs())
+}
+
+// Check returns a PolicyViolationError if tx breaks the policy when signed by a wallet
+// holding walletAddrs. The tx is not counted in the daily limit
+func (p *SigningPolicy) Check(tx *txs.Tx, walletAddrs set.Set[ids.ShortID]) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	_, err := p.check(tx, walletAddrs)
+	return err
+}
+
+// authorize checks tx and counts its amount in the daily limit, until cancel is called
+func (p *SigningPolicy) authorize(tx *txs.Tx, walletAddrs set.Set[ids.ShortID]) (func(), error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	amount, err := p.check(tx, walletAddrs)
+	if err != nil || p.MaxAmountPerDay == 0 {
+		return func() {}, err
+	}
+	spend := &policySpend{time: policyNow(), amount: amount}
+	p.spends = append(p.spends, spend)
+	return func() {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		p.spends = slices.DeleteFunc(p.spends, func(s *policySpend) bool {
+			return s == spend
+		})
+	}, nil
+}
+
+// check returns the amount of tx, or the violation of the first broken rule
+func (p *SigningPolicy) check(tx *txs.Tx, walletAddrs set.Set[ids.ShortID]) (uint64, error) {
+	kind := txKind(tx)
+	violation := func(rule error, format string, args ...interface{}) error {
+		return &PolicyViolationError{Kind: kind, Rule: rule, Details: fmt.Sprintf(format, args...)}
+	}
+	if len(p.AllowedTxKinds) > 0 && !slices.Contains(p.AllowedTxKinds, kind) {
+		return 0, violation(ErrTxKindNotAllowed, "allowed kinds are %v", p.AllowedTxKinds)
+	}
+
+	allowed := set.Of(p.AllowedDestinations...)
+	amount := uint64(0)
+	for _, out := range txOutputs(tx.Unsigned) {
+		owners := outputOwners(out.Out)
+		if owners != nil && len(owners.Addrs) > 0 && containsAll(walletAddrs, owners.Addrs) {
+			continue
+		}
+		amount += out.Out.Amount()
+		if len(p.AllowedDestinations) == 0 {
+			continue
+		}
+		if owners == nil {
+			return 0, violation(ErrDestinationNotAllowed, "output of unknown type %T", out.Out)
+		}
+		for _, addr := range owners.Addrs {
+			if !allowed.Contains(addr) && !walletAddrs.Contains(addr) {
+				return 0, violation(ErrDestinationNotAllowed, "output owned by %s", addr)
+			}
+		}
+	}
+	if p.MaxAmountPerTx > 0 && amount > p.MaxAmountPerTx {
+		return 0, violation(ErrTxAmountLimitExceeded, "amount %s is above %s", amounts.Amount(amount), amounts.Amount(p.MaxAmountPerTx))
+	}
+	if p.MaxAmountPerDay > 0 {
+		if spent := p.spentToday(); spent+amount > p.MaxAmountPerDay {
+			return 0, violation(ErrDailyAmountLimitExceeded, "amount %s added to the %s signed in the last 24 hours is above %s", amounts.Amount(amount), amounts.Amount(spent), amounts.Amount(p.MaxAmountPerDay))
+		}
+	}
+
+	if len(p.RequiredCoSigners) > 0 {
+		signers, err := txSigners(tx)
+		if err != nil {
+			return 0, err
+		}
+		missing := []ids.ShortID{}
+		for _, coSigner := range p.RequiredCoSigners {
+			if !signers.Contains(coSigner) {
+				missing = append(missing, coSigner)
+			}
+		}
+		if len(missing) > 0 {
+			return 0, violation(ErrCoSignersMissing, "missing signatures of %v", missing)
+		}
+	}
+	return amount, nil
+}
+
+// spentToday returns the amount signed within policyWindow, forgetting the older spends
+func (p *SigningPolicy) spentToday() uint64 {
+	since := policyNow().Add(-policyWindow)
+	p.spends = slices.DeleteFunc(p.spends, func(s *policySpend) bool {
+		return s.time.Before(since)
+	})
+	spent := uint64(0)
+	for _, spend := range p.spends {
+		spent += spend.amount
+	}
+	return spent
+}
+
+func containsAll(addrs set.Set[ids.ShortID], elts []ids.ShortID) bool {
+	for _, elt := range elts {
+		if !addrs.Contains(elt) {
+			return false
+		}
+	}
+	return true
+}
+
+// stakingTx is implemented by the txs locking a stake, e.g. AddValidatorTx
+type stakingTx interface {
+	Stake() []*dione.TransferableOutput
+}
+
+// txOutputs returns the outputs of utx, including its stake and exported outputs
+func txOutputs(utx txs.UnsignedTx) []*dione.TransferableOutput {
+	outs := slices.Clone(utx.Outputs())
+	switch utx := utx.(type) {
+	case *txs.ExportTx:
+		outs = append(outs, utx.ExportedOutputs...)
+	case stakingTx:
+		outs = append(outs, utx.Stake()...)
+	}
+	return outs
+}
+
+// outputOwners returns the owners of out, or nil if its type is unknown
+func outputOwners(out dione.TransferableOut) *secp256k1fx.OutputOwners {
+	switch out := out.(type) {
+	case *secp256k1fx.TransferOutput:
+		return &out.OutputOwners
+	case *stakeable.LockOut:
+		return outputOwners(out.TransferableOut)
+	}
+	return nil
+}
+
+// txSigners returns the addresses whose signatures are in the credentials of tx
+func txSigners(tx *txs.Tx) (set.Set[ids.ShortID], error) {
+	signers := set.Set[ids.ShortID]{}
+	emptySig := [secp256k1.SignatureLen]byte{}
+	var hash []byte
+	factory := secp256k1.Factory{}
+	for _, credIntf := range tx.Creds {
+		cred, ok := credIntf.(*secp256k1fx.Credential)
+		if !ok {
+			continue
+		}
+		for _, sig := range cred.Sigs {
+			if sig == emptySig {
+				continue
+			}
+			if hash == nil {
+				unsignedBytes, err := txs.Codec.Marshal(txs.Version, &tx.Unsigned)
+				if err != nil {
+					return nil, err
+				}
+				hash = hashing.ComputeHash256(unsignedBytes)
+			}
+			publicKey, err := factory.RecoverHashPublicKey(hash, sig[:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid signature in tx: %w", err)
+			}
+			signers.Add(publicKey.Address())
+		}
+	}
+	return signers, nil
+}
+
+// policySigner is an O-Chain signer checking the txs against a signing policy before signing
+// them
+type policySigner struct {
+	o.Signer
+	policy      *SigningPolicy
+	walletAddrs set.Set[ids.ShortID]
+}
+
+func (s *policySigner) SignUnsigned(ctx context.Context, utx txs.UnsignedTx) (*txs.Tx, error) {
+	tx := &txs.Tx{Unsigned: utx}
+	return tx, s.Sign(ctx, tx)
+}
+
+func (s *policySigner) Sign(ctx context.Context, tx *txs.Tx) error {
+	cancel, err := s.policy.authorize(tx, s.walletAddrs)
+	if err != nil {
+		return err
+	}
+	if err := s.Signer.Sign(ctx, tx); err != nil {
+		cancel()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+	"github.com/DioneProtocol/odysseygo/utils/hashing"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/components/verify"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/chain/o"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPolicyTestTx returns a CreateSubnetTx sending amount to each of addrs
+func newPolicyTestTx(amount uint64, addrs ...ids.ShortID) *txs.Tx {
+	utx := &txs.CreateSubnetTx{Owner: &secp256k1fx.OutputOwners{}}
+	for _, addr := range addrs {
+		utx.Outs = append(utx.Outs, &dione.TransferableOutput{
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amount,
+				OutputOwners: secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr}},
+			},
+		})
+	}
+	return &txs.Tx{Unsigned: utx}
+}
+
+// fakeSigner counts the txs it signs, failing with err
+type fakeSigner struct {
+	o.Signer
+	signed int
+	err    error
+}
+
+func (s *fakeSigner) Sign(context.Context, *txs.Tx) error {
+	s.signed++
+	return s.err
+}
+
+func TestSigningPolicy_Check(t *testing.T) {
+	walletAddr, friend, stranger := ids.ShortID{1}, ids.ShortID{2}, ids.ShortID{3}
+	walletAddrs := set.Of(walletAddr)
+	policy := &SigningPolicy{
+		AllowedTxKinds:      []string{"CreateSubnetTx"},
+		MaxAmountPerTx:      100,
+		AllowedDestinations: []ids.ShortID{friend},
+	}
+
+	// the change is not counted
+	require.NoError(t, policy.Check(newPolicyTestTx(100, friend, walletAddr), walletAddrs))
+
+	err := policy.Check(newPolicyTestTx(101, friend), walletAddrs)
+	require.ErrorIs(t, err, ErrPolicyViolation)
+	require.ErrorIs(t, err, ErrTxAmountLimitExceeded)
+	var violation *PolicyViolationError
+	require.ErrorAs(t, err, &violation)
+	assert.Equal(t, "CreateSubnetTx", violation.Kind)
+
+	require.ErrorIs(t, policy.Check(newPolicyTestTx(1, stranger), walletAddrs), ErrDestinationNotAllowed)
+	require.ErrorIs(t, policy.Check(&txs.Tx{Unsigned: &txs.CreateChainTx{}}, walletAddrs), ErrTxKindNotAllowed)
+}
+
+func TestSigningPolicy_DailyLimit(t *testing.T) {
+	now := time.Now()
+	policyNow = func() time.Time { return now }
+	t.Cleanup(func() { policyNow = time.Now })
+	policy := &SigningPolicy{MaxAmountPerDay: 100}
+	signer := &fakeSigner{}
+	// wallets sharing the policy share its limit
+	signers := []o.Signer{
+		&policySigner{Signer: signer, policy: policy},
+		&policySigner{Signer: signer, policy: policy},
+	}
+
+	_, err := signers[0].SignUnsigned(context.Background(), newPolicyTestTx(60, ids.ShortID{2}).Unsigned)
+	require.NoError(t, err)
+	now = now.Add(time.Hour)
+	err = signers[1].Sign(context.Background(), newPolicyTestTx(60, ids.ShortID{2}))
+	require.ErrorIs(t, err, ErrDailyAmountLimitExceeded)
+	assert.Equal(t, 1, signer.signed)
+
+	// the txs failing to be signed are not counted
+	signer.err = errors.New("ledger disconnected")
+	require.ErrorIs(t, signers[1].Sign(context.Background(), newPolicyTestTx(40, ids.ShortID{2})), signer.err)
+	signer.err = nil
+	require.NoError(t, signers[1].Sign(context.Background(), newPolicyTestTx(40, ids.ShortID{2})))
+
+	now = now.Add(policyWindow)
+	require.NoError(t, signers[0].Sign(context.Background(), newPolicyTestTx(60, ids.ShortID{2})))
+	assert.Equal(t, 4, signer.signed)
+}
+
+func TestSigningPolicy_RequiredCoSigners(t *testing.T) {
+	factory := secp256k1.Factory{}
+	approver, err := factory.NewPrivateKey()
+	require.NoError(t, err)
+	policy := &SigningPolicy{RequiredCoSigners: []ids.ShortID{approver.Address()}}
+	tx := newPolicyTestTx(1, ids.ShortID{2})
+	tx.Creds = []verify.Verifiable{&secp256k1fx.Credential{Sigs: make([][secp256k1.SignatureLen]byte, 1)}}
+
+	err = policy.Check(tx, set.Set[ids.ShortID]{})
+	require.ErrorIs(t, err, ErrCoSignersMissing)
+	require.ErrorContains(t, err, approver.Address().String())
+
+	unsignedBytes, err := txs.Codec.Marshal(txs.Version, &tx.Unsigned)
+	require.NoError(t, err)
+	sig, err := approver.SignHash(hashing.ComputeHash256(unsignedBytes))
+	require.NoError(t, err)
+	copy(tx.Creds[0].(*secp256k1fx.Credential).Sigs[0][:], sig)
+	require.NoError(t, policy.Check(tx, set.Set[ids.ShortID]{}))
+}
+
+func TestHistoryOWallet_SigningPolicy(t *testing.T) {
+	signer := &fakeSigner{}
+	w := &historyOWallet{Wallet: &signerOWallet{signer: signer}, policy: &SigningPolicy{MaxAmountPerTx: 10}}
+	_, err := w.Signer().SignUnsigned(context.Background(), newPolicyTestTx(11, ids.ShortID{2}).Unsigned)
+	require.ErrorIs(t, err, ErrTxAmountLimitExceeded)
+	assert.Zero(t, signer.signed)
+
+	w.policy = nil
+	assert.Same(t, signer, w.Signer())
+}
+
+// signerOWallet is an O-Chain wallet signing with signer
+type signerOWallet struct {
+	o.Wallet
+	signer o.Signer
+}
+
+func (w *signerOWallet) Signer() o.Signer {
+	return w.signer
+}
@@ -0,0 +1,361 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package wallet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/signer"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/chain/o"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary/common"
+)
+
+// DefaultUTXOReservationTimeout is how long the UTXOs consumed by a tx built by a wallet stay
+// reserved when the tx is not issued, or its acceptance is not known
+const DefaultUTXOReservationTimeout = 5 * time.Minute
+
+// WithUTXOReservationTimeout sets how long the UTXOs consumed by a tx built by the wallet stay
+// reserved, instead of DefaultUTXOReservationTimeout. See New
+func WithUTXOReservationTimeout(timeout time.Duration) WalletOption {
+	return func(op *WalletOp) {
+		op.reservationTimeout = timeout
+	}
+}
+
+// WithSerializedIssuance makes the O-Chain txs issued by the wallet go one at a time, from
+// building to acceptance. A tx then spends the change of the previous one, instead of
+// failing for lack of unreserved UTXOs when the wallet holds few of them
+func WithSerializedIssuance() WalletOption {
+	return func(op *WalletOp) {
+		op.serializeIssuance = true
+	}
+}
+
+// utxoReservations reserves the O-Chain UTXOs consumed by the txs built by a wallet, so that
+// txs built concurrently from the wallet, or its copies, do not spend the same UTXOs
+type utxoReservations struct {
+	backend o.BuilderBackend
+	addrs   set.Set[ids.ShortID]
+	timeout time.Duration
+
+	// buildLock makes the building of a tx and the reservation of its UTXOs atomic
+	buildLock sync.Mutex
+
+	// issueLock serializes the issuance of the txs if set, see WithSerializedIssuance
+	issueLock *sync.Mutex
+
+	lock sync.Mutex
+	// reserved maps the reserved UTXOs to the end of their reservation
+	reserved map[ids.ID]time.Time
+	now      func() time.Time
+}
+
+func newUTXOReservations(backend o.BuilderBackend, addrs set.Set[ids.ShortID], op WalletOp) *utxoReservations {
+	r := &utxoReservations{
+		backend:  backend,
+		addrs:    addrs,
+		timeout:  op.reservationTimeout,
+		reserved: map[ids.ID]time.Time{},
+		now:      time.Now,
+	}
+	if r.timeout <= 0 {
+		r.timeout = DefaultUTXOReservationTimeout
+	}
+	if op.serializeIssuance {
+		r.issueLock = &sync.Mutex{}
+	}
+	return r
+}
+
+func (r *utxoReservations) reserve(utxoIDs set.Set[ids.ID]) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	end := r.now().Add(r.timeout)
+	for utxoID := range utxoIDs {
+		r.reserved[utxoID] = end
+	}
+}
+
+// release is a no-op on a nil r, as are the other methods used by the O-Chain wallet
+func (r *utxoReservations) release(utxoIDs set.Set[ids.ID]) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for utxoID := range utxoIDs {
+		delete(r.reserved, utxoID)
+	}
+}
+
+func (r *utxoReservations) isReserved(utxoID ids.ID) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	end, ok := r.reserved[utxoID]
+	if ok && !r.now().Before(end) {
+		delete(r.reserved, utxoID)
+		return false
+	}
+	return ok
+}
+
+// list returns the UTXOs currently reserved
+func (r *utxoReservations) list() []ids.ID {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	utxoIDs := []ids.ID{}
+	for utxoID, end := range r.reserved {
+		if r.now().Before(end) {
+			utxoIDs = append(utxoIDs, utxoID)
+		}
+	}
+	return utxoIDs
+}
+
+// serialize waits for the previous tx to be issued if issuance is serialized, and returns the
+// function ending the issuance of the tx
+func (r *utxoReservations) serialize() func() {
+	if r == nil || r.issueLock == nil {
+		return func() {}
+	}
+	r.issueLock.Lock()
+	return r.issueLock.Unlock
+}
+
+// builder returns a builder spending the unreserved UTXOs only, of feePayer if not empty,
+// reserving those of the txs it builds
+func (r *utxoReservations) builder(options []common.Option, feePayer ids.ShortID) o.Builder {
+	var backend o.BuilderBackend = &unreservedUTXOsBackend{BuilderBackend: r.backend, reservations: r}
+	if feePayer != ids.ShortEmpty {
+		backend = &feePayerBackend{BuilderBackend: backend, feePayer: set.Of(feePayer)}
+		options = feePayerOptions(options, feePayer)
+	}
+	builder := o.NewBuilder(r.addrs, backend)
+	return &reservingBuilder{Builder: o.NewBuilderWithOptions(builder, options...), reservations: r}
+}
+
+// ReservedUTXOs returns the O-Chain UTXOs reserved by the txs built by the wallet, which the
+// txs it builds do not spend until the txs are accepted or their reservation times out
+func (w *Wallet) ReservedUTXOs() []ids.ID {
+	if w.reservations == nil {
+		return nil
+	}
+	return w.reservations.list()
+}
+
+// ReleaseUTXOs releases the UTXOs reserved by utx, e.g. when a tx built with O().Builder()
+// is abandoned before being issued
+func (w *Wallet) ReleaseUTXOs(utx txs.UnsignedTx) {
+	w.reservations.release(utx.InputIDs())
+}
+
+// unreservedUTXOsBackend hides the reserved UTXOs from the builder it backs
+type unreservedUTXOsBackend struct {
+	o.BuilderBackend
+	reservations *utxoReservations
+}
+
+func (b *unreservedUTXOsBackend) UTXOs(ctx context.Context, sourceChainID ids.ID) ([]*dione.UTXO, error) {
+	utxos, err := b.BuilderBackend.UTXOs(ctx, sourceChainID)
+	if err != nil {
+		return nil, err
+	}
+	unreserved := make([]*dione.UTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		if !b.reservations.isReserved(utxo.InputID()) {
+			unreserved = append(unreserved, utxo)
+		}
+	}
+	return unreserved, nil
+}
+
+// reservingBuilder reserves the UTXOs consumed by the txs it builds
+type reservingBuilder struct {
+	o.Builder
+	reservations *utxoReservations
+}
+
+// buildAndReserve builds a tx with newTx and reserves its UTXOs, with no other tx built in
+// between
+func buildAndReserve[T txs.UnsignedTx](b *reservingBuilder, newTx func() (T, error)) (T, error) {
+	b.reservations.buildLock.Lock()
+	defer b.reservations.buildLock.Unlock()
+	utx, err := newTx()
+	if err == nil {
+		b.reservations.reserve(utx.InputIDs())
+	}
+	return utx, err
+}
+
+func (b *reservingBuilder) NewBaseTx(
+	outputs []*dione.TransferableOutput,
+	options ...common.Option,
+) (*txs.CreateSubnetTx, error) {
+	return buildAndReserve(b, func() (*txs.CreateSubnetTx, error) {
+		return b.Builder.NewBaseTx(outputs, options...)
+	})
+}
+
+func (b *reservingBuilder) NewAddValidatorTx(
+	vdr *txs.Validator,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	shares uint32,
+	options ...common.Option,
+) (*txs.AddValidatorTx, error) {
+	return buildAndReserve(b, func() (*txs.AddValidatorTx, error) {
+		return b.Builder.NewAddValidatorTx(vdr, rewardsOwner, shares, options...)
+	})
+}
+
+func (b *reservingBuilder) NewAddSubnetValidatorTx(
+	vdr *txs.SubnetValidator,
+	options ...common.Option,
+) (*txs.AddSubnetValidatorTx, error) {
+	return buildAndReserve(b, func() (*txs.AddSubnetValidatorTx, error) {
+		return b.Builder.NewAddSubnetValidatorTx(vdr, options...)
+	})
+}
+
+func (b *reservingBuilder) NewRemoveSubnetValidatorTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	options ...common.Option,
+) (*txs.RemoveSubnetValidatorTx, error) {
+	return buildAndReserve(b, func() (*txs.RemoveSubnetValidatorTx, error) {
+		return b.Builder.NewRemoveSubnetValidatorTx(nodeID, subnetID, options...)
+	})
+}
+
+func (b *reservingBuilder) NewAddDelegatorTx(
+	vdr *txs.Validator,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.AddDelegatorTx, error) {
+	return buildAndReserve(b, func() (*txs.AddDelegatorTx, error) {
+		return b.Builder.NewAddDelegatorTx(vdr, rewardsOwner, options...)
+	})
+}
+
+func (b *reservingBuilder) NewCreateChainTx(
+	subnetID ids.ID,
+	genesis []byte,
+	vmID ids.ID,
+	fxIDs []ids.ID,
+	chainName string,
+	options ...common.Option,
+) (*txs.CreateChainTx, error) {
+	return buildAndReserve(b, func() (*txs.CreateChainTx, error) {
+		return b.Builder.NewCreateChainTx(subnetID, genesis, vmID, fxIDs, chainName, options...)
+	})
+}
+
+func (b *reservingBuilder) NewCreateSubnetTx(
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.CreateSubnetTx, error) {
+	return buildAndReserve(b, func() (*txs.CreateSubnetTx, error) {
+		return b.Builder.NewCreateSubnetTx(owner, options...)
+	})
+}
+
+func (b *reservingBuilder) NewImportTx(
+	chainID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.ImportTx, error) {
+	return buildAndReserve(b, func() (*txs.ImportTx, error) {
+		return b.Builder.NewImportTx(chainID, to, options...)
+	})
+}
+
+func (b *reservingBuilder) NewExportTx(
+	chainID ids.ID,
+	outputs []*dione.TransferableOutput,
+	options ...common.Option,
+) (*txs.ExportTx, error) {
+	return buildAndReserve(b, func() (*txs.ExportTx, error) {
+		return b.Builder.NewExportTx(chainID, outputs, options...)
+	})
+}
+
+func (b *reservingBuilder) NewTransformSubnetTx(
+	subnetID ids.ID,
+	assetID ids.ID,
+	initialSupply uint64,
+	maxSupply uint64,
+	minConsumptionRate uint64,
+	maxConsumptionRate uint64,
+	minValidatorStake uint64,
+	maxValidatorStake uint64,
+	minValidatorStakeDuration time.Duration,
+	maxValidatorStakeDuration time.Duration,
+	minDelegatorStakeDuration time.Duration,
+	maxDelegatorStakeDuration time.Duration,
+	minDelegationFee uint32,
+	minDelegatorStake uint64,
+	maxValidatorWeightFactor byte,
+	uptimeRequirement uint32,
+	options ...common.Option,
+) (*txs.TransformSubnetTx, error) {
+	return buildAndReserve(b, func() (*txs.TransformSubnetTx, error) {
+		return b.Builder.NewTransformSubnetTx(
+			subnetID,
+			assetID,
+			initialSupply,
+			maxSupply,
+			minConsumptionRate,
+			maxConsumptionRate,
+			minValidatorStake,
+			maxValidatorStake,
+			minValidatorStakeDuration,
+			maxValidatorStakeDuration,
+			minDelegatorStakeDuration,
+			maxDelegatorStakeDuration,
+			minDelegationFee,
+			minDelegatorStake,
+			maxValidatorWeightFactor,
+			uptimeRequirement,
+			options...,
+		)
+	})
+}
+
+func (b *reservingBuilder) NewAddPermissionlessValidatorTx(
+	vdr *txs.SubnetValidator,
+	signer signer.Signer,
+	assetID ids.ID,
+	validationRewardsOwner *secp256k1fx.OutputOwners,
+	delegationRewardsOwner *secp256k1fx.OutputOwners,
+	shares uint32,
+	options ...common.Option,
+) (*txs.AddPermissionlessValidatorTx, error) {
+	return buildAndReserve(b, func() (*txs.AddPermissionlessValidatorTx, error) {
+		return b.Builder.NewAddPermissionlessValidatorTx(
+			vdr,
+			signer,
+			assetID,
+			validationRewardsOwner,
+			delegationRewardsOwner,
+			shares,
+			options...,
+		)
+	})
+}
+
+func (b *reservingBuilder) NewAddPermissionlessDelegatorTx(
+	vdr *txs.SubnetValidator,
+	assetID ids.ID,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.AddPermissionlessDelegatorTx, error) {
+	return buildAndReserve(b, func() (*txs.AddPermissionlessDelegatorTx, error) {
+		return b.Builder.NewAddPermissionlessDelegatorTx(vdr, assetID, rewardsOwner, options...)
+	})
+}
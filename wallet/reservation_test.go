@@ -0,0 +1,184 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/chain/o"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newReservationTestWallet returns a wallet reserving the UTXOs of the txs it builds from
+// count UTXOs of addr, each of them paying the fee of one CreateSubnetTx, without network
+// access
+func newReservationTestWallet(t *testing.T, addr ids.ShortID, count int, opts ...WalletOption) Wallet {
+	dioneAssetID := ids.GenerateTestID()
+	utxos := primary.NewChainUTXOs(constants.OmegaChainID, primary.NewUTXOs())
+	for i := 0; i < count; i++ {
+		require.NoError(t, utxos.AddUTXO(context.Background(), constants.OmegaChainID, &dione.UTXO{
+			UTXOID: dione.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  dione.Asset{ID: dioneAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          1000,
+				OutputOwners: secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr}},
+			},
+		}))
+	}
+	backend := o.NewBackend(
+		o.NewContext(constants.TestnetID, dioneAssetID, 0, 600, 0, 0, 0, 0, 0, 0),
+		utxos,
+		map[ids.ID]*txs.Tx{},
+	)
+	op := WalletOp{}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	builder := o.NewBuilder(set.Of(addr), backend)
+	return Wallet{
+		Wallet:       primary.NewWallet(o.NewWallet(builder, nil, nil, backend), nil, nil),
+		reservations: newUTXOReservations(backend, set.Of(addr), op),
+	}
+}
+
+func TestWallet_UTXOReservations(t *testing.T) {
+	addr := ids.GenerateTestShortID()
+	w := newReservationTestWallet(t, addr, 2, WithUTXOReservationTimeout(time.Minute))
+	now := time.Now()
+	w.reservations.now = func() time.Time { return now }
+	owner := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr}}
+
+	// concurrent txs spend different UTXOs
+	built := make([]*txs.CreateSubnetTx, 2)
+	errs := make([]error, 2)
+	wg := sync.WaitGroup{}
+	for i := range built {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			built[i], errs[i] = w.O().Builder().NewCreateSubnetTx(owner)
+		}(i)
+	}
+	wg.Wait()
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+	inputs := built[0].InputIDs()
+	require.False(t, inputs.Overlaps(built[1].InputIDs()))
+	assert.Len(t, w.ReservedUTXOs(), 2)
+
+	// a copy of the wallet shares the reservations
+	walletCopy := w
+	_, err := walletCopy.O().Builder().NewCreateSubnetTx(owner)
+	require.Error(t, err)
+
+	// an abandoned tx releases its UTXOs
+	w.ReleaseUTXOs(built[0])
+	utx, err := w.O().Builder().NewCreateSubnetTx(owner)
+	require.NoError(t, err)
+	assert.Equal(t, built[0].InputIDs(), utx.InputIDs())
+
+	// the reservations time out
+	now = now.Add(time.Minute)
+	assert.Empty(t, w.ReservedUTXOs())
+	_, err = w.O().Builder().NewCreateSubnetTx(owner)
+	require.NoError(t, err)
+}
+
+// reservationOWallet issues txs calling the post issuance function if issued, then failing
+// with issueErr
+type reservationOWallet struct {
+	o.Wallet
+	issued   bool
+	issueErr error
+
+	lock     sync.Mutex
+	inFlight int
+	overlaps bool
+}
+
+func (w *reservationOWallet) IssueTx(tx *txs.Tx, options ...common.Option) error {
+	w.lock.Lock()
+	w.inFlight++
+	w.overlaps = w.overlaps || w.inFlight > 1
+	w.lock.Unlock()
+	time.Sleep(time.Millisecond)
+	if f := common.NewOptions(options).PostIssuanceFunc(); f != nil && w.issued {
+		f(tx.ID())
+	}
+	w.lock.Lock()
+	w.inFlight--
+	w.lock.Unlock()
+	return w.issueErr
+}
+
+func TestHistoryOWallet_IssueTx_ReleasesUTXOs(t *testing.T) {
+	errIssue := errors.New("issue failed")
+	tests := []struct {
+		name         string
+		issued       bool
+		issueErr     error
+		stayReserved bool
+	}{
+		{name: "accepted", issued: true},
+		{name: "not issued", issueErr: errIssue},
+		{name: "acceptance unknown", issued: true, issueErr: errIssue, stayReserved: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := ids.GenerateTestShortID()
+			w := newReservationTestWallet(t, addr, 1)
+			utx, err := w.O().Builder().NewCreateSubnetTx(&secp256k1fx.OutputOwners{Threshold: 1})
+			require.NoError(t, err)
+			require.Len(t, w.ReservedUTXOs(), 1)
+
+			oWallet := &historyOWallet{
+				Wallet:       &reservationOWallet{issued: tt.issued, issueErr: tt.issueErr},
+				reservations: w.reservations,
+			}
+			tx := &txs.Tx{Unsigned: utx}
+			require.NoError(t, tx.Initialize(txs.Codec))
+			require.ErrorIs(t, oWallet.IssueTx(tx), tt.issueErr)
+			assert.Equal(t, tt.stayReserved, len(w.ReservedUTXOs()) == 1)
+		})
+	}
+}
+
+func TestHistoryOWallet_SerializedIssuance(t *testing.T) {
+	for _, serialized := range []bool{false, true} {
+		opts := []WalletOption{}
+		if serialized {
+			opts = append(opts, WithSerializedIssuance())
+		}
+		w := newReservationTestWallet(t, ids.GenerateTestShortID(), 1, opts...)
+		inner := &reservationOWallet{issued: true}
+		oWallet := &historyOWallet{Wallet: inner, reservations: w.reservations}
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				tx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{Owner: &secp256k1fx.OutputOwners{Threshold: 1}}}
+				require.NoError(t, tx.Initialize(txs.Codec))
+				require.NoError(t, oWallet.IssueTx(tx))
+			}()
+		}
+		wg.Wait()
+		if serialized {
+			assert.False(t, inner.overlaps)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package wallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/multisig"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+	"github.com/DioneProtocol/odysseygo/utils/hashing"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+)
+
+var ErrNoKeychain = errors.New("wallet has no keychain")
+
+// SignatureReport describes the subnet auth signatures of a tx after AppendSignatures
+type SignatureReport struct {
+	// Signed are the auth signers whose signatures were added by the wallet
+	Signed []ids.ShortID
+	// AlreadySigned are the auth signers whose signatures were already in the tx, and were kept
+	AlreadySigned []ids.ShortID
+	// Remaining are the auth signers that still have to sign the tx
+	Remaining []ids.ShortID
+}
+
+// Changed tells if signatures were added to the tx
+func (r SignatureReport) Changed() bool {
+	return len(r.Signed) > 0
+}
+
+// Complete tells if all the auth signers signed the tx
+func (r SignatureReport) Complete() bool {
+	return len(r.Remaining) == 0
+}
+
+// AppendSignatures adds to the partially signed tx of ms the subnet auth signatures of the
+// remaining signers held by the wallet keychain. Only the empty signature slots are filled,
+// so the signatures of the other participants are never overwritten. The tx is checked
+// against the signing policy of the wallet first, if any
+func (w *Wallet) AppendSignatures(ms *multisig.Multisig) (SignatureReport, error) {
+	if w.Keychain.Keychain == nil {
+		return SignatureReport{}, ErrNoKeychain
+	}
+	authSigners, _, err := ms.GetRemainingAuthSigners()
+	if err != nil {
+		return SignatureReport{}, err
+	}
+	tx := ms.OChainTx
+	cancel, err := w.authorizeSigning(tx)
+	if err != nil {
+		return SignatureReport{}, err
+	}
+	report, err := w.appendSignatures(tx, authSigners)
+	if err != nil || !report.Changed() {
+		cancel()
+	}
+	return report, err
+}
+
+func (w *Wallet) appendSignatures(tx *txs.Tx, authSigners []ids.ShortID) (SignatureReport, error) {
+	// GetRemainingAuthSigners checked that the last cred is the subnet auth one
+	cred := tx.Creds[len(tx.Creds)-1].(*secp256k1fx.Credential)
+	unsignedHash := hashing.ComputeHash256(tx.Unsigned.Bytes())
+	emptySig := [secp256k1.SignatureLen]byte{}
+	// signatures are applied once all of them are computed, leaving the tx untouched on error
+	sigs := map[int][]byte{}
+	report := SignatureReport{
+		Signed:        []ids.ShortID{},
+		AlreadySigned: []ids.ShortID{},
+		Remaining:     []ids.ShortID{},
+	}
+	for i, authSigner := range authSigners {
+		if cred.Sigs[i] != emptySig {
+			report.AlreadySigned = append(report.AlreadySigned, authSigner)
+			continue
+		}
+		signer, ok := w.Keychain.Get(authSigner)
+		if !ok {
+			report.Remaining = append(report.Remaining, authSigner)
+			continue
+		}
+		sig, err := signer.SignHash(unsignedHash)
+		if err != nil {
+			return SignatureReport{}, fmt.Errorf("failed to sign tx %s for %s: %w", tx.ID(), authSigner, err)
+		}
+		if len(sig) != secp256k1.SignatureLen {
+			return SignatureReport{}, fmt.Errorf("unexpected signature length %d for %s", len(sig), authSigner)
+		}
+		sigs[i] = sig
+		report.Signed = append(report.Signed, authSigner)
+	}
+	for i, sig := range sigs {
+		copy(cred.Sigs[i][:], sig)
+	}
+	if report.Changed() {
+		// the tx ID covers the credentials
+		if err := tx.Initialize(txs.Codec); err != nil {
+			return SignatureReport{}, fmt.Errorf("error initializing signed tx: %w", err)
+		}
+	}
+	return report, nil
+}
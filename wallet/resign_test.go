@@ -0,0 +1,81 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/keychain"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/multisig"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+	"github.com/DioneProtocol/odysseygo/vms/components/verify"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/txs"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendSignatures(t *testing.T) {
+	factory := secp256k1.Factory{}
+	keys := make([]*secp256k1.PrivateKey, 3)
+	controlKeys := make([]ids.ShortID, 3)
+	for i := range keys {
+		key, err := factory.NewPrivateKey()
+		require.NoError(t, err)
+		keys[i] = key
+		controlKeys[i] = key.Address()
+	}
+	otherSig := [secp256k1.SignatureLen]byte{1, 2, 3}
+	tx := &txs.Tx{
+		Unsigned: &txs.AddSubnetValidatorTx{
+			SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{0, 1, 2}},
+		},
+		Creds: []verify.Verifiable{
+			&secp256k1fx.Credential{Sigs: [][secp256k1.SignatureLen]byte{{9}}},
+			// the second control key already signed
+			&secp256k1fx.Credential{Sigs: make([][secp256k1.SignatureLen]byte, 3)},
+		},
+	}
+	tx.Creds[1].(*secp256k1fx.Credential).Sigs[1] = otherSig
+	require.NoError(t, tx.Initialize(txs.Codec))
+	ms := multisig.New(tx)
+	ms.SetSubnetOwners(controlKeys, 3)
+
+	// the wallet holds the first two control keys
+	w := Wallet{
+		Keychain: keychain.NewKeychainFromExisting(secp256k1fx.NewKeychain(keys[0], keys[1]), odyssey.TestnetNetwork()),
+	}
+	txID := tx.ID()
+	report, err := w.AppendSignatures(ms)
+	require.NoError(t, err)
+	assert.Equal(t, []ids.ShortID{controlKeys[0]}, report.Signed)
+	assert.Equal(t, []ids.ShortID{controlKeys[1]}, report.AlreadySigned)
+	assert.Equal(t, []ids.ShortID{controlKeys[2]}, report.Remaining)
+	assert.True(t, report.Changed())
+	assert.False(t, report.Complete())
+	assert.NotEqual(t, txID, tx.ID())
+
+	sigs := tx.Creds[1].(*secp256k1fx.Credential).Sigs
+	assert.Equal(t, otherSig, sigs[1])
+	assert.Equal(t, [secp256k1.SignatureLen]byte{}, sigs[2])
+	assert.True(t, keys[0].PublicKey().Verify(tx.Unsigned.Bytes(), sigs[0][:]))
+
+	_, remaining, err := ms.GetRemainingAuthSigners()
+	require.NoError(t, err)
+	assert.Equal(t, []ids.ShortID{controlKeys[2]}, remaining)
+
+	// signing again changes nothing
+	report, err = w.AppendSignatures(ms)
+	require.NoError(t, err)
+	assert.False(t, report.Changed())
+	assert.Equal(t, []ids.ShortID{controlKeys[0], controlKeys[1]}, report.AlreadySigned)
+}
+
+func TestAppendSignatures_NoKeychain(t *testing.T) {
+	w := Wallet{}
+	_, err := w.AppendSignatures(multisig.New(nil))
+	require.ErrorIs(t, err, ErrNoKeychain)
+}
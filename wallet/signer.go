@@ -0,0 +1,122 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/keychain"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+	"github.com/DioneProtocol/odysseygo/utils/hashing"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+)
+
+var ErrInvalidExternalSignature = errors.New("invalid external signature")
+
+// SignFunc returns the signature of hash by the key of addr, e.g. by calling a remote signing
+// service, an MPC provider or an HSM. The signature must be a 65 bytes recoverable secp256k1
+// signature [r || s || v], v being 0 or 1
+type SignFunc func(addr ids.ShortID, hash []byte) ([]byte, error)
+
+// WalletOp holds the options of New
+type WalletOp struct {
+	signFunc           SignFunc
+	signerAddrs        []ids.ShortID
+	reservationTimeout time.Duration
+	serializeIssuance  bool
+	skipEndpointCheck  bool
+	policy             *SigningPolicy
+	feePayer           ids.ShortID
+}
+
+// WalletOption configures New
+type WalletOption func(*WalletOp)
+
+// WithExternalSigner makes the wallet sign for addrs with sign, besides the keys of the
+// config keychain. The config keychain can be nil when all the keys are external
+func WithExternalSigner(sign SignFunc, addrs ...ids.ShortID) WalletOption {
+	return func(op *WalletOp) {
+		op.signFunc = sign
+		op.signerAddrs = addrs
+	}
+}
+
+// NewExternalKeychain returns a keychain for addrs delegating signatures to sign
+func NewExternalKeychain(sign SignFunc, addrs ...ids.ShortID) keychain.Keychain {
+	return &externalKeychain{
+		addrs: set.Of(addrs...),
+		sign:  sign,
+	}
+}
+
+type externalKeychain struct {
+	addrs set.Set[ids.ShortID]
+	sign  SignFunc
+}
+
+func (kc *externalKeychain) Get(addr ids.ShortID) (keychain.Signer, bool) {
+	if !kc.addrs.Contains(addr) {
+		return nil, false
+	}
+	return &externalSigner{addr: addr, sign: kc.sign}, true
+}
+
+func (kc *externalKeychain) Addresses() set.Set[ids.ShortID] {
+	return kc.addrs
+}
+
+type externalSigner struct {
+	addr ids.ShortID
+	sign SignFunc
+}
+
+func (s *externalSigner) Address() ids.ShortID {
+	return s.addr
+}
+
+func (s *externalSigner) Sign(msg []byte) ([]byte, error) {
+	return s.SignHash(hashing.ComputeHash256(msg))
+}
+
+// SignHash calls the sign function, checking that the signature was made by the key of the
+// signer address so that a misconfigured signer fails before the tx is issued
+func (s *externalSigner) SignHash(hash []byte) ([]byte, error) {
+	sig, err := s.sign(s.addr, hash)
+	if err != nil {
+		return nil, fmt.Errorf("external signer failed to sign for %s: %w", s.addr, err)
+	}
+	if len(sig) != secp256k1.SignatureLen {
+		return nil, fmt.Errorf("%w for %s: expected %d bytes, got %d", ErrInvalidExternalSignature, s.addr, secp256k1.SignatureLen, len(sig))
+	}
+	publicKey, err := (&secp256k1.Factory{}).RecoverHashPublicKey(hash, sig)
+	if err != nil {
+		return nil, fmt.Errorf("%w for %s: %w", ErrInvalidExternalSignature, s.addr, err)
+	}
+	if signer := publicKey.Address(); signer != s.addr {
+		return nil, fmt.Errorf("%w for %s: signed by %s", ErrInvalidExternalSignature, s.addr, signer)
+	}
+	return sig, nil
+}
+
+// multiKeychain is the union of keychains, the first keychain holding an address signing for it
+type multiKeychain []keychain.Keychain
+
+func (kcs multiKeychain) Get(addr ids.ShortID) (keychain.Signer, bool) {
+	for _, kc := range kcs {
+		if signer, ok := kc.Get(addr); ok {
+			return signer, true
+		}
+	}
+	return nil, false
+}
+
+func (kcs multiKeychain) Addresses() set.Set[ids.ShortID] {
+	addrs := set.Set[ids.ShortID]{}
+	for _, kc := range kcs {
+		addrs.Union(kc.Addresses())
+	}
+	return addrs
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/secp256k1"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalKeychain(t *testing.T) {
+	factory := secp256k1.Factory{}
+	remoteKey, err := factory.NewPrivateKey()
+	require.NoError(t, err)
+	otherKey, err := factory.NewPrivateKey()
+	require.NoError(t, err)
+
+	signedFor := []ids.ShortID{}
+	kc := NewExternalKeychain(func(addr ids.ShortID, hash []byte) ([]byte, error) {
+		signedFor = append(signedFor, addr)
+		// misconfigured for otherKey, signing with the wrong key
+		return remoteKey.SignHash(hash)
+	}, remoteKey.Address(), otherKey.Address())
+
+	signer, ok := kc.Get(remoteKey.Address())
+	require.True(t, ok)
+	msg := []byte("unsigned tx")
+	sig, err := signer.Sign(msg)
+	require.NoError(t, err)
+	assert.True(t, remoteKey.PublicKey().Verify(msg, sig))
+
+	signer, ok = kc.Get(otherKey.Address())
+	require.True(t, ok)
+	_, err = signer.Sign(msg)
+	require.ErrorIs(t, err, ErrInvalidExternalSignature)
+	assert.Equal(t, []ids.ShortID{remoteKey.Address(), otherKey.Address()}, signedFor)
+
+	_, ok = kc.Get(ids.GenerateTestShortID())
+	assert.False(t, ok)
+}
+
+func TestExternalKeychain_SignerErrors(t *testing.T) {
+	errUnavailable := errors.New("HSM unavailable")
+	addr := ids.GenerateTestShortID()
+	signer, ok := NewExternalKeychain(func(ids.ShortID, []byte) ([]byte, error) {
+		return nil, errUnavailable
+	}, addr).Get(addr)
+	require.True(t, ok)
+	_, err := signer.SignHash(make([]byte, 32))
+	require.ErrorIs(t, err, errUnavailable)
+
+	signer, ok = NewExternalKeychain(func(ids.ShortID, []byte) ([]byte, error) {
+		return []byte{1, 2, 3}, nil
+	}, addr).Get(addr)
+	require.True(t, ok)
+	_, err = signer.SignHash(make([]byte, 32))
+	require.ErrorIs(t, err, ErrInvalidExternalSignature)
+}
+
+func TestWithExternalSigner(t *testing.T) {
+	localKey, err := (&secp256k1.Factory{}).NewPrivateKey()
+	require.NoError(t, err)
+	externalAddr := ids.GenerateTestShortID()
+	op := WalletOp{}
+	WithExternalSigner(func(ids.ShortID, []byte) ([]byte, error) { return nil, nil }, externalAddr)(&op)
+
+	config := withExternalSigner(&primary.WalletConfig{DIONEKeychain: secp256k1fx.NewKeychain(localKey)}, op)
+	addrs := config.DIONEKeychain.Addresses()
+	assert.True(t, addrs.Contains(localKey.Address()))
+	assert.True(t, addrs.Contains(externalAddr))
+	require.NotNil(t, config.EthKeychain)
+	_, ok := config.DIONEKeychain.Get(localKey.Address())
+	assert.True(t, ok)
+
+	config = withExternalSigner(&primary.WalletConfig{}, op)
+	assert.Equal(t, 1, config.DIONEKeychain.Addresses().Len())
+}
+
+func TestWithEthKeychain(t *testing.T) {
+	localKey, err := (&secp256k1.Factory{}).NewPrivateKey()
+	require.NoError(t, err)
+
+	// an empty EthKeychain is populated from the DIONE keys
+	config := withEthKeychain(&primary.WalletConfig{
+		DIONEKeychain: secp256k1fx.NewKeychain(localKey),
+		EthKeychain:   secp256k1fx.NewKeychain(),
+	})
+	assert.Equal(t, 1, config.EthKeychain.EthAddresses().Len())
+
+	// an EthKeychain with keys is kept
+	otherKey, err := (&secp256k1.Factory{}).NewPrivateKey()
+	require.NoError(t, err)
+	ethKeychain := secp256k1fx.NewKeychain(otherKey)
+	config = withEthKeychain(&primary.WalletConfig{
+		DIONEKeychain: secp256k1fx.NewKeychain(localKey),
+		EthKeychain:   ethKeychain,
+	})
+	assert.Same(t, ethKeychain, config.EthKeychain)
+
+	config = withEthKeychain(&primary.WalletConfig{DIONEKeychain: NewWatchOnlyKeychain(localKey.Address())})
+	assert.Equal(t, 0, config.EthKeychain.EthAddresses().Len())
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/addressbook"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/amounts"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/vms/components/dione"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary/common"
+)
+
+var (
+	ErrEmptyTransferDestination = errors.New("transfer destination cannot be empty")
+	ErrInvalidTransferAmount    = errors.New("amount to transfer must be positive")
+)
+
+// Transfer sends amount on the O-Chain from the wallet keys to the address to, and waits
+// for the tx to be accepted. Amounts are parsed from human strings with amounts.Parse, e.g.
+// "2.5 DIONE". Returns the ID of the tx
+func (w *Wallet) Transfer(ctx context.Context, to ids.ShortID, amount amounts.Amount) (ids.ID, error) {
+	switch {
+	case to == ids.ShortEmpty:
+		return ids.Empty, ErrEmptyTransferDestination
+	case amount == 0:
+		return ids.Empty, ErrInvalidTransferAmount
+	case w.Wallet == nil:
+		return ids.Empty, ErrOfflineWalletNoNetwork
+	}
+	oWallet := w.O()
+	output := &dione.TransferableOutput{
+		Asset: dione.Asset{ID: oWallet.DIONEAssetID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: uint64(amount),
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{to},
+			},
+		},
+	}
+	tx, err := oWallet.IssueBaseTx([]*dione.TransferableOutput{output}, common.WithContext(ctx))
+	if err != nil {
+		return ids.Empty, fmt.Errorf("failed to transfer %s to %s: %w", amount, to, err)
+	}
+	return tx.ID(), nil
+}
+
+// TransferTo is Transfer to to, a label of book or an O-Chain address. book may be nil to
+// only accept addresses
+func (w *Wallet) TransferTo(ctx context.Context, book *addressbook.AddressBook, to string, amount amounts.Amount) (ids.ID, error) {
+	toID, err := book.ResolveShortID(to)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.Transfer(ctx, toID, amount)
+}
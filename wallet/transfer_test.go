@@ -0,0 +1,83 @@
+// Copyright (C) 2025, Dione Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/addressbook"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/amounts"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odysseytest"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/formatting/address"
+	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWallet_Transfer(t *testing.T) {
+	srv := odysseytest.NewServer()
+	defer srv.Close()
+	kc, err := odysseytest.NewKeychain(1)
+	require.NoError(t, err)
+	srv.AddUTXOs(odysseytest.NewUTXO(srv.DIONEAssetID(), uint64(5*amounts.DIONE), odysseytest.Owners(1, kc.Addresses().List()[0])))
+	w, err := New(context.Background(), &primary.WalletConfig{URI: srv.URI(), DIONEKeychain: kc})
+	require.NoError(t, err)
+	to := ids.GenerateTestShortID()
+
+	_, err = w.Transfer(context.Background(), ids.ShortEmpty, amounts.DIONE)
+	require.ErrorIs(t, err, ErrEmptyTransferDestination)
+	_, err = w.Transfer(context.Background(), to, 0)
+	require.ErrorIs(t, err, ErrInvalidTransferAmount)
+
+	txID, err := w.Transfer(context.Background(), to, amounts.MustParse("2.5 DIONE"))
+	require.NoError(t, err)
+	require.Len(t, srv.IssuedTxs(), 1)
+	assert.Equal(t, txID, srv.IssuedTxs()[0].ID())
+	received := uint64(0)
+	for _, utxo := range srv.UTXOs() {
+		out := utxo.Out.(*secp256k1fx.TransferOutput)
+		if out.Addrs[0] == to {
+			received += out.Amt
+		}
+	}
+	assert.Equal(t, uint64(2_500_000_000), received)
+
+	_, err = w.Transfer(context.Background(), to, 10*amounts.DIONE)
+	require.ErrorContains(t, err, "failed to transfer 10 DIONE")
+}
+
+func TestWallet_TransferTo(t *testing.T) {
+	srv := odysseytest.NewServer()
+	defer srv.Close()
+	kc, err := odysseytest.NewKeychain(1)
+	require.NoError(t, err)
+	srv.AddUTXOs(odysseytest.NewUTXO(srv.DIONEAssetID(), uint64(5*amounts.DIONE), odysseytest.Owners(1, kc.Addresses().List()[0])))
+	w, err := New(context.Background(), &primary.WalletConfig{URI: srv.URI(), DIONEKeychain: kc})
+	require.NoError(t, err)
+	to := ids.GenerateTestShortID()
+	toAddr, err := address.Format("O", "testnet", to[:])
+	require.NoError(t, err)
+	book := addressbook.New()
+	require.NoError(t, book.Add(addressbook.Entry{Label: "treasury", OChainAddress: toAddr}))
+
+	_, err = w.TransferTo(context.Background(), book, "unknown", amounts.DIONE)
+	require.ErrorContains(t, err, "neither a known label nor a valid address")
+	require.Empty(t, srv.IssuedTxs())
+
+	_, err = w.TransferTo(context.Background(), book, "treasury", amounts.DIONE)
+	require.NoError(t, err)
+	_, err = w.TransferTo(context.Background(), nil, toAddr, amounts.DIONE)
+	require.NoError(t, err)
+	received := uint64(0)
+	for _, utxo := range srv.UTXOs() {
+		out := utxo.Out.(*secp256k1fx.TransferOutput)
+		if out.Addrs[0] == to {
+			received += out.Amt
+		}
+	}
+	assert.Equal(t, uint64(2*amounts.DIONE), received)
+}
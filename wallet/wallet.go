@@ -5,29 +5,83 @@ package wallet
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	sdkconfig "github.com/DioneProtocol/odyssey-tooling-sdk-go/config"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/keychain"
+	"github.com/DioneProtocol/odyssey-tooling-sdk-go/multisig"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
 	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
 	"github.com/DioneProtocol/odysseygo/utils/set"
 	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
+	"github.com/DioneProtocol/odysseygo/wallet/chain/o"
 	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
 	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary/common"
 )
 
-var ErrNotReadyToCommit = errors.New("tx is not fully signed so can't be committed")
+// ErrNotReadyToCommit is returned when committing a tx that does not have enough signatures yet
+var ErrNotReadyToCommit = multisig.ErrThresholdNotMet
 
 type Wallet struct {
 	primary.Wallet
 	Keychain keychain.Keychain
 	options  []common.Option
 	config   *primary.WalletConfig
+	history  TxHistoryStore
+	memo     []byte
+
+	// reservations of the UTXOs of the txs built by O(), shared by the copies of the wallet
+	reservations *utxoReservations
+
+	// policy checked before signing the O-Chain txs, see SetSigningPolicy
+	policy *SigningPolicy
+
+	// address whose UTXOs pay the O-Chain txs, if not empty, see SetFeePayer
+	feePayer ids.ShortID
+}
+
+// WithoutEndpointCheck makes New skip the check that the endpoint is ready, e.g. for nodes
+// whose info API is disabled
+func WithoutEndpointCheck() WalletOption {
+	return func(op *WalletOp) {
+		op.skipEndpointCheck = true
+	}
 }
 
-func New(ctx context.Context, config *primary.WalletConfig) (Wallet, error) {
+// New creates a wallet from config. If config.URI is empty, the endpoint of the default
+// network of the SDK config file is used.
+//
+// The O-Chain UTXOs consumed by a tx built with O(), or its Builder, are reserved until the
+// tx is accepted, fails to be issued or its reservation times out, see
+// WithUTXOReservationTimeout. The txs built concurrently from the wallet and its copies thus
+// spend different UTXOs.
+//
+// The endpoint is checked to be ready first, see odyssey.Network.IsReady, so that New fails
+// fast with an error matching odyssey.ErrEndpointUnreachable or odyssey.ErrEndpointUnhealthy
+// instead of the errors of the odysseygo clients. See WithoutEndpointCheck
+func New(ctx context.Context, config *primary.WalletConfig, opts ...WalletOption) (Wallet, error) {
 	if config == nil {
 		return Wallet{}, errors.New("wallet config cannot be nil")
 	}
+	if config.URI == "" {
+		configWithURI := *config
+		configWithURI.URI = sdkconfig.Get().Endpoint("")
+		config = &configWithURI
+	}
+	config = withEthKeychain(config)
+	op := WalletOp{}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	if op.signFunc != nil {
+		config = withExternalSigner(config, op)
+	}
+	if !op.skipEndpointCheck {
+		if err := (odyssey.Network{Endpoint: config.URI}).IsReady(ctx); err != nil {
+			return Wallet{}, fmt.Errorf("cannot create wallet: %w", err)
+		}
+	}
 
 	wallet, err := primary.MakeWallet(
 		ctx,
@@ -39,13 +93,79 @@ func New(ctx context.Context, config *primary.WalletConfig) (Wallet, error) {
 
 	// Determine network from URI and create keychain with proper network
 	network := odyssey.NetworkFromURI(config.URI)
+	if network.Kind == odyssey.Undefined {
+		network = networkFromID(wallet.O().NetworkID(), config.URI)
+	}
 	kc := keychain.NewKeychainFromExisting(config.DIONEKeychain, network)
 
-	return Wallet{
+	w := Wallet{
 		Wallet:   wallet,
 		Keychain: kc,
 		config:   config,
-	}, nil
+		policy:   op.policy,
+	}
+	// the wallet built by primary.MakeWallet is its own backend
+	if backend, ok := wallet.O().(o.BuilderBackend); ok {
+		w.reservations = newUTXOReservations(backend, config.DIONEKeychain.Addresses(), op)
+	}
+	if op.feePayer != ids.ShortEmpty {
+		if err := w.SetFeePayer(op.feePayer); err != nil {
+			return Wallet{}, err
+		}
+	}
+	return w, nil
+}
+
+// withEthKeychain returns a copy of config signing the D-Chain EVM inputs with the keys of
+// its DIONE keychain, unless config has an EthKeychain holding keys
+func withEthKeychain(config *primary.WalletConfig) *primary.WalletConfig {
+	if config.EthKeychain != nil && config.EthKeychain.EthAddresses().Len() > 0 {
+		return config
+	}
+	configWithEthKeychain := *config
+	dioneKeychain := keychain.NewKeychainFromExisting(config.DIONEKeychain, odyssey.Network{})
+	configWithEthKeychain.EthKeychain = dioneKeychain.EthKeychain()
+	return &configWithEthKeychain
+}
+
+// withExternalSigner returns a copy of config whose keychain also signs for the external
+// signer addresses of op
+func withExternalSigner(config *primary.WalletConfig, op WalletOp) *primary.WalletConfig {
+	configWithSigner := *config
+	external := NewExternalKeychain(op.signFunc, op.signerAddrs...)
+	if config.DIONEKeychain == nil {
+		configWithSigner.DIONEKeychain = external
+	} else {
+		configWithSigner.DIONEKeychain = multiKeychain{config.DIONEKeychain, external}
+	}
+	if config.EthKeychain == nil {
+		// external signers do not sign D-Chain EVM inputs
+		configWithSigner.EthKeychain = secp256k1fx.NewKeychain()
+	}
+	return &configWithSigner
+}
+
+// networkFromID returns the network of the node at uri given its network ID. Custom networks
+// use the HRP the node formats its addresses with
+func networkFromID(networkID uint32, uri string) odyssey.Network {
+	network := odyssey.NetworkFromNetworkID(networkID)
+	if network.Kind != odyssey.Undefined {
+		return network
+	}
+	network = odyssey.NewNetwork(odyssey.Devnet, networkID, uri)
+	network.CustomHRP = constants.GetHRP(networkID)
+	return network
+}
+
+// Network returns the network the wallet addresses are formatted for
+func (w *Wallet) Network() odyssey.Network {
+	return w.Keychain.Network()
+}
+
+// SetNetwork overrides the network the wallet addresses are formatted for, e.g. to use
+// the HRP of a private network
+func (w *Wallet) SetNetwork(network odyssey.Network) {
+	w.Keychain.SetNetwork(network)
 }
 
 // SecureWalletIsChangeOwner ensures that a fee paying address (wallet's keychain) will receive
@@ -77,11 +197,21 @@ func (w *Wallet) SetAuthKeys(authKeys []ids.ShortID) {
 	w.Wallet = primary.NewWalletWithOptions(w.Wallet, w.options...)
 }
 
+// SetSubnetAuthMultisig makes the wallet sign subnet transactions with authKeys, as computed
+// by Keychain.SubnetAuth
 func (w *Wallet) SetSubnetAuthMultisig(authKeys []ids.ShortID) {
 	w.SecureWalletIsChangeOwner()
 	w.SetAuthKeys(authKeys)
 }
 
+// URI returns the API endpoint the wallet was created for
+func (w *Wallet) URI() string {
+	if w.config == nil {
+		return ""
+	}
+	return w.config.URI
+}
+
 func (w *Wallet) Addresses() []ids.ShortID {
 	return w.Keychain.Addresses().List()
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/keychain"
 	"github.com/DioneProtocol/odyssey-tooling-sdk-go/odyssey"
 	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/constants"
 	"github.com/DioneProtocol/odysseygo/utils/set"
 	"github.com/DioneProtocol/odysseygo/vms/secp256k1fx"
 	"github.com/DioneProtocol/odysseygo/wallet/subnet/primary"
@@ -303,7 +304,7 @@ func TestWalletSecureChangeOwner(t *testing.T) {
 
 func TestWalletSecureChangeOwnerEmptyAddresses(t *testing.T) {
 	// Test that SecureWalletIsChangeOwner doesn't panic when wallet has addresses
-	// The network automatically uses local node when LOCAL_NODE=true is set
+	// The network uses the local node when LOCAL_NODE=true is set, see local_node_test.go
 	// Note: We cannot test empty addresses scenario directly as wallet creation requires addresses,
 	// but the fix ensures the function checks len(addrs) == 0 before accessing addrs[0]
 
@@ -1627,3 +1628,35 @@ func TestWalletWithNetworkAndKeychainFromPrivateKey(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkFromID(t *testing.T) {
+	uri := "http://10.0.0.1:9650"
+	require.Equal(t, odyssey.MainnetNetwork(), networkFromID(constants.MainnetID, uri))
+
+	local := networkFromID(constants.LocalID, uri)
+	require.Equal(t, odyssey.Devnet, local.Kind)
+	require.Equal(t, uri, local.Endpoint)
+	require.Equal(t, constants.LocalHRP, local.HRP())
+
+	custom := networkFromID(1337, uri)
+	require.Equal(t, uint32(1337), custom.ID)
+	require.Equal(t, constants.FallbackHRP, custom.HRP())
+}
+
+func TestWalletSetNetwork(t *testing.T) {
+	k, err := key.NewSoft()
+	require.NoError(t, err)
+	w := Wallet{Keychain: keychain.NewKeychainFromExisting(k.KeyChain(), odyssey.UndefinedNetwork)}
+	network, err := odyssey.NewCustomNetwork(1337, "http://10.0.0.1:9650", "private")
+	require.NoError(t, err)
+	w.SetNetwork(network)
+	require.Equal(t, network, w.Network())
+
+	addrs, err := w.Keychain.O()
+	require.NoError(t, err)
+	require.Len(t, addrs, 1)
+	require.True(t, strings.HasPrefix(addrs[0], "O-private1"))
+	addrs, err = w.Keychain.A()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(addrs[0], "A-private1"))
+}